@@ -17,19 +17,40 @@ type ApplyManifest struct {
 	domainRepo  CFDomainRepository
 	processRepo CFProcessRepository
 	routeRepo   CFRouteRepository
+	sidecarRepo CFSidecarRepository
 }
 
-func NewApplyManifest(appRepo CFAppRepository, domainRepo CFDomainRepository, processRepo CFProcessRepository, routeRepo CFRouteRepository) *ApplyManifest {
+func NewApplyManifest(appRepo CFAppRepository, domainRepo CFDomainRepository, processRepo CFProcessRepository, routeRepo CFRouteRepository, sidecarRepo CFSidecarRepository) *ApplyManifest {
 	return &ApplyManifest{
 		appRepo:     appRepo,
 		domainRepo:  domainRepo,
 		processRepo: processRepo,
 		routeRepo:   routeRepo,
+		sidecarRepo: sidecarRepo,
 	}
 }
 
+// Invoke applies each application of the manifest in turn. A failing application does not stop the others from
+// being applied, its error is reported against the application name instead.
 func (a *ApplyManifest) Invoke(ctx context.Context, authInfo authorization.Info, spaceGUID string, defaultDomainName string, manifest payloads.Manifest) error {
-	appInfo := manifest.Applications[0]
+	var appErrors JobErrors
+	for _, appInfo := range manifest.Applications {
+		if err := a.applyApp(ctx, authInfo, spaceGUID, defaultDomainName, appInfo); err != nil {
+			appErrors = append(appErrors, newManifestApplicationError(appInfo.Name, err))
+		}
+	}
+
+	switch len(appErrors) {
+	case 0:
+		return nil
+	case 1:
+		return appErrors[0]
+	default:
+		return appErrors
+	}
+}
+
+func (a *ApplyManifest) applyApp(ctx context.Context, authInfo authorization.Info, spaceGUID string, defaultDomainName string, appInfo payloads.ManifestApplication) error {
 	exists := true
 	appRecord, err := a.appRepo.GetAppByNameAndSpace(ctx, authInfo, appInfo.Name, spaceGUID)
 	if err != nil {
@@ -50,6 +71,11 @@ func (a *ApplyManifest) Invoke(ctx context.Context, authInfo authorization.Info,
 		return err
 	}
 
+	err = a.createOrUpdateSidecars(ctx, authInfo, appRecord, appInfo.Sidecars)
+	if err != nil {
+		return err
+	}
+
 	err = a.checkAndUpdateDefaultRoute(ctx, authInfo, appRecord, defaultDomainName, &appInfo)
 	if err != nil {
 		return err
@@ -144,6 +170,42 @@ func (a *ApplyManifest) createApp(ctx context.Context, authInfo authorization.In
 	return appRecord, nil
 }
 
+// createOrUpdateSidecars matches the sidecars of the manifest to those of the app by name. Sidecars of the app that
+// are not in the manifest are left alone.
+func (a *ApplyManifest) createOrUpdateSidecars(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord, sidecars []payloads.ManifestApplicationSidecar) error {
+	if len(sidecars) == 0 {
+		return nil
+	}
+
+	existingSidecars, err := a.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUID:   appRecord.GUID,
+		SpaceGUID: appRecord.SpaceGUID,
+	})
+	if err != nil {
+		return err
+	}
+
+	sidecarGUIDsByName := map[string]string{}
+	for _, sidecar := range existingSidecars {
+		sidecarGUIDsByName[sidecar.Name] = sidecar.GUID
+	}
+
+	for _, sidecarInfo := range sidecars {
+		if sidecarGUID, exists := sidecarGUIDsByName[sidecarInfo.Name]; exists {
+			_, err = a.sidecarRepo.PatchSidecar(ctx, authInfo, sidecarInfo.ToSidecarPatchMessage(sidecarGUID, appRecord.SpaceGUID))
+		} else {
+			message := sidecarInfo.ToSidecarCreateMessage(appRecord.GUID, appRecord.SpaceGUID)
+			message.AppEtcdUID = appRecord.EtcdUID
+			_, err = a.sidecarRepo.CreateSidecar(ctx, authInfo, message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *ApplyManifest) createOrUpdateRoutes(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord, routes []payloads.ManifestRoute) error {
 	if len(routes) == 0 {
 		return nil
@@ -202,3 +264,35 @@ func splitRoute(route string) (string, string, string) {
 	}
 	return hostName, domain, path
 }
+
+// manifestApplicationError identifies the application of the manifest that an error occurred for
+type manifestApplicationError struct {
+	apierrors.ApiError
+	appName string
+	err     error
+}
+
+func newManifestApplicationError(appName string, err error) manifestApplicationError {
+	var apiError apierrors.ApiError
+	if !errors.As(err, &apiError) {
+		apiError = apierrors.NewUnknownError(err)
+	}
+
+	return manifestApplicationError{
+		ApiError: apiError,
+		appName:  appName,
+		err:      err,
+	}
+}
+
+func (e manifestApplicationError) Error() string {
+	return e.err.Error()
+}
+
+func (e manifestApplicationError) Unwrap() error {
+	return e.err
+}
+
+func (e manifestApplicationError) Detail() string {
+	return fmt.Sprintf("For application '%s': %s", e.appName, e.ApiError.Detail())
+}
@@ -16,6 +16,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ApplyManifest", func() {
@@ -34,6 +35,7 @@ var _ = Describe("ApplyManifest", func() {
 		domainRepo  *fake.CFDomainRepository
 		processRepo *fake.CFProcessRepository
 		routeRepo   *fake.CFRouteRepository
+		sidecarRepo *fake.CFSidecarRepository
 		authInfo    authorization.Info
 
 		applyManifestAction *ApplyManifest
@@ -51,6 +53,7 @@ var _ = Describe("ApplyManifest", func() {
 
 		processRepo = new(fake.CFProcessRepository)
 		routeRepo = new(fake.CFRouteRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		authInfo = authorization.Info{Token: "a-token"}
 		manifest = payloads.Manifest{
 			Version: 1,
@@ -65,7 +68,7 @@ var _ = Describe("ApplyManifest", func() {
 			},
 		}
 
-		applyManifestAction = NewApplyManifest(appRepo, domainRepo, processRepo, routeRepo)
+		applyManifestAction = NewApplyManifest(appRepo, domainRepo, processRepo, routeRepo, sidecarRepo)
 	})
 
 	JustBeforeEach(func() {
@@ -109,6 +112,84 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 
+	When("the manifest contains several apps", func() {
+		BeforeEach(func() {
+			manifest.Applications = append(manifest.Applications,
+				payloads.ManifestApplication{Name: "other-app"},
+				payloads.ManifestApplication{Name: "third-app"},
+			)
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			appRepo.CreateAppStub = func(_ context.Context, _ authorization.Info, message repositories.CreateAppMessage) (repositories.AppRecord, error) {
+				return repositories.AppRecord{GUID: message.Name + "-guid", SpaceGUID: message.SpaceGUID}, nil
+			}
+		})
+
+		It("applies each app in turn", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(appRepo.CreateAppCallCount()).To(Equal(3))
+
+			var appNames []string
+			for i := 0; i < appRepo.CreateAppCallCount(); i++ {
+				_, _, appMessage := appRepo.CreateAppArgsForCall(i)
+				Expect(appMessage.SpaceGUID).To(Equal(spaceGUID))
+				appNames = append(appNames, appMessage.Name)
+			}
+			Expect(appNames).To(Equal([]string{appName, "other-app", "third-app"}))
+		})
+
+		When("applying one of the apps fails", func() {
+			BeforeEach(func() {
+				appRepo.CreateAppStub = func(_ context.Context, _ authorization.Info, message repositories.CreateAppMessage) (repositories.AppRecord, error) {
+					if message.Name == "other-app" {
+						return repositories.AppRecord{}, apierrors.NewUnprocessableEntityError(errors.New("boom"), "it is broken")
+					}
+					return repositories.AppRecord{GUID: message.Name + "-guid", SpaceGUID: message.SpaceGUID}, nil
+				}
+			})
+
+			It("still applies the other apps", func() {
+				Expect(appRepo.CreateAppCallCount()).To(Equal(3))
+			})
+
+			It("returns an error that identifies the app", func() {
+				Expect(applyErr).To(MatchError("boom"))
+				Expect(applyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+
+				var apiErr apierrors.ApiError
+				Expect(errors.As(applyErr, &apiErr)).To(BeTrue())
+				Expect(apiErr.Title()).To(Equal("CF-UnprocessableEntity"))
+				Expect(apiErr.Detail()).To(Equal("For application 'other-app': it is broken"))
+			})
+		})
+
+		When("applying several of the apps fails", func() {
+			BeforeEach(func() {
+				appRepo.CreateAppStub = func(_ context.Context, _ authorization.Info, message repositories.CreateAppMessage) (repositories.AppRecord, error) {
+					if message.Name == appName {
+						return repositories.AppRecord{}, nil
+					}
+					return repositories.AppRecord{}, errors.New("boom-" + message.Name)
+				}
+			})
+
+			It("returns an error for each of the failed apps", func() {
+				var jobErrors JobErrors
+				Expect(errors.As(applyErr, &jobErrors)).To(BeTrue())
+				Expect(jobErrors).To(HaveLen(2))
+
+				var apiErr apierrors.ApiError
+				Expect(errors.As(jobErrors[0], &apiErr)).To(BeTrue())
+				Expect(apiErr.Title()).To(Equal("UnknownError"))
+				Expect(apiErr.Detail()).To(Equal("For application 'other-app': An unknown error occurred."))
+				Expect(jobErrors[0]).To(MatchError("boom-other-app"))
+
+				Expect(errors.As(jobErrors[1], &apiErr)).To(BeTrue())
+				Expect(apiErr.Detail()).To(Equal("For application 'third-app': An unknown error occurred."))
+				Expect(jobErrors[1]).To(MatchError("boom-third-app"))
+			})
+		})
+	})
+
 	When("the app does not exist", func() {
 		BeforeEach(func() {
 			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
@@ -254,6 +335,78 @@ var _ = Describe("ApplyManifest", func() {
 			})
 		})
 
+		It("does not look up any sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(0))
+		})
+
+		When("sidecars are specified for the app", func() {
+			BeforeEach(func() {
+				memory := "64M"
+				manifest.Applications[0].Sidecars = []payloads.ManifestApplicationSidecar{
+					{Name: "existing", Command: "./existing", ProcessTypes: []string{"web"}, Memory: &memory},
+					{Name: "new", Command: "./new", ProcessTypes: []string{"web", "worker"}},
+				}
+
+				sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+					{GUID: "existing-guid", Name: "existing"},
+					{GUID: "other-guid", Name: "other"},
+				}, nil)
+			})
+
+			It("lists the sidecars of the app", func() {
+				Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := sidecarRepo.ListSidecarsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message.AppGUID).To(Equal(appGUID))
+				Expect(message.SpaceGUID).To(Equal(spaceGUID))
+			})
+
+			It("patches the sidecars that exist", func() {
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := sidecarRepo.PatchSidecarArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message.GUID).To(Equal("existing-guid"))
+				Expect(message.SpaceGUID).To(Equal(spaceGUID))
+				Expect(message.Command).To(PointTo(Equal("./existing")))
+				Expect(message.ProcessTypes).To(Equal([]string{"web"}))
+				Expect(message.MemoryMB).To(PointTo(BeEquivalentTo(64)))
+			})
+
+			It("creates the sidecars that do not exist", func() {
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := sidecarRepo.CreateSidecarArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.CreateSidecarMessage{
+					Name:         "new",
+					Command:      "./new",
+					ProcessTypes: []string{"web", "worker"},
+					AppGUID:      appGUID,
+					AppEtcdUID:   appEtcdUID,
+					SpaceGUID:    spaceGUID,
+				}))
+			})
+
+			When("listing the sidecars errors", func() {
+				BeforeEach(func() {
+					sidecarRepo.ListSidecarsReturns(nil, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					Expect(applyErr).To(MatchError(ContainSubstring("boom")))
+				})
+			})
+
+			When("creating a sidecar errors", func() {
+				BeforeEach(func() {
+					sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					Expect(applyErr).To(MatchError(ContainSubstring("boom")))
+				})
+			})
+		})
+
 		When("default route is specified for the app, and no routes are specified", func() {
 			BeforeEach(func() {
 				manifest.Applications[0].DefaultRoute = true
@@ -304,7 +457,7 @@ var _ = Describe("ApplyManifest", func() {
 						ok := errors.As(applyErr, &apierr)
 						Expect(ok).To(BeTrue())
 						Expect(apierr.Detail()).To(Equal(
-							fmt.Sprintf("The configured default domain %q was not found", defaultDomainName),
+							fmt.Sprintf("For application '%s': The configured default domain %q was not found", appName, defaultDomainName),
 						))
 					})
 				})
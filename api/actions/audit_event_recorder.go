@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	redactedValue = "[PRIVATE DATA HIDDEN]"
+
+	orgTargetType = "organization"
+)
+
+// redactedKeys name request fields whose values may hold secrets. Their values are never stored in audit events.
+var redactedKeys = map[string]bool{
+	"environment_variables": true,
+	"var":                   true,
+	"credentials":           true,
+	"parameters":            true,
+	"password":              true,
+}
+
+// AuditEventRecorder records who did what. The actor is the identity the request was authenticated as, and the
+// request data is stored with the values of secret fields redacted.
+type AuditEventRecorder struct {
+	logger           logr.Logger
+	identityProvider authorization.IdentityProvider
+	auditEventRepo   CFAuditEventRepository
+	spaceRepo        CFSpaceRepository
+}
+
+func NewAuditEventRecorder(logger logr.Logger, identityProvider authorization.IdentityProvider, auditEventRepo CFAuditEventRepository, spaceRepo CFSpaceRepository) *AuditEventRecorder {
+	return &AuditEventRecorder{
+		logger:           logger,
+		identityProvider: identityProvider,
+		auditEventRepo:   auditEventRepo,
+		spaceRepo:        spaceRepo,
+	}
+}
+
+// Record stores an audit event for a request that has already acted on the target. The event belongs to the space
+// and its org, or to the org itself when the target is an org. The request payload, if any, is stored as event data.
+// A failure to record the event does not undo the action, so it is logged rather than returned.
+func (r *AuditEventRecorder) Record(ctx context.Context, authInfo authorization.Info, eventType string, target repositories.AuditEventTarget, spaceGUID string, request interface{}) {
+	message := repositories.CreateAuditEventMessage{
+		Type:      eventType,
+		Target:    target,
+		SpaceGUID: spaceGUID,
+		Data:      map[string]interface{}{},
+	}
+	if target.Type == orgTargetType {
+		message.OrganizationGUID = target.GUID
+	}
+	if request != nil {
+		message.Data["request"] = request
+	}
+
+	if err := r.record(ctx, authInfo, message); err != nil {
+		r.logger.Error(err, "failed to record audit event", "type", message.Type, "targetGUID", message.Target.GUID)
+	}
+}
+
+func (r *AuditEventRecorder) record(ctx context.Context, authInfo authorization.Info, message repositories.CreateAuditEventMessage) error {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	message.Actor = repositories.AuditEventActor{
+		GUID: identity.Name,
+		Type: actorType(identity),
+		Name: identity.Name,
+	}
+
+	if message.OrganizationGUID == "" && message.SpaceGUID != "" {
+		space, err := r.spaceRepo.GetSpace(ctx, authInfo, message.SpaceGUID)
+		if err != nil {
+			return fmt.Errorf("failed to get space %s: %w", message.SpaceGUID, err)
+		}
+		message.OrganizationGUID = space.OrganizationGUID
+	}
+
+	message.Data, err = redact(message.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.auditEventRepo.CreateAuditEvent(ctx, message)
+	return err
+}
+
+// PruneAuditEvents deletes the audit events older than retention every interval, until ctx is done
+func (r *AuditEventRecorder) PruneAuditEvents(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.auditEventRepo.DeleteAuditEventsBefore(ctx, time.Now().Add(-retention)); err != nil {
+			r.logger.Error(err, "failed to prune audit events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func actorType(identity authorization.Identity) string {
+	if identity.Kind == rbacv1.ServiceAccountKind {
+		return "service_account"
+	}
+	return "user"
+}
+
+// redact converts data to its JSON representation, so that request payloads are stored the way they were sent,
+// and hides the values of the redacted keys at any depth
+func redact(data map[string]interface{}) (map[string]interface{}, error) {
+	if data == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	rawData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	redactedData := map[string]interface{}{}
+	if err := json.Unmarshal(rawData, &redactedData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit event data: %w", err)
+	}
+
+	redactValue(redactedData)
+	return redactedData, nil
+}
+
+func redactValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if redactedKeys[key] && nested != nil {
+				v[key] = redactedValue
+				continue
+			}
+			redactValue(nested)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			redactValue(nested)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("AuditEventRecorder", func() {
+	var (
+		identityProvider *authfake.IdentityProvider
+		auditEventRepo   *fake.CFAuditEventRepository
+		spaceRepo        *fake.CFSpaceRepository
+		authInfo         authorization.Info
+		eventType        string
+		target           repositories.AuditEventTarget
+		spaceGUID        string
+		request          interface{}
+
+		recorder *actions.AuditEventRecorder
+	)
+
+	BeforeEach(func() {
+		identityProvider = new(authfake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}, nil)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "some-space-guid", OrganizationGUID: "some-org-guid"}, nil)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		eventType = "audit.app.update"
+		target = repositories.AuditEventTarget{GUID: "some-app-guid", Type: "app", Name: "some-app"}
+		spaceGUID = "some-space-guid"
+		request = map[string]interface{}{
+			"name":                  "some-app",
+			"environment_variables": map[string]string{"SECRET": "s3cr3t"},
+			"services": []map[string]interface{}{
+				{"credentials": map[string]string{"password": "hunter2"}},
+			},
+		}
+
+		recorder = actions.NewAuditEventRecorder(logr.Discard(), identityProvider, auditEventRepo, spaceRepo)
+	})
+
+	Describe("Record", func() {
+		JustBeforeEach(func() {
+			recorder.Record(context.Background(), authInfo, eventType, target, spaceGUID, request)
+		})
+
+		It("records the event with the identity of the request as actor", func() {
+			Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+			_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(1))
+			_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(createMessage.Type).To(Equal("audit.app.update"))
+			Expect(createMessage.Actor).To(Equal(repositories.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"}))
+			Expect(createMessage.Target).To(Equal(repositories.AuditEventTarget{GUID: "some-app-guid", Type: "app", Name: "some-app"}))
+			Expect(createMessage.SpaceGUID).To(Equal("some-space-guid"))
+		})
+
+		It("looks up the org of the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, spaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(spaceGUID).To(Equal("some-space-guid"))
+
+			_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(createMessage.OrganizationGUID).To(Equal("some-org-guid"))
+		})
+
+		It("redacts secret values in the request data", func() {
+			_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+			Expect(createMessage.Data).To(Equal(map[string]interface{}{
+				"request": map[string]interface{}{
+					"name":                  "some-app",
+					"environment_variables": "[PRIVATE DATA HIDDEN]",
+					"services": []interface{}{
+						map[string]interface{}{"credentials": "[PRIVATE DATA HIDDEN]"},
+					},
+				},
+			}))
+		})
+
+		When("there is no request payload", func() {
+			BeforeEach(func() {
+				request = nil
+			})
+
+			It("records the event without data", func() {
+				_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+				Expect(createMessage.Data).To(BeEmpty())
+			})
+		})
+
+		When("the target is an org", func() {
+			BeforeEach(func() {
+				target = repositories.AuditEventTarget{GUID: "another-org-guid", Type: "organization", Name: "some-org"}
+				spaceGUID = ""
+			})
+
+			It("records the event in the org without looking up a space", func() {
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(0))
+				_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+				Expect(createMessage.OrganizationGUID).To(Equal("another-org-guid"))
+				Expect(createMessage.SpaceGUID).To(BeEmpty())
+			})
+		})
+
+		When("the identity is a service account", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.ServiceAccountKind, Name: "some-sa"}, nil)
+			})
+
+			It("records the service account as actor", func() {
+				_, createMessage := auditEventRepo.CreateAuditEventArgsForCall(0)
+				Expect(createMessage.Actor).To(Equal(repositories.AuditEventActor{GUID: "some-sa", Type: "service_account", Name: "some-sa"}))
+			})
+		})
+
+		When("getting the identity fails", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("identity-err"))
+			})
+
+			It("does not record the event", func() {
+				Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(0))
+			})
+		})
+
+		When("looking up the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("space-err"))
+			})
+
+			It("does not record the event", func() {
+				Expect(auditEventRepo.CreateAuditEventCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("PruneAuditEvents", func() {
+		var cancel context.CancelFunc
+
+		BeforeEach(func() {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go recorder.PruneAuditEvents(ctx, time.Hour, time.Hour)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("deletes the audit events older than the retention", func() {
+			Eventually(auditEventRepo.DeleteAuditEventsBeforeCallCount).Should(Equal(1))
+			Consistently(auditEventRepo.DeleteAuditEventsBeforeCallCount).Should(Equal(1))
+
+			_, cutoff := auditEventRepo.DeleteAuditEventsBeforeArgsForCall(0)
+			Expect(cutoff).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type DeleteProcessInstance struct {
+	processRepo CFProcessRepository
+	podRepo     PodRepository
+	appRepo     CFAppRepository
+}
+
+func NewDeleteProcessInstance(processRepo CFProcessRepository, podRepo PodRepository, appRepo CFAppRepository) *DeleteProcessInstance {
+	return &DeleteProcessInstance{
+		processRepo: processRepo,
+		podRepo:     podRepo,
+		appRepo:     appRepo,
+	}
+}
+
+// Invoke deletes the pod backing the instance of the process with the given index
+func (a *DeleteProcessInstance) Invoke(ctx context.Context, authInfo authorization.Info, processGUID string, index int) error {
+	process, err := a.processRepo.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(err)
+	}
+
+	app, err := a.appRepo.GetApp(ctx, authInfo, process.AppGUID)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return a.deleteInstance(ctx, authInfo, app, process, index)
+}
+
+// InvokeForApp deletes the pod backing the instance with the given index of the app process of the given type
+func (a *DeleteProcessInstance) InvokeForApp(ctx context.Context, authInfo authorization.Info, appGUID string, processType string, index int) error {
+	app, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(err)
+	}
+
+	process, err := a.processRepo.GetProcessByAppTypeAndSpace(ctx, authInfo, app.GUID, processType, app.SpaceGUID)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return a.deleteInstance(ctx, authInfo, app, process, index)
+}
+
+func (a *DeleteProcessInstance) deleteInstance(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord, process repositories.ProcessRecord, index int) error {
+	if app.State == repositories.StoppedState || index < 0 || index >= process.DesiredInstances {
+		return apierrors.NewNotFoundError(nil, repositories.InstanceResourceType)
+	}
+
+	return a.podRepo.DeletePod(ctx, authInfo, repositories.DeletePodMessage{
+		SpaceGUID:     process.SpaceGUID,
+		AppGUID:       process.AppGUID,
+		AppRevision:   app.Revision,
+		ProcessGUID:   process.GUID,
+		InstanceIndex: index,
+	})
+}
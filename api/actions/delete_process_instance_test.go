@@ -0,0 +1,175 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteProcessInstance", func() {
+	const (
+		appGUID     = "some-app-guid"
+		spaceGUID   = "some-space-guid"
+		processGUID = "some-process-guid"
+		processType = "web"
+	)
+
+	var (
+		processRepo *fake.CFProcessRepository
+		podRepo     *fake.PodRepository
+		appRepo     *fake.CFAppRepository
+		authInfo    authorization.Info
+
+		deleteProcessInstance *actions.DeleteProcessInstance
+
+		index       int
+		responseErr error
+	)
+
+	BeforeEach(func() {
+		processRepo = new(fake.CFProcessRepository)
+		podRepo = new(fake.PodRepository)
+		appRepo = new(fake.CFAppRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+		index = 1
+
+		process := repositories.ProcessRecord{
+			GUID:             processGUID,
+			SpaceGUID:        spaceGUID,
+			AppGUID:          appGUID,
+			Type:             processType,
+			DesiredInstances: 2,
+		}
+		processRepo.GetProcessReturns(process, nil)
+		processRepo.GetProcessByAppTypeAndSpaceReturns(process, nil)
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      appGUID,
+			SpaceGUID: spaceGUID,
+			State:     repositories.StartedState,
+			Revision:  "2",
+		}, nil)
+
+		deleteProcessInstance = actions.NewDeleteProcessInstance(processRepo, podRepo, appRepo)
+	})
+
+	Describe("Invoke", func() {
+		JustBeforeEach(func() {
+			responseErr = deleteProcessInstance.Invoke(context.Background(), authInfo, processGUID, index)
+		})
+
+		It("deletes the pod for the instance of the current app revision", func() {
+			Expect(responseErr).NotTo(HaveOccurred())
+
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal(processGUID))
+
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(podRepo.DeletePodCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := podRepo.DeletePodArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeletePodMessage{
+				SpaceGUID:     spaceGUID,
+				AppGUID:       appGUID,
+				AppRevision:   "2",
+				ProcessGUID:   processGUID,
+				InstanceIndex: 1,
+			}))
+		})
+
+		When("the index is not lower than the desired instances", func() {
+			BeforeEach(func() {
+				index = 2
+			})
+
+			It("returns a not found error without deleting anything", func() {
+				Expect(responseErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				Expect(podRepo.DeletePodCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{State: repositories.StoppedState}, nil)
+			})
+
+			It("returns a not found error without deleting anything", func() {
+				Expect(responseErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				Expect(podRepo.DeletePodCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized to get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(responseErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("deleting the pod fails", func() {
+			BeforeEach(func() {
+				podRepo.DeletePodReturns(errors.New("delete-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(responseErr).To(MatchError("delete-error"))
+			})
+		})
+	})
+
+	Describe("InvokeForApp", func() {
+		JustBeforeEach(func() {
+			responseErr = deleteProcessInstance.InvokeForApp(context.Background(), authInfo, appGUID, processType, index)
+		})
+
+		It("deletes the pod for the instance of the app process with the given type", func() {
+			Expect(responseErr).NotTo(HaveOccurred())
+
+			_, _, actualAppGUID, actualProcessType, actualSpaceGUID := processRepo.GetProcessByAppTypeAndSpaceArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+			Expect(actualProcessType).To(Equal(processType))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+			Expect(podRepo.DeletePodCallCount()).To(Equal(1))
+			_, _, message := podRepo.DeletePodArgsForCall(0)
+			Expect(message.ProcessGUID).To(Equal(processGUID))
+			Expect(message.InstanceIndex).To(Equal(1))
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(responseErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				Expect(podRepo.DeletePodCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app has no process of the given type", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessByAppTypeAndSpaceReturns(repositories.ProcessRecord{}, apierrors.NewNotFoundError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(responseErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				Expect(podRepo.DeletePodCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,287 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"code.cloudfoundry.org/bytefmt"
+)
+
+const (
+	diffOpAdd     = "add"
+	diffOpReplace = "replace"
+)
+
+type DiffManifest struct {
+	appRepo     CFAppRepository
+	processRepo CFProcessRepository
+	routeRepo   CFRouteRepository
+}
+
+func NewDiffManifest(appRepo CFAppRepository, processRepo CFProcessRepository, routeRepo CFRouteRepository) *DiffManifest {
+	return &DiffManifest{
+		appRepo:     appRepo,
+		processRepo: processRepo,
+		routeRepo:   routeRepo,
+	}
+}
+
+// Invoke compares the manifest with the current state of its applications in the space. Like apply_manifest, it
+// only considers the fields set in the manifest, and never removes anything that is missing from it.
+func (d *DiffManifest) Invoke(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) ([]presenter.ManifestDiffEntry, error) {
+	diff := []presenter.ManifestDiffEntry{}
+
+	for i, appInfo := range manifest.Applications {
+		appPath := fmt.Sprintf("/applications/%d", i)
+
+		appRecord, err := d.appRepo.GetAppByNameAndSpace(ctx, authInfo, appInfo.Name, spaceGUID)
+		if err != nil {
+			if errors.As(err, new(apierrors.NotFoundError)) {
+				diff = append(diff, newAppDiff(appPath, appInfo)...)
+				continue
+			}
+			return nil, apierrors.ForbiddenAsNotFound(err)
+		}
+
+		appDiff, err := d.diffApp(ctx, authInfo, appPath, appRecord, appInfo)
+		if err != nil {
+			return nil, err
+		}
+		diff = append(diff, appDiff...)
+	}
+
+	return diff, nil
+}
+
+func newAppDiff(appPath string, appInfo payloads.ManifestApplication) []presenter.ManifestDiffEntry {
+	diff := []presenter.ManifestDiffEntry{addEntry(appPath+"/name", appInfo.Name)}
+
+	if len(appInfo.Env) > 0 {
+		diff = append(diff, addEntry(appPath+"/env", appInfo.Env))
+	}
+
+	if len(appInfo.Processes) > 0 {
+		processes := make([]map[string]interface{}, 0, len(appInfo.Processes))
+		for _, processInfo := range appInfo.Processes {
+			processes = append(processes, manifestProcessValues(processInfo))
+		}
+		diff = append(diff, addEntry(appPath+"/processes", processes))
+	}
+
+	routes := manifestRouteValues(appInfo.Routes)
+	if len(routes) > 0 {
+		diff = append(diff, addEntry(appPath+"/routes", routes))
+	}
+
+	return diff
+}
+
+func (d *DiffManifest) diffApp(ctx context.Context, authInfo authorization.Info, appPath string, appRecord repositories.AppRecord, appInfo payloads.ManifestApplication) ([]presenter.ManifestDiffEntry, error) {
+	var diff []presenter.ManifestDiffEntry
+
+	if len(appInfo.Env) > 0 {
+		currentEnv, err := d.appRepo.GetAppEnv(ctx, authInfo, appRecord.GUID)
+		if err != nil {
+			return nil, err
+		}
+		diff = append(diff, diffEnv(appPath+"/env", currentEnv, appInfo.Env)...)
+	}
+
+	if len(appInfo.Processes) > 0 {
+		processes, err := d.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
+			AppGUIDs:  []string{appRecord.GUID},
+			SpaceGUID: appRecord.SpaceGUID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		processesByType := map[string]repositories.ProcessRecord{}
+		for _, process := range processes {
+			processesByType[process.Type] = process
+		}
+
+		for i, processInfo := range appInfo.Processes {
+			processPath := fmt.Sprintf("%s/processes/%d", appPath, i)
+			process, exists := processesByType[processInfo.Type]
+			if !exists {
+				diff = append(diff, addEntry(processPath, manifestProcessValues(processInfo)))
+				continue
+			}
+			diff = append(diff, diffProcess(processPath, process, processInfo)...)
+		}
+	}
+
+	if len(appInfo.Routes) > 0 {
+		routes, err := d.routeRepo.ListRoutesForApp(ctx, authInfo, appRecord.GUID, appRecord.SpaceGUID)
+		if err != nil {
+			return nil, err
+		}
+
+		currentRoutes := map[routeKey]bool{}
+		for _, route := range routes {
+			currentRoutes[routeKey{host: route.Host, domain: route.Domain.Name, path: route.Path}] = true
+		}
+
+		// routes are only ever added to the app, so routes missing from the manifest are not removed
+		for i, routeInfo := range appInfo.Routes {
+			if routeInfo.Route == nil {
+				continue
+			}
+			// parse the route the same way applying the manifest does, so that the diff matches what apply would create
+			hostName, domainName, path := splitRoute(*routeInfo.Route)
+			if currentRoutes[routeKey{host: hostName, domain: domainName, path: path}] {
+				continue
+			}
+			diff = append(diff, addEntry(fmt.Sprintf("%s/routes/%d", appPath, i), map[string]interface{}{"route": *routeInfo.Route}))
+		}
+	}
+
+	return diff, nil
+}
+
+type routeKey struct {
+	host   string
+	domain string
+	path   string
+}
+
+func diffEnv(envPath string, currentEnv, env map[string]string) []presenter.ManifestDiffEntry {
+	var diff []presenter.ManifestDiffEntry
+
+	for _, key := range sortedKeys(env) {
+		keyPath := envPath + "/" + escapeJSONPointer(key)
+		currentValue, exists := currentEnv[key]
+		switch {
+		case !exists:
+			diff = append(diff, addEntry(keyPath, env[key]))
+		case currentValue != env[key]:
+			diff = append(diff, replaceEntry(keyPath, currentValue, env[key]))
+		}
+	}
+
+	return diff
+}
+
+func diffProcess(processPath string, process repositories.ProcessRecord, processInfo payloads.ManifestApplicationProcess) []presenter.ManifestDiffEntry {
+	var diff []presenter.ManifestDiffEntry
+
+	diffString := func(key, current string, desired *string) {
+		switch {
+		case desired == nil || current == *desired:
+		case current == "":
+			diff = append(diff, addEntry(processPath+"/"+key, *desired))
+		default:
+			diff = append(diff, replaceEntry(processPath+"/"+key, current, *desired))
+		}
+	}
+	diffInt := func(key string, current int64, desired *int64) {
+		switch {
+		case desired == nil || current == *desired:
+		case current == 0:
+			diff = append(diff, addEntry(processPath+"/"+key, *desired))
+		default:
+			diff = append(diff, replaceEntry(processPath+"/"+key, current, *desired))
+		}
+	}
+	diffMegabytes := func(key string, currentMB int64, desired *string) {
+		if desired == nil {
+			return
+		}
+		// error ignored intentionally, since the manifest yaml is validated in handlers
+		desiredMB, _ := bytefmt.ToMegabytes(*desired)
+		if int64(desiredMB) != currentMB {
+			diff = append(diff, replaceEntry(processPath+"/"+key, fmt.Sprintf("%dM", currentMB), *desired))
+		}
+	}
+
+	diffString("command", process.Command, processInfo.Command)
+	diffMegabytes("disk_quota", process.DiskQuotaMB, processInfo.DiskQuota)
+	diffString("health-check-http-endpoint", process.HealthCheck.Data.HTTPEndpoint, processInfo.HealthCheckHTTPEndpoint)
+	diffInt("health-check-invocation-timeout", process.HealthCheck.Data.InvocationTimeoutSeconds, processInfo.HealthCheckInvocationTimeout)
+	if processInfo.HealthCheckType != nil {
+		healthCheckType := processInfo.ToProcessPatchMessage(process.GUID, process.SpaceGUID).HealthCheckType
+		if *healthCheckType != process.HealthCheck.Type {
+			diff = append(diff, replaceEntry(processPath+"/health-check-type", process.HealthCheck.Type, *processInfo.HealthCheckType))
+		}
+	}
+	if processInfo.Instances != nil && process.DesiredInstances != *processInfo.Instances {
+		diff = append(diff, replaceEntry(processPath+"/instances", process.DesiredInstances, *processInfo.Instances))
+	}
+	diffMegabytes("memory", process.MemoryMB, processInfo.Memory)
+	diffInt("timeout", process.HealthCheck.Data.TimeoutSeconds, processInfo.Timeout)
+
+	return diff
+}
+
+func manifestProcessValues(processInfo payloads.ManifestApplicationProcess) map[string]interface{} {
+	values := map[string]interface{}{"type": processInfo.Type}
+
+	if processInfo.Command != nil {
+		values["command"] = *processInfo.Command
+	}
+	if processInfo.DiskQuota != nil {
+		values["disk_quota"] = *processInfo.DiskQuota
+	}
+	if processInfo.HealthCheckHTTPEndpoint != nil {
+		values["health-check-http-endpoint"] = *processInfo.HealthCheckHTTPEndpoint
+	}
+	if processInfo.HealthCheckInvocationTimeout != nil {
+		values["health-check-invocation-timeout"] = *processInfo.HealthCheckInvocationTimeout
+	}
+	if processInfo.HealthCheckType != nil {
+		values["health-check-type"] = *processInfo.HealthCheckType
+	}
+	if processInfo.Instances != nil {
+		values["instances"] = *processInfo.Instances
+	}
+	if processInfo.Memory != nil {
+		values["memory"] = *processInfo.Memory
+	}
+	if processInfo.Timeout != nil {
+		values["timeout"] = *processInfo.Timeout
+	}
+
+	return values
+}
+
+func manifestRouteValues(routes []payloads.ManifestRoute) []map[string]interface{} {
+	values := []map[string]interface{}{}
+	for _, route := range routes {
+		if route.Route != nil {
+			values = append(values, map[string]interface{}{"route": *route.Route})
+		}
+	}
+	return values
+}
+
+func addEntry(path string, value interface{}) presenter.ManifestDiffEntry {
+	return presenter.ManifestDiffEntry{Op: diffOpAdd, Path: path, Value: value}
+}
+
+func replaceEntry(path string, was, value interface{}) presenter.ManifestDiffEntry {
+	return presenter.ManifestDiffEntry{Op: diffOpReplace, Path: path, Was: was, Value: value}
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer path segment, see RFC 6901
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,315 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffManifest", func() {
+	const (
+		spaceGUID = "test-space-guid"
+		appName   = "my-app"
+		appGUID   = "my-app-guid"
+	)
+
+	var (
+		appRepo     *fake.CFAppRepository
+		processRepo *fake.CFProcessRepository
+		routeRepo   *fake.CFRouteRepository
+		authInfo    authorization.Info
+		manifest    payloads.Manifest
+
+		diff    []presenter.ManifestDiffEntry
+		diffErr error
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		processRepo = new(fake.CFProcessRepository)
+		routeRepo = new(fake.CFRouteRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{
+			Name:      appName,
+			GUID:      appGUID,
+			SpaceGUID: spaceGUID,
+		}, nil)
+		appRepo.GetAppEnvReturns(map[string]string{"FOO": "foo", "BAR": "bar"}, nil)
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{{
+			GUID:             "web-process-guid",
+			SpaceGUID:        spaceGUID,
+			AppGUID:          appGUID,
+			Type:             "web",
+			Command:          "start-web",
+			DesiredInstances: 1,
+			MemoryMB:         1024,
+			DiskQuotaMB:      1024,
+			HealthCheck: repositories.HealthCheck{
+				Type: "port",
+			},
+		}}, nil)
+		routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{{
+			Host:   "my-app",
+			Domain: repositories.DomainRecord{Name: "example.org"},
+		}}, nil)
+
+		manifest = payloads.Manifest{
+			Version: 1,
+			Applications: []payloads.ManifestApplication{{
+				Name: appName,
+			}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		diff, diffErr = NewDiffManifest(appRepo, processRepo, routeRepo).Invoke(context.Background(), authInfo, spaceGUID, manifest)
+	})
+
+	It("fetches the app by name in the space", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+		Expect(appRepo.GetAppByNameAndSpaceCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppName, actualSpaceGUID := appRepo.GetAppByNameAndSpaceArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualAppName).To(Equal(appName))
+		Expect(actualSpaceGUID).To(Equal(spaceGUID))
+	})
+
+	It("returns an empty diff when the manifest only names the app", func() {
+		Expect(diff).To(BeEmpty())
+		Expect(diff).NotTo(BeNil())
+	})
+
+	It("does not look up state that the manifest does not set", func() {
+		Expect(appRepo.GetAppEnvCallCount()).To(Equal(0))
+		Expect(processRepo.ListProcessesCallCount()).To(Equal(0))
+		Expect(routeRepo.ListRoutesForAppCallCount()).To(Equal(0))
+	})
+
+	When("the manifest sets env vars", func() {
+		BeforeEach(func() {
+			manifest.Applications[0].Env = map[string]string{
+				"FOO":     "foo",
+				"BAR":     "new-bar",
+				"NEW/VAR": "new",
+			}
+		})
+
+		It("diffs them against the app env", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			_, _, actualAppGUID := appRepo.GetAppEnvArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+				{Op: "replace", Path: "/applications/0/env/BAR", Was: "bar", Value: "new-bar"},
+				{Op: "add", Path: "/applications/0/env/NEW~1VAR", Value: "new"},
+			}))
+		})
+
+		When("getting the app env fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppEnvReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(diffErr).To(MatchError("boom"))
+			})
+		})
+	})
+
+	When("the manifest sets processes", func() {
+		BeforeEach(func() {
+			manifest.Applications[0].Processes = []payloads.ManifestApplicationProcess{
+				{
+					Type:            "web",
+					Command:         stringPointer("start-web"),
+					Instances:       intPointer(3),
+					Memory:          stringPointer("1G"),
+					DiskQuota:       stringPointer("2G"),
+					HealthCheckType: stringPointer("http"),
+				},
+				{
+					Type:    "worker",
+					Command: stringPointer("start-worker"),
+				},
+			}
+		})
+
+		It("diffs the fields set in the manifest against the app processes", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			_, _, listMessage := processRepo.ListProcessesArgsForCall(0)
+			Expect(listMessage).To(Equal(repositories.ListProcessesMessage{
+				AppGUIDs:  []string{appGUID},
+				SpaceGUID: spaceGUID,
+			}))
+
+			Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+				{Op: "replace", Path: "/applications/0/processes/0/disk_quota", Was: "1024M", Value: "2G"},
+				{Op: "replace", Path: "/applications/0/processes/0/health-check-type", Was: "port", Value: "http"},
+				{Op: "replace", Path: "/applications/0/processes/0/instances", Was: 1, Value: 3},
+				{Op: "add", Path: "/applications/0/processes/1", Value: map[string]interface{}{
+					"type":    "worker",
+					"command": "start-worker",
+				}},
+			}))
+		})
+
+		When("the manifest sets health check timeouts the process does not have", func() {
+			BeforeEach(func() {
+				manifest.Applications[0].Processes = []payloads.ManifestApplicationProcess{{
+					Type:                         "web",
+					Timeout:                      int64Pointer(60),
+					HealthCheckInvocationTimeout: int64Pointer(5),
+				}}
+			})
+
+			It("adds them", func() {
+				Expect(diffErr).NotTo(HaveOccurred())
+				Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+					{Op: "add", Path: "/applications/0/processes/0/health-check-invocation-timeout", Value: int64(5)},
+					{Op: "add", Path: "/applications/0/processes/0/timeout", Value: int64(60)},
+				}))
+			})
+		})
+
+		When("listing the processes fails", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(diffErr).To(MatchError("boom"))
+			})
+		})
+	})
+
+	When("the manifest sets routes", func() {
+		BeforeEach(func() {
+			manifest.Applications[0].Routes = []payloads.ManifestRoute{
+				{Route: stringPointer("my-app.example.org")},
+				{Route: stringPointer("other.example.org/path")},
+			}
+		})
+
+		It("adds the routes that are not mapped to the app yet", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			_, _, actualAppGUID, actualSpaceGUID := routeRepo.ListRoutesForAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+			Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+				{Op: "add", Path: "/applications/0/routes/1", Value: map[string]interface{}{"route": "other.example.org/path"}},
+			}))
+		})
+
+		When("the app already has a route with a path", func() {
+			BeforeEach(func() {
+				routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{
+					{Host: "my-app", Domain: repositories.DomainRecord{Name: "example.org"}},
+					{Host: "other", Domain: repositories.DomainRecord{Name: "example.org"}, Path: "/path"},
+				}, nil)
+			})
+
+			It("matches it against the parsed manifest route", func() {
+				Expect(diffErr).NotTo(HaveOccurred())
+				Expect(diff).To(BeEmpty())
+			})
+		})
+
+		When("the app has a route without a host", func() {
+			BeforeEach(func() {
+				routeRepo.ListRoutesForAppReturns([]repositories.RouteRecord{
+					{Domain: repositories.DomainRecord{Name: "my-app.example.org"}},
+				}, nil)
+			})
+
+			It("does not match it against a manifest route that applying would give a host", func() {
+				Expect(diffErr).NotTo(HaveOccurred())
+				Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+					{Op: "add", Path: "/applications/0/routes/0", Value: map[string]interface{}{"route": "my-app.example.org"}},
+					{Op: "add", Path: "/applications/0/routes/1", Value: map[string]interface{}{"route": "other.example.org/path"}},
+				}))
+			})
+		})
+
+		When("listing the routes fails", func() {
+			BeforeEach(func() {
+				routeRepo.ListRoutesForAppReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(diffErr).To(MatchError("boom"))
+			})
+		})
+	})
+
+	When("the app does not exist", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			manifest.Applications = append(manifest.Applications, payloads.ManifestApplication{
+				Name: "new-app",
+				Env:  map[string]string{"FOO": "bar"},
+				Processes: []payloads.ManifestApplicationProcess{
+					{Type: "web", Instances: intPointer(2)},
+				},
+				Routes: []payloads.ManifestRoute{
+					{Route: stringPointer("new-app.example.org")},
+				},
+			})
+		})
+
+		It("adds the whole app", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]presenter.ManifestDiffEntry{
+				{Op: "add", Path: "/applications/0/name", Value: appName},
+				{Op: "add", Path: "/applications/1/name", Value: "new-app"},
+				{Op: "add", Path: "/applications/1/env", Value: map[string]string{"FOO": "bar"}},
+				{Op: "add", Path: "/applications/1/processes", Value: []map[string]interface{}{
+					{"type": "web", "instances": 2},
+				}},
+				{Op: "add", Path: "/applications/1/routes", Value: []map[string]interface{}{
+					{"route": "new-app.example.org"},
+				}},
+			}))
+		})
+	})
+
+	When("fetching the app is forbidden", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+		})
+
+		It("returns a not found error", func() {
+			Expect(diffErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+
+	When("fetching the app fails", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{}, errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("boom"))
+		})
+	})
+})
+
+func intPointer(i int) *int {
+	return &i
+}
+
+func int64Pointer(i int64) *int64 {
+	return &i
+}
@@ -11,6 +11,19 @@ import (
 )
 
 type PodRepository struct {
+	DeletePodStub        func(context.Context, authorization.Info, repositories.DeletePodMessage) error
+	deletePodMutex       sync.RWMutex
+	deletePodArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePodMessage
+	}
+	deletePodReturns struct {
+		result1 error
+	}
+	deletePodReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ListPodStatsStub        func(context.Context, authorization.Info, repositories.ListPodStatsMessage) ([]repositories.PodStatsRecord, error)
 	listPodStatsMutex       sync.RWMutex
 	listPodStatsArgsForCall []struct {
@@ -30,6 +43,69 @@ type PodRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PodRepository) DeletePod(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeletePodMessage) error {
+	fake.deletePodMutex.Lock()
+	ret, specificReturn := fake.deletePodReturnsOnCall[len(fake.deletePodArgsForCall)]
+	fake.deletePodArgsForCall = append(fake.deletePodArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePodMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeletePodStub
+	fakeReturns := fake.deletePodReturns
+	fake.recordInvocation("DeletePod", []interface{}{arg1, arg2, arg3})
+	fake.deletePodMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PodRepository) DeletePodCallCount() int {
+	fake.deletePodMutex.RLock()
+	defer fake.deletePodMutex.RUnlock()
+	return len(fake.deletePodArgsForCall)
+}
+
+func (fake *PodRepository) DeletePodCalls(stub func(context.Context, authorization.Info, repositories.DeletePodMessage) error) {
+	fake.deletePodMutex.Lock()
+	defer fake.deletePodMutex.Unlock()
+	fake.DeletePodStub = stub
+}
+
+func (fake *PodRepository) DeletePodArgsForCall(i int) (context.Context, authorization.Info, repositories.DeletePodMessage) {
+	fake.deletePodMutex.RLock()
+	defer fake.deletePodMutex.RUnlock()
+	argsForCall := fake.deletePodArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PodRepository) DeletePodReturns(result1 error) {
+	fake.deletePodMutex.Lock()
+	defer fake.deletePodMutex.Unlock()
+	fake.DeletePodStub = nil
+	fake.deletePodReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *PodRepository) DeletePodReturnsOnCall(i int, result1 error) {
+	fake.deletePodMutex.Lock()
+	defer fake.deletePodMutex.Unlock()
+	fake.DeletePodStub = nil
+	if fake.deletePodReturnsOnCall == nil {
+		fake.deletePodReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePodReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *PodRepository) ListPodStats(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListPodStatsMessage) ([]repositories.PodStatsRecord, error) {
 	fake.listPodStatsMutex.Lock()
 	ret, specificReturn := fake.listPodStatsReturnsOnCall[len(fake.listPodStatsArgsForCall)]
@@ -99,6 +175,8 @@ func (fake *PodRepository) ListPodStatsReturnsOnCall(i int, result1 []repositori
 func (fake *PodRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.deletePodMutex.RLock()
+	defer fake.deletePodMutex.RUnlock()
 	fake.listPodStatsMutex.RLock()
 	defer fake.listPodStatsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,304 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	jobTimeoutErrorCode   = 290006
+	jobTimeoutErrorTitle  = "CF-JobTimeout"
+	jobTimeoutErrorDetail = "The job execution has timed out."
+
+	jobInterruptedErrorCode   = 10001
+	jobInterruptedErrorTitle  = "UnknownError"
+	jobInterruptedErrorDetail = "The job was interrupted by a restart of the API."
+)
+
+// JobErrors lets work that carries on past a failure report all of its failures, each of which is recorded as a
+// separate job error
+type JobErrors []error
+
+func (e JobErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// JobRunner records asynchronous jobs and performs their work in the background, driving each job from
+// PROCESSING to COMPLETE, or to FAILED with the errors the work returned. Jobs are recorded with the identity that
+// started them, so that only their creator can see them. While a job runs, its heartbeat is recorded every
+// heartbeatInterval, which tells the API instances apart from the one that went away with a job still running.
+type JobRunner struct {
+	logger            logr.Logger
+	identityProvider  authorization.IdentityProvider
+	jobRepo           CFJobRepository
+	timeout           time.Duration
+	pollInterval      time.Duration
+	heartbeatInterval time.Duration
+}
+
+func NewJobRunner(logger logr.Logger, identityProvider authorization.IdentityProvider, jobRepo CFJobRepository, timeout time.Duration, pollInterval time.Duration, heartbeatInterval time.Duration) *JobRunner {
+	return &JobRunner{
+		logger:            logger,
+		identityProvider:  identityProvider,
+		jobRepo:           jobRepo,
+		timeout:           timeout,
+		pollInterval:      pollInterval,
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Start records a PROCESSING job and runs work in the background. The work outlives the request, so it must not
+// use the request context, and is passed a context that is cancelled when the job times out.
+func (r *JobRunner) Start(ctx context.Context, authInfo authorization.Info, message repositories.CreateJobMessage, work func(context.Context) ([]string, error)) (repositories.JobRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return repositories.JobRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+	message.Creator = authorization.Identity{Kind: identity.Kind, Name: identity.Name}
+
+	job, err := r.jobRepo.CreateJob(ctx, message)
+	if err != nil {
+		return repositories.JobRecord{}, err
+	}
+
+	go r.run(job, work)
+
+	return job, nil
+}
+
+// StartDeletion starts a job that completes once getResource reports the deleted resource as not found
+func (r *JobRunner) StartDeletion(ctx context.Context, authInfo authorization.Info, message repositories.CreateJobMessage, getResource func(context.Context) error) (repositories.JobRecord, error) {
+	return r.StartAwaiting(ctx, authInfo, message, func(ctx context.Context) (bool, error) {
+		err := apierrors.ForbiddenAsNotFound(getResource(ctx))
+		if errors.As(err, new(apierrors.NotFoundError)) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+// StartAwaiting starts a job that polls isDone until it reports the work of a controller as done, or returns an error
+func (r *JobRunner) StartAwaiting(ctx context.Context, authInfo authorization.Info, message repositories.CreateJobMessage, isDone func(context.Context) (bool, error)) (repositories.JobRecord, error) {
+	return r.Start(ctx, authInfo, message, func(ctx context.Context) ([]string, error) {
+		return nil, r.poll(ctx, isDone)
+	})
+}
+
+func (r *JobRunner) poll(ctx context.Context, isDone func(context.Context) (bool, error)) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, err := isDone(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *JobRunner) run(job repositories.JobRecord, work func(context.Context) ([]string, error)) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(context.Background())
+	go r.recordHeartbeats(heartbeatCtx, job.GUID)
+	warnings, err := work(ctx)
+	stopHeartbeat()
+
+	message := repositories.UpdateJobMessage{
+		GUID:     job.GUID,
+		State:    repositories.JobStateComplete,
+		Warnings: warnings,
+	}
+	if err != nil {
+		r.logger.Info("job failed", "guid", job.GUID, "operation", job.Operation, "error", err)
+		message.State = repositories.JobStateFailed
+		message.Errors = toJobErrors(ctx, err)
+	}
+
+	// the job context may have expired, the final state must be recorded regardless
+	if _, err = r.jobRepo.UpdateJob(context.Background(), message); err != nil {
+		r.logger.Error(err, "failed to record job state", "guid", job.GUID, "state", message.State)
+	}
+}
+
+func (r *JobRunner) recordHeartbeats(ctx context.Context, jobGUID string) {
+	ticker := time.NewTicker(r.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.jobRepo.RecordJobHeartbeat(ctx, jobGUID); err != nil && ctx.Err() == nil {
+			r.logger.Error(err, "failed to record job heartbeat", "guid", jobGUID)
+		}
+	}
+}
+
+// FailAbandonedJobs fails the PROCESSING jobs whose heartbeat is older than expiry every interval, until ctx is done.
+// The work of these jobs ran in an API instance that has gone away, and will never complete. Jobs that are still run
+// by any instance, including other replicas, keep their heartbeat fresh and are left alone.
+func (r *JobRunner) FailAbandonedJobs(ctx context.Context, expiry time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.failAbandonedJobs(ctx, expiry); err != nil {
+			r.logger.Error(err, "failed to fail abandoned jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *JobRunner) failAbandonedJobs(ctx context.Context, expiry time.Duration) error {
+	jobs, err := r.jobRepo.ListJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-expiry)
+	for _, job := range jobs {
+		if job.State != repositories.JobStateProcessing {
+			continue
+		}
+
+		// jobs recorded before heartbeats were, have none and are as old as their creation
+		heartbeatAt := job.HeartbeatAt
+		if heartbeatAt == "" {
+			heartbeatAt = job.CreatedAt
+		}
+		lastHeartbeat, err := time.Parse(repositories.TimestampFormat, heartbeatAt)
+		if err != nil {
+			return fmt.Errorf("failed to parse heartbeat of job %q: %w", job.GUID, err)
+		}
+		if lastHeartbeat.After(cutoff) {
+			continue
+		}
+
+		_, err = r.jobRepo.UpdateJob(ctx, repositories.UpdateJobMessage{
+			GUID:  job.GUID,
+			State: repositories.JobStateFailed,
+			Errors: []repositories.JobError{{
+				Code:   jobInterruptedErrorCode,
+				Title:  jobInterruptedErrorTitle,
+				Detail: jobInterruptedErrorDetail,
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fail abandoned job %q: %w", job.GUID, err)
+		}
+	}
+
+	return nil
+}
+
+// PruneJobs deletes the finished jobs older than retention every interval, until ctx is done
+func (r *JobRunner) PruneJobs(ctx context.Context, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.pruneJobs(ctx, retention); err != nil {
+			r.logger.Error(err, "failed to prune jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *JobRunner) pruneJobs(ctx context.Context, retention time.Duration) error {
+	jobs, err := r.jobRepo.ListJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	expiry := time.Now().Add(-retention)
+	for _, job := range jobs {
+		if job.State == repositories.JobStateProcessing {
+			continue
+		}
+
+		createdAt, err := time.Parse(repositories.TimestampFormat, job.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to parse creation time of job %q: %w", job.GUID, err)
+		}
+		if createdAt.After(expiry) {
+			continue
+		}
+
+		if err = r.jobRepo.DeleteJob(ctx, job.GUID); err != nil {
+			return fmt.Errorf("failed to delete job %q: %w", job.GUID, err)
+		}
+	}
+
+	return nil
+}
+
+func toJobErrors(ctx context.Context, err error) []repositories.JobError {
+	var jobErrors JobErrors
+	if !errors.As(err, &jobErrors) {
+		return []repositories.JobError{toJobError(ctx, err)}
+	}
+
+	result := make([]repositories.JobError, 0, len(jobErrors))
+	for _, jobErr := range jobErrors {
+		result = append(result, toJobError(ctx, jobErr))
+	}
+	return result
+}
+
+func toJobError(ctx context.Context, err error) repositories.JobError {
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil {
+		return repositories.JobError{
+			Code:   jobTimeoutErrorCode,
+			Title:  jobTimeoutErrorTitle,
+			Detail: jobTimeoutErrorDetail,
+		}
+	}
+
+	var apiError apierrors.ApiError
+	if !errors.As(err, &apiError) {
+		apiError = apierrors.NewUnknownError(err)
+	}
+
+	return repositories.JobError{
+		Code:   apiError.Code(),
+		Title:  apiError.Title(),
+		Detail: apiError.Detail(),
+	}
+}
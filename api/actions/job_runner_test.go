@@ -0,0 +1,384 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("JobRunner", func() {
+	var (
+		identityProvider *authfake.IdentityProvider
+		jobRepo          *fake.CFJobRepository
+		jobRunner        *JobRunner
+		authInfo         authorization.Info
+		message          repositories.CreateJobMessage
+		job              repositories.JobRecord
+		err              error
+	)
+
+	BeforeEach(func() {
+		authInfo = authorization.Info{Token: "a-token"}
+		identityProvider = new(authfake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"admins"}}, nil)
+		jobRepo = new(fake.CFJobRepository)
+		jobRepo.CreateJobReturns(repositories.JobRecord{
+			GUID:         "job-guid",
+			Operation:    repositories.AppDeleteJobOperation,
+			ResourceGUID: "app-guid",
+			State:        repositories.JobStateProcessing,
+		}, nil)
+
+		jobRunner = NewJobRunner(logf.Log.WithName("TestJobRunner"), identityProvider, jobRepo, 200*time.Millisecond, 10*time.Millisecond, 20*time.Millisecond)
+		message = repositories.CreateJobMessage{
+			Operation:    repositories.AppDeleteJobOperation,
+			ResourceGUID: "app-guid",
+		}
+	})
+
+	updatedJob := func() repositories.UpdateJobMessage {
+		Eventually(jobRepo.UpdateJobCallCount).Should(Equal(1))
+		_, updateMessage := jobRepo.UpdateJobArgsForCall(0)
+		return updateMessage
+	}
+
+	Describe("Start", func() {
+		var (
+			warnings []string
+			workErr  error
+		)
+
+		BeforeEach(func() {
+			warnings = []string{"careful"}
+			workErr = nil
+		})
+
+		JustBeforeEach(func() {
+			job, err = jobRunner.Start(context.Background(), authInfo, message, func(context.Context) ([]string, error) {
+				return warnings, workErr
+			})
+		})
+
+		It("creates the job and returns it", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.GUID).To(Equal("job-guid"))
+
+			Expect(jobRepo.CreateJobCallCount()).To(Equal(1))
+			_, createMessage := jobRepo.CreateJobArgsForCall(0)
+			Expect(createMessage.Operation).To(Equal(message.Operation))
+			Expect(createMessage.ResourceGUID).To(Equal(message.ResourceGUID))
+		})
+
+		It("records the identity that started the job as its creator", func() {
+			Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+			_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			_, createMessage := jobRepo.CreateJobArgsForCall(0)
+			Expect(createMessage.Creator).To(Equal(authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}))
+		})
+
+		It("completes the job with the warnings of the work", func() {
+			Expect(updatedJob()).To(Equal(repositories.UpdateJobMessage{
+				GUID:     "job-guid",
+				State:    repositories.JobStateComplete,
+				Warnings: []string{"careful"},
+			}))
+		})
+
+		When("the work fails with an api error", func() {
+			BeforeEach(func() {
+				workErr = apierrors.NewNotFoundError(nil, repositories.DomainResourceType)
+			})
+
+			It("fails the job with the error", func() {
+				updateMessage := updatedJob()
+				Expect(updateMessage.State).To(Equal(repositories.JobStateFailed))
+				Expect(updateMessage.Errors).To(ConsistOf(repositories.JobError{
+					Code:   10010,
+					Title:  "CF-ResourceNotFound",
+					Detail: "Domain not found. Ensure it exists and you have access to it.",
+				}))
+			})
+		})
+
+		When("the work fails with an unexpected error", func() {
+			BeforeEach(func() {
+				workErr = errors.New("boom")
+			})
+
+			It("fails the job with an unknown error", func() {
+				updateMessage := updatedJob()
+				Expect(updateMessage.State).To(Equal(repositories.JobStateFailed))
+				Expect(updateMessage.Errors).To(ConsistOf(repositories.JobError{
+					Code:   10001,
+					Title:  "UnknownError",
+					Detail: "An unknown error occurred.",
+				}))
+			})
+		})
+
+		When("the work reports several errors", func() {
+			BeforeEach(func() {
+				workErr = JobErrors{
+					apierrors.NewNotFoundError(nil, repositories.DomainResourceType),
+					errors.New("boom"),
+				}
+			})
+
+			It("fails the job with each of the errors", func() {
+				updateMessage := updatedJob()
+				Expect(updateMessage.State).To(Equal(repositories.JobStateFailed))
+				Expect(updateMessage.Errors).To(Equal([]repositories.JobError{
+					{
+						Code:   10010,
+						Title:  "CF-ResourceNotFound",
+						Detail: "Domain not found. Ensure it exists and you have access to it.",
+					},
+					{
+						Code:   10001,
+						Title:  "UnknownError",
+						Detail: "An unknown error occurred.",
+					},
+				}))
+			})
+		})
+
+		When("getting the identity fails", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("who-are-you"))
+			})
+
+			It("returns the error and does not create the job", func() {
+				Expect(err).To(MatchError(ContainSubstring("who-are-you")))
+				Expect(jobRepo.CreateJobCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the job fails", func() {
+			BeforeEach(func() {
+				jobRepo.CreateJobReturns(repositories.JobRecord{}, errors.New("create-failed"))
+			})
+
+			It("returns the error and does not run the work", func() {
+				Expect(err).To(MatchError("create-failed"))
+				Consistently(jobRepo.UpdateJobCallCount).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("Start with work that does not finish in time", func() {
+		BeforeEach(func() {
+			_, err = jobRunner.Start(context.Background(), authInfo, message, func(ctx context.Context) ([]string, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails the job with a timeout error", func() {
+			updateMessage := updatedJob()
+			Expect(updateMessage.State).To(Equal(repositories.JobStateFailed))
+			Expect(updateMessage.Errors).To(ConsistOf(repositories.JobError{
+				Code:   290006,
+				Title:  "CF-JobTimeout",
+				Detail: "The job execution has timed out.",
+			}))
+		})
+	})
+
+	Describe("Start with work that outlasts the heartbeat interval", func() {
+		var finishWork chan struct{}
+
+		BeforeEach(func() {
+			finishWork = make(chan struct{})
+			_, err = jobRunner.Start(context.Background(), authInfo, message, func(ctx context.Context) ([]string, error) {
+				<-finishWork
+				return nil, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("records heartbeats of the job until it finishes", func() {
+			Eventually(jobRepo.RecordJobHeartbeatCallCount).Should(BeNumerically(">=", 2))
+			_, jobGUID := jobRepo.RecordJobHeartbeatArgsForCall(0)
+			Expect(jobGUID).To(Equal("job-guid"))
+
+			close(finishWork)
+			Eventually(jobRepo.UpdateJobCallCount).Should(Equal(1))
+			heartbeats := jobRepo.RecordJobHeartbeatCallCount()
+			Consistently(jobRepo.RecordJobHeartbeatCallCount, "100ms").Should(Equal(heartbeats))
+		})
+	})
+
+	Describe("StartDeletion", func() {
+		var (
+			getCount    int
+			notFoundErr error
+		)
+
+		BeforeEach(func() {
+			getCount = 0
+			notFoundErr = apierrors.NewNotFoundError(nil, repositories.AppResourceType)
+		})
+
+		JustBeforeEach(func() {
+			job, err = jobRunner.StartDeletion(context.Background(), authInfo, message, func(context.Context) error {
+				getCount++
+				if getCount < 3 {
+					return nil
+				}
+				return notFoundErr
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("completes the job once the resource is gone", func() {
+			Expect(updatedJob()).To(Equal(repositories.UpdateJobMessage{
+				GUID:  "job-guid",
+				State: repositories.JobStateComplete,
+			}))
+			Expect(getCount).To(Equal(3))
+		})
+
+		When("the resource becomes forbidden", func() {
+			BeforeEach(func() {
+				notFoundErr = apierrors.NewForbiddenError(nil, repositories.AppResourceType)
+			})
+
+			It("completes the job", func() {
+				Expect(updatedJob().State).To(Equal(repositories.JobStateComplete))
+			})
+		})
+
+		When("getting the resource fails", func() {
+			BeforeEach(func() {
+				notFoundErr = errors.New("boom")
+			})
+
+			It("fails the job", func() {
+				Expect(updatedJob().State).To(Equal(repositories.JobStateFailed))
+			})
+		})
+	})
+
+	Describe("StartAwaiting", func() {
+		var (
+			pollCount int
+			pollErr   error
+		)
+
+		BeforeEach(func() {
+			pollCount = 0
+			pollErr = nil
+		})
+
+		JustBeforeEach(func() {
+			job, err = jobRunner.StartAwaiting(context.Background(), authInfo, message, func(context.Context) (bool, error) {
+				pollCount++
+				return pollCount >= 3, pollErr
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("completes the job once the work is done", func() {
+			Expect(updatedJob().State).To(Equal(repositories.JobStateComplete))
+			Expect(pollCount).To(Equal(3))
+		})
+
+		When("polling fails", func() {
+			BeforeEach(func() {
+				pollErr = errors.New("boom")
+			})
+
+			It("fails the job", func() {
+				Expect(updatedJob().State).To(Equal(repositories.JobStateFailed))
+				Expect(pollCount).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("FailAbandonedJobs", func() {
+		var cancel context.CancelFunc
+
+		BeforeEach(func() {
+			old := time.Now().Add(-2 * time.Hour).UTC().Format(repositories.TimestampFormat)
+			recent := time.Now().UTC().Format(repositories.TimestampFormat)
+			jobRepo.ListJobsReturns([]repositories.JobRecord{
+				{GUID: "abandoned-job", State: repositories.JobStateProcessing, HeartbeatAt: old, CreatedAt: old},
+				{GUID: "running-job", State: repositories.JobStateProcessing, HeartbeatAt: recent, CreatedAt: old},
+				{GUID: "job-without-heartbeat", State: repositories.JobStateProcessing, CreatedAt: old},
+				{GUID: "new-job-without-heartbeat", State: repositories.JobStateProcessing, CreatedAt: recent},
+				{GUID: "complete-job", State: repositories.JobStateComplete, HeartbeatAt: old, CreatedAt: old},
+				{GUID: "failed-job", State: repositories.JobStateFailed, HeartbeatAt: old, CreatedAt: old},
+			}, nil)
+
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go jobRunner.FailAbandonedJobs(ctx, time.Hour, time.Hour)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("fails the processing jobs whose heartbeat has expired", func() {
+			Eventually(jobRepo.UpdateJobCallCount).Should(Equal(2))
+			Consistently(jobRepo.UpdateJobCallCount).Should(Equal(2))
+
+			_, firstMessage := jobRepo.UpdateJobArgsForCall(0)
+			_, secondMessage := jobRepo.UpdateJobArgsForCall(1)
+			Expect([]string{firstMessage.GUID, secondMessage.GUID}).To(ConsistOf("abandoned-job", "job-without-heartbeat"))
+			Expect(firstMessage.State).To(Equal(repositories.JobStateFailed))
+			Expect(firstMessage.Errors).To(ConsistOf(repositories.JobError{
+				Code:   10001,
+				Title:  "UnknownError",
+				Detail: "The job was interrupted by a restart of the API.",
+			}))
+		})
+	})
+
+	Describe("PruneJobs", func() {
+		var cancel context.CancelFunc
+
+		BeforeEach(func() {
+			old := time.Now().Add(-2 * time.Hour).UTC().Format(repositories.TimestampFormat)
+			recent := time.Now().UTC().Format(repositories.TimestampFormat)
+			jobRepo.ListJobsReturns([]repositories.JobRecord{
+				{GUID: "old-complete-job", State: repositories.JobStateComplete, CreatedAt: old},
+				{GUID: "old-failed-job", State: repositories.JobStateFailed, CreatedAt: old},
+				{GUID: "old-processing-job", State: repositories.JobStateProcessing, CreatedAt: old},
+				{GUID: "recent-complete-job", State: repositories.JobStateComplete, CreatedAt: recent},
+			}, nil)
+
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			go jobRunner.PruneJobs(ctx, time.Hour, time.Hour)
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("deletes the finished jobs older than the retention", func() {
+			Eventually(jobRepo.DeleteJobCallCount).Should(Equal(2))
+			Consistently(jobRepo.DeleteJobCallCount).Should(Equal(2))
+
+			_, firstGUID := jobRepo.DeleteJobArgsForCall(0)
+			_, secondGUID := jobRepo.DeleteJobArgsForCall(1)
+			Expect([]string{firstGUID, secondGUID}).To(ConsistOf("old-complete-job", "old-failed-job"))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -26,12 +27,14 @@ type CFAppRepository interface {
 	GetAppByNameAndSpace(context.Context, authorization.Info, string, string) (repositories.AppRecord, error)
 	CreateOrPatchAppEnvVars(context.Context, authorization.Info, repositories.CreateOrPatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
+	GetAppEnv(context.Context, authorization.Info, string) (map[string]string, error)
 }
 
 //counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
 
 type PodRepository interface {
 	ListPodStats(ctx context.Context, authInfo authorization.Info, message repositories.ListPodStatsMessage) ([]repositories.PodStatsRecord, error)
+	DeletePod(ctx context.Context, authInfo authorization.Info, message repositories.DeletePodMessage) error
 }
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -47,3 +50,34 @@ type CFRouteRepository interface {
 	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsToRouteMessage) (repositories.RouteRecord, error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+
+type CFSidecarRepository interface {
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CFJobRepository . CFJobRepository
+
+type CFJobRepository interface {
+	CreateJob(context.Context, repositories.CreateJobMessage) (repositories.JobRecord, error)
+	ListJobs(context.Context) ([]repositories.JobRecord, error)
+	UpdateJob(context.Context, repositories.UpdateJobMessage) (repositories.JobRecord, error)
+	RecordJobHeartbeat(context.Context, string) error
+	DeleteJob(context.Context, string) error
+}
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+
+type CFAuditEventRepository interface {
+	CreateAuditEvent(context.Context, repositories.CreateAuditEventMessage) (repositories.AuditEventRecord, error)
+	DeleteAuditEventsBefore(context.Context, time.Time) error
+}
+
+//counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
+
+type CFSpaceRepository interface {
+	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
+}
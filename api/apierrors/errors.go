@@ -189,6 +189,22 @@ func NewUnknownKeyError(cause error, validKeys []string) UnknownKeyError {
 	}
 }
 
+type BadQueryParameterError struct {
+	apiError
+}
+
+func NewBadQueryParameterError(cause error, message string) BadQueryParameterError {
+	return BadQueryParameterError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-BadQueryParameter",
+			detail:     fmt.Sprintf("The query parameter is invalid: %s", message),
+			code:       10005,
+			httpStatus: http.StatusBadRequest,
+		},
+	}
+}
+
 type UniquenessError struct {
 	apiError
 }
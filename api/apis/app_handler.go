@@ -26,6 +26,7 @@ const (
 	AppProcessesPath                  = "/v3/apps/{guid}/processes"
 	AppProcessByTypePath              = "/v3/apps/{guid}/processes/{type}"
 	AppProcessScalePath               = "/v3/apps/{guid}/processes/{processType}/actions/scale"
+	AppProcessInstancePath            = "/v3/apps/{guid}/processes/{processType}/instances/{index}"
 	AppRoutesPath                     = "/v3/apps/{guid}/routes"
 	AppStartPath                      = "/v3/apps/{guid}/actions/start"
 	AppStopPath                       = "/v3/apps/{guid}/actions/stop"
@@ -42,8 +43,10 @@ const (
 type CFAppRepository interface {
 	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
 	ListApps(context.Context, authorization.Info, repositories.ListAppsMessage) ([]repositories.AppRecord, error)
+	ListSpaceApps(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.AppRecord, int, error)
 	PatchAppEnvVars(context.Context, authorization.Info, repositories.PatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
+	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
 	SetCurrentDroplet(context.Context, authorization.Info, repositories.SetCurrentDropletMessage) (repositories.CurrentDropletRecord, error)
 	SetAppDesiredState(context.Context, authorization.Info, repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error)
 	DeleteApp(context.Context, authorization.Info, repositories.DeleteAppMessage) error
@@ -53,17 +56,23 @@ type CFAppRepository interface {
 //counterfeiter:generate -o fake -fake-name ScaleAppProcess . ScaleAppProcess
 type ScaleAppProcess func(ctx context.Context, authInfo authorization.Info, appGUID string, processType string, scale repositories.ProcessScaleValues) (repositories.ProcessRecord, error)
 
+//counterfeiter:generate -o fake -fake-name DeleteAppProcessInstance . DeleteAppProcessInstance
+type DeleteAppProcessInstance func(ctx context.Context, authInfo authorization.Info, appGUID string, processType string, index int) error
+
 type AppHandler struct {
-	logger           logr.Logger
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	dropletRepo      CFDropletRepository
-	processRepo      CFProcessRepository
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	spaceRepo        SpaceRepository
-	scaleAppProcess  ScaleAppProcess
-	decoderValidator *DecoderValidator
+	logger                   logr.Logger
+	serverURL                url.URL
+	appRepo                  CFAppRepository
+	dropletRepo              CFDropletRepository
+	processRepo              CFProcessRepository
+	routeRepo                CFRouteRepository
+	domainRepo               CFDomainRepository
+	spaceRepo                SpaceRepository
+	scaleAppProcess          ScaleAppProcess
+	deleteAppProcessInstance DeleteAppProcessInstance
+	jobRunner                JobRunner
+	auditEventRecorder       AuditEventRecorder
+	decoderValidator         *DecoderValidator
 }
 
 func NewAppHandler(
@@ -76,19 +85,25 @@ func NewAppHandler(
 	domainRepo CFDomainRepository,
 	spaceRepo SpaceRepository,
 	scaleAppProcessFunc ScaleAppProcess,
+	deleteAppProcessInstanceFunc DeleteAppProcessInstance,
+	jobRunner JobRunner,
+	auditEventRecorder AuditEventRecorder,
 	decoderValidator *DecoderValidator,
 ) *AppHandler {
 	return &AppHandler{
-		logger:           logger,
-		serverURL:        serverURL,
-		appRepo:          appRepo,
-		dropletRepo:      dropletRepo,
-		processRepo:      processRepo,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		decoderValidator: decoderValidator,
-		spaceRepo:        spaceRepo,
-		scaleAppProcess:  scaleAppProcessFunc,
+		logger:                   logger,
+		serverURL:                serverURL,
+		appRepo:                  appRepo,
+		dropletRepo:              dropletRepo,
+		processRepo:              processRepo,
+		routeRepo:                routeRepo,
+		domainRepo:               domainRepo,
+		decoderValidator:         decoderValidator,
+		spaceRepo:                spaceRepo,
+		scaleAppProcess:          scaleAppProcessFunc,
+		deleteAppProcessInstance: deleteAppProcessInstanceFunc,
+		jobRunner:                jobRunner,
+		auditEventRecorder:       auditEventRecorder,
 	}
 }
 
@@ -126,10 +141,38 @@ func (h *AppHandler) appCreateHandler(authInfo authorization.Info, r *http.Reque
 		h.logger.Error(err, "Failed to create app", "App Name", payload.Name)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.create", repositories.AuditEventTarget{GUID: appRecord.GUID, Type: "app", Name: appRecord.Name}, appRecord.SpaceGUID, payload)
 
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL)), nil
 }
 
+func (h *AppHandler) appPatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	appGUID := vars["guid"]
+
+	var payload payloads.AppPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	updatedApp, err := h.appRepo.PatchApp(ctx, authInfo, payload.ToMessage(appGUID, app.SpaceGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch app", "AppGUID", appGUID)
+		return nil, err
+	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.update", repositories.AuditEventTarget{GUID: updatedApp.GUID, Type: "app", Name: updatedApp.Name}, updatedApp.SpaceGUID, payload)
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForApp(updatedApp, h.serverURL)), nil
+}
+
 func (h *AppHandler) appListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
 	ctx := r.Context()
 
@@ -160,7 +203,30 @@ func (h *AppHandler) appListHandler(authInfo authorization.Info, r *http.Request
 		}
 	}
 
-	appList, err := h.appRepo.ListApps(ctx, authInfo, appListFilter.ToMessage())
+	if err = appListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = appListFilter.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
+	message := appListFilter.ToMessage()
+
+	// the apps of a single space are paginated by the Kubernetes API, unless they have to be filtered in memory
+	if len(message.SpaceGuids) == 1 && len(message.Names) == 0 && len(message.Guids) == 0 {
+		appList, totalResults, err := h.appRepo.ListSpaceApps(ctx, authInfo, message.SpaceGuids[0], message.LabelSelector, appListFilter.ToPage())
+		if err != nil {
+			h.logger.Error(err, "Failed to fetch app(s) from Kubernetes")
+			return nil, err
+		}
+
+		return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAppListPage(appList, totalResults, h.serverURL, *r.URL)), nil
+	}
+
+	appList, err := h.appRepo.ListApps(ctx, authInfo, message)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch app(s) from Kubernetes")
 		return nil, err
@@ -205,6 +271,7 @@ func (h *AppHandler) appSetCurrentDropletHandler(authInfo authorization.Info, r
 		h.logger.Error(err, "Error setting current droplet")
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.droplet.mapped", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, payload)
 
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForCurrentDroplet(currentDroplet, h.serverURL)), nil
 }
@@ -259,6 +326,7 @@ func (h *AppHandler) appStartHandler(authInfo authorization.Info, r *http.Reques
 		h.logger.Error(err, "Failed to update app in Kubernetes", "AppGUID", appGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.start", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, nil)
 
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -284,6 +352,7 @@ func (h *AppHandler) appStopHandler(authInfo authorization.Info, r *http.Request
 		h.logger.Error(err, "Failed to update app in Kubernetes", "AppGUID", appGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.stop", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, nil)
 
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -294,24 +363,25 @@ func (h *AppHandler) getProcessesForAppHandler(authInfo authorization.Info, r *h
 	vars := mux.Vars(r)
 	appGUID := vars["guid"]
 
+	pagination := payloads.PaginationFromQuery(r.URL.Query())
+	if err := pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
 		return nil, apierrors.ForbiddenAsNotFound(err)
 	}
 
-	fetchProcessesForAppMessage := repositories.ListProcessesMessage{
-		AppGUIDs:  []string{appGUID},
-		SpaceGUID: app.SpaceGUID,
-	}
-
-	processList, err := h.processRepo.ListProcesses(ctx, authInfo, fetchProcessesForAppMessage)
+	processList, totalResults, err := h.processRepo.ListAppProcesses(ctx, authInfo, app.SpaceGUID, appGUID, pagination.ToPage())
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch app Process(es) from Kubernetes")
 		return nil, err
 	}
 
-	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForProcessList(processList, h.serverURL, *r.URL)), nil
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForProcessListPage(processList, totalResults, h.serverURL, *r.URL)), nil
 }
 
 func (h *AppHandler) getRoutesForAppHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
@@ -320,6 +390,11 @@ func (h *AppHandler) getRoutesForAppHandler(authInfo authorization.Info, r *http
 	vars := mux.Vars(r)
 	appGUID := vars["guid"]
 
+	if err := payloads.PaginationFromQuery(r.URL.Query()).Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
@@ -356,6 +431,27 @@ func (h *AppHandler) appScaleProcessHandler(authInfo authorization.Info, r *http
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForProcess(processRecord, h.serverURL)), nil
 }
 
+func (h *AppHandler) appDeleteProcessInstanceHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	appGUID := vars["guid"]
+	processType := vars["processType"]
+
+	index, err := parseInstanceIndex(vars["index"])
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.deleteAppProcessInstance(ctx, authInfo, appGUID, processType, index)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete app process instance", "AppGUID", appGUID, "ProcessType", processType, "Index", index)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
 func (h *AppHandler) appRestartHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 
@@ -394,6 +490,7 @@ func (h *AppHandler) appRestartHandler(authInfo authorization.Info, r *http.Requ
 		h.logger.Error(err, "Failed to update app in Kubernetes", "AppGUID", appGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.restart", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, nil)
 
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -417,8 +514,21 @@ func (h *AppHandler) appDeleteHandler(authInfo authorization.Info, r *http.Reque
 		h.logger.Error(err, "Failed to delete app", "AppGUID", appGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.delete-request", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, nil)
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.AppDeleteJobOperation,
+		ResourceGUID: appGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start app delete job", "AppGUID", appGUID)
+		return nil, err
+	}
 
-	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/app.delete-%s", h.serverURL.String(), appGUID)), nil
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
 }
 
 func (h *AppHandler) lookupAppRouteAndDomainList(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) ([]repositories.RouteRecord, error) {
@@ -451,6 +561,7 @@ func (h *AppHandler) appPatchEnvVarsHandler(authInfo authorization.Info, r *http
 		h.logger.Error(err, "Error updating app environment variables")
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.app.update", repositories.AuditEventTarget{GUID: app.GUID, Type: "app", Name: app.Name}, app.SpaceGUID, payload)
 
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAppEnvVars(envVarsRecord, h.serverURL)), nil
 }
@@ -495,12 +606,14 @@ func (h *AppHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(AppPath).Methods("GET").HandlerFunc(w.Wrap(h.appGetHandler))
 	router.Path(AppsPath).Methods("GET").HandlerFunc(w.Wrap(h.appListHandler))
 	router.Path(AppsPath).Methods("POST").HandlerFunc(w.Wrap(h.appCreateHandler))
+	router.Path(AppPath).Methods("PATCH").HandlerFunc(w.Wrap(h.appPatchHandler))
 	router.Path(AppCurrentDropletRelationshipPath).Methods("PATCH").HandlerFunc(w.Wrap(h.appSetCurrentDropletHandler))
 	router.Path(AppCurrentDropletPath).Methods("GET").HandlerFunc(w.Wrap(h.appGetCurrentDropletHandler))
 	router.Path(AppStartPath).Methods("POST").HandlerFunc(w.Wrap(h.appStartHandler))
 	router.Path(AppStopPath).Methods("POST").HandlerFunc(w.Wrap(h.appStopHandler))
 	router.Path(AppRestartPath).Methods("POST").HandlerFunc(w.Wrap(h.appRestartHandler))
 	router.Path(AppProcessScalePath).Methods("POST").HandlerFunc(w.Wrap(h.appScaleProcessHandler))
+	router.Path(AppProcessInstancePath).Methods("DELETE").HandlerFunc(w.Wrap(h.appDeleteProcessInstanceHandler))
 	router.Path(AppProcessesPath).Methods("GET").HandlerFunc(w.Wrap(h.getProcessesForAppHandler))
 	router.Path(AppProcessByTypePath).Methods("GET").HandlerFunc(w.Wrap(h.getProcessByTypeForAppHander))
 	router.Path(AppRoutesPath).Methods("GET").HandlerFunc(w.Wrap(h.getRoutesForAppHandler))
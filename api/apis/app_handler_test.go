@@ -12,10 +12,12 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	. "code.cloudfoundry.org/korifi/api/apis"
 	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -28,14 +30,17 @@ const (
 
 var _ = Describe("AppHandler", func() {
 	var (
-		appRepo             *fake.CFAppRepository
-		dropletRepo         *fake.CFDropletRepository
-		processRepo         *fake.CFProcessRepository
-		routeRepo           *fake.CFRouteRepository
-		scaleAppProcessFunc *fake.ScaleAppProcess
-		domainRepo          *fake.CFDomainRepository
-		spaceRepo           *fake.SpaceRepository
-		req                 *http.Request
+		appRepo                  *fake.CFAppRepository
+		dropletRepo              *fake.CFDropletRepository
+		processRepo              *fake.CFProcessRepository
+		routeRepo                *fake.CFRouteRepository
+		scaleAppProcessFunc      *fake.ScaleAppProcess
+		deleteAppProcessInstance *fake.DeleteAppProcessInstance
+		domainRepo               *fake.CFDomainRepository
+		spaceRepo                *fake.SpaceRepository
+		jobRunner                *fake.JobRunner
+		auditEventRecorder       *fake.AuditEventRecorder
+		req                      *http.Request
 	)
 
 	BeforeEach(func() {
@@ -45,7 +50,10 @@ var _ = Describe("AppHandler", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		scaleAppProcessFunc = new(fake.ScaleAppProcess)
+		deleteAppProcessInstance = new(fake.DeleteAppProcessInstance)
 		spaceRepo = new(fake.SpaceRepository)
+		jobRunner = new(fake.JobRunner)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -59,6 +67,9 @@ var _ = Describe("AppHandler", func() {
 			domainRepo,
 			spaceRepo,
 			scaleAppProcessFunc.Spy,
+			deleteAppProcessInstance.Spy,
+			jobRunner,
+			auditEventRecorder,
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -326,6 +337,42 @@ var _ = Describe("AppHandler", func() {
 			})
 		})
 
+		When("the app has the docker lifecycle", func() {
+			BeforeEach(func() {
+				queuePostRequest(`{
+					"name": "test-app",
+					"lifecycle": { "type": "docker", "data": {} },
+					"relationships": { "space": { "data": { "guid": "0c78dd5d-c723-4f2e-b168-df3c3e1d0806" } } }
+				}`)
+			})
+
+			It("does not require buildpacks or a stack", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(appRepo.CreateAppCallCount()).To(Equal(1))
+				_, _, createMessage := appRepo.CreateAppArgsForCall(0)
+				Expect(createMessage.Lifecycle.Type).To(Equal("docker"))
+			})
+		})
+
+		When("the app is created", func() {
+			BeforeEach(func() {
+				appRepo.CreateAppReturns(repositories.AppRecord{GUID: appGUID, Name: testAppName, SpaceGUID: spaceGUID}, nil)
+				requestBody := initializeCreateAppRequestBody(testAppName, spaceGUID, nil, nil, nil)
+				queuePostRequest(requestBody)
+			})
+
+			It("records an audit.app.create event with the request", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, actualAuthInfo, eventType, target, eventSpaceGUID, eventRequest := auditEventRecorder.RecordArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(eventType).To(Equal("audit.app.create"))
+				Expect(target).To(Equal(repositories.AuditEventTarget{GUID: appGUID, Type: "app", Name: testAppName}))
+				Expect(eventSpaceGUID).To(Equal(spaceGUID))
+				Expect(eventRequest).To(BeAssignableToTypeOf(payloads.AppCreate{}))
+			})
+		})
+
 		When("the space does not exist", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
@@ -349,6 +396,10 @@ var _ = Describe("AppHandler", func() {
 			It("returns an error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record an audit event", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(BeZero())
+			})
 		})
 	})
 
@@ -405,10 +456,10 @@ var _ = Describe("AppHandler", func() {
 				  "total_results": 2,
 				  "total_pages": 1,
 				  "first": {
-					"href": "%[1]s/v3/apps"
+					"href": "%[1]s/v3/apps?page=1&per_page=50"
 				  },
 				  "last": {
-					"href": "%[1]s/v3/apps"
+					"href": "%[1]s/v3/apps?page=1&per_page=50"
 				  },
 				  "next": null,
 				  "previous": null
@@ -592,6 +643,131 @@ var _ = Describe("AppHandler", func() {
 					Expect(rr.Body.String()).To(ContainSubstring("https://api.example.org/v3/apps?names=app1,app2&space_guids=space1,space2"))
 				})
 			})
+
+			When("a single space_guid and no other filter is provided", func() {
+				BeforeEach(func() {
+					appRepo.ListSpaceAppsReturns([]repositories.AppRecord{{GUID: "second-test-app-guid", SpaceGUID: "space1"}}, 3, nil)
+
+					var err error
+					req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?space_guids=space1&label_selector=env%3Dprod&page=2&per_page=1", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("lists a page of the apps of the space", func() {
+					Expect(appRepo.ListAppsCallCount()).To(Equal(0))
+					Expect(appRepo.ListSpaceAppsCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualSpaceGUID, actualLabelSelector, actualPage := appRepo.ListSpaceAppsArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualSpaceGUID).To(Equal("space1"))
+					Expect(actualLabelSelector).To(Equal("env=prod"))
+					Expect(actualPage).To(Equal(repositories.Page{Number: 2, PerPage: 1}))
+				})
+
+				It("paginates the response with the total number of apps", func() {
+					Expect(rr.Code).To(Equal(http.StatusOK))
+					response := map[string]interface{}{}
+					Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+					Expect(response).To(HaveKeyWithValue("pagination", MatchKeys(IgnoreExtras, Keys{
+						"total_results": BeNumerically("==", 3),
+						"total_pages":   BeNumerically("==", 3),
+					})))
+					Expect(response).To(HaveKeyWithValue("resources", ConsistOf(
+						HaveKeyWithValue("guid", "second-test-app-guid"),
+					)))
+				})
+
+				When("listing the apps of the space fails", func() {
+					BeforeEach(func() {
+						appRepo.ListSpaceAppsReturns(nil, 0, errors.New("boom"))
+					})
+
+					It("returns an unknown error", func() {
+						expectUnknownError()
+					})
+				})
+			})
+
+			When("a label_selector is provided", func() {
+				BeforeEach(func() {
+					var err error
+					req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?label_selector=env%3Dprod,example.com/team", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("passes it to the repository", func() {
+					Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+					_, _, message := appRepo.ListAppsArgsForCall(0)
+					Expect(message.LabelSelector).To(Equal("env=prod,example.com/team"))
+				})
+			})
+
+			When("pagination query params are provided", func() {
+				BeforeEach(func() {
+					var err error
+					req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?names=app1&page=2&per_page=1", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns the requested page with links to the other pages", func() {
+					response := map[string]interface{}{}
+					Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+					Expect(response).To(HaveKeyWithValue("pagination", MatchAllKeys(Keys{
+						"total_results": BeNumerically("==", 2),
+						"total_pages":   BeNumerically("==", 2),
+						"first":         HaveKeyWithValue("href", "https://api.example.org/v3/apps?names=app1&page=1&per_page=1"),
+						"last":          HaveKeyWithValue("href", "https://api.example.org/v3/apps?names=app1&page=2&per_page=1"),
+						"next":          BeNil(),
+						"previous":      HaveKeyWithValue("href", "https://api.example.org/v3/apps?names=app1&page=1&per_page=1"),
+					})))
+					Expect(response).To(HaveKeyWithValue("resources", ConsistOf(
+						HaveKeyWithValue("guid", "second-test-app-guid"),
+					)))
+				})
+			})
+		})
+
+		When("the page query parameter is invalid", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?page=0", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Page must be greater than 0")
+			})
+
+			It("does not list the apps", func() {
+				Expect(appRepo.ListAppsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the label_selector query parameter is invalid", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?label_selector=env%20in%20prod", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Invalid label_selector value")
+			})
+
+			It("does not list the apps", func() {
+				Expect(appRepo.ListAppsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the per_page query parameter is too large", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps?per_page=5001", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Per page must be between 1 and 5000")
+			})
 		})
 
 		When("no apps can be found", func() {
@@ -614,10 +790,10 @@ var _ = Describe("AppHandler", func() {
 				  "total_results": 0,
 				  "total_pages": 1,
 				  "first": {
-					"href": "%[1]s/v3/apps"
+					"href": "%[1]s/v3/apps?page=1&per_page=50"
 				  },
 				  "last": {
-					"href": "%[1]s/v3/apps"
+					"href": "%[1]s/v3/apps?page=1&per_page=50"
 				  },
 				  "next": null,
 				  "previous": null
@@ -645,7 +821,168 @@ var _ = Describe("AppHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, guids, space_guids, order_by'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, guids, space_guids, order_by, label_selector, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/apps/:guid endpoint", func() {
+		makePatchRequest := func(requestBody string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/apps/"+appGUID, strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				Name:      appName,
+				SpaceGUID: spaceGUID,
+			}, nil)
+			appRepo.PatchAppReturns(repositories.AppRecord{
+				GUID:        appGUID,
+				Name:        "new-name",
+				SpaceGUID:   spaceGUID,
+				State:       "STOPPED",
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{"example.com/owner": "team-a"},
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{
+						Buildpacks: []string{"java_buildpack"},
+						Stack:      "cflinuxfs3",
+					},
+				},
+			}, nil)
+
+			makePatchRequest(`{
+				"name": "new-name",
+				"lifecycle": {
+					"type": "buildpack",
+					"data": {
+						"buildpacks": ["java_buildpack"],
+						"stack": "cflinuxfs3"
+					}
+				},
+				"metadata": {
+					"labels": {"env": "prod", "deprecated": null},
+					"annotations": {"example.com/owner": "team-a"}
+				}
+			}`)
+		})
+
+		It("patches the app in its space", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUID).To(Equal(appGUID))
+			Expect(message.SpaceGUID).To(Equal(spaceGUID))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+			Expect(message.Buildpacks).To(PointTo(Equal([]string{"java_buildpack"})))
+			Expect(message.Stack).To(PointTo(Equal("cflinuxfs3")))
+			Expect(message.MetadataPatch.Labels).To(MatchAllKeys(Keys{
+				"env":        PointTo(Equal("prod")),
+				"deprecated": BeNil(),
+			}))
+			Expect(message.MetadataPatch.Annotations).To(MatchAllKeys(Keys{
+				"example.com/owner": PointTo(Equal("team-a")),
+			}))
+		})
+
+		It("records an audit.app.update event", func() {
+			Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+			_, _, eventType, target, _, _ := auditEventRecorder.RecordArgsForCall(0)
+			Expect(eventType).To(Equal("audit.app.update"))
+			Expect(target).To(Equal(repositories.AuditEventTarget{GUID: appGUID, Type: "app", Name: "new-name"}))
+		})
+
+		It("returns the updated app", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+			response := map[string]interface{}{}
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response).To(HaveKeyWithValue("name", "new-name"))
+			Expect(response).To(HaveKeyWithValue("lifecycle", HaveKeyWithValue("data", HaveKeyWithValue("buildpacks", ConsistOf("java_buildpack")))))
+			Expect(response).To(HaveKeyWithValue("metadata", Equal(map[string]interface{}{
+				"labels":      map[string]interface{}{"env": "prod"},
+				"annotations": map[string]interface{}{"example.com/owner": "team-a"},
+			})))
+		})
+
+		When("only the metadata is patched", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"metadata": {"labels": {"env": "prod"}}}`)
+			})
+
+			It("leaves the other fields unchanged", func() {
+				_, _, message := appRepo.PatchAppArgsForCall(0)
+				Expect(message.Name).To(BeNil())
+				Expect(message.Buildpacks).To(BeNil())
+				Expect(message.Stack).To(BeNil())
+			})
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"name": ""}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the request body has an unknown field", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"state": "STARTED"}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`invalid request body: json: unknown field "state"`)
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+				Expect(appRepo.PatchAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("another app in the space already has the name", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, apierrors.NewUniquenessError(nil, "App with the name 'new-name' already exists."))
+			})
+
+			It("returns a uniqueness error", func() {
+				expectJSONResponse(http.StatusUnprocessableEntity, `{
+					"errors": [
+						{
+							"detail": "App with the name 'new-name' already exists.",
+							"title": "CF-UniquenessError",
+							"code": 10016
+						}
+					]
+				}`)
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
 			})
 		})
 	})
@@ -861,6 +1198,13 @@ var _ = Describe("AppHandler", func() {
 		})
 
 		When("on the happy path", func() {
+			It("records an audit.app.start event", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, _, eventType, target, _, _ := auditEventRecorder.RecordArgsForCall(0)
+				Expect(eventType).To(Equal("audit.app.start"))
+				Expect(target.GUID).To(Equal(appGUID))
+			})
+
 			It("returns status 200 OK", func() {
 				Expect(rr.Code).To(Equal(http.StatusOK), "Matching HTTP response code:")
 			})
@@ -1364,6 +1708,7 @@ var _ = Describe("AppHandler", func() {
 				CreatedAt:   "2016-03-23T18:48:22Z",
 				UpdatedAt:   "2016-03-23T18:48:42Z",
 			}
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
 			processRecord2 := processRecord
 			processRecord2.GUID = "process-2-guid"
 			processRecord2.Type = "worker"
@@ -1372,10 +1717,10 @@ var _ = Describe("AppHandler", func() {
 
 			process1Record = &processRecord
 			process2Record = &processRecord2
-			processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			processRepo.ListAppProcessesReturns([]repositories.ProcessRecord{
 				processRecord,
 				processRecord2,
-			}, nil)
+			}, 2, nil)
 
 			var err error
 			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/"+appGUID+"/processes", nil)
@@ -1387,6 +1732,15 @@ var _ = Describe("AppHandler", func() {
 					Expect(rr.Code).To(Equal(http.StatusOK), "Matching HTTP response code:")
 				})
 
+				It("lists the first page of the processes of the app in its space", func() {
+					Expect(processRepo.ListAppProcessesCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualSpaceGUID, actualAppGUID, actualPage := processRepo.ListAppProcessesArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualSpaceGUID).To(Equal(spaceGUID))
+					Expect(actualAppGUID).To(Equal(appGUID))
+					Expect(actualPage).To(Equal(repositories.Page{Number: 1, PerPage: 50}))
+				})
+
 				It("returns the Processes in the response", func() {
 					contentTypeHeader := rr.Header().Get("Content-Type")
 					Expect(contentTypeHeader).To(Equal(jsonHeader), "Matching Content-Type header:")
@@ -1396,10 +1750,10 @@ var _ = Describe("AppHandler", func() {
 						  "total_results": 2,
 						  "total_pages": 1,
 						  "first": {
-							"href": "%[1]s/v3/apps/%[2]s/processes"
+							"href": "%[1]s/v3/apps/%[2]s/processes?page=1&per_page=50"
 						  },
 						  "last": {
-							"href": "%[1]s/v3/apps/%[2]s/processes"
+							"href": "%[1]s/v3/apps/%[2]s/processes?page=1&per_page=50"
 						  },
 						  "next": null,
 						  "previous": null
@@ -1501,9 +1855,41 @@ var _ = Describe("AppHandler", func() {
 				})
 			})
 
+			When("a later page is requested", func() {
+				BeforeEach(func() {
+					processRepo.ListAppProcessesReturns([]repositories.ProcessRecord{*process2Record}, 3, nil)
+
+					var err error
+					req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/"+appGUID+"/processes?page=2&per_page=1", nil)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("lists that page of the processes", func() {
+					Expect(processRepo.ListAppProcessesCallCount()).To(Equal(1))
+					_, _, _, _, actualPage := processRepo.ListAppProcessesArgsForCall(0)
+					Expect(actualPage).To(Equal(repositories.Page{Number: 2, PerPage: 1}))
+				})
+
+				It("paginates the response with the total number of processes", func() {
+					response := map[string]interface{}{}
+					Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+					Expect(response).To(HaveKeyWithValue("pagination", MatchAllKeys(Keys{
+						"total_results": BeNumerically("==", 3),
+						"total_pages":   BeNumerically("==", 3),
+						"first":         HaveKeyWithValue("href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=1&per_page=1"),
+						"last":          HaveKeyWithValue("href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=3&per_page=1"),
+						"next":          HaveKeyWithValue("href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=3&per_page=1"),
+						"previous":      HaveKeyWithValue("href", "https://api.example.org/v3/apps/"+appGUID+"/processes?page=1&per_page=1"),
+					})))
+					Expect(response).To(HaveKeyWithValue("resources", ConsistOf(
+						HaveKeyWithValue("guid", process2Record.GUID),
+					)))
+				})
+			})
+
 			When("The App does not have associated processes", func() {
 				BeforeEach(func() {
-					processRepo.ListProcessesReturns([]repositories.ProcessRecord{}, nil)
+					processRepo.ListAppProcessesReturns([]repositories.ProcessRecord{}, 0, nil)
 				})
 
 				It("returns status 200 OK", func() {
@@ -1519,10 +1905,10 @@ var _ = Describe("AppHandler", func() {
 						  "total_results": 0,
 						  "total_pages": 1,
 						  "first": {
-							"href": "%[1]s/v3/apps/%[2]s/processes"
+							"href": "%[1]s/v3/apps/%[2]s/processes?page=1&per_page=50"
 						  },
 						  "last": {
-							"href": "%[1]s/v3/apps/%[2]s/processes"
+							"href": "%[1]s/v3/apps/%[2]s/processes?page=1&per_page=50"
 						  },
 						  "next": null,
 						  "previous": null
@@ -1555,7 +1941,7 @@ var _ = Describe("AppHandler", func() {
 			})
 			When("there is some error fetching the app's processes", func() {
 				BeforeEach(func() {
-					processRepo.ListProcessesReturns([]repositories.ProcessRecord{}, errors.New("unknown!"))
+					processRepo.ListAppProcessesReturns(nil, 0, errors.New("unknown!"))
 				})
 
 				It("returns an error", func() {
@@ -2034,10 +2420,10 @@ var _ = Describe("AppHandler", func() {
 							"total_results": 1,
 							"total_pages": 1,
 							"first": {
-								"href": "%[1]s/v3/apps/%[2]s/routes"
+								"href": "%[1]s/v3/apps/%[2]s/routes?page=1&per_page=50"
 							},
 							"last": {
-								"href": "%[1]s/v3/apps/%[2]s/routes"
+								"href": "%[1]s/v3/apps/%[2]s/routes?page=1&per_page=50"
 							},
 							"next": null,
 							"previous": null
@@ -2107,10 +2493,10 @@ var _ = Describe("AppHandler", func() {
 						  "total_results": 0,
 						  "total_pages": 1,
 						  "first": {
-							"href": "%[1]s/v3/apps/%[2]s/routes"
+							"href": "%[1]s/v3/apps/%[2]s/routes?page=1&per_page=50"
 						  },
 						  "last": {
-							"href": "%[1]s/v3/apps/%[2]s/routes"
+							"href": "%[1]s/v3/apps/%[2]s/routes?page=1&per_page=50"
 						  },
 						  "next": null,
 						  "previous": null
@@ -2637,6 +3023,71 @@ var _ = Describe("AppHandler", func() {
 		})
 	})
 
+	Describe("the DELETE /v3/apps/:guid/processes/:processType/instances/:index endpoint", func() {
+		makeDeleteInstanceRequest := func(index string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/apps/"+appGUID+"/processes/web/instances/"+index, nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			makeDeleteInstanceRequest("0")
+		})
+
+		It("deletes the app process instance and responds with 204 No Content", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+			Expect(rr.Body.String()).To(BeEmpty())
+
+			Expect(deleteAppProcessInstance.CallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID, actualProcessType, actualIndex := deleteAppProcessInstance.ArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
+			Expect(actualProcessType).To(Equal("web"))
+			Expect(actualIndex).To(Equal(0))
+		})
+
+		When("the index is not a number", func() {
+			BeforeEach(func() {
+				makeDeleteInstanceRequest("zero")
+			})
+
+			It("returns a not found error without deleting anything", func() {
+				expectNotFoundError("Instance not found")
+				Expect(deleteAppProcessInstance.CallCount()).To(BeZero())
+			})
+		})
+
+		When("the app or process cannot be found", func() {
+			BeforeEach(func() {
+				deleteAppProcessInstance.Returns(apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+			})
+		})
+
+		When("the user is not allowed to delete the instance", func() {
+			BeforeEach(func() {
+				deleteAppProcessInstance.Returns(apierrors.NewForbiddenError(nil, repositories.InstanceResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("deleting the instance fails", func() {
+			BeforeEach(func() {
+				deleteAppProcessInstance.Returns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the DELETE /v3/apps/:guid endpoint", func() {
 		var app repositories.AppRecord
 
@@ -2644,6 +3095,7 @@ var _ = Describe("AppHandler", func() {
 			app = repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}
 
 			appRepo.GetAppReturns(app, nil)
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
 
 			var err error
 			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/apps/"+appGUID, nil)
@@ -2657,7 +3109,23 @@ var _ = Describe("AppHandler", func() {
 
 			It("responds with a job URL in a location header", func() {
 				locationHeader := rr.Header().Get("Location")
-				Expect(locationHeader).To(Equal("https://api.example.org/v3/jobs/app.delete-"+appGUID), "Matching Location header")
+				Expect(locationHeader).To(Equal("https://api.example.org/v3/jobs/job-guid"), "Matching Location header")
+			})
+
+			It("starts an app.delete job that waits for the app to be gone", func() {
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(1))
+				_, jobAuthInfo, message, getResource := jobRunner.StartDeletionArgsForCall(0)
+				Expect(jobAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    "app.delete",
+					ResourceGUID: appGUID,
+				}))
+
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+				Expect(getResource(ctx)).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(1)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualAppGUID).To(Equal(appGUID))
 			})
 
 			It("fetches the right App", func() {
@@ -2672,6 +3140,15 @@ var _ = Describe("AppHandler", func() {
 				Expect(message.AppGUID).To(Equal(appGUID))
 				Expect(message.SpaceGUID).To(Equal(spaceGUID))
 			})
+
+			It("records an audit.app.delete-request event", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, _, eventType, target, eventSpaceGUID, eventRequest := auditEventRecorder.RecordArgsForCall(0)
+				Expect(eventType).To(Equal("audit.app.delete-request"))
+				Expect(target.GUID).To(Equal(appGUID))
+				Expect(eventSpaceGUID).To(Equal(spaceGUID))
+				Expect(eventRequest).To(BeNil())
+			})
 		})
 
 		When("fetching the App errors", func() {
@@ -2692,6 +3169,20 @@ var _ = Describe("AppHandler", func() {
 			It("returns an error", func() {
 				expectUnknownError()
 			})
+
+			It("does not start a job", func() {
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(0))
+			})
+		})
+
+		When("starting the delete job errors", func() {
+			BeforeEach(func() {
+				jobRunner.StartDeletionReturns(repositories.JobRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 
@@ -2820,6 +3311,14 @@ var _ = Describe("AppHandler", func() {
 					key2: &value2,
 				}))
 			})
+
+			It("records an audit.app.update event with the request", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, _, eventType, target, _, eventRequest := auditEventRecorder.RecordArgsForCall(0)
+				Expect(eventType).To(Equal("audit.app.update"))
+				Expect(target.GUID).To(Equal(appGUID))
+				Expect(eventRequest).To(BeAssignableToTypeOf(payloads.AppPatchEnvVars{}))
+			})
 		})
 
 		When("the validating env vars", func() {
@@ -0,0 +1,103 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+type CFAuditEventRepository interface {
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventRecorder . AuditEventRecorder
+
+// AuditEventRecorder records the actions users take, which are then reported by the /v3/audit_events endpoint.
+// Handlers record an action once it has succeeded, passing the space of the target, if any, and the request payload.
+type AuditEventRecorder interface {
+	Record(ctx context.Context, authInfo authorization.Info, eventType string, target repositories.AuditEventTarget, spaceGUID string, request interface{})
+}
+
+type AuditEventHandler struct {
+	logger         logr.Logger
+	serverURL      url.URL
+	auditEventRepo CFAuditEventRepository
+}
+
+func NewAuditEventHandler(logger logr.Logger, serverURL url.URL, auditEventRepo CFAuditEventRepository) *AuditEventHandler {
+	return &AuditEventHandler{
+		logger:         logger,
+		serverURL:      serverURL,
+		auditEventRepo: auditEventRepo,
+	}
+}
+
+func (h *AuditEventHandler) auditEventListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.AuditEventList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in AuditEvent filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Validate(); err != nil {
+		h.logger.Info("Invalid audit event query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list audit events")
+		return nil, err
+	}
+
+	if listFilter.Descending() {
+		for i, j := 0, len(auditEvents)-1; i < j; i, j = i+1, j-1 {
+			auditEvents[i], auditEvents[j] = auditEvents[j], auditEvents[i]
+		}
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForAuditEventList(auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEventHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(AuditEventsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.auditEventListHandler))
+}
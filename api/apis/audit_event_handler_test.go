@@ -0,0 +1,177 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("AuditEventHandler", func() {
+	var (
+		auditEventRepo *fake.CFAuditEventRepository
+		requestPath    string
+	)
+
+	BeforeEach(func() {
+		requestPath = "/v3/audit_events"
+
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+			{
+				GUID:             "event-1",
+				Type:             "audit.app.update",
+				Actor:            repositories.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+				Target:           repositories.AuditEventTarget{GUID: "app-guid", Type: "app", Name: "my-app"},
+				SpaceGUID:        "space-guid",
+				OrganizationGUID: "org-guid",
+				Data: map[string]interface{}{
+					"request": map[string]interface{}{"environment_variables": "[PRIVATE DATA HIDDEN]"},
+				},
+				CreatedAt: "2022-04-01T12:00:00Z",
+				UpdatedAt: "2022-04-01T12:00:00Z",
+			},
+			{
+				GUID:             "event-2",
+				Type:             "audit.organization.create",
+				Actor:            repositories.AuditEventActor{GUID: "bob", Type: "user", Name: "bob"},
+				Target:           repositories.AuditEventTarget{GUID: "org-guid", Type: "organization", Name: "my-org"},
+				OrganizationGUID: "org-guid",
+				CreatedAt:        "2022-04-01T12:00:05Z",
+				UpdatedAt:        "2022-04-01T12:00:05Z",
+			},
+		}, nil)
+
+		handler := apis.NewAuditEventHandler(
+			logf.Log.WithName("TestAuditEventHandler"),
+			*serverURL,
+			auditEventRepo,
+		)
+		handler.RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, "GET", requestPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		router.ServeHTTP(rr, req)
+	})
+
+	It("lists the audit events visible to the user", func() {
+		Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+		_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(message).To(Equal(repositories.ListAuditEventsMessage{
+			Types:       []string{},
+			TargetGUIDs: []string{},
+			SpaceGUIDs:  []string{},
+		}))
+	})
+
+	It("presents the audit events", func() {
+		expectJSONResponse(http.StatusOK, `{
+			"pagination": {
+				"total_results": 2,
+				"total_pages": 1,
+				"first": {"href": "https://api.example.org/v3/audit_events?page=1&per_page=50"},
+				"last": {"href": "https://api.example.org/v3/audit_events?page=1&per_page=50"},
+				"next": null,
+				"previous": null
+			},
+			"resources": [
+				{
+					"guid": "event-1",
+					"created_at": "2022-04-01T12:00:00Z",
+					"updated_at": "2022-04-01T12:00:00Z",
+					"type": "audit.app.update",
+					"actor": {"guid": "alice", "type": "user", "name": "alice"},
+					"target": {"guid": "app-guid", "type": "app", "name": "my-app"},
+					"data": {"request": {"environment_variables": "[PRIVATE DATA HIDDEN]"}},
+					"space": {"guid": "space-guid"},
+					"organization": {"guid": "org-guid"}
+				},
+				{
+					"guid": "event-2",
+					"created_at": "2022-04-01T12:00:05Z",
+					"updated_at": "2022-04-01T12:00:05Z",
+					"type": "audit.organization.create",
+					"actor": {"guid": "bob", "type": "user", "name": "bob"},
+					"target": {"guid": "org-guid", "type": "organization", "name": "my-org"},
+					"data": {},
+					"space": null,
+					"organization": {"guid": "org-guid"}
+				}
+			]
+		}`)
+	})
+
+	When("filters are provided", func() {
+		BeforeEach(func() {
+			requestPath += "?types=audit.app.update,audit.app.start&target_guids=app-guid&space_guids=space-guid" +
+				"&created_ats=2022-04-01T12:00:00Z&created_ats[gt]=2022-03-01T00:00:00Z&created_ats[lte]=2022-05-01T00:00:00Z"
+		})
+
+		It("passes them to the repository", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, _, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(message.Types).To(ConsistOf("audit.app.update", "audit.app.start"))
+			Expect(message.TargetGUIDs).To(ConsistOf("app-guid"))
+			Expect(message.SpaceGUIDs).To(ConsistOf("space-guid"))
+			Expect(message.CreatedAts.Equals).To(ConsistOf(time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)))
+			Expect(message.CreatedAts.GreaterThan).To(PointTo(Equal(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))))
+			Expect(message.CreatedAts.LessThanOrEqual).To(PointTo(Equal(time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC))))
+			Expect(message.CreatedAts.GreaterThanOrEqual).To(BeNil())
+			Expect(message.CreatedAts.LessThan).To(BeNil())
+		})
+	})
+
+	When("the events are ordered by descending creation time", func() {
+		BeforeEach(func() {
+			requestPath += "?order_by=-created_at"
+		})
+
+		It("lists the newest event first", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchRegexp(`(?s)"guid":"event-2".*"guid":"event-1"`))
+		})
+	})
+
+	When("a created_ats value is not a timestamp", func() {
+		BeforeEach(func() {
+			requestPath += "?created_ats[lt]=yesterday"
+		})
+
+		It("returns a bad query parameter error", func() {
+			expectUnknownKeyError(`The query parameter is invalid: Invalid created_ats value "yesterday": timestamps must be in RFC3339 format`)
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(BeZero())
+		})
+	})
+
+	When("an unknown query parameter is provided", func() {
+		BeforeEach(func() {
+			requestPath += "?foo=bar"
+		})
+
+		It("returns an unknown key error", func() {
+			expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'types, target_guids, space_guids, created_ats, order_by, page, per_page'")
+		})
+	})
+
+	When("listing the audit events fails", func() {
+		BeforeEach(func() {
+			auditEventRepo.ListAuditEventsReturns(nil, errors.New("boom"))
+		})
+
+		It("returns an unknown error", func() {
+			expectUnknownError()
+		})
+	})
+})
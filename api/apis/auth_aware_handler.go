@@ -40,11 +40,23 @@ func (r *HandlerResponse) WithBody(body interface{}) *HandlerResponse {
 type AuthAwareHandlerFunc func(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error)
 
 type AuthAwareHandlerFuncWrapper struct {
-	logger logr.Logger
+	logger       logr.Logger
+	presentError func(http.ResponseWriter, error)
 }
 
 func NewAuthAwareHandlerFuncWrapper(logger logr.Logger) *AuthAwareHandlerFuncWrapper {
-	return &AuthAwareHandlerFuncWrapper{logger: logger}
+	return &AuthAwareHandlerFuncWrapper{
+		logger:       logger,
+		presentError: presentError,
+	}
+}
+
+// NewLogCacheAuthAwareHandlerFuncWrapper returns a wrapper that presents errors in the log-cache format
+func NewLogCacheAuthAwareHandlerFuncWrapper(logger logr.Logger) *AuthAwareHandlerFuncWrapper {
+	return &AuthAwareHandlerFuncWrapper{
+		logger:       logger,
+		presentError: presentLogCacheError,
+	}
 }
 
 func (wrapper *AuthAwareHandlerFuncWrapper) Wrap(delegate AuthAwareHandlerFunc) http.HandlerFunc {
@@ -52,14 +64,14 @@ func (wrapper *AuthAwareHandlerFuncWrapper) Wrap(delegate AuthAwareHandlerFunc)
 		authInfo, ok := authorization.InfoFromContext(r.Context())
 		if !ok {
 			wrapper.logger.Error(nil, "unable to get auth info")
-			presentError(w, nil)
+			wrapper.presentError(w, nil)
 			return
 		}
 
 		handlerResponse, err := delegate(authInfo, r)
 		if err != nil {
 			wrapper.logger.Info("handler returned error", "error", err)
-			presentError(w, err)
+			wrapper.presentError(w, err)
 			return
 		}
 
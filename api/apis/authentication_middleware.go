@@ -2,7 +2,6 @@ package apis
 
 import (
 	"net/http"
-	"regexp"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"github.com/go-http-utils/headers"
@@ -45,7 +44,7 @@ func (a *AuthenticationMiddleware) Middleware(next http.Handler) http.Handler {
 		authInfo, err := a.authInfoParser.Parse(r.Header.Get(headers.Authorization))
 		if err != nil {
 			a.logger.Error(err, "failed to parse auth info")
-			presentError(w, err)
+			a.presentError(w, r, err)
 			return
 		}
 
@@ -54,7 +53,7 @@ func (a *AuthenticationMiddleware) Middleware(next http.Handler) http.Handler {
 		_, err = a.identityProvider.GetIdentity(r.Context(), authInfo)
 		if err != nil {
 			a.logger.Error(err, "failed to get identity")
-			presentError(w, err)
+			a.presentError(w, r, err)
 			return
 		}
 
@@ -62,13 +61,16 @@ func (a *AuthenticationMiddleware) Middleware(next http.Handler) http.Handler {
 	})
 }
 
-// Eventually we will want to authenticate the log-cache read endpoint and go back to using the simple
-// unauthenticatedEndpoints map. For now though we need to do a more complicated regexp match against
-// the path since the log-cache read endpoint includes an arbitrary guid as part of its path
-var logCacheReadEndpointRegexp = regexp.MustCompile(`\/api\/v1\/read\/[0-9a-fA-F\-]*$`)
-
 func (a *AuthenticationMiddleware) isUnauthenticatedEndpoint(p string) bool {
 	_, authNotRequired := a.unauthenticatedEndpoints[p]
+	return authNotRequired
+}
+
+func (a *AuthenticationMiddleware) presentError(w http.ResponseWriter, r *http.Request, err error) {
+	if isLogCacheRequest(r) {
+		presentLogCacheError(w, err)
+		return
+	}
 
-	return authNotRequired || logCacheReadEndpointRegexp.MatchString(p)
+	presentError(w, err)
 }
@@ -97,24 +97,6 @@ var _ = Describe("Authentication Middleware", func() {
 				Expect(ok).To(BeFalse())
 			})
 		})
-
-		Describe("/api/v1/read/:guid", func() {
-			When("given an arbitrary guid", func() {
-				BeforeEach(func() {
-					guid := uuid.NewString()
-					requestPath = fmt.Sprintf("/api/v1/read/%s", guid)
-				})
-
-				It("passes through", func() {
-					Expect(rr).To(HaveHTTPStatus(http.StatusTeapot), fmt.Sprintf("Request path: %s", requestPath))
-				})
-
-				It("does not inject an authorization.Info in the context", func() {
-					_, ok := authorization.InfoFromContext(actualReq.Context())
-					Expect(ok).To(BeFalse(), fmt.Sprintf("Request path: %s", requestPath))
-				})
-			})
-		})
 	})
 
 	Describe("endpoints requiring authentication", func() {
@@ -141,6 +123,40 @@ var _ = Describe("Authentication Middleware", func() {
 			Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
 		})
 
+		When("reading logs from log-cache", func() {
+			BeforeEach(func() {
+				requestPath = fmt.Sprintf("/api/v1/read/%s", uuid.NewString())
+			})
+
+			It("verifies authentication and passes through", func() {
+				Expect(authInfoParser.ParseCallCount()).To(Equal(1))
+				Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+				Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			})
+
+			When("parsing the Authorization header fails", func() {
+				BeforeEach(func() {
+					authInfoParser.ParseReturns(authorization.Info{}, apierrors.NewNotAuthenticatedError(nil))
+				})
+
+				It("returns a log-cache unauthorized error", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+					Expect(rr).To(HaveHTTPBody(MatchJSON(`{"error": "unauthorized", "message": "Authentication error"}`)))
+				})
+			})
+
+			When("getting the identity fails", func() {
+				BeforeEach(func() {
+					identityProvider.GetIdentityReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(nil))
+				})
+
+				It("returns a log-cache unauthorized error", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusUnauthorized))
+					Expect(rr).To(HaveHTTPBody(MatchJSON(`{"error": "unauthorized", "message": "Invalid Auth Token"}`)))
+				})
+			})
+		})
+
 		When("parsing the Authorization header fails", func() {
 			BeforeEach(func() {
 				authInfoParser.ParseReturns(authorization.Info{}, apierrors.NewInvalidAuthError(nil))
@@ -15,6 +15,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
 )
 
 const (
@@ -25,7 +26,10 @@ const (
 //counterfeiter:generate -o fake -fake-name CFBuildRepository . CFBuildRepository
 type CFBuildRepository interface {
 	GetBuild(context.Context, authorization.Info, string) (repositories.BuildRecord, error)
+	ListBuilds(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)
 	CreateBuild(context.Context, authorization.Info, repositories.CreateBuildMessage) (repositories.BuildRecord, error)
+	GetLatestBuildByAppGUID(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)
+	GetBuildLogs(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)
 }
 
 type BuildHandler struct {
@@ -67,6 +71,55 @@ func (h *BuildHandler) buildGetHandler(authInfo authorization.Info, r *http.Requ
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForBuild(build, h.serverURL)), nil
 }
 
+func (h *BuildHandler) buildListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	buildListFilter := new(payloads.BuildList)
+	err := schema.NewDecoder().Decode(buildListFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Build filter")
+					return nil, apierrors.NewUnknownKeyError(err, buildListFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = buildListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = buildListFilter.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
+	buildList, err := h.buildRepo.ListBuilds(ctx, authInfo, buildListFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch builds from Kubernetes")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForBuildList(buildList, h.serverURL, *r.URL)), nil
+}
+
 func (h *BuildHandler) buildCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	var payload payloads.BuildCreate
 	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
@@ -97,5 +150,6 @@ func (h *BuildHandler) buildCreateHandler(authInfo authorization.Info, r *http.R
 func (h *BuildHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(BuildPath).Methods("GET").HandlerFunc(w.Wrap(h.buildGetHandler))
+	router.Path(BuildsPath).Methods("GET").HandlerFunc(w.Wrap(h.buildListHandler))
 	router.Path(BuildsPath).Methods("POST").HandlerFunc(w.Wrap(h.buildCreateHandler))
 }
@@ -1,6 +1,7 @@
 package apis_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -320,6 +321,135 @@ var _ = Describe("BuildHandler", func() {
 		})
 	})
 
+	Describe("the GET /v3/builds endpoint", func() {
+		var (
+			buildRepo *fake.CFBuildRepository
+			req       *http.Request
+		)
+
+		BeforeEach(func() {
+			buildRepo = new(fake.CFBuildRepository)
+			buildRepo.ListBuildsReturns([]repositories.BuildRecord{
+				{
+					GUID:        "build-1-guid",
+					State:       "STAGED",
+					PackageGUID: "package-1-guid",
+					AppGUID:     "app-1-guid",
+					DropletGUID: "build-1-guid",
+					Lifecycle: repositories.Lifecycle{
+						Type: "buildpack",
+						Data: repositories.LifecycleData{Buildpacks: []string{}},
+					},
+					Labels:      map[string]string{"env": "prod"},
+					Annotations: map[string]string{"example.com/owner": "team-a"},
+				},
+				{
+					GUID:        "build-2-guid",
+					State:       "STAGING",
+					PackageGUID: "package-2-guid",
+					AppGUID:     "app-2-guid",
+					Lifecycle: repositories.Lifecycle{
+						Type: "buildpack",
+						Data: repositories.LifecycleData{Buildpacks: []string{}},
+					},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/builds?app_guids=app-1-guid,app-2-guid&label_selector=env", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			decoderValidator, err := NewDefaultDecoderValidator()
+			Expect(err).NotTo(HaveOccurred())
+
+			buildHandler := NewBuildHandler(
+				logf.Log.WithName(testBuildHandlerLoggerName),
+				*serverURL,
+				buildRepo,
+				new(fake.CFPackageRepository),
+				decoderValidator,
+			)
+			buildHandler.RegisterRoutes(router)
+		})
+
+		JustBeforeEach(func() {
+			router.ServeHTTP(rr, req)
+		})
+
+		It("lists the builds matching the filters", func() {
+			Expect(buildRepo.ListBuildsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := buildRepo.ListBuildsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListBuildsMessage{
+				AppGUIDs:      []string{"app-1-guid", "app-2-guid"},
+				PackageGUIDs:  []string{},
+				LabelSelector: "env",
+			}))
+		})
+
+		It("returns the builds with their metadata", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+			response := map[string]interface{}{}
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response).To(HaveKeyWithValue("pagination", HaveKeyWithValue("total_results", BeNumerically("==", 2))))
+			Expect(response).To(HaveKeyWithValue("resources", ConsistOf(
+				SatisfyAll(
+					HaveKeyWithValue("guid", "build-1-guid"),
+					HaveKeyWithValue("metadata", Equal(map[string]interface{}{
+						"labels":      map[string]interface{}{"env": "prod"},
+						"annotations": map[string]interface{}{"example.com/owner": "team-a"},
+					})),
+				),
+				SatisfyAll(
+					HaveKeyWithValue("guid", "build-2-guid"),
+					HaveKeyWithValue("metadata", Equal(map[string]interface{}{
+						"labels":      map[string]interface{}{},
+						"annotations": map[string]interface{}{},
+					})),
+				),
+			)))
+		})
+
+		When("the label_selector is invalid", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/builds?label_selector=env%20in%20prod", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Invalid label_selector value")
+			})
+
+			It("does not list the builds", func() {
+				Expect(buildRepo.ListBuildsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("an unknown query parameter is provided", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/builds?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an Unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, package_guids, order_by, label_selector, page, per_page'")
+			})
+		})
+
+		When("listing the builds fails", func() {
+			BeforeEach(func() {
+				buildRepo.ListBuildsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the POST /v3/builds endpoint", func() {
 		var (
 			packageRepo *fake.CFPackageRepository
@@ -76,6 +76,11 @@ func (h *BuildpackHandler) buildpackListHandler(authInfo authorization.Info, r *
 		}
 	}
 
+	if err = buildpackListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	buildpacks, err := h.buildpackRepo.GetBuildpacksForBuilder(ctx, authInfo, h.clusterBuilderName)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch buildpacks from Kubernetes")
@@ -79,10 +79,10 @@ var _ = Describe("BuildpackHandler", func() {
 						"total_results": 1,
 						"total_pages": 1,
 						"first": {
-							"href": "%[1]s/v3/buildpacks"
+							"href": "%[1]s/v3/buildpacks?page=1&per_page=50"
 						},
 						"last": {
-							"href": "%[1]s/v3/buildpacks"
+							"href": "%[1]s/v3/buildpacks?page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
@@ -129,7 +129,7 @@ var _ = Describe("BuildpackHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'order_by'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'order_by, page, per_page'")
 			})
 		})
 	})
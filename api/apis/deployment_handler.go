@@ -0,0 +1,234 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	DeploymentsPath      = "/v3/deployments"
+	DeploymentPath       = "/v3/deployments/{guid}"
+	DeploymentCancelPath = "/v3/deployments/{guid}/actions/cancel"
+)
+
+//counterfeiter:generate -o fake -fake-name CFDeploymentRepository . CFDeploymentRepository
+type CFDeploymentRepository interface {
+	CreateDeployment(context.Context, authorization.Info, repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error)
+	GetDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+	ListDeployments(context.Context, authorization.Info, repositories.ListDeploymentsMessage) ([]repositories.DeploymentRecord, error)
+	CancelDeployment(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+}
+
+type DeploymentHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	dropletRepo      CFDropletRepository
+	revisionRepo     CFRevisionRepository
+	deploymentRepo   CFDeploymentRepository
+	decoderValidator *DecoderValidator
+}
+
+func NewDeploymentHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	dropletRepo CFDropletRepository,
+	revisionRepo CFRevisionRepository,
+	deploymentRepo CFDeploymentRepository,
+	decoderValidator *DecoderValidator,
+) *DeploymentHandler {
+	return &DeploymentHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		dropletRepo:      dropletRepo,
+		revisionRepo:     revisionRepo,
+		deploymentRepo:   deploymentRepo,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *DeploymentHandler) deploymentCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.DeploymentCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	appGUID := payload.Relationships.App.Data.GUID
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Info("Error finding App", "AppGUID", appGUID)
+		return nil, apierrors.AsUnprocessibleEntity(
+			err,
+			"Unable to use app. Ensure that the app exists and you have access to it.",
+			apierrors.NotFoundError{},
+			apierrors.ForbiddenError{},
+		)
+	}
+
+	message := payload.ToMessage(app)
+	if payload.Revision != nil {
+		revision, err := h.revisionRepo.GetRevision(ctx, authInfo, payload.Revision.GUID)
+		if err == nil && revision.AppGUID != appGUID {
+			err = apierrors.NewNotFoundError(fmt.Errorf("revision %s does not belong to app %s", revision.GUID, appGUID), repositories.RevisionResourceType)
+		}
+		if err != nil {
+			h.logger.Info("Error finding Revision", "RevisionGUID", payload.Revision.GUID)
+			return nil, apierrors.AsUnprocessibleEntity(
+				err,
+				"Unable to use revision. Ensure that the revision exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			)
+		}
+
+		if !revision.Deployable {
+			h.logger.Info("Cannot deploy a revision without a staged droplet", "RevisionGUID", revision.GUID)
+			return nil, apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("droplet %s of revision %s is not staged", revision.DropletGUID, revision.GUID),
+				"Unable to deploy this revision, the droplet for this revision no longer exists.",
+			)
+		}
+		message.DropletGUID = revision.DropletGUID
+	}
+
+	if message.DropletGUID == "" {
+		h.logger.Info("Cannot deploy an app without a droplet", "AppGUID", appGUID)
+		return nil, apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("app %s has no current droplet", appGUID),
+			"Invalid droplet. Please specify a droplet in the request or set a current droplet for the app.",
+		)
+	}
+
+	if payload.Droplet != nil {
+		droplet, err := h.dropletRepo.GetDroplet(ctx, authInfo, message.DropletGUID)
+		if err == nil && droplet.AppGUID != appGUID {
+			err = apierrors.NewNotFoundError(fmt.Errorf("droplet %s does not belong to app %s", droplet.GUID, appGUID), repositories.DropletResourceType)
+		}
+		if err != nil {
+			h.logger.Info("Error finding Droplet", "DropletGUID", message.DropletGUID)
+			return nil, apierrors.AsUnprocessibleEntity(
+				err,
+				"Unable to use droplet. Ensure that the droplet exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			)
+		}
+	}
+
+	deployment, err := h.deploymentRepo.CreateDeployment(ctx, authInfo, message)
+	if err != nil {
+		h.logger.Error(err, "Failed to create deployment", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
+func (h *DeploymentHandler) deploymentListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.DeploymentList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Deployment filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	deployments, err := h.deploymentRepo.ListDeployments(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list deployments")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForDeploymentList(deployments, h.serverURL, *r.URL)), nil
+}
+
+func (h *DeploymentHandler) deploymentGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	deploymentGUID := mux.Vars(r)["guid"]
+
+	deployment, err := h.deploymentRepo.GetDeployment(ctx, authInfo, deploymentGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch deployment", "DeploymentGUID", deploymentGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
+func (h *DeploymentHandler) deploymentCancelHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	deploymentGUID := mux.Vars(r)["guid"]
+
+	deployment, err := h.deploymentRepo.GetDeployment(ctx, authInfo, deploymentGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch deployment", "DeploymentGUID", deploymentGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	if deployment.StatusValue == repositories.DeploymentStatusValueFinalized {
+		h.logger.Info("Cannot cancel a finalized deployment", "DeploymentGUID", deploymentGUID, "Reason", deployment.StatusReason)
+		return nil, apierrors.NewUnprocessableEntityError(
+			errors.New("deployment is finalized"),
+			fmt.Sprintf("Cannot cancel a %s deployment", deployment.State),
+		)
+	}
+
+	deployment, err = h.deploymentRepo.CancelDeployment(ctx, authInfo, deploymentGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to cancel deployment", "DeploymentGUID", deploymentGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForDeployment(deployment, h.serverURL)), nil
+}
+
+func (h *DeploymentHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(DeploymentsPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.deploymentCreateHandler))
+	router.Path(DeploymentsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.deploymentListHandler))
+	router.Path(DeploymentPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.deploymentGetHandler))
+	router.Path(DeploymentCancelPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.deploymentCancelHandler))
+}
@@ -0,0 +1,395 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("DeploymentHandler", func() {
+	var (
+		req            *http.Request
+		appRepo        *fake.CFAppRepository
+		dropletRepo    *fake.CFDropletRepository
+		revisionRepo   *fake.CFRevisionRepository
+		deploymentRepo *fake.CFDeploymentRepository
+		deployment     repositories.DeploymentRecord
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:        "app-guid",
+			SpaceGUID:   "space-guid",
+			DropletGUID: "current-droplet-guid",
+		}, nil)
+
+		dropletRepo = new(fake.CFDropletRepository)
+		dropletRepo.GetDropletReturns(repositories.DropletRecord{
+			GUID:    "droplet-guid",
+			AppGUID: "app-guid",
+		}, nil)
+
+		revisionRepo = new(fake.CFRevisionRepository)
+		revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+			GUID:        "revision-guid",
+			AppGUID:     "app-guid",
+			DropletGUID: "revision-droplet-guid",
+			Deployable:  true,
+		}, nil)
+
+		deploymentRepo = new(fake.CFDeploymentRepository)
+		deployment = repositories.DeploymentRecord{
+			GUID:                "deployment-guid",
+			AppGUID:             "app-guid",
+			SpaceGUID:           "space-guid",
+			DropletGUID:         "droplet-guid",
+			PreviousDropletGUID: "current-droplet-guid",
+			Strategy:            "rolling",
+			State:               repositories.DeploymentStatusReasonDeploying,
+			StatusValue:         repositories.DeploymentStatusValueActive,
+			StatusReason:        repositories.DeploymentStatusReasonDeploying,
+			LastStatusChange:    "2019-05-10T17:17:48Z",
+			NewProcesses:        []repositories.DeploymentProcess{{GUID: "process-guid", Type: "web"}},
+			CreatedAt:           "2019-05-10T17:17:48Z",
+			UpdatedAt:           "2019-05-10T17:17:48Z",
+		}
+
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		NewDeploymentHandler(
+			logf.Log.WithName("TestDeploymentHandler"),
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			revisionRepo,
+			deploymentRepo,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/deployments endpoint", func() {
+		makePostRequest := func(body string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/deployments", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			deploymentRepo.CreateDeploymentReturns(deployment, nil)
+			makePostRequest(`{
+				"droplet": {"guid": "droplet-guid"},
+				"strategy": "rolling",
+				"relationships": {"app": {"data": {"guid": "app-guid"}}},
+				"metadata": {"labels": {"env": "prod"}}
+			}`)
+		})
+
+		It("creates the deployment in the space of the app", func() {
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(deploymentRepo.CreateDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := deploymentRepo.CreateDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateDeploymentMessage{
+				AppGUID:     "app-guid",
+				SpaceGUID:   "space-guid",
+				DropletGUID: "droplet-guid",
+				Strategy:    "rolling",
+				Labels:      map[string]string{"env": "prod"},
+			}))
+		})
+
+		It("returns the deployment", func() {
+			Expect(rr.Code).To(Equal(http.StatusCreated))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "deployment-guid",
+				"state": "DEPLOYING",
+				"status": {
+					"value": "ACTIVE",
+					"reason": "DEPLOYING",
+					"details": {"last_status_change": "2019-05-10T17:17:48Z"}
+				},
+				"strategy": "rolling",
+				"droplet": {"guid": "droplet-guid"},
+				"previous_droplet": {"guid": "current-droplet-guid"},
+				"new_processes": [{"guid": "process-guid", "type": "web"}],
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {"app": {"data": {"guid": "app-guid"}}},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/deployments/deployment-guid"},
+					"app": {"href": "https://api.example.org/v3/apps/app-guid"},
+					"cancel": {"href": "https://api.example.org/v3/deployments/deployment-guid/actions/cancel", "method": "POST"}
+				}
+			}`))
+		})
+
+		When("no droplet is specified", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"relationships": {"app": {"data": {"guid": "app-guid"}}}}`)
+			})
+
+			It("redeploys the current droplet of the app with the rolling strategy", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(BeZero())
+				_, _, message := deploymentRepo.CreateDeploymentArgsForCall(0)
+				Expect(message.DropletGUID).To(Equal("current-droplet-guid"))
+				Expect(message.Strategy).To(Equal("rolling"))
+			})
+
+			When("the app has no current droplet", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Invalid droplet. Please specify a droplet in the request or set a current droplet for the app.")
+					Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"revision": {"guid": "revision-guid"}, "relationships": {"app": {"data": {"guid": "app-guid"}}}}`)
+			})
+
+			It("deploys the droplet of the revision", func() {
+				_, _, actualRevisionGUID := revisionRepo.GetRevisionArgsForCall(0)
+				Expect(actualRevisionGUID).To(Equal("revision-guid"))
+
+				_, _, message := deploymentRepo.CreateDeploymentArgsForCall(0)
+				Expect(message.RevisionGUID).To(Equal("revision-guid"))
+				Expect(message.DropletGUID).To(Equal("revision-droplet-guid"))
+			})
+
+			When("the revision belongs to another app", func() {
+				BeforeEach(func() {
+					revisionRepo.GetRevisionReturns(repositories.RevisionRecord{GUID: "revision-guid", AppGUID: "another-app-guid"}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Unable to use revision. Ensure that the revision exists and you have access to it.")
+					Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+				})
+			})
+
+			When("the revision is not accessible", func() {
+				BeforeEach(func() {
+					revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Unable to use revision. Ensure that the revision exists and you have access to it.")
+				})
+			})
+
+			When("the droplet of the revision is gone", func() {
+				BeforeEach(func() {
+					revisionRepo.GetRevisionReturns(repositories.RevisionRecord{GUID: "revision-guid", AppGUID: "app-guid"}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Unable to deploy this revision, the droplet for this revision no longer exists.")
+				})
+			})
+
+			When("a droplet is specified as well", func() {
+				BeforeEach(func() {
+					makePostRequest(`{"droplet": {"guid": "droplet-guid"}, "revision": {"guid": "revision-guid"}, "relationships": {"app": {"data": {"guid": "app-guid"}}}}`)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot pass both 'droplet' and 'revision' in a create deployment request")
+					Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the strategy is not supported", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"strategy": "recreate", "relationships": {"app": {"data": {"guid": "app-guid"}}}}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Strategy must be one of [rolling]")
+			})
+		})
+
+		When("the app relationship is missing", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"strategy": "rolling"}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Relationships is a required field")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to use app. Ensure that the app exists and you have access to it.")
+				Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet does not exist", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewNotFoundError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to use droplet. Ensure that the droplet exists and you have access to it.")
+				Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the droplet belongs to another app", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{GUID: "droplet-guid", AppGUID: "another-app-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to use droplet. Ensure that the droplet exists and you have access to it.")
+				Expect(deploymentRepo.CreateDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the deployment fails", func() {
+			BeforeEach(func() {
+				deploymentRepo.CreateDeploymentReturns(repositories.DeploymentRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/deployments endpoint", func() {
+		BeforeEach(func() {
+			deploymentRepo.ListDeploymentsReturns([]repositories.DeploymentRecord{deployment}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/deployments?app_guids=app-guid&status_values=ACTIVE", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the deployments", func() {
+			_, _, message := deploymentRepo.ListDeploymentsArgsForCall(0)
+			Expect(message.AppGUIDs).To(Equal([]string{"app-guid"}))
+			Expect(message.StatusValues).To(Equal([]string{"ACTIVE"}))
+			Expect(message.StatusReasons).To(BeEmpty())
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"total_results":1`))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"deployment-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/deployments?strategies=rolling", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, states, status_values, status_reasons, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the GET /v3/deployments/:guid endpoint", func() {
+		BeforeEach(func() {
+			deploymentRepo.GetDeploymentReturns(deployment, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/deployments/deployment-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the deployment", func() {
+			_, _, actualGUID := deploymentRepo.GetDeploymentArgsForCall(0)
+			Expect(actualGUID).To(Equal("deployment-guid"))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"deployment-guid"`))
+		})
+
+		When("the deployment is not accessible", func() {
+			BeforeEach(func() {
+				deploymentRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Deployment not found")
+			})
+		})
+	})
+
+	Describe("the POST /v3/deployments/:guid/actions/cancel endpoint", func() {
+		BeforeEach(func() {
+			deploymentRepo.GetDeploymentReturns(deployment, nil)
+			canceledDeployment := deployment
+			canceledDeployment.State = repositories.DeploymentStatusReasonCanceling
+			canceledDeployment.StatusReason = repositories.DeploymentStatusReasonCanceling
+			deploymentRepo.CancelDeploymentReturns(canceledDeployment, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/deployments/deployment-guid/actions/cancel", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cancels the deployment", func() {
+			Expect(deploymentRepo.CancelDeploymentCallCount()).To(Equal(1))
+			_, _, actualGUID := deploymentRepo.CancelDeploymentArgsForCall(0)
+			Expect(actualGUID).To(Equal("deployment-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"reason":"CANCELING"`))
+		})
+
+		When("the deployment is finalized", func() {
+			BeforeEach(func() {
+				deployment.State = repositories.DeploymentStatusReasonDeployed
+				deployment.StatusValue = repositories.DeploymentStatusValueFinalized
+				deployment.StatusReason = repositories.DeploymentStatusReasonDeployed
+				deploymentRepo.GetDeploymentReturns(deployment, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot cancel a DEPLOYED deployment")
+				Expect(deploymentRepo.CancelDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the deployment does not exist", func() {
+			BeforeEach(func() {
+				deploymentRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewNotFoundError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Deployment not found")
+			})
+		})
+	})
+})
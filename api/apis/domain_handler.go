@@ -76,6 +76,11 @@ func (h *DomainHandler) DomainListHandler(authInfo authorization.Info, r *http.R
 		}
 	}
 
+	if err = domainListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	domainList, err := h.domainRepo.ListDomains(ctx, authInfo, domainListFilter.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch domain(s) from Kubernetes")
@@ -66,10 +66,10 @@ var _ = Describe("DomainHandler", func() {
 					"total_results": 1,
 					"total_pages": 1,
 					"first": {
-						"href": "%[1]s/v3/domains"
+						"href": "%[1]s/v3/domains?page=1&per_page=50"
 					},
 					"last": {
-						"href": "%[1]s/v3/domains"
+						"href": "%[1]s/v3/domains?page=1&per_page=50"
 					},
 					"next": null,
 					"previous": null
@@ -133,10 +133,10 @@ var _ = Describe("DomainHandler", func() {
 						"total_results": 0,
 						"total_pages": 1,
 						"first": {
-							"href": "%[1]s/v3/domains"
+							"href": "%[1]s/v3/domains?page=1&per_page=50"
 						},
 						"last": {
-							"href": "%[1]s/v3/domains"
+							"href": "%[1]s/v3/domains?page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
@@ -169,7 +169,7 @@ var _ = Describe("DomainHandler", func() {
 				router.ServeHTTP(rr, req)
 			})
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, page, per_page'")
 			})
 		})
 	})
@@ -1,6 +1,7 @@
 package apis_test
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 
@@ -142,6 +143,30 @@ var _ = Describe("DropletHandler", func() {
 			})
 		})
 
+		When("the droplet was staged from a docker image", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: "STAGED",
+					Lifecycle: repositories.Lifecycle{
+						Type: "docker",
+						Data: repositories.LifecycleData{Buildpacks: []string{}},
+					},
+					Image:       "registry.example.org/my-image:latest",
+					AppGUID:     appGUID,
+					PackageGUID: packageGUID,
+				}, nil)
+				router.ServeHTTP(rr, req)
+			})
+
+			It("returns the image of the droplet", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				response := map[string]interface{}{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("image", "registry.example.org/my-image:latest"))
+			})
+		})
+
 		When("access to the droplet is forbidden", func() {
 			BeforeEach(func() {
 				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
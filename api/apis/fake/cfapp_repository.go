@@ -84,6 +84,40 @@ type CFAppRepository struct {
 		result1 []repositories.AppRecord
 		result2 error
 	}
+	ListSpaceAppsStub        func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.AppRecord, int, error)
+	listSpaceAppsMutex       sync.RWMutex
+	listSpaceAppsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}
+	listSpaceAppsReturns struct {
+		result1 []repositories.AppRecord
+		result2 int
+		result3 error
+	}
+	listSpaceAppsReturnsOnCall map[int]struct {
+		result1 []repositories.AppRecord
+		result2 int
+		result3 error
+	}
+	PatchAppStub        func(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	patchAppMutex       sync.RWMutex
+	patchAppArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchAppMessage
+	}
+	patchAppReturns struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
+	patchAppReturnsOnCall map[int]struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
 	PatchAppEnvVarsStub        func(context.Context, authorization.Info, repositories.PatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error)
 	patchAppEnvVarsMutex       sync.RWMutex
 	patchAppEnvVarsArgsForCall []struct {
@@ -460,6 +494,143 @@ func (fake *CFAppRepository) ListAppsReturnsOnCall(i int, result1 []repositories
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) ListSpaceApps(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 repositories.Page) ([]repositories.AppRecord, int, error) {
+	fake.listSpaceAppsMutex.Lock()
+	ret, specificReturn := fake.listSpaceAppsReturnsOnCall[len(fake.listSpaceAppsArgsForCall)]
+	fake.listSpaceAppsArgsForCall = append(fake.listSpaceAppsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListSpaceAppsStub
+	fakeReturns := fake.listSpaceAppsReturns
+	fake.recordInvocation("ListSpaceApps", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listSpaceAppsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *CFAppRepository) ListSpaceAppsCallCount() int {
+	fake.listSpaceAppsMutex.RLock()
+	defer fake.listSpaceAppsMutex.RUnlock()
+	return len(fake.listSpaceAppsArgsForCall)
+}
+
+func (fake *CFAppRepository) ListSpaceAppsCalls(stub func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.AppRecord, int, error)) {
+	fake.listSpaceAppsMutex.Lock()
+	defer fake.listSpaceAppsMutex.Unlock()
+	fake.ListSpaceAppsStub = stub
+}
+
+func (fake *CFAppRepository) ListSpaceAppsArgsForCall(i int) (context.Context, authorization.Info, string, string, repositories.Page) {
+	fake.listSpaceAppsMutex.RLock()
+	defer fake.listSpaceAppsMutex.RUnlock()
+	argsForCall := fake.listSpaceAppsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *CFAppRepository) ListSpaceAppsReturns(result1 []repositories.AppRecord, result2 int, result3 error) {
+	fake.listSpaceAppsMutex.Lock()
+	defer fake.listSpaceAppsMutex.Unlock()
+	fake.ListSpaceAppsStub = nil
+	fake.listSpaceAppsReturns = struct {
+		result1 []repositories.AppRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CFAppRepository) ListSpaceAppsReturnsOnCall(i int, result1 []repositories.AppRecord, result2 int, result3 error) {
+	fake.listSpaceAppsMutex.Lock()
+	defer fake.listSpaceAppsMutex.Unlock()
+	fake.ListSpaceAppsStub = nil
+	if fake.listSpaceAppsReturnsOnCall == nil {
+		fake.listSpaceAppsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.AppRecord
+			result2 int
+			result3 error
+		})
+	}
+	fake.listSpaceAppsReturnsOnCall[i] = struct {
+		result1 []repositories.AppRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CFAppRepository) PatchApp(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchAppMessage) (repositories.AppRecord, error) {
+	fake.patchAppMutex.Lock()
+	ret, specificReturn := fake.patchAppReturnsOnCall[len(fake.patchAppArgsForCall)]
+	fake.patchAppArgsForCall = append(fake.patchAppArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchAppMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchAppStub
+	fakeReturns := fake.patchAppReturns
+	fake.recordInvocation("PatchApp", []interface{}{arg1, arg2, arg3})
+	fake.patchAppMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFAppRepository) PatchAppCallCount() int {
+	fake.patchAppMutex.RLock()
+	defer fake.patchAppMutex.RUnlock()
+	return len(fake.patchAppArgsForCall)
+}
+
+func (fake *CFAppRepository) PatchAppCalls(stub func(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)) {
+	fake.patchAppMutex.Lock()
+	defer fake.patchAppMutex.Unlock()
+	fake.PatchAppStub = stub
+}
+
+func (fake *CFAppRepository) PatchAppArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchAppMessage) {
+	fake.patchAppMutex.RLock()
+	defer fake.patchAppMutex.RUnlock()
+	argsForCall := fake.patchAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) PatchAppReturns(result1 repositories.AppRecord, result2 error) {
+	fake.patchAppMutex.Lock()
+	defer fake.patchAppMutex.Unlock()
+	fake.PatchAppStub = nil
+	fake.patchAppReturns = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFAppRepository) PatchAppReturnsOnCall(i int, result1 repositories.AppRecord, result2 error) {
+	fake.patchAppMutex.Lock()
+	defer fake.patchAppMutex.Unlock()
+	fake.PatchAppStub = nil
+	if fake.patchAppReturnsOnCall == nil {
+		fake.patchAppReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppRecord
+			result2 error
+		})
+	}
+	fake.patchAppReturnsOnCall[i] = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFAppRepository) PatchAppEnvVars(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error) {
 	fake.patchAppEnvVarsMutex.Lock()
 	ret, specificReturn := fake.patchAppEnvVarsReturnsOnCall[len(fake.patchAppEnvVarsArgsForCall)]
@@ -671,6 +842,10 @@ func (fake *CFAppRepository) Invocations() map[string][][]interface{} {
 	defer fake.getAppEnvMutex.RUnlock()
 	fake.listAppsMutex.RLock()
 	defer fake.listAppsMutex.RUnlock()
+	fake.listSpaceAppsMutex.RLock()
+	defer fake.listSpaceAppsMutex.RUnlock()
+	fake.patchAppMutex.RLock()
+	defer fake.patchAppMutex.RUnlock()
 	fake.patchAppEnvVarsMutex.RLock()
 	defer fake.patchAppEnvVarsMutex.RUnlock()
 	fake.setAppDesiredStateMutex.RLock()
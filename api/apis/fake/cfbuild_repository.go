@@ -41,6 +41,52 @@ type CFBuildRepository struct {
 		result1 repositories.BuildRecord
 		result2 error
 	}
+	GetBuildLogsStub        func(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)
+	getBuildLogsMutex       sync.RWMutex
+	getBuildLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.BuildLogsMessage
+	}
+	getBuildLogsReturns struct {
+		result1 []repositories.LogRecord
+		result2 error
+	}
+	getBuildLogsReturnsOnCall map[int]struct {
+		result1 []repositories.LogRecord
+		result2 error
+	}
+	GetLatestBuildByAppGUIDStub        func(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)
+	getLatestBuildByAppGUIDMutex       sync.RWMutex
+	getLatestBuildByAppGUIDArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	getLatestBuildByAppGUIDReturns struct {
+		result1 repositories.BuildRecord
+		result2 error
+	}
+	getLatestBuildByAppGUIDReturnsOnCall map[int]struct {
+		result1 repositories.BuildRecord
+		result2 error
+	}
+	ListBuildsStub        func(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)
+	listBuildsMutex       sync.RWMutex
+	listBuildsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListBuildsMessage
+	}
+	listBuildsReturns struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}
+	listBuildsReturnsOnCall map[int]struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -177,6 +223,205 @@ func (fake *CFBuildRepository) GetBuildReturnsOnCall(i int, result1 repositories
 	}{result1, result2}
 }
 
+func (fake *CFBuildRepository) GetBuildLogs(arg1 context.Context, arg2 authorization.Info, arg3 repositories.BuildLogsMessage) ([]repositories.LogRecord, error) {
+	fake.getBuildLogsMutex.Lock()
+	ret, specificReturn := fake.getBuildLogsReturnsOnCall[len(fake.getBuildLogsArgsForCall)]
+	fake.getBuildLogsArgsForCall = append(fake.getBuildLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.BuildLogsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.GetBuildLogsStub
+	fakeReturns := fake.getBuildLogsReturns
+	fake.recordInvocation("GetBuildLogs", []interface{}{arg1, arg2, arg3})
+	fake.getBuildLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFBuildRepository) GetBuildLogsCallCount() int {
+	fake.getBuildLogsMutex.RLock()
+	defer fake.getBuildLogsMutex.RUnlock()
+	return len(fake.getBuildLogsArgsForCall)
+}
+
+func (fake *CFBuildRepository) GetBuildLogsCalls(stub func(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)) {
+	fake.getBuildLogsMutex.Lock()
+	defer fake.getBuildLogsMutex.Unlock()
+	fake.GetBuildLogsStub = stub
+}
+
+func (fake *CFBuildRepository) GetBuildLogsArgsForCall(i int) (context.Context, authorization.Info, repositories.BuildLogsMessage) {
+	fake.getBuildLogsMutex.RLock()
+	defer fake.getBuildLogsMutex.RUnlock()
+	argsForCall := fake.getBuildLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFBuildRepository) GetBuildLogsReturns(result1 []repositories.LogRecord, result2 error) {
+	fake.getBuildLogsMutex.Lock()
+	defer fake.getBuildLogsMutex.Unlock()
+	fake.GetBuildLogsStub = nil
+	fake.getBuildLogsReturns = struct {
+		result1 []repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) GetBuildLogsReturnsOnCall(i int, result1 []repositories.LogRecord, result2 error) {
+	fake.getBuildLogsMutex.Lock()
+	defer fake.getBuildLogsMutex.Unlock()
+	fake.GetBuildLogsStub = nil
+	if fake.getBuildLogsReturnsOnCall == nil {
+		fake.getBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.LogRecord
+			result2 error
+		})
+	}
+	fake.getBuildLogsReturnsOnCall[i] = struct {
+		result1 []repositories.LogRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUID(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (repositories.BuildRecord, error) {
+	fake.getLatestBuildByAppGUIDMutex.Lock()
+	ret, specificReturn := fake.getLatestBuildByAppGUIDReturnsOnCall[len(fake.getLatestBuildByAppGUIDArgsForCall)]
+	fake.getLatestBuildByAppGUIDArgsForCall = append(fake.getLatestBuildByAppGUIDArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetLatestBuildByAppGUIDStub
+	fakeReturns := fake.getLatestBuildByAppGUIDReturns
+	fake.recordInvocation("GetLatestBuildByAppGUID", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getLatestBuildByAppGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUIDCallCount() int {
+	fake.getLatestBuildByAppGUIDMutex.RLock()
+	defer fake.getLatestBuildByAppGUIDMutex.RUnlock()
+	return len(fake.getLatestBuildByAppGUIDArgsForCall)
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUIDCalls(stub func(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)) {
+	fake.getLatestBuildByAppGUIDMutex.Lock()
+	defer fake.getLatestBuildByAppGUIDMutex.Unlock()
+	fake.GetLatestBuildByAppGUIDStub = stub
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUIDArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.getLatestBuildByAppGUIDMutex.RLock()
+	defer fake.getLatestBuildByAppGUIDMutex.RUnlock()
+	argsForCall := fake.getLatestBuildByAppGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUIDReturns(result1 repositories.BuildRecord, result2 error) {
+	fake.getLatestBuildByAppGUIDMutex.Lock()
+	defer fake.getLatestBuildByAppGUIDMutex.Unlock()
+	fake.GetLatestBuildByAppGUIDStub = nil
+	fake.getLatestBuildByAppGUIDReturns = struct {
+		result1 repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) GetLatestBuildByAppGUIDReturnsOnCall(i int, result1 repositories.BuildRecord, result2 error) {
+	fake.getLatestBuildByAppGUIDMutex.Lock()
+	defer fake.getLatestBuildByAppGUIDMutex.Unlock()
+	fake.GetLatestBuildByAppGUIDStub = nil
+	if fake.getLatestBuildByAppGUIDReturnsOnCall == nil {
+		fake.getLatestBuildByAppGUIDReturnsOnCall = make(map[int]struct {
+			result1 repositories.BuildRecord
+			result2 error
+		})
+	}
+	fake.getLatestBuildByAppGUIDReturnsOnCall[i] = struct {
+		result1 repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) ListBuilds(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListBuildsMessage) ([]repositories.BuildRecord, error) {
+	fake.listBuildsMutex.Lock()
+	ret, specificReturn := fake.listBuildsReturnsOnCall[len(fake.listBuildsArgsForCall)]
+	fake.listBuildsArgsForCall = append(fake.listBuildsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListBuildsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListBuildsStub
+	fakeReturns := fake.listBuildsReturns
+	fake.recordInvocation("ListBuilds", []interface{}{arg1, arg2, arg3})
+	fake.listBuildsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFBuildRepository) ListBuildsCallCount() int {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	return len(fake.listBuildsArgsForCall)
+}
+
+func (fake *CFBuildRepository) ListBuildsCalls(stub func(context.Context, authorization.Info, repositories.ListBuildsMessage) ([]repositories.BuildRecord, error)) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = stub
+}
+
+func (fake *CFBuildRepository) ListBuildsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListBuildsMessage) {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	argsForCall := fake.listBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFBuildRepository) ListBuildsReturns(result1 []repositories.BuildRecord, result2 error) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = nil
+	fake.listBuildsReturns = struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFBuildRepository) ListBuildsReturnsOnCall(i int, result1 []repositories.BuildRecord, result2 error) {
+	fake.listBuildsMutex.Lock()
+	defer fake.listBuildsMutex.Unlock()
+	fake.ListBuildsStub = nil
+	if fake.listBuildsReturnsOnCall == nil {
+		fake.listBuildsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.BuildRecord
+			result2 error
+		})
+	}
+	fake.listBuildsReturnsOnCall[i] = struct {
+		result1 []repositories.BuildRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFBuildRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -184,6 +429,12 @@ func (fake *CFBuildRepository) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.getBuildMutex.RLock()
 	defer fake.getBuildMutex.RUnlock()
+	fake.getBuildLogsMutex.RLock()
+	defer fake.getBuildLogsMutex.RUnlock()
+	fake.getLatestBuildByAppGUIDMutex.RLock()
+	defer fake.getLatestBuildByAppGUIDMutex.RUnlock()
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -43,6 +43,25 @@ type CFProcessRepository struct {
 		result1 repositories.ProcessRecord
 		result2 error
 	}
+	ListAppProcessesStub        func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.ProcessRecord, int, error)
+	listAppProcessesMutex       sync.RWMutex
+	listAppProcessesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}
+	listAppProcessesReturns struct {
+		result1 []repositories.ProcessRecord
+		result2 int
+		result3 error
+	}
+	listAppProcessesReturnsOnCall map[int]struct {
+		result1 []repositories.ProcessRecord
+		result2 int
+		result3 error
+	}
 	ListProcessesStub        func(context.Context, authorization.Info, repositories.ListProcessesMessage) ([]repositories.ProcessRecord, error)
 	listProcessesMutex       sync.RWMutex
 	listProcessesArgsForCall []struct {
@@ -211,6 +230,77 @@ func (fake *CFProcessRepository) GetProcessByAppTypeAndSpaceReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *CFProcessRepository) ListAppProcesses(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 repositories.Page) ([]repositories.ProcessRecord, int, error) {
+	fake.listAppProcessesMutex.Lock()
+	ret, specificReturn := fake.listAppProcessesReturnsOnCall[len(fake.listAppProcessesArgsForCall)]
+	fake.listAppProcessesArgsForCall = append(fake.listAppProcessesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListAppProcessesStub
+	fakeReturns := fake.listAppProcessesReturns
+	fake.recordInvocation("ListAppProcesses", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listAppProcessesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *CFProcessRepository) ListAppProcessesCallCount() int {
+	fake.listAppProcessesMutex.RLock()
+	defer fake.listAppProcessesMutex.RUnlock()
+	return len(fake.listAppProcessesArgsForCall)
+}
+
+func (fake *CFProcessRepository) ListAppProcessesCalls(stub func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.ProcessRecord, int, error)) {
+	fake.listAppProcessesMutex.Lock()
+	defer fake.listAppProcessesMutex.Unlock()
+	fake.ListAppProcessesStub = stub
+}
+
+func (fake *CFProcessRepository) ListAppProcessesArgsForCall(i int) (context.Context, authorization.Info, string, string, repositories.Page) {
+	fake.listAppProcessesMutex.RLock()
+	defer fake.listAppProcessesMutex.RUnlock()
+	argsForCall := fake.listAppProcessesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *CFProcessRepository) ListAppProcessesReturns(result1 []repositories.ProcessRecord, result2 int, result3 error) {
+	fake.listAppProcessesMutex.Lock()
+	defer fake.listAppProcessesMutex.Unlock()
+	fake.ListAppProcessesStub = nil
+	fake.listAppProcessesReturns = struct {
+		result1 []repositories.ProcessRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CFProcessRepository) ListAppProcessesReturnsOnCall(i int, result1 []repositories.ProcessRecord, result2 int, result3 error) {
+	fake.listAppProcessesMutex.Lock()
+	defer fake.listAppProcessesMutex.Unlock()
+	fake.ListAppProcessesStub = nil
+	if fake.listAppProcessesReturnsOnCall == nil {
+		fake.listAppProcessesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ProcessRecord
+			result2 int
+			result3 error
+		})
+	}
+	fake.listAppProcessesReturnsOnCall[i] = struct {
+		result1 []repositories.ProcessRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *CFProcessRepository) ListProcesses(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListProcessesMessage) ([]repositories.ProcessRecord, error) {
 	fake.listProcessesMutex.Lock()
 	ret, specificReturn := fake.listProcessesReturnsOnCall[len(fake.listProcessesArgsForCall)]
@@ -350,6 +440,8 @@ func (fake *CFProcessRepository) Invocations() map[string][][]interface{} {
 	defer fake.getProcessMutex.RUnlock()
 	fake.getProcessByAppTypeAndSpaceMutex.RLock()
 	defer fake.getProcessByAppTypeAndSpaceMutex.RUnlock()
+	fake.listAppProcessesMutex.RLock()
+	defer fake.listAppProcessesMutex.RUnlock()
 	fake.listProcessesMutex.RLock()
 	defer fake.listProcessesMutex.RUnlock()
 	fake.patchProcessMutex.RLock()
@@ -100,6 +100,40 @@ type CFRouteRepository struct {
 		result1 []repositories.RouteRecord
 		result2 error
 	}
+	ListSpaceRoutesStub        func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.RouteRecord, int, error)
+	listSpaceRoutesMutex       sync.RWMutex
+	listSpaceRoutesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}
+	listSpaceRoutesReturns struct {
+		result1 []repositories.RouteRecord
+		result2 int
+		result3 error
+	}
+	listSpaceRoutesReturnsOnCall map[int]struct {
+		result1 []repositories.RouteRecord
+		result2 int
+		result3 error
+	}
+	PatchRouteStub        func(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)
+	patchRouteMutex       sync.RWMutex
+	patchRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchRouteMessage
+	}
+	patchRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	patchRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -498,6 +532,143 @@ func (fake *CFRouteRepository) ListRoutesForAppReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ListSpaceRoutes(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 repositories.Page) ([]repositories.RouteRecord, int, error) {
+	fake.listSpaceRoutesMutex.Lock()
+	ret, specificReturn := fake.listSpaceRoutesReturnsOnCall[len(fake.listSpaceRoutesArgsForCall)]
+	fake.listSpaceRoutesArgsForCall = append(fake.listSpaceRoutesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 repositories.Page
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListSpaceRoutesStub
+	fakeReturns := fake.listSpaceRoutesReturns
+	fake.recordInvocation("ListSpaceRoutes", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listSpaceRoutesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *CFRouteRepository) ListSpaceRoutesCallCount() int {
+	fake.listSpaceRoutesMutex.RLock()
+	defer fake.listSpaceRoutesMutex.RUnlock()
+	return len(fake.listSpaceRoutesArgsForCall)
+}
+
+func (fake *CFRouteRepository) ListSpaceRoutesCalls(stub func(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.RouteRecord, int, error)) {
+	fake.listSpaceRoutesMutex.Lock()
+	defer fake.listSpaceRoutesMutex.Unlock()
+	fake.ListSpaceRoutesStub = stub
+}
+
+func (fake *CFRouteRepository) ListSpaceRoutesArgsForCall(i int) (context.Context, authorization.Info, string, string, repositories.Page) {
+	fake.listSpaceRoutesMutex.RLock()
+	defer fake.listSpaceRoutesMutex.RUnlock()
+	argsForCall := fake.listSpaceRoutesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *CFRouteRepository) ListSpaceRoutesReturns(result1 []repositories.RouteRecord, result2 int, result3 error) {
+	fake.listSpaceRoutesMutex.Lock()
+	defer fake.listSpaceRoutesMutex.Unlock()
+	fake.ListSpaceRoutesStub = nil
+	fake.listSpaceRoutesReturns = struct {
+		result1 []repositories.RouteRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CFRouteRepository) ListSpaceRoutesReturnsOnCall(i int, result1 []repositories.RouteRecord, result2 int, result3 error) {
+	fake.listSpaceRoutesMutex.Lock()
+	defer fake.listSpaceRoutesMutex.Unlock()
+	fake.ListSpaceRoutesStub = nil
+	if fake.listSpaceRoutesReturnsOnCall == nil {
+		fake.listSpaceRoutesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.RouteRecord
+			result2 int
+			result3 error
+		})
+	}
+	fake.listSpaceRoutesReturnsOnCall[i] = struct {
+		result1 []repositories.RouteRecord
+		result2 int
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *CFRouteRepository) PatchRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchRouteMessage) (repositories.RouteRecord, error) {
+	fake.patchRouteMutex.Lock()
+	ret, specificReturn := fake.patchRouteReturnsOnCall[len(fake.patchRouteArgsForCall)]
+	fake.patchRouteArgsForCall = append(fake.patchRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchRouteStub
+	fakeReturns := fake.patchRouteReturns
+	fake.recordInvocation("PatchRoute", []interface{}{arg1, arg2, arg3})
+	fake.patchRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) PatchRouteCallCount() int {
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
+	return len(fake.patchRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) PatchRouteCalls(stub func(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = stub
+}
+
+func (fake *CFRouteRepository) PatchRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchRouteMessage) {
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
+	argsForCall := fake.patchRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) PatchRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = nil
+	fake.patchRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) PatchRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = nil
+	if fake.patchRouteReturnsOnCall == nil {
+		fake.patchRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.patchRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -513,6 +684,10 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.listRoutesMutex.RUnlock()
 	fake.listRoutesForAppMutex.RLock()
 	defer fake.listRoutesForAppMutex.RUnlock()
+	fake.listSpaceRoutesMutex.RLock()
+	defer fake.listSpaceRoutesMutex.RUnlock()
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
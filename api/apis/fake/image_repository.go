@@ -8,17 +8,34 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/apis"
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
 type ImageRepository struct {
-	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, string) (string, error)
+	MatchResourcesStub        func(context.Context, authorization.Info, []repositories.PackageResource) ([]repositories.PackageResource, error)
+	matchResourcesMutex       sync.RWMutex
+	matchResourcesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 []repositories.PackageResource
+	}
+	matchResourcesReturns struct {
+		result1 []repositories.PackageResource
+		result2 error
+	}
+	matchResourcesReturnsOnCall map[int]struct {
+		result1 []repositories.PackageResource
+		result2 error
+	}
+	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, []repositories.PackageResource, string) (string, error)
 	uploadSourceImageMutex       sync.RWMutex
 	uploadSourceImageArgsForCall []struct {
 		arg1 context.Context
 		arg2 authorization.Info
 		arg3 string
 		arg4 io.Reader
-		arg5 string
+		arg5 []repositories.PackageResource
+		arg6 string
 	}
 	uploadSourceImageReturns struct {
 		result1 string
@@ -32,7 +49,83 @@ type ImageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string) (string, error) {
+func (fake *ImageRepository) MatchResources(arg1 context.Context, arg2 authorization.Info, arg3 []repositories.PackageResource) ([]repositories.PackageResource, error) {
+	var arg3Copy []repositories.PackageResource
+	if arg3 != nil {
+		arg3Copy = make([]repositories.PackageResource, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.matchResourcesMutex.Lock()
+	ret, specificReturn := fake.matchResourcesReturnsOnCall[len(fake.matchResourcesArgsForCall)]
+	fake.matchResourcesArgsForCall = append(fake.matchResourcesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 []repositories.PackageResource
+	}{arg1, arg2, arg3Copy})
+	stub := fake.MatchResourcesStub
+	fakeReturns := fake.matchResourcesReturns
+	fake.recordInvocation("MatchResources", []interface{}{arg1, arg2, arg3Copy})
+	fake.matchResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) MatchResourcesCallCount() int {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	return len(fake.matchResourcesArgsForCall)
+}
+
+func (fake *ImageRepository) MatchResourcesCalls(stub func(context.Context, authorization.Info, []repositories.PackageResource) ([]repositories.PackageResource, error)) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = stub
+}
+
+func (fake *ImageRepository) MatchResourcesArgsForCall(i int) (context.Context, authorization.Info, []repositories.PackageResource) {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	argsForCall := fake.matchResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageRepository) MatchResourcesReturns(result1 []repositories.PackageResource, result2 error) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	fake.matchResourcesReturns = struct {
+		result1 []repositories.PackageResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) MatchResourcesReturnsOnCall(i int, result1 []repositories.PackageResource, result2 error) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	if fake.matchResourcesReturnsOnCall == nil {
+		fake.matchResourcesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.PackageResource
+			result2 error
+		})
+	}
+	fake.matchResourcesReturnsOnCall[i] = struct {
+		result1 []repositories.PackageResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 []repositories.PackageResource, arg6 string) (string, error) {
+	var arg5Copy []repositories.PackageResource
+	if arg5 != nil {
+		arg5Copy = make([]repositories.PackageResource, len(arg5))
+		copy(arg5Copy, arg5)
+	}
 	fake.uploadSourceImageMutex.Lock()
 	ret, specificReturn := fake.uploadSourceImageReturnsOnCall[len(fake.uploadSourceImageArgsForCall)]
 	fake.uploadSourceImageArgsForCall = append(fake.uploadSourceImageArgsForCall, struct {
@@ -40,14 +133,15 @@ func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 author
 		arg2 authorization.Info
 		arg3 string
 		arg4 io.Reader
-		arg5 string
-	}{arg1, arg2, arg3, arg4, arg5})
+		arg5 []repositories.PackageResource
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5Copy, arg6})
 	stub := fake.UploadSourceImageStub
 	fakeReturns := fake.uploadSourceImageReturns
-	fake.recordInvocation("UploadSourceImage", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("UploadSourceImage", []interface{}{arg1, arg2, arg3, arg4, arg5Copy, arg6})
 	fake.uploadSourceImageMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -61,17 +155,17 @@ func (fake *ImageRepository) UploadSourceImageCallCount() int {
 	return len(fake.uploadSourceImageArgsForCall)
 }
 
-func (fake *ImageRepository) UploadSourceImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, string) (string, error)) {
+func (fake *ImageRepository) UploadSourceImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, []repositories.PackageResource, string) (string, error)) {
 	fake.uploadSourceImageMutex.Lock()
 	defer fake.uploadSourceImageMutex.Unlock()
 	fake.UploadSourceImageStub = stub
 }
 
-func (fake *ImageRepository) UploadSourceImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, string) {
+func (fake *ImageRepository) UploadSourceImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, []repositories.PackageResource, string) {
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	argsForCall := fake.uploadSourceImageArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *ImageRepository) UploadSourceImageReturns(result1 string, result2 error) {
@@ -103,6 +197,8 @@ func (fake *ImageRepository) UploadSourceImageReturnsOnCall(i int, result1 strin
 func (fake *ImageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
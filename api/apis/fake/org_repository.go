@@ -69,6 +69,21 @@ type OrgRepository struct {
 		result1 []repositories.OrgRecord
 		result2 error
 	}
+	PatchOrgStub        func(context.Context, authorization.Info, repositories.PatchOrgMessage) (repositories.OrgRecord, error)
+	patchOrgMutex       sync.RWMutex
+	patchOrgArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchOrgMessage
+	}
+	patchOrgReturns struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}
+	patchOrgReturnsOnCall map[int]struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -334,6 +349,72 @@ func (fake *OrgRepository) ListOrgsReturnsOnCall(i int, result1 []repositories.O
 	}{result1, result2}
 }
 
+func (fake *OrgRepository) PatchOrg(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchOrgMessage) (repositories.OrgRecord, error) {
+	fake.patchOrgMutex.Lock()
+	ret, specificReturn := fake.patchOrgReturnsOnCall[len(fake.patchOrgArgsForCall)]
+	fake.patchOrgArgsForCall = append(fake.patchOrgArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchOrgMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchOrgStub
+	fakeReturns := fake.patchOrgReturns
+	fake.recordInvocation("PatchOrg", []interface{}{arg1, arg2, arg3})
+	fake.patchOrgMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *OrgRepository) PatchOrgCallCount() int {
+	fake.patchOrgMutex.RLock()
+	defer fake.patchOrgMutex.RUnlock()
+	return len(fake.patchOrgArgsForCall)
+}
+
+func (fake *OrgRepository) PatchOrgCalls(stub func(context.Context, authorization.Info, repositories.PatchOrgMessage) (repositories.OrgRecord, error)) {
+	fake.patchOrgMutex.Lock()
+	defer fake.patchOrgMutex.Unlock()
+	fake.PatchOrgStub = stub
+}
+
+func (fake *OrgRepository) PatchOrgArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchOrgMessage) {
+	fake.patchOrgMutex.RLock()
+	defer fake.patchOrgMutex.RUnlock()
+	argsForCall := fake.patchOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *OrgRepository) PatchOrgReturns(result1 repositories.OrgRecord, result2 error) {
+	fake.patchOrgMutex.Lock()
+	defer fake.patchOrgMutex.Unlock()
+	fake.PatchOrgStub = nil
+	fake.patchOrgReturns = struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *OrgRepository) PatchOrgReturnsOnCall(i int, result1 repositories.OrgRecord, result2 error) {
+	fake.patchOrgMutex.Lock()
+	defer fake.patchOrgMutex.Unlock()
+	fake.PatchOrgStub = nil
+	if fake.patchOrgReturnsOnCall == nil {
+		fake.patchOrgReturnsOnCall = make(map[int]struct {
+			result1 repositories.OrgRecord
+			result2 error
+		})
+	}
+	fake.patchOrgReturnsOnCall[i] = struct {
+		result1 repositories.OrgRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *OrgRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -345,6 +426,8 @@ func (fake *OrgRepository) Invocations() map[string][][]interface{} {
 	defer fake.getOrgMutex.RUnlock()
 	fake.listOrgsMutex.RLock()
 	defer fake.listOrgsMutex.RUnlock()
+	fake.patchOrgMutex.RLock()
+	defer fake.patchOrgMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	workloads "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
@@ -124,6 +125,9 @@ var _ = Describe("App Handler", func() {
 			domainRepo,
 			orgRepo,
 			scaleAppProcess,
+			nil,
+			actions.NewJobRunner(logf.Log.WithName("integration tests"), identityProvider, repositories.NewJobRepo(rootNamespace, k8sClient), time.Minute, 100*time.Millisecond, time.Second),
+			new(fake.AuditEventRecorder),
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
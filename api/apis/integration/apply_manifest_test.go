@@ -35,6 +35,7 @@ var _ = Describe("POST /v3/spaces/<space-guid>/actions/apply_manifest endpoint",
 		domainRepo := repositories.NewDomainRepo(clientFactory, namespaceRetriever, rootNamespace)
 		processRepo := repositories.NewProcessRepo(namespaceRetriever, clientFactory, nsPermissions)
 		routeRepo := repositories.NewRouteRepo(namespaceRetriever, clientFactory, nsPermissions)
+		sidecarRepo := repositories.NewSidecarRepo(namespaceRetriever, clientFactory)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -42,8 +43,10 @@ var _ = Describe("POST /v3/spaces/<space-guid>/actions/apply_manifest endpoint",
 			logf.Log.WithName("integration tests"),
 			*serverURL,
 			domainName,
-			actions.NewApplyManifest(appRepo, domainRepo, processRepo, routeRepo).Invoke,
-			repositories.NewOrgRepo("cf", k8sClient, clientFactory, nsPermissions, 1*time.Minute),
+			actions.NewApplyManifest(appRepo, domainRepo, processRepo, routeRepo, sidecarRepo).Invoke,
+			actions.NewDiffManifest(appRepo, processRepo, routeRepo).Invoke,
+			repositories.NewOrgRepo(rootNamespace, k8sClient, clientFactory, nsPermissions, 1*time.Minute),
+			actions.NewJobRunner(logf.Log.WithName("integration tests"), identityProvider, repositories.NewJobRepo(rootNamespace, k8sClient), time.Minute, 100*time.Millisecond, time.Second),
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -150,7 +153,7 @@ var _ = Describe("POST /v3/spaces/<space-guid>/actions/apply_manifest endpoint",
 				Expect(err).NotTo(HaveOccurred())
 				Expect(body).To(BeEmpty())
 
-				Expect(rr.Header().Get("Location")).To(Equal(serverURI("/v3/jobs/space.apply_manifest-", namespace.Name)))
+				Expect(rr.Header().Get("Location")).To(HavePrefix(serverURI("/v3/jobs/")))
 
 				var app1 workloadsv1alpha1.CFApp
 				By("confirming that the app was created", func() {
@@ -501,7 +504,7 @@ var _ = Describe("POST /v3/spaces/<space-guid>/actions/apply_manifest endpoint",
 				Expect(err).NotTo(HaveOccurred())
 				Expect(body).To(BeEmpty())
 
-				Expect(rr.Header().Get("Location")).To(Equal(serverURI("/v3/jobs/space.apply_manifest-", namespace.Name)))
+				Expect(rr.Header().Get("Location")).To(HavePrefix(serverURI("/v3/jobs/")))
 
 				var app1 workloadsv1alpha1.CFApp
 				By("confirming that the app fields are unchanged", func() {
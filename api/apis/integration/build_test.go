@@ -24,7 +24,7 @@ var _ = Describe("Build", func() {
 	)
 
 	BeforeEach(func() {
-		buildRepo := repositories.NewBuildRepo(namespaceRetriever, clientFactory)
+		buildRepo := repositories.NewBuildRepo(namespaceRetriever, clientFactory, nsPermissions)
 		packageRepo := repositories.NewPackageRepo(clientFactory, namespaceRetriever, nsPermissions)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
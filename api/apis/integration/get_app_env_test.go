@@ -8,6 +8,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 
@@ -44,6 +45,9 @@ var _ = Describe("GET /v3/apps/:guid/env", func() {
 			domainRepo,
 			orgRepo,
 			scaleAppProcess,
+			nil,
+			actions.NewJobRunner(logf.Log.WithName("integration tests"), identityProvider, repositories.NewJobRepo(rootNamespace, k8sClient), time.Minute, 100*time.Millisecond, time.Second),
+			new(fake.AuditEventRecorder),
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -68,6 +68,7 @@ var (
 	rootNamespace         string
 	clientFactory         repositories.UserK8sClientFactory
 	nsPermissions         *authorization.NamespacePermissions
+	identityProvider      authorization.IdentityProvider
 )
 
 var _ = BeforeSuite(func() {
@@ -130,7 +131,7 @@ var _ = BeforeEach(func() {
 	clientFactory = repositories.NewUnprivilegedClientFactory(k8sConfig, mapper)
 	tokenInspector := authorization.NewTokenReviewer(k8sClient)
 	certInspector := authorization.NewCertInspector(k8sConfig)
-	identityProvider := authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
+	identityProvider = authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
 	nsPermissions = authorization.NewNamespacePermissions(k8sClient, identityProvider, rootNamespace)
 
 	userName = generateGUID()
@@ -29,6 +29,8 @@ var _ = Describe("Process", func() {
 			logf.Log.WithName("integration tests"),
 			*serverURL,
 			processRepo,
+			repositories.NewSidecarRepo(namespaceRetriever, clientFactory),
+			nil,
 			nil,
 			nil,
 			nil,
@@ -5,12 +5,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/apis"
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 )
 
@@ -27,11 +30,15 @@ var _ = Describe("Role", func() {
 			"organization_manager": {Name: "cf-organization-manager"},
 			"cf_user":              {Name: "cf-user"},
 		}
-		roleRepo := repositories.NewRoleRepo(k8sClient, clientFactory, nsPermissions, rootNamespace, roleMappings)
+		roleRepo := repositories.NewRoleRepo(k8sClient, clientFactory, nsPermissions, nsPermissions, rootNamespace, roleMappings)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
-		apiHandler = apis.NewRoleHandler(*serverURL, roleRepo, decoderValidator)
+		orgRepo := repositories.NewOrgRepo(rootNamespace, k8sClient, clientFactory, nsPermissions, time.Minute)
+		jobRunner := actions.NewJobRunner(logf.Log.WithName("integration tests"), identityProvider, repositories.NewJobRepo(rootNamespace, k8sClient), time.Minute, 100*time.Millisecond, time.Second)
+
+		userRepo := repositories.NewUserRepo(rootNamespace, k8sClient, clientFactory, nsPermissions)
+		apiHandler = apis.NewRoleHandler(*serverURL, roleRepo, userRepo, orgRepo, orgRepo, jobRunner, decoderValidator)
 		apiHandler.RegisterRoutes(router)
 
 		org = createOrgAnchorAndNamespace(ctx, rootNamespace, generateGUID())
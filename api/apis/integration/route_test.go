@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
 
@@ -45,6 +47,8 @@ var _ = Describe("Route Handler", func() {
 			domainRepo,
 			appRepo,
 			orgRepo,
+			actions.NewJobRunner(logf.Log.WithName("TestRouteHandler"), identityProvider, repositories.NewJobRepo(rootNamespace, k8sClient), time.Minute, 100*time.Millisecond, time.Second),
+			new(fake.AuditEventRecorder),
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
@@ -36,7 +37,7 @@ var _ = Describe("ServiceBinding Handler", func() {
 
 	BeforeEach(func() {
 		appRepo := repositories.NewAppRepo(namespaceRetriever, clientFactory, nsPermissions)
-		serviceInstanceRepo := repositories.NewServiceInstanceRepo(namespaceRetriever, clientFactory, nsPermissions)
+		serviceInstanceRepo := repositories.NewServiceInstanceRepo(rootNamespace, namespaceRetriever, clientFactory, nsPermissions)
 		serviceBindingRepo := repositories.NewServiceBindingRepo(namespaceRetriever, clientFactory, nsPermissions)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
@@ -47,6 +48,7 @@ var _ = Describe("ServiceBinding Handler", func() {
 			serviceBindingRepo,
 			appRepo,
 			serviceInstanceRepo,
+			new(fake.AuditEventRecorder),
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -1,81 +1,80 @@
 package apis
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"regexp"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 )
 
 const (
-	JobPath           = "/v3/jobs/{guid}"
-	syncSpacePrefix   = "space.apply_manifest"
-	appDeletePrefix   = "app.delete"
-	orgDeletePrefix   = "org.delete"
-	routeDeletePrefix = "route.delete"
-	spaceDeletePrefix = "space.delete"
+	JobPath = "/v3/jobs/{guid}"
 )
 
-const JobResourceType = "Job"
+//counterfeiter:generate -o fake -fake-name JobRepository . JobRepository
+
+type JobRepository interface {
+	GetJob(context.Context, string) (repositories.JobRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name JobRunner . JobRunner
+
+// JobRunner records asynchronous jobs, whose state is then reported by the /v3/jobs endpoint
+type JobRunner interface {
+	Start(context.Context, authorization.Info, repositories.CreateJobMessage, func(context.Context) ([]string, error)) (repositories.JobRecord, error)
+	StartDeletion(context.Context, authorization.Info, repositories.CreateJobMessage, func(context.Context) error) (repositories.JobRecord, error)
+	StartAwaiting(context.Context, authorization.Info, repositories.CreateJobMessage, func(context.Context) (bool, error)) (repositories.JobRecord, error)
+}
 
 type JobHandler struct {
-	logger    logr.Logger
-	serverURL url.URL
+	logger           logr.Logger
+	serverURL        url.URL
+	identityProvider IdentityProvider
+	jobRepo          JobRepository
 }
 
-func NewJobHandler(logger logr.Logger, serverURL url.URL) *JobHandler {
+func NewJobHandler(logger logr.Logger, serverURL url.URL, identityProvider IdentityProvider, jobRepo JobRepository) *JobHandler {
 	return &JobHandler{
-		logger:    logger,
-		serverURL: serverURL,
+		logger:           logger,
+		serverURL:        serverURL,
+		identityProvider: identityProvider,
+		jobRepo:          jobRepo,
 	}
 }
 
-func (h *JobHandler) jobGetHandler(_ authorization.Info, r *http.Request) (*HandlerResponse, error) {
+func (h *JobHandler) jobGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	vars := mux.Vars(r)
 	jobGUID := vars["guid"]
 
-	jobType, resourceGUID, match := parseJobGUID(jobGUID)
-
-	if !match {
-		h.logger.Info("Invalid Job GUID")
-		return nil, apierrors.NewNotFoundError(fmt.Errorf("invalid job guid: %s", jobGUID), JobResourceType)
+	job, err := h.jobRepo.GetJob(r.Context(), jobGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch job", "JobGUID", jobGUID)
+		return nil, err
 	}
 
-	var jobResponse presenter.JobResponse
+	identity, err := h.identityProvider.GetIdentity(r.Context(), authInfo)
+	if err != nil {
+		h.logger.Error(err, "Failed to get identity")
+		return nil, err
+	}
 
-	switch jobType {
-	case syncSpacePrefix:
-		jobResponse = presenter.ForManifestApplyJob(jobGUID, resourceGUID, h.serverURL)
-	case appDeletePrefix, orgDeletePrefix, spaceDeletePrefix, routeDeletePrefix:
-		jobResponse = presenter.ForDeleteJob(jobGUID, jobType, h.serverURL)
-	default:
-		h.logger.Info("Invalid Job type: %s", jobType)
-		return nil, apierrors.NewNotFoundError(fmt.Errorf("invalid job type: %s", jobType), JobResourceType)
+	// jobs are only visible to the identity that started them
+	if job.Creator.Kind != identity.Kind || job.Creator.Name != identity.Name {
+		return nil, apierrors.NewNotFoundError(fmt.Errorf("job %q was not created by %s %q", jobGUID, identity.Kind, identity.Name), repositories.JobResourceType)
 	}
 
-	return NewHandlerResponse(http.StatusOK).WithBody(jobResponse), nil
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForJob(job, h.serverURL)), nil
 }
 
 func (h *JobHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(JobPath).Methods("GET").HandlerFunc(w.Wrap(h.jobGetHandler))
 }
-
-func parseJobGUID(jobGUID string) (string, string, bool) {
-	// Match job.type-GUID and capture the job type and GUID for later use
-	jobRegexp := regexp.MustCompile("([a-z_-]+[.][a-z_]+)-(?:cf-[a-z]+-)?([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})")
-	matches := jobRegexp.FindStringSubmatch(jobGUID)
-
-	if len(matches) != 3 {
-		return "", "", false
-	} else {
-		return matches[1], matches[2], true
-	}
-}
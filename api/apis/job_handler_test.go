@@ -1,31 +1,59 @@
 package apis_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"github.com/go-http-utils/headers"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var _ = Describe("JobHandler", func() {
 	Describe("GET /v3/jobs endpoint", func() {
 		var (
-			resourceGUID string
-			jobGUID      string
-			req          *http.Request
+			jobGUID          string
+			resourceGUID     string
+			creator          authorization.Identity
+			identityProvider *fake.IdentityProvider
+			jobRepo          *fake.JobRepository
+			req              *http.Request
 		)
 
 		BeforeEach(func() {
+			jobGUID = uuid.NewString()
 			resourceGUID = uuid.NewString()
+			creator = authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}
+			identityProvider = new(fake.IdentityProvider)
+			identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.UserKind, Name: "alice", Groups: []string{"admins"}}, nil)
+			jobRepo = new(fake.JobRepository)
+			jobRepo.GetJobReturns(repositories.JobRecord{
+				GUID:         jobGUID,
+				Operation:    "app.delete",
+				ResourceGUID: resourceGUID,
+				State:        "PROCESSING",
+				Errors:       []repositories.JobError{},
+				Warnings:     []string{},
+				Creator:      creator,
+				CreatedAt:    "2022-04-01T12:00:00Z",
+				UpdatedAt:    "2022-04-01T12:00:05Z",
+			}, nil)
+
 			jobsHandler := apis.NewJobHandler(
 				logf.Log.WithName("TestJobsHandler"),
 				*serverURL,
+				identityProvider,
+				jobRepo,
 			)
 			jobsHandler.RegisterRoutes(router)
 		})
@@ -38,144 +66,129 @@ var _ = Describe("JobHandler", func() {
 			router.ServeHTTP(rr, req)
 		})
 
-		When("getting an existing job", func() {
+		It("fetches the job from the repository", func() {
+			Expect(jobRepo.GetJobCallCount()).To(Equal(1))
+			_, actualJobGUID := jobRepo.GetJobArgsForCall(0)
+			Expect(actualJobGUID).To(Equal(jobGUID))
+		})
+
+		It("gets the identity of the user", func() {
+			Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+			_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+		})
+
+		It("returns the job", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue(headers.ContentType, jsonHeader))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+				"created_at": "2022-04-01T12:00:00Z",
+				"errors": [],
+				"guid": "%[2]s",
+				"links": {
+					"self": {
+						"href": "%[1]s/v3/jobs/%[2]s"
+					}
+				},
+				"operation": "app.delete",
+				"state": "PROCESSING",
+				"updated_at": "2022-04-01T12:00:05Z",
+				"warnings": []
+			}`, defaultServerURL, jobGUID))))
+		})
+
+		When("the job is a failed space.apply_manifest job", func() {
 			BeforeEach(func() {
-				jobGUID = "space.apply_manifest-" + resourceGUID
+				jobRepo.GetJobReturns(repositories.JobRecord{
+					GUID:         jobGUID,
+					Operation:    "space.apply_manifest",
+					ResourceGUID: resourceGUID,
+					State:        "FAILED",
+					Errors: []repositories.JobError{{
+						Code:   10010,
+						Title:  "CF-ResourceNotFound",
+						Detail: "Domain not found",
+					}},
+					Warnings:  []string{"something looks odd"},
+					Creator:   creator,
+					CreatedAt: "2022-04-01T12:00:00Z",
+					UpdatedAt: "2022-04-01T12:00:05Z",
+				}, nil)
 			})
 
-			It("returns status 200 OK", func() {
-				Expect(rr.Code).To(Equal(http.StatusOK))
+			It("returns the job errors, warnings and space link", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+					"created_at": "2022-04-01T12:00:00Z",
+					"errors": [{
+						"code": 10010,
+						"title": "CF-ResourceNotFound",
+						"detail": "Domain not found"
+					}],
+					"guid": "%[2]s",
+					"links": {
+						"self": {
+							"href": "%[1]s/v3/jobs/%[2]s"
+						},
+						"space": {
+							"href": "%[1]s/v3/spaces/%[3]s"
+						}
+					},
+					"operation": "space.apply_manifest",
+					"state": "FAILED",
+					"updated_at": "2022-04-01T12:00:05Z",
+					"warnings": [{"detail": "something looks odd"}]
+				}`, defaultServerURL, jobGUID, resourceGUID))))
 			})
+		})
 
-			It("returns Content-Type as JSON in header", func() {
-				Expect(rr).To(HaveHTTPHeaderWithValue(headers.ContentType, jsonHeader))
+		When("the job does not exist", func() {
+			BeforeEach(func() {
+				jobRepo.GetJobReturns(repositories.JobRecord{}, apierrors.NewNotFoundError(nil, repositories.JobResourceType))
 			})
 
-			When("the existing job operation is space.apply-manifest", func() {
-				It("returns the job", func() {
-					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
-						"created_at": "",
-						"errors": null,
-						"guid": "%[2]s",
-						"links": {
-							"self": {
-								"href": "%[1]s/v3/jobs/%[2]s"
-							},
-							"space": {
-								"href": "%[1]s/v3/spaces/%[3]s"
-							}
-						},
-						"operation": "space.apply_manifest",
-						"state": "COMPLETE",
-						"updated_at": "",
-						"warnings": null
-						}`, defaultServerURL, jobGUID, resourceGUID)))
-				})
+			It("returns a not found error", func() {
+				expectNotFoundError("Job not found")
 			})
+		})
 
-			When("the existing job operation is app.delete", func() {
-				BeforeEach(func() {
-					jobGUID = "app.delete-" + resourceGUID
-				})
-
-				It("returns the job", func() {
-					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
-						"created_at": "",
-						"errors": null,
-						"guid": "%[2]s",
-						"links": {
-							"self": {
-								"href": "%[1]s/v3/jobs/%[2]s"
-							}
-						},
-						"operation": "app.delete",
-						"state": "COMPLETE",
-						"updated_at": "",
-						"warnings": null
-					}`, defaultServerURL, jobGUID)))
-				})
+		When("the job was created by someone else", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.UserKind, Name: "bob"}, nil)
 			})
 
-			When("the existing job operation is org.delete", func() {
-				BeforeEach(func() {
-					resourceGUID = "cf-org-" + uuid.NewString()
-					jobGUID = "org.delete-" + resourceGUID
-				})
-
-				It("returns the job", func() {
-					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
-						"created_at": "",
-						"errors": null,
-						"guid": "%[2]s",
-						"links": {
-							"self": {
-								"href": "%[1]s/v3/jobs/%[2]s"
-							}
-						},
-						"operation": "org.delete",
-						"state": "COMPLETE",
-						"updated_at": "",
-						"warnings": null
-					}`, defaultServerURL, jobGUID)))
-				})
+			It("returns a not found error", func() {
+				expectNotFoundError("Job not found")
 			})
+		})
 
-			When("the existing job operation is space.delete", func() {
-				BeforeEach(func() {
-					resourceGUID = "cf-space-" + uuid.NewString()
-					jobGUID = "space.delete-" + resourceGUID
-				})
-
-				It("returns the job", func() {
-					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
-						"created_at": "",
-						"errors": null,
-						"guid": "%[2]s",
-						"links": {
-							"self": {
-								"href": "%[1]s/v3/jobs/%[2]s"
-							}
-						},
-						"operation": "space.delete",
-						"state": "COMPLETE",
-						"updated_at": "",
-						"warnings": null
-					}`, defaultServerURL, jobGUID)))
-				})
+		When("the job was created by a service account of the same name", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{Kind: rbacv1.ServiceAccountKind, Name: "alice"}, nil)
 			})
 
-			When("the existing job operation is route.delete", func() {
-				BeforeEach(func() {
-					resourceGUID = "cf-route-" + uuid.NewString()
-					jobGUID = "route.delete-" + resourceGUID
-				})
-
-				It("returns the job", func() {
-					Expect(rr.Body).To(MatchJSON(fmt.Sprintf(`{
-						"created_at": "",
-						"errors": null,
-						"guid": "%[2]s",
-						"links": {
-							"self": {
-								"href": "%[1]s/v3/jobs/%[2]s"
-							}
-						},
-						"operation": "route.delete",
-						"state": "COMPLETE",
-						"updated_at": "",
-						"warnings": null
-					}`, defaultServerURL, jobGUID)))
-				})
+			It("returns a not found error", func() {
+				expectNotFoundError("Job not found")
 			})
 		})
 
-		When("guid provided is not a valid job guid", func() {
+		When("getting the identity fails", func() {
 			BeforeEach(func() {
-				jobGUID = "some-guid"
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
 			})
 
-			It("return an error", func() {
-				expectNotFoundError("Job not found")
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("fetching the job fails", func() {
+			BeforeEach(func() {
+				jobRepo.GetJobReturns(repositories.JobRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
 			})
 		})
 	})
@@ -1,24 +1,69 @@
 package apis
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"sort"
+	"strings"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
 )
 
 const (
-	LogCacheInfoPath = "/api/v1/info"
-	LogCacheReadPath = "/api/v1/read/{guid}"
-	logCacheVersion  = "2.11.4+cf-k8s"
+	LogCachePathPrefix = "/api/v1/"
+	LogCacheInfoPath   = "/api/v1/info"
+	LogCacheReadPath   = "/api/v1/read/{guid}"
+	logCacheVersion    = "2.11.4+cf-k8s"
 )
 
+//counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
+type PodRepository interface {
+	GetRuntimeLogsForApp(context.Context, authorization.Info, repositories.RuntimeLogsMessage) ([]repositories.LogRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name NamespacePermissions . NamespacePermissions
+type NamespacePermissions interface {
+	GetAuthorizedSpaceNamespaces(context.Context, authorization.Info) (map[string]bool, error)
+}
+
 // LogCacheHandler implements the minimal set of log-cache API endpoints/features necessary
-// to support the "cf push" workflow.
-// It does not support actually fetching and returning application logs at this time
-type LogCacheHandler struct{}
+// to support the "cf push" and "cf logs" workflows.
+// Log envelopes are built from the container logs of the app's LRP pods and of the kpack
+// pods that staged its latest build.
+type LogCacheHandler struct {
+	logger           logr.Logger
+	appRepo          CFAppRepository
+	buildRepo        CFBuildRepository
+	podRepo          PodRepository
+	nsPermissions    NamespacePermissions
+	decoderValidator *DecoderValidator
+}
 
-func NewLogCacheHandler() *LogCacheHandler {
-	return &LogCacheHandler{}
+func NewLogCacheHandler(
+	logger logr.Logger,
+	appRepo CFAppRepository,
+	buildRepo CFBuildRepository,
+	podRepo PodRepository,
+	nsPermissions NamespacePermissions,
+	decoderValidator *DecoderValidator,
+) *LogCacheHandler {
+	return &LogCacheHandler{
+		logger:           logger,
+		appRepo:          appRepo,
+		buildRepo:        buildRepo,
+		podRepo:          podRepo,
+		nsPermissions:    nsPermissions,
+		decoderValidator: decoderValidator,
+	}
 }
 
 func (h *LogCacheHandler) logCacheInfoHandler(w http.ResponseWriter, r *http.Request) {
@@ -28,18 +73,135 @@ func (h *LogCacheHandler) logCacheInfoHandler(w http.ResponseWriter, r *http.Req
 	})
 }
 
-func (h *LogCacheHandler) logCacheEmptyReadHandler(w http.ResponseWriter, r *http.Request) {
-	// Since we're not currently returning an app logs there is no need to check the validity of the app guid
-	// provided in the request. A full implementation of this endpoint needs to have the appropriate
-	// validity and authorization checks in place.
-	writeResponse(w, http.StatusOK, map[string]interface{}{
-		"envelopes": map[string]interface{}{
-			"batch": []interface{}{},
-		},
+func (h *LogCacheHandler) logCacheReadHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	appGUID := vars["guid"]
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	payload := new(payloads.LogRead)
+	decoder := schema.NewDecoder()
+	// log-cache clients send parameters we do not support (e.g. name_filter), these are ignored rather than rejected
+	decoder.IgnoreUnknownKeys(true)
+	if err := decoder.Decode(payload, r.Form); err != nil {
+		h.logger.Error(err, "Unable to decode request query parameters")
+		return nil, apierrors.NewMessageParseError(err)
+	}
+
+	if err := h.decoderValidator.validatePayload(payload); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	authorizedSpaces, err := h.nsPermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		h.logger.Error(err, "Failed to get authorized space namespaces")
+		return nil, err
+	}
+
+	if !authorizedSpaces[app.SpaceGUID] {
+		h.logger.Info("User is not authorized to read logs in space", "AppGUID", appGUID, "SpaceGUID", app.SpaceGUID)
+		return nil, apierrors.NewNotFoundError(nil, repositories.AppResourceType)
+	}
+
+	if !payload.IncludesLogs() {
+		return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForLogs(appGUID, nil)), nil
+	}
+
+	limit := payload.GetLimit()
+	// no container can contribute more than limit lines to the result: the newest ones when reading newest first, and
+	// the oldest ones otherwise
+	var tailLines *int64
+	if payload.Descending {
+		tailLines = &limit
+	}
+
+	logs, err := h.getBuildLogs(ctx, authInfo, app, payload.StartTime, tailLines, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	runtimeLogs, err := h.podRepo.GetRuntimeLogsForApp(ctx, authInfo, repositories.RuntimeLogsMessage{
+		SpaceGUID: app.SpaceGUID,
+		AppGUID:   app.GUID,
+		StartTime: payload.StartTime,
+		TailLines: tailLines,
+		Limit:     limit,
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app runtime logs", "AppGUID", appGUID)
+		return nil, err
+	}
+	logs = append(logs, runtimeLogs...)
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		if payload.Descending {
+			return logs[i].Timestamp > logs[j].Timestamp
+		}
+		return logs[i].Timestamp < logs[j].Timestamp
+	})
+
+	if int64(len(logs)) > limit {
+		logs = logs[:limit]
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForLogs(appGUID, logs)), nil
+}
+
+func (h *LogCacheHandler) getBuildLogs(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord, startTime int64, tailLines *int64, limit int64) ([]repositories.LogRecord, error) {
+	build, err := h.buildRepo.GetLatestBuildByAppGUID(ctx, authInfo, app.SpaceGUID, app.GUID)
+	if err != nil {
+		if errors.As(err, new(apierrors.NotFoundError)) {
+			return nil, nil
+		}
+		h.logger.Error(err, "Failed to fetch latest app build", "AppGUID", app.GUID)
+		return nil, err
+	}
+
+	buildLogs, err := h.buildRepo.GetBuildLogs(ctx, authInfo, repositories.BuildLogsMessage{
+		SpaceGUID: app.SpaceGUID,
+		BuildGUID: build.GUID,
+		StartTime: startTime,
+		TailLines: tailLines,
+		Limit:     limit,
 	})
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch build logs", "AppGUID", app.GUID, "BuildGUID", build.GUID)
+		return nil, err
+	}
+
+	return buildLogs, nil
 }
 
 func (h *LogCacheHandler) RegisterRoutes(router *mux.Router) {
+	w := NewLogCacheAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(LogCacheInfoPath).Methods("GET").HandlerFunc(h.logCacheInfoHandler)
-	router.Path(LogCacheReadPath).Methods("GET").HandlerFunc(h.logCacheEmptyReadHandler)
+	router.Path(LogCacheReadPath).Methods("GET").HandlerFunc(w.Wrap(h.logCacheReadHandler))
+}
+
+func isLogCacheRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, LogCachePathPrefix)
+}
+
+// presentLogCacheError writes errors the way log-cache does, so that log-cache clients such as the cf CLI
+// can handle them. Resources the user cannot see are reported as not found, like log-cache does for source IDs.
+func presentLogCacheError(w http.ResponseWriter, err error) {
+	var apiError apierrors.ApiError
+	if !errors.As(apierrors.ForbiddenAsNotFound(err), &apiError) {
+		apiError = apierrors.NewUnknownError(err)
+	}
+
+	NewHandlerResponse(apiError.HttpStatus()).
+		WithBody(presenter.ForLogCacheError(apiError.HttpStatus(), apiError.Detail())).
+		writeTo(w)
 }
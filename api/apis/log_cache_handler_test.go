@@ -1,23 +1,54 @@
 package apis_test
 
 import (
+	"errors"
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 var _ = Describe("LogCacheHandler", func() {
-	var req *http.Request
+	const (
+		appGUID   = "test-app-guid"
+		spaceGUID = "test-space-guid"
+		buildGUID = "test-build-guid"
+	)
+
+	var (
+		req           *http.Request
+		appRepo       *fake.CFAppRepository
+		buildRepo     *fake.CFBuildRepository
+		podRepo       *fake.PodRepository
+		nsPermissions *fake.NamespacePermissions
+	)
 
 	BeforeEach(func() {
-		handler := apis.NewLogCacheHandler()
-		handler.RegisterRoutes(router)
-	})
+		appRepo = new(fake.CFAppRepository)
+		buildRepo = new(fake.CFBuildRepository)
+		podRepo = new(fake.PodRepository)
+		nsPermissions = new(fake.NamespacePermissions)
+		nsPermissions.GetAuthorizedSpaceNamespacesReturns(map[string]bool{spaceGUID: true}, nil)
+
+		decoderValidator, err := apis.NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
 
-	JustBeforeEach(func() {
-		router.ServeHTTP(rr, req)
+		handler := apis.NewLogCacheHandler(
+			logf.Log.WithName("TestLogCacheHandler"),
+			appRepo,
+			buildRepo,
+			podRepo,
+			nsPermissions,
+			decoderValidator,
+		)
+		handler.RegisterRoutes(router)
 	})
 
 	Describe("the GET /api/v1/info endpoint", func() {
@@ -27,6 +58,10 @@ var _ = Describe("LogCacheHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		JustBeforeEach(func() {
+			router.ServeHTTP(rr, req)
+		})
+
 		It("returns status 200 OK", func() {
 			Expect(rr.Code).To(Equal(http.StatusOK))
 		})
@@ -43,24 +78,263 @@ var _ = Describe("LogCacheHandler", func() {
 	})
 
 	Describe("the GET /api/v1/read/<app-guid> endpoint", func() {
+		var queryString string
+
 		BeforeEach(func() {
+			queryString = ""
+
+			appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
+			buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{GUID: buildGUID}, nil)
+			buildRepo.GetBuildLogsReturns([]repositories.LogRecord{
+				{Message: "staging", Timestamp: 100, SourceType: "STG", InstanceID: "0"},
+				{Message: "staged", Timestamp: 300, SourceType: "STG", InstanceID: "0"},
+			}, nil)
+			podRepo.GetRuntimeLogsForAppReturns([]repositories.LogRecord{
+				{Message: "starting", Timestamp: 200, SourceType: "APP/PROC/WEB", InstanceID: "1"},
+				{Message: "started", Timestamp: 400, SourceType: "APP/PROC/WEB", InstanceID: "0"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
 			var err error
-			req, err = http.NewRequest("GET", "/api/v1/read/unused-app-guid", nil)
+			req, err = http.NewRequestWithContext(ctx, "GET", "/api/v1/read/"+appGUID+queryString, nil)
 			Expect(err).NotTo(HaveOccurred())
+			router.ServeHTTP(rr, req)
 		})
 
-		It("returns status 200 OK", func() {
-			Expect(rr.Code).To(Equal(http.StatusOK))
+		It("fetches the app using the auth info", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal(appGUID))
 		})
 
-		It("returns Content-Type as JSON in header", func() {
-			contentTypeHeader := rr.Header().Get("Content-Type")
-			Expect(contentTypeHeader).To(Equal(jsonHeader))
+		It("checks that the user can see the app space", func() {
+			Expect(nsPermissions.GetAuthorizedSpaceNamespacesCallCount()).To(Equal(1))
+			_, actualAuthInfo := nsPermissions.GetAuthorizedSpaceNamespacesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
 		})
 
-		It("returns a hardcoded list of empty log envelopes", func() {
-			expectedBody := `{"envelopes":{"batch":[]}}`
-			Expect(rr.Body).To(MatchJSON(expectedBody))
+		It("fetches the logs of the latest build", func() {
+			Expect(buildRepo.GetLatestBuildByAppGUIDCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID, actualAppGUID := buildRepo.GetLatestBuildByAppGUIDArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(buildRepo.GetBuildLogsCallCount()).To(Equal(1))
+			_, _, message := buildRepo.GetBuildLogsArgsForCall(0)
+			Expect(message).To(Equal(repositories.BuildLogsMessage{
+				SpaceGUID: spaceGUID,
+				BuildGUID: buildGUID,
+				Limit:     payloads.DefaultLogLimit,
+			}))
+		})
+
+		It("fetches the runtime logs of the app", func() {
+			Expect(podRepo.GetRuntimeLogsForAppCallCount()).To(Equal(1))
+			_, _, message := podRepo.GetRuntimeLogsForAppArgsForCall(0)
+			Expect(message).To(Equal(repositories.RuntimeLogsMessage{
+				SpaceGUID: spaceGUID,
+				AppGUID:   appGUID,
+				Limit:     payloads.DefaultLogLimit,
+			}))
+		})
+
+		It("returns the log envelopes in ascending timestamp order", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"envelopes": {
+					"batch": [
+						{
+							"timestamp": "100",
+							"source_id": "test-app-guid",
+							"instance_id": "0",
+							"tags": {"source_type": "STG"},
+							"log": {"payload": "c3RhZ2luZw==", "type": "OUT"}
+						},
+						{
+							"timestamp": "200",
+							"source_id": "test-app-guid",
+							"instance_id": "1",
+							"tags": {"source_type": "APP/PROC/WEB"},
+							"log": {"payload": "c3RhcnRpbmc=", "type": "OUT"}
+						},
+						{
+							"timestamp": "300",
+							"source_id": "test-app-guid",
+							"instance_id": "0",
+							"tags": {"source_type": "STG"},
+							"log": {"payload": "c3RhZ2Vk", "type": "OUT"}
+						},
+						{
+							"timestamp": "400",
+							"source_id": "test-app-guid",
+							"instance_id": "0",
+							"tags": {"source_type": "APP/PROC/WEB"},
+							"log": {"payload": "c3RhcnRlZA==", "type": "OUT"}
+						}
+					]
+				}
+			}`)
+		})
+
+		When("start_time is given", func() {
+			BeforeEach(func() {
+				queryString = "?start_time=150"
+			})
+
+			It("passes it to the repositories", func() {
+				_, _, buildMessage := buildRepo.GetBuildLogsArgsForCall(0)
+				Expect(buildMessage.StartTime).To(BeEquivalentTo(150))
+
+				_, _, runtimeMessage := podRepo.GetRuntimeLogsForAppArgsForCall(0)
+				Expect(runtimeMessage.StartTime).To(BeEquivalentTo(150))
+			})
+		})
+
+		When("limit is given", func() {
+			BeforeEach(func() {
+				queryString = "?limit=2"
+			})
+
+			It("stops reading each container log once the limit is reached", func() {
+				_, _, buildMessage := buildRepo.GetBuildLogsArgsForCall(0)
+				Expect(buildMessage.Limit).To(BeEquivalentTo(2))
+				Expect(buildMessage.TailLines).To(BeNil())
+
+				_, _, runtimeMessage := podRepo.GetRuntimeLogsForAppArgsForCall(0)
+				Expect(runtimeMessage.Limit).To(BeEquivalentTo(2))
+				Expect(runtimeMessage.TailLines).To(BeNil())
+			})
+		})
+
+		When("descending and limit are given", func() {
+			BeforeEach(func() {
+				queryString = "?descending=true&limit=2"
+			})
+
+			It("only reads the last lines of each container", func() {
+				_, _, buildMessage := buildRepo.GetBuildLogsArgsForCall(0)
+				Expect(buildMessage.TailLines).To(gstruct.PointTo(BeEquivalentTo(2)))
+
+				_, _, runtimeMessage := podRepo.GetRuntimeLogsForAppArgsForCall(0)
+				Expect(runtimeMessage.TailLines).To(gstruct.PointTo(BeEquivalentTo(2)))
+			})
+
+			It("returns the newest envelopes first, up to the limit", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchRegexp(`"timestamp":"400".*"timestamp":"300"`),
+					Not(ContainSubstring(`"timestamp":"200"`)),
+					Not(ContainSubstring(`"timestamp":"100"`)),
+				)))
+			})
+		})
+
+		When("the limit exceeds the log-cache maximum", func() {
+			BeforeEach(func() {
+				queryString = "?limit=1001"
+			})
+
+			It("returns a log-cache error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+					"error": "unprocessable entity",
+					"message": "Limit must be 1,000 or less"
+				}`)))
+			})
+		})
+
+		When("log envelopes are not requested", func() {
+			BeforeEach(func() {
+				queryString = "?envelope_types=GAUGE"
+			})
+
+			It("returns an empty batch without reading logs", func() {
+				expectJSONResponse(http.StatusOK, `{"envelopes":{"batch":[]}}`)
+				Expect(buildRepo.GetBuildLogsCallCount()).To(Equal(0))
+				Expect(podRepo.GetRuntimeLogsForAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("log envelopes are requested among other types", func() {
+			BeforeEach(func() {
+				queryString = "?envelope_types=GAUGE&envelope_types=LOG"
+			})
+
+			It("returns the logs", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(podRepo.GetRuntimeLogsForAppCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the app has never been built", func() {
+			BeforeEach(func() {
+				buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildResourceType))
+			})
+
+			It("returns the runtime logs only", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(buildRepo.GetBuildLogsCallCount()).To(Equal(0))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					ContainSubstring(`"timestamp":"200"`),
+					Not(ContainSubstring(`"timestamp":"100"`)),
+				)))
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a log-cache not found error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"error": "not found", "message": "App not found. Ensure it exists and you have access to it."}`)))
+			})
+		})
+
+		When("the user cannot see the app space", func() {
+			BeforeEach(func() {
+				nsPermissions.GetAuthorizedSpaceNamespacesReturns(map[string]bool{"another-space": true}, nil)
+			})
+
+			It("returns a log-cache not found error without reading logs", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"error": "not found", "message": "App not found. Ensure it exists and you have access to it."}`)))
+				Expect(buildRepo.GetBuildLogsCallCount()).To(Equal(0))
+				Expect(podRepo.GetRuntimeLogsForAppCallCount()).To(Equal(0))
+			})
+		})
+
+		When("getting the authorized spaces fails", func() {
+			BeforeEach(func() {
+				nsPermissions.GetAuthorizedSpaceNamespacesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns a log-cache error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusInternalServerError))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"error": "internal server error", "message": "An unknown error occurred."}`)))
+			})
+		})
+
+		When("fetching the build logs fails", func() {
+			BeforeEach(func() {
+				buildRepo.GetBuildLogsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusInternalServerError))
+			})
+		})
+
+		When("fetching the runtime logs fails", func() {
+			BeforeEach(func() {
+				podRepo.GetRuntimeLogsForAppReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusInternalServerError))
+			})
 		})
 	})
 })
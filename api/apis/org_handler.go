@@ -30,23 +30,28 @@ type CFOrgRepository interface {
 	ListOrgs(context.Context, authorization.Info, repositories.ListOrgsMessage) ([]repositories.OrgRecord, error)
 	DeleteOrg(context.Context, authorization.Info, repositories.DeleteOrgMessage) error
 	GetOrg(context.Context, authorization.Info, string) (repositories.OrgRecord, error)
+	PatchOrg(context.Context, authorization.Info, repositories.PatchOrgMessage) (repositories.OrgRecord, error)
 }
 
 type OrgHandler struct {
-	logger           logr.Logger
-	apiBaseURL       url.URL
-	orgRepo          CFOrgRepository
-	domainRepo       CFDomainRepository
-	decoderValidator *DecoderValidator
+	logger             logr.Logger
+	apiBaseURL         url.URL
+	orgRepo            CFOrgRepository
+	domainRepo         CFDomainRepository
+	jobRunner          JobRunner
+	auditEventRecorder AuditEventRecorder
+	decoderValidator   *DecoderValidator
 }
 
-func NewOrgHandler(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, decoderValidator *DecoderValidator) *OrgHandler {
+func NewOrgHandler(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, jobRunner JobRunner, auditEventRecorder AuditEventRecorder, decoderValidator *DecoderValidator) *OrgHandler {
 	return &OrgHandler{
-		logger:           controllerruntime.Log.WithName("Org Handler"),
-		apiBaseURL:       apiBaseURL,
-		orgRepo:          orgRepo,
-		domainRepo:       domainRepo,
-		decoderValidator: decoderValidator,
+		logger:             controllerruntime.Log.WithName("Org Handler"),
+		apiBaseURL:         apiBaseURL,
+		orgRepo:            orgRepo,
+		domainRepo:         domainRepo,
+		jobRunner:          jobRunner,
+		auditEventRecorder: auditEventRecorder,
+		decoderValidator:   decoderValidator,
 	}
 }
 
@@ -62,10 +67,36 @@ func (h *OrgHandler) orgCreateHandler(info authorization.Info, r *http.Request)
 		h.logger.Error(err, "Failed to create org", "Org Name", payload.Name)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(r.Context(), info, "audit.organization.create", repositories.AuditEventTarget{GUID: record.GUID, Type: "organization", Name: record.Name}, "", payload)
 
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForCreateOrg(record, h.apiBaseURL)), nil
 }
 
+func (h *OrgHandler) orgPatchHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	orgGUID := vars["guid"]
+
+	var payload payloads.OrgPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.orgRepo.GetOrg(ctx, info, orgGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch org", "OrgGUID", orgGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	record, err := h.orgRepo.PatchOrg(ctx, info, payload.ToMessage(orgGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch org", "OrgGUID", orgGUID)
+		return nil, err
+	}
+	h.auditEventRecorder.Record(ctx, info, "audit.organization.update", repositories.AuditEventTarget{GUID: record.GUID, Type: "organization", Name: record.Name}, "", payload)
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForCreateOrg(record, h.apiBaseURL)), nil
+}
+
 func (h *OrgHandler) orgDeleteHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -79,16 +110,40 @@ func (h *OrgHandler) orgDeleteHandler(info authorization.Info, r *http.Request)
 		h.logger.Error(err, "Failed to delete org", "OrgGUID", orgGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, info, "audit.organization.delete-request", repositories.AuditEventTarget{GUID: orgGUID, Type: "organization"}, "", nil)
+
+	job, err := h.jobRunner.StartDeletion(ctx, info, repositories.CreateJobMessage{
+		Operation:    repositories.OrgDeleteJobOperation,
+		ResourceGUID: orgGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.orgRepo.GetOrg(ctx, info, orgGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start org delete job", "OrgGUID", orgGUID)
+		return nil, err
+	}
 
-	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/org.delete-%s", h.apiBaseURL.String(), orgGUID)), nil
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.apiBaseURL.String(), job.GUID)), nil
 }
 
 func (h *OrgHandler) orgListHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 
+	if err := payloads.PaginationFromQuery(r.URL.Query()).Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	labelSelector := payloads.LabelSelectorFromQuery(r.URL.Query())
+	if err := labelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
 	names := parseCommaSeparatedList(r.URL.Query().Get("names"))
 
-	orgs, err := h.orgRepo.ListOrgs(ctx, info, repositories.ListOrgsMessage{Names: names})
+	orgs, err := h.orgRepo.ListOrgs(ctx, info, repositories.ListOrgsMessage{Names: names, LabelSelector: labelSelector.Selector})
 	if err != nil {
 		h.logger.Error(err, "failed to fetch orgs")
 		return nil, err
@@ -135,6 +190,11 @@ func (h *OrgHandler) orgListDomainHandler(info authorization.Info, r *http.Reque
 		}
 	}
 
+	if err = domainListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	domainList, err := h.domainRepo.ListDomains(ctx, info, domainListFilter.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch domain(s) from Kubernetes")
@@ -148,6 +208,7 @@ func (h *OrgHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(OrgsPath).Methods("GET").HandlerFunc(w.Wrap(h.orgListHandler))
 	router.Path(OrgPath).Methods("DELETE").HandlerFunc(w.Wrap(h.orgDeleteHandler))
+	router.Path(OrgPath).Methods("PATCH").HandlerFunc(w.Wrap(h.orgPatchHandler))
 	router.Path(OrgsPath).Methods("POST").HandlerFunc(w.Wrap(h.orgCreateHandler))
 	router.Path(OrgDomainsPath).Methods("GET").HandlerFunc(w.Wrap(h.orgListDomainHandler))
 }
@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/apis"
 	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -30,6 +31,9 @@ var _ = Describe("OrgHandler", func() {
 		orgRepo    *fake.OrgRepository
 		now        time.Time
 		domainRepo *fake.CFDomainRepository
+		jobRunner  *fake.JobRunner
+
+		auditEventRecorder *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
@@ -37,10 +41,12 @@ var _ = Describe("OrgHandler", func() {
 
 		orgRepo = new(fake.OrgRepository)
 		domainRepo = new(fake.CFDomainRepository)
+		jobRunner = new(fake.JobRunner)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
-		orgHandler = apis.NewOrgHandler(*serverURL, orgRepo, domainRepo, decoderValidator)
+		orgHandler = apis.NewOrgHandler(*serverURL, orgRepo, domainRepo, jobRunner, auditEventRecorder, decoderValidator)
 		orgHandler.RegisterRoutes(router)
 	})
 
@@ -104,6 +110,15 @@ var _ = Describe("OrgHandler", func() {
 				Expect(orgRecord.Labels).To(BeEmpty())
 				Expect(orgRecord.Annotations).To(BeEmpty())
 			})
+
+			It("records an audit.organization.create event", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, info, eventType, target, eventSpaceGUID, _ := auditEventRecorder.RecordArgsForCall(0)
+				Expect(info).To(Equal(authInfo))
+				Expect(eventType).To(Equal("audit.organization.create"))
+				Expect(target).To(Equal(repositories.AuditEventTarget{GUID: "t-h-e-o-r-g", Type: "organization", Name: "the-org"}))
+				Expect(eventSpaceGUID).To(BeEmpty())
+			})
 		})
 
 		When("the org repo returns an error", func() {
@@ -296,10 +311,10 @@ var _ = Describe("OrgHandler", func() {
                         "total_results": 2,
                         "total_pages": 1,
                         "first": {
-                            "href": "%[1]s/v3/organizations"
+                            "href": "%[1]s/v3/organizations?page=1&per_page=50"
                         },
                         "last": {
-                            "href": "%[1]s/v3/organizations"
+                            "href": "%[1]s/v3/organizations?page=1&per_page=50"
                         },
                         "next": null,
                         "previous": null
@@ -388,6 +403,7 @@ var _ = Describe("OrgHandler", func() {
 			request, err = http.NewRequestWithContext(ctx, http.MethodDelete, orgsBase+"/"+orgGUID, nil)
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Add(headers.Authorization, "Bearer my-token")
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
 		})
 
 		When("on the happy path", func() {
@@ -399,7 +415,22 @@ var _ = Describe("OrgHandler", func() {
 			})
 
 			It("responds with a job URL in a location header", func() {
-				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/org.delete-"+orgGUID))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+			})
+
+			It("starts an org.delete job that waits for the org to be gone", func() {
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(1))
+				_, _, message, getResource := jobRunner.StartDeletionArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    "org.delete",
+					ResourceGUID: orgGUID,
+				}))
+
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+				Expect(getResource(ctx)).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				_, info, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+				Expect(info).To(Equal(authInfo))
+				Expect(actualOrgGUID).To(Equal(orgGUID))
 			})
 
 			It("deletes the K8s record via the repository", func() {
@@ -410,6 +441,13 @@ var _ = Describe("OrgHandler", func() {
 					GUID: orgGUID,
 				}))
 			})
+
+			It("records an audit.organization.delete-request event", func() {
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, _, eventType, target, _, _ := auditEventRecorder.RecordArgsForCall(0)
+				Expect(eventType).To(Equal("audit.organization.delete-request"))
+				Expect(target.GUID).To(Equal(orgGUID))
+			})
 		})
 
 		When("invoking the delete org repository fails", func() {
@@ -422,6 +460,117 @@ var _ = Describe("OrgHandler", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("starting the delete job fails", func() {
+			BeforeEach(func() {
+				jobRunner.StartDeletionReturns(repositories.JobRecord{}, errors.New("unknown-error"))
+				router.ServeHTTP(rr, request)
+			})
+
+			It("returns unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("Patch Org", func() {
+		const orgGUID = "orgGUID"
+
+		makePatchRequest := func(requestBody string) {
+			request, err := http.NewRequestWithContext(ctx, http.MethodPatch, orgsBase+"/"+orgGUID, strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Add(headers.Authorization, "Bearer my-token")
+
+			router.ServeHTTP(rr, request)
+		}
+
+		BeforeEach(func() {
+			orgRepo.GetOrgReturns(repositories.OrgRecord{GUID: orgGUID, Name: "the-org"}, nil)
+			orgRepo.PatchOrgReturns(repositories.OrgRecord{
+				GUID:        orgGUID,
+				Name:        "new-name",
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{},
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}, nil)
+		})
+
+		When("on the happy path", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"name": "new-name", "metadata": {"labels": {"env": "prod", "deprecated": null}}}`)
+			})
+
+			It("patches the org via the repository", func() {
+				Expect(orgRepo.PatchOrgCallCount()).To(Equal(1))
+				_, info, message := orgRepo.PatchOrgArgsForCall(0)
+				Expect(info).To(Equal(authInfo))
+
+				newName, prod := "new-name", "prod"
+				Expect(message).To(Equal(repositories.PatchOrgMessage{
+					GUID: orgGUID,
+					Name: &newName,
+					MetadataPatch: repositories.MetadataPatch{
+						Labels: map[string]*string{"env": &prod, "deprecated": nil},
+					},
+				}))
+			})
+
+			It("returns the updated org", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+					"guid": "orgGUID",
+					"created_at": "2021-09-17T15:23:10Z",
+					"updated_at": "2021-09-17T15:23:10Z",
+					"name": "new-name",
+					"suspended": false,
+					"metadata": {
+						"labels": {"env": "prod"},
+						"annotations": {}
+					},
+					"relationships": {},
+					"links": {
+						"self": {
+							"href": "%[1]s/v3/organizations/orgGUID"
+						}
+					}
+				}`, rootURL))))
+			})
+		})
+
+		When("the org is not accessible", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+				makePatchRequest(`{"name": "new-name"}`)
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Org not found")
+				Expect(orgRepo.PatchOrgCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"suspended": "yes"}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(orgRepo.PatchOrgCallCount()).To(Equal(0))
+			})
+		})
+
+		When("patching the org fails", func() {
+			BeforeEach(func() {
+				orgRepo.PatchOrgReturns(repositories.OrgRecord{}, errors.New("boom"))
+				makePatchRequest(`{"name": "new-name"}`)
+			})
+
+			It("returns unknown error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("List Domains", func() {
@@ -469,10 +618,10 @@ var _ = Describe("OrgHandler", func() {
 					"total_results": 1,
 					"total_pages": 1,
 					"first": {
-						"href": "%[1]s/v3/organizations/%[6]s/domains"
+						"href": "%[1]s/v3/organizations/%[6]s/domains?page=1&per_page=50"
 					},
 					"last": {
-						"href": "%[1]s/v3/organizations/%[6]s/domains"
+						"href": "%[1]s/v3/organizations/%[6]s/domains?page=1&per_page=50"
 					},
 					"next": null,
 					"previous": null
@@ -542,10 +691,10 @@ var _ = Describe("OrgHandler", func() {
 						"total_results": 0,
 						"total_pages": 1,
 						"first": {
-							"href": "%[1]s/v3/organizations/%[2]s/domains"
+							"href": "%[1]s/v3/organizations/%[2]s/domains?page=1&per_page=50"
 						},
 						"last": {
-							"href": "%[1]s/v3/organizations/%[2]s/domains"
+							"href": "%[1]s/v3/organizations/%[2]s/domains?page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
@@ -574,7 +723,7 @@ var _ = Describe("OrgHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, page, per_page'")
 			})
 		})
 	})
@@ -0,0 +1,218 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	OrgQuotasPath             = "/v3/organization_quotas"
+	OrgQuotaPath              = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsPath = "/v3/organization_quotas/{guid}/relationships/organizations"
+)
+
+//counterfeiter:generate -o fake -fake-name CFOrgQuotaRepository . CFOrgQuotaRepository
+type CFOrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	PatchOrgQuota(context.Context, authorization.Info, repositories.PatchOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	DeleteOrgQuota(context.Context, authorization.Info, string) error
+	ApplyOrgQuota(context.Context, authorization.Info, string, []string) (repositories.OrgQuotaRecord, error)
+}
+
+type OrgQuotaHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	orgQuotaRepo     CFOrgQuotaRepository
+	jobRunner        JobRunner
+	decoderValidator *DecoderValidator
+}
+
+func NewOrgQuotaHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	orgQuotaRepo CFOrgQuotaRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *OrgQuotaHandler {
+	return &OrgQuotaHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		orgQuotaRepo:     orgQuotaRepo,
+		jobRunner:        jobRunner,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *OrgQuotaHandler) orgQuotaCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.OrgQuotaCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	orgQuota, err := h.orgQuotaRepo.CreateOrgQuota(ctx, authInfo, payload.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to create org quota", "name", payload.Name)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	orgQuotaGUID := mux.Vars(r)["guid"]
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, orgQuotaGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch org quota", "guid", orgQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.OrgQuotaList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in OrgQuota filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	orgQuotas, err := h.orgQuotaRepo.ListOrgQuotas(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list org quotas")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaList(orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaPatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	orgQuotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.OrgQuotaPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, orgQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch org quota", "guid", orgQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.PatchOrgQuota(ctx, authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch org quota", "guid", orgQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	orgQuotaGUID := mux.Vars(r)["guid"]
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, orgQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch org quota", "guid", orgQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.orgQuotaRepo.DeleteOrgQuota(ctx, authInfo, orgQuotaGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete org quota", "guid", orgQuotaGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.OrgQuotaDeleteJobOperation,
+		ResourceGUID: orgQuotaGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, orgQuotaGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start org quota delete job", "guid", orgQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+func (h *OrgQuotaHandler) orgQuotaApplyHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	orgQuotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.ToManyRelationship
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.orgQuotaRepo.GetOrgQuota(ctx, authInfo, orgQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch org quota", "guid", orgQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.ApplyOrgQuota(ctx, authInfo, orgQuotaGUID, payload.GUIDs())
+	if err != nil {
+		h.logger.Error(err, "Failed to apply org quota", "guid", orgQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuotaHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(OrgQuotasPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.orgQuotaCreateHandler))
+	router.Path(OrgQuotasPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.orgQuotaListHandler))
+	router.Path(OrgQuotaPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.orgQuotaGetHandler))
+	router.Path(OrgQuotaPath).Methods(http.MethodPatch).HandlerFunc(w.Wrap(h.orgQuotaPatchHandler))
+	router.Path(OrgQuotaPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.orgQuotaDeleteHandler))
+	router.Path(OrgQuotaOrganizationsPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.orgQuotaApplyHandler))
+}
@@ -0,0 +1,310 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("OrgQuotaHandler", func() {
+	var (
+		req          *http.Request
+		orgQuotaRepo *fake.CFOrgQuotaRepository
+		jobRunner    *fake.JobRunner
+		orgQuota     repositories.OrgQuotaRecord
+	)
+
+	int64Ptr := func(value int64) *int64 {
+		return &value
+	}
+
+	makeRequest := func(method, path, body string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		jobRunner = new(fake.JobRunner)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		orgQuota = repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				TotalMemoryMB: int64Ptr(10240),
+				AppInstances:  int64Ptr(20),
+				Routes:        int64Ptr(5),
+			},
+			OrgGUIDs:  []string{"org-guid"},
+			CreatedAt: "2019-05-10T17:17:48Z",
+			UpdatedAt: "2019-05-10T17:17:48Z",
+		}
+		orgQuotaRepo.GetOrgQuotaReturns(orgQuota, nil)
+
+		NewOrgQuotaHandler(
+			logf.Log.WithName("TestOrgQuotaHandler"),
+			*serverURL,
+			orgQuotaRepo,
+			jobRunner,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/organization_quotas endpoint", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.CreateOrgQuotaReturns(orgQuota, nil)
+			makeRequest(http.MethodPost, "/v3/organization_quotas", `{
+				"name": "my-quota",
+				"apps": {"total_memory_in_mb": 10240, "per_process_memory_in_mb": null, "total_instances": 20},
+				"services": {"paid_services_allowed": true},
+				"routes": {"total_routes": 5},
+				"relationships": {"organizations": {"data": [{"guid": "org-guid"}]}}
+			}`)
+		})
+
+		It("creates the org quota", func() {
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, _, message := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB: int64Ptr(10240),
+					AppInstances:  int64Ptr(20),
+					Routes:        int64Ptr(5),
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}))
+		})
+
+		It("returns 201 Created with the org quota", func() {
+			expectJSONResponse(http.StatusCreated, `{
+				"guid": "quota-guid",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": 10240,
+					"per_process_memory_in_mb": null,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": 20,
+					"per_app_tasks": null
+				},
+				"services": {
+					"paid_services_allowed": true,
+					"total_service_instances": null,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": 5,
+					"total_reserved_ports": null
+				},
+				"domains": {
+					"total_domains": null
+				},
+				"relationships": {
+					"organizations": {"data": [{"guid": "org-guid"}]}
+				},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/organization_quotas/quota-guid"}
+				}
+			}`)
+		})
+
+		When("a limit is negative", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/organization_quotas", `{"name": "my-quota", "routes": {"total_routes": -1}}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("TotalRoutes must be 0 or greater")
+				Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/organization_quotas", `{"apps": {"total_instances": 3}}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Name is a required field")
+			})
+		})
+
+		When("creating the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/organization_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodGet, "/v3/organization_quotas/quota-guid", "")
+		})
+
+		It("returns the org quota", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"quota-guid"`))
+		})
+
+		When("the org quota is forbidden", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Organization Quota not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/organization_quotas endpoint", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{orgQuota}, nil)
+			makeRequest(http.MethodGet, "/v3/organization_quotas?names=my-quota,other&organization_guids=org-guid", "")
+		})
+
+		It("lists the org quotas matching the filter", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(message.Names).To(ConsistOf("my-quota", "other"))
+			Expect(message.OrgGUIDs).To(ConsistOf("org-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"quota-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodGet, "/v3/organization_quotas?foo=bar", "")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, names, organization_guids, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/organization_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.PatchOrgQuotaReturns(orgQuota, nil)
+			makeRequest(http.MethodPatch, "/v3/organization_quotas/quota-guid", `{
+				"name": "new-name",
+				"apps": {"total_memory_in_mb": null, "total_instances": 30}
+			}`)
+		})
+
+		It("patches the limits that are mentioned, removing the null ones", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(orgQuotaRepo.PatchOrgQuotaCallCount()).To(Equal(1))
+			_, _, message := orgQuotaRepo.PatchOrgQuotaArgsForCall(0)
+			Expect(message.GUID).To(Equal("quota-guid"))
+			Expect(*message.Name).To(Equal("new-name"))
+			Expect(message.Limits).To(Equal(repositories.QuotaLimitsPatch{
+				TotalMemoryMB: repositories.QuotaLimitPatch{Set: true},
+				AppInstances:  repositories.QuotaLimitPatch{Set: true, Value: int64Ptr(30)},
+			}))
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Organization Quota not found")
+				Expect(orgQuotaRepo.PatchOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/organization_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+			makeRequest(http.MethodDelete, "/v3/organization_quotas/quota-guid", "")
+		})
+
+		It("deletes the org quota and returns the location of the delete job", func() {
+			Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID := orgQuotaRepo.DeleteOrgQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.OrgQuotaDeleteJobOperation,
+				ResourceGUID: "quota-guid",
+			}))
+		})
+
+		When("the org quota is still applied to orgs", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.DeleteOrgQuotaReturns(apierrors.NewUnprocessableEntityError(nil, "This quota is applied to one or more organizations. Remove this quota from all organizations before deleting."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("This quota is applied to one or more organizations. Remove this quota from all organizations before deleting.")
+				Expect(jobRunner.StartDeletionCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the POST /v3/organization_quotas/:guid/relationships/organizations endpoint", func() {
+		BeforeEach(func() {
+			orgQuota.OrgGUIDs = []string{"org-guid", "other-org-guid"}
+			orgQuotaRepo.ApplyOrgQuotaReturns(orgQuota, nil)
+			makeRequest(http.MethodPost, "/v3/organization_quotas/quota-guid/relationships/organizations", `{
+				"data": [{"guid": "other-org-guid"}]
+			}`)
+		})
+
+		It("applies the quota to the orgs", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID, orgGUIDs := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+			Expect(orgGUIDs).To(ConsistOf("other-org-guid"))
+		})
+
+		It("returns all the orgs the quota is applied to", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"data": [{"guid": "org-guid"}, {"guid": "other-org-guid"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"}
+				}
+			}`)
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, `Organizations with guids ["other-org-guid"] do not exist, or you do not have access to them.`))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(`Organizations with guids ["other-org-guid"] do not exist, or you do not have access to them.`)
+			})
+		})
+	})
+})
@@ -2,6 +2,8 @@ package apis
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
@@ -36,7 +38,8 @@ type CFPackageRepository interface {
 }
 
 type ImageRepository interface {
-	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string) (imageRefWithDigest string, err error)
+	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, resources []repositories.PackageResource, spaceGUID string) (imageRefWithDigest string, err error)
+	MatchResources(ctx context.Context, authInfo authorization.Info, resources []repositories.PackageResource) ([]repositories.PackageResource, error)
 }
 
 type PackageHandler struct {
@@ -113,6 +116,16 @@ func (h PackageHandler) packageListHandler(authInfo authorization.Info, r *http.
 		}
 	}
 
+	if err = packageListQueryParameters.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = packageListQueryParameters.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
 	records, err := h.packageRepo.ListPackages(r.Context(), authInfo, packageListQueryParameters.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "Error fetching package with repository", "error")
@@ -139,6 +152,11 @@ func (h PackageHandler) packageCreateHandler(authInfo authorization.Info, r *htt
 		)
 	}
 
+	if payload.Type == "docker" && appRecord.Lifecycle.Type != "docker" {
+		h.logger.Info("Cannot create a docker package for an app with a different lifecycle", "App GUID", appRecord.GUID)
+		return nil, apierrors.NewUnprocessableEntityError(nil, "Cannot create Docker package for a buildpack app.")
+	}
+
 	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
 	if err != nil {
 		h.logger.Info("Error creating package with repository", "error", err.Error())
@@ -156,12 +174,26 @@ func (h PackageHandler) packageUploadHandler(authInfo authorization.Info, r *htt
 		return nil, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
+	bitsFile, _, bitsErr := r.FormFile("bits")
+	if bitsErr == nil {
+		defer bitsFile.Close()
+	}
+
+	resources, err := h.parseUploadResources(r)
 	if err != nil {
-		h.logger.Info("Error reading form file \"bits\"", "error", err.Error())
-		return nil, apierrors.NewUnprocessableEntityError(err, "Upload must include bits")
+		return nil, err
+	}
+
+	// the bits can be omitted when every file of the package is a cached resource
+	var srcReader io.Reader
+	switch {
+	case bitsErr == nil:
+		srcReader = bitsFile
+	case errors.Is(bitsErr, http.ErrMissingFile) && len(resources) > 0:
+	default:
+		h.logger.Info("Error reading form file \"bits\"", "error", bitsErr.Error())
+		return nil, apierrors.NewUnprocessableEntityError(bitsErr, "Upload must include bits")
 	}
-	defer bitsFile.Close()
 
 	record, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
@@ -175,7 +207,7 @@ func (h PackageHandler) packageUploadHandler(authInfo authorization.Info, r *htt
 	}
 
 	imageRef := path.Join(h.registryBase, packageGUID)
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, imageRef, bitsFile, record.SpaceGUID)
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, imageRef, srcReader, resources, record.SpaceGUID)
 	if err != nil {
 		h.logger.Info("Error calling uploadSourceImage", "error", err.Error())
 		return nil, err
@@ -195,6 +227,27 @@ func (h PackageHandler) packageUploadHandler(authInfo authorization.Info, r *htt
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+// parseUploadResources reads the files of the package that were matched by the resource matches endpoint, and so are
+// not included in the bits. It expects the multipart form to be parsed already.
+func (h PackageHandler) parseUploadResources(r *http.Request) ([]repositories.PackageResource, error) {
+	resourcesField := r.FormValue("resources")
+	if resourcesField == "" {
+		return nil, nil
+	}
+
+	payload := payloads.ResourceMatches{}
+	if err := json.Unmarshal([]byte(resourcesField), &payload.Resources); err != nil {
+		h.logger.Info("Error decoding the resources of the upload", "error", err.Error())
+		return nil, apierrors.NewUnprocessableEntityError(err, "Resources must be an array of resource match objects")
+	}
+
+	if err := h.decoderValidator.validatePayload(payload); err != nil {
+		return nil, err
+	}
+
+	return payload.ToMessage(), nil
+}
+
 func (h PackageHandler) packageListDropletsHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	if err := r.ParseForm(); err != nil {
 		h.logger.Error(err, "Unable to parse request query parameters")
@@ -222,6 +275,11 @@ func (h PackageHandler) packageListDropletsHandler(authInfo authorization.Info,
 		}
 	}
 
+	if err = packageListDropletsQueryParams.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	packageGUID := mux.Vars(r)["guid"]
 	_, err = h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
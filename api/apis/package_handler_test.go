@@ -2,6 +2,7 @@ package apis_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -226,10 +227,10 @@ var _ = Describe("PackageHandler", func() {
 								"total_results":2,
 								"total_pages": 1,
 								"first": {
-									"href": "%[1]s/v3/packages"
+									"href": "%[1]s/v3/packages?page=1&per_page=50"
 								},
 								"last": {
-									"href": "%[1]s/v3/packages"
+									"href": "%[1]s/v3/packages?page=1&per_page=50"
 								},
 								"next": null,
 								"previous": null
@@ -380,14 +381,24 @@ var _ = Describe("PackageHandler", func() {
 
 		When("the 'per_page' parameter is sent", func() {
 			BeforeEach(func() {
-				queryParamString = "?per_page=some_weird_value"
+				queryParamString = "?per_page=1"
 			})
 
-			It("ignores it and returns status 200", func() {
+			It("returns status 200", func() {
 				Expect(rr.Code).To(Equal(http.StatusOK), "Matching HTTP response code:")
 			})
 		})
 
+		When("the 'per_page' parameter is not a number", func() {
+			BeforeEach(func() {
+				queryParamString = "?per_page=some_weird_value"
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Per page must be between 1 and 5000")
+			})
+		})
+
 		When("the 'states' parameter is sent", func() {
 			BeforeEach(func() {
 				queryParamString = "?states=READY,AWAITING_UPLOAD"
@@ -414,7 +425,7 @@ var _ = Describe("PackageHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, order_by, per_page, states'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, label_selector, order_by, page, per_page, states'")
 			})
 		})
 
@@ -439,10 +450,10 @@ var _ = Describe("PackageHandler", func() {
 								"total_results": 0,
 								"total_pages": 1,
 								"first": {
-									"href": "%[1]s/v3/packages"
+									"href": "%[1]s/v3/packages?page=1&per_page=50"
 								},
 								"last": {
-									"href": "%[1]s/v3/packages"
+									"href": "%[1]s/v3/packages?page=1&per_page=50"
 								},
 								"next": null,
 								"previous": null
@@ -618,7 +629,7 @@ var _ = Describe("PackageHandler", func() {
 		When("the type is invalid", func() {
 			BeforeEach(func() {
 				body = `{
-					"type": "docker",
+					"type": "rock",
 					"relationships": {
 						"app": {
 							"data": {
@@ -630,7 +641,110 @@ var _ = Describe("PackageHandler", func() {
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Type must be one of ['bits']")
+				expectUnprocessableEntityError("Type must be one of ['bits' 'docker']")
+			})
+		})
+
+		When("the package is a docker image", func() {
+			BeforeEach(func() {
+				body = `{
+					"type": "docker",
+					"data": {
+						"image": "registry.example.com/my/image:latest",
+						"username": "user",
+						"password": "pass"
+					},
+					"relationships": {
+						"app": {
+							"data": {
+								"guid": "` + appGUID + `"
+							}
+						}
+					}
+				}`
+
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					EtcdUID:   appUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:      "docker",
+					AppGUID:   appGUID,
+					SpaceGUID: spaceGUID,
+					GUID:      packageGUID,
+					State:     "READY",
+					ImageRef:  "registry.example.com/my/image:latest",
+				}, nil)
+			})
+
+			It("creates a package referencing the image and its credentials", func() {
+				Expect(rr.Code).To(Equal(http.StatusCreated))
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+				Expect(actualCreate.Type).To(Equal("docker"))
+				username, password := "user", "pass"
+				Expect(actualCreate.Data).To(Equal(&repositories.PackageData{
+					Image:    "registry.example.com/my/image:latest",
+					Username: &username,
+					Password: &password,
+				}))
+			})
+
+			It("presents the image of the package", func() {
+				var response map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("data", map[string]interface{}{
+					"image": "registry.example.com/my/image:latest",
+				}))
+			})
+
+			When("the image is missing", func() {
+				BeforeEach(func() {
+					body = `{
+						"type": "docker",
+						"relationships": { "app": { "data": { "guid": "` + appGUID + `" } } }
+					}`
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Data is a required field")
+				})
+
+				itDoesntCreateAPackage()
+			})
+
+			When("only the username is set", func() {
+				BeforeEach(func() {
+					body = `{
+						"type": "docker",
+						"data": { "image": "my/image", "username": "user" },
+						"relationships": { "app": { "data": { "guid": "` + appGUID + `" } } }
+					}`
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Password is a required field")
+				})
+
+				itDoesntCreateAPackage()
+			})
+
+			When("the app is a buildpack app", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						SpaceGUID: spaceGUID,
+						GUID:      appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Cannot create Docker package for a buildpack app.")
+				})
+
+				itDoesntCreateAPackage()
 			})
 		})
 
@@ -769,12 +883,13 @@ var _ = Describe("PackageHandler", func() {
 
 		It("uploads the image source", func() {
 			Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
-			_, actualAuthInfo, imageRef, srcFile, actualSpaceGUID := imageRepo.UploadSourceImageArgsForCall(0)
+			_, actualAuthInfo, imageRef, srcFile, actualResources, actualSpaceGUID := imageRepo.UploadSourceImageArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(imageRef).To(Equal(fmt.Sprintf("%s/%s", packageRegistryBase, packageGUID)))
 			actualSrcContents, err := io.ReadAll(srcFile)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(string(actualSrcContents)).To(Equal("the-src-file-contents"))
+			Expect(actualResources).To(BeEmpty())
 			Expect(actualSpaceGUID).To(Equal(spaceGUID))
 		})
 
@@ -889,6 +1004,81 @@ var _ = Describe("PackageHandler", func() {
 			itDoesntUpdateAnyPackages()
 		})
 
+		When("the upload includes cached resources", func() {
+			queueUploadWithResources := func(resources string) {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				Expect(writer.WriteField("resources", resources)).To(Succeed())
+				part, err := writer.CreateFormFile("bits", "unused.zip")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-src-file-contents"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writer.Close()).To(Succeed())
+				body = &b
+				formDataHeader = writer.FormDataContentType()
+			}
+
+			BeforeEach(func() {
+				queueUploadWithResources(`[{"checksum":{"value":"b907173290db6a155949ab4dc9b2d019dea0c901"},"size_in_bytes":65536,"path":"lib/app.jar","mode":"755"}]`)
+			})
+
+			It("uploads the image source with the resources", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+				_, _, _, srcFile, actualResources, _ := imageRepo.UploadSourceImageArgsForCall(0)
+				actualSrcContents, err := io.ReadAll(srcFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(actualSrcContents)).To(Equal("the-src-file-contents"))
+				Expect(actualResources).To(Equal([]repositories.PackageResource{{
+					SHA1:        "b907173290db6a155949ab4dc9b2d019dea0c901",
+					SizeInBytes: 65536,
+					Path:        "lib/app.jar",
+					Mode:        0o755,
+				}}))
+			})
+
+			When("the resources are not valid json", func() {
+				BeforeEach(func() {
+					queueUploadWithResources(`{"checksum"`)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Resources must be an array of resource match objects")
+				})
+				itDoesntUploadSourceImage()
+			})
+
+			When("a resource has an invalid mode", func() {
+				BeforeEach(func() {
+					queueUploadWithResources(`[{"checksum":{"value":"b907173290db6a155949ab4dc9b2d019dea0c901"},"size_in_bytes":65536,"path":"lib/app.jar","mode":"rwx"}]`)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Mode must be an octal file mode")
+				})
+				itDoesntUploadSourceImage()
+			})
+		})
+
+		When("the upload only has cached resources", func() {
+			BeforeEach(func() {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				Expect(writer.WriteField("resources", `[{"checksum":{"value":"b907173290db6a155949ab4dc9b2d019dea0c901"},"size_in_bytes":65536,"path":"lib/app.jar","mode":"644"}]`)).To(Succeed())
+				Expect(writer.Close()).To(Succeed())
+				body = &b
+				formDataHeader = writer.FormDataContentType()
+			})
+
+			It("uploads the image source without bits", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+				_, _, _, srcFile, actualResources, _ := imageRepo.UploadSourceImageArgsForCall(0)
+				Expect(srcFile).To(BeNil())
+				Expect(actualResources).To(HaveLen(1))
+			})
+		})
+
 		When("uploading the source image errors", func() {
 			BeforeEach(func() {
 				imageRepo.UploadSourceImageReturns("", errors.New("boom"))
@@ -1014,10 +1204,10 @@ var _ = Describe("PackageHandler", func() {
 							"total_results": 1,
 							"total_pages": 1,
 							"first": {
-								"href": "%[1]s/v3/packages/%[2]s/droplets"
+								"href": "%[1]s/v3/packages/%[2]s/droplets?page=1&per_page=50"
 							},
 							"last": {
-								"href": "%[1]s/v3/packages/%[2]s/droplets"
+								"href": "%[1]s/v3/packages/%[2]s/droplets?page=1&per_page=50"
 							},
 							"next": null,
 							"previous": null
@@ -1088,14 +1278,24 @@ var _ = Describe("PackageHandler", func() {
 
 		When("the \"per_page\" query parameter is provided", func() {
 			BeforeEach(func() {
-				queryString = "?per_page=SOME_WEIRD_VALUE"
+				queryString = "?per_page=1"
 			})
 
-			It("ignores it and returns status 200", func() {
+			It("returns status 200", func() {
 				Expect(rr.Code).To(Equal(http.StatusOK), "Matching HTTP response code:")
 			})
 		})
 
+		When("the \"per_page\" query parameter is not a number", func() {
+			BeforeEach(func() {
+				queryString = "?per_page=SOME_WEIRD_VALUE"
+			})
+
+			It("returns a bad query parameter error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Per page must be between 1 and 5000")
+			})
+		})
+
 		When("an error occurs while fetching the package", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{}, errors.New("boom"))
@@ -1133,7 +1333,7 @@ var _ = Describe("PackageHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'states, per_page'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'states, page, per_page'")
 			})
 		})
 	})
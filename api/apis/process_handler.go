@@ -2,9 +2,9 @@ package apis
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gorilla/schema"
 
@@ -23,6 +23,7 @@ const (
 	ProcessSidecarsPath = "/v3/processes/{guid}/sidecars"
 	ProcessScalePath    = "/v3/processes/{guid}/actions/scale"
 	ProcessStatsPath    = "/v3/processes/{guid}/stats"
+	ProcessInstancePath = "/v3/processes/{guid}/instances/{index}"
 	ProcessesPath       = "/v3/processes"
 )
 
@@ -30,6 +31,7 @@ const (
 type CFProcessRepository interface {
 	GetProcess(context.Context, authorization.Info, string) (repositories.ProcessRecord, error)
 	ListProcesses(context.Context, authorization.Info, repositories.ListProcessesMessage) ([]repositories.ProcessRecord, error)
+	ListAppProcesses(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.ProcessRecord, int, error)
 	GetProcessByAppTypeAndSpace(context.Context, authorization.Info, string, string, string) (repositories.ProcessRecord, error)
 	PatchProcess(context.Context, authorization.Info, repositories.PatchProcessMessage) (repositories.ProcessRecord, error)
 }
@@ -40,30 +42,39 @@ type ScaleProcess func(ctx context.Context, authInfo authorization.Info, process
 //counterfeiter:generate -o fake -fake-name FetchProcessStats . FetchProcessStats
 type FetchProcessStats func(context.Context, authorization.Info, string) ([]repositories.PodStatsRecord, error)
 
+//counterfeiter:generate -o fake -fake-name DeleteProcessInstance . DeleteProcessInstance
+type DeleteProcessInstance func(ctx context.Context, authInfo authorization.Info, processGUID string, index int) error
+
 type ProcessHandler struct {
-	logger            logr.Logger
-	serverURL         url.URL
-	processRepo       CFProcessRepository
-	fetchProcessStats FetchProcessStats
-	scaleProcess      ScaleProcess
-	decoderValidator  *DecoderValidator
+	logger                logr.Logger
+	serverURL             url.URL
+	processRepo           CFProcessRepository
+	sidecarRepo           CFSidecarRepository
+	fetchProcessStats     FetchProcessStats
+	scaleProcess          ScaleProcess
+	deleteProcessInstance DeleteProcessInstance
+	decoderValidator      *DecoderValidator
 }
 
 func NewProcessHandler(
 	logger logr.Logger,
 	serverURL url.URL,
 	processRepo CFProcessRepository,
+	sidecarRepo CFSidecarRepository,
 	fetchProcessStats FetchProcessStats,
 	scaleProcessFunc ScaleProcess,
+	deleteProcessInstanceFunc DeleteProcessInstance,
 	decoderValidator *DecoderValidator,
 ) *ProcessHandler {
 	return &ProcessHandler{
-		logger:            logger,
-		serverURL:         serverURL,
-		processRepo:       processRepo,
-		fetchProcessStats: fetchProcessStats,
-		scaleProcess:      scaleProcessFunc,
-		decoderValidator:  decoderValidator,
+		logger:                logger,
+		serverURL:             serverURL,
+		processRepo:           processRepo,
+		sidecarRepo:           sidecarRepo,
+		fetchProcessStats:     fetchProcessStats,
+		scaleProcess:          scaleProcessFunc,
+		deleteProcessInstance: deleteProcessInstanceFunc,
+		decoderValidator:      decoderValidator,
 	}
 }
 
@@ -88,27 +99,23 @@ func (h *ProcessHandler) processGetSidecarsHandler(authInfo authorization.Info,
 	vars := mux.Vars(r)
 	processGUID := vars["guid"]
 
-	_, err := h.processRepo.GetProcess(ctx, authInfo, processGUID)
+	process, err := h.processRepo.GetProcess(ctx, authInfo, processGUID)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
 		return nil, apierrors.ForbiddenAsNotFound(err)
 	}
 
-	return NewHandlerResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
+	sidecars, err := h.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUID:      process.AppGUID,
+		SpaceGUID:    process.SpaceGUID,
+		ProcessTypes: []string{process.Type},
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to list sidecars of process", "ProcessGUID", processGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSidecarList(sidecars, h.serverURL, *r.URL)), nil
 }
 
 func (h *ProcessHandler) processScaleHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
@@ -131,6 +138,26 @@ func (h *ProcessHandler) processScaleHandler(authInfo authorization.Info, r *htt
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForProcess(processRecord, h.serverURL)), nil
 }
 
+func (h *ProcessHandler) processDeleteInstanceHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	processGUID := vars["guid"]
+
+	index, err := parseInstanceIndex(vars["index"])
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.deleteProcessInstance(ctx, authInfo, processGUID, index)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete process instance", "ProcessGUID", processGUID, "Index", index)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
 func (h *ProcessHandler) processGetStatsHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 
@@ -176,6 +203,16 @@ func (h *ProcessHandler) processListHandler(authInfo authorization.Info, r *http
 		}
 	}
 
+	if err = processListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = processListFilter.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
 	processList, err := h.processRepo.ListProcesses(ctx, authInfo, processListFilter.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch processes(s) from Kubernetes")
@@ -217,6 +254,18 @@ func (h *ProcessHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(ProcessSidecarsPath).Methods("GET").HandlerFunc(w.Wrap(h.processGetSidecarsHandler))
 	router.Path(ProcessScalePath).Methods("POST").HandlerFunc(w.Wrap(h.processScaleHandler))
 	router.Path(ProcessStatsPath).Methods("GET").HandlerFunc(w.Wrap(h.processGetStatsHandler))
+	router.Path(ProcessInstancePath).Methods("DELETE").HandlerFunc(w.Wrap(h.processDeleteInstanceHandler))
 	router.Path(ProcessesPath).Methods("GET").HandlerFunc(w.Wrap(h.processListHandler))
 	router.Path(ProcessPath).Methods("PATCH").HandlerFunc(w.Wrap(h.processPatchHandler))
 }
+
+// parseInstanceIndex parses the instance index of a process from a request path.
+// Anything that is not a non-negative integer can never match an instance.
+func parseInstanceIndex(indexParam string) (int, error) {
+	index, err := strconv.Atoi(indexParam)
+	if err != nil || index < 0 {
+		return 0, apierrors.NewNotFoundError(err, repositories.InstanceResourceType)
+	}
+
+	return index, nil
+}
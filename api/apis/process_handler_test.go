@@ -23,16 +23,20 @@ var _ = Describe("ProcessHandler", func() {
 	)
 
 	var (
-		processRepo       *fake.CFProcessRepository
-		fetchProcessStats *fake.FetchProcessStats
-		scaleProcessFunc  *fake.ScaleProcess
-		req               *http.Request
+		processRepo           *fake.CFProcessRepository
+		sidecarRepo           *fake.CFSidecarRepository
+		fetchProcessStats     *fake.FetchProcessStats
+		scaleProcessFunc      *fake.ScaleProcess
+		deleteProcessInstance *fake.DeleteProcessInstance
+		req                   *http.Request
 	)
 
 	BeforeEach(func() {
 		processRepo = new(fake.CFProcessRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		fetchProcessStats = new(fake.FetchProcessStats)
 		scaleProcessFunc = new(fake.ScaleProcess)
+		deleteProcessInstance = new(fake.DeleteProcessInstance)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -40,8 +44,10 @@ var _ = Describe("ProcessHandler", func() {
 			logf.Log.WithName(testAppHandlerLoggerName),
 			*serverURL,
 			processRepo,
+			sidecarRepo,
 			fetchProcessStats.Spy,
 			scaleProcessFunc.Spy,
+			deleteProcessInstance.Spy,
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -187,7 +193,24 @@ var _ = Describe("ProcessHandler", func() {
 
 	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
 		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      processGUID,
+				SpaceGUID: "space-guid",
+				AppGUID:   "app-guid",
+				Type:      "web",
+			}, nil)
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{{
+				GUID:         "sidecar-guid",
+				Name:         "apm-agent",
+				Command:      "./apm-agent",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+				Origin:       "user",
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				CreatedAt:    "1906-04-18T13:12:00Z",
+				UpdatedAt:    "1906-04-18T13:12:01Z",
+			}}, nil)
 
 			var err error
 			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/processes/"+processGUID+"/sidecars", nil)
@@ -205,28 +228,65 @@ var _ = Describe("ProcessHandler", func() {
 				Expect(actualAuthInfo).To(Equal(authInfo))
 			})
 
-			It("returns a canned response with the processGUID in it", func() {
+			It("lists the sidecars of the process type", func() {
+				Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := sidecarRepo.ListSidecarsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message).To(Equal(repositories.ListSidecarsMessage{
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					ProcessTypes: []string{"web"},
+				}))
+			})
+
+			It("returns the sidecars of the process", func() {
 				contentTypeHeader := rr.Header().Get("Content-Type")
 				Expect(contentTypeHeader).To(Equal(jsonHeader), "Matching Content-Type header:")
 
 				Expect(rr.Body.String()).To(MatchJSON(fmt.Sprintf(`{
 					"pagination": {
-						"total_results": 0,
+						"total_results": 1,
 						"total_pages": 1,
 						"first": {
-							"href": "%[1]s/v3/processes/%[2]s/sidecars"
+							"href": "%[1]s/v3/processes/%[2]s/sidecars?page=1&per_page=50"
 						},
 						"last": {
-							"href": "%[1]s/v3/processes/%[2]s/sidecars"
+							"href": "%[1]s/v3/processes/%[2]s/sidecars?page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
 					},
-					"resources": []
+					"resources": [{
+						"guid": "sidecar-guid",
+						"name": "apm-agent",
+						"command": "./apm-agent",
+						"process_types": ["web", "worker"],
+						"memory_in_mb": 64,
+						"origin": "user",
+						"relationships": {
+							"app": {
+								"data": {
+									"guid": "app-guid"
+								}
+							}
+						},
+						"created_at": "1906-04-18T13:12:00Z",
+						"updated_at": "1906-04-18T13:12:01Z"
+					}]
 				}`, defaultServerURL, processGUID)), "Response body matches response:")
 			})
 		})
 
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("the process isn't accessible to the user", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
@@ -656,6 +716,81 @@ var _ = Describe("ProcessHandler", func() {
 		})
 	})
 
+	Describe("the DELETE /v3/processes/:guid/instances/:index endpoint", func() {
+		makeDeleteInstanceRequest := func(index string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/processes/"+processGUID+"/instances/"+index, nil)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			makeDeleteInstanceRequest("1")
+		})
+
+		It("deletes the process instance and responds with 204 No Content", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+			Expect(rr.Body.String()).To(BeEmpty())
+
+			Expect(deleteProcessInstance.CallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID, actualIndex := deleteProcessInstance.ArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal(processGUID))
+			Expect(actualIndex).To(Equal(1))
+		})
+
+		When("the index is not a number", func() {
+			BeforeEach(func() {
+				makeDeleteInstanceRequest("one")
+			})
+
+			It("returns a not found error without deleting anything", func() {
+				expectNotFoundError("Instance not found")
+				Expect(deleteProcessInstance.CallCount()).To(BeZero())
+			})
+		})
+
+		When("the index is negative", func() {
+			BeforeEach(func() {
+				makeDeleteInstanceRequest("-1")
+			})
+
+			It("returns a not found error without deleting anything", func() {
+				expectNotFoundError("Instance not found")
+				Expect(deleteProcessInstance.CallCount()).To(BeZero())
+			})
+		})
+
+		When("the process instance does not exist", func() {
+			BeforeEach(func() {
+				deleteProcessInstance.Returns(apierrors.NewNotFoundError(nil, repositories.InstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Instance not found")
+			})
+		})
+
+		When("the user is not allowed to delete the instance", func() {
+			BeforeEach(func() {
+				deleteProcessInstance.Returns(apierrors.NewForbiddenError(nil, repositories.InstanceResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("deleting the instance fails", func() {
+			BeforeEach(func() {
+				deleteProcessInstance.Returns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the GET /v3/processes endpoint", func() {
 		const (
 			processGUID     = "process-guid"
@@ -722,10 +857,10 @@ var _ = Describe("ProcessHandler", func() {
 					"total_results": 1,
 					"total_pages": 1,
 					"first": {
-						"href": "`+baseURL+`/v3/processes"
+						"href": "`+baseURL+`/v3/processes?page=1&per_page=50"
 					},
 					"last": {
-						"href": "`+baseURL+`/v3/processes"
+						"href": "`+baseURL+`/v3/processes?page=1&per_page=50"
 					},
 					"next": null,
 					"previous": null
@@ -808,7 +943,7 @@ var _ = Describe("ProcessHandler", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, label_selector, page, per_page'")
 			})
 		})
 
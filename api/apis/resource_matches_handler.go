@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"github.com/go-logr/logr"
 	"github.com/gorilla/mux"
 )
@@ -13,19 +15,32 @@ const (
 )
 
 type ResourceMatchesHandler struct {
-	logger logr.Logger
+	logger           logr.Logger
+	imageRepo        ImageRepository
+	decoderValidator *DecoderValidator
 }
 
-func NewResourceMatchesHandler(logger logr.Logger) *ResourceMatchesHandler {
+func NewResourceMatchesHandler(logger logr.Logger, imageRepo ImageRepository, decoderValidator *DecoderValidator) *ResourceMatchesHandler {
 	return &ResourceMatchesHandler{
-		logger: logger,
+		logger:           logger,
+		imageRepo:        imageRepo,
+		decoderValidator: decoderValidator,
 	}
 }
 
-func (h *ResourceMatchesHandler) resourceMatchesPostHandler(_ authorization.Info, r *http.Request) (*HandlerResponse, error) {
-	return NewHandlerResponse(http.StatusCreated).WithBody(map[string]interface{}{
-		"resources": []interface{}{},
-	}), nil
+func (h *ResourceMatchesHandler) resourceMatchesPostHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	var payload payloads.ResourceMatches
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	matches, err := h.imageRepo.MatchResources(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		h.logger.Info("Error matching resources", "error", err.Error())
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForResourceMatches(matches)), nil
 }
 
 func (h *ResourceMatchesHandler) RegisterRoutes(router *mux.Router) {
@@ -1,10 +1,13 @@
 package apis_test
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,10 +15,17 @@ import (
 )
 
 var _ = Describe("ResourceMatchesHandler", func() {
-	var req *http.Request
+	var (
+		req       *http.Request
+		imageRepo *fake.ImageRepository
+	)
 
 	BeforeEach(func() {
-		handler := NewResourceMatchesHandler(logf.Log.WithName("TestResourceMatchesHandler"))
+		imageRepo = new(fake.ImageRepository)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		handler := NewResourceMatchesHandler(logf.Log.WithName("TestResourceMatchesHandler"), imageRepo, decoderValidator)
 		handler.RegisterRoutes(router)
 	})
 
@@ -23,10 +33,32 @@ var _ = Describe("ResourceMatchesHandler", func() {
 		router.ServeHTTP(rr, req)
 	})
 
-	Describe("Get Resource Match Endpoint", func() {
+	Describe("Create Resource Match Endpoint", func() {
 		BeforeEach(func() {
+			imageRepo.MatchResourcesReturns([]repositories.PackageResource{{
+				SHA1:        "002d760bea1be268e27077412e11a320d0f164d3",
+				SizeInBytes: 65536,
+				Path:        "lib/app.jar",
+				Mode:        0o644,
+			}}, nil)
+
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("{}"))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader(`{
+				"resources": [
+					{
+						"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+						"size_in_bytes": 65536,
+						"path": "lib/app.jar",
+						"mode": "644"
+					},
+					{
+						"checksum": { "value": "a9993e364706816aba3e25717850c26c9cd0d89d" },
+						"size_in_bytes": 70000,
+						"path": "lib/new.jar",
+						"mode": "755"
+					}
+				]
+			}`))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -39,10 +71,61 @@ var _ = Describe("ResourceMatchesHandler", func() {
 			Expect(contentTypeHeader).To(Equal(jsonHeader), "Matching Content-Type header:")
 		})
 
-		It("returns a CF API formatted Error response", func() {
+		It("matches the resources in the request", func() {
+			Expect(imageRepo.MatchResourcesCallCount()).To(Equal(1))
+			_, actualAuthInfo, resources := imageRepo.MatchResourcesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(resources).To(Equal([]repositories.PackageResource{
+				{SHA1: "002d760bea1be268e27077412e11a320d0f164d3", SizeInBytes: 65536, Path: "lib/app.jar", Mode: 0o644},
+				{SHA1: "a9993e364706816aba3e25717850c26c9cd0d89d", SizeInBytes: 70000, Path: "lib/new.jar", Mode: 0o755},
+			}))
+		})
+
+		It("returns the matched resources", func() {
 			Expect(rr.Body.String()).To(MatchJSON(`{
-				"resources": []
-			  }`), "Response body matches response:")
+				"resources": [
+					{
+						"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+						"size_in_bytes": 65536,
+						"path": "lib/app.jar",
+						"mode": "644"
+					}
+				]
+			}`), "Response body matches response:")
+		})
+
+		When("no resource matches", func() {
+			BeforeEach(func() {
+				imageRepo.MatchResourcesReturns([]repositories.PackageResource{}, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr.Body.String()).To(MatchJSON(`{"resources": []}`))
+			})
+		})
+
+		When("a resource has no checksum", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader(`{
+					"resources": [{ "size_in_bytes": 65536, "path": "lib/app.jar", "mode": "644" }]
+				}`))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Value is a required field")
+			})
+		})
+
+		When("matching the resources fails", func() {
+			BeforeEach(func() {
+				imageRepo.MatchResourcesReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
 		})
 	})
 })
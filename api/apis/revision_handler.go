@@ -0,0 +1,162 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	RevisionPath             = "/v3/revisions/{guid}"
+	AppRevisionsPath         = "/v3/apps/{guid}/revisions"
+	AppDeployedRevisionsPath = "/v3/apps/{guid}/revisions/deployed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+	ListDeployedRevisions(context.Context, authorization.Info, string, string) ([]repositories.RevisionRecord, error)
+}
+
+type RevisionHandler struct {
+	logger       logr.Logger
+	serverURL    url.URL
+	appRepo      CFAppRepository
+	revisionRepo CFRevisionRepository
+}
+
+func NewRevisionHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	revisionRepo CFRevisionRepository,
+) *RevisionHandler {
+	return &RevisionHandler{
+		logger:       logger,
+		serverURL:    serverURL,
+		appRepo:      appRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (h *RevisionHandler) revisionGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	revisionGUID := mux.Vars(r)["guid"]
+
+	revision, err := h.revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch revision", "RevisionGUID", revisionGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *RevisionHandler) appRevisionListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	listFilter := new(payloads.RevisionList)
+	if err := h.decodeListFilter(r, listFilter); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	message := listFilter.ToMessage()
+	message.AppGUID = app.GUID
+	message.SpaceGUID = app.SpaceGUID
+	revisions, err := h.revisionRepo.ListRevisions(ctx, authInfo, message)
+	if err != nil {
+		h.logger.Error(err, "Failed to list revisions", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRevisionList(revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *RevisionHandler) appDeployedRevisionListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	listFilter := new(payloads.DeployedRevisionList)
+	if err := h.decodeListFilter(r, listFilter); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	revisions, err := h.revisionRepo.ListDeployedRevisions(ctx, authInfo, app.SpaceGUID, app.GUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to list deployed revisions", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRevisionList(revisions, h.serverURL, *r.URL)), nil
+}
+
+type revisionListFilter interface {
+	SupportedFilterKeys() []string
+	Validate() error
+}
+
+func (h *RevisionHandler) decodeListFilter(r *http.Request, listFilter revisionListFilter) error {
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return err
+	}
+
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Revision filter")
+					return apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return err
+		}
+	}
+
+	if err = listFilter.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (h *RevisionHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(RevisionPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.revisionGetHandler))
+	router.Path(AppRevisionsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.appRevisionListHandler))
+	router.Path(AppDeployedRevisionsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.appDeployedRevisionListHandler))
+}
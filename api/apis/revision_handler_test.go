@@ -0,0 +1,198 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("RevisionHandler", func() {
+	var (
+		req          *http.Request
+		appRepo      *fake.CFAppRepository
+		revisionRepo *fake.CFRevisionRepository
+		revision     repositories.RevisionRecord
+	)
+
+	makeGetRequest := func(path string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		revisionRepo = new(fake.CFRevisionRepository)
+		revision = repositories.RevisionRecord{
+			GUID:            "app-guid-1",
+			Version:         1,
+			Description:     "Initial revision.",
+			AppGUID:         "app-guid",
+			SpaceGUID:       "space-guid",
+			DropletGUID:     "droplet-guid",
+			ProcessCommands: map[string]string{"web": "bundle exec rackup", "worker": ""},
+			Deployable:      true,
+			CreatedAt:       "2019-05-10T17:17:48Z",
+			UpdatedAt:       "2019-05-10T17:17:48Z",
+		}
+
+		NewRevisionHandler(
+			logf.Log.WithName("TestRevisionHandler"),
+			*serverURL,
+			appRepo,
+			revisionRepo,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/revisions/:guid endpoint", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionReturns(revision, nil)
+			makeGetRequest("/v3/revisions/app-guid-1")
+		})
+
+		It("returns the revision", func() {
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("app-guid-1"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "app-guid-1",
+				"version": 1,
+				"droplet": {"guid": "droplet-guid"},
+				"processes": {
+					"web": {"command": "bundle exec rackup"},
+					"worker": {"command": null}
+				},
+				"sidecars": [],
+				"description": "Initial revision.",
+				"deployable": true,
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {"app": {"data": {"guid": "app-guid"}}},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/revisions/app-guid-1"},
+					"app": {"href": "https://api.example.org/v3/apps/app-guid"}
+				}
+			}`))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Revision not found")
+			})
+		})
+
+		When("fetching the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/apps/:guid/revisions endpoint", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{revision}, nil)
+			makeGetRequest("/v3/apps/app-guid/revisions?versions=1,2")
+		})
+
+		It("lists the revisions of the app", func() {
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			_, _, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				Versions:  []string{"1", "2"},
+			}))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"total_results":1`))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"app-guid-1"`))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+				Expect(revisionRepo.ListRevisionsCallCount()).To(BeZero())
+			})
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				makeGetRequest("/v3/apps/app-guid/revisions?states=DEPLOYED")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'versions, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the GET /v3/apps/:guid/revisions/deployed endpoint", func() {
+		BeforeEach(func() {
+			revisionRepo.ListDeployedRevisionsReturns([]repositories.RevisionRecord{revision}, nil)
+			makeGetRequest("/v3/apps/app-guid/revisions/deployed")
+		})
+
+		It("lists the deployed revisions of the app", func() {
+			_, _, actualSpaceGUID, actualAppGUID := revisionRepo.ListDeployedRevisionsArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"app-guid-1"`))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+			})
+		})
+
+		When("a version filter is used", func() {
+			BeforeEach(func() {
+				makeGetRequest("/v3/apps/app-guid/revisions/deployed?versions=1")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'page, per_page'")
+			})
+		})
+	})
+})
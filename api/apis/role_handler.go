@@ -2,9 +2,11 @@ package apis
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
@@ -12,11 +14,13 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 )
 
 const (
 	RolesPath = "/v3/roles"
+	RolePath  = "/v3/roles/{guid}"
 )
 
 type RoleName string
@@ -39,20 +43,39 @@ const (
 
 type CFRoleRepository interface {
 	CreateRole(context.Context, authorization.Info, repositories.CreateRoleMessage) (repositories.RoleRecord, error)
+	ListRoles(context.Context, authorization.Info, repositories.ListRolesMessage) ([]repositories.RoleRecord, error)
+	GetRole(context.Context, authorization.Info, string) (repositories.RoleRecord, error)
+	DeleteRole(context.Context, authorization.Info, string) error
 }
 
 type RoleHandler struct {
 	logger           logr.Logger
 	apiBaseURL       url.URL
 	roleRepo         CFRoleRepository
+	userRepo         CFUserRepository
+	orgRepo          CFOrgRepository
+	spaceRepo        SpaceRepository
+	jobRunner        JobRunner
 	decoderValidator *DecoderValidator
 }
 
-func NewRoleHandler(apiBaseURL url.URL, roleRepo CFRoleRepository, decoderValidator *DecoderValidator) *RoleHandler {
+func NewRoleHandler(
+	apiBaseURL url.URL,
+	roleRepo CFRoleRepository,
+	userRepo CFUserRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo SpaceRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *RoleHandler {
 	return &RoleHandler{
 		logger:           controllerruntime.Log.WithName("Role Handler"),
 		apiBaseURL:       apiBaseURL,
 		roleRepo:         roleRepo,
+		userRepo:         userRepo,
+		orgRepo:          orgRepo,
+		spaceRepo:        spaceRepo,
+		jobRunner:        jobRunner,
 		decoderValidator: decoderValidator,
 	}
 }
@@ -75,7 +98,158 @@ func (h *RoleHandler) roleCreateHandler(authInfo authorization.Info, r *http.Req
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForCreateRole(record, h.apiBaseURL)), nil
 }
 
+func (h *RoleHandler) roleListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.RoleList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Role filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Validate(); err != nil {
+		h.logger.Info("Invalid role query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	roles, err := h.roleRepo.ListRoles(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list roles")
+		return nil, err
+	}
+
+	var users []repositories.UserRecord
+	if listFilter.Includes(payloads.RoleIncludeUser) {
+		users, err = h.userRepo.ListUsers(ctx, authInfo, repositories.ListUsersMessage{GUIDs: roleUserGUIDs(roles)})
+		if err != nil {
+			h.logger.Error(err, "Failed to list the users of the roles")
+			return nil, err
+		}
+	}
+
+	var spaces []repositories.SpaceRecord
+	if listFilter.Includes(payloads.RoleIncludeSpace) {
+		spaces, err = h.spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{GUIDs: roleSpaceGUIDs(roles)})
+		if err != nil {
+			h.logger.Error(err, "Failed to list the spaces of the roles")
+			return nil, err
+		}
+	}
+
+	var orgs []repositories.OrgRecord
+	if listFilter.Includes(payloads.RoleIncludeOrganization) {
+		orgs, err = h.orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{GUIDs: roleOrgGUIDs(roles)})
+		if err != nil {
+			h.logger.Error(err, "Failed to list the orgs of the roles")
+			return nil, err
+		}
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRoleList(roles, users, spaces, orgs, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *RoleHandler) roleGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	roleGUID := mux.Vars(r)["guid"]
+
+	role, err := h.roleRepo.GetRole(r.Context(), authInfo, roleGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch role", "guid", roleGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRole(role, h.apiBaseURL)), nil
+}
+
+func (h *RoleHandler) roleDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	roleGUID := mux.Vars(r)["guid"]
+
+	if _, err := h.roleRepo.GetRole(ctx, authInfo, roleGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch role", "guid", roleGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.roleRepo.DeleteRole(ctx, authInfo, roleGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete role", "guid", roleGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.RoleDeleteJobOperation,
+		ResourceGUID: roleGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.roleRepo.GetRole(ctx, authInfo, roleGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start role delete job", "guid", roleGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.apiBaseURL.String(), job.GUID)), nil
+}
+
 func (h *RoleHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(RolesPath).Methods("POST").HandlerFunc(w.Wrap(h.roleCreateHandler))
+	router.Path(RolesPath).Methods("GET").HandlerFunc(w.Wrap(h.roleListHandler))
+	router.Path(RolePath).Methods("GET").HandlerFunc(w.Wrap(h.roleGetHandler))
+	router.Path(RolePath).Methods("DELETE").HandlerFunc(w.Wrap(h.roleDeleteHandler))
+}
+
+func roleUserGUIDs(roles []repositories.RoleRecord) []string {
+	guids := []string{}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if !seen[role.User] {
+			seen[role.User] = true
+			guids = append(guids, role.User)
+		}
+	}
+
+	return guids
+}
+
+func roleSpaceGUIDs(roles []repositories.RoleRecord) []string {
+	guids := []string{}
+	for _, role := range roles {
+		if role.Space != "" {
+			guids = append(guids, role.Space)
+		}
+	}
+
+	return guids
+}
+
+func roleOrgGUIDs(roles []repositories.RoleRecord) []string {
+	guids := []string{}
+	for _, role := range roles {
+		if role.Org != "" {
+			guids = append(guids, role.Org)
+		}
+	}
+
+	return guids
 }
@@ -2,12 +2,14 @@ package apis_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/apis"
 	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -25,6 +27,10 @@ var _ = Describe("RoleHandler", func() {
 	var (
 		roleHandler *apis.RoleHandler
 		roleRepo    *fake.CFRoleRepository
+		userRepo    *fake.CFUserRepository
+		orgRepo     *fake.OrgRepository
+		spaceRepo   *fake.SpaceRepository
+		jobRunner   *fake.JobRunner
 		now         time.Time
 	)
 
@@ -32,10 +38,14 @@ var _ = Describe("RoleHandler", func() {
 		now = time.Unix(1631892190, 0) // 2021-09-17T15:23:10Z
 
 		roleRepo = new(fake.CFRoleRepository)
+		userRepo = new(fake.CFUserRepository)
+		orgRepo = new(fake.OrgRepository)
+		spaceRepo = new(fake.SpaceRepository)
+		jobRunner = new(fake.JobRunner)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
-		roleHandler = apis.NewRoleHandler(*serverURL, roleRepo, decoderValidator)
+		roleHandler = apis.NewRoleHandler(*serverURL, roleRepo, userRepo, orgRepo, spaceRepo, jobRunner, decoderValidator)
 		roleHandler.RegisterRoutes(router)
 	})
 
@@ -327,7 +337,37 @@ var _ = Describe("RoleHandler", func() {
 			})
 		})
 
-		When("the role does not contain a user or service account", func() {
+		When("the kind is a group", func() {
+			BeforeEach(func() {
+				createRoleRequestBody = `{
+                    "type": "organization_manager",
+                    "relationships": {
+                        "kubernetesGroup": {
+                            "data": {
+                                "guid": "my-group"
+                            }
+                        },
+                        "organization": {
+                            "data": {
+                                "guid": "my-org"
+                            }
+                        }
+                    }
+                }`
+			})
+
+			It("creates a group role binding", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(roleRepo.CreateRoleCallCount()).To(Equal(1))
+				_, _, roleRecord := roleRepo.CreateRoleArgsForCall(0)
+				Expect(roleRecord.Type).To(Equal("organization_manager"))
+				Expect(roleRecord.Org).To(Equal("my-org"))
+				Expect(roleRecord.User).To(Equal("my-group"))
+				Expect(roleRecord.Kind).To(Equal(rbacv1.GroupKind))
+			})
+		})
+
+		When("the role does not contain a user, service account or group", func() {
 			BeforeEach(func() {
 				createRoleRequestBody = `{
                     "type": "organization_manager",
@@ -345,8 +385,9 @@ var _ = Describe("RoleHandler", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
-					ContainSubstring("Field validation for 'User' failed on the 'required_without' tag"),
-					ContainSubstring("Field validation for 'KubernetesServiceAccount' failed on the 'required_without' tag"),
+					ContainSubstring("Field validation for 'User' failed on the 'required_without_all' tag"),
+					ContainSubstring("Field validation for 'KubernetesServiceAccount' failed on the 'required_without_all' tag"),
+					ContainSubstring("Field validation for 'KubernetesGroup' failed on the 'required_without_all' tag"),
 				)))
 			})
 		})
@@ -509,4 +550,208 @@ var _ = Describe("RoleHandler", func() {
 			})
 		})
 	})
+
+	Describe("List roles", func() {
+		var (
+			orgRole   repositories.RoleRecord
+			spaceRole repositories.RoleRecord
+			query     string
+		)
+
+		BeforeEach(func() {
+			orgRole = repositories.RoleRecord{
+				GUID:      "org-role-guid",
+				CreatedAt: now,
+				UpdatedAt: now,
+				Type:      "organization_user",
+				Org:       "org-guid",
+				User:      "my-user",
+				Kind:      rbacv1.UserKind,
+			}
+			spaceRole = repositories.RoleRecord{
+				GUID:      "space-role-guid",
+				CreatedAt: now,
+				UpdatedAt: now,
+				Type:      "space_developer",
+				Space:     "space-guid",
+				User:      "my-user",
+				Kind:      rbacv1.UserKind,
+			}
+			roleRepo.ListRolesReturns([]repositories.RoleRecord{orgRole, spaceRole}, nil)
+			spaceRepo.ListSpacesReturns([]repositories.SpaceRecord{{GUID: "space-guid", Name: "my-space", OrganizationGUID: "org-guid"}}, nil)
+			userRepo.ListUsersReturns([]repositories.UserRecord{{GUID: "my-user", Username: "my-user", PresentationName: "My User", Origin: "uaa"}}, nil)
+			query = "?types=space_developer,organization_user&space_guids=space-guid&user_guids=my-user"
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", rolesBase+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("lists the roles matching the filters", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+			Expect(roleRepo.ListRolesCallCount()).To(Equal(1))
+			_, _, message := roleRepo.ListRolesArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListRolesMessage{
+				GUIDs:      []string{},
+				Types:      []string{"space_developer", "organization_user"},
+				SpaceGUIDs: []string{"space-guid"},
+				OrgGUIDs:   []string{},
+				UserGUIDs:  []string{"my-user"},
+			}))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				ContainSubstring(`"guid":"org-role-guid"`),
+				ContainSubstring(`"guid":"space-role-guid"`),
+				Not(ContainSubstring(`"included"`)),
+			)))
+			Expect(spaceRepo.ListSpacesCallCount()).To(BeZero())
+			Expect(userRepo.ListUsersCallCount()).To(BeZero())
+		})
+
+		When("the users and spaces are included", func() {
+			BeforeEach(func() {
+				query = "?include=user,space"
+			})
+
+			It("includes the users and the spaces of the roles", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+				_, _, message := spaceRepo.ListSpacesArgsForCall(0)
+				Expect(message.GUIDs).To(ConsistOf("space-guid"))
+				Expect(orgRepo.ListOrgsCallCount()).To(BeZero())
+
+				Expect(userRepo.ListUsersCallCount()).To(Equal(1))
+				_, _, userMessage := userRepo.ListUsersArgsForCall(0)
+				Expect(userMessage.GUIDs).To(ConsistOf("my-user"))
+
+				var response map[string]interface{}
+				Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("included", SatisfyAll(
+					HaveKeyWithValue("users", ConsistOf(SatisfyAll(
+						HaveKeyWithValue("username", "my-user"),
+						HaveKeyWithValue("presentation_name", "My User"),
+						HaveKeyWithValue("origin", "uaa"),
+					))),
+					HaveKeyWithValue("spaces", ConsistOf(HaveKeyWithValue("name", "my-space"))),
+					Not(HaveKey("organizations")),
+				)))
+			})
+		})
+
+		When("an invalid resource is included", func() {
+			BeforeEach(func() {
+				query = "?include=app"
+			})
+
+			It("returns a bad query parameter error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+				Expect(rr).To(HaveHTTPBody(ContainSubstring("Invalid included resource")))
+			})
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				query = "?foo=bar"
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, types, space_guids, organization_guids, user_guids, include, page, per_page'")
+			})
+		})
+	})
+
+	Describe("Get role", func() {
+		BeforeEach(func() {
+			roleRepo.GetRoleReturns(repositories.RoleRecord{
+				GUID:      "role-guid",
+				CreatedAt: now,
+				Type:      "space_developer",
+				Space:     "space-guid",
+				User:      "my-user",
+				Kind:      rbacv1.UserKind,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", rolesBase+"/role-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("returns the role", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			_, _, actualGUID := roleRepo.GetRoleArgsForCall(0)
+			Expect(actualGUID).To(Equal("role-guid"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"guid": "role-guid",
+				"created_at": "2021-09-17T15:23:10Z",
+				"updated_at": "2021-09-17T15:23:10Z",
+				"type": "space_developer",
+				"relationships": {
+					"user": {"data": {"guid": "my-user"}},
+					"space": {"data": {"guid": "space-guid"}},
+					"organization": {"data": null}
+				},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/roles/role-guid"},
+					"space": {"href": "https://api.example.org/v3/spaces/space-guid"}
+				}
+			}`)))
+		})
+
+		When("the role is not found", func() {
+			BeforeEach(func() {
+				roleRepo.GetRoleReturns(repositories.RoleRecord{}, apierrors.NewNotFoundError(nil, repositories.RoleResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Role not found")
+			})
+		})
+	})
+
+	Describe("Delete role", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "DELETE", rolesBase+"/role-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			router.ServeHTTP(rr, req)
+		})
+
+		It("deletes the role and returns the location of the delete job", func() {
+			Expect(roleRepo.DeleteRoleCallCount()).To(Equal(1))
+			_, _, actualGUID := roleRepo.DeleteRoleArgsForCall(0)
+			Expect(actualGUID).To(Equal("role-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.RoleDeleteJobOperation,
+				ResourceGUID: "role-guid",
+			}))
+		})
+
+		When("the user still has space roles in the org", func() {
+			BeforeEach(func() {
+				roleRepo.DeleteRoleReturns(apierrors.NewUnprocessableEntityError(nil, "Cannot delete the 'organization_user' role of user 'my-user' while the user still has roles in spaces of the organization."))
+			})
+
+			It("returns an error and does not start a job", func() {
+				expectUnprocessableEntityError("Cannot delete the 'organization_user' role of user 'my-user' while the user still has roles in spaces of the organization.")
+				Expect(jobRunner.StartDeletionCallCount()).To(BeZero())
+			})
+		})
+	})
 })
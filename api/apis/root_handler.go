@@ -13,15 +13,21 @@ const (
 
 type RootHandler struct {
 	serverURL string
+	loginURL  string
+	uaaURL    string
 }
 
-func NewRootHandler(serverURL string) *RootHandler {
-	return &RootHandler{serverURL: serverURL}
+func NewRootHandler(serverURL, loginURL, uaaURL string) *RootHandler {
+	return &RootHandler{
+		serverURL: serverURL,
+		loginURL:  loginURL,
+		uaaURL:    uaaURL,
+	}
 }
 
 func (h *RootHandler) rootGetHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	writeResponse(w, http.StatusOK, presenter.GetRootResponse(h.serverURL))
+	writeResponse(w, http.StatusOK, presenter.GetRootResponse(h.serverURL, h.loginURL, h.uaaURL))
 }
 
 func (h *RootHandler) RegisterRoutes(router *mux.Router) {
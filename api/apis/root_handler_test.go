@@ -12,16 +12,24 @@ import (
 )
 
 var _ = Describe("RootHandler", func() {
-	var req *http.Request
+	var (
+		req              *http.Request
+		loginURL, uaaURL string
+	)
 
 	BeforeEach(func() {
+		loginURL = ""
+		uaaURL = ""
+	})
+
+	JustBeforeEach(func() {
 		apiHandler := apis.NewRootHandler(
 			defaultServerURL,
+			loginURL,
+			uaaURL,
 		)
 		apiHandler.RegisterRoutes(router)
-	})
 
-	JustBeforeEach(func() {
 		router.ServeHTTP(rr, req)
 	})
 
@@ -76,5 +84,20 @@ var _ = Describe("RootHandler", func() {
 				"CFOnK8s": Equal(true),
 			}))
 		})
+
+		When("a login server is configured", func() {
+			BeforeEach(func() {
+				loginURL = "https://login.example.org"
+				uaaURL = "https://uaa.example.org"
+			})
+
+			It("links to the login server", func() {
+				var resp presenter.RootResponse
+				Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+
+				Expect(resp.Links).To(HaveKeyWithValue("login", &presenter.APILink{Link: presenter.Link{HREF: "https://login.example.org"}}))
+				Expect(resp.Links).To(HaveKeyWithValue("uaa", &presenter.APILink{Link: presenter.Link{HREF: "https://uaa.example.org"}}))
+			})
+		})
 	})
 })
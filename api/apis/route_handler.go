@@ -28,20 +28,24 @@ const (
 type CFRouteRepository interface {
 	GetRoute(context.Context, authorization.Info, string) (repositories.RouteRecord, error)
 	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) ([]repositories.RouteRecord, error)
+	ListSpaceRoutes(context.Context, authorization.Info, string, string, repositories.Page) ([]repositories.RouteRecord, int, error)
 	ListRoutesForApp(context.Context, authorization.Info, string, string) ([]repositories.RouteRecord, error)
 	CreateRoute(context.Context, authorization.Info, repositories.CreateRouteMessage) (repositories.RouteRecord, error)
 	DeleteRoute(context.Context, authorization.Info, repositories.DeleteRouteMessage) error
+	PatchRoute(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsToRouteMessage) (repositories.RouteRecord, error)
 }
 
 type RouteHandler struct {
-	logger           logr.Logger
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        SpaceRepository
-	decoderValidator *DecoderValidator
+	logger             logr.Logger
+	serverURL          url.URL
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	appRepo            CFAppRepository
+	spaceRepo          SpaceRepository
+	jobRunner          JobRunner
+	auditEventRecorder AuditEventRecorder
+	decoderValidator   *DecoderValidator
 }
 
 func NewRouteHandler(
@@ -51,16 +55,20 @@ func NewRouteHandler(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo SpaceRepository,
+	jobRunner JobRunner,
+	auditEventRecorder AuditEventRecorder,
 	decoderValidator *DecoderValidator,
 ) *RouteHandler {
 	return &RouteHandler{
-		logger:           logger,
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		decoderValidator: decoderValidator,
+		logger:             logger,
+		serverURL:          serverURL,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		jobRunner:          jobRunner,
+		auditEventRecorder: auditEventRecorder,
+		decoderValidator:   decoderValidator,
 	}
 }
 
@@ -107,7 +115,36 @@ func (h *RouteHandler) routeGetListHandler(authInfo authorization.Info, r *http.
 		}
 	}
 
-	routes, err := h.lookupRouteAndDomainList(ctx, authInfo, routeListFilter.ToMessage())
+	if err = routeListFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = routeListFilter.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
+	message := routeListFilter.ToMessage()
+
+	// the routes of a single space are paginated by the Kubernetes API, unless they have to be filtered in memory
+	if len(message.SpaceGUIDs) == 1 && len(message.AppGUIDs) == 0 && len(message.DomainGUIDs) == 0 && len(message.Hosts) == 0 && len(message.Paths) == 0 {
+		routes, totalResults, err := h.routeRepo.ListSpaceRoutes(ctx, authInfo, message.SpaceGUIDs[0], message.LabelSelector, routeListFilter.ToPage())
+		if err != nil {
+			h.logger.Error(err, "Failed to fetch routes from Kubernetes")
+			return nil, err
+		}
+
+		routes, err = getDomainsForRoutes(ctx, h.domainRepo, authInfo, routes)
+		if err != nil {
+			h.logger.Error(err, "Failed to fetch domains of routes from Kubernetes")
+			return nil, err
+		}
+
+		return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRouteListPage(routes, totalResults, h.serverURL, *r.URL)), nil
+	}
+
+	routes, err := h.lookupRouteAndDomainList(ctx, authInfo, message)
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch routes from Kubernetes")
 		return nil, err
@@ -168,10 +205,39 @@ func (h *RouteHandler) routeCreateHandler(authInfo authorization.Info, r *http.R
 	}
 
 	responseRouteRecord = responseRouteRecord.UpdateDomainRef(domain)
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.route.create", repositories.AuditEventTarget{GUID: responseRouteRecord.GUID, Type: "route", Name: responseRouteRecord.Host}, responseRouteRecord.SpaceGUID, payload)
 
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+func (h *RouteHandler) routePatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	routeGUID := vars["guid"]
+
+	var payload payloads.RoutePatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	routeRecord, err := h.lookupRouteAndDomain(ctx, routeGUID, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	responseRouteRecord, err := h.routeRepo.PatchRoute(ctx, authInfo, payload.ToMessage(routeGUID, routeRecord.SpaceGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch route", "RouteGUID", routeGUID)
+		return nil, err
+	}
+
+	responseRouteRecord = responseRouteRecord.UpdateDomainRef(routeRecord.Domain)
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.route.update", repositories.AuditEventTarget{GUID: responseRouteRecord.GUID, Type: "route", Name: responseRouteRecord.Host}, responseRouteRecord.SpaceGUID, payload)
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
+}
+
 func (h *RouteHandler) routeAddDestinationsHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 
@@ -218,8 +284,21 @@ func (h *RouteHandler) routeDeleteHandler(authInfo authorization.Info, r *http.R
 		h.logger.Error(err, "Failed to delete route", "routeGUID", routeGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.route.delete-request", repositories.AuditEventTarget{GUID: routeRecord.GUID, Type: "route", Name: routeRecord.Host}, routeRecord.SpaceGUID, nil)
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.RouteDeleteJobOperation,
+		ResourceGUID: routeGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.routeRepo.GetRoute(ctx, authInfo, routeGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start route delete job", "routeGUID", routeGUID)
+		return nil, err
+	}
 
-	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/route.delete-%s", h.serverURL.String(), routeGUID)), nil
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
 }
 
 func (h *RouteHandler) RegisterRoutes(router *mux.Router) {
@@ -229,10 +308,12 @@ func (h *RouteHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(RouteDestinationsPath).Methods("GET").HandlerFunc(w.Wrap(h.routeGetDestinationsHandler))
 	router.Path(RoutesPath).Methods("POST").HandlerFunc(w.Wrap(h.routeCreateHandler))
 	router.Path(RoutePath).Methods("DELETE").HandlerFunc(w.Wrap(h.routeDeleteHandler))
+	router.Path(RoutePath).Methods("PATCH").HandlerFunc(w.Wrap(h.routePatchHandler))
 	router.Path(RouteDestinationsPath).Methods("POST").HandlerFunc(w.Wrap(h.routeAddDestinationsHandler))
 }
 
 // Fetch Route and compose related Domain information within
+
 func (h *RouteHandler) lookupRouteAndDomain(ctx context.Context, routeGUID string, authInfo authorization.Info) (repositories.RouteRecord, error) {
 	route, err := h.routeRepo.GetRoute(ctx, authInfo, routeGUID)
 	if err != nil {
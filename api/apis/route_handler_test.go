@@ -33,6 +33,8 @@ var _ = Describe("RouteHandler", func() {
 		domainRepo *fake.CFDomainRepository
 		appRepo    *fake.CFAppRepository
 		spaceRepo  *fake.SpaceRepository
+		jobRunner  *fake.JobRunner
+		recorder   *fake.AuditEventRecorder
 
 		requestMethod string
 		requestPath   string
@@ -44,6 +46,8 @@ var _ = Describe("RouteHandler", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		appRepo = new(fake.CFAppRepository)
 		spaceRepo = new(fake.SpaceRepository)
+		jobRunner = new(fake.JobRunner)
+		recorder = new(fake.AuditEventRecorder)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -54,6 +58,8 @@ var _ = Describe("RouteHandler", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			jobRunner,
+			recorder,
 			decoderValidator,
 		)
 		routeHandler.RegisterRoutes(router)
@@ -277,10 +283,10 @@ var _ = Describe("RouteHandler", func() {
 	   					"total_results": 1,
 	   					"total_pages": 1,
 	   					"first": {
-	   						"href": "%[1]s/v3/routes"
+	   						"href": "%[1]s/v3/routes?page=1&per_page=50"
 	   					},
 	   					"last": {
-	   						"href": "%[1]s/v3/routes"
+	   						"href": "%[1]s/v3/routes?page=1&per_page=50"
 	   					},
 	   					"next": null,
 	   					"previous": null
@@ -355,7 +361,7 @@ var _ = Describe("RouteHandler", func() {
 
 			When("space_guids query parameters are provided", func() {
 				BeforeEach(func() {
-					requestPath += "?space_guids=my-space-guid"
+					requestPath += "?space_guids=my-space-guid,my-other-space-guid"
 				})
 
 				It("returns status 200 OK", func() {
@@ -363,15 +369,50 @@ var _ = Describe("RouteHandler", func() {
 				})
 
 				It("returns the Pagination Data with the space_guids filter", func() {
-					Expect(rr.Body.String()).To(ContainSubstring("https://api.example.org/v3/routes?space_guids=my-space-guid"))
+					Expect(rr.Body.String()).To(ContainSubstring("https://api.example.org/v3/routes?space_guids=my-space-guid,my-other-space-guid"))
 				})
 
 				It("calls route with expected parameters", func() {
 					Expect(routeRepo.ListRoutesCallCount()).To(Equal(1))
 					_, _, message := routeRepo.ListRoutesArgsForCall(0)
 					Expect(message.AppGUIDs).To(HaveLen(0))
-					Expect(message.SpaceGUIDs).To(HaveLen(1))
-					Expect(message.SpaceGUIDs[0]).To(Equal("my-space-guid"))
+					Expect(message.SpaceGUIDs).To(ConsistOf("my-space-guid", "my-other-space-guid"))
+				})
+			})
+
+			When("a single space_guid and no other filter is provided", func() {
+				BeforeEach(func() {
+					routeRepo.ListSpaceRoutesReturns([]repositories.RouteRecord{routeRecord}, 3, nil)
+					requestPath += "?space_guids=my-space-guid&label_selector=foo&page=2&per_page=1"
+				})
+
+				It("lists a page of the routes of the space", func() {
+					Expect(routeRepo.ListRoutesCallCount()).To(Equal(0))
+					Expect(routeRepo.ListSpaceRoutesCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualSpaceGUID, actualLabelSelector, actualPage := routeRepo.ListSpaceRoutesArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualSpaceGUID).To(Equal("my-space-guid"))
+					Expect(actualLabelSelector).To(Equal("foo"))
+					Expect(actualPage).To(Equal(repositories.Page{Number: 2, PerPage: 1}))
+				})
+
+				It("paginates the response with the total number of routes", func() {
+					Expect(rr.Code).To(Equal(http.StatusOK))
+					response := map[string]interface{}{}
+					Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+					Expect(response).To(HaveKeyWithValue("pagination", MatchKeys(IgnoreExtras, Keys{
+						"total_results": BeNumerically("==", 3),
+						"total_pages":   BeNumerically("==", 3),
+					})))
+					Expect(response).To(HaveKeyWithValue("resources", ConsistOf(
+						HaveKeyWithValue("guid", testRouteGUID),
+					)))
+				})
+
+				It("looks up the domains of the routes on the page", func() {
+					Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
+					_, _, actualDomainGUID := domainRepo.GetDomainArgsForCall(0)
+					Expect(actualDomainGUID).To(Equal(testDomainGUID))
 				})
 			})
 
@@ -434,7 +475,7 @@ var _ = Describe("RouteHandler", func() {
 					err := json.Unmarshal(rr.Body.Bytes(), &response)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(response).To(SatisfyAll(
-						HaveKeyWithValue("pagination", HaveKeyWithValue("first", HaveKeyWithValue("href", "https://api.example.org/v3/routes?hosts="))),
+						HaveKeyWithValue("pagination", HaveKeyWithValue("first", HaveKeyWithValue("href", "https://api.example.org/v3/routes?hosts=&page=1&per_page=50"))),
 						HaveKeyWithValue("resources", BeEmpty()),
 					))
 				})
@@ -491,10 +532,10 @@ var _ = Describe("RouteHandler", func() {
 	   						"total_results": 0,
 	   						"total_pages": 1,
 	   						"first": {
-	   							"href": "%[1]s/v3/routes"
+	   							"href": "%[1]s/v3/routes?page=1&per_page=50"
 	   						},
 	   						"last": {
-	   							"href": "%[1]s/v3/routes"
+	   							"href": "%[1]s/v3/routes?page=1&per_page=50"
 	   						},
 	   						"next": null,
 	   						"previous": null
@@ -533,7 +574,7 @@ var _ = Describe("RouteHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, space_guids, domain_guids, hosts, paths'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, space_guids, domain_guids, hosts, paths, label_selector, page, per_page'")
 			})
 		})
 	})
@@ -598,6 +639,15 @@ var _ = Describe("RouteHandler", func() {
 					Expect(rr.Code).To(Equal(http.StatusCreated), "Matching HTTP response code:")
 				})
 
+				It("records an audit.route.create event", func() {
+					Expect(recorder.RecordCallCount()).To(Equal(1))
+					_, actualAuthInfo, eventType, target, eventSpaceGUID, _ := recorder.RecordArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(eventType).To(Equal("audit.route.create"))
+					Expect(target).To(Equal(repositories.AuditEventTarget{GUID: testRouteGUID, Type: "route", Name: testRouteHost}))
+					Expect(eventSpaceGUID).To(Equal(testSpaceGUID))
+				})
+
 				It("returns Content-Type as JSON in header", func() {
 					Expect(rr.Header().Get("Content-Type")).To(Equal(jsonHeader), "Matching Content-Type header:")
 				})
@@ -1488,6 +1538,97 @@ var _ = Describe("RouteHandler", func() {
 		})
 	})
 
+	Describe("the PATCH /v3/routes/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/" + testRouteGUID
+			requestBody = `{"metadata": {"labels": {"env": "prod"}, "annotations": {"example.com/owner": null}}}`
+
+			routeRepo.GetRouteReturns(repositories.RouteRecord{
+				GUID:      testRouteGUID,
+				SpaceGUID: testSpaceGUID,
+				Domain: repositories.DomainRecord{
+					GUID: testDomainGUID,
+				},
+				Host: testRouteHost,
+			}, nil)
+			routeRepo.PatchRouteReturns(repositories.RouteRecord{
+				GUID:      testRouteGUID,
+				SpaceGUID: testSpaceGUID,
+				Domain: repositories.DomainRecord{
+					GUID: testDomainGUID,
+				},
+				Host:        testRouteHost,
+				Protocol:    "http",
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{},
+			}, nil)
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				GUID: testDomainGUID,
+				Name: testDomainName,
+			}, nil)
+		})
+
+		It("patches the route metadata", func() {
+			Expect(routeRepo.PatchRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.PatchRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			prod := "prod"
+			Expect(message).To(Equal(repositories.PatchRouteMessage{
+				GUID:      testRouteGUID,
+				SpaceGUID: testSpaceGUID,
+				MetadataPatch: repositories.MetadataPatch{
+					Labels:      map[string]*string{"env": &prod},
+					Annotations: map[string]*string{"example.com/owner": nil},
+				},
+			}))
+		})
+
+		It("returns the updated route with its domain", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+			response := map[string]interface{}{}
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response).To(HaveKeyWithValue("url", testRouteHost+"."+testDomainName))
+			Expect(response).To(HaveKeyWithValue("metadata", Equal(map[string]interface{}{
+				"labels":      map[string]interface{}{"env": "prod"},
+				"annotations": map[string]interface{}{},
+			})))
+		})
+
+		When("the request body has fields other than metadata", func() {
+			BeforeEach(func() {
+				requestBody = `{"host": "other-host"}`
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(`invalid request body: json: unknown field "host"`)
+			})
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route not found")
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(0))
+			})
+		})
+
+		When("patching the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.PatchRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the DELETE /v3/routes/:guid endpoint", func() {
 		BeforeEach(func() {
 			requestMethod = http.MethodDelete
@@ -1502,6 +1643,7 @@ var _ = Describe("RouteHandler", func() {
 				},
 			}, nil)
 			routeRepo.DeleteRouteReturns(nil)
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
 		})
 
 		When("on the happy path", func() {
@@ -1510,7 +1652,22 @@ var _ = Describe("RouteHandler", func() {
 			})
 
 			It("responds with a job URL in a location header", func() {
-				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/route.delete-"+testRouteGUID))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+			})
+
+			It("starts a route.delete job that waits for the route to be gone", func() {
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(1))
+				_, _, message, getResource := jobRunner.StartDeletionArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    "route.delete",
+					ResourceGUID: testRouteGUID,
+				}))
+
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+				Expect(getResource(ctx)).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				_, info, actualRouteGUID := routeRepo.GetRouteArgsForCall(1)
+				Expect(info).To(Equal(authInfo))
+				Expect(actualRouteGUID).To(Equal(testRouteGUID))
 			})
 
 			It("fetches the right route", func() {
@@ -1527,6 +1684,14 @@ var _ = Describe("RouteHandler", func() {
 				Expect(deleteMessage.GUID).To(Equal(testRouteGUID))
 				Expect(deleteMessage.SpaceGUID).To(Equal(testSpaceGUID))
 			})
+
+			It("records an audit.route.delete-request event", func() {
+				Expect(recorder.RecordCallCount()).To(Equal(1))
+				_, _, eventType, target, eventSpaceGUID, _ := recorder.RecordArgsForCall(0)
+				Expect(eventType).To(Equal("audit.route.delete-request"))
+				Expect(target.GUID).To(Equal(testRouteGUID))
+				Expect(eventSpaceGUID).To(Equal(testSpaceGUID))
+			})
 		})
 
 		When("fetching the route errors", func() {
@@ -1548,6 +1713,16 @@ var _ = Describe("RouteHandler", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("starting the delete job errors", func() {
+			BeforeEach(func() {
+				jobRunner.StartDeletionReturns(repositories.JobRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
 
@@ -0,0 +1,268 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	SecurityGroupsPath             = "/v3/security_groups"
+	SecurityGroupPath              = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupRunningSpacePath  = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpacesPath = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupStagingSpacePath  = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
+type CFSecurityGroupRepository interface {
+	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)
+	PatchSecurityGroup(context.Context, authorization.Info, repositories.PatchSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+}
+
+type SecurityGroupHandler struct {
+	logger            logr.Logger
+	serverURL         url.URL
+	securityGroupRepo CFSecurityGroupRepository
+	jobRunner         JobRunner
+	decoderValidator  *DecoderValidator
+}
+
+func NewSecurityGroupHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	securityGroupRepo CFSecurityGroupRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *SecurityGroupHandler {
+	return &SecurityGroupHandler{
+		logger:            logger,
+		serverURL:         serverURL,
+		securityGroupRepo: securityGroupRepo,
+		jobRunner:         jobRunner,
+		decoderValidator:  decoderValidator,
+	}
+}
+
+func (h *SecurityGroupHandler) securityGroupCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.SecurityGroupCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	securityGroup, err := h.securityGroupRepo.CreateSecurityGroup(ctx, authInfo, payload.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to create security group", "name", payload.Name)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch security group", "guid", securityGroupGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.SecurityGroupList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in SecurityGroup filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list security groups")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupList(securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupPatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SecurityGroupPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch security group", "guid", securityGroupGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	securityGroup, err := h.securityGroupRepo.PatchSecurityGroup(ctx, authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch security group", "guid", securityGroupGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch security group", "guid", securityGroupGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.securityGroupRepo.DeleteSecurityGroup(ctx, authInfo, securityGroupGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete security group", "guid", securityGroupGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.SecurityGroupDeleteJobOperation,
+		ResourceGUID: securityGroupGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start security group delete job", "guid", securityGroupGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupBindRunningHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	return h.bindSecurityGroup(authInfo, r, repositories.SecurityGroupRunningLifecycle)
+}
+
+func (h *SecurityGroupHandler) securityGroupBindStagingHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	return h.bindSecurityGroup(authInfo, r, repositories.SecurityGroupStagingLifecycle)
+}
+
+func (h *SecurityGroupHandler) bindSecurityGroup(authInfo authorization.Info, r *http.Request, lifecycle string) (*HandlerResponse, error) {
+	ctx := r.Context()
+	securityGroupGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.ToManyRelationship
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch security group", "guid", securityGroupGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	securityGroup, err := h.securityGroupRepo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+		GUID:       securityGroupGUID,
+		Lifecycle:  lifecycle,
+		SpaceGUIDs: payload.GUIDs(),
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to bind security group", "guid", securityGroupGUID, "lifecycle", lifecycle)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupSpaces(securityGroup, lifecycle, h.serverURL)), nil
+}
+
+func (h *SecurityGroupHandler) securityGroupUnbindRunningHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	return h.unbindSecurityGroup(authInfo, r, repositories.SecurityGroupRunningLifecycle)
+}
+
+func (h *SecurityGroupHandler) securityGroupUnbindStagingHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	return h.unbindSecurityGroup(authInfo, r, repositories.SecurityGroupStagingLifecycle)
+}
+
+func (h *SecurityGroupHandler) unbindSecurityGroup(authInfo authorization.Info, r *http.Request, lifecycle string) (*HandlerResponse, error) {
+	ctx := r.Context()
+	securityGroupGUID := mux.Vars(r)["guid"]
+	spaceGUID := mux.Vars(r)["space_guid"]
+
+	if _, err := h.securityGroupRepo.GetSecurityGroup(ctx, authInfo, securityGroupGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch security group", "guid", securityGroupGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:      securityGroupGUID,
+		Lifecycle: lifecycle,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to unbind security group", "guid", securityGroupGUID, "lifecycle", lifecycle, "spaceGUID", spaceGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
+func (h *SecurityGroupHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(SecurityGroupsPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.securityGroupCreateHandler))
+	router.Path(SecurityGroupsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.securityGroupListHandler))
+	router.Path(SecurityGroupPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.securityGroupGetHandler))
+	router.Path(SecurityGroupPath).Methods(http.MethodPatch).HandlerFunc(w.Wrap(h.securityGroupPatchHandler))
+	router.Path(SecurityGroupPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.securityGroupDeleteHandler))
+	router.Path(SecurityGroupRunningSpacesPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.securityGroupBindRunningHandler))
+	router.Path(SecurityGroupRunningSpacePath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.securityGroupUnbindRunningHandler))
+	router.Path(SecurityGroupStagingSpacesPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.securityGroupBindStagingHandler))
+	router.Path(SecurityGroupStagingSpacePath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.securityGroupUnbindStagingHandler))
+}
@@ -0,0 +1,316 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("SecurityGroupHandler", func() {
+	var (
+		req               *http.Request
+		securityGroupRepo *fake.CFSecurityGroupRepository
+		jobRunner         *fake.JobRunner
+		securityGroup     repositories.SecurityGroupRecord
+	)
+
+	intPtr := func(value int) *int {
+		return &value
+	}
+
+	makeRequest := func(method, path, body string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		securityGroupRepo = new(fake.CFSecurityGroupRepository)
+		jobRunner = new(fake.JobRunner)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		securityGroup = repositories.SecurityGroupRecord{
+			GUID:                   "sg-guid",
+			Name:                   "my-group",
+			GloballyEnabledRunning: true,
+			Rules: []repositories.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443", Description: "https"},
+				{Protocol: "icmp", Destination: "10.0.0.1", Type: intPtr(8), Code: intPtr(0)},
+			},
+			RunningSpaceGUIDs: []string{"space-guid"},
+			StagingSpaceGUIDs: []string{},
+			CreatedAt:         "2019-05-10T17:17:48Z",
+			UpdatedAt:         "2019-05-10T17:17:48Z",
+		}
+		securityGroupRepo.GetSecurityGroupReturns(securityGroup, nil)
+
+		NewSecurityGroupHandler(
+			logf.Log.WithName("TestSecurityGroupHandler"),
+			*serverURL,
+			securityGroupRepo,
+			jobRunner,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/security_groups endpoint", func() {
+		BeforeEach(func() {
+			securityGroupRepo.CreateSecurityGroupReturns(securityGroup, nil)
+			makeRequest(http.MethodPost, "/v3/security_groups", `{
+				"name": "my-group",
+				"globally_enabled": {"running": true},
+				"rules": [
+					{"protocol": "tcp", "destination": "10.0.0.0/16", "ports": "443", "description": "https"},
+					{"protocol": "icmp", "destination": "10.0.0.1", "type": 8, "code": 0}
+				],
+				"relationships": {"running_spaces": {"data": [{"guid": "space-guid"}]}}
+			}`)
+		})
+
+		It("creates the security group", func() {
+			Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.CreateSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:                   "my-group",
+				GloballyEnabledRunning: true,
+				Rules:                  securityGroup.Rules,
+				RunningSpaceGUIDs:      []string{"space-guid"},
+				StagingSpaceGUIDs:      []string{},
+			}))
+		})
+
+		It("returns 201 Created with the security group", func() {
+			expectJSONResponse(http.StatusCreated, `{
+				"guid": "sg-guid",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"name": "my-group",
+				"globally_enabled": {"running": true, "staging": false},
+				"rules": [
+					{"protocol": "tcp", "destination": "10.0.0.0/16", "ports": "443", "description": "https"},
+					{"protocol": "icmp", "destination": "10.0.0.1", "type": 8, "code": 0}
+				],
+				"relationships": {
+					"running_spaces": {"data": [{"guid": "space-guid"}]},
+					"staging_spaces": {"data": []}
+				},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/security_groups/sg-guid"}
+				}
+			}`)
+		})
+
+		When("a rule is invalid", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/security_groups", `{
+					"name": "my-group",
+					"rules": [
+						{"protocol": "tcp", "destination": "10.0.0.0/16", "ports": "443"},
+						{"protocol": "udp", "destination": "10.0.0.0/16"}
+					]
+				}`)
+			})
+
+			It("returns an error naming the rule", func() {
+				expectUnprocessableEntityError("Rules[1]: ports are required for protocols of type TCP and UDP")
+				Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/security_groups", `{"rules": []}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Name is a required field")
+			})
+		})
+
+		When("creating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/security_groups/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodGet, "/v3/security_groups/sg-guid", "")
+		})
+
+		It("returns the security group", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualGUID).To(Equal("sg-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"sg-guid"`))
+		})
+
+		When("the security group is forbidden", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Security Group not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/security_groups endpoint", func() {
+		BeforeEach(func() {
+			securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{securityGroup}, nil)
+			makeRequest(http.MethodGet, "/v3/security_groups?names=my-group,other&globally_enabled_running=true&staging_space_guids=space-guid", "")
+		})
+
+		It("lists the security groups matching the filter", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(message.Names).To(ConsistOf("my-group", "other"))
+			Expect(message.GloballyEnabledRunning).To(PointTo(BeTrue()))
+			Expect(message.GloballyEnabledStaging).To(BeNil())
+			Expect(message.StagingSpaceGUIDs).To(ConsistOf("space-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"sg-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodGet, "/v3/security_groups?foo=bar", "")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, names, globally_enabled_running, globally_enabled_staging, running_space_guids, staging_space_guids, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/security_groups/:guid endpoint", func() {
+		BeforeEach(func() {
+			securityGroupRepo.PatchSecurityGroupReturns(securityGroup, nil)
+			makeRequest(http.MethodPatch, "/v3/security_groups/sg-guid", `{
+				"globally_enabled": {"staging": true},
+				"rules": []
+			}`)
+		})
+
+		It("patches the fields that are set, replacing the rules", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(securityGroupRepo.PatchSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.PatchSecurityGroupArgsForCall(0)
+			Expect(message.GUID).To(Equal("sg-guid"))
+			Expect(message.Name).To(BeNil())
+			Expect(message.GloballyEnabledRunning).To(BeNil())
+			Expect(message.GloballyEnabledStaging).To(PointTo(BeTrue()))
+			Expect(message.Rules).To(BeEmpty())
+			Expect(message.Rules).NotTo(BeNil())
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Security Group not found")
+				Expect(securityGroupRepo.PatchSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/security_groups/:guid endpoint", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+			makeRequest(http.MethodDelete, "/v3/security_groups/sg-guid", "")
+		})
+
+		It("deletes the security group and returns the location of the delete job", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, _, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.SecurityGroupDeleteJobOperation,
+				ResourceGUID: "sg-guid",
+			}))
+		})
+	})
+
+	Describe("the POST /v3/security_groups/:guid/relationships/staging_spaces endpoint", func() {
+		BeforeEach(func() {
+			securityGroup.StagingSpaceGUIDs = []string{"space-guid", "other-space-guid"}
+			securityGroupRepo.BindSecurityGroupReturns(securityGroup, nil)
+			makeRequest(http.MethodPost, "/v3/security_groups/sg-guid/relationships/staging_spaces", `{
+				"data": [{"guid": "other-space-guid"}]
+			}`)
+		})
+
+		It("binds the security group to the staging workloads of the spaces", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:       "sg-guid",
+				Lifecycle:  repositories.SecurityGroupStagingLifecycle,
+				SpaceGUIDs: []string{"other-space-guid"},
+			}))
+		})
+
+		It("returns all the spaces the security group is bound to for staging", func() {
+			expectJSONResponse(http.StatusOK, `{
+				"data": [{"guid": "space-guid"}, {"guid": "other-space-guid"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/security_groups/sg-guid/relationships/staging_spaces"}
+				}
+			}`)
+		})
+
+		When("a space does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewUnprocessableEntityError(nil, `Spaces with guids ["other-space-guid"] do not exist, or you do not have access to them.`))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(`Spaces with guids ["other-space-guid"] do not exist, or you do not have access to them.`)
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/security_groups/:guid/relationships/running_spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodDelete, "/v3/security_groups/sg-guid/relationships/running_spaces/space-guid", "")
+		})
+
+		It("unbinds the security group from the running workloads of the space", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(message).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:      "sg-guid",
+				Lifecycle: repositories.SecurityGroupRunningLifecycle,
+				SpaceGUID: "space-guid",
+			}))
+		})
+	})
+})
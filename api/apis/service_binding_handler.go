@@ -27,24 +27,27 @@ type ServiceBindingHandler struct {
 	serviceBindingRepo  CFServiceBindingRepository
 	serviceInstanceRepo CFServiceInstanceRepository
 	serverURL           url.URL
+	auditEventRecorder  AuditEventRecorder
 	decoderValidator    *DecoderValidator
 }
 
 //counterfeiter:generate -o fake -fake-name CFServiceBindingRepository . CFServiceBindingRepository
 type CFServiceBindingRepository interface {
 	CreateServiceBinding(context.Context, authorization.Info, repositories.CreateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
+	GetServiceBinding(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
 	DeleteServiceBinding(context.Context, authorization.Info, string) error
 	ServiceBindingExists(ctx context.Context, info authorization.Info, spaceGUID, appGUID, serviceInsanceGUID string) (bool, error)
 	ListServiceBindings(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error)
 }
 
-func NewServiceBindingHandler(logger logr.Logger, serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, decoderValidator *DecoderValidator) *ServiceBindingHandler {
+func NewServiceBindingHandler(logger logr.Logger, serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, auditEventRecorder AuditEventRecorder, decoderValidator *DecoderValidator) *ServiceBindingHandler {
 	return &ServiceBindingHandler{
 		logger:              logger,
 		appRepo:             appRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
 		serverURL:           serverURL,
+		auditEventRecorder:  auditEventRecorder,
 		decoderValidator:    decoderValidator,
 	}
 }
@@ -89,6 +92,7 @@ func (h *ServiceBindingHandler) createHandler(authInfo authorization.Info, r *ht
 		h.logger.Error(err, "failed to create %s", repositories.ServiceBindingResourceType)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.service_binding.create", serviceBindingAuditTarget(serviceBinding), serviceBinding.SpaceGUID, payload)
 
 	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
@@ -98,11 +102,18 @@ func (h *ServiceBindingHandler) deleteHandler(authInfo authorization.Info, r *ht
 	vars := mux.Vars(r)
 	serviceBindingGUID := vars["guid"]
 
-	err := h.serviceBindingRepo.DeleteServiceBinding(ctx, authInfo, serviceBindingGUID)
+	serviceBinding, err := h.serviceBindingRepo.GetServiceBinding(ctx, authInfo, serviceBindingGUID)
+	if err != nil {
+		h.logger.Error(err, "error when getting service binding", "guid", serviceBindingGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err = h.serviceBindingRepo.DeleteServiceBinding(ctx, authInfo, serviceBindingGUID)
 	if err != nil {
 		h.logger.Error(err, "error when deleting service binding", "guid", serviceBindingGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, authInfo, "audit.service_binding.delete", serviceBindingAuditTarget(serviceBinding), serviceBinding.SpaceGUID, nil)
 
 	return NewHandlerResponse(http.StatusNoContent).WithBody(map[string]interface{}{}), nil
 }
@@ -127,6 +138,11 @@ func (h *ServiceBindingHandler) listHandler(authInfo authorization.Info, r *http
 		}
 	}
 
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	serviceBindingList, err := h.serviceBindingRepo.ListServiceBindings(ctx, authInfo, listFilter.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "failed to list %s", repositories.ServiceBindingResourceType)
@@ -151,6 +167,14 @@ func (h *ServiceBindingHandler) listHandler(authInfo authorization.Info, r *http
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceBindingList(serviceBindingList, appRecords, h.serverURL, *r.URL)), nil
 }
 
+func serviceBindingAuditTarget(serviceBinding repositories.ServiceBindingRecord) repositories.AuditEventTarget {
+	target := repositories.AuditEventTarget{GUID: serviceBinding.GUID, Type: "service_binding"}
+	if serviceBinding.Name != nil {
+		target.Name = *serviceBinding.Name
+	}
+	return target
+}
+
 func (h *ServiceBindingHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(ServiceBindingsPath).Methods("POST").HandlerFunc(w.Wrap(h.createHandler))
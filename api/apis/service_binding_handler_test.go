@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/apis/fake"
 	"code.cloudfoundry.org/korifi/api/repositories"
 
@@ -34,12 +35,14 @@ var _ = Describe("ServiceBindingHandler", func() {
 		serviceBindingRepo  *fake.CFServiceBindingRepository
 		appRepo             *fake.CFAppRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
+		auditEventRecorder  *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
 		appRepo = new(fake.CFAppRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -49,6 +52,7 @@ var _ = Describe("ServiceBindingHandler", func() {
 			serviceBindingRepo,
 			appRepo,
 			serviceInstanceRepo,
+			auditEventRecorder,
 			decoderValidator,
 		)
 		handler.RegisterRoutes(router)
@@ -91,6 +95,28 @@ var _ = Describe("ServiceBindingHandler", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		When("the binding is created", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
+					GUID:                serviceBindingGUID,
+					AppGUID:             appGUID,
+					ServiceInstanceGUID: serviceInstanceGUID,
+					SpaceGUID:           spaceGUID,
+				}, nil)
+			})
+
+			It("records an audit.service_binding.create event", func() {
+				Expect(rr.Code).To(Equal(http.StatusCreated))
+				Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+				_, actualAuthInfo, eventType, target, eventSpaceGUID, eventRequest := auditEventRecorder.RecordArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(eventType).To(Equal("audit.service_binding.create"))
+				Expect(target).To(Equal(repositories.AuditEventTarget{GUID: serviceBindingGUID, Type: "service_binding"}))
+				Expect(eventSpaceGUID).To(Equal(spaceGUID))
+				Expect(eventRequest).NotTo(BeNil())
+			})
+		})
+
 		When("the request body is invalid json", func() {
 			BeforeEach(func() {
 				req.Body = io.NopCloser(strings.NewReader(`{"description"`))
@@ -318,10 +344,10 @@ var _ = Describe("ServiceBindingHandler", func() {
 				  "total_results": 0,
 				  "total_pages": 1,
 				  "first": {
-					"href": "%[1]s/v3/service_credential_bindings"
+					"href": "%[1]s/v3/service_credential_bindings?page=1&per_page=50"
 				  },
 				  "last": {
-					"href": "%[1]s/v3/service_credential_bindings"
+					"href": "%[1]s/v3/service_credential_bindings?page=1&per_page=50"
 				  },
 				  "next": null,
 				  "previous": null
@@ -345,7 +371,7 @@ var _ = Describe("ServiceBindingHandler", func() {
 			BeforeEach(func() {
 				req.URL.RawQuery = "include=app"
 
-				appRepo.ListAppsReturns([]repositories.AppRecord{{Name: "some-app-name"}}, nil)
+				appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: appGUID, Name: "some-app-name"}}, nil)
 			})
 
 			It("calls the App repository to fetch apps from the bindings", func() {
@@ -376,7 +402,7 @@ var _ = Describe("ServiceBindingHandler", func() {
 			BeforeEach(func() {
 				req.URL.RawQuery = "app_guids=1,2,3"
 
-				appRepo.ListAppsReturns([]repositories.AppRecord{{Name: "some-app-name"}}, nil)
+				appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: appGUID, Name: "some-app-name"}}, nil)
 			})
 
 			It("passes the list of app GUIDs to the repository", func() {
@@ -391,7 +417,7 @@ var _ = Describe("ServiceBindingHandler", func() {
 			BeforeEach(func() {
 				req.URL.RawQuery = "service_instance_guids=1,2,3"
 
-				appRepo.ListAppsReturns([]repositories.AppRecord{{Name: "some-app-name"}}, nil)
+				appRepo.ListAppsReturns([]repositories.AppRecord{{GUID: appGUID, Name: "some-app-name"}}, nil)
 			})
 
 			It("passes the list of service instance GUIDs to the repository", func() {
@@ -425,7 +451,7 @@ var _ = Describe("ServiceBindingHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, service_instance_guids, include, type'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, service_instance_guids, include, type, page, per_page'")
 			})
 		})
 	})
@@ -434,6 +460,11 @@ var _ = Describe("ServiceBindingHandler", func() {
 		const serviceBindingGUID = "test-service-instance-guid"
 
 		BeforeEach(func() {
+			serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+				GUID:      serviceBindingGUID,
+				SpaceGUID: spaceGUID,
+			}, nil)
+
 			var err error
 			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/service_credential_bindings/"+serviceBindingGUID, nil)
 			Expect(err).NotTo(HaveOccurred())
@@ -449,5 +480,25 @@ var _ = Describe("ServiceBindingHandler", func() {
 			_, _, guid := serviceBindingRepo.DeleteServiceBindingArgsForCall(0)
 			Expect(guid).To(Equal(serviceBindingGUID))
 		})
+
+		It("records an audit.service_binding.delete event", func() {
+			Expect(auditEventRecorder.RecordCallCount()).To(Equal(1))
+			_, _, eventType, target, eventSpaceGUID, _ := auditEventRecorder.RecordArgsForCall(0)
+			Expect(eventType).To(Equal("audit.service_binding.delete"))
+			Expect(target.GUID).To(Equal(serviceBindingGUID))
+			Expect(eventSpaceGUID).To(Equal(spaceGUID))
+		})
+
+		When("the service binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBindingResourceType))
+			})
+
+			It("returns a not found error and does not delete the binding", func() {
+				expectNotFoundError(repositories.ServiceBindingResourceType + " not found")
+				Expect(serviceBindingRepo.DeleteServiceBindingCallCount()).To(BeZero())
+				Expect(auditEventRecorder.RecordCallCount()).To(BeZero())
+			})
+		})
 	})
 })
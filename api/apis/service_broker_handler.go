@@ -0,0 +1,192 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ServiceBrokersPath = "/v3/service_brokers"
+	ServiceBrokerPath  = "/v3/service_brokers/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceBrokerRepository . CFServiceBrokerRepository
+type CFServiceBrokerRepository interface {
+	CreateServiceBroker(context.Context, authorization.Info, repositories.CreateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error)
+	GetServiceBroker(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)
+	ListServiceBrokers(context.Context, authorization.Info, repositories.ListServiceBrokersMessage) ([]repositories.ServiceBrokerRecord, error)
+	DeleteServiceBroker(context.Context, authorization.Info, string) error
+}
+
+type ServiceBrokerHandler struct {
+	logger            logr.Logger
+	serverURL         url.URL
+	serviceBrokerRepo CFServiceBrokerRepository
+	jobRunner         JobRunner
+	decoderValidator  *DecoderValidator
+}
+
+func NewServiceBrokerHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	serviceBrokerRepo CFServiceBrokerRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *ServiceBrokerHandler {
+	return &ServiceBrokerHandler{
+		logger:            logger,
+		serverURL:         serverURL,
+		serviceBrokerRepo: serviceBrokerRepo,
+		jobRunner:         jobRunner,
+		decoderValidator:  decoderValidator,
+	}
+}
+
+func (h *ServiceBrokerHandler) serviceBrokerCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.ServiceBrokerCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	serviceBroker, err := h.serviceBrokerRepo.CreateServiceBroker(ctx, authInfo, payload.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to create service broker", "name", payload.Name)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartAwaiting(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.ServiceBrokerCreateJobOperation,
+		ResourceGUID: serviceBroker.GUID,
+	}, func(ctx context.Context) (bool, error) {
+		return h.isCatalogSynced(ctx, authInfo, serviceBroker.GUID)
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start service broker create job", "guid", serviceBroker.GUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+// isCatalogSynced reports whether the catalog of the broker has been synced into service offerings and plans
+func (h *ServiceBrokerHandler) isCatalogSynced(ctx context.Context, authInfo authorization.Info, guid string) (bool, error) {
+	serviceBroker, err := h.serviceBrokerRepo.GetServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return false, err
+	}
+
+	switch serviceBroker.CatalogSynced {
+	case metav1.ConditionTrue:
+		return true, nil
+	case metav1.ConditionFalse:
+		return false, apierrors.NewUnprocessableEntityError(errors.New(serviceBroker.CatalogSyncMessage), "Failed to fetch the catalog of the service broker: "+serviceBroker.CatalogSyncMessage)
+	default:
+		return false, nil
+	}
+}
+
+func (h *ServiceBrokerHandler) serviceBrokerGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	serviceBrokerGUID := mux.Vars(r)["guid"]
+
+	serviceBroker, err := h.serviceBrokerRepo.GetServiceBroker(ctx, authInfo, serviceBrokerGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch service broker", "guid", serviceBrokerGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceBroker(serviceBroker, h.serverURL)), nil
+}
+
+func (h *ServiceBrokerHandler) serviceBrokerListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.ServiceBrokerList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in ServiceBroker filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	serviceBrokers, err := h.serviceBrokerRepo.ListServiceBrokers(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list service brokers")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceBrokerList(serviceBrokers, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceBrokerHandler) serviceBrokerDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	serviceBrokerGUID := mux.Vars(r)["guid"]
+
+	err := h.serviceBrokerRepo.DeleteServiceBroker(ctx, authInfo, serviceBrokerGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete service broker", "guid", serviceBrokerGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.ServiceBrokerDeleteJobOperation,
+		ResourceGUID: serviceBrokerGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.serviceBrokerRepo.GetServiceBroker(ctx, authInfo, serviceBrokerGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start service broker delete job", "guid", serviceBrokerGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+func (h *ServiceBrokerHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(ServiceBrokersPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.serviceBrokerCreateHandler))
+	router.Path(ServiceBrokersPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.serviceBrokerListHandler))
+	router.Path(ServiceBrokerPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.serviceBrokerGetHandler))
+	router.Path(ServiceBrokerPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.serviceBrokerDeleteHandler))
+}
@@ -0,0 +1,247 @@
+package apis_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ServiceBrokerHandler", func() {
+	var (
+		req               *http.Request
+		serviceBrokerRepo *fake.CFServiceBrokerRepository
+		jobRunner         *fake.JobRunner
+		serviceBroker     repositories.ServiceBrokerRecord
+	)
+
+	BeforeEach(func() {
+		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
+		jobRunner = new(fake.JobRunner)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		serviceBroker = repositories.ServiceBrokerRecord{
+			GUID:      "broker-guid",
+			Name:      "my-broker",
+			URL:       "https://broker.example.com",
+			CreatedAt: "2019-05-10T17:17:48Z",
+			UpdatedAt: "2019-05-10T17:17:48Z",
+		}
+
+		NewServiceBrokerHandler(
+			logf.Log.WithName("TestServiceBrokerHandler"),
+			*serverURL,
+			serviceBrokerRepo,
+			jobRunner,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/service_brokers endpoint", func() {
+		makePostRequest := func(body string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/service_brokers", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			makePostRequest(`{
+				"name": "my-broker",
+				"url": "https://broker.example.com",
+				"authentication": {
+					"type": "basic",
+					"credentials": {"username": "user", "password": "pass"}
+				},
+				"metadata": {"labels": {"env": "test"}}
+			}`)
+			serviceBrokerRepo.CreateServiceBrokerReturns(serviceBroker, nil)
+			jobRunner.StartAwaitingReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+		})
+
+		It("creates the service broker", func() {
+			Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(Equal(1))
+			_, _, message := serviceBrokerRepo.CreateServiceBrokerArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateServiceBrokerMessage{
+				Name:     "my-broker",
+				URL:      "https://broker.example.com",
+				Username: "user",
+				Password: "pass",
+				Labels:   map[string]string{"env": "test"},
+			}))
+		})
+
+		It("returns 202 Accepted with the location of the job syncing the catalog", func() {
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			Expect(jobRunner.StartAwaitingCallCount()).To(Equal(1))
+			_, _, message, _ := jobRunner.StartAwaitingArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.ServiceBrokerCreateJobOperation,
+				ResourceGUID: "broker-guid",
+			}))
+		})
+
+		It("completes the job once the catalog has been synced", func() {
+			_, _, _, isDone := jobRunner.StartAwaitingArgsForCall(0)
+
+			serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{CatalogSynced: metav1.ConditionUnknown}, nil)
+			Expect(isDone(context.Background())).To(BeFalse())
+
+			serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{CatalogSynced: metav1.ConditionTrue}, nil)
+			Expect(isDone(context.Background())).To(BeTrue())
+
+			serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{CatalogSynced: metav1.ConditionFalse, CatalogSyncMessage: "connection refused"}, nil)
+			_, err := isDone(context.Background())
+			Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		})
+
+		When("the authentication type is not supported", func() {
+			BeforeEach(func() {
+				makePostRequest(`{
+					"name": "my-broker",
+					"url": "https://broker.example.com",
+					"authentication": {
+						"type": "oauth",
+						"credentials": {"username": "user", "password": "pass"}
+					}
+				}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Type must be one of [basic]")
+				Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the service broker fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.CreateServiceBrokerReturns(repositories.ServiceBrokerRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(jobRunner.StartAwaitingCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_brokers/:guid endpoint", func() {
+		BeforeEach(func() {
+			serviceBrokerRepo.GetServiceBrokerReturns(serviceBroker, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_brokers/broker-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service broker", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "broker-guid",
+				"name": "my-broker",
+				"url": "https://broker.example.com",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_brokers/broker-guid"},
+					"service_offerings": {"href": "https://api.example.org/v3/service_offerings?service_broker_guids=broker-guid"}
+				}
+			}`))
+		})
+
+		When("the service broker is forbidden", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBrokerResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Broker not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_brokers endpoint", func() {
+		BeforeEach(func() {
+			serviceBrokerRepo.ListServiceBrokersReturns([]repositories.ServiceBrokerRecord{serviceBroker}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_brokers?names=my-broker,other", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service brokers matching the filter", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(serviceBrokerRepo.ListServiceBrokersCallCount()).To(Equal(1))
+			_, _, message := serviceBrokerRepo.ListServiceBrokersArgsForCall(0)
+			Expect(message.Names).To(ConsistOf("my-broker", "other"))
+
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"broker-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_brokers?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/service_brokers/:guid endpoint", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodDelete, "/v3/service_brokers/broker-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the service broker and returns the location of the delete job", func() {
+			Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(1))
+			_, _, actualGUID := serviceBrokerRepo.DeleteServiceBrokerArgsForCall(0)
+			Expect(actualGUID).To(Equal("broker-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.ServiceBrokerDeleteJobOperation,
+				ResourceGUID: "broker-guid",
+			}))
+		})
+
+		When("deleting the service broker fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.DeleteServiceBrokerReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(jobRunner.StartDeletionCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -2,6 +2,8 @@ package apis
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -16,6 +18,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 
 	"github.com/gorilla/mux"
 
@@ -33,6 +36,7 @@ type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) error
+	PatchServiceInstance(context.Context, authorization.Info, repositories.PatchServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 }
 
 type ServiceInstanceHandler struct {
@@ -40,6 +44,8 @@ type ServiceInstanceHandler struct {
 	serverURL           url.URL
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           SpaceRepository
+	servicePlanRepo     CFServicePlanRepository
+	jobRunner           JobRunner
 	decoderValidator    *DecoderValidator
 }
 
@@ -48,6 +54,8 @@ func NewServiceInstanceHandler(
 	serverURL url.URL,
 	serviceInstanceRepo CFServiceInstanceRepository,
 	spaceRepo SpaceRepository,
+	servicePlanRepo CFServicePlanRepository,
+	jobRunner JobRunner,
 	decoderValidator *DecoderValidator,
 ) *ServiceInstanceHandler {
 	return &ServiceInstanceHandler{
@@ -55,6 +63,8 @@ func NewServiceInstanceHandler(
 		serverURL:           serverURL,
 		serviceInstanceRepo: serviceInstanceRepo,
 		spaceRepo:           spaceRepo,
+		servicePlanRepo:     servicePlanRepo,
+		jobRunner:           jobRunner,
 		decoderValidator:    decoderValidator,
 	}
 }
@@ -74,13 +84,59 @@ func (h *ServiceInstanceHandler) serviceInstanceCreateHandler(authInfo authoriza
 		return nil, apierrors.AsUnprocessibleEntity(err, "Invalid space. Ensure that the space exists and you have access to it.", apierrors.NotFoundError{})
 	}
 
+	if payload.Type == servicesv1alpha1.ManagedType {
+		servicePlanGUID := payload.Relationships.ServicePlan.Data.GUID
+		_, err = h.servicePlanRepo.GetServicePlan(ctx, authInfo, servicePlanGUID)
+		if err != nil {
+			h.logger.Error(err, "Failed to fetch service plan", "servicePlanGUID", servicePlanGUID)
+			return nil, apierrors.AsUnprocessibleEntity(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.", apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+		}
+	}
+
 	serviceInstanceRecord, err := h.serviceInstanceRepo.CreateServiceInstance(ctx, authInfo, payload.ToServiceInstanceCreateMessage())
 	if err != nil {
 		h.logger.Error(err, "Failed to create service instance", "Service Instance Name", serviceInstanceRecord.Name)
 		return nil, err
 	}
 
-	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
+	if serviceInstanceRecord.Type != servicesv1alpha1.ManagedType {
+		return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
+	}
+
+	job, err := h.jobRunner.StartAwaiting(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.ServiceInstanceCreateJobOperation,
+		ResourceGUID: serviceInstanceRecord.GUID,
+	}, func(ctx context.Context) (bool, error) {
+		return h.isProvisioned(ctx, authInfo, serviceInstanceRecord.GUID)
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start service instance create job", "guid", serviceInstanceRecord.GUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+// isProvisioned reports whether the broker has finished provisioning a managed service instance
+func (h *ServiceInstanceHandler) isProvisioned(ctx context.Context, authInfo authorization.Info, guid string) (bool, error) {
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return false, err
+	}
+
+	lastOperation := serviceInstance.LastOperation
+	if lastOperation == nil || lastOperation.Type != servicesv1alpha1.CreateOperationType {
+		return false, nil
+	}
+
+	switch lastOperation.State {
+	case servicesv1alpha1.OperationStateSucceeded:
+		return true, nil
+	case servicesv1alpha1.OperationStateFailed:
+		return false, apierrors.NewUnprocessableEntityError(errors.New(lastOperation.Description), "Service broker failed to provision the service instance: "+lastOperation.Description)
+	default:
+		return false, nil
+	}
 }
 
 func (h *ServiceInstanceHandler) serviceInstanceListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
@@ -119,6 +175,16 @@ func (h *ServiceInstanceHandler) serviceInstanceListHandler(authInfo authorizati
 		}
 	}
 
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	if err = listFilter.LabelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
 	serviceInstanceList, err := h.serviceInstanceRepo.ListServiceInstances(ctx, authInfo, listFilter.ToMessage())
 	if err != nil {
 		h.logger.Error(err, "Failed to list service instance")
@@ -128,6 +194,31 @@ func (h *ServiceInstanceHandler) serviceInstanceListHandler(authInfo authorizati
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceList(serviceInstanceList, h.serverURL, *r.URL)), nil
 }
 
+func (h *ServiceInstanceHandler) serviceInstancePatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	serviceInstanceGUID := vars["guid"]
+
+	var payload payloads.ServiceInstancePatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		h.logger.Error(err, "failed to get service instance")
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	serviceInstanceRecord, err := h.serviceInstanceRepo.PatchServiceInstance(ctx, authInfo, payload.ToMessage(serviceInstanceGUID, serviceInstance.SpaceGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch service instance", "guid", serviceInstanceGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
+}
+
 func (h *ServiceInstanceHandler) serviceInstanceDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -148,12 +239,30 @@ func (h *ServiceInstanceHandler) serviceInstanceDeleteHandler(authInfo authoriza
 		return nil, err
 	}
 
-	return NewHandlerResponse(http.StatusNoContent), nil
+	if serviceInstance.Type != servicesv1alpha1.ManagedType {
+		return NewHandlerResponse(http.StatusNoContent), nil
+	}
+
+	// managed service instances are gone once the broker has deprovisioned them
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.ServiceInstanceDeleteJobOperation,
+		ResourceGUID: serviceInstanceGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start service instance delete job", "guid", serviceInstanceGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
 }
 
 func (h *ServiceInstanceHandler) RegisterRoutes(router *mux.Router) {
 	w := NewAuthAwareHandlerFuncWrapper(h.logger)
 	router.Path(ServiceInstancesPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.serviceInstanceCreateHandler))
 	router.Path(ServiceInstancesPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.serviceInstanceListHandler))
+	router.Path(ServiceInstancePath).Methods(http.MethodPatch).HandlerFunc(w.Wrap(h.serviceInstancePatchHandler))
 	router.Path(ServiceInstancePath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.serviceInstanceDeleteHandler))
 }
@@ -33,11 +33,15 @@ var _ = Describe("ServiceInstanceHandler", func() {
 		req                 *http.Request
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		spaceRepo           *fake.SpaceRepository
+		servicePlanRepo     *fake.CFServicePlanRepository
+		jobRunner           *fake.JobRunner
 	)
 
 	BeforeEach(func() {
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		spaceRepo = new(fake.SpaceRepository)
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+		jobRunner = new(fake.JobRunner)
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -46,6 +50,8 @@ var _ = Describe("ServiceInstanceHandler", func() {
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			servicePlanRepo,
+			jobRunner,
 			decoderValidator,
 		)
 		serviceInstanceHandler.RegisterRoutes(router)
@@ -263,12 +269,12 @@ var _ = Describe("ServiceInstanceHandler", func() {
 						}
 					}
 				},
-				"type": "managed"
+				"type": "mystery"
 			}`)
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Type must be one of [user-provided]")
+				expectUnprocessableEntityError("Type must be one of [user-provided managed]")
 			})
 		})
 
@@ -346,6 +352,98 @@ var _ = Describe("ServiceInstanceHandler", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the service instance is managed", func() {
+			const managedBody = `{
+				"name": "my-db",
+				"type": "managed",
+				"relationships": {
+					"space": {"data": {"guid": "` + serviceInstanceSpaceGUID + `"}},
+					"service_plan": {"data": {"guid": "plan-guid"}}
+				}
+			}`
+
+			BeforeEach(func() {
+				serviceInstanceRepo.CreateServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					Name:            "my-db",
+					GUID:            serviceInstanceGUID,
+					SpaceGUID:       serviceInstanceSpaceGUID,
+					Type:            "managed",
+					ServicePlanGUID: "plan-guid",
+				}, nil)
+				jobRunner.StartAwaitingReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+
+				makePostRequest(managedBody)
+			})
+
+			It("creates the service instance from the plan", func() {
+				Expect(servicePlanRepo.GetServicePlanCallCount()).To(Equal(1))
+				_, _, actualPlanGUID := servicePlanRepo.GetServicePlanArgsForCall(0)
+				Expect(actualPlanGUID).To(Equal("plan-guid"))
+
+				Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(Equal(1))
+				_, _, message := serviceInstanceRepo.CreateServiceInstanceArgsForCall(0)
+				Expect(message.Type).To(Equal("managed"))
+				Expect(message.ServicePlanGUID).To(Equal("plan-guid"))
+			})
+
+			It("returns 202 Accepted with the location of the create job", func() {
+				Expect(rr.Code).To(Equal(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+				Expect(jobRunner.StartAwaitingCallCount()).To(Equal(1))
+				_, _, message, _ := jobRunner.StartAwaitingArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    repositories.ServiceInstanceCreateJobOperation,
+					ResourceGUID: serviceInstanceGUID,
+				}))
+			})
+
+			It("completes the job once the service instance has been provisioned", func() {
+				_, _, _, isDone := jobRunner.StartAwaitingArgsForCall(0)
+
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					LastOperation: &repositories.ServiceInstanceOperation{Type: "create", State: "in progress"},
+				}, nil)
+				Expect(isDone(ctx)).To(BeFalse())
+
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					LastOperation: &repositories.ServiceInstanceOperation{Type: "create", State: "succeeded"},
+				}, nil)
+				Expect(isDone(ctx)).To(BeTrue())
+
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					LastOperation: &repositories.ServiceInstanceOperation{Type: "create", State: "failed", Description: "no capacity"},
+				}, nil)
+				_, err := isDone(ctx)
+				Expect(err).To(MatchError(ContainSubstring("no capacity")))
+			})
+
+			When("the service plan is not set", func() {
+				BeforeEach(func() {
+					makePostRequest(`{
+						"name": "my-db",
+						"type": "managed",
+						"relationships": {"space": {"data": {"guid": "` + serviceInstanceSpaceGUID + `"}}}
+					}`)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("relationships.service_plan is a required field")
+				})
+			})
+
+			When("the service plan does not exist", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetServicePlanReturns(repositories.ServicePlanRecord{}, apierrors.NewNotFoundError(nil, repositories.ServicePlanResourceType))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+					Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("the GET /v3/service_instances endpoint", func() {
@@ -416,10 +514,10 @@ var _ = Describe("ServiceInstanceHandler", func() {
 						"total_results": 2,
 						"total_pages": 1,
 						"first": {
-						  "href": "%[1]s/v3/service_instances"
+						  "href": "%[1]s/v3/service_instances?page=1&per_page=50"
 						},
 						"last": {
-						  "href": "%[1]s/v3/service_instances"
+						  "href": "%[1]s/v3/service_instances?page=1&per_page=50"
 						},
 						"next": null,
 						"previous": null
@@ -588,7 +686,7 @@ var _ = Describe("ServiceInstanceHandler", func() {
 				})
 
 				It("correctly sets the query parameter in response pagination links", func() {
-					Expect(rr.Body.String()).To(ContainSubstring("/v3/service_instances?per_page=10"))
+					Expect(rr.Body.String()).To(ContainSubstring("/v3/service_instances?page=1&per_page=10"))
 				})
 			})
 		})
@@ -614,10 +712,10 @@ var _ = Describe("ServiceInstanceHandler", func() {
 				  "total_results": 0,
 				  "total_pages": 1,
 				  "first": {
-					"href": "%[1]s/v3/service_instances"
+					"href": "%[1]s/v3/service_instances?page=1&per_page=50"
 				  },
 				  "last": {
-					"href": "%[1]s/v3/service_instances"
+					"href": "%[1]s/v3/service_instances?page=1&per_page=50"
 				  },
 				  "next": null,
 				  "previous": null
@@ -644,7 +742,122 @@ var _ = Describe("ServiceInstanceHandler", func() {
 			})
 
 			It("returns an Unknown key error", func() {
-				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, space_guids, fields, order_by, per_page'")
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, space_guids, fields, order_by, label_selector, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/service_instances/:guid endpoint", func() {
+		makePatchRequest := func(requestBody string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPatch, "/v3/service_instances/"+serviceInstanceGUID, strings.NewReader(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      serviceInstanceGUID,
+				SpaceGUID: serviceInstanceSpaceGUID,
+			}, nil)
+			serviceInstanceRepo.PatchServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:        serviceInstanceGUID,
+				SpaceGUID:   serviceInstanceSpaceGUID,
+				Name:        "new-name",
+				Type:        serviceInstanceTypeUserProvided,
+				Tags:        []string{"database"},
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{},
+			}, nil)
+
+			makePatchRequest(`{
+				"name": "new-name",
+				"tags": ["database"],
+				"credentials": {"password": "secret"},
+				"metadata": {"labels": {"env": "prod"}}
+			}`)
+		})
+
+		It("patches the service instance in its space", func() {
+			Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			newName, prod := "new-name", "prod"
+			Expect(message).To(Equal(repositories.PatchServiceInstanceMessage{
+				GUID:        serviceInstanceGUID,
+				SpaceGUID:   serviceInstanceSpaceGUID,
+				Name:        &newName,
+				Tags:        &[]string{"database"},
+				Credentials: &map[string]string{"password": "secret"},
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"env": &prod},
+				},
+			}))
+		})
+
+		It("returns the updated service instance", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"name":"new-name"`))
+			Expect(rr.Body.String()).To(ContainSubstring(`"labels":{"env":"prod"}`))
+		})
+
+		When("the credentials are not patched", func() {
+			BeforeEach(func() {
+				makePatchRequest(`{"name": "new-name"}`)
+			})
+
+			It("leaves them unchanged", func() {
+				_, _, message := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(message.Credentials).To(BeNil())
+				Expect(message.Tags).To(BeNil())
+			})
+		})
+
+		When("the tags are too long", func() {
+			BeforeEach(func() {
+				makePatchRequest(fmt.Sprintf(`{"tags": [%q, %q]}`, randomString(1024), randomString(1024)))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("getting the service instance fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the new name is already taken", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.PatchServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewUnprocessableEntityError(nil, "The service instance name is taken: new-name"),
+				)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The service instance name is taken: new-name")
+			})
+		})
+
+		When("patching the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.PatchServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
 			})
 		})
 	})
@@ -707,6 +920,25 @@ var _ = Describe("ServiceInstanceHandler", func() {
 				Expect(rr.Code).To(Equal(http.StatusInternalServerError))
 			})
 		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{SpaceGUID: spaceGUID, Type: "managed"}, nil)
+				jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+			})
+
+			It("returns 202 Accepted with the location of the delete job", func() {
+				Expect(rr.Code).To(Equal(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(1))
+				_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    repositories.ServiceInstanceDeleteJobOperation,
+					ResourceGUID: serviceInstanceGUID,
+				}))
+			})
+		})
 	})
 })
 
@@ -0,0 +1,109 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	ServiceOfferingsPath = "/v3/service_offerings"
+	ServiceOfferingPath  = "/v3/service_offerings/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceOfferingRepository . CFServiceOfferingRepository
+type CFServiceOfferingRepository interface {
+	GetServiceOffering(context.Context, authorization.Info, string) (repositories.ServiceOfferingRecord, error)
+	ListServiceOfferings(context.Context, authorization.Info, repositories.ListServiceOfferingsMessage) ([]repositories.ServiceOfferingRecord, error)
+}
+
+type ServiceOfferingHandler struct {
+	logger              logr.Logger
+	serverURL           url.URL
+	serviceOfferingRepo CFServiceOfferingRepository
+}
+
+func NewServiceOfferingHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	serviceOfferingRepo CFServiceOfferingRepository,
+) *ServiceOfferingHandler {
+	return &ServiceOfferingHandler{
+		logger:              logger,
+		serverURL:           serverURL,
+		serviceOfferingRepo: serviceOfferingRepo,
+	}
+}
+
+func (h *ServiceOfferingHandler) serviceOfferingGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	serviceOfferingGUID := mux.Vars(r)["guid"]
+
+	serviceOffering, err := h.serviceOfferingRepo.GetServiceOffering(ctx, authInfo, serviceOfferingGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch service offering", "guid", serviceOfferingGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceOffering(serviceOffering, h.serverURL)), nil
+}
+
+func (h *ServiceOfferingHandler) serviceOfferingListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.ServiceOfferingList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in ServiceOffering filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	serviceOfferings, err := h.serviceOfferingRepo.ListServiceOfferings(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list service offerings")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceOfferingList(serviceOfferings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceOfferingHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(ServiceOfferingsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.serviceOfferingListHandler))
+	router.Path(ServiceOfferingPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.serviceOfferingGetHandler))
+}
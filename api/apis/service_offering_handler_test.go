@@ -0,0 +1,138 @@
+package apis_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ServiceOfferingHandler", func() {
+	var (
+		req                 *http.Request
+		serviceOfferingRepo *fake.CFServiceOfferingRepository
+		serviceOffering     repositories.ServiceOfferingRecord
+	)
+
+	BeforeEach(func() {
+		serviceOfferingRepo = new(fake.CFServiceOfferingRepository)
+		serviceOffering = repositories.ServiceOfferingRecord{
+			GUID:              "offering-guid",
+			Name:              "postgres",
+			Description:       "a database",
+			Tags:              []string{"sql"},
+			Bindable:          true,
+			CatalogID:         "service-id",
+			ServiceBrokerGUID: "broker-guid",
+			CreatedAt:         "2019-05-10T17:17:48Z",
+			UpdatedAt:         "2019-05-10T17:17:48Z",
+		}
+
+		NewServiceOfferingHandler(
+			logf.Log.WithName("TestServiceOfferingHandler"),
+			*serverURL,
+			serviceOfferingRepo,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/service_offerings/:guid endpoint", func() {
+		BeforeEach(func() {
+			serviceOfferingRepo.GetServiceOfferingReturns(serviceOffering, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_offerings/offering-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service offering", func() {
+			_, _, actualGUID := serviceOfferingRepo.GetServiceOfferingArgsForCall(0)
+			Expect(actualGUID).To(Equal("offering-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "offering-guid",
+				"name": "postgres",
+				"description": "a database",
+				"available": true,
+				"tags": ["sql"],
+				"requires": [],
+				"shareable": false,
+				"documentation_url": null,
+				"broker_catalog": {
+					"id": "service-id",
+					"metadata": {},
+					"features": {
+						"plan_updateable": false,
+						"bindable": true,
+						"instances_retrievable": false,
+						"bindings_retrievable": false,
+						"allow_context_updates": false
+					}
+				},
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {
+					"service_broker": {"data": {"guid": "broker-guid"}}
+				},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_offerings/offering-guid"},
+					"service_plans": {"href": "https://api.example.org/v3/service_plans?service_offering_guids=offering-guid"},
+					"service_broker": {"href": "https://api.example.org/v3/service_brokers/broker-guid"}
+				}
+			}`))
+		})
+
+		When("the service offering does not exist", func() {
+			BeforeEach(func() {
+				serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceOfferingResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Offering not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_offerings endpoint", func() {
+		BeforeEach(func() {
+			serviceOfferingRepo.ListServiceOfferingsReturns([]repositories.ServiceOfferingRecord{serviceOffering}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_offerings?names=postgres&service_broker_guids=broker-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service offerings matching the filters", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := serviceOfferingRepo.ListServiceOfferingsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListServiceOfferingsMessage{
+				Names:              []string{"postgres"},
+				ServiceBrokerGUIDs: []string{"broker-guid"},
+			}))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"offering-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_offerings?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, service_broker_guids, page, per_page'")
+			})
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	ServicePlansPath = "/v3/service_plans"
+	ServicePlanPath  = "/v3/service_plans/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServicePlanRepository . CFServicePlanRepository
+type CFServicePlanRepository interface {
+	GetServicePlan(context.Context, authorization.Info, string) (repositories.ServicePlanRecord, error)
+	ListServicePlans(context.Context, authorization.Info, repositories.ListServicePlansMessage) ([]repositories.ServicePlanRecord, error)
+}
+
+type ServicePlanHandler struct {
+	logger          logr.Logger
+	serverURL       url.URL
+	servicePlanRepo CFServicePlanRepository
+}
+
+func NewServicePlanHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	servicePlanRepo CFServicePlanRepository,
+) *ServicePlanHandler {
+	return &ServicePlanHandler{
+		logger:          logger,
+		serverURL:       serverURL,
+		servicePlanRepo: servicePlanRepo,
+	}
+}
+
+func (h *ServicePlanHandler) servicePlanGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	servicePlanGUID := mux.Vars(r)["guid"]
+
+	servicePlan, err := h.servicePlanRepo.GetServicePlan(ctx, authInfo, servicePlanGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch service plan", "guid", servicePlanGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServicePlan(servicePlan, h.serverURL)), nil
+}
+
+func (h *ServicePlanHandler) servicePlanListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.ServicePlanList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in ServicePlan filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	servicePlans, err := h.servicePlanRepo.ListServicePlans(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list service plans")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServicePlanList(servicePlans, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServicePlanHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(ServicePlansPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.servicePlanListHandler))
+	router.Path(ServicePlanPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.servicePlanGetHandler))
+}
@@ -0,0 +1,125 @@
+package apis_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ServicePlanHandler", func() {
+	var (
+		req             *http.Request
+		servicePlanRepo *fake.CFServicePlanRepository
+		servicePlan     repositories.ServicePlanRecord
+	)
+
+	BeforeEach(func() {
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+		servicePlan = repositories.ServicePlanRecord{
+			GUID:                "plan-guid",
+			Name:                "small",
+			Description:         "a small database",
+			Free:                true,
+			CatalogID:           "small-id",
+			ServiceOfferingGUID: "offering-guid",
+			CreatedAt:           "2019-05-10T17:17:48Z",
+			UpdatedAt:           "2019-05-10T17:17:48Z",
+		}
+
+		NewServicePlanHandler(
+			logf.Log.WithName("TestServicePlanHandler"),
+			*serverURL,
+			servicePlanRepo,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/service_plans/:guid endpoint", func() {
+		BeforeEach(func() {
+			servicePlanRepo.GetServicePlanReturns(servicePlan, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_plans/plan-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service plan", func() {
+			_, _, actualGUID := servicePlanRepo.GetServicePlanArgsForCall(0)
+			Expect(actualGUID).To(Equal("plan-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "plan-guid",
+				"name": "small",
+				"description": "a small database",
+				"visibility_type": "public",
+				"available": true,
+				"free": true,
+				"costs": [],
+				"broker_catalog": {
+					"id": "small-id",
+					"metadata": {}
+				},
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {
+					"service_offering": {"data": {"guid": "offering-guid"}}
+				},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_plans/plan-guid"},
+					"service_offering": {"href": "https://api.example.org/v3/service_offerings/offering-guid"}
+				}
+			}`))
+		})
+
+		When("the service plan does not exist", func() {
+			BeforeEach(func() {
+				servicePlanRepo.GetServicePlanReturns(repositories.ServicePlanRecord{}, apierrors.NewNotFoundError(nil, repositories.ServicePlanResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Plan not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/service_plans endpoint", func() {
+		BeforeEach(func() {
+			servicePlanRepo.ListServicePlansReturns([]repositories.ServicePlanRecord{servicePlan}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_plans?service_offering_guids=offering-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service plans matching the filters", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := servicePlanRepo.ListServicePlansArgsForCall(0)
+			Expect(message.ServiceOfferingGUIDs).To(ConsistOf("offering-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"plan-guid"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/service_plans?foo=bar", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'names, service_offering_guids, page, per_page'")
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 
 	"github.com/go-logr/logr"
@@ -31,6 +32,11 @@ func NewServiceRouteBindingHandler(
 }
 
 func (h *ServiceRouteBindingHandler) serviceRouteBindingsListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	if err := payloads.PaginationFromQuery(r.URL.Query()).Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
 	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingsList(h.serverURL, *r.URL)), nil
 }
 
@@ -41,10 +41,10 @@ var _ = Describe("ServiceRouteBinding", func() {
 				  "total_results": 0,
 				  "total_pages": 1,
 				  "first": {
-					"href": "%[1]s/v3/service_route_bindings"
+					"href": "%[1]s/v3/service_route_bindings?page=1&per_page=50"
 				  },
 				  "last": {
-					"href": "%[1]s/v3/service_route_bindings"
+					"href": "%[1]s/v3/service_route_bindings?page=1&per_page=50"
 				  },
 				  "next": null,
 				  "previous": null
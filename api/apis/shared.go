@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
 
 	"code.cloudfoundry.org/bytefmt"
 	"github.com/go-http-utils/headers"
@@ -130,8 +133,19 @@ func wireValidator() (*validator.Validate, ut.Translator, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	err = v.RegisterValidation("filemode", fileModeString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v.RegisterCustomTypeFunc(quotaLimitValue, payloads.QuotaLimit{})
+
+	v.RegisterStructValidation(checkLifecycleData, payloads.Lifecycle{})
 
 	v.RegisterStructValidation(checkRoleTypeAndOrgSpace, payloads.RoleCreate{})
+	v.RegisterStructValidation(checkServiceInstancePlan, payloads.ServiceInstanceCreate{})
+	v.RegisterStructValidation(checkDeploymentDropletOrRevision, payloads.DeploymentCreate{})
+	v.RegisterStructValidation(checkSecurityGroupRule, payloads.SecurityGroupRule{})
 	err = v.RegisterTranslation("cannot_have_both_org_and_space_set", trans, func(ut ut.Translator) error {
 		return ut.Add("cannot_have_both_org_and_space_set", "Cannot pass both 'organization' and 'space' in a create role request", false)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -142,6 +156,16 @@ func wireValidator() (*validator.Validate, ut.Translator, error) {
 		return nil, nil, err
 	}
 
+	err = v.RegisterTranslation("cannot_have_both_droplet_and_revision_set", trans, func(ut ut.Translator) error {
+		return ut.Add("cannot_have_both_droplet_and_revision_set", "Cannot pass both 'droplet' and 'revision' in a create deployment request", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("cannot_have_both_droplet_and_revision_set", fe.Field())
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	err = v.RegisterTranslation("valid_role", trans, func(ut ut.Translator) error {
 		return ut.Add("valid_role", "{0} is not a valid role", false)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -152,6 +176,49 @@ func wireValidator() (*validator.Validate, ut.Translator, error) {
 		return nil, nil, err
 	}
 
+	for _, conditionallyRequiredTag := range []string{"required_if", "required_with"} {
+		tag := conditionallyRequiredTag
+		err = v.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
+			return ut.Add(tag, "{0} is a required field", false)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T(fe.Tag(), fe.Field())
+			return t
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = v.RegisterTranslation("filemode", trans, func(ut ut.Translator) error {
+		return ut.Add("filemode", "{0} must be an octal file mode", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("filemode", fe.Field())
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = v.RegisterTranslation("dns_rfc1035_label", trans, func(ut ut.Translator) error {
+		return ut.Add("dns_rfc1035_label", "{0} must consist of lower case alphanumeric characters or '-', start with a letter and end with an alphanumeric character", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("dns_rfc1035_label", fe.Field())
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = v.RegisterTranslation("security_group_rule", trans, func(ut ut.Translator) error {
+		return ut.Add("security_group_rule", "{0}: {1}", false)
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		t, _ := ut.T("security_group_rule", securityGroupRuleName(fe), fe.Param())
+		return t
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	err = v.RegisterTranslation("route", trans, func(ut ut.Translator) error {
 		return ut.Add("invalid_route", `"{0}" is not a valid route URI`, false)
 	}, func(ut ut.Translator, fe validator.FieldError) string {
@@ -215,6 +282,21 @@ func checkRoleTypeAndOrgSpace(sl validator.StructLevel) {
 	}
 }
 
+func checkLifecycleData(sl validator.StructLevel) {
+	lifecycle := sl.Current().Interface().(payloads.Lifecycle)
+
+	if lifecycle.Type == "docker" {
+		return
+	}
+
+	if lifecycle.Data.Buildpacks == nil {
+		sl.ReportError(lifecycle.Data.Buildpacks, "Buildpacks", "Buildpacks", "required", "")
+	}
+	if lifecycle.Data.Stack == "" {
+		sl.ReportError(lifecycle.Data.Stack, "Stack", "Stack", "required", "")
+	}
+}
+
 func writeResponse(w http.ResponseWriter, status int, responseBody interface{}) {
 	w.Header().Set(headers.ContentType, "application/json")
 	w.WriteHeader(status)
@@ -260,6 +342,11 @@ func routeString(fl validator.FieldLevel) bool {
 	return routeRegex.MatchString(val)
 }
 
+func fileModeString(fl validator.FieldLevel) bool {
+	_, err := strconv.ParseUint(fl.Field().String(), 8, 32)
+	return err == nil
+}
+
 func serviceInstanceTagLength(fl validator.FieldLevel) bool {
 	tags, ok := fl.Field().Interface().([]string)
 	if !ok {
@@ -273,3 +360,55 @@ func serviceInstanceTagLength(fl validator.FieldLevel) bool {
 
 	return tagLen < 2048
 }
+
+func checkDeploymentDropletOrRevision(sl validator.StructLevel) {
+	deploymentCreate := sl.Current().Interface().(payloads.DeploymentCreate)
+
+	if deploymentCreate.Droplet != nil && deploymentCreate.Revision != nil {
+		sl.ReportError(deploymentCreate.Revision, "revision", "Revision", "cannot_have_both_droplet_and_revision_set", "")
+	}
+}
+
+// checkSecurityGroupRule validates rules with the controllers, which are the ones turning them into network policies
+func checkSecurityGroupRule(sl validator.StructLevel) {
+	rule := sl.Current().Interface().(payloads.SecurityGroupRule)
+
+	err := networkingv1alpha1.SecurityGroupRule{
+		Protocol:    rule.Protocol,
+		Destination: rule.Destination,
+		Ports:       rule.Ports,
+		Type:        rule.Type,
+		Code:        rule.Code,
+	}.Validate()
+	if err != nil {
+		sl.ReportError(rule, "rule", "Rule", "security_group_rule", err.Error())
+	}
+}
+
+// securityGroupRuleName names the invalid rule after its index, such as Rules[0]
+func securityGroupRuleName(fe validator.FieldError) string {
+	namespace := strings.TrimSuffix(fe.StructNamespace(), ".Rule")
+	if i := strings.Index(namespace, "."); i >= 0 {
+		namespace = namespace[i+1:]
+	}
+
+	return namespace
+}
+
+func checkServiceInstancePlan(sl validator.StructLevel) {
+	serviceInstanceCreate := sl.Current().Interface().(payloads.ServiceInstanceCreate)
+
+	if serviceInstanceCreate.Type == "managed" && serviceInstanceCreate.Relationships.ServicePlan == nil {
+		sl.ReportError(serviceInstanceCreate.Relationships.ServicePlan, "relationships.service_plan", "ServicePlan", "required", "")
+	}
+}
+
+// quotaLimitValue validates quota limits as their value. Unlimited limits are validated as nil, which omitempty skips.
+func quotaLimitValue(field reflect.Value) interface{} {
+	limit, ok := field.Interface().(payloads.QuotaLimit)
+	if !ok || limit.Value == nil {
+		return nil
+	}
+
+	return *limit.Value
+}
@@ -0,0 +1,203 @@
+package apis
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	SidecarPath     = "/v3/sidecars/{guid}"
+	AppSidecarsPath = "/v3/apps/{guid}/sidecars"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+type CFSidecarRepository interface {
+	GetSidecar(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+	DeleteSidecar(context.Context, authorization.Info, repositories.DeleteSidecarMessage) error
+}
+
+type SidecarHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	sidecarRepo      CFSidecarRepository
+	decoderValidator *DecoderValidator
+}
+
+func NewSidecarHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	sidecarRepo CFSidecarRepository,
+	decoderValidator *DecoderValidator,
+) *SidecarHandler {
+	return &SidecarHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		sidecarRepo:      sidecarRepo,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *SidecarHandler) sidecarGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	sidecarGUID := mux.Vars(r)["guid"]
+
+	sidecar, err := h.sidecarRepo.GetSidecar(ctx, authInfo, sidecarGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar)), nil
+}
+
+func (h *SidecarHandler) sidecarPatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	sidecarGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SidecarPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	sidecar, err := h.sidecarRepo.GetSidecar(ctx, authInfo, sidecarGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	sidecar, err = h.sidecarRepo.PatchSidecar(ctx, authInfo, payload.ToMessage(sidecarGUID, sidecar.SpaceGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch sidecar", "SidecarGUID", sidecarGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar)), nil
+}
+
+func (h *SidecarHandler) sidecarDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	sidecarGUID := mux.Vars(r)["guid"]
+
+	sidecar, err := h.sidecarRepo.GetSidecar(ctx, authInfo, sidecarGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch sidecar", "SidecarGUID", sidecarGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err = h.sidecarRepo.DeleteSidecar(ctx, authInfo, repositories.DeleteSidecarMessage{
+		GUID:      sidecar.GUID,
+		SpaceGUID: sidecar.SpaceGUID,
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to delete sidecar", "SidecarGUID", sidecarGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
+func (h *SidecarHandler) appSidecarCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SidecarCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	message := payload.ToMessage(app.GUID, app.SpaceGUID)
+	message.AppEtcdUID = app.EtcdUID
+	sidecar, err := h.sidecarRepo.CreateSidecar(ctx, authInfo, message)
+	if err != nil {
+		h.logger.Error(err, "Failed to create sidecar", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForSidecar(sidecar)), nil
+}
+
+func (h *SidecarHandler) appSidecarListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.SidecarList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Sidecar filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to list sidecars", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSidecarList(sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *SidecarHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(SidecarPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.sidecarGetHandler))
+	router.Path(SidecarPath).Methods(http.MethodPatch).HandlerFunc(w.Wrap(h.sidecarPatchHandler))
+	router.Path(SidecarPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.sidecarDeleteHandler))
+	router.Path(AppSidecarsPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.appSidecarCreateHandler))
+	router.Path(AppSidecarsPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.appSidecarListHandler))
+}
@@ -0,0 +1,312 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("SidecarHandler", func() {
+	var (
+		req         *http.Request
+		appRepo     *fake.CFAppRepository
+		sidecarRepo *fake.CFSidecarRepository
+		sidecar     repositories.SidecarRecord
+	)
+
+	makeRequest := func(method, path, body string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			EtcdUID:   "app-uid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		sidecarRepo = new(fake.CFSidecarRepository)
+		sidecar = repositories.SidecarRecord{
+			GUID:         "sidecar-guid",
+			Name:         "apm-agent",
+			Command:      "./apm-agent",
+			ProcessTypes: []string{"web"},
+			Origin:       "user",
+			AppGUID:      "app-guid",
+			SpaceGUID:    "space-guid",
+			CreatedAt:    "2019-05-10T17:17:48Z",
+			UpdatedAt:    "2019-05-10T17:17:48Z",
+		}
+		sidecarRepo.GetSidecarReturns(sidecar, nil)
+
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		NewSidecarHandler(
+			logf.Log.WithName("TestSidecarHandler"),
+			*serverURL,
+			appRepo,
+			sidecarRepo,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/sidecars/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodGet, "/v3/sidecars/sidecar-guid", "")
+		})
+
+		It("returns the sidecar", func() {
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "sidecar-guid",
+				"name": "apm-agent",
+				"command": "./apm-agent",
+				"process_types": ["web"],
+				"memory_in_mb": null,
+				"origin": "user",
+				"relationships": {"app": {"data": {"guid": "app-guid"}}},
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z"
+			}`))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Sidecar not found")
+			})
+		})
+
+		When("fetching the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/sidecars/:guid endpoint", func() {
+		BeforeEach(func() {
+			sidecarRepo.PatchSidecarReturns(sidecar, nil)
+			makeRequest(http.MethodPatch, "/v3/sidecars/sidecar-guid", `{"command": "./apm-agent --verbose", "memory_in_mb": 128}`)
+		})
+
+		It("patches the sidecar", func() {
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.PatchSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("sidecar-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.Name).To(BeNil())
+			Expect(message.Command).To(PointTo(Equal("./apm-agent --verbose")))
+			Expect(message.ProcessTypes).To(BeNil())
+			Expect(message.MemoryMB).To(PointTo(BeEquivalentTo(128)))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"sidecar-guid"`))
+		})
+
+		When("the name is not a valid container name", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPatch, "/v3/sidecars/sidecar-guid", `{"name": "APM Agent"}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Name must consist of lower case alphanumeric characters or '-', start with a letter and end with an alphanumeric character")
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Sidecar not found")
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/sidecars/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodDelete, "/v3/sidecars/sidecar-guid", "")
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteSidecarMessage{
+				GUID:      "sidecar-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Sidecar not found")
+				Expect(sidecarRepo.DeleteSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/apps/:guid/sidecars endpoint", func() {
+		BeforeEach(func() {
+			sidecarRepo.CreateSidecarReturns(sidecar, nil)
+			makeRequest(http.MethodPost, "/v3/apps/app-guid/sidecars", `{
+				"name": "apm-agent",
+				"command": "./apm-agent",
+				"process_types": ["web"],
+				"memory_in_mb": 64
+			}`)
+		})
+
+		It("creates the sidecar for the app", func() {
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateSidecarMessage{
+				Name:         "apm-agent",
+				Command:      "./apm-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+				AppGUID:      "app-guid",
+				AppEtcdUID:   "app-uid",
+				SpaceGUID:    "space-guid",
+			}))
+
+			Expect(rr.Code).To(Equal(http.StatusCreated))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"sidecar-guid"`))
+		})
+
+		When("no process types are given", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/apps/app-guid/sidecars", `{"name": "apm-agent", "command": "./apm-agent", "process_types": []}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("ProcessTypes must contain at least 1 item")
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("a sidecar with the same name exists", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, apierrors.NewUnprocessableEntityError(nil, "Sidecar with name 'apm-agent' already exists for given app"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Sidecar with name 'apm-agent' already exists for given app")
+			})
+		})
+	})
+
+	Describe("the GET /v3/apps/:guid/sidecars endpoint", func() {
+		BeforeEach(func() {
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{sidecar}, nil)
+			makeRequest(http.MethodGet, "/v3/apps/app-guid/sidecars", "")
+		})
+
+		It("lists the sidecars of the app", func() {
+			_, _, message := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"total_results":1`))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"sidecar-guid"`))
+		})
+
+		When("an unknown query parameter is given", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodGet, "/v3/apps/app-guid/sidecars?foo=bar", "")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'page, per_page'")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+			})
+		})
+	})
+})
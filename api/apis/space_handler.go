@@ -31,6 +31,7 @@ type SpaceRepository interface {
 	ListSpaces(context.Context, authorization.Info, repositories.ListSpacesMessage) ([]repositories.SpaceRecord, error)
 	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, repositories.DeleteSpaceMessage) error
+	PatchSpace(context.Context, authorization.Info, repositories.PatchSpaceMessage) (repositories.SpaceRecord, error)
 }
 
 type SpaceHandler struct {
@@ -38,15 +39,19 @@ type SpaceHandler struct {
 	logger                  logr.Logger
 	apiBaseURL              url.URL
 	imageRegistrySecretName string
+	jobRunner               JobRunner
+	auditEventRecorder      AuditEventRecorder
 	decoderValidator        *DecoderValidator
 }
 
-func NewSpaceHandler(apiBaseURL url.URL, imageRegistrySecretName string, spaceRepo SpaceRepository, decoderValidator *DecoderValidator) *SpaceHandler {
+func NewSpaceHandler(apiBaseURL url.URL, imageRegistrySecretName string, spaceRepo SpaceRepository, jobRunner JobRunner, auditEventRecorder AuditEventRecorder, decoderValidator *DecoderValidator) *SpaceHandler {
 	return &SpaceHandler{
 		apiBaseURL:              apiBaseURL,
 		imageRegistrySecretName: imageRegistrySecretName,
 		spaceRepo:               spaceRepo,
 		logger:                  controllerruntime.Log.WithName("Space Handler"),
+		jobRunner:               jobRunner,
+		auditEventRecorder:      auditEventRecorder,
 		decoderValidator:        decoderValidator,
 	}
 }
@@ -66,6 +71,7 @@ func (h *SpaceHandler) spaceCreateHandler(info authorization.Info, r *http.Reque
 		h.logger.Error(err, "Failed to create space", "Space Name", space.Name)
 		return nil, apierrors.AsUnprocessibleEntity(err, "Invalid organization. Ensure the organization exists and you have access to it.", apierrors.NotFoundError{})
 	}
+	h.auditEventRecorder.Record(ctx, info, "audit.space.create", repositories.AuditEventTarget{GUID: record.GUID, Type: "space", Name: record.Name}, record.GUID, payload)
 
 	spaceResponse := presenter.ForCreateSpace(record, h.apiBaseURL)
 	return NewHandlerResponse(http.StatusCreated).WithBody(spaceResponse), nil
@@ -74,12 +80,24 @@ func (h *SpaceHandler) spaceCreateHandler(info authorization.Info, r *http.Reque
 func (h *SpaceHandler) spaceListHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 
+	if err := payloads.PaginationFromQuery(r.URL.Query()).Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	labelSelector := payloads.LabelSelectorFromQuery(r.URL.Query())
+	if err := labelSelector.Validate(); err != nil {
+		h.logger.Info("Invalid label_selector query parameter", "error", err.Error())
+		return nil, err
+	}
+
 	orgUIDs := parseCommaSeparatedList(r.URL.Query().Get("organization_guids"))
 	names := parseCommaSeparatedList(r.URL.Query().Get("names"))
 
 	spaces, err := h.spaceRepo.ListSpaces(ctx, info, repositories.ListSpacesMessage{
 		OrganizationGUIDs: orgUIDs,
 		Names:             names,
+		LabelSelector:     labelSelector.Selector,
 	})
 	if err != nil {
 		h.logger.Error(err, "Failed to fetch spaces")
@@ -90,6 +108,32 @@ func (h *SpaceHandler) spaceListHandler(info authorization.Info, r *http.Request
 	return NewHandlerResponse(http.StatusOK).WithBody(spaceList), nil
 }
 
+func (h *SpaceHandler) spacePatchHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	spaceGUID := vars["guid"]
+
+	var payload payloads.SpacePatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	spaceRecord, err := h.spaceRepo.GetSpace(ctx, info, spaceGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch space", "SpaceGUID", spaceGUID)
+		return nil, err
+	}
+
+	record, err := h.spaceRepo.PatchSpace(ctx, info, payload.ToMessage(spaceGUID, spaceRecord.OrganizationGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch space", "SpaceGUID", spaceGUID)
+		return nil, err
+	}
+	h.auditEventRecorder.Record(ctx, info, "audit.space.update", repositories.AuditEventTarget{GUID: record.GUID, Type: "space", Name: record.Name}, record.GUID, payload)
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForCreateSpace(record, h.apiBaseURL)), nil
+}
+
 func (h *SpaceHandler) spaceDeleteHandler(info authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -110,8 +154,21 @@ func (h *SpaceHandler) spaceDeleteHandler(info authorization.Info, r *http.Reque
 		h.logger.Error(err, "Failed to delete space", "SpaceGUID", spaceGUID)
 		return nil, err
 	}
+	h.auditEventRecorder.Record(ctx, info, "audit.space.delete-request", repositories.AuditEventTarget{GUID: spaceRecord.GUID, Type: "space", Name: spaceRecord.Name}, spaceRecord.GUID, nil)
+
+	job, err := h.jobRunner.StartDeletion(ctx, info, repositories.CreateJobMessage{
+		Operation:    repositories.SpaceDeleteJobOperation,
+		ResourceGUID: spaceGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.spaceRepo.GetSpace(ctx, info, spaceGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start space delete job", "SpaceGUID", spaceGUID)
+		return nil, err
+	}
 
-	return NewHandlerResponse(http.StatusAccepted).WithHeader(headers.Location, fmt.Sprintf("%s/v3/jobs/space.delete-%s", h.apiBaseURL.String(), spaceGUID)), nil
+	return NewHandlerResponse(http.StatusAccepted).WithHeader(headers.Location, fmt.Sprintf("%s/v3/jobs/%s", h.apiBaseURL.String(), job.GUID)), nil
 }
 
 func (h *SpaceHandler) RegisterRoutes(router *mux.Router) {
@@ -119,6 +176,7 @@ func (h *SpaceHandler) RegisterRoutes(router *mux.Router) {
 	router.Path(SpacesPath).Methods("GET").HandlerFunc(w.Wrap(h.spaceListHandler))
 	router.Path(SpacesPath).Methods("POST").HandlerFunc(w.Wrap(h.spaceCreateHandler))
 	router.Path(SpacePath).Methods("DELETE").HandlerFunc(w.Wrap(h.spaceDeleteHandler))
+	router.Path(SpacePath).Methods("PATCH").HandlerFunc(w.Wrap(h.spacePatchHandler))
 }
 
 func parseCommaSeparatedList(list string) []string {
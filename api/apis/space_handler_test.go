@@ -24,6 +24,8 @@ var _ = Describe("Spaces", func() {
 		now           time.Time
 		spaceHandler  *apis.SpaceHandler
 		spaceRepo     *fake.SpaceRepository
+		jobRunner     *fake.JobRunner
+		recorder      *fake.AuditEventRecorder
 		requestMethod string
 		requestBody   string
 		requestPath   string
@@ -34,6 +36,8 @@ var _ = Describe("Spaces", func() {
 		requestBody = ""
 		requestPath = spacesBase
 		spaceRepo = new(fake.SpaceRepository)
+		jobRunner = new(fake.JobRunner)
+		recorder = new(fake.AuditEventRecorder)
 		decoderValidator, err := apis.NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -41,6 +45,8 @@ var _ = Describe("Spaces", func() {
 			*serverURL,
 			registryCredentialsSecretName,
 			spaceRepo,
+			jobRunner,
+			recorder,
 			decoderValidator,
 		)
 		spaceHandler.RegisterRoutes(router)
@@ -207,10 +213,10 @@ var _ = Describe("Spaces", func() {
                     "total_results": 2,
                     "total_pages": 1,
                     "first": {
-                        "href": "%[1]s/v3/spaces"
+                        "href": "%[1]s/v3/spaces?page=1&per_page=50"
                     },
                     "last": {
-                        "href": "%[1]s/v3/spaces"
+                        "href": "%[1]s/v3/spaces?page=1&per_page=50"
                     },
                     "next": null,
                     "previous": null
@@ -315,6 +321,110 @@ var _ = Describe("Spaces", func() {
 		})
 	})
 
+	Describe("Patching a Space", func() {
+		const (
+			spaceGUID = "spaceGUID"
+			orgGUID   = "orgGUID"
+		)
+
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = spacesBase + "/" + spaceGUID
+			requestBody = `{"name": "new-name", "metadata": {"annotations": {"example.com/owner": "team-a"}}}`
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:             spaceGUID,
+				OrganizationGUID: orgGUID,
+			}, nil)
+			spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{
+				GUID:             spaceGUID,
+				OrganizationGUID: orgGUID,
+				Name:             "new-name",
+				Labels:           map[string]string{},
+				Annotations:      map[string]string{"example.com/owner": "team-a"},
+				CreatedAt:        now,
+				UpdatedAt:        now,
+			}, nil)
+		})
+
+		It("patches the space in its org", func() {
+			Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(1))
+			_, info, message := spaceRepo.PatchSpaceArgsForCall(0)
+			Expect(info).To(Equal(authInfo))
+
+			newName, owner := "new-name", "team-a"
+			Expect(message).To(Equal(repositories.PatchSpaceMessage{
+				GUID:             spaceGUID,
+				OrganizationGUID: orgGUID,
+				Name:             &newName,
+				MetadataPatch: repositories.MetadataPatch{
+					Annotations: map[string]*string{"example.com/owner": &owner},
+				},
+			}))
+		})
+
+		It("returns the updated space", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(fmt.Sprintf(`{
+				"guid": "spaceGUID",
+				"created_at": "2021-09-17T15:23:10Z",
+				"updated_at": "2021-09-17T15:23:10Z",
+				"name": "new-name",
+				"metadata": {
+					"labels": {},
+					"annotations": {"example.com/owner": "team-a"}
+				},
+				"relationships": {
+					"organization": {
+						"data": {
+							"guid": "orgGUID"
+						}
+					}
+				},
+				"links": {
+					"self": {
+						"href": "%[1]s/v3/spaces/spaceGUID"
+					},
+					"organization": {
+						"href": "%[1]s/v3/organizations/orgGUID"
+					}
+				}
+			}`, defaultServerURL))))
+		})
+
+		When("fetching the space errors", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Space not found")
+				Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestBody = `{"name": ""}`
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("patching the space errors", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("Deleting a Space", func() {
 		const (
 			spaceGUID = "spaceGUID"
@@ -332,6 +442,7 @@ var _ = Describe("Spaces", func() {
 
 			spaceRepo.GetSpaceReturns(space, nil)
 			spaceRepo.DeleteSpaceReturns(nil)
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
 		})
 
 		When("on the happy path", func() {
@@ -340,7 +451,22 @@ var _ = Describe("Spaces", func() {
 			})
 
 			It("responds with a job URL in a location header", func() {
-				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space.delete-"+spaceGUID))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+			})
+
+			It("starts a space.delete job that waits for the space to be gone", func() {
+				Expect(jobRunner.StartDeletionCallCount()).To(Equal(1))
+				_, _, message, getResource := jobRunner.StartDeletionArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    "space.delete",
+					ResourceGUID: spaceGUID,
+				}))
+
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+				Expect(getResource(ctx)).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				_, info, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(1)
+				Expect(info).To(Equal(authInfo))
+				Expect(actualSpaceGUID).To(Equal(spaceGUID))
 			})
 
 			It("fetches the right space", func() {
@@ -359,6 +485,15 @@ var _ = Describe("Spaces", func() {
 					OrganizationGUID: orgGUID,
 				}))
 			})
+
+			It("records an audit.space.delete-request event", func() {
+				Expect(recorder.RecordCallCount()).To(Equal(1))
+				_, info, eventType, target, eventSpaceGUID, _ := recorder.RecordArgsForCall(0)
+				Expect(info).To(Equal(authInfo))
+				Expect(eventType).To(Equal("audit.space.delete-request"))
+				Expect(target).To(Equal(repositories.AuditEventTarget{GUID: spaceGUID, Type: "space"}))
+				Expect(eventSpaceGUID).To(Equal(spaceGUID))
+			})
 		})
 
 		When("fetching the space errors", func() {
@@ -380,5 +515,15 @@ var _ = Describe("Spaces", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("starting the delete job errors", func() {
+			BeforeEach(func() {
+				jobRunner.StartDeletionReturns(repositories.JobRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
@@ -12,6 +12,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
@@ -25,19 +26,26 @@ type SpaceManifestHandler struct {
 	serverURL           url.URL
 	defaultDomainName   string
 	applyManifestAction ApplyManifestAction
+	diffManifestAction  DiffManifestAction
 	spaceRepo           repositories.CFSpaceRepository
+	jobRunner           JobRunner
 	decoderValidator    *DecoderValidator
 }
 
 //counterfeiter:generate -o fake -fake-name ApplyManifestAction . ApplyManifestAction
 type ApplyManifestAction func(ctx context.Context, authInfo authorization.Info, spaceGUID string, defaultDomainName string, manifest payloads.Manifest) error
 
+//counterfeiter:generate -o fake -fake-name DiffManifestAction . DiffManifestAction
+type DiffManifestAction func(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) ([]presenter.ManifestDiffEntry, error)
+
 func NewSpaceManifestHandler(
 	logger logr.Logger,
 	serverURL url.URL,
 	defaultDomainName string,
 	applyManifestAction ApplyManifestAction,
+	diffManifestAction DiffManifestAction,
 	spaceRepo repositories.CFSpaceRepository,
+	jobRunner JobRunner,
 	decoderValidator *DecoderValidator,
 ) *SpaceManifestHandler {
 	return &SpaceManifestHandler{
@@ -45,7 +53,9 @@ func NewSpaceManifestHandler(
 		serverURL:           serverURL,
 		defaultDomainName:   defaultDomainName,
 		applyManifestAction: applyManifestAction,
+		diffManifestAction:  diffManifestAction,
 		spaceRepo:           spaceRepo,
+		jobRunner:           jobRunner,
 		decoderValidator:    decoderValidator,
 	}
 }
@@ -64,23 +74,48 @@ func (h *SpaceManifestHandler) applyManifestHandler(authInfo authorization.Info,
 		return nil, err
 	}
 
-	if err := h.applyManifestAction(r.Context(), authInfo, spaceGUID, h.defaultDomainName, manifest); err != nil {
-		h.logger.Error(err, "Error applying manifest")
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		h.logger.Error(err, "failed to get space", "guid", spaceGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.Start(r.Context(), authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.ApplyManifestJobOperation,
+		ResourceGUID: spaceGUID,
+	}, func(ctx context.Context) ([]string, error) {
+		if err := h.applyManifestAction(ctx, authInfo, spaceGUID, h.defaultDomainName, manifest); err != nil {
+			h.logger.Error(err, "Error applying manifest", "SpaceGUID", spaceGUID)
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start apply manifest job", "SpaceGUID", spaceGUID)
 		return nil, err
 	}
 
 	return NewHandlerResponse(http.StatusAccepted).
-		WithHeader(headers.Location, fmt.Sprintf("%s/v3/jobs/space.apply_manifest-%s", h.serverURL.String(), spaceGUID)), nil
+		WithHeader(headers.Location, fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
 }
 
 func (h *SpaceManifestHandler) diffManifestHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
 	vars := mux.Vars(r)
 	spaceGUID := vars["spaceGUID"]
+	var manifest payloads.Manifest
+	if err := h.decoderValidator.DecodeAndValidateYAMLPayload(r, &manifest); err != nil {
+		return nil, err
+	}
 
 	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
 		h.logger.Error(err, "failed to get space", "guid", spaceGUID)
 		return nil, err
 	}
 
-	return NewHandlerResponse(http.StatusAccepted).WithBody(map[string]interface{}{"diff": []string{}}), nil
+	diff, err := h.diffManifestAction(r.Context(), authInfo, spaceGUID, manifest)
+	if err != nil {
+		h.logger.Error(err, "Error diffing manifest", "SpaceGUID", spaceGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithBody(presenter.ForManifestDiff(diff)), nil
 }
@@ -1,11 +1,14 @@
 package apis_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	repositoriesfake "code.cloudfoundry.org/korifi/api/repositories/fake"
 
@@ -20,16 +23,27 @@ import (
 var _ = Describe("SpaceManifestHandler", func() {
 	var (
 		applyManifestAction *fake.ApplyManifestAction
+		diffManifestAction  *fake.DiffManifestAction
 		spaceRepo           *repositoriesfake.CFSpaceRepository
+		jobRunner           *fake.JobRunner
+		jobErr              error
 		req                 *http.Request
 		defaultDomainName   string
 	)
 
 	BeforeEach(func() {
 		applyManifestAction = new(fake.ApplyManifestAction)
+		diffManifestAction = new(fake.DiffManifestAction)
 		spaceRepo = new(repositoriesfake.CFSpaceRepository)
 		defaultDomainName = "apps.example.org"
 
+		jobErr = nil
+		jobRunner = new(fake.JobRunner)
+		jobRunner.StartStub = func(ctx context.Context, _ authorization.Info, _ repositories.CreateJobMessage, work func(context.Context) ([]string, error)) (repositories.JobRecord, error) {
+			_, jobErr = work(ctx)
+			return repositories.JobRecord{GUID: "job-guid"}, nil
+		}
+
 		decoderValidator, err := NewDefaultDecoderValidator()
 		Expect(err).NotTo(HaveOccurred())
 
@@ -38,7 +52,9 @@ var _ = Describe("SpaceManifestHandler", func() {
 			*serverURL,
 			defaultDomainName,
 			applyManifestAction.Spy,
+			diffManifestAction.Spy,
 			spaceRepo,
+			jobRunner,
 			decoderValidator,
 		)
 		apiHandler.RegisterRoutes(router)
@@ -124,10 +140,18 @@ var _ = Describe("SpaceManifestHandler", func() {
 				Expect(actualAuthInfo).To(Equal(authInfo))
 			})
 
-			It("creates the record without erroring", func() {
+			It("applies the manifest in an apply_manifest job", func() {
+				Expect(jobRunner.StartCallCount()).To(Equal(1))
+				_, _, message, _ := jobRunner.StartArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateJobMessage{
+					Operation:    "space.apply_manifest",
+					ResourceGUID: spaceGUID,
+				}))
+
 				Expect(rr.Code).To(Equal(http.StatusAccepted))
-				Expect(rr.Header().Get("Location")).To(Equal(defaultServerURI("/v3/jobs/space.apply_manifest-", spaceGUID)))
+				Expect(rr.Header().Get("Location")).To(Equal(defaultServerURI("/v3/jobs/job-guid")))
 				Expect(applyManifestAction.CallCount()).To(Equal(1))
+				Expect(jobErr).NotTo(HaveOccurred())
 			})
 		})
 
@@ -144,12 +168,37 @@ var _ = Describe("SpaceManifestHandler", func() {
 				req.Header.Add("Content-type", "application/x-yaml")
 			})
 
+			It("applies all the apps in the manifest", func() {
+				Expect(rr.Code).To(Equal(http.StatusAccepted))
+				Expect(applyManifestAction.CallCount()).To(Equal(1))
+				_, _, _, _, payload := applyManifestAction.ArgsForCall(0)
+				Expect(payload.Applications).To(HaveLen(2))
+				Expect(payload.Applications[0].Name).To(Equal("app1"))
+				Expect(payload.Applications[1].Name).To(Equal("app2"))
+			})
+		})
+
+		When("the manifest contains multiple apps with the same name", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "POST", "/v3/spaces/"+spaceGUID+"/actions/apply_manifest", strings.NewReader(`---
+                version: 1
+                applications:
+                  - name: app1
+                  - name: app2
+                  - name: app1
+            `))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Add("Content-type", "application/x-yaml")
+			})
+
 			It("responds 422", func() {
-				expectUnprocessableEntityError("Applications must contain at maximum 1 item")
+				expectUnprocessableEntityError("Applications must contain unique values")
 			})
 
 			It("doesn't apply the manifest", func() {
 				Expect(applyManifestAction.CallCount()).To(Equal(0))
+				Expect(jobRunner.StartCallCount()).To(Equal(0))
 			})
 		})
 
@@ -263,9 +312,21 @@ var _ = Describe("SpaceManifestHandler", func() {
 			})
 		})
 
-		When("applying the manifest errors", func() {
+		When("the space cannot be found", func() {
 			BeforeEach(func() {
-				applyManifestAction.Returns(errors.New("boom"))
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("responds with not found without starting a job", func() {
+				expectNotFoundError("Space not found")
+				Expect(jobRunner.StartCallCount()).To(Equal(0))
+			})
+		})
+
+		When("starting the job fails", func() {
+			BeforeEach(func() {
+				jobRunner.StartStub = nil
+				jobRunner.StartReturns(repositories.JobRecord{}, errors.New("boom"))
 			})
 
 			It("respond with Unknown Error", func() {
@@ -273,6 +334,17 @@ var _ = Describe("SpaceManifestHandler", func() {
 			})
 		})
 
+		When("applying the manifest errors", func() {
+			BeforeEach(func() {
+				applyManifestAction.Returns(errors.New("boom"))
+			})
+
+			It("accepts the request and fails the job", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(jobErr).To(MatchError("boom"))
+			})
+		})
+
 		When("a manifest with default-route: true is applied", func() {
 			BeforeEach(func() {
 				var err error
@@ -298,49 +370,110 @@ var _ = Describe("SpaceManifestHandler", func() {
 				applyManifestAction.Returns(apierrors.NewNotFoundError(errors.New("can't find"), repositories.DomainResourceType))
 			})
 
-			It("respond with NotFoundErr", func() {
-				expectNotFoundError("Domain")
+			It("fails the job with the NotFoundErr", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(jobErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
 			})
 		})
 	})
 
 	Describe("POST /v3/spaces/{spaceGUID}/manifest_diff", func() {
+		var requestBody string
+
 		BeforeEach(func() {
+			requestBody = `---
+                version: 1
+                applications:
+                  - name: app1
+                    env:
+                      FOO: bar
+            `
+			diffManifestAction.Returns([]presenter.ManifestDiffEntry{
+				{Op: "add", Path: "/applications/0/env/FOO", Value: "bar"},
+				{Op: "replace", Path: "/applications/0/processes/0/instances", Was: 1, Value: 3},
+				{Op: "replace", Path: "/applications/0/processes/0/command", Was: nil, Value: "start-web"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/spaces/"+spaceGUID+"/manifest_diff", strings.NewReader(`---
-				version: 1
-				applications:
-				  - name: app1
-			`))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/spaces/"+spaceGUID+"/manifest_diff", strings.NewReader(requestBody))
 			Expect(err).NotTo(HaveOccurred())
 			req.Header.Add("Content-type", "application/x-yaml")
-		})
 
-		JustBeforeEach(func() {
 			router.ServeHTTP(rr, req)
 		})
 
-		When("the space exists", func() {
-			It("returns 202 with an empty diff", func() {
+		It("checks that the space exists", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+		})
+
+		It("diffs the manifest against the space", func() {
+			Expect(diffManifestAction.CallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, payload := diffManifestAction.ArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(payload.Applications).To(HaveLen(1))
+			Expect(payload.Applications[0].Name).To(Equal("app1"))
+			Expect(payload.Applications[0].Env).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+
+		It("returns 202 with the diff", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"diff": [
+					{"op": "add", "path": "/applications/0/env/FOO", "value": "bar"},
+					{"op": "replace", "path": "/applications/0/processes/0/instances", "was": 1, "value": 3},
+					{"op": "replace", "path": "/applications/0/processes/0/command", "was": null, "value": "start-web"}
+				]
+			}`)))
+		})
+
+		When("there are no differences", func() {
+			BeforeEach(func() {
+				diffManifestAction.Returns([]presenter.ManifestDiffEntry{}, nil)
+			})
+
+			It("returns an empty diff", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
-				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-				Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-                	"diff": []
-            	}`)))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"diff": []}`)))
+			})
+		})
+
+		When("the manifest is invalid", func() {
+			BeforeEach(func() {
+				requestBody = `---
+                version: 1
+                applications:
+                  - env:
+                      FOO: bar
+                `
+			})
+
+			It("responds 422 without diffing", func() {
+				expectUnprocessableEntityError("Name is a required field")
+				Expect(diffManifestAction.CallCount()).To(Equal(0))
 			})
 		})
 
 		When("getting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("foo"))
-				var err error
-				req, err = http.NewRequestWithContext(ctx, "POST", "/v3/spaces/"+"fake-space-guid"+"/manifest_diff", strings.NewReader(`---
-				version: 1
-				applications:
-				  - name: app1
-			`))
-				Expect(err).NotTo(HaveOccurred())
-				req.Header.Add("Content-type", "application/x-yaml")
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(diffManifestAction.CallCount()).To(Equal(0))
+			})
+		})
+
+		When("diffing the manifest errors", func() {
+			BeforeEach(func() {
+				diffManifestAction.Returns(nil, errors.New("boom"))
 			})
 
 			It("returns an error", func() {
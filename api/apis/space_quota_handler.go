@@ -0,0 +1,241 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	SpaceQuotasPath      = "/v3/space_quotas"
+	SpaceQuotaPath       = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesPath = "/v3/space_quotas/{guid}/relationships/spaces"
+	SpaceQuotaSpacePath  = "/v3/space_quotas/{guid}/relationships/spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSpaceQuotaRepository . CFSpaceQuotaRepository
+type CFSpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	PatchSpaceQuota(context.Context, authorization.Info, repositories.PatchSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	DeleteSpaceQuota(context.Context, authorization.Info, string) error
+	ApplySpaceQuota(context.Context, authorization.Info, string, []string) (repositories.SpaceQuotaRecord, error)
+	RemoveSpaceQuota(context.Context, authorization.Info, string, string) error
+}
+
+type SpaceQuotaHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	spaceQuotaRepo   CFSpaceQuotaRepository
+	jobRunner        JobRunner
+	decoderValidator *DecoderValidator
+}
+
+func NewSpaceQuotaHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	spaceQuotaRepo CFSpaceQuotaRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *SpaceQuotaHandler {
+	return &SpaceQuotaHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		spaceQuotaRepo:   spaceQuotaRepo,
+		jobRunner:        jobRunner,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.SpaceQuotaCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, payload.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to create space quota", "name", payload.Name)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	spaceQuotaGUID := mux.Vars(r)["guid"]
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch space quota", "guid", spaceQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.SpaceQuotaList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in SpaceQuota filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	spaceQuotas, err := h.spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list space quotas")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaList(spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaPatchHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	spaceQuotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.SpaceQuotaPatch
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch space quota", "guid", spaceQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.PatchSpaceQuota(ctx, authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		h.logger.Error(err, "Failed to patch space quota", "guid", spaceQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	spaceQuotaGUID := mux.Vars(r)["guid"]
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch space quota", "guid", spaceQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.spaceQuotaRepo.DeleteSpaceQuota(ctx, authInfo, spaceQuotaGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete space quota", "guid", spaceQuotaGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.SpaceQuotaDeleteJobOperation,
+		ResourceGUID: spaceQuotaGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start space quota delete job", "guid", spaceQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaApplyHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	spaceQuotaGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.ToManyRelationship
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch space quota", "guid", spaceQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.ApplySpaceQuota(ctx, authInfo, spaceQuotaGUID, payload.GUIDs())
+	if err != nil {
+		h.logger.Error(err, "Failed to apply space quota", "guid", spaceQuotaGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuotaHandler) spaceQuotaRemoveHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	spaceQuotaGUID := vars["guid"]
+	spaceGUID := vars["space_guid"]
+
+	if _, err := h.spaceQuotaRepo.GetSpaceQuota(ctx, authInfo, spaceQuotaGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch space quota", "guid", spaceQuotaGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.spaceQuotaRepo.RemoveSpaceQuota(ctx, authInfo, spaceQuotaGUID, spaceGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to remove space quota", "guid", spaceQuotaGUID, "spaceGUID", spaceGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusNoContent), nil
+}
+
+func (h *SpaceQuotaHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(SpaceQuotasPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.spaceQuotaCreateHandler))
+	router.Path(SpaceQuotasPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.spaceQuotaListHandler))
+	router.Path(SpaceQuotaPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.spaceQuotaGetHandler))
+	router.Path(SpaceQuotaPath).Methods(http.MethodPatch).HandlerFunc(w.Wrap(h.spaceQuotaPatchHandler))
+	router.Path(SpaceQuotaPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.spaceQuotaDeleteHandler))
+	router.Path(SpaceQuotaSpacesPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.spaceQuotaApplyHandler))
+	router.Path(SpaceQuotaSpacePath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.spaceQuotaRemoveHandler))
+}
@@ -0,0 +1,258 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("SpaceQuotaHandler", func() {
+	var (
+		req            *http.Request
+		spaceQuotaRepo *fake.CFSpaceQuotaRepository
+		jobRunner      *fake.JobRunner
+		spaceQuota     repositories.SpaceQuotaRecord
+	)
+
+	int64Ptr := func(value int64) *int64 {
+		return &value
+	}
+
+	makeRequest := func(method, path, body string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		jobRunner = new(fake.JobRunner)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		spaceQuota = repositories.SpaceQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				InstanceMemoryMB: int64Ptr(1024),
+				ServiceInstances: int64Ptr(2),
+			},
+			OrgGUID:    "org-guid",
+			SpaceGUIDs: []string{"space-guid"},
+			CreatedAt:  "2019-05-10T17:17:48Z",
+			UpdatedAt:  "2019-05-10T17:17:48Z",
+		}
+		spaceQuotaRepo.GetSpaceQuotaReturns(spaceQuota, nil)
+
+		NewSpaceQuotaHandler(
+			logf.Log.WithName("TestSpaceQuotaHandler"),
+			*serverURL,
+			spaceQuotaRepo,
+			jobRunner,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/space_quotas endpoint", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.CreateSpaceQuotaReturns(spaceQuota, nil)
+			makeRequest(http.MethodPost, "/v3/space_quotas", `{
+				"name": "my-quota",
+				"apps": {"per_process_memory_in_mb": 1024},
+				"services": {"total_service_instances": 2},
+				"relationships": {
+					"organization": {"data": {"guid": "org-guid"}},
+					"spaces": {"data": [{"guid": "space-guid"}]}
+				}
+			}`)
+		})
+
+		It("creates the space quota in the org", func() {
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, _, message := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					InstanceMemoryMB: int64Ptr(1024),
+					ServiceInstances: int64Ptr(2),
+				},
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+		})
+
+		It("returns 201 Created with the space quota", func() {
+			expectJSONResponse(http.StatusCreated, `{
+				"guid": "quota-guid",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": null,
+					"per_process_memory_in_mb": 1024,
+					"log_rate_limit_in_bytes_per_second": null,
+					"total_instances": null,
+					"per_app_tasks": null
+				},
+				"services": {
+					"paid_services_allowed": true,
+					"total_service_instances": 2,
+					"total_service_keys": null
+				},
+				"routes": {
+					"total_routes": null,
+					"total_reserved_ports": null
+				},
+				"relationships": {
+					"organization": {"data": {"guid": "org-guid"}},
+					"spaces": {"data": [{"guid": "space-guid"}]}
+				},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/space_quotas/quota-guid"},
+					"organization": {"href": "https://api.example.org/v3/organizations/org-guid"}
+				}
+			}`)
+		})
+
+		When("the organization is missing", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/space_quotas", `{"name": "my-quota"}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Data is a required field")
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the GET /v3/space_quotas endpoint", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{spaceQuota}, nil)
+			makeRequest(http.MethodGet, "/v3/space_quotas?organization_guids=org-guid&space_guids=space-guid", "")
+		})
+
+		It("lists the space quotas matching the filter", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(message.OrgGUIDs).To(ConsistOf("org-guid"))
+			Expect(message.SpaceGUIDs).To(ConsistOf("space-guid"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"quota-guid"`))
+		})
+	})
+
+	Describe("the GET /v3/space_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodGet, "/v3/space_quotas/quota-guid", "")
+		})
+
+		It("returns the space quota", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"quota-guid"`))
+		})
+
+		When("the space quota is forbidden", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Space Quota not found")
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/space_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.PatchSpaceQuotaReturns(spaceQuota, nil)
+			makeRequest(http.MethodPatch, "/v3/space_quotas/quota-guid", `{"routes": {"total_routes": 10}}`)
+		})
+
+		It("patches the space quota", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := spaceQuotaRepo.PatchSpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.PatchSpaceQuotaMessage{
+				GUID: "quota-guid",
+				Limits: repositories.QuotaLimitsPatch{
+					Routes: repositories.QuotaLimitPatch{Set: true, Value: int64Ptr(10)},
+				},
+			}))
+		})
+	})
+
+	Describe("the DELETE /v3/space_quotas/:guid endpoint", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+			makeRequest(http.MethodDelete, "/v3/space_quotas/quota-guid", "")
+		})
+
+		It("deletes the space quota and returns the location of the delete job", func() {
+			Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(Equal(1))
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.SpaceQuotaDeleteJobOperation,
+				ResourceGUID: "quota-guid",
+			}))
+		})
+	})
+
+	Describe("the POST /v3/space_quotas/:guid/relationships/spaces endpoint", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.ApplySpaceQuotaReturns(spaceQuota, nil)
+			makeRequest(http.MethodPost, "/v3/space_quotas/quota-guid/relationships/spaces", `{"data": [{"guid": "space-guid"}]}`)
+		})
+
+		It("applies the quota to the spaces", func() {
+			_, _, actualGUID, spaceGUIDs := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+			Expect(spaceGUIDs).To(ConsistOf("space-guid"))
+
+			expectJSONResponse(http.StatusOK, `{
+				"data": [{"guid": "space-guid"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"}
+				}
+			}`)
+		})
+	})
+
+	Describe("the DELETE /v3/space_quotas/:guid/relationships/spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodDelete, "/v3/space_quotas/quota-guid/relationships/spaces/space-guid", "")
+		})
+
+		It("removes the quota from the space", func() {
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+			Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID, spaceGUID := spaceQuotaRepo.RemoveSpaceQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+			Expect(spaceGUID).To(Equal("space-guid"))
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Space Quota not found")
+				Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,218 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	TasksPath      = "/v3/tasks"
+	TaskPath       = "/v3/tasks/{guid}"
+	TaskCancelPath = "/v3/tasks/{guid}/actions/cancel"
+	AppTasksPath   = "/v3/apps/{guid}/tasks"
+)
+
+//counterfeiter:generate -o fake -fake-name CFTaskRepository . CFTaskRepository
+type CFTaskRepository interface {
+	CreateTask(context.Context, authorization.Info, repositories.CreateTaskMessage) (repositories.TaskRecord, error)
+	GetTask(context.Context, authorization.Info, string) (repositories.TaskRecord, error)
+	ListTasks(context.Context, authorization.Info, repositories.ListTasksMessage) ([]repositories.TaskRecord, error)
+	CancelTask(context.Context, authorization.Info, string) (repositories.TaskRecord, error)
+}
+
+type TaskHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	taskRepo         CFTaskRepository
+	decoderValidator *DecoderValidator
+}
+
+func NewTaskHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	taskRepo CFTaskRepository,
+	decoderValidator *DecoderValidator,
+) *TaskHandler {
+	return &TaskHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		taskRepo:         taskRepo,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *TaskHandler) appTaskCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	var payload payloads.TaskCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	if app.DropletGUID == "" {
+		h.logger.Info("Cannot create a task for an app without a droplet", "AppGUID", appGUID)
+		return nil, apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("app %s has no current droplet", appGUID),
+			"Task must have a droplet. Assign current droplet to app.",
+		)
+	}
+
+	task, err := h.taskRepo.CreateTask(ctx, authInfo, payload.ToMessage(app))
+	if err != nil {
+		h.logger.Error(err, "Failed to create task", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForTask(task, h.serverURL)), nil
+}
+
+func (h *TaskHandler) appTaskListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	appGUID := mux.Vars(r)["guid"]
+
+	listFilter, err := h.decodeListFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch app", "AppGUID", appGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	message := listFilter.ToMessage()
+	message.AppGUIDs = []string{appGUID}
+	tasks, err := h.taskRepo.ListTasks(ctx, authInfo, message)
+	if err != nil {
+		h.logger.Error(err, "Failed to list tasks", "AppGUID", appGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForTaskList(tasks, h.serverURL, *r.URL)), nil
+}
+
+func (h *TaskHandler) taskListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	listFilter, err := h.decodeListFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskRepo.ListTasks(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list tasks")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForTaskList(tasks, h.serverURL, *r.URL)), nil
+}
+
+func (h *TaskHandler) taskGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	taskGUID := mux.Vars(r)["guid"]
+
+	task, err := h.taskRepo.GetTask(ctx, authInfo, taskGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch task", "TaskGUID", taskGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForTask(task, h.serverURL)), nil
+}
+
+func (h *TaskHandler) taskCancelHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	taskGUID := mux.Vars(r)["guid"]
+
+	task, err := h.taskRepo.GetTask(ctx, authInfo, taskGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch task", "TaskGUID", taskGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	if task.State == repositories.TaskStateSucceeded || task.State == repositories.TaskStateFailed {
+		h.logger.Info("Cannot cancel a task that has terminated", "TaskGUID", taskGUID, "State", task.State)
+		return nil, apierrors.NewUnprocessableEntityError(
+			errors.New("task has terminated"),
+			fmt.Sprintf("Task state is %s and therefore cannot be canceled", task.State),
+		)
+	}
+
+	task, err = h.taskRepo.CancelTask(ctx, authInfo, taskGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to cancel task", "TaskGUID", taskGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithBody(presenter.ForTask(task, h.serverURL)), nil
+}
+
+func (h *TaskHandler) decodeListFilter(r *http.Request) (*payloads.TaskList, error) {
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.TaskList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in Task filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	return listFilter, nil
+}
+
+func (h *TaskHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(AppTasksPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.appTaskCreateHandler))
+	router.Path(AppTasksPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.appTaskListHandler))
+	router.Path(TasksPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.taskListHandler))
+	router.Path(TaskPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.taskGetHandler))
+	router.Path(TaskCancelPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.taskCancelHandler))
+}
@@ -0,0 +1,322 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("TaskHandler", func() {
+	var (
+		req      *http.Request
+		appRepo  *fake.CFAppRepository
+		taskRepo *fake.CFTaskRepository
+		task     repositories.TaskRecord
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:        "app-guid",
+			SpaceGUID:   "space-guid",
+			DropletGUID: "droplet-guid",
+		}, nil)
+
+		taskRepo = new(fake.CFTaskRepository)
+		task = repositories.TaskRecord{
+			GUID:        "task-guid",
+			Name:        "migrate",
+			Command:     "rake db:migrate",
+			AppGUID:     "app-guid",
+			SpaceGUID:   "space-guid",
+			DropletGUID: "droplet-guid",
+			SequenceID:  3,
+			State:       repositories.TaskStatePending,
+			MemoryMB:    256,
+			DiskMB:      512,
+			CreatedAt:   "2019-05-10T17:17:48Z",
+			UpdatedAt:   "2019-05-10T17:17:48Z",
+		}
+
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		NewTaskHandler(
+			logf.Log.WithName("TestTaskHandler"),
+			*serverURL,
+			appRepo,
+			taskRepo,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/apps/:guid/tasks endpoint", func() {
+		makePostRequest := func(body string) {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/apps/app-guid/tasks", strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			taskRepo.CreateTaskReturns(task, nil)
+			makePostRequest(`{"command": "rake db:migrate", "name": "migrate", "memory_in_mb": 256, "disk_in_mb": 512, "metadata": {"labels": {"env": "prod"}}}`)
+		})
+
+		It("creates the task in the space of the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(taskRepo.CreateTaskCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := taskRepo.CreateTaskArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.CreateTaskMessage{
+				Name:      "migrate",
+				Command:   "rake db:migrate",
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+				MemoryMB:  256,
+				DiskMB:    512,
+				Labels:    map[string]string{"env": "prod"},
+			}))
+		})
+
+		It("returns the task", func() {
+			Expect(rr.Code).To(Equal(http.StatusCreated))
+			Expect(rr.Body.String()).To(MatchJSON(`{
+				"guid": "task-guid",
+				"sequence_id": 3,
+				"name": "migrate",
+				"command": "rake db:migrate",
+				"state": "PENDING",
+				"memory_in_mb": 256,
+				"disk_in_mb": 512,
+				"result": {"failure_reason": null},
+				"droplet_guid": "droplet-guid",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"relationships": {"app": {"data": {"guid": "app-guid"}}},
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/tasks/task-guid"},
+					"app": {"href": "https://api.example.org/v3/apps/app-guid"},
+					"cancel": {"href": "https://api.example.org/v3/tasks/task-guid/actions/cancel", "method": "POST"},
+					"droplet": {"href": "https://api.example.org/v3/droplets/droplet-guid"}
+				}
+			}`))
+		})
+
+		When("the memory and disk are not specified", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"command": "rake db:migrate"}`)
+			})
+
+			It("uses the defaults", func() {
+				_, _, message := taskRepo.CreateTaskArgsForCall(0)
+				Expect(message.MemoryMB).To(BeEquivalentTo(1024))
+				Expect(message.DiskMB).To(BeEquivalentTo(1024))
+			})
+		})
+
+		When("the command is missing", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"name": "migrate"}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Command is a required field")
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the memory is not positive", func() {
+			BeforeEach(func() {
+				makePostRequest(`{"command": "rake db:migrate", "memory_in_mb": 0}`)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("MemoryInMB must be greater than 0")
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the app has no droplet", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Task must have a droplet. Assign current droplet to app.")
+				Expect(taskRepo.CreateTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the task fails", func() {
+			BeforeEach(func() {
+				taskRepo.CreateTaskReturns(repositories.TaskRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/apps/:guid/tasks endpoint", func() {
+		BeforeEach(func() {
+			taskRepo.ListTasksReturns([]repositories.TaskRecord{task}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/apps/app-guid/tasks?states=RUNNING,PENDING", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the tasks of the app without their commands", func() {
+			Expect(taskRepo.ListTasksCallCount()).To(Equal(1))
+			_, _, message := taskRepo.ListTasksArgsForCall(0)
+			Expect(message.AppGUIDs).To(Equal([]string{"app-guid"}))
+			Expect(message.States).To(Equal([]string{"RUNNING", "PENDING"}))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"task-guid"`))
+			Expect(rr.Body.String()).NotTo(ContainSubstring("rake db:migrate"))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App not found")
+				Expect(taskRepo.ListTasksCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("the GET /v3/tasks endpoint", func() {
+		BeforeEach(func() {
+			taskRepo.ListTasksReturns([]repositories.TaskRecord{task}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/tasks?app_guids=app-guid&names=migrate", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the tasks", func() {
+			_, _, message := taskRepo.ListTasksArgsForCall(0)
+			Expect(message.AppGUIDs).To(Equal([]string{"app-guid"}))
+			Expect(message.Names).To(Equal([]string{"migrate"}))
+			Expect(message.States).To(BeEmpty())
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"total_results":1`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/tasks?sequence_ids=1", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'app_guids, names, states, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the GET /v3/tasks/:guid endpoint", func() {
+		BeforeEach(func() {
+			taskRepo.GetTaskReturns(task, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/v3/tasks/task-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the task", func() {
+			_, _, actualGUID := taskRepo.GetTaskArgsForCall(0)
+			Expect(actualGUID).To(Equal("task-guid"))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Body.String()).To(ContainSubstring(`"command":"rake db:migrate"`))
+		})
+
+		When("the task is not accessible", func() {
+			BeforeEach(func() {
+				taskRepo.GetTaskReturns(repositories.TaskRecord{}, apierrors.NewForbiddenError(nil, repositories.TaskResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Task not found")
+			})
+		})
+	})
+
+	Describe("the POST /v3/tasks/:guid/actions/cancel endpoint", func() {
+		BeforeEach(func() {
+			taskRepo.GetTaskReturns(task, nil)
+			canceledTask := task
+			canceledTask.State = repositories.TaskStateCanceling
+			taskRepo.CancelTaskReturns(canceledTask, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, "/v3/tasks/task-guid/actions/cancel", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("cancels the task", func() {
+			Expect(taskRepo.CancelTaskCallCount()).To(Equal(1))
+			_, _, actualGUID := taskRepo.CancelTaskArgsForCall(0)
+			Expect(actualGUID).To(Equal("task-guid"))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr.Body.String()).To(ContainSubstring(`"state":"CANCELING"`))
+		})
+
+		When("the task has already terminated", func() {
+			BeforeEach(func() {
+				task.State = repositories.TaskStateSucceeded
+				taskRepo.GetTaskReturns(task, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Task state is SUCCEEDED and therefore cannot be canceled")
+				Expect(taskRepo.CancelTaskCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the task does not exist", func() {
+			BeforeEach(func() {
+				taskRepo.GetTaskReturns(repositories.TaskRecord{}, apierrors.NewNotFoundError(nil, repositories.TaskResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Task not found")
+			})
+		})
+	})
+})
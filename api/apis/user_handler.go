@@ -0,0 +1,168 @@
+package apis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+const (
+	UsersPath = "/v3/users"
+	UserPath  = "/v3/users/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFUserRepository . CFUserRepository
+type CFUserRepository interface {
+	CreateUser(context.Context, authorization.Info, repositories.CreateUserMessage) (repositories.UserRecord, error)
+	GetUser(context.Context, authorization.Info, string) (repositories.UserRecord, error)
+	ListUsers(context.Context, authorization.Info, repositories.ListUsersMessage) ([]repositories.UserRecord, error)
+	DeleteUser(context.Context, authorization.Info, string) error
+}
+
+type UserHandler struct {
+	logger           logr.Logger
+	serverURL        url.URL
+	userRepo         CFUserRepository
+	jobRunner        JobRunner
+	decoderValidator *DecoderValidator
+}
+
+func NewUserHandler(
+	logger logr.Logger,
+	serverURL url.URL,
+	userRepo CFUserRepository,
+	jobRunner JobRunner,
+	decoderValidator *DecoderValidator,
+) *UserHandler {
+	return &UserHandler{
+		logger:           logger,
+		serverURL:        serverURL,
+		userRepo:         userRepo,
+		jobRunner:        jobRunner,
+		decoderValidator: decoderValidator,
+	}
+}
+
+func (h *UserHandler) userCreateHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+
+	var payload payloads.UserCreate
+	if err := h.decoderValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, err
+	}
+
+	message := payload.ToMessage()
+	user, err := h.userRepo.CreateUser(ctx, authInfo, message)
+	if err != nil {
+		h.logger.Error(err, "Failed to create user", "username", message.Username)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusCreated).WithBody(presenter.ForUser(user, h.serverURL)), nil
+}
+
+func (h *UserHandler) userGetHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	userGUID := mux.Vars(r)["guid"]
+
+	user, err := h.userRepo.GetUser(ctx, authInfo, userGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to fetch user", "guid", userGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForUser(user, h.serverURL)), nil
+}
+
+func (h *UserHandler) userListHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) { //nolint:dupl
+	ctx := r.Context()
+
+	if err := r.ParseForm(); err != nil {
+		h.logger.Error(err, "Unable to parse request query parameters")
+		return nil, err
+	}
+
+	listFilter := new(payloads.UserList)
+	err := schema.NewDecoder().Decode(listFilter, r.Form)
+	if err != nil {
+		switch err.(type) {
+		case schema.MultiError:
+			multiError := err.(schema.MultiError)
+			for _, v := range multiError {
+				_, ok := v.(schema.UnknownKeyError)
+				if ok {
+					h.logger.Info("Unknown key used in User filter")
+					return nil, apierrors.NewUnknownKeyError(err, listFilter.SupportedFilterKeys())
+				}
+			}
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+
+		default:
+			h.logger.Error(err, "Unable to decode request query parameters")
+			return nil, err
+		}
+	}
+
+	if err = listFilter.Pagination.Validate(); err != nil {
+		h.logger.Info("Invalid pagination query parameters", "error", err.Error())
+		return nil, err
+	}
+
+	users, err := h.userRepo.ListUsers(ctx, authInfo, listFilter.ToMessage())
+	if err != nil {
+		h.logger.Error(err, "Failed to list users")
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusOK).WithBody(presenter.ForUserList(users, h.serverURL, *r.URL)), nil
+}
+
+func (h *UserHandler) userDeleteHandler(authInfo authorization.Info, r *http.Request) (*HandlerResponse, error) {
+	ctx := r.Context()
+	userGUID := mux.Vars(r)["guid"]
+
+	if _, err := h.userRepo.GetUser(ctx, authInfo, userGUID); err != nil {
+		h.logger.Error(err, "Failed to fetch user", "guid", userGUID)
+		return nil, apierrors.ForbiddenAsNotFound(err)
+	}
+
+	err := h.userRepo.DeleteUser(ctx, authInfo, userGUID)
+	if err != nil {
+		h.logger.Error(err, "Failed to delete user", "guid", userGUID)
+		return nil, err
+	}
+
+	job, err := h.jobRunner.StartDeletion(ctx, authInfo, repositories.CreateJobMessage{
+		Operation:    repositories.UserDeleteJobOperation,
+		ResourceGUID: userGUID,
+	}, func(ctx context.Context) error {
+		_, err := h.userRepo.GetUser(ctx, authInfo, userGUID)
+		return err
+	})
+	if err != nil {
+		h.logger.Error(err, "Failed to start user delete job", "guid", userGUID)
+		return nil, err
+	}
+
+	return NewHandlerResponse(http.StatusAccepted).WithHeader("Location", fmt.Sprintf("%s/v3/jobs/%s", h.serverURL.String(), job.GUID)), nil
+}
+
+func (h *UserHandler) RegisterRoutes(router *mux.Router) {
+	w := NewAuthAwareHandlerFuncWrapper(h.logger)
+	router.Path(UsersPath).Methods(http.MethodPost).HandlerFunc(w.Wrap(h.userCreateHandler))
+	router.Path(UsersPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.userListHandler))
+	router.Path(UserPath).Methods(http.MethodGet).HandlerFunc(w.Wrap(h.userGetHandler))
+	router.Path(UserPath).Methods(http.MethodDelete).HandlerFunc(w.Wrap(h.userDeleteHandler))
+}
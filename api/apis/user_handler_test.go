@@ -0,0 +1,241 @@
+package apis_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/apis"
+	"code.cloudfoundry.org/korifi/api/apis/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("UserHandler", func() {
+	var (
+		req       *http.Request
+		userRepo  *fake.CFUserRepository
+		jobRunner *fake.JobRunner
+		user      repositories.UserRecord
+	)
+
+	makeRequest := func(method, path, body string) {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		userRepo = new(fake.CFUserRepository)
+		jobRunner = new(fake.JobRunner)
+		decoderValidator, err := NewDefaultDecoderValidator()
+		Expect(err).NotTo(HaveOccurred())
+
+		createdAt := time.Date(2019, 5, 10, 17, 17, 48, 0, time.UTC)
+		user = repositories.UserRecord{
+			GUID:             "alice@example.org",
+			Username:         "alice@example.org",
+			PresentationName: "alice@example.org",
+			Origin:           "uaa",
+			CreatedAt:        createdAt,
+			UpdatedAt:        createdAt,
+		}
+		userRepo.GetUserReturns(user, nil)
+
+		NewUserHandler(
+			logf.Log.WithName("TestUserHandler"),
+			*serverURL,
+			userRepo,
+			jobRunner,
+			decoderValidator,
+		).RegisterRoutes(router)
+	})
+
+	JustBeforeEach(func() {
+		router.ServeHTTP(rr, req)
+	})
+
+	Describe("the POST /v3/users endpoint", func() {
+		BeforeEach(func() {
+			userRepo.CreateUserReturns(user, nil)
+			makeRequest(http.MethodPost, "/v3/users", `{"username": "alice@example.org", "origin": "uaa"}`)
+		})
+
+		It("creates the user", func() {
+			Expect(userRepo.CreateUserCallCount()).To(Equal(1))
+			_, _, message := userRepo.CreateUserArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateUserMessage{
+				Username: "alice@example.org",
+				Origin:   "uaa",
+			}))
+		})
+
+		It("returns 201 Created with the user", func() {
+			expectJSONResponse(http.StatusCreated, `{
+				"guid": "alice@example.org",
+				"created_at": "2019-05-10T17:17:48Z",
+				"updated_at": "2019-05-10T17:17:48Z",
+				"username": "alice@example.org",
+				"presentation_name": "alice@example.org",
+				"origin": "uaa",
+				"metadata": {"labels": {}, "annotations": {}},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/users/alice@example.org"}
+				}
+			}`)
+		})
+
+		When("the user is created by GUID", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/users", `{"guid": "bob"}`)
+			})
+
+			It("uses the GUID as the username", func() {
+				Expect(userRepo.CreateUserCallCount()).To(Equal(1))
+				_, _, message := userRepo.CreateUserArgsForCall(0)
+				Expect(message).To(Equal(repositories.CreateUserMessage{Username: "bob"}))
+			})
+		})
+
+		When("the username is set without an origin", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/users", `{"username": "alice@example.org"}`)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Origin is a required field")
+				Expect(userRepo.CreateUserCallCount()).To(BeZero())
+			})
+		})
+
+		When("neither the guid nor the username are set", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodPost, "/v3/users", `{}`)
+			})
+
+			It("returns an error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+				Expect(userRepo.CreateUserCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user already exists", func() {
+			BeforeEach(func() {
+				userRepo.CreateUserReturns(repositories.UserRecord{}, apierrors.NewUnprocessableEntityError(nil, "User with username 'alice@example.org' already exists."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("User with username 'alice@example.org' already exists.")
+			})
+		})
+
+		When("creating the user fails", func() {
+			BeforeEach(func() {
+				userRepo.CreateUserReturns(repositories.UserRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/users/:guid endpoint", func() {
+		BeforeEach(func() {
+			makeRequest(http.MethodGet, "/v3/users/alice@example.org", "")
+		})
+
+		It("returns the user", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, actualGUID := userRepo.GetUserArgsForCall(0)
+			Expect(actualGUID).To(Equal("alice@example.org"))
+			Expect(rr.Body.String()).To(ContainSubstring(`"username":"alice@example.org"`))
+		})
+
+		When("the user is not known by its origin", func() {
+			BeforeEach(func() {
+				user.Origin = ""
+				userRepo.GetUserReturns(user, nil)
+			})
+
+			It("returns a null origin", func() {
+				Expect(rr.Body.String()).To(ContainSubstring(`"origin":null`))
+			})
+		})
+
+		When("the user is forbidden", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewForbiddenError(nil, repositories.UserResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("User not found")
+			})
+		})
+	})
+
+	Describe("the GET /v3/users endpoint", func() {
+		BeforeEach(func() {
+			userRepo.ListUsersReturns([]repositories.UserRecord{user}, nil)
+			makeRequest(http.MethodGet, "/v3/users?usernames=alice@example.org,bob&origins=uaa", "")
+		})
+
+		It("lists the users matching the filter", func() {
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			_, _, message := userRepo.ListUsersArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListUsersMessage{
+				GUIDs:     []string{},
+				Usernames: []string{"alice@example.org", "bob"},
+				Origins:   []string{"uaa"},
+			}))
+			Expect(rr.Body.String()).To(ContainSubstring(`"guid":"alice@example.org"`))
+		})
+
+		When("an unknown filter is used", func() {
+			BeforeEach(func() {
+				makeRequest(http.MethodGet, "/v3/users?foo=bar", "")
+			})
+
+			It("returns an unknown key error", func() {
+				expectUnknownKeyError("The query parameter is invalid: Valid parameters are: 'guids, usernames, origins, page, per_page'")
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/users/:guid endpoint", func() {
+		BeforeEach(func() {
+			jobRunner.StartDeletionReturns(repositories.JobRecord{GUID: "job-guid"}, nil)
+			makeRequest(http.MethodDelete, "/v3/users/alice@example.org", "")
+		})
+
+		It("deletes the user and returns the location of the delete job", func() {
+			Expect(userRepo.DeleteUserCallCount()).To(Equal(1))
+			_, _, actualGUID := userRepo.DeleteUserArgsForCall(0)
+			Expect(actualGUID).To(Equal("alice@example.org"))
+
+			Expect(rr.Code).To(Equal(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/job-guid"))
+			_, _, message, _ := jobRunner.StartDeletionArgsForCall(0)
+			Expect(message).To(Equal(repositories.CreateJobMessage{
+				Operation:    repositories.UserDeleteJobOperation,
+				ResourceGUID: "alice@example.org",
+			}))
+		})
+
+		When("the user does not exist", func() {
+			BeforeEach(func() {
+				userRepo.GetUserReturns(repositories.UserRecord{}, apierrors.NewNotFoundError(nil, repositories.UserResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("User not found")
+				Expect(userRepo.DeleteUserCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -2,42 +2,85 @@ package authorization
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"gopkg.in/square/go-jose.v2/jwt"
 	"k8s.io/apimachinery/pkg/util/cache"
 )
 
-const (
-	cacheTTL = 120 * time.Second
-)
-
+// CachingIdentityProvider caches the identities of the auth infos for the ttl, or until the token expires if it is a
+// JWT. Auth infos that are rejected are cached for the failure ttl, so that a bad token is not reviewed on every
+// request. Other failures are not cached.
 type CachingIdentityProvider struct {
 	identityProvider IdentityProvider
-	identityCache    *cache.Expiring
+	identityCache    *cache.LRUExpireCache
+	ttl              time.Duration
+	failureTTL       time.Duration
 }
 
-func NewCachingIdentityProvider(identityProvider IdentityProvider, identityCache *cache.Expiring) *CachingIdentityProvider {
+type cachedIdentity struct {
+	identity Identity
+	err      error
+}
+
+func NewCachingIdentityProvider(identityProvider IdentityProvider, identityCache *cache.LRUExpireCache, ttl, failureTTL time.Duration) *CachingIdentityProvider {
 	return &CachingIdentityProvider{
 		identityProvider: identityProvider,
 		identityCache:    identityCache,
+		ttl:              ttl,
+		failureTTL:       failureTTL,
 	}
 }
 
 func (p *CachingIdentityProvider) GetIdentity(ctx context.Context, info Info) (Identity, error) {
-	idInterface, ok := p.identityCache.Get(info.Hash())
+	key := info.Hash()
+
+	cachedInterface, ok := p.identityCache.Get(key)
 	if ok {
-		id, castOK := idInterface.(Identity)
+		cached, castOK := cachedInterface.(cachedIdentity)
 		if castOK {
-			return id, nil
+			return cached.identity, cached.err
 		}
-		return Identity{}, fmt.Errorf("identity-provider cache: expected authorization.Identity{}, got %T", idInterface)
+		return Identity{}, fmt.Errorf("identity-provider cache: expected authorization.cachedIdentity{}, got %T", cachedInterface)
 	}
 
 	identity, err := p.identityProvider.GetIdentity(ctx, info)
-	if err == nil {
-		p.identityCache.Set(info.Hash(), identity, cacheTTL)
+	if err != nil {
+		if errors.As(err, &apierrors.InvalidAuthError{}) {
+			p.identityCache.Add(key, cachedIdentity{err: err}, p.failureTTL)
+		}
+		return Identity{}, err
+	}
+
+	if ttl := p.identityTTL(info); ttl > 0 {
+		p.identityCache.Add(key, cachedIdentity{identity: identity}, ttl)
+	}
+
+	return identity, nil
+}
+
+// identityTTL shortens the ttl to the time left until the token expires, when the token is a JWT with an expiry
+func (p *CachingIdentityProvider) identityTTL(info Info) time.Duration {
+	if info.Token == "" {
+		return p.ttl
+	}
+
+	token, err := jwt.ParseSigned(info.Token)
+	if err != nil {
+		return p.ttl
+	}
+
+	var claims jwt.Claims
+	if err = token.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return p.ttl
+	}
+
+	if untilExpiry := time.Until(claims.Expiry.Time()); untilExpiry < p.ttl {
+		return untilExpiry
 	}
 
-	return identity, err
+	return p.ttl
 }
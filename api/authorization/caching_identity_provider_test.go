@@ -2,13 +2,18 @@ package authorization_test
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/authorization/fake"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/utils/clock/testing"
@@ -21,14 +26,14 @@ var _ = Describe("IdentityProvider", func() {
 		idProvider    *authorization.CachingIdentityProvider
 		aliceId, id   authorization.Identity
 		clock         *testing.FakeClock
-		identityCache *cache.Expiring
+		identityCache *cache.LRUExpireCache
 		getErr        error
 	)
 
 	BeforeEach(func() {
 		fakeProvider = new(fake.IdentityProvider)
 		clock = testing.NewFakeClock(time.Now())
-		identityCache = cache.NewExpiringWithClock(clock)
+		identityCache = cache.NewLRUExpireCacheWithClock(2, clock)
 
 		aliceId = authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}
 		authInfo = authorization.Info{
@@ -36,7 +41,7 @@ var _ = Describe("IdentityProvider", func() {
 		}
 		fakeProvider.GetIdentityReturns(aliceId, nil)
 
-		idProvider = authorization.NewCachingIdentityProvider(fakeProvider, identityCache)
+		idProvider = authorization.NewCachingIdentityProvider(fakeProvider, identityCache, 2*time.Minute, 10*time.Second)
 	})
 
 	JustBeforeEach(func() {
@@ -53,6 +58,12 @@ var _ = Describe("IdentityProvider", func() {
 		Expect(actualAuthInfo).To(Equal(authInfo))
 	})
 
+	It("uses the digest of the auth info as a key", func() {
+		Expect(identityCache.Keys()).To(ConsistOf(authInfo.Hash()))
+		Expect(authInfo.Hash()).To(HaveLen(64))
+		Expect(authInfo.Hash()).NotTo(ContainSubstring(authInfo.Token))
+	})
+
 	When("the real identity provider fails", func() {
 		BeforeEach(func() {
 			fakeProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
@@ -61,6 +72,33 @@ var _ = Describe("IdentityProvider", func() {
 		It("returns an error", func() {
 			Expect(getErr).To(MatchError(ContainSubstring("boom")))
 		})
+
+		It("does not cache the failure", func() {
+			Expect(identityCache.Keys()).To(BeEmpty())
+		})
+	})
+
+	When("the real identity provider rejects the auth info", func() {
+		BeforeEach(func() {
+			fakeProvider.GetIdentityReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(errors.New("not authenticated")))
+			_, err := idProvider.GetIdentity(context.Background(), authInfo)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns the cached error without calling the real identity provider again", func() {
+			Expect(getErr).To(MatchError(ContainSubstring("not authenticated")))
+			Expect(fakeProvider.GetIdentityCallCount()).To(Equal(1))
+		})
+
+		When("the failure TTL has passed", func() {
+			BeforeEach(func() {
+				clock.Step(11 * time.Second)
+			})
+
+			It("calls the real identity provider again", func() {
+				Expect(fakeProvider.GetIdentityCallCount()).To(Equal(2))
+			})
+		})
 	})
 
 	When("the token is cached", func() {
@@ -77,10 +115,14 @@ var _ = Describe("IdentityProvider", func() {
 			Expect(id).To(Equal(aliceId))
 		})
 
-		It("uses the hash of the auth info as a key", func() {
-			Expect(identityCache.Len()).To(Equal(1))
-			_, ok := identityCache.Get(authInfo.Hash())
-			Expect(ok).To(BeTrue())
+		When("the TTL has passed", func() {
+			BeforeEach(func() {
+				clock.Step(121 * time.Second)
+			})
+
+			It("calls the real identity provider again", func() {
+				Expect(fakeProvider.GetIdentityCallCount()).To(Equal(2))
+			})
 		})
 
 		When("a different auth info is sent", func() {
@@ -97,14 +139,50 @@ var _ = Describe("IdentityProvider", func() {
 			})
 		})
 
-		When("the cache has a non Identity value for the key", func() {
+		When("more auth infos than the size of the cache are sent", func() {
 			BeforeEach(func() {
-				identityCache.Set(authInfo.Hash(), 42, time.Minute)
+				for _, token := range []string{"token-1", "token-2"} {
+					_, err := idProvider.GetIdentity(context.Background(), authorization.Info{Token: token})
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("evicts the least recently used identity", func() {
+				Expect(identityCache.Keys()).To(HaveLen(2))
+				Expect(fakeProvider.GetIdentityCallCount()).To(Equal(4))
+			})
+		})
+
+		When("the cache has an unexpected value for the key", func() {
+			BeforeEach(func() {
+				identityCache.Add(authInfo.Hash(), 42, time.Minute)
 			})
 
 			It("returns an error", func() {
-				Expect(getErr).To(MatchError(ContainSubstring("identity-provider cache: expected authorization.Identity{}, got int")))
+				Expect(getErr).To(MatchError(ContainSubstring("identity-provider cache: expected authorization.cachedIdentity{}, got int")))
 			})
 		})
 	})
+
+	When("the token is a JWT that expires before the TTL", func() {
+		BeforeEach(func() {
+			signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: signingKey}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			authInfo.Token, err = jwt.Signed(signer).Claims(jwt.Claims{
+				Expiry: jwt.NewNumericDate(time.Now().Add(30 * time.Second)),
+			}).CompactSerialize()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = idProvider.GetIdentity(context.Background(), authInfo)
+			Expect(err).NotTo(HaveOccurred())
+			clock.Step(31 * time.Second)
+		})
+
+		It("evicts the identity when the token expires", func() {
+			Expect(fakeProvider.GetIdentityCallCount()).To(Equal(2))
+		})
+	})
 })
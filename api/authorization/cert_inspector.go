@@ -50,8 +50,10 @@ func (c *CertInspector) WhoAmI(ctx context.Context, certPEM []byte) (Identity, e
 		return Identity{}, apierrors.FromK8sError(err, "")
 	}
 
+	// Kubernetes takes the organizations of the subject of client certificates as the groups of the user
 	return Identity{
-		Name: cert.Subject.CommonName,
-		Kind: rbacv1.UserKind,
+		Name:   cert.Subject.CommonName,
+		Kind:   rbacv1.UserKind,
+		Groups: cert.Subject.Organization,
 	}, nil
 }
@@ -17,6 +17,9 @@ const (
 type Identity struct {
 	Name string
 	Kind string
+	// Groups are the groups of the identity, as reported by the cluster for tokens, or the organizations of the
+	// subject for client certificates
+	Groups []string
 }
 
 type TokenIdentityInspector interface {
@@ -40,8 +40,12 @@ func (i Info) Scheme() string {
 	return UnknownScheme
 }
 
+// Hash is the sha256 digest of the token and the certificate, which identifies the info without keeping the secrets
+// in memory
 func (i Info) Hash() string {
-	key := append([]byte(i.Token), i.CertData...)
 	hasher := sha256.New()
-	return hex.EncodeToString(hasher.Sum(key))
+	hasher.Write([]byte(i.Token))
+	hasher.Write([]byte{0})
+	hasher.Write(i.CertData)
+	return hex.EncodeToString(hasher.Sum(nil))
 }
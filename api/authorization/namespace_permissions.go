@@ -3,10 +3,13 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 )
@@ -28,20 +31,41 @@ type NamespacePermissions struct {
 	privilegedClient client.Client
 	identityProvider IdentityProvider
 	rootNamespace    string
+	permissionsCache *cache.LRUExpireCache
+	ttl              time.Duration
 }
 
 func NewNamespacePermissions(privilegedClient client.Client, identityProvider IdentityProvider, rootNamespace string) *NamespacePermissions {
+	return NewCachingNamespacePermissions(privilegedClient, identityProvider, rootNamespace, nil, 0)
+}
+
+// NewCachingNamespacePermissions caches the authorized orgs and spaces of each identity for the ttl, so that listing
+// resources does not list every RoleBinding of the cluster. The repositories invalidate the cache when they change
+// roles, while roles changed outside of the API are seen once the cached namespaces expire.
+func NewCachingNamespacePermissions(
+	privilegedClient client.Client,
+	identityProvider IdentityProvider,
+	rootNamespace string,
+	permissionsCache *cache.LRUExpireCache,
+	ttl time.Duration,
+) *NamespacePermissions {
 	return &NamespacePermissions{
 		privilegedClient: privilegedClient,
 		identityProvider: identityProvider,
 		rootNamespace:    rootNamespace,
+		permissionsCache: permissionsCache,
+		ttl:              ttl,
 	}
 }
 
+// GetAuthorizedOrgNamespaces returns the org namespaces the identity of the info has a role in. The map may be shared
+// with other callers and must not be modified.
 func (o *NamespacePermissions) GetAuthorizedOrgNamespaces(ctx context.Context, info Info) (map[string]bool, error) {
 	return o.getAuthorizedNamespaces(ctx, info, orgLevel, "Org")
 }
 
+// GetAuthorizedSpaceNamespaces returns the space namespaces the identity of the info has a role in. The map may be
+// shared with other callers and must not be modified.
 func (o *NamespacePermissions) GetAuthorizedSpaceNamespaces(ctx context.Context, info Info) (map[string]bool, error) {
 	return o.getAuthorizedNamespaces(ctx, info, spaceLevel, "Space")
 }
@@ -52,6 +76,40 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 		return nil, fmt.Errorf("failed to get identity: %w", err)
 	}
 
+	if o.permissionsCache == nil {
+		return o.listAuthorizedNamespaces(ctx, identity, orgSpaceLevel, resourceType)
+	}
+
+	key := permissionsCacheKey(identity, orgSpaceLevel)
+	if cached, ok := o.permissionsCache.Get(key); ok {
+		if authorizedNamespaces, castOK := cached.(map[string]bool); castOK {
+			return authorizedNamespaces, nil
+		}
+		return nil, fmt.Errorf("namespace-permissions cache: expected map[string]bool, got %T", cached)
+	}
+
+	authorizedNamespaces, err := o.listAuthorizedNamespaces(ctx, identity, orgSpaceLevel, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	o.permissionsCache.Add(key, authorizedNamespaces, o.ttl)
+
+	return authorizedNamespaces, nil
+}
+
+// Invalidate forgets the cached namespaces of every identity. A role may be granted to a group, so changing it can
+// change the namespaces of any identity.
+func (o *NamespacePermissions) Invalidate() {
+	if o.permissionsCache == nil {
+		return
+	}
+
+	for _, key := range o.permissionsCache.Keys() {
+		o.permissionsCache.Remove(key)
+	}
+}
+
+func (o *NamespacePermissions) listAuthorizedNamespaces(ctx context.Context, identity Identity, orgSpaceLevel, resourceType string) (map[string]bool, error) {
 	var rolebindings rbacv1.RoleBindingList
 	if err := o.privilegedClient.List(ctx, &rolebindings); err != nil {
 		return nil, fmt.Errorf("failed to list rolebindings: %w", apierrors.FromK8sError(err, resourceType))
@@ -73,7 +131,7 @@ func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			if subject.Kind == identity.Kind && subject.Name == identity.Name {
+			if subjectMatches(subject, identity) {
 				if cfNamespaces[roleBinding.Namespace] {
 					authorizedNamespaces[roleBinding.Namespace] = true
 				}
@@ -93,7 +151,7 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 
 	for _, roleBinding := range rolebindings.Items {
 		for _, subject := range roleBinding.Subjects {
-			if subject.Kind == identity.Kind && subject.Name == identity.Name {
+			if subjectMatches(subject, identity) {
 				return true, nil
 			}
 		}
@@ -101,3 +159,16 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 
 	return false, nil
 }
+
+func permissionsCacheKey(identity Identity, orgSpaceLevel string) string {
+	return strings.Join([]string{orgSpaceLevel, identity.Kind, identity.Name, strings.Join(identity.Groups, ",")}, "/")
+}
+
+// subjectMatches reports whether the role binding subject is the identity itself or one of its groups
+func subjectMatches(subject rbacv1.Subject, identity Identity) bool {
+	if subject.Kind == identity.Kind && subject.Name == identity.Name {
+		return true
+	}
+
+	return subject.Kind == rbacv1.GroupKind && contains(identity.Groups, subject.Name)
+}
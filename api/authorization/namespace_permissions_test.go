@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 )
 
@@ -83,6 +84,31 @@ var _ = Describe("Namespace Permissions", func() {
 		return role
 	}
 
+	createRoleBindingForGroup := func(group, roleName, namespace string) *rbacv1.RoleBinding {
+		role := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", group, roleName),
+				Namespace: namespace,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					APIGroup: rbacv1.GroupName,
+					Name:     group,
+					Kind:     rbacv1.GroupKind,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     roleName,
+			},
+		}
+
+		Expect(k8sClient.Create(ctx, role)).To(Succeed())
+
+		return role
+	}
+
 	BeforeEach(func() {
 		rootNamespace = generateGUID("root-ns")
 		userName = generateGUID("alice")
@@ -142,6 +168,73 @@ var _ = Describe("Namespace Permissions", func() {
 			Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
 		})
 
+		When("a group of the user has a rolebinding", func() {
+			var groupName string
+
+			BeforeEach(func() {
+				groupName = generateGUID("developers")
+				createRoleBindingForGroup(groupName, roleName1, org2NS)
+				createRoleBindingForGroup(generateGUID("auditors"), roleName1, org2NS)
+
+				identity.Groups = []string{"system:authenticated", groupName}
+				identityProvider.GetIdentityReturns(identity, nil)
+			})
+
+			It("lists the namespaces with bindings for the group too", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true, org2NS: true}))
+			})
+		})
+
+		When("a user has the name of a group with a rolebinding", func() {
+			BeforeEach(func() {
+				createRoleBindingForGroup(userName, roleName1, org2NS)
+			})
+
+			It("does not list the namespaces of the group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
+			})
+		})
+
+		When("the namespaces are cached", func() {
+			BeforeEach(func() {
+				nsPerms = authorization.NewCachingNamespacePermissions(k8sClient, identityProvider, rootNamespace, cache.NewLRUExpireCache(10), time.Minute)
+				_, err := nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				createRoleBindingForUser(userName, roleName1, org2NS)
+			})
+
+			It("returns the cached namespaces", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
+			})
+
+			When("the cache is invalidated", func() {
+				BeforeEach(func() {
+					nsPerms.Invalidate()
+				})
+
+				It("lists the namespaces again", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(namespaces).To(Equal(map[string]bool{org1NS: true, org2NS: true}))
+				})
+			})
+
+			When("the identity has other groups", func() {
+				BeforeEach(func() {
+					identity.Groups = []string{"system:authenticated"}
+					identityProvider.GetIdentityReturns(identity, nil)
+				})
+
+				It("does not use the namespaces cached for the other identity", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(namespaces).To(Equal(map[string]bool{org1NS: true, org2NS: true}))
+				})
+			})
+		})
+
 		When("the id provider fails", func() {
 			BeforeEach(func() {
 				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("boom"))
@@ -250,6 +343,31 @@ var _ = Describe("Namespace Permissions", func() {
 				Expect(authorized).To(BeFalse())
 			})
 		})
+
+		When("a group of the user has a RoleBinding in the namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForGroup("org2-developers", roleName1, org2NS)
+				identity.Groups = []string{"org2-developers"}
+			})
+
+			It("returns true", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, identity, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+		})
+
+		When("the identity is a group with a RoleBinding in the namespace", func() {
+			BeforeEach(func() {
+				createRoleBindingForGroup("org2-developers", roleName1, org2NS)
+			})
+
+			It("returns true", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, authorization.Identity{Name: "org2-developers", Kind: rbacv1.GroupKind}, org2NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+		})
 	})
 })
 
@@ -0,0 +1,204 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+	tokenLeeway          = time.Minute
+
+	// NoPrefix disables the prefixing of usernames or groups, which are otherwise prefixed with "<issuer URL>#"
+	NoPrefix = "-"
+
+	// reservedPrefix is the prefix of the users and groups of Kubernetes itself, which must never be impersonated
+	reservedPrefix = "system:"
+)
+
+// OIDCIssuer is an OIDC or UAA identity provider whose tokens are validated by the API with the keys of the issuer,
+// rather than by the cluster. Usernames and groups are prefixed with "<issuer URL>#" unless another prefix, or
+// NoPrefix, is set, as they are by kube-apiserver.
+type OIDCIssuer struct {
+	URL            string
+	Audience       string
+	Keys           jose.JSONWebKeySet
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+func LoadJWKS(path string) (jose.JSONWebKeySet, error) {
+	jwksBytes, err := os.ReadFile(path)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to read JWKS file %q: %w", path, err)
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err = json.Unmarshal(jwksBytes, &jwks); err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to parse JWKS file %q: %w", path, err)
+	}
+
+	return jwks, nil
+}
+
+// OIDCIdentityProvider gets the identity of the tokens of the configured issuers from their claims. Client
+// certificates and the tokens of other issuers are left to the delegate provider.
+type OIDCIdentityProvider struct {
+	issuers  map[string]OIDCIssuer
+	delegate IdentityProvider
+}
+
+func NewOIDCIdentityProvider(issuers []OIDCIssuer, delegate IdentityProvider) *OIDCIdentityProvider {
+	issuersByURL := map[string]OIDCIssuer{}
+	for _, issuer := range issuers {
+		if issuer.UsernameClaim == "" {
+			issuer.UsernameClaim = defaultUsernameClaim
+		}
+		if issuer.GroupsClaim == "" {
+			issuer.GroupsClaim = defaultGroupsClaim
+		}
+		issuer.UsernamePrefix = prefixOrDefault(issuer.UsernamePrefix, issuer.URL)
+		issuer.GroupsPrefix = prefixOrDefault(issuer.GroupsPrefix, issuer.URL)
+		issuersByURL[issuer.URL] = issuer
+	}
+
+	return &OIDCIdentityProvider{
+		issuers:  issuersByURL,
+		delegate: delegate,
+	}
+}
+
+func prefixOrDefault(prefix, issuerURL string) string {
+	switch prefix {
+	case "":
+		return issuerURL + "#"
+	case NoPrefix:
+		return ""
+	default:
+		return prefix
+	}
+}
+
+// Recognizes reports whether the token claims to be issued by one of the configured issuers. The claim is only
+// trusted once GetIdentity has verified the signature of the token.
+func (p *OIDCIdentityProvider) Recognizes(token string) bool {
+	_, _, ok := p.issuerOf(token)
+	return ok
+}
+
+func (p *OIDCIdentityProvider) GetIdentity(ctx context.Context, info Info) (Identity, error) {
+	if info.Token == "" {
+		return p.delegate.GetIdentity(ctx, info)
+	}
+
+	issuer, token, ok := p.issuerOf(info.Token)
+	if !ok {
+		return p.delegate.GetIdentity(ctx, info)
+	}
+
+	var claims jwt.Claims
+	customClaims := map[string]interface{}{}
+	if err := verifyClaims(issuer, token, &claims, &customClaims); err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	expected := jwt.Expected{Issuer: issuer.URL, Time: time.Now()}
+	if issuer.Audience != "" {
+		expected.Audience = jwt.Audience{issuer.Audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, tokenLeeway); err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("invalid token claims: %w", err))
+	}
+	// tokens without an expiry would be valid forever
+	if claims.Expiry == nil {
+		return Identity{}, apierrors.NewInvalidAuthError(errors.New(`token has no "exp" claim`))
+	}
+
+	username, ok := customClaims[issuer.UsernameClaim].(string)
+	if !ok || username == "" {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("token has no %q claim", issuer.UsernameClaim))
+	}
+
+	name := issuer.UsernamePrefix + username
+	if strings.HasPrefix(name, reservedPrefix) {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("username %q is reserved", name))
+	}
+
+	groups := []string{}
+	for _, group := range stringsClaim(customClaims[issuer.GroupsClaim]) {
+		group = issuer.GroupsPrefix + group
+		if strings.HasPrefix(group, reservedPrefix) {
+			return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("group %q is reserved", group))
+		}
+		groups = append(groups, group)
+	}
+
+	return Identity{
+		Name:   name,
+		Kind:   rbacv1.UserKind,
+		Groups: groups,
+	}, nil
+}
+
+func (p *OIDCIdentityProvider) issuerOf(rawToken string) (OIDCIssuer, *jwt.JSONWebToken, bool) {
+	token, err := jwt.ParseSigned(rawToken)
+	if err != nil {
+		return OIDCIssuer{}, nil, false
+	}
+
+	var claims jwt.Claims
+	if err = token.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return OIDCIssuer{}, nil, false
+	}
+
+	issuer, ok := p.issuers[claims.Issuer]
+	return issuer, token, ok
+}
+
+// verifyClaims decodes the claims of the token with the first key of the issuer that verifies its signature
+func verifyClaims(issuer OIDCIssuer, token *jwt.JSONWebToken, claims ...interface{}) error {
+	keys := issuer.Keys.Keys
+	if len(token.Headers) > 0 && token.Headers[0].KeyID != "" {
+		keys = issuer.Keys.Key(token.Headers[0].KeyID)
+	}
+
+	for _, key := range keys {
+		if err := token.Claims(key.Key, claims...); err == nil {
+			return nil
+		}
+	}
+
+	return errors.New("failed to verify the token signature with the keys of the issuer")
+}
+
+// stringsClaim reads a claim that is either a list of strings or a single string of space separated values, such as
+// the OAuth scope claim
+func stringsClaim(claim interface{}) []string {
+	switch claim := claim.(type) {
+	case string:
+		return strings.Fields(claim)
+	case []interface{}:
+		values := []string{}
+		for _, value := range claim {
+			if value, ok := value.(string); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,290 @@
+package authorization_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCIdentityProvider", func() {
+	const issuerURL = "https://login.example.org/oauth/token"
+
+	var (
+		signingKey   *rsa.PrivateKey
+		jwksPath     string
+		issuer       authorization.OIDCIssuer
+		delegate     *fake.IdentityProvider
+		idProvider   *authorization.OIDCIdentityProvider
+		claims       map[string]interface{}
+		authInfo     authorization.Info
+		id           authorization.Identity
+		getErr       error
+		delegatedId  authorization.Identity
+		signWithKey  *rsa.PrivateKey
+		signingKeyID string
+	)
+
+	signToken := func(claims map[string]interface{}) string {
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.RS256, Key: signWithKey},
+			(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", signingKeyID),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+		Expect(err).NotTo(HaveOccurred())
+
+		return token
+	}
+
+	BeforeEach(func() {
+		var err error
+		signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		signWithKey = signingKey
+		signingKeyID = "key-1"
+
+		jwksBytes, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &signingKey.PublicKey,
+			KeyID:     "key-1",
+			Use:       "sig",
+			Algorithm: "RS256",
+		}}})
+		Expect(err).NotTo(HaveOccurred())
+		jwksPath = filepath.Join(GinkgoT().TempDir(), "jwks.json")
+		Expect(os.WriteFile(jwksPath, jwksBytes, 0o600)).To(Succeed())
+
+		keys, err := authorization.LoadJWKS(jwksPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		issuer = authorization.OIDCIssuer{
+			URL:            issuerURL,
+			Audience:       "cf",
+			Keys:           keys,
+			UsernameClaim:  "user_name",
+			UsernamePrefix: "sso:",
+			GroupsPrefix:   "sso:",
+		}
+
+		delegatedId = authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}
+		delegate = new(fake.IdentityProvider)
+		delegate.GetIdentityReturns(delegatedId, nil)
+
+		claims = map[string]interface{}{
+			"iss":       issuerURL,
+			"aud":       []string{"cf", "openid"},
+			"sub":       "0d5c52d4-2b7d-4b5e-a4d4-0c6c6b1e6bb5",
+			"user_name": "bob",
+			"groups":    []string{"developers", "auditors"},
+			"exp":       time.Now().Add(time.Hour).Unix(),
+			"iat":       time.Now().Unix(),
+		}
+	})
+
+	JustBeforeEach(func() {
+		idProvider = authorization.NewOIDCIdentityProvider([]authorization.OIDCIssuer{issuer}, delegate)
+		authInfo = authorization.Info{Token: signToken(claims)}
+		id, getErr = idProvider.GetIdentity(context.Background(), authInfo)
+	})
+
+	It("maps the claims of the token to the identity", func() {
+		Expect(getErr).NotTo(HaveOccurred())
+		Expect(id).To(Equal(authorization.Identity{
+			Name:   "sso:bob",
+			Kind:   rbacv1.UserKind,
+			Groups: []string{"sso:developers", "sso:auditors"},
+		}))
+		Expect(delegate.GetIdentityCallCount()).To(BeZero())
+	})
+
+	It("recognizes the token", func() {
+		Expect(idProvider.Recognizes(authInfo.Token)).To(BeTrue())
+	})
+
+	When("the groups claim is a string of space separated values", func() {
+		BeforeEach(func() {
+			issuer.GroupsClaim = "scope"
+			claims["scope"] = "openid cloud_controller.read"
+		})
+
+		It("splits the groups", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id.Groups).To(Equal([]string{"sso:openid", "sso:cloud_controller.read"}))
+		})
+	})
+
+	When("the token is issued by another issuer", func() {
+		BeforeEach(func() {
+			claims["iss"] = "https://kubernetes.default.svc"
+		})
+
+		It("delegates to the other identity provider", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id).To(Equal(delegatedId))
+			Expect(delegate.GetIdentityCallCount()).To(Equal(1))
+			Expect(idProvider.Recognizes(authInfo.Token)).To(BeFalse())
+		})
+	})
+
+	When("the token is not a JWT", func() {
+		JustBeforeEach(func() {
+			authInfo = authorization.Info{Token: "an-opaque-token"}
+			id, getErr = idProvider.GetIdentity(context.Background(), authInfo)
+		})
+
+		It("delegates to the other identity provider", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id).To(Equal(delegatedId))
+		})
+	})
+
+	When("the authorization info is a client certificate", func() {
+		JustBeforeEach(func() {
+			authInfo = authorization.Info{CertData: []byte("a-cert")}
+			id, getErr = idProvider.GetIdentity(context.Background(), authInfo)
+		})
+
+		It("delegates to the other identity provider", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id).To(Equal(delegatedId))
+		})
+	})
+
+	When("the token is signed with another key", func() {
+		BeforeEach(func() {
+			var err error
+			signWithKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(delegate.GetIdentityCallCount()).To(BeZero())
+		})
+	})
+
+	When("the token has an unknown key ID", func() {
+		BeforeEach(func() {
+			signingKeyID = "key-2"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token has expired", func() {
+		BeforeEach(func() {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token has no expiry", func() {
+		BeforeEach(func() {
+			delete(claims, "exp")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(getErr).To(MatchError(ContainSubstring(`token has no "exp" claim`)))
+		})
+	})
+
+	When("the token is issued for another audience", func() {
+		BeforeEach(func() {
+			claims["aud"] = "another-client"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token has no username claim", func() {
+		BeforeEach(func() {
+			delete(claims, "user_name")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(getErr).To(MatchError(ContainSubstring(`token has no "user_name" claim`)))
+		})
+	})
+
+	When("the issuer uses the default claims and prefixes", func() {
+		BeforeEach(func() {
+			issuer.UsernameClaim = ""
+			issuer.UsernamePrefix = ""
+			issuer.GroupsPrefix = ""
+		})
+
+		It("maps the sub and groups claims prefixed with the issuer URL", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal(issuerURL + "#0d5c52d4-2b7d-4b5e-a4d4-0c6c6b1e6bb5"))
+			Expect(id.Groups).To(Equal([]string{issuerURL + "#developers", issuerURL + "#auditors"}))
+		})
+	})
+
+	When("prefixing is disabled", func() {
+		BeforeEach(func() {
+			issuer.UsernamePrefix = authorization.NoPrefix
+			issuer.GroupsPrefix = authorization.NoPrefix
+		})
+
+		It("does not prefix the username and groups", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("bob"))
+			Expect(id.Groups).To(Equal([]string{"developers", "auditors"}))
+		})
+
+		When("the username is reserved by Kubernetes", func() {
+			BeforeEach(func() {
+				claims["user_name"] = "system:admin"
+			})
+
+			It("returns an invalid auth error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+				Expect(getErr).To(MatchError(ContainSubstring(`username "system:admin" is reserved`)))
+			})
+		})
+
+		When("a group is reserved by Kubernetes", func() {
+			BeforeEach(func() {
+				claims["groups"] = []string{"developers", "system:masters"}
+			})
+
+			It("returns an invalid auth error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+				Expect(getErr).To(MatchError(ContainSubstring(`group "system:masters" is reserved`)))
+			})
+		})
+	})
+
+	When("the prefix is reserved by Kubernetes", func() {
+		BeforeEach(func() {
+			issuer.UsernamePrefix = "system:"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+})
@@ -7,12 +7,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
-	defaultExternalProtocol = "https"
+	defaultExternalProtocol       = "https"
+	defaultResourceCacheDirName   = "korifi-resource-cache"
+	defaultResourceCacheMaxSizeMB = 1024
+
+	defaultAuthCacheTTLSeconds            = 120
+	defaultAuthCacheFailureTTLSeconds     = 10
+	defaultAuthCachePermissionsTTLSeconds = 10
+	defaultAuthCacheMaxEntries            = 1000
 )
 
 type APIConfig struct {
@@ -27,11 +35,17 @@ type APIConfig struct {
 	PackageRegistryBase       string `yaml:"packageRegistryBase"`
 	PackageRegistrySecretName string `yaml:"packageRegistrySecretName"`
 	ClusterBuilderName        string `yaml:"clusterBuilderName"`
+	ResourceCacheDir          string `yaml:"resourceCacheDir"`
+	ResourceCacheMaxSizeMB    int64  `yaml:"resourceCacheMaxSizeMB"`
 	DefaultDomainName         string `yaml:"defaultDomainName"`
 
 	DefaultLifecycleConfig DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
 
 	RoleMappings map[string]Role `yaml:"roleMappings"`
+
+	OIDC OIDCConfig `yaml:"oidc"`
+
+	AuthCache AuthCacheConfig `yaml:"authCache"`
 }
 
 type Role struct {
@@ -39,6 +53,49 @@ type Role struct {
 	Propagate bool   `yaml:"propagate"`
 }
 
+// OIDCConfig contains the identity providers whose tokens are validated by the API rather than by the cluster, and
+// the login server advertised to the CF CLI. The API impersonates the users of these tokens.
+type OIDCConfig struct {
+	LoginURL string       `yaml:"loginURL"`
+	UAAURL   string       `yaml:"uaaURL"`
+	Issuers  []OIDCIssuer `yaml:"issuers"`
+}
+
+// OIDCIssuer validates the tokens of an identity provider with the keys of its JWKS file. The username and groups of
+// the users are read from the claims of their tokens, by default "sub" and "groups", and prefixed, by default with
+// "<url>#". A prefix of "-" disables prefixing.
+type OIDCIssuer struct {
+	URL            string `yaml:"url"`
+	Audience       string `yaml:"audience"`
+	JWKSPath       string `yaml:"jwksPath"`
+	UsernameClaim  string `yaml:"usernameClaim"`
+	UsernamePrefix string `yaml:"usernamePrefix"`
+	GroupsClaim    string `yaml:"groupsClaim"`
+	GroupsPrefix   string `yaml:"groupsPrefix"`
+}
+
+// AuthCacheConfig bounds the caches of the identities of the auth infos sent to the API and of the orgs and spaces
+// these identities have roles in. Failed authentications are cached for the failure TTL. Each cache holds at most
+// MaxEntries entries.
+type AuthCacheConfig struct {
+	TTLSeconds            int `yaml:"ttlSeconds"`
+	FailureTTLSeconds     int `yaml:"failureTTLSeconds"`
+	PermissionsTTLSeconds int `yaml:"permissionsTTLSeconds"`
+	MaxEntries            int `yaml:"maxEntries"`
+}
+
+func (c AuthCacheConfig) TTL() time.Duration {
+	return time.Duration(c.TTLSeconds) * time.Second
+}
+
+func (c AuthCacheConfig) FailureTTL() time.Duration {
+	return time.Duration(c.FailureTTLSeconds) * time.Second
+}
+
+func (c AuthCacheConfig) PermissionsTTL() time.Duration {
+	return time.Duration(c.PermissionsTTLSeconds) * time.Second
+}
+
 // DefaultLifecycleConfig contains default values of the Lifecycle block of CFApps and Builds created by the Shim
 type DefaultLifecycleConfig struct {
 	Type            string `yaml:"type"`
@@ -74,6 +131,20 @@ func LoadFromPath(path string) (*APIConfig, error) {
 		}
 	}
 
+	if config.ResourceCacheDir == "" {
+		config.ResourceCacheDir = filepath.Join(os.TempDir(), defaultResourceCacheDirName)
+	}
+
+	if config.ResourceCacheMaxSizeMB == 0 {
+		config.ResourceCacheMaxSizeMB = defaultResourceCacheMaxSizeMB
+	}
+
+	if config.OIDC.UAAURL == "" {
+		config.OIDC.UAAURL = config.OIDC.LoginURL
+	}
+
+	config.AuthCache.setDefaults()
+
 	config.ServerURL, err = config.composeServerURL()
 	if err != nil {
 		return nil, err
@@ -82,6 +153,24 @@ func LoadFromPath(path string) (*APIConfig, error) {
 	return &config, nil
 }
 
+func (c *AuthCacheConfig) setDefaults() {
+	if c.TTLSeconds == 0 {
+		c.TTLSeconds = defaultAuthCacheTTLSeconds
+	}
+
+	if c.FailureTTLSeconds == 0 {
+		c.FailureTTLSeconds = defaultAuthCacheFailureTTLSeconds
+	}
+
+	if c.PermissionsTTLSeconds == 0 {
+		c.PermissionsTTLSeconds = defaultAuthCachePermissionsTTLSeconds
+	}
+
+	if c.MaxEntries == 0 {
+		c.MaxEntries = defaultAuthCacheMaxEntries
+	}
+}
+
 func (c *APIConfig) composeServerURL() (string, error) {
 	if c.ExternalFQDN == "" {
 		return "", errors.New("ExternalFQDN not specified")
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -35,7 +36,19 @@ import (
 	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 )
 
-var createTimeout = time.Second * 120
+var (
+	createTimeout    = time.Second * 120
+	jobTimeout       = time.Minute * 10
+	jobPollInterval  = time.Second * 2
+	jobRetention     = time.Hour * 24
+	jobPruneInterval = time.Hour
+	// a job whose heartbeat is older than jobHeartbeatExpiry is failed, as the API instance running it has gone away
+	jobHeartbeatInterval = time.Second * 30
+	jobHeartbeatExpiry   = time.Minute * 2
+
+	auditEventRetention     = time.Hour * 24 * 31
+	auditEventPruneInterval = time.Hour
+)
 
 func init() {
 	utilruntime.Must(workloadsv1alpha1.AddToScheme(scheme.Scheme))
@@ -91,8 +104,24 @@ func main() {
 	var userClientFactory repositories.UserK8sClientFactory = repositories.NewUnprivilegedClientFactory(k8sClientConfig, mapper)
 
 	identityProvider := wireIdentityProvider(privilegedCRClient, k8sClientConfig)
-	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
-	nsPermissions := authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider, config.RootNamespace)
+	if len(config.OIDC.Issuers) > 0 {
+		oidcIdentityProvider := wireOIDCIdentityProvider(config.OIDC, identityProvider)
+		identityProvider = oidcIdentityProvider
+		userClientFactory = repositories.NewImpersonatingClientFactory(k8sClientConfig, mapper, oidcIdentityProvider)
+	}
+	cachingIdentityProvider := authorization.NewCachingIdentityProvider(
+		identityProvider,
+		cache.NewLRUExpireCache(config.AuthCache.MaxEntries),
+		config.AuthCache.TTL(),
+		config.AuthCache.FailureTTL(),
+	)
+	nsPermissions := authorization.NewCachingNamespacePermissions(
+		privilegedCRClient,
+		cachingIdentityProvider,
+		config.RootNamespace,
+		cache.NewLRUExpireCache(config.AuthCache.MaxEntries),
+		config.AuthCache.PermissionsTTL(),
+	)
 
 	serverURL, err := url.Parse(config.ServerURL)
 	if err != nil {
@@ -110,40 +139,64 @@ func main() {
 	dropletRepo := repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPermissions)
 	routeRepo := repositories.NewRouteRepo(namespaceRetriever, userClientFactory, nsPermissions)
 	domainRepo := repositories.NewDomainRepo(userClientFactory, namespaceRetriever, config.RootNamespace)
-	buildRepo := repositories.NewBuildRepo(namespaceRetriever, userClientFactory)
+	buildRepo := repositories.NewBuildRepo(namespaceRetriever, userClientFactory, nsPermissions)
 	packageRepo := repositories.NewPackageRepo(userClientFactory, namespaceRetriever, nsPermissions)
-	serviceInstanceRepo := repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPermissions)
+	serviceInstanceRepo := repositories.NewServiceInstanceRepo(config.RootNamespace, namespaceRetriever, userClientFactory, nsPermissions)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(namespaceRetriever, userClientFactory, nsPermissions)
+	serviceBrokerRepo := repositories.NewServiceBrokerRepo(config.RootNamespace, userClientFactory)
+	serviceOfferingRepo := repositories.NewServiceOfferingRepo(config.RootNamespace, userClientFactory)
+	servicePlanRepo := repositories.NewServicePlanRepo(config.RootNamespace, userClientFactory)
+	taskRepo := repositories.NewTaskRepo(namespaceRetriever, userClientFactory, nsPermissions, createTimeout)
+	deploymentRepo := repositories.NewDeploymentRepo(namespaceRetriever, userClientFactory, nsPermissions)
+	revisionRepo := repositories.NewRevisionRepo(namespaceRetriever, userClientFactory)
+	sidecarRepo := repositories.NewSidecarRepo(namespaceRetriever, userClientFactory)
 	buildpackRepo := repositories.NewBuildpackRepository(userClientFactory)
+	jobRepo := repositories.NewJobRepo(config.RootNamespace, privilegedCRClient)
+	auditEventRepo := repositories.NewAuditEventRepo(config.RootNamespace, privilegedCRClient, nsPermissions)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(config.RootNamespace, privilegedCRClient, userClientFactory)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(namespaceRetriever, privilegedCRClient, userClientFactory, nsPermissions)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(config.RootNamespace, privilegedCRClient, userClientFactory)
 	roleRepo := repositories.NewRoleRepo(
 		privilegedCRClient,
 		userClientFactory,
-		authorization.NewNamespacePermissions(
-			privilegedCRClient,
-			cachingIdentityProvider,
-			config.RootNamespace,
-		),
+		nsPermissions,
+		nsPermissions,
 		config.RootNamespace,
 		config.RoleMappings,
 	)
+	userRepo := repositories.NewUserRepo(config.RootNamespace, privilegedCRClient, userClientFactory, nsPermissions)
+	resourceCache, err := reporegistry.NewResourceCache(config.ResourceCacheDir, config.ResourceCacheMaxSizeMB*1024*1024)
+	if err != nil {
+		panic(fmt.Sprintf("could not create resource cache: %v", err))
+	}
 	imageRepo := repositories.NewImageRepository(
 		privilegedK8sClient,
 		userClientFactory,
+		nsPermissions,
 		config.RootNamespace,
 		config.PackageRegistrySecretName,
-		reporegistry.NewImageBuilder(),
+		reporegistry.NewImageBuilder(resourceCache),
 		reporegistry.NewImagePusher(remote.Write),
+		resourceCache,
 	)
 
 	scaleProcessAction := actions.NewScaleProcess(processRepo)
 	scaleAppProcessAction := actions.NewScaleAppProcess(appRepo, processRepo, scaleProcessAction.Invoke)
 	fetchProcessStatsAction := actions.NewFetchProcessStats(processRepo, podRepo, appRepo)
+	deleteProcessInstanceAction := actions.NewDeleteProcessInstance(processRepo, podRepo, appRepo)
+	jobRunner := actions.NewJobRunner(ctrl.Log.WithName("JobRunner"), cachingIdentityProvider, jobRepo, jobTimeout, jobPollInterval, jobHeartbeatInterval)
+	go jobRunner.FailAbandonedJobs(context.Background(), jobHeartbeatExpiry, jobHeartbeatInterval)
+	go jobRunner.PruneJobs(context.Background(), jobRetention, jobPruneInterval)
+	auditEventRecorder := actions.NewAuditEventRecorder(ctrl.Log.WithName("AuditEventRecorder"), cachingIdentityProvider, auditEventRepo, orgRepo)
+	go auditEventRecorder.PruneAuditEvents(context.Background(), auditEventRetention, auditEventPruneInterval)
 	applyManifestAction := actions.NewApplyManifest(
 		appRepo,
 		domainRepo,
 		processRepo,
 		routeRepo,
+		sidecarRepo,
 	).Invoke
+	diffManifestAction := actions.NewDiffManifest(appRepo, processRepo, routeRepo).Invoke
 
 	decoderValidator, err := apis.NewDefaultDecoderValidator()
 	if err != nil {
@@ -154,8 +207,14 @@ func main() {
 		apis.NewRootV3Handler(config.ServerURL),
 		apis.NewRootHandler(
 			config.ServerURL,
+			config.OIDC.LoginURL,
+			config.OIDC.UAAURL,
+		),
+		apis.NewResourceMatchesHandler(
+			ctrl.Log.WithName("ResourceMatchesHandler"),
+			imageRepo,
+			decoderValidator,
 		),
-		apis.NewResourceMatchesHandler(ctrl.Log.WithName("ResourceMatchesHandler")),
 		apis.NewAppHandler(
 			ctrl.Log.WithName("AppHandler"),
 			*serverURL,
@@ -166,6 +225,9 @@ func main() {
 			domainRepo,
 			orgRepo,
 			scaleAppProcessAction.Invoke,
+			deleteProcessInstanceAction.InvokeForApp,
+			jobRunner,
+			auditEventRecorder,
 			decoderValidator,
 		),
 		apis.NewRouteHandler(
@@ -175,6 +237,8 @@ func main() {
 			domainRepo,
 			appRepo,
 			orgRepo,
+			jobRunner,
+			auditEventRecorder,
 			decoderValidator,
 		),
 		apis.NewServiceRouteBindingHandler(
@@ -208,8 +272,10 @@ func main() {
 			ctrl.Log.WithName("ProcessHandler"),
 			*serverURL,
 			processRepo,
+			sidecarRepo,
 			fetchProcessStatsAction.Invoke,
 			scaleProcessAction.Invoke,
+			deleteProcessInstanceAction.Invoke,
 			decoderValidator,
 		),
 		apis.NewDomainHandler(
@@ -220,25 +286,45 @@ func main() {
 		apis.NewJobHandler(
 			ctrl.Log.WithName("JobHandler"),
 			*serverURL,
+			cachingIdentityProvider,
+			jobRepo,
+		),
+		apis.NewAuditEventHandler(
+			ctrl.Log.WithName("AuditEventHandler"),
+			*serverURL,
+			auditEventRepo,
+		),
+		apis.NewLogCacheHandler(
+			ctrl.Log.WithName("LogCacheHandler"),
+			appRepo,
+			buildRepo,
+			podRepo,
+			nsPermissions,
+			decoderValidator,
 		),
-		apis.NewLogCacheHandler(),
 
-		apis.NewOrgHandler(*serverURL, orgRepo, domainRepo, decoderValidator),
+		apis.NewOrgHandler(*serverURL, orgRepo, domainRepo, jobRunner, auditEventRecorder, decoderValidator),
 
-		apis.NewSpaceHandler(*serverURL, config.PackageRegistrySecretName, orgRepo, decoderValidator),
+		apis.NewSpaceHandler(*serverURL, config.PackageRegistrySecretName, orgRepo, jobRunner, auditEventRecorder, decoderValidator),
 
 		apis.NewSpaceManifestHandler(
 			ctrl.Log.WithName("SpaceManifestHandler"),
 			*serverURL,
 			config.DefaultDomainName,
 			applyManifestAction,
+			diffManifestAction,
 			orgRepo,
+			jobRunner,
 			decoderValidator,
 		),
 
 		apis.NewRoleHandler(
 			*serverURL,
 			roleRepo,
+			userRepo,
+			orgRepo,
+			orgRepo,
+			jobRunner,
 			decoderValidator,
 		),
 
@@ -256,6 +342,8 @@ func main() {
 			*serverURL,
 			serviceInstanceRepo,
 			orgRepo,
+			servicePlanRepo,
+			jobRunner,
 			decoderValidator,
 		),
 
@@ -265,6 +353,91 @@ func main() {
 			serviceBindingRepo,
 			appRepo,
 			serviceInstanceRepo,
+			auditEventRecorder,
+			decoderValidator,
+		),
+
+		apis.NewServiceBrokerHandler(
+			ctrl.Log.WithName("ServiceBrokerHandler"),
+			*serverURL,
+			serviceBrokerRepo,
+			jobRunner,
+			decoderValidator,
+		),
+
+		apis.NewServiceOfferingHandler(
+			ctrl.Log.WithName("ServiceOfferingHandler"),
+			*serverURL,
+			serviceOfferingRepo,
+		),
+
+		apis.NewServicePlanHandler(
+			ctrl.Log.WithName("ServicePlanHandler"),
+			*serverURL,
+			servicePlanRepo,
+		),
+
+		apis.NewTaskHandler(
+			ctrl.Log.WithName("TaskHandler"),
+			*serverURL,
+			appRepo,
+			taskRepo,
+			decoderValidator,
+		),
+
+		apis.NewDeploymentHandler(
+			ctrl.Log.WithName("DeploymentHandler"),
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			revisionRepo,
+			deploymentRepo,
+			decoderValidator,
+		),
+
+		apis.NewRevisionHandler(
+			ctrl.Log.WithName("RevisionHandler"),
+			*serverURL,
+			appRepo,
+			revisionRepo,
+		),
+		apis.NewSidecarHandler(
+			ctrl.Log.WithName("SidecarHandler"),
+			*serverURL,
+			appRepo,
+			sidecarRepo,
+			decoderValidator,
+		),
+
+		apis.NewOrgQuotaHandler(
+			ctrl.Log.WithName("OrgQuotaHandler"),
+			*serverURL,
+			orgQuotaRepo,
+			jobRunner,
+			decoderValidator,
+		),
+
+		apis.NewSpaceQuotaHandler(
+			ctrl.Log.WithName("SpaceQuotaHandler"),
+			*serverURL,
+			spaceQuotaRepo,
+			jobRunner,
+			decoderValidator,
+		),
+
+		apis.NewSecurityGroupHandler(
+			ctrl.Log.WithName("SecurityGroupHandler"),
+			*serverURL,
+			securityGroupRepo,
+			jobRunner,
+			decoderValidator,
+		),
+
+		apis.NewUserHandler(
+			ctrl.Log.WithName("UserHandler"),
+			*serverURL,
+			userRepo,
+			jobRunner,
 			decoderValidator,
 		),
 	}
@@ -291,3 +464,25 @@ func wireIdentityProvider(client client.Client, restConfig *rest.Config) authori
 	certInspector := authorization.NewCertInspector(restConfig)
 	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
 }
+
+func wireOIDCIdentityProvider(oidcConfig config.OIDCConfig, delegate authorization.IdentityProvider) *authorization.OIDCIdentityProvider {
+	issuers := []authorization.OIDCIssuer{}
+	for _, issuerConfig := range oidcConfig.Issuers {
+		keys, err := authorization.LoadJWKS(issuerConfig.JWKSPath)
+		if err != nil {
+			panic(fmt.Sprintf("could not load the keys of OIDC issuer %q: %v", issuerConfig.URL, err))
+		}
+
+		issuers = append(issuers, authorization.OIDCIssuer{
+			URL:            issuerConfig.URL,
+			Audience:       issuerConfig.Audience,
+			Keys:           keys,
+			UsernameClaim:  issuerConfig.UsernameClaim,
+			UsernamePrefix: issuerConfig.UsernamePrefix,
+			GroupsClaim:    issuerConfig.GroupsClaim,
+			GroupsPrefix:   issuerConfig.GroupsPrefix,
+		})
+	}
+
+	return authorization.NewOIDCIdentityProvider(issuers, delegate)
+}
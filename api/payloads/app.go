@@ -35,6 +35,7 @@ func (p AppCreate) ToAppCreateMessage() repositories.CreateAppMessage {
 		},
 	}
 	if p.Lifecycle != nil {
+		lifecycleBlock.Type = p.Lifecycle.Type
 		lifecycleBlock.Data.Stack = p.Lifecycle.Data.Stack
 		lifecycleBlock.Data.Buildpacks = p.Lifecycle.Data.Buildpacks
 	}
@@ -50,6 +51,38 @@ func (p AppCreate) ToAppCreateMessage() repositories.CreateAppMessage {
 	}
 }
 
+type AppPatch struct {
+	Name      *string         `json:"name" validate:"omitempty,min=1"`
+	Lifecycle *LifecyclePatch `json:"lifecycle"`
+	Metadata  MetadataPatch   `json:"metadata"`
+}
+
+type LifecyclePatch struct {
+	Type string              `json:"type" validate:"omitempty,oneof=buildpack"`
+	Data *LifecycleDataPatch `json:"data"`
+}
+
+type LifecycleDataPatch struct {
+	Buildpacks *[]string `json:"buildpacks"`
+	Stack      *string   `json:"stack"`
+}
+
+func (p AppPatch) ToMessage(appGUID, spaceGUID string) repositories.PatchAppMessage {
+	message := repositories.PatchAppMessage{
+		AppGUID:       appGUID,
+		SpaceGUID:     spaceGUID,
+		Name:          p.Name,
+		MetadataPatch: p.Metadata.toMessage(),
+	}
+
+	if p.Lifecycle != nil && p.Lifecycle.Data != nil {
+		message.Buildpacks = p.Lifecycle.Data.Buildpacks
+		message.Stack = p.Lifecycle.Data.Stack
+	}
+
+	return message
+}
+
 type AppSetCurrentDroplet struct {
 	Relationship `json:",inline" validate:"required"`
 }
@@ -59,18 +92,21 @@ type AppList struct {
 	GUIDs      *string `schema:"guids"`
 	SpaceGuids *string `schema:"space_guids"`
 	OrderBy    string  `schema:"order_by"`
+	LabelSelector
+	Pagination
 }
 
 func (a *AppList) ToMessage() repositories.ListAppsMessage {
 	return repositories.ListAppsMessage{
-		Names:      ParseArrayParam(a.Names),
-		Guids:      ParseArrayParam(a.GUIDs),
-		SpaceGuids: ParseArrayParam(a.SpaceGuids),
+		Names:         ParseArrayParam(a.Names),
+		Guids:         ParseArrayParam(a.GUIDs),
+		SpaceGuids:    ParseArrayParam(a.SpaceGuids),
+		LabelSelector: a.LabelSelector.Selector,
 	}
 }
 
 func (a *AppList) SupportedFilterKeys() []string {
-	return []string{"names", "guids", "space_guids", "order_by"}
+	return []string{"names", "guids", "space_guids", "order_by", "label_selector", "page", "per_page"}
 }
 
 type AppPatchEnvVars struct {
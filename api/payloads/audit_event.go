@@ -0,0 +1,99 @@
+package payloads
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AuditEventList struct {
+	Types               *string `schema:"types"`
+	TargetGUIDs         *string `schema:"target_guids"`
+	SpaceGUIDs          *string `schema:"space_guids"`
+	CreatedAts          *string `schema:"created_ats"`
+	CreatedAtsGreaterEq *string `schema:"created_ats[gte]"`
+	CreatedAtsGreater   *string `schema:"created_ats[gt]"`
+	CreatedAtsLessEq    *string `schema:"created_ats[lte]"`
+	CreatedAtsLess      *string `schema:"created_ats[lt]"`
+	OrderBy             string  `schema:"order_by"`
+	Pagination
+}
+
+// Validate checks the pagination parameters and that the created_ats filters are RFC3339 timestamps
+func (l *AuditEventList) Validate() error {
+	if err := l.Pagination.Validate(); err != nil {
+		return err
+	}
+
+	if l.OrderBy != "" && l.OrderBy != "created_at" && l.OrderBy != "-created_at" {
+		return apierrors.NewBadQueryParameterError(fmt.Errorf("invalid order_by %q", l.OrderBy), "Order by can only be: 'created_at'")
+	}
+
+	_, err := l.createdAtsFilter()
+	return err
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	createdAts, _ := l.createdAtsFilter()
+
+	return repositories.ListAuditEventsMessage{
+		Types:       ParseArrayParam(l.Types),
+		TargetGUIDs: ParseArrayParam(l.TargetGUIDs),
+		SpaceGUIDs:  ParseArrayParam(l.SpaceGUIDs),
+		CreatedAts:  createdAts,
+	}
+}
+
+// Descending reports whether the newest events should be listed first
+func (l *AuditEventList) Descending() bool {
+	return l.OrderBy == "-created_at"
+}
+
+func (l *AuditEventList) SupportedFilterKeys() []string {
+	return []string{"types", "target_guids", "space_guids", "created_ats", "order_by", "page", "per_page"}
+}
+
+func (l *AuditEventList) createdAtsFilter() (repositories.TimeFilter, error) {
+	filter := repositories.TimeFilter{}
+
+	for _, createdAt := range ParseArrayParam(l.CreatedAts) {
+		t, err := parseTimestamp(createdAt)
+		if err != nil {
+			return repositories.TimeFilter{}, err
+		}
+		filter.Equals = append(filter.Equals, t)
+	}
+
+	bounds := []struct {
+		param *string
+		bound **time.Time
+	}{
+		{l.CreatedAtsGreater, &filter.GreaterThan},
+		{l.CreatedAtsGreaterEq, &filter.GreaterThanOrEqual},
+		{l.CreatedAtsLess, &filter.LessThan},
+		{l.CreatedAtsLessEq, &filter.LessThanOrEqual},
+	}
+	for _, b := range bounds {
+		if b.param == nil {
+			continue
+		}
+
+		t, err := parseTimestamp(*b.param)
+		if err != nil {
+			return repositories.TimeFilter{}, err
+		}
+		*b.bound = &t
+	}
+
+	return filter, nil
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, apierrors.NewBadQueryParameterError(err, fmt.Sprintf("Invalid created_ats value %q: timestamps must be in RFC3339 format", value))
+	}
+	return t, nil
+}
@@ -4,6 +4,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 )
 
 type BuildCreate struct {
@@ -14,6 +15,26 @@ type BuildCreate struct {
 	Metadata        Metadata          `json:"metadata"`
 }
 
+type BuildList struct {
+	AppGUIDs     *string `schema:"app_guids"`
+	PackageGUIDs *string `schema:"package_guids"`
+	OrderBy      string  `schema:"order_by"`
+	LabelSelector
+	Pagination
+}
+
+func (l *BuildList) ToMessage() repositories.ListBuildsMessage {
+	return repositories.ListBuildsMessage{
+		AppGUIDs:      ParseArrayParam(l.AppGUIDs),
+		PackageGUIDs:  ParseArrayParam(l.PackageGUIDs),
+		LabelSelector: l.LabelSelector.Selector,
+	}
+}
+
+func (l *BuildList) SupportedFilterKeys() []string {
+	return []string{"app_guids", "package_guids", "order_by", "label_selector", "page", "per_page"}
+}
+
 func (c *BuildCreate) ToMessage(record repositories.PackageRecord) repositories.CreateBuildMessage {
 	toReturn := repositories.CreateBuildMessage{
 		AppGUID:         record.AppGUID,
@@ -38,5 +59,15 @@ func (c *BuildCreate) ToMessage(record repositories.PackageRecord) repositories.
 		},
 	}
 
+	// builds of docker packages have nothing to stage, they run the image of the package as is
+	if record.Type == string(v1alpha1.DockerPackage) {
+		toReturn.Lifecycle = repositories.Lifecycle{
+			Type: string(v1alpha1.DockerLifecycle),
+			Data: repositories.LifecycleData{
+				Buildpacks: []string{},
+			},
+		}
+	}
+
 	return toReturn
 }
@@ -2,8 +2,9 @@ package payloads
 
 type BuildpackList struct {
 	OrderBy string `schema:"order_by"`
+	Pagination
 }
 
 func (d *BuildpackList) SupportedQueryParams() []string {
-	return []string{"order_by"}
+	return []string{"order_by", "page", "per_page"}
 }
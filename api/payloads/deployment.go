@@ -0,0 +1,65 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const defaultDeploymentStrategy = "rolling"
+
+type DeploymentCreate struct {
+	Droplet       *RelationshipData        `json:"droplet"`
+	Revision      *RelationshipData        `json:"revision"`
+	Strategy      string                   `json:"strategy" validate:"omitempty,oneof=rolling"`
+	Relationships *DeploymentRelationships `json:"relationships" validate:"required"`
+	Metadata      Metadata                 `json:"metadata"`
+}
+
+type DeploymentRelationships struct {
+	App *Relationship `json:"app" validate:"required"`
+}
+
+// ToMessage deploys the given droplet, or redeploys the current droplet of the app when there is none.
+// Deployments of a revision are given the droplet of the revision by the caller.
+func (p DeploymentCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDeploymentMessage {
+	message := repositories.CreateDeploymentMessage{
+		AppGUID:     appRecord.GUID,
+		SpaceGUID:   appRecord.SpaceGUID,
+		DropletGUID: appRecord.DropletGUID,
+		Strategy:    defaultDeploymentStrategy,
+		Labels:      p.Metadata.Labels,
+		Annotations: p.Metadata.Annotations,
+	}
+
+	if p.Droplet != nil {
+		message.DropletGUID = p.Droplet.GUID
+	}
+	if p.Revision != nil {
+		message.RevisionGUID = p.Revision.GUID
+	}
+	if p.Strategy != "" {
+		message.Strategy = p.Strategy
+	}
+
+	return message
+}
+
+type DeploymentList struct {
+	AppGUIDs      *string `schema:"app_guids"`
+	States        *string `schema:"states"`
+	StatusValues  *string `schema:"status_values"`
+	StatusReasons *string `schema:"status_reasons"`
+	Pagination
+}
+
+func (l *DeploymentList) ToMessage() repositories.ListDeploymentsMessage {
+	return repositories.ListDeploymentsMessage{
+		AppGUIDs:      ParseArrayParam(l.AppGUIDs),
+		States:        ParseArrayParam(l.States),
+		StatusValues:  ParseArrayParam(l.StatusValues),
+		StatusReasons: ParseArrayParam(l.StatusReasons),
+	}
+}
+
+func (l *DeploymentList) SupportedFilterKeys() []string {
+	return []string{"app_guids", "states", "status_values", "status_reasons", "page", "per_page"}
+}
@@ -6,6 +6,7 @@ import (
 
 type DomainList struct {
 	Names *string `schema:"names"`
+	Pagination
 }
 
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
@@ -15,5 +16,5 @@ func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 }
 
 func (d *DomainList) SupportedFilterKeys() []string {
-	return []string{"names"}
+	return []string{"names", "page", "per_page"}
 }
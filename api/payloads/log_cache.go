@@ -0,0 +1,39 @@
+package payloads
+
+const (
+	LogEnvelopeType = "LOG"
+	AnyEnvelopeType = "ANY"
+
+	DefaultLogLimit int64 = 100
+)
+
+// LogRead mirrors the query parameters of the log-cache /api/v1/read endpoint
+type LogRead struct {
+	StartTime     int64    `schema:"start_time"`
+	EnvelopeTypes []string `schema:"envelope_types" validate:"dive,oneof=LOG COUNTER GAUGE TIMER EVENT ANY"`
+	Limit         int64    `schema:"limit" validate:"gte=0,lte=1000"`
+	Descending    bool     `schema:"descending"`
+}
+
+// IncludesLogs returns whether log envelopes were requested. Log-cache returns all envelope types when none are given.
+func (l LogRead) IncludesLogs() bool {
+	if len(l.EnvelopeTypes) == 0 {
+		return true
+	}
+
+	for _, envelopeType := range l.EnvelopeTypes {
+		if envelopeType == LogEnvelopeType || envelopeType == AnyEnvelopeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l LogRead) GetLimit() int64 {
+	if l.Limit == 0 {
+		return DefaultLogLimit
+	}
+
+	return l.Limit
+}
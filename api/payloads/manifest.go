@@ -9,7 +9,7 @@ import (
 
 type Manifest struct {
 	Version      int                   `yaml:"version"`
-	Applications []ManifestApplication `yaml:"applications" validate:"max=1,dive"`
+	Applications []ManifestApplication `yaml:"applications" validate:"unique=Name,dive"`
 }
 
 type ManifestApplication struct {
@@ -18,6 +18,7 @@ type ManifestApplication struct {
 	Processes    []ManifestApplicationProcess `yaml:"processes" validate:"dive"`
 	DefaultRoute bool                         `yaml:"default-route"`
 	Routes       []ManifestRoute              `yaml:"routes" validate:"dive"`
+	Sidecars     []ManifestApplicationSidecar `yaml:"sidecars" validate:"unique=Name,dive"`
 }
 
 type ManifestApplicationProcess struct {
@@ -32,6 +33,13 @@ type ManifestApplicationProcess struct {
 	Timeout                      *int64  `yaml:"timeout"`
 }
 
+type ManifestApplicationSidecar struct {
+	Name         string   `yaml:"name" validate:"required,dns_rfc1035_label"`
+	Command      string   `yaml:"command" validate:"required"`
+	ProcessTypes []string `yaml:"process_types" validate:"required,min=1,dive,required"`
+	Memory       *string  `yaml:"memory" validate:"megabytestring"`
+}
+
 type ManifestRoute struct {
 	Route *string `yaml:"route" validate:"route"`
 }
@@ -155,3 +163,34 @@ func normalizeHealthCheckType(healthCheckType string) string {
 		return healthCheckType
 	}
 }
+
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	message := repositories.CreateSidecarMessage{
+		Name:         s.Name,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+	}
+	if s.Memory != nil {
+		// error ignored intentionally, since the manifest yaml is validated in handlers
+		memoryMB, _ := bytefmt.ToMegabytes(*s.Memory)
+		message.MemoryMB = int64(memoryMB)
+	}
+	return message
+}
+
+func (s ManifestApplicationSidecar) ToSidecarPatchMessage(sidecarGUID, spaceGUID string) repositories.PatchSidecarMessage {
+	message := repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		SpaceGUID:    spaceGUID,
+		Command:      &s.Command,
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		memoryMB, _ := bytefmt.ToMegabytes(*s.Memory)
+		int64MMB := int64(memoryMB)
+		message.MemoryMB = &int64MMB
+	}
+	return message
+}
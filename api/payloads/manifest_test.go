@@ -243,6 +243,62 @@ var _ = Describe("ManifestApplicationProcess", func() {
 	})
 })
 
+var _ = Describe("ManifestApplicationSidecar", func() {
+	var sidecarInfo ManifestApplicationSidecar
+
+	BeforeEach(func() {
+		sidecarInfo = ManifestApplicationSidecar{
+			Name:         "apm-agent",
+			Command:      "./apm-agent",
+			ProcessTypes: []string{"web"},
+		}
+	})
+
+	Describe("ToSidecarCreateMessage", func() {
+		It("returns a CreateSidecarMessage without a memory limit", func() {
+			Expect(sidecarInfo.ToSidecarCreateMessage("app-guid", "space-guid")).To(Equal(repositories.CreateSidecarMessage{
+				Name:         "apm-agent",
+				Command:      "./apm-agent",
+				ProcessTypes: []string{"web"},
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+			}))
+		})
+
+		When("Memory is specified", func() {
+			BeforeEach(func() {
+				sidecarInfo.Memory = stringPointer("1G")
+			})
+
+			It("returns a message with MemoryMB set to the parsed value", func() {
+				Expect(sidecarInfo.ToSidecarCreateMessage("app-guid", "space-guid").MemoryMB).To(BeEquivalentTo(1024))
+			})
+		})
+	})
+
+	Describe("ToSidecarPatchMessage", func() {
+		It("returns a PatchSidecarMessage that leaves the name and memory limit alone", func() {
+			message := sidecarInfo.ToSidecarPatchMessage("sidecar-guid", "space-guid")
+			Expect(message.GUID).To(Equal("sidecar-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.Name).To(BeNil())
+			Expect(message.Command).To(PointTo(Equal("./apm-agent")))
+			Expect(message.ProcessTypes).To(Equal([]string{"web"}))
+			Expect(message.MemoryMB).To(BeNil())
+		})
+
+		When("Memory is specified", func() {
+			BeforeEach(func() {
+				sidecarInfo.Memory = stringPointer("64M")
+			})
+
+			It("returns a message with MemoryMB set to the parsed value", func() {
+				Expect(sidecarInfo.ToSidecarPatchMessage("sidecar-guid", "space-guid").MemoryMB).To(PointTo(BeEquivalentTo(64)))
+			})
+		})
+	})
+})
+
 func stringPointer(s string) *string {
 	return &s
 }
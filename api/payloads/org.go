@@ -16,3 +16,16 @@ func (p OrgCreate) ToMessage() repositories.CreateOrgMessage {
 		Annotations: p.Metadata.Annotations,
 	}
 }
+
+type OrgPatch struct {
+	Name     *string       `json:"name" validate:"omitempty,min=1"`
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMessage {
+	return repositories.PatchOrgMessage{
+		GUID:          orgGUID,
+		Name:          p.Name,
+		MetadataPatch: p.Metadata.toMessage(),
+	}
+}
@@ -6,11 +6,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 )
 
 type PackageCreate struct {
-	Type          string                `json:"type" validate:"required,oneof='bits'"`
+	Type          string                `json:"type" validate:"required,oneof='bits' 'docker'"`
 	Relationships *PackageRelationships `json:"relationships" validate:"required"`
+	Data          *PackageData          `json:"data" validate:"required_if=Type docker"`
+	Metadata      Metadata              `json:"metadata"`
+}
+
+type PackageData struct {
+	Image    string  `json:"image" validate:"required"`
+	Username *string `json:"username" validate:"required_with=Password"`
+	Password *string `json:"password" validate:"required_with=Username"`
 }
 
 type PackageRelationships struct {
@@ -28,6 +37,22 @@ func (m PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 			Name:       record.GUID,
 			UID:        record.EtcdUID,
 		},
+		Data:        m.Data.toMessage(m.Type),
+		Labels:      m.Metadata.Labels,
+		Annotations: m.Metadata.Annotations,
+	}
+}
+
+func (d *PackageData) toMessage(packageType string) *repositories.PackageData {
+	// only docker packages reference an image, the one of bits packages is the result of the upload
+	if d == nil || packageType != string(v1alpha1.DockerPackage) {
+		return nil
+	}
+
+	return &repositories.PackageData{
+		Image:    d.Image,
+		Username: d.Username,
+		Password: d.Password,
 	}
 }
 
@@ -35,9 +60,8 @@ type PackageListQueryParameters struct {
 	AppGUIDs *string `schema:"app_guids"`
 	States   *string `schema:"states"`
 	OrderBy  string  `schema:"order_by"`
-
-	// Below parameters are ignored, but must be included to ignore as query parameters
-	PerPage string `schema:"per_page"`
+	LabelSelector
+	Pagination
 }
 
 func (p *PackageListQueryParameters) ToMessage() repositories.ListPackagesMessage {
@@ -52,17 +76,18 @@ func (p *PackageListQueryParameters) ToMessage() repositories.ListPackagesMessag
 		States:          ParseArrayParam(p.States),
 		SortBy:          strings.TrimPrefix(p.OrderBy, "-"),
 		DescendingOrder: descendingOrder,
+		LabelSelector:   p.LabelSelector.Selector,
 	}
 }
 
 func (p *PackageListQueryParameters) SupportedQueryParameters() []string {
-	return []string{"app_guids", "order_by", "per_page", "states"}
+	return []string{"app_guids", "label_selector", "order_by", "page", "per_page", "states"}
 }
 
 type PackageListDropletsQueryParameters struct {
-	// Below parameters are ignored, but must be included to ignore as query parameters
-	States  string `schema:"states"`
-	PerPage string `schema:"per_page"`
+	// Below parameter is ignored, but must be included to ignore as query parameter
+	States string `schema:"states"`
+	Pagination
 }
 
 func (p *PackageListDropletsQueryParameters) ToMessage(packageGUIDs []string) repositories.ListDropletsMessage {
@@ -72,5 +97,5 @@ func (p *PackageListDropletsQueryParameters) ToMessage(packageGUIDs []string) re
 }
 
 func (p *PackageListDropletsQueryParameters) SupportedQueryParameters() []string {
-	return []string{"states", "per_page"}
+	return []string{"states", "page", "per_page"}
 }
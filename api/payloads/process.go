@@ -36,16 +36,19 @@ func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 
 type ProcessList struct {
 	AppGUIDs *string `schema:"app_guids"`
+	LabelSelector
+	Pagination
 }
 
 func (p *ProcessList) ToMessage() repositories.ListProcessesMessage {
 	return repositories.ListProcessesMessage{
-		AppGUIDs: ParseArrayParam(p.AppGUIDs),
+		AppGUIDs:      ParseArrayParam(p.AppGUIDs),
+		LabelSelector: p.LabelSelector.Selector,
 	}
 }
 
 func (p *ProcessList) SupportedFilterKeys() []string {
-	return []string{"app_guids"}
+	return []string{"app_guids", "label_selector", "page", "per_page"}
 }
 
 func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
@@ -0,0 +1,199 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// QuotaLimit is a limit of an org or space quota. A null limit is unlimited. Set tells apart the limits that are
+// null from the ones that are not mentioned, which PATCH requests leave as they are.
+type QuotaLimit struct {
+	Set   bool
+	Value *int64
+}
+
+func (l *QuotaLimit) UnmarshalJSON(data []byte) error {
+	l.Set = true
+	l.Value = nil
+
+	return json.Unmarshal(data, &l.Value)
+}
+
+func (l QuotaLimit) toMessage() repositories.QuotaLimitPatch {
+	return repositories.QuotaLimitPatch{
+		Set:   l.Set,
+		Value: l.Value,
+	}
+}
+
+// QuotaLimits are the limits shared by org and space quotas. Limits are validated as their Value, see wireValidator.
+type QuotaLimits struct {
+	Apps     QuotaAppsLimits     `json:"apps"`
+	Services QuotaServicesLimits `json:"services"`
+	Routes   QuotaRoutesLimits   `json:"routes"`
+}
+
+type QuotaAppsLimits struct {
+	TotalMemoryInMB      QuotaLimit `json:"total_memory_in_mb" validate:"omitempty,gte=0"`
+	PerProcessMemoryInMB QuotaLimit `json:"per_process_memory_in_mb" validate:"omitempty,gte=0"`
+	TotalInstances       QuotaLimit `json:"total_instances" validate:"omitempty,gte=0"`
+}
+
+type QuotaServicesLimits struct {
+	// PaidServicesAllowed is accepted for compatibility only. Service plans have no price, so none of them are paid.
+	PaidServicesAllowed   *bool      `json:"paid_services_allowed"`
+	TotalServiceInstances QuotaLimit `json:"total_service_instances" validate:"omitempty,gte=0"`
+}
+
+type QuotaRoutesLimits struct {
+	TotalRoutes QuotaLimit `json:"total_routes" validate:"omitempty,gte=0"`
+}
+
+func (l QuotaLimits) toMessage() repositories.QuotaLimits {
+	return repositories.QuotaLimits{
+		TotalMemoryMB:    l.Apps.TotalMemoryInMB.Value,
+		InstanceMemoryMB: l.Apps.PerProcessMemoryInMB.Value,
+		AppInstances:     l.Apps.TotalInstances.Value,
+		Routes:           l.Routes.TotalRoutes.Value,
+		ServiceInstances: l.Services.TotalServiceInstances.Value,
+	}
+}
+
+func (l QuotaLimits) toPatchMessage() repositories.QuotaLimitsPatch {
+	return repositories.QuotaLimitsPatch{
+		TotalMemoryMB:    l.Apps.TotalMemoryInMB.toMessage(),
+		InstanceMemoryMB: l.Apps.PerProcessMemoryInMB.toMessage(),
+		AppInstances:     l.Apps.TotalInstances.toMessage(),
+		Routes:           l.Routes.TotalRoutes.toMessage(),
+		ServiceInstances: l.Services.TotalServiceInstances.toMessage(),
+	}
+}
+
+// ToManyRelationship is a relationship to several resources, such as the orgs a quota is applied to
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data" validate:"dive"`
+}
+
+func (r ToManyRelationship) GUIDs() []string {
+	guids := []string{}
+	for _, data := range r.Data {
+		guids = append(guids, data.GUID)
+	}
+
+	return guids
+}
+
+type OrgQuotaCreate struct {
+	Name          string                 `json:"name" validate:"required"`
+	Relationships *OrgQuotaRelationships `json:"relationships"`
+	QuotaLimits
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+func (p OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	message := repositories.CreateOrgQuotaMessage{
+		Name:     p.Name,
+		Limits:   p.QuotaLimits.toMessage(),
+		OrgGUIDs: []string{},
+	}
+	if p.Relationships != nil {
+		message.OrgGUIDs = p.Relationships.Organizations.GUIDs()
+	}
+
+	return message
+}
+
+type OrgQuotaPatch struct {
+	Name *string `json:"name" validate:"omitempty,min=1"`
+	QuotaLimits
+}
+
+func (p OrgQuotaPatch) ToMessage(guid string) repositories.PatchOrgQuotaMessage {
+	return repositories.PatchOrgQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.QuotaLimits.toPatchMessage(),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs             *string `schema:"guids"`
+	Names             *string `schema:"names"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+	Pagination
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:    ParseArrayParam(l.GUIDs),
+		Names:    ParseArrayParam(l.Names),
+		OrgGUIDs: ParseArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *OrgQuotaList) SupportedFilterKeys() []string {
+	return []string{"guids", "names", "organization_guids", "page", "per_page"}
+}
+
+type SpaceQuotaCreate struct {
+	Name          string                  `json:"name" validate:"required"`
+	Relationships SpaceQuotaRelationships `json:"relationships" validate:"required"`
+	QuotaLimits
+}
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship        `json:"organization" validate:"required"`
+	Spaces       *ToManyRelationship `json:"spaces"`
+}
+
+func (p SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	message := repositories.CreateSpaceQuotaMessage{
+		Name:       p.Name,
+		Limits:     p.QuotaLimits.toMessage(),
+		OrgGUID:    p.Relationships.Organization.Data.GUID,
+		SpaceGUIDs: []string{},
+	}
+	if p.Relationships.Spaces != nil {
+		message.SpaceGUIDs = p.Relationships.Spaces.GUIDs()
+	}
+
+	return message
+}
+
+type SpaceQuotaPatch struct {
+	Name *string `json:"name" validate:"omitempty,min=1"`
+	QuotaLimits
+}
+
+func (p SpaceQuotaPatch) ToMessage(guid string) repositories.PatchSpaceQuotaMessage {
+	return repositories.PatchSpaceQuotaMessage{
+		GUID:   guid,
+		Name:   p.Name,
+		Limits: p.QuotaLimits.toPatchMessage(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             *string `schema:"guids"`
+	Names             *string `schema:"names"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+	SpaceGUIDs        *string `schema:"space_guids"`
+	Pagination
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:      ParseArrayParam(l.GUIDs),
+		Names:      ParseArrayParam(l.Names),
+		OrgGUIDs:   ParseArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs: ParseArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedFilterKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "page", "per_page"}
+}
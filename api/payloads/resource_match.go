@@ -0,0 +1,50 @@
+package payloads
+
+import (
+	"os"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// defaultResourceMode is used for resources that are sent without a mode
+const defaultResourceMode = 0o644
+
+type ResourceMatches struct {
+	Resources []ResourceMatch `json:"resources" validate:"dive"`
+}
+
+type ResourceMatch struct {
+	Checksum    ResourceChecksum `json:"checksum"`
+	SizeInBytes int64            `json:"size_in_bytes" validate:"gte=0"`
+	Path        string           `json:"path" validate:"required"`
+	Mode        string           `json:"mode" validate:"omitempty,filemode"`
+}
+
+type ResourceChecksum struct {
+	Value string `json:"value" validate:"required"`
+}
+
+func (m ResourceMatches) ToMessage() []repositories.PackageResource {
+	resources := make([]repositories.PackageResource, 0, len(m.Resources))
+	for _, resource := range m.Resources {
+		resources = append(resources, resource.toPackageResource())
+	}
+	return resources
+}
+
+func (m ResourceMatch) toPackageResource() repositories.PackageResource {
+	mode := os.FileMode(defaultResourceMode)
+	if m.Mode != "" {
+		// error ignored intentionally, since the mode is validated in handlers
+		parsedMode, _ := strconv.ParseUint(m.Mode, 8, 32)
+		mode = os.FileMode(parsedMode).Perm()
+	}
+
+	return repositories.PackageResource{
+		SHA1:        m.Checksum.Value,
+		SizeInBytes: m.SizeInBytes,
+		Path:        m.Path,
+		Mode:        mode,
+	}
+}
@@ -0,0 +1,28 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RevisionList struct {
+	Versions *string `schema:"versions"`
+	Pagination
+}
+
+func (l *RevisionList) ToMessage() repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		Versions: ParseArrayParam(l.Versions),
+	}
+}
+
+func (l *RevisionList) SupportedFilterKeys() []string {
+	return []string{"versions", "page", "per_page"}
+}
+
+type DeployedRevisionList struct {
+	Pagination
+}
+
+func (l *DeployedRevisionList) SupportedFilterKeys() []string {
+	return []string{"page", "per_page"}
+}
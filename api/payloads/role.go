@@ -1,6 +1,9 @@
 package payloads
 
 import (
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	rbacv1 "k8s.io/api/rbac/v1"
 )
@@ -20,8 +23,9 @@ type UserRelationshipData struct {
 }
 
 type RoleRelationships struct {
-	User                     *UserRelationship `json:"user" validate:"required_without=KubernetesServiceAccount"`
-	KubernetesServiceAccount *Relationship     `json:"kubernetesServiceAccount" validate:"required_without=User"`
+	User                     *UserRelationship `json:"user" validate:"required_without_all=KubernetesServiceAccount KubernetesGroup"`
+	KubernetesServiceAccount *Relationship     `json:"kubernetesServiceAccount" validate:"required_without_all=User KubernetesGroup"`
+	KubernetesGroup          *Relationship     `json:"kubernetesGroup" validate:"required_without_all=User KubernetesServiceAccount"`
 	Space                    *Relationship     `json:"space"`
 	Organization             *Relationship     `json:"organization"`
 }
@@ -45,10 +49,72 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 		if p.Relationships.User.Data.GUID != "" {
 			record.User = p.Relationships.User.Data.GUID
 		}
-	} else {
+	} else if p.Relationships.KubernetesServiceAccount != nil {
 		record.Kind = rbacv1.ServiceAccountKind
 		record.User = p.Relationships.KubernetesServiceAccount.Data.GUID
+	} else {
+		record.Kind = rbacv1.GroupKind
+		record.User = p.Relationships.KubernetesGroup.Data.GUID
 	}
 
 	return record
 }
+
+const (
+	RoleIncludeUser         = "user"
+	RoleIncludeSpace        = "space"
+	RoleIncludeOrganization = "organization"
+)
+
+type RoleList struct {
+	GUIDs             *string `schema:"guids"`
+	Types             *string `schema:"types"`
+	SpaceGUIDs        *string `schema:"space_guids"`
+	OrganizationGUIDs *string `schema:"organization_guids"`
+	UserGUIDs         *string `schema:"user_guids"`
+	Include           *string `schema:"include"`
+	Pagination
+}
+
+// Validate checks the pagination parameters and the resources to include
+func (l *RoleList) Validate() error {
+	if err := l.Pagination.Validate(); err != nil {
+		return err
+	}
+
+	for _, include := range ParseArrayParam(l.Include) {
+		if include != RoleIncludeUser && include != RoleIncludeSpace && include != RoleIncludeOrganization {
+			return apierrors.NewBadQueryParameterError(
+				fmt.Errorf("invalid include %q", include),
+				"Invalid included resource: 'include' must be one of 'user', 'space', 'organization'",
+			)
+		}
+	}
+
+	return nil
+}
+
+// Includes reports whether the resource related to the roles should be included in the response
+func (l *RoleList) Includes(resource string) bool {
+	for _, include := range ParseArrayParam(l.Include) {
+		if include == resource {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *RoleList) ToMessage() repositories.ListRolesMessage {
+	return repositories.ListRolesMessage{
+		GUIDs:      ParseArrayParam(l.GUIDs),
+		Types:      ParseArrayParam(l.Types),
+		SpaceGUIDs: ParseArrayParam(l.SpaceGUIDs),
+		OrgGUIDs:   ParseArrayParam(l.OrganizationGUIDs),
+		UserGUIDs:  ParseArrayParam(l.UserGUIDs),
+	}
+}
+
+func (l *RoleList) SupportedFilterKeys() []string {
+	return []string{"guids", "types", "space_guids", "organization_guids", "user_guids", "include", "page", "per_page"}
+}
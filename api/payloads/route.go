@@ -29,24 +29,39 @@ func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.
 	}
 }
 
+type RoutePatch struct {
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (p RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRouteMessage {
+	return repositories.PatchRouteMessage{
+		GUID:          routeGUID,
+		SpaceGUID:     spaceGUID,
+		MetadataPatch: p.Metadata.toMessage(),
+	}
+}
+
 type RouteList struct {
 	AppGUIDs    *string `schema:"app_guids"`
 	SpaceGUIDs  *string `schema:"space_guids"`
 	DomainGUIDs *string `schema:"domain_guids"`
 	Hosts       *string `schema:"hosts"`
 	Paths       *string `schema:"paths"`
+	LabelSelector
+	Pagination
 }
 
 func (p *RouteList) ToMessage() repositories.ListRoutesMessage {
 	return repositories.ListRoutesMessage{
-		AppGUIDs:    ParseArrayParam(p.AppGUIDs),
-		SpaceGUIDs:  ParseArrayParam(p.SpaceGUIDs),
-		DomainGUIDs: ParseArrayParam(p.DomainGUIDs),
-		Hosts:       ParseArrayParam(p.Hosts),
-		Paths:       ParseArrayParam(p.Paths),
+		AppGUIDs:      ParseArrayParam(p.AppGUIDs),
+		SpaceGUIDs:    ParseArrayParam(p.SpaceGUIDs),
+		DomainGUIDs:   ParseArrayParam(p.DomainGUIDs),
+		Hosts:         ParseArrayParam(p.Hosts),
+		Paths:         ParseArrayParam(p.Paths),
+		LabelSelector: p.LabelSelector.Selector,
 	}
 }
 
 func (p *RouteList) SupportedFilterKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths"}
+	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "label_selector", "page", "per_page"}
 }
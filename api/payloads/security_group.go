@@ -0,0 +1,126 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// SecurityGroupRule is validated as a whole with the rules of the controllers, see wireValidator
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports"`
+	Type        *int   `json:"type"`
+	Code        *int   `json:"code"`
+	Description string `json:"description"`
+	Log         bool   `json:"log"`
+}
+
+func (r SecurityGroupRule) toMessage() repositories.SecurityGroupRule {
+	return repositories.SecurityGroupRule{
+		Protocol:    r.Protocol,
+		Destination: r.Destination,
+		Ports:       r.Ports,
+		Type:        r.Type,
+		Code:        r.Code,
+		Description: r.Description,
+		Log:         r.Log,
+	}
+}
+
+func securityGroupRulesToMessage(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	messages := []repositories.SecurityGroupRule{}
+	for _, rule := range rules {
+		messages = append(messages, rule.toMessage())
+	}
+
+	return messages
+}
+
+type SecurityGroupCreate struct {
+	Name            string                      `json:"name" validate:"required"`
+	GloballyEnabled *SecurityGroupWorkloads     `json:"globally_enabled"`
+	Rules           []SecurityGroupRule         `json:"rules" validate:"dive"`
+	Relationships   *SecurityGroupRelationships `json:"relationships"`
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+func (p SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
+	message := repositories.CreateSecurityGroupMessage{
+		Name:              p.Name,
+		Rules:             securityGroupRulesToMessage(p.Rules),
+		RunningSpaceGUIDs: []string{},
+		StagingSpaceGUIDs: []string{},
+	}
+	if p.GloballyEnabled != nil {
+		message.GloballyEnabledRunning = p.GloballyEnabled.Running
+		message.GloballyEnabledStaging = p.GloballyEnabled.Staging
+	}
+	if p.Relationships != nil {
+		message.RunningSpaceGUIDs = p.Relationships.RunningSpaces.GUIDs()
+		message.StagingSpaceGUIDs = p.Relationships.StagingSpaces.GUIDs()
+	}
+
+	return message
+}
+
+// SecurityGroupPatch replaces the rules of the security group when they are set
+type SecurityGroupPatch struct {
+	Name            *string                      `json:"name" validate:"omitempty,min=1"`
+	GloballyEnabled *SecurityGroupWorkloadsPatch `json:"globally_enabled"`
+	Rules           *[]SecurityGroupRule         `json:"rules" validate:"omitempty,dive"`
+}
+
+type SecurityGroupWorkloadsPatch struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+func (p SecurityGroupPatch) ToMessage(guid string) repositories.PatchSecurityGroupMessage {
+	message := repositories.PatchSecurityGroupMessage{
+		GUID: guid,
+		Name: p.Name,
+	}
+	if p.GloballyEnabled != nil {
+		message.GloballyEnabledRunning = p.GloballyEnabled.Running
+		message.GloballyEnabledStaging = p.GloballyEnabled.Staging
+	}
+	if p.Rules != nil {
+		message.Rules = securityGroupRulesToMessage(*p.Rules)
+	}
+
+	return message
+}
+
+type SecurityGroupList struct {
+	GUIDs                  *string `schema:"guids"`
+	Names                  *string `schema:"names"`
+	GloballyEnabledRunning *bool   `schema:"globally_enabled_running"`
+	GloballyEnabledStaging *bool   `schema:"globally_enabled_staging"`
+	RunningSpaceGUIDs      *string `schema:"running_space_guids"`
+	StagingSpaceGUIDs      *string `schema:"staging_space_guids"`
+	Pagination
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupsMessage {
+	return repositories.ListSecurityGroupsMessage{
+		GUIDs:                  ParseArrayParam(l.GUIDs),
+		Names:                  ParseArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      ParseArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      ParseArrayParam(l.StagingSpaceGUIDs),
+	}
+}
+
+func (l *SecurityGroupList) SupportedFilterKeys() []string {
+	return []string{"guids", "names", "globally_enabled_running", "globally_enabled_staging", "running_space_guids", "staging_space_guids", "page", "per_page"}
+}
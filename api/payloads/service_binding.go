@@ -8,6 +8,7 @@ type ServiceBindingCreate struct {
 	Relationships *ServiceBindingRelationships `json:"relationships" validate:"required"`
 	Type          string                       `json:"type" validate:"oneof=app"`
 	Name          *string                      `json:"name"`
+	Metadata      Metadata                     `json:"metadata"`
 }
 
 type ServiceBindingRelationships struct {
@@ -21,6 +22,8 @@ func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateSer
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
 		AppGUID:             p.Relationships.App.Data.GUID,
 		SpaceGUID:           spaceGUID,
+		Labels:              p.Metadata.Labels,
+		Annotations:         p.Metadata.Annotations,
 	}
 }
 
@@ -29,6 +32,7 @@ type ServiceBindingList struct {
 	ServiceInstanceGUIDs *string `schema:"service_instance_guids"`
 	Include              *string `schema:"include" validate:"oneof=app"`
 	Type                 *string `schema:"type" validate:"oneof=app"`
+	Pagination
 }
 
 func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage {
@@ -39,5 +43,5 @@ func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage
 }
 
 func (l *ServiceBindingList) SupportedFilterKeys() []string {
-	return []string{"app_guids, service_instance_guids, include, type"}
+	return []string{"app_guids", "service_instance_guids", "include", "type", "page", "per_page"}
 }
@@ -0,0 +1,48 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServiceBrokerCreate struct {
+	Name           string                      `json:"name" validate:"required"`
+	URL            string                      `json:"url" validate:"required,url"`
+	Authentication ServiceBrokerAuthentication `json:"authentication" validate:"required"`
+	Metadata       Metadata                    `json:"metadata"`
+}
+
+type ServiceBrokerAuthentication struct {
+	Type        string                   `json:"type" validate:"required,oneof=basic"`
+	Credentials ServiceBrokerCredentials `json:"credentials" validate:"required"`
+}
+
+type ServiceBrokerCredentials struct {
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (p ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage {
+	return repositories.CreateServiceBrokerMessage{
+		Name:        p.Name,
+		URL:         p.URL,
+		Username:    p.Authentication.Credentials.Username,
+		Password:    p.Authentication.Credentials.Password,
+		Labels:      p.Metadata.Labels,
+		Annotations: p.Metadata.Annotations,
+	}
+}
+
+type ServiceBrokerList struct {
+	Names *string `schema:"names"`
+	Pagination
+}
+
+func (l *ServiceBrokerList) ToMessage() repositories.ListServiceBrokersMessage {
+	return repositories.ListServiceBrokersMessage{
+		Names: ParseArrayParam(l.Names),
+	}
+}
+
+func (l *ServiceBrokerList) SupportedFilterKeys() []string {
+	return []string{"names", "page", "per_page"}
+}
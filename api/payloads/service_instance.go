@@ -8,7 +8,7 @@ import (
 
 type ServiceInstanceCreate struct {
 	Name          string                       `json:"name" validate:"required"`
-	Type          string                       `json:"type" validate:"required,oneof=user-provided"`
+	Type          string                       `json:"type" validate:"required,oneof=user-provided managed"`
 	Tags          []string                     `json:"tags" validate:"serviceinstancetaglength"`
 	Credentials   map[string]string            `json:"credentials"`
 	Relationships ServiceInstanceRelationships `json:"relationships" validate:"required"`
@@ -16,11 +16,12 @@ type ServiceInstanceCreate struct {
 }
 
 type ServiceInstanceRelationships struct {
-	Space Relationship `json:"space" validate:"required"`
+	Space       Relationship  `json:"space" validate:"required"`
+	ServicePlan *Relationship `json:"service_plan"`
 }
 
 func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.CreateServiceInstanceMessage {
-	return repositories.CreateServiceInstanceMessage{
+	message := repositories.CreateServiceInstanceMessage{
 		Name:        p.Name,
 		SpaceGUID:   p.Relationships.Space.Data.GUID,
 		Credentials: p.Credentials,
@@ -29,23 +30,49 @@ func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.Cre
 		Labels:      p.Metadata.Labels,
 		Annotations: p.Metadata.Annotations,
 	}
+	if p.Relationships.ServicePlan != nil {
+		message.ServicePlanGUID = p.Relationships.ServicePlan.Data.GUID
+	}
+
+	return message
+}
+
+type ServiceInstancePatch struct {
+	Name        *string            `json:"name" validate:"omitempty,min=1"`
+	Tags        *[]string          `json:"tags" validate:"omitempty,serviceinstancetaglength"`
+	Credentials *map[string]string `json:"credentials"`
+	Metadata    MetadataPatch      `json:"metadata"`
+}
+
+func (p ServiceInstancePatch) ToMessage(serviceInstanceGUID, spaceGUID string) repositories.PatchServiceInstanceMessage {
+	return repositories.PatchServiceInstanceMessage{
+		GUID:          serviceInstanceGUID,
+		SpaceGUID:     spaceGUID,
+		Name:          p.Name,
+		Tags:          p.Tags,
+		Credentials:   p.Credentials,
+		MetadataPatch: p.Metadata.toMessage(),
+	}
 }
 
 type ServiceInstanceList struct {
 	Names      *string `schema:"names"`
 	SpaceGuids *string `schema:"space_guids"`
 	OrderBy    string  `schema:"order_by"`
+	LabelSelector
+	Pagination
 }
 
 func (l *ServiceInstanceList) ToMessage() repositories.ListServiceInstanceMessage {
 	return repositories.ListServiceInstanceMessage{
 		Names:           ParseArrayParam(l.Names),
 		SpaceGuids:      ParseArrayParam(l.SpaceGuids),
+		LabelSelector:   l.LabelSelector.Selector,
 		OrderBy:         strings.TrimPrefix(l.OrderBy, "-"),
 		DescendingOrder: strings.HasPrefix(l.OrderBy, "-"),
 	}
 }
 
 func (l *ServiceInstanceList) SupportedFilterKeys() []string {
-	return []string{"names", "space_guids", "fields", "order_by", "per_page"}
+	return []string{"names", "space_guids", "fields", "order_by", "label_selector", "page", "per_page"}
 }
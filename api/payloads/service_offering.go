@@ -0,0 +1,22 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServiceOfferingList struct {
+	Names              *string `schema:"names"`
+	ServiceBrokerGUIDs *string `schema:"service_broker_guids"`
+	Pagination
+}
+
+func (l *ServiceOfferingList) ToMessage() repositories.ListServiceOfferingsMessage {
+	return repositories.ListServiceOfferingsMessage{
+		Names:              ParseArrayParam(l.Names),
+		ServiceBrokerGUIDs: ParseArrayParam(l.ServiceBrokerGUIDs),
+	}
+}
+
+func (l *ServiceOfferingList) SupportedFilterKeys() []string {
+	return []string{"names", "service_broker_guids", "page", "per_page"}
+}
@@ -0,0 +1,22 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServicePlanList struct {
+	Names                *string `schema:"names"`
+	ServiceOfferingGUIDs *string `schema:"service_offering_guids"`
+	Pagination
+}
+
+func (l *ServicePlanList) ToMessage() repositories.ListServicePlansMessage {
+	return repositories.ListServicePlansMessage{
+		Names:                ParseArrayParam(l.Names),
+		ServiceOfferingGUIDs: ParseArrayParam(l.ServiceOfferingGUIDs),
+	}
+}
+
+func (l *ServicePlanList) SupportedFilterKeys() []string {
+	return []string{"names", "service_offering_guids", "page", "per_page"}
+}
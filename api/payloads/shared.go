@@ -1,15 +1,29 @@
 package payloads
 
-import "strings"
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	DefaultPerPage = 50
+	MaxPerPage     = 5000
+)
+
+// Lifecycle requires buildpacks and a stack, unless its type is docker. That is checked by a struct level validation.
 type Lifecycle struct {
-	Type string        `json:"type" validate:"required"`
+	Type string        `json:"type" validate:"required,oneof=buildpack docker"`
 	Data LifecycleData `json:"data" validate:"required"`
 }
 
 type LifecycleData struct {
-	Buildpacks []string `json:"buildpacks" validate:"required"`
-	Stack      string   `json:"stack" validate:"required"`
+	Buildpacks []string `json:"buildpacks"`
+	Stack      string   `json:"stack"`
 }
 
 type Relationship struct {
@@ -25,6 +39,20 @@ type Metadata struct {
 	Annotations map[string]string `json:"annotations"`
 }
 
+// MetadataPatch holds the metadata of PATCH requests. Labels and annotations with a null value are removed, and the
+// ones that are not mentioned are left as they are.
+type MetadataPatch struct {
+	Labels      map[string]*string `json:"labels"`
+	Annotations map[string]*string `json:"annotations"`
+}
+
+func (p MetadataPatch) toMessage() repositories.MetadataPatch {
+	return repositories.MetadataPatch{
+		Labels:      p.Labels,
+		Annotations: p.Annotations,
+	}
+}
+
 func ParseArrayParam(arrayParam *string) []string {
 	if arrayParam == nil {
 		return []string{}
@@ -37,3 +65,79 @@ func ParseArrayParam(arrayParam *string) []string {
 
 	return elements
 }
+
+// LabelSelector holds the label_selector query parameter of the list endpoints that filter resources by their CF
+// labels. It is embedded in list payloads, so that decoding them accepts this parameter.
+type LabelSelector struct {
+	Selector string `schema:"label_selector"`
+}
+
+func LabelSelectorFromQuery(query url.Values) LabelSelector {
+	return LabelSelector{Selector: query.Get("label_selector")}
+}
+
+func (l LabelSelector) Validate() error {
+	if _, err := repositories.ParseLabelSelector(l.Selector); err != nil {
+		return apierrors.NewBadQueryParameterError(err, "Invalid label_selector value")
+	}
+
+	return nil
+}
+
+// Pagination holds the page and per_page query parameters that all list endpoints support.
+// It is embedded in list payloads, so that decoding them accepts these parameters.
+type Pagination struct {
+	Page    string `schema:"page"`
+	PerPage string `schema:"per_page"`
+}
+
+func PaginationFromQuery(query url.Values) Pagination {
+	return Pagination{
+		Page:    query.Get("page"),
+		PerPage: query.Get("per_page"),
+	}
+}
+
+func (p Pagination) Validate() error {
+	if p.Page != "" {
+		page, err := strconv.Atoi(p.Page)
+		if err != nil || page < 1 {
+			return apierrors.NewBadQueryParameterError(errors.New("invalid page"), "Page must be greater than 0")
+		}
+	}
+
+	if p.PerPage != "" {
+		perPage, err := strconv.Atoi(p.PerPage)
+		if err != nil || perPage < 1 || perPage > MaxPerPage {
+			return apierrors.NewBadQueryParameterError(errors.New("invalid per_page"), "Per page must be between 1 and 5000")
+		}
+	}
+
+	return nil
+}
+
+// PageNumber returns the requested page, or the first page when it is not set or invalid
+func (p Pagination) PageNumber() int {
+	page, err := strconv.Atoi(p.Page)
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+// ToPage converts the parameters into the page of a repository that paginates lists itself
+func (p Pagination) ToPage() repositories.Page {
+	return repositories.Page{
+		Number:  p.PageNumber(),
+		PerPage: p.PerPageNumber(),
+	}
+}
+
+// PerPageNumber returns the requested page size, or the default page size when it is not set or invalid
+func (p Pagination) PerPageNumber() int {
+	perPage, err := strconv.Atoi(p.PerPage)
+	if err != nil || perPage < 1 || perPage > MaxPerPage {
+		return DefaultPerPage
+	}
+	return perPage
+}
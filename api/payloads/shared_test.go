@@ -1,6 +1,9 @@
 package payloads_test
 
 import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
 	. "code.cloudfoundry.org/korifi/api/payloads"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -34,3 +37,60 @@ var _ = Describe("ParseArrayParam", func() {
 		})
 	})
 })
+
+var _ = Describe("Pagination", func() {
+	var pagination Pagination
+
+	BeforeEach(func() {
+		pagination = PaginationFromQuery(url.Values{"page": {"3"}, "per_page": {"20"}})
+	})
+
+	It("reads the page and per_page query parameters", func() {
+		Expect(pagination.Validate()).To(Succeed())
+		Expect(pagination.PageNumber()).To(Equal(3))
+		Expect(pagination.PerPageNumber()).To(Equal(20))
+	})
+
+	When("the parameters are not set", func() {
+		BeforeEach(func() {
+			pagination = PaginationFromQuery(url.Values{})
+		})
+
+		It("defaults to the first page of 50 results", func() {
+			Expect(pagination.Validate()).To(Succeed())
+			Expect(pagination.PageNumber()).To(Equal(1))
+			Expect(pagination.PerPageNumber()).To(Equal(DefaultPerPage))
+		})
+	})
+
+	DescribeTable("invalid parameters",
+		func(page, perPage, detail string) {
+			err := Pagination{Page: page, PerPage: perPage}.Validate()
+			Expect(err).To(BeAssignableToTypeOf(apierrors.BadQueryParameterError{}))
+			Expect(err.(apierrors.BadQueryParameterError).Detail()).To(Equal(detail))
+		},
+		Entry("page is zero", "0", "", "The query parameter is invalid: Page must be greater than 0"),
+		Entry("page is not a number", "first", "", "The query parameter is invalid: Page must be greater than 0"),
+		Entry("per_page is zero", "", "0", "The query parameter is invalid: Per page must be between 1 and 5000"),
+		Entry("per_page is too large", "", "5001", "The query parameter is invalid: Per page must be between 1 and 5000"),
+		Entry("per_page is not a number", "", "many", "The query parameter is invalid: Per page must be between 1 and 5000"),
+	)
+})
+
+var _ = Describe("LabelSelector", func() {
+	It("reads the label_selector query parameter", func() {
+		labelSelector := LabelSelectorFromQuery(url.Values{"label_selector": {"env=prod,!deprecated"}})
+		Expect(labelSelector.Selector).To(Equal("env=prod,!deprecated"))
+		Expect(labelSelector.Validate()).To(Succeed())
+	})
+
+	It("accepts an empty label_selector", func() {
+		Expect(LabelSelectorFromQuery(url.Values{}).Validate()).To(Succeed())
+	})
+
+	It("rejects an invalid label_selector", func() {
+		err := LabelSelector{Selector: "env in prod"}.Validate()
+		Expect(err).To(BeAssignableToTypeOf(apierrors.BadQueryParameterError{}))
+		Expect(err.(apierrors.BadQueryParameterError).Detail()).To(Equal("The query parameter is invalid: Invalid label_selector value"))
+	})
+})
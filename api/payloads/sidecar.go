@@ -0,0 +1,54 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name" validate:"required,dns_rfc1035_label"`
+	Command      string   `json:"command" validate:"required"`
+	ProcessTypes []string `json:"process_types" validate:"required,min=1,dive,required"`
+	MemoryInMB   *int64   `json:"memory_in_mb" validate:"omitempty,gt=0"`
+}
+
+func (p SidecarCreate) ToMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	var memoryMB int64
+	if p.MemoryInMB != nil {
+		memoryMB = *p.MemoryInMB
+	}
+
+	return repositories.CreateSidecarMessage{
+		Name:         p.Name,
+		Command:      p.Command,
+		ProcessTypes: p.ProcessTypes,
+		MemoryMB:     memoryMB,
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+	}
+}
+
+type SidecarPatch struct {
+	Name         *string  `json:"name" validate:"omitempty,dns_rfc1035_label"`
+	Command      *string  `json:"command" validate:"omitempty,min=1"`
+	ProcessTypes []string `json:"process_types" validate:"omitempty,min=1,dive,required"`
+	MemoryInMB   *int64   `json:"memory_in_mb" validate:"omitempty,gt=0"`
+}
+
+func (p SidecarPatch) ToMessage(sidecarGUID, spaceGUID string) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         p.Name,
+		Command:      p.Command,
+		ProcessTypes: p.ProcessTypes,
+		MemoryMB:     p.MemoryInMB,
+	}
+}
+
+type SidecarList struct {
+	Pagination
+}
+
+func (l *SidecarList) SupportedFilterKeys() []string {
+	return []string{"page", "per_page"}
+}
@@ -17,5 +17,21 @@ func (p SpaceCreate) ToMessage(imageRegistryCredentialSecret string) repositorie
 		Name:                     p.Name,
 		OrganizationGUID:         p.Relationships.Org.Data.GUID,
 		ImageRegistryCredentials: imageRegistryCredentialSecret,
+		Labels:                   p.Metadata.Labels,
+		Annotations:              p.Metadata.Annotations,
+	}
+}
+
+type SpacePatch struct {
+	Name     *string       `json:"name" validate:"omitempty,min=1"`
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (p SpacePatch) ToMessage(spaceGUID, orgGUID string) repositories.PatchSpaceMessage {
+	return repositories.PatchSpaceMessage{
+		GUID:             spaceGUID,
+		OrganizationGUID: orgGUID,
+		Name:             p.Name,
+		MetadataPatch:    p.Metadata.toMessage(),
 	}
 }
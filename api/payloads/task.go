@@ -0,0 +1,59 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	defaultTaskMemoryMB = 1024
+	defaultTaskDiskMB   = 1024
+)
+
+type TaskCreate struct {
+	Command    string   `json:"command" validate:"required"`
+	Name       string   `json:"name"`
+	MemoryInMB *int64   `json:"memory_in_mb" validate:"omitempty,gt=0"`
+	DiskInMB   *int64   `json:"disk_in_mb" validate:"omitempty,gt=0"`
+	Metadata   Metadata `json:"metadata"`
+}
+
+func (p TaskCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateTaskMessage {
+	message := repositories.CreateTaskMessage{
+		Name:        p.Name,
+		Command:     p.Command,
+		AppGUID:     appRecord.GUID,
+		SpaceGUID:   appRecord.SpaceGUID,
+		MemoryMB:    defaultTaskMemoryMB,
+		DiskMB:      defaultTaskDiskMB,
+		Labels:      p.Metadata.Labels,
+		Annotations: p.Metadata.Annotations,
+	}
+
+	if p.MemoryInMB != nil {
+		message.MemoryMB = *p.MemoryInMB
+	}
+	if p.DiskInMB != nil {
+		message.DiskMB = *p.DiskInMB
+	}
+
+	return message
+}
+
+type TaskList struct {
+	AppGUIDs *string `schema:"app_guids"`
+	Names    *string `schema:"names"`
+	States   *string `schema:"states"`
+	Pagination
+}
+
+func (l *TaskList) ToMessage() repositories.ListTasksMessage {
+	return repositories.ListTasksMessage{
+		AppGUIDs: ParseArrayParam(l.AppGUIDs),
+		Names:    ParseArrayParam(l.Names),
+		States:   ParseArrayParam(l.States),
+	}
+}
+
+func (l *TaskList) SupportedFilterKeys() []string {
+	return []string{"app_guids", "names", "states", "page", "per_page"}
+}
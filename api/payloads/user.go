@@ -0,0 +1,43 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+// UserCreate creates a user either by GUID or by username and origin. The GUID of a user is its username.
+type UserCreate struct {
+	GUID     string `json:"guid" validate:"required_without=Username"`
+	Username string `json:"username" validate:"required_without=GUID"`
+	Origin   string `json:"origin" validate:"required_with=Username"`
+}
+
+func (p UserCreate) ToMessage() repositories.CreateUserMessage {
+	username := p.Username
+	if p.GUID != "" {
+		username = p.GUID
+	}
+
+	return repositories.CreateUserMessage{
+		Username: username,
+		Origin:   p.Origin,
+	}
+}
+
+type UserList struct {
+	GUIDs     *string `schema:"guids"`
+	Usernames *string `schema:"usernames"`
+	Origins   *string `schema:"origins"`
+	Pagination
+}
+
+func (l *UserList) ToMessage() repositories.ListUsersMessage {
+	return repositories.ListUsersMessage{
+		GUIDs:     ParseArrayParam(l.GUIDs),
+		Usernames: ParseArrayParam(l.Usernames),
+		Origins:   ParseArrayParam(l.Origins),
+	}
+}
+
+func (l *UserList) SupportedFilterKeys() []string {
+	return []string{"guids", "usernames", "origins", "page", "per_page"}
+}
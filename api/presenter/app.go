@@ -61,8 +61,8 @@ func ForApp(responseApp repositories.AppRecord, baseURL url.URL) AppResponse {
 			},
 		},
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(responseApp.Labels),
+			Annotations: orEmptyMap(responseApp.Annotations),
 		},
 		Links: AppLinks{
 			Self: Link{
@@ -111,12 +111,20 @@ func ForApp(responseApp repositories.AppRecord, baseURL url.URL) AppResponse {
 }
 
 func ForAppList(appRecordList []repositories.AppRecord, baseURL, requestURL url.URL) ListResponse {
+	return ForList(toAppListResponses(appRecordList, baseURL), baseURL, requestURL)
+}
+
+// ForAppListPage presents a page of apps that the repository has already paginated
+func ForAppListPage(appRecordList []repositories.AppRecord, totalResults int, baseURL, requestURL url.URL) ListResponse {
+	return ForListPage(toAppListResponses(appRecordList, baseURL), totalResults, baseURL, requestURL)
+}
+
+func toAppListResponses(appRecordList []repositories.AppRecord, baseURL url.URL) []interface{} {
 	appResponses := make([]interface{}, 0, len(appRecordList))
 	for _, app := range appRecordList {
 		appResponses = append(appResponses, ForApp(app, baseURL))
 	}
-
-	return ForList(appResponses, baseURL, requestURL)
+	return appResponses
 }
 
 type CurrentDropletResponse struct {
@@ -0,0 +1,78 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AuditEventResponse struct {
+	GUID         string                 `json:"guid"`
+	CreatedAt    string                 `json:"created_at"`
+	UpdatedAt    string                 `json:"updated_at"`
+	Type         string                 `json:"type"`
+	Actor        AuditEventActor        `json:"actor"`
+	Target       AuditEventTarget       `json:"target"`
+	Data         map[string]interface{} `json:"data"`
+	Space        *AuditEventGUID        `json:"space"`
+	Organization *AuditEventGUID        `json:"organization"`
+}
+
+type AuditEventActor struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTarget struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventGUID struct {
+	GUID string `json:"guid"`
+}
+
+func ForAuditEvent(record repositories.AuditEventRecord) AuditEventResponse {
+	data := record.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	return AuditEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Type:      record.Type,
+		Actor: AuditEventActor{
+			GUID: record.Actor.GUID,
+			Type: record.Actor.Type,
+			Name: record.Actor.Name,
+		},
+		Target: AuditEventTarget{
+			GUID: record.Target.GUID,
+			Type: record.Target.Type,
+			Name: record.Target.Name,
+		},
+		Data:         data,
+		Space:        auditEventGUIDOrNil(record.SpaceGUID),
+		Organization: auditEventGUIDOrNil(record.OrganizationGUID),
+	}
+}
+
+func ForAuditEventList(records []repositories.AuditEventRecord, baseURL, requestURL url.URL) ListResponse {
+	auditEventResponses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		auditEventResponses = append(auditEventResponses, ForAuditEvent(record))
+	}
+
+	return ForList(auditEventResponses, baseURL, requestURL)
+}
+
+func auditEventGUIDOrNil(guid string) *AuditEventGUID {
+	if guid == "" {
+		return nil
+	}
+	return &AuditEventGUID{GUID: guid}
+}
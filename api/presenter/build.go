@@ -56,8 +56,8 @@ func ForBuild(buildRecord repositories.BuildRecord, baseURL url.URL) BuildRespon
 			},
 		},
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(buildRecord.Labels),
+			Annotations: orEmptyMap(buildRecord.Annotations),
 		},
 		Links: map[string]Link{
 			"self": {
@@ -85,3 +85,12 @@ func ForBuild(buildRecord repositories.BuildRecord, baseURL url.URL) BuildRespon
 
 	return toReturn
 }
+
+func ForBuildList(buildRecordList []repositories.BuildRecord, baseURL, requestURL url.URL) ListResponse {
+	buildResponses := make([]interface{}, 0, len(buildRecordList))
+	for _, build := range buildRecordList {
+		buildResponses = append(buildResponses, ForBuild(build, baseURL))
+	}
+
+	return ForList(buildResponses, baseURL, requestURL)
+}
@@ -0,0 +1,115 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	deploymentsBase = "/v3/deployments"
+)
+
+type DeploymentResponse struct {
+	GUID            string                      `json:"guid"`
+	State           string                      `json:"state"`
+	Status          DeploymentStatus            `json:"status"`
+	Strategy        string                      `json:"strategy"`
+	Droplet         DeploymentDroplet           `json:"droplet"`
+	PreviousDroplet DeploymentDroplet           `json:"previous_droplet"`
+	NewProcesses    []DeploymentProcessResponse `json:"new_processes"`
+	CreatedAt       string                      `json:"created_at"`
+	UpdatedAt       string                      `json:"updated_at"`
+	Relationships   Relationships               `json:"relationships"`
+	Metadata        Metadata                    `json:"metadata"`
+	Links           DeploymentLinks             `json:"links"`
+}
+
+type DeploymentStatus struct {
+	Value   string                  `json:"value"`
+	Reason  string                  `json:"reason"`
+	Details DeploymentStatusDetails `json:"details"`
+}
+
+type DeploymentStatusDetails struct {
+	LastStatusChange string `json:"last_status_change"`
+}
+
+type DeploymentDroplet struct {
+	GUID *string `json:"guid"`
+}
+
+type DeploymentProcessResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type DeploymentLinks struct {
+	Self   Link `json:"self"`
+	App    Link `json:"app"`
+	Cancel Link `json:"cancel"`
+}
+
+func ForDeployment(deploymentRecord repositories.DeploymentRecord, baseURL url.URL) DeploymentResponse {
+	newProcesses := make([]DeploymentProcessResponse, 0, len(deploymentRecord.NewProcesses))
+	for _, process := range deploymentRecord.NewProcesses {
+		newProcesses = append(newProcesses, DeploymentProcessResponse{GUID: process.GUID, Type: process.Type})
+	}
+
+	return DeploymentResponse{
+		GUID:  deploymentRecord.GUID,
+		State: deploymentRecord.State,
+		Status: DeploymentStatus{
+			Value:  deploymentRecord.StatusValue,
+			Reason: deploymentRecord.StatusReason,
+			Details: DeploymentStatusDetails{
+				LastStatusChange: deploymentRecord.LastStatusChange,
+			},
+		},
+		Strategy:        deploymentRecord.Strategy,
+		Droplet:         deploymentDroplet(deploymentRecord.DropletGUID),
+		PreviousDroplet: deploymentDroplet(deploymentRecord.PreviousDropletGUID),
+		NewProcesses:    newProcesses,
+		CreatedAt:       deploymentRecord.CreatedAt,
+		UpdatedAt:       deploymentRecord.UpdatedAt,
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: deploymentRecord.AppGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(deploymentRecord.Labels),
+			Annotations: orEmptyMap(deploymentRecord.Annotations),
+		},
+		Links: DeploymentLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(deploymentsBase, deploymentRecord.GUID).build(),
+			},
+			App: Link{
+				HREF: buildURL(baseURL).appendPath(appsBase, deploymentRecord.AppGUID).build(),
+			},
+			Cancel: Link{
+				HREF:   buildURL(baseURL).appendPath(deploymentsBase, deploymentRecord.GUID, "actions/cancel").build(),
+				Method: "POST",
+			},
+		},
+	}
+}
+
+func ForDeploymentList(deploymentRecords []repositories.DeploymentRecord, baseURL, requestURL url.URL) ListResponse {
+	deploymentResponses := make([]interface{}, 0, len(deploymentRecords))
+	for _, deployment := range deploymentRecords {
+		deploymentResponses = append(deploymentResponses, ForDeployment(deployment, baseURL))
+	}
+
+	return ForList(deploymentResponses, baseURL, requestURL)
+}
+
+func deploymentDroplet(dropletGUID string) DeploymentDroplet {
+	if dropletGUID == "" {
+		return DeploymentDroplet{}
+	}
+	return DeploymentDroplet{GUID: &dropletGUID}
+}
@@ -54,8 +54,8 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL) Domain
 		UpdatedAt:          responseDomain.UpdatedAt,
 
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(responseDomain.Labels),
+			Annotations: orEmptyMap(responseDomain.Annotations),
 		},
 		Relationships: DomainRelationships{
 			Organization: Organization{
@@ -61,8 +61,8 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL) Dropl
 			},
 		},
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(dropletRecord.Labels),
+			Annotations: orEmptyMap(dropletRecord.Annotations),
 		},
 		Links: map[string]*Link{
 			"self": {
@@ -84,6 +84,9 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL) Dropl
 	if dropletRecord.DropletErrorMsg != "" {
 		toReturn.Error = &dropletRecord.DropletErrorMsg
 	}
+	if dropletRecord.Image != "" {
+		toReturn.Image = &dropletRecord.Image
+	}
 	return toReturn
 }
 
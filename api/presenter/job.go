@@ -1,57 +1,72 @@
 package presenter
 
-import "net/url"
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
 
 type JobResponse struct {
-	GUID      string   `json:"guid"`
-	Errors    *string  `json:"errors"`
-	Warnings  *string  `json:"warnings"`
-	Operation string   `json:"operation"`
-	State     string   `json:"state"`
-	CreatedAt string   `json:"created_at"`
-	UpdatedAt string   `json:"updated_at"`
-	Links     JobLinks `json:"links"`
+	GUID      string           `json:"guid"`
+	Errors    []PresentedError `json:"errors"`
+	Warnings  []JobWarning     `json:"warnings"`
+	Operation string           `json:"operation"`
+	State     string           `json:"state"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
+	Links     JobLinks         `json:"links"`
+}
+
+type JobWarning struct {
+	Detail string `json:"detail"`
 }
 
-// TODO: Generalize job links or just omit missing links for jobs that do not require them?
 type JobLinks struct {
-	Self  Link  `json:"self"`
-	Space *Link `json:"space,omitempty"`
+	Self          Link  `json:"self"`
+	Space         *Link `json:"space,omitempty"`
+	ServiceBroker *Link `json:"service_brokers,omitempty"`
 }
 
-func ForManifestApplyJob(jobGUID string, spaceGUID string, baseURL url.URL) JobResponse {
-	return JobResponse{
-		GUID:      jobGUID,
-		Errors:    nil,
-		Warnings:  nil,
-		Operation: "space.apply_manifest",
-		State:     "COMPLETE",
-		CreatedAt: "",
-		UpdatedAt: "",
-		Links: JobLinks{
-			Self: Link{
-				HREF: buildURL(baseURL).appendPath("/v3/jobs", jobGUID).build(),
-			},
-			Space: &Link{
-				HREF: buildURL(baseURL).appendPath("/v3/spaces", spaceGUID).build(),
-			},
-		},
+func ForJob(job repositories.JobRecord, baseURL url.URL) JobResponse {
+	errors := make([]PresentedError, 0, len(job.Errors))
+	for _, jobError := range job.Errors {
+		errors = append(errors, PresentedError{
+			Detail: jobError.Detail,
+			Title:  jobError.Title,
+			Code:   jobError.Code,
+		})
+	}
+
+	warnings := make([]JobWarning, 0, len(job.Warnings))
+	for _, warning := range job.Warnings {
+		warnings = append(warnings, JobWarning{Detail: warning})
 	}
-}
 
-func ForDeleteJob(jobGUID string, operation string, baseURL url.URL) JobResponse {
-	return JobResponse{
-		GUID:      jobGUID,
-		Errors:    nil,
-		Warnings:  nil,
-		Operation: operation,
-		State:     "COMPLETE",
-		CreatedAt: "",
-		UpdatedAt: "",
+	response := JobResponse{
+		GUID:      job.GUID,
+		Errors:    errors,
+		Warnings:  warnings,
+		Operation: job.Operation,
+		State:     job.State,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
 		Links: JobLinks{
 			Self: Link{
-				HREF: buildURL(baseURL).appendPath("/v3/jobs", jobGUID).build(),
+				HREF: buildURL(baseURL).appendPath("/v3/jobs", job.GUID).build(),
 			},
 		},
 	}
+
+	switch job.Operation {
+	case repositories.ApplyManifestJobOperation:
+		response.Links.Space = &Link{
+			HREF: buildURL(baseURL).appendPath("/v3/spaces", job.ResourceGUID).build(),
+		}
+	case repositories.ServiceBrokerCreateJobOperation:
+		response.Links.ServiceBroker = &Link{
+			HREF: buildURL(baseURL).appendPath("/v3/service_brokers", job.ResourceGUID).build(),
+		}
+	}
+
+	return response
 }
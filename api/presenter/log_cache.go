@@ -0,0 +1,71 @@
+package presenter
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const logTypeOut = "OUT"
+
+// LogCacheReadResponse is the JSON representation of a loggregator v2 EnvelopeBatch as served by log-cache
+type LogCacheReadResponse struct {
+	Envelopes LogCacheEnvelopeBatch `json:"envelopes"`
+}
+
+type LogCacheEnvelopeBatch struct {
+	Batch []LogCacheEnvelope `json:"batch"`
+}
+
+type LogCacheEnvelope struct {
+	Timestamp  string            `json:"timestamp"`
+	SourceID   string            `json:"source_id"`
+	InstanceID string            `json:"instance_id"`
+	Tags       map[string]string `json:"tags"`
+	Log        LogCacheLog       `json:"log"`
+}
+
+type LogCacheLog struct {
+	Payload []byte `json:"payload"`
+	Type    string `json:"type"`
+}
+
+func ForLogs(sourceID string, logs []repositories.LogRecord) LogCacheReadResponse {
+	batch := make([]LogCacheEnvelope, 0, len(logs))
+	for _, log := range logs {
+		batch = append(batch, LogCacheEnvelope{
+			// int64 values are encoded as strings in the protobuf JSON mapping
+			Timestamp:  strconv.FormatInt(log.Timestamp, 10),
+			SourceID:   sourceID,
+			InstanceID: log.InstanceID,
+			Tags: map[string]string{
+				"source_type": log.SourceType,
+			},
+			Log: LogCacheLog{
+				Payload: []byte(log.Message),
+				Type:    logTypeOut,
+			},
+		})
+	}
+
+	return LogCacheReadResponse{
+		Envelopes: LogCacheEnvelopeBatch{
+			Batch: batch,
+		},
+	}
+}
+
+// LogCacheErrorResponse is the error body returned by log-cache, which the cf CLI expects instead of CF API errors
+type LogCacheErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+func ForLogCacheError(httpStatus int, message string) LogCacheErrorResponse {
+	return LogCacheErrorResponse{
+		Error:   strings.ToLower(http.StatusText(httpStatus)),
+		Message: message,
+	}
+}
@@ -0,0 +1,41 @@
+package presenter
+
+const manifestDiffOpAdd = "add"
+
+// ManifestDiffEntry is a JSON-patch style operation on the manifest of a space, as computed by the manifest_diff action.
+// Paths address the applications, processes and routes of the submitted manifest by their index.
+type ManifestDiffEntry struct {
+	Op    string
+	Path  string
+	Was   interface{}
+	Value interface{}
+}
+
+type ManifestDiffResponse struct {
+	Diff []ManifestDiffEntryResponse `json:"diff"`
+}
+
+type ManifestDiffEntryResponse struct {
+	Op    string       `json:"op"`
+	Path  string       `json:"path"`
+	Was   *interface{} `json:"was,omitempty"`
+	Value interface{}  `json:"value"`
+}
+
+func ForManifestDiff(entries []ManifestDiffEntry) ManifestDiffResponse {
+	diff := make([]ManifestDiffEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response := ManifestDiffEntryResponse{
+			Op:    entry.Op,
+			Path:  entry.Path,
+			Value: entry.Value,
+		}
+		if entry.Op != manifestDiffOpAdd {
+			was := entry.Was
+			response.Was = &was
+		}
+		diff = append(diff, response)
+	}
+
+	return ManifestDiffResponse{Diff: diff}
+}
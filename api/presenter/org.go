@@ -80,8 +80,8 @@ func toSpaceResponse(space repositories.SpaceRecord, apiBaseURL url.URL) SpaceRe
 		CreatedAt: space.CreatedAt.UTC().Format(time.RFC3339),
 		UpdatedAt: space.CreatedAt.UTC().Format(time.RFC3339),
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(space.Labels),
+			Annotations: orEmptyMap(space.Annotations),
 		},
 		Relationships: Relationships{
 			"organization": Relationship{
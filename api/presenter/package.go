@@ -22,7 +22,9 @@ type PackageResponse struct {
 	UpdatedAt     string        `json:"updated_at"`
 }
 
-type PackageData struct{}
+type PackageData struct {
+	Image string `json:"image,omitempty"`
+}
 
 type PackageLinks struct {
 	Self     Link `json:"self"`
@@ -32,9 +34,15 @@ type PackageLinks struct {
 }
 
 func ForPackage(record repositories.PackageRecord, baseURL url.URL) PackageResponse {
+	data := PackageData{}
+	if record.Type == "docker" {
+		data.Image = record.ImageRef
+	}
+
 	return PackageResponse{
 		GUID:      record.GUID,
 		Type:      record.Type,
+		Data:      data,
 		State:     record.State,
 		CreatedAt: record.CreatedAt,
 		UpdatedAt: record.UpdatedAt,
@@ -62,8 +70,8 @@ func ForPackage(record repositories.PackageRecord, baseURL url.URL) PackageRespo
 			},
 		},
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(record.Labels),
+			Annotations: orEmptyMap(record.Annotations),
 		},
 	}
 }
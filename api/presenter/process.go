@@ -118,8 +118,8 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) Pro
 			},
 		},
 		Metadata: Metadata{
-			Labels:      responseProcess.Labels,
-			Annotations: responseProcess.Annotations,
+			Labels:      orEmptyMap(responseProcess.Labels),
+			Annotations: orEmptyMap(responseProcess.Annotations),
 		},
 		CreatedAt: responseProcess.CreatedAt,
 		UpdatedAt: responseProcess.UpdatedAt,
@@ -145,12 +145,20 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL) Pro
 }
 
 func ForProcessList(processRecordList []repositories.ProcessRecord, baseURL, requestURL url.URL) ListResponse {
+	return ForList(toProcessListResponses(processRecordList, baseURL), baseURL, requestURL)
+}
+
+// ForProcessListPage presents a page of processes that the repository has already paginated
+func ForProcessListPage(processRecordList []repositories.ProcessRecord, totalResults int, baseURL, requestURL url.URL) ListResponse {
+	return ForListPage(toProcessListResponses(processRecordList, baseURL), totalResults, baseURL, requestURL)
+}
+
+func toProcessListResponses(processRecordList []repositories.ProcessRecord, baseURL url.URL) []interface{} {
 	processResponses := make([]interface{}, 0, len(processRecordList))
 	for _, process := range processRecordList {
 		processResponse := ForProcess(process, baseURL)
 		processResponse.Command = "[PRIVATE DATA HIDDEN IN LISTS]"
 		processResponses = append(processResponses, processResponse)
 	}
-
-	return ForList(processResponses, baseURL, requestURL)
+	return processResponses
 }
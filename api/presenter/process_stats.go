@@ -49,7 +49,7 @@ func ForProcessStats(records []repositories.PodStatsRecord) ProcessStatsResponse
 
 func statRecordToResource(record repositories.PodStatsRecord) ProcessStatsResource {
 	var processInstancePorts *[]ProcessInstancePort
-	if record.State != "DOWN" {
+	if record.State != "DOWN" && record.State != repositories.CrashedState {
 		processInstancePorts = &[]ProcessInstancePort{}
 	}
 	return ProcessStatsResource{
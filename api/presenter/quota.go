@@ -0,0 +1,187 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	orgQuotasBase   = "/v3/organization_quotas"
+	spaceQuotasBase = "/v3/space_quotas"
+)
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          QuotaAppsResponse     `json:"apps"`
+	Services      QuotaServicesResponse `json:"services"`
+	Routes        QuotaRoutesResponse   `json:"routes"`
+	Domains       QuotaDomainsResponse  `json:"domains"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         map[string]Link       `json:"links"`
+}
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          QuotaAppsResponse       `json:"apps"`
+	Services      QuotaServicesResponse   `json:"services"`
+	Routes        QuotaRoutesResponse     `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         map[string]Link         `json:"links"`
+}
+
+// QuotaAppsResponse presents the app limits of a quota. Per app task and log rate limits are not supported, so
+// they are always unlimited.
+type QuotaAppsResponse struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+	TotalInstances               *int64 `json:"total_instances"`
+	PerAppTasks                  *int64 `json:"per_app_tasks"`
+}
+
+type QuotaServicesResponse struct {
+	PaidServicesAllowed   bool   `json:"paid_services_allowed"`
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+	TotalServiceKeys      *int64 `json:"total_service_keys"`
+}
+
+type QuotaRoutesResponse struct {
+	TotalRoutes        *int64 `json:"total_routes"`
+	TotalReservedPorts *int64 `json:"total_reserved_ports"`
+}
+
+type QuotaDomainsResponse struct {
+	TotalDomains *int64 `json:"total_domains"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship       `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func ForOrgQuota(record repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Name:      record.Name,
+		Apps:      forQuotaApps(record.Limits),
+		Services:  forQuotaServices(record.Limits),
+		Routes:    QuotaRoutesResponse{TotalRoutes: record.Limits.Routes},
+		Domains:   QuotaDomainsResponse{},
+		Relationships: OrgQuotaRelationships{
+			Organizations: forToManyRelationship(record.OrgGUIDs),
+		},
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForOrgQuotaList(records []repositories.OrgQuotaRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForOrgQuota(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+func ForSpaceQuota(record repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Name:      record.Name,
+		Apps:      forQuotaApps(record.Limits),
+		Services:  forQuotaServices(record.Limits),
+		Routes:    QuotaRoutesResponse{TotalRoutes: record.Limits.Routes},
+		Relationships: SpaceQuotaRelationships{
+			Organization: Relationship{Data: &RelationshipData{GUID: record.OrgGUID}},
+			Spaces:       forToManyRelationship(record.SpaceGUIDs),
+		},
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID).build(),
+			},
+			"organization": {
+				HREF: buildURL(baseURL).appendPath(orgsBase, record.OrgGUID).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceQuotaList(records []repositories.SpaceQuotaRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForSpaceQuota(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+type ToManyRelationshipResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links map[string]Link    `json:"links"`
+}
+
+// forQuotaRelationship presents the orgs or spaces a quota is applied to, as returned when applying the quota
+func forQuotaRelationship(guids []string, baseURL url.URL, quotasBase, quotaGUID, relationship string) ToManyRelationshipResponse {
+	return ToManyRelationshipResponse{
+		Data: forToManyRelationship(guids).Data,
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(baseURL).appendPath(quotasBase, quotaGUID, "relationships", relationship).build(),
+			},
+		},
+	}
+}
+
+func ForOrgQuotaOrganizations(record repositories.OrgQuotaRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return forQuotaRelationship(record.OrgGUIDs, baseURL, orgQuotasBase, record.GUID, "organizations")
+}
+
+func ForSpaceQuotaSpaces(record repositories.SpaceQuotaRecord, baseURL url.URL) ToManyRelationshipResponse {
+	return forQuotaRelationship(record.SpaceGUIDs, baseURL, spaceQuotasBase, record.GUID, "spaces")
+}
+
+func forQuotaApps(limits repositories.QuotaLimits) QuotaAppsResponse {
+	return QuotaAppsResponse{
+		TotalMemoryInMB:      limits.TotalMemoryMB,
+		PerProcessMemoryInMB: limits.InstanceMemoryMB,
+		TotalInstances:       limits.AppInstances,
+	}
+}
+
+func forQuotaServices(limits repositories.QuotaLimits) QuotaServicesResponse {
+	return QuotaServicesResponse{
+		PaidServicesAllowed:   true,
+		TotalServiceInstances: limits.ServiceInstances,
+	}
+}
+
+func forToManyRelationship(guids []string) ToManyRelationship {
+	data := make([]RelationshipData, 0, len(guids))
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+
+	return ToManyRelationship{Data: data}
+}
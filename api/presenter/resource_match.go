@@ -0,0 +1,36 @@
+package presenter
+
+import (
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ResourceMatchesResponse struct {
+	Resources []ResourceMatchResponse `json:"resources"`
+}
+
+type ResourceMatchResponse struct {
+	Checksum    ResourceChecksum `json:"checksum"`
+	SizeInBytes int64            `json:"size_in_bytes"`
+	Path        string           `json:"path"`
+	Mode        string           `json:"mode"`
+}
+
+type ResourceChecksum struct {
+	Value string `json:"value"`
+}
+
+func ForResourceMatches(resources []repositories.PackageResource) ResourceMatchesResponse {
+	matches := make([]ResourceMatchResponse, 0, len(resources))
+	for _, resource := range resources {
+		matches = append(matches, ResourceMatchResponse{
+			Checksum:    ResourceChecksum{Value: resource.SHA1},
+			SizeInBytes: resource.SizeInBytes,
+			Path:        resource.Path,
+			Mode:        strconv.FormatUint(uint64(resource.Mode), 8),
+		})
+	}
+
+	return ResourceMatchesResponse{Resources: matches}
+}
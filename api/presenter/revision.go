@@ -0,0 +1,93 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                             `json:"guid"`
+	Version       int64                              `json:"version"`
+	Droplet       RevisionDroplet                    `json:"droplet"`
+	Processes     map[string]RevisionProcessResponse `json:"processes"`
+	Sidecars      []interface{}                      `json:"sidecars"`
+	Description   string                             `json:"description"`
+	Deployable    bool                               `json:"deployable"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+	Relationships Relationships                      `json:"relationships"`
+	Metadata      Metadata                           `json:"metadata"`
+	Links         RevisionLinks                      `json:"links"`
+}
+
+type RevisionDroplet struct {
+	GUID string `json:"guid"`
+}
+
+type RevisionProcessResponse struct {
+	Command *string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self Link `json:"self"`
+	App  Link `json:"app"`
+}
+
+func ForRevision(revisionRecord repositories.RevisionRecord, baseURL url.URL) RevisionResponse {
+	processes := make(map[string]RevisionProcessResponse, len(revisionRecord.ProcessCommands))
+	for processType, command := range revisionRecord.ProcessCommands {
+		processes[processType] = RevisionProcessResponse{Command: revisionCommand(command)}
+	}
+
+	return RevisionResponse{
+		GUID:        revisionRecord.GUID,
+		Version:     revisionRecord.Version,
+		Droplet:     RevisionDroplet{GUID: revisionRecord.DropletGUID},
+		Processes:   processes,
+		Sidecars:    []interface{}{},
+		Description: revisionRecord.Description,
+		Deployable:  revisionRecord.Deployable,
+		CreatedAt:   revisionRecord.CreatedAt,
+		UpdatedAt:   revisionRecord.UpdatedAt,
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: revisionRecord.AppGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(revisionRecord.Labels),
+			Annotations: orEmptyMap(revisionRecord.Annotations),
+		},
+		Links: RevisionLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(revisionsBase, revisionRecord.GUID).build(),
+			},
+			App: Link{
+				HREF: buildURL(baseURL).appendPath(appsBase, revisionRecord.AppGUID).build(),
+			},
+		},
+	}
+}
+
+func ForRevisionList(revisionRecords []repositories.RevisionRecord, baseURL, requestURL url.URL) ListResponse {
+	revisionResponses := make([]interface{}, 0, len(revisionRecords))
+	for _, revision := range revisionRecords {
+		revisionResponses = append(revisionResponses, ForRevision(revision, baseURL))
+	}
+
+	return ForList(revisionResponses, baseURL, requestURL)
+}
+
+func revisionCommand(command string) *string {
+	if command == "" {
+		return nil
+	}
+	return &command
+}
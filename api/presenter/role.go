@@ -31,6 +31,58 @@ func ForCreateRole(role repositories.RoleRecord, apiBaseURL url.URL) RoleRespons
 	return toRoleResponse(role, apiBaseURL)
 }
 
+func ForRole(role repositories.RoleRecord, apiBaseURL url.URL) RoleResponse {
+	return toRoleResponse(role, apiBaseURL)
+}
+
+// ForRoleList presents the roles, including the users, spaces and orgs passed. Only the related resources of the roles
+// on the requested page are included.
+func ForRoleList(roles []repositories.RoleRecord, users []repositories.UserRecord, spaces []repositories.SpaceRecord, orgs []repositories.OrgRecord, apiBaseURL, requestURL url.URL) ListResponse {
+	roleResponses := make([]interface{}, 0, len(roles))
+	for _, role := range roles {
+		roleResponses = append(roleResponses, toRoleResponse(role, apiBaseURL))
+	}
+
+	ret := ForList(roleResponses, apiBaseURL, requestURL)
+	if users == nil && spaces == nil && orgs == nil {
+		return ret
+	}
+
+	pageUsers := map[string]bool{}
+	pageSpaces := map[string]bool{}
+	pageOrgs := map[string]bool{}
+	included := IncludedData{}
+	for _, resource := range ret.Resources {
+		relationships := resource.(RoleResponse).Relationships
+		pageUsers[relationships["user"].Data.GUID] = true
+		if data := relationships["space"].Data; data != nil {
+			pageSpaces[data.GUID] = true
+		}
+		if data := relationships["organization"].Data; data != nil {
+			pageOrgs[data.GUID] = true
+		}
+	}
+
+	for _, user := range users {
+		if pageUsers[user.GUID] {
+			included.Users = append(included.Users, toUserResponse(user, apiBaseURL))
+		}
+	}
+	for _, space := range spaces {
+		if pageSpaces[space.GUID] {
+			included.Spaces = append(included.Spaces, toSpaceResponse(space, apiBaseURL))
+		}
+	}
+	for _, org := range orgs {
+		if pageOrgs[org.GUID] {
+			included.Organizations = append(included.Organizations, toOrgResponse(org, apiBaseURL))
+		}
+	}
+	ret.Included = &included
+
+	return ret
+}
+
 func toRoleResponse(role repositories.RoleRecord, apiBaseURL url.URL) RoleResponse {
 	resp := RoleResponse{
 		GUID:      role.GUID,
@@ -16,8 +16,10 @@ type RootResponse struct {
 
 const v3APIVersion = "3.111.0+cf-k8s"
 
-func GetRootResponse(serverURL string) RootResponse {
-	return RootResponse{
+// GetRootResponse presents the links of the API. The login and uaa links are only set when a login server is
+// configured, so that the CF CLI can find its token endpoint.
+func GetRootResponse(serverURL, loginURL, uaaURL string) RootResponse {
+	response := RootResponse{
 		Links: map[string]*APILink{
 			"self":                {Link: Link{HREF: serverURL}},
 			"bits_service":        nil,
@@ -36,4 +38,13 @@ func GetRootResponse(serverURL string) RootResponse {
 		},
 		CFOnK8s: true,
 	}
+
+	if loginURL != "" {
+		response.Links["login"] = &APILink{Link: Link{HREF: loginURL}}
+	}
+	if uaaURL != "" {
+		response.Links["uaa"] = &APILink{Link: Link{HREF: uaaURL}}
+	}
+
+	return response
 }
@@ -88,8 +88,8 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL) RouteResponse {
 		},
 		Destinations: destinations,
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(route.Labels),
+			Annotations: orEmptyMap(route.Annotations),
 		},
 		Links: routeLinks{
 			Self: Link{
@@ -109,12 +109,20 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL) RouteResponse {
 }
 
 func ForRouteList(routeRecordList []repositories.RouteRecord, baseURL, requestURL url.URL) ListResponse {
+	return ForList(toRouteListResponses(routeRecordList, baseURL), baseURL, requestURL)
+}
+
+// ForRouteListPage presents a page of routes that the repository has already paginated
+func ForRouteListPage(routeRecordList []repositories.RouteRecord, totalResults int, baseURL, requestURL url.URL) ListResponse {
+	return ForListPage(toRouteListResponses(routeRecordList, baseURL), totalResults, baseURL, requestURL)
+}
+
+func toRouteListResponses(routeRecordList []repositories.RouteRecord, baseURL url.URL) []interface{} {
 	routeResponses := make([]interface{}, 0, len(routeRecordList))
 	for _, routeRecord := range routeRecordList {
 		routeResponses = append(routeResponses, ForRoute(routeRecord, baseURL))
 	}
-
-	return ForList(routeResponses, baseURL, requestURL)
+	return routeResponses
 }
 
 func forDestination(destination repositories.DestinationRecord) routeDestination {
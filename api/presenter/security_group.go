@@ -0,0 +1,100 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	securityGroupsBase = "/v3/security_groups"
+)
+
+type SecurityGroupResponse struct {
+	GUID            string                         `json:"guid"`
+	CreatedAt       string                         `json:"created_at"`
+	UpdatedAt       string                         `json:"updated_at"`
+	Name            string                         `json:"name"`
+	GloballyEnabled SecurityGroupWorkloadsResponse `json:"globally_enabled"`
+	Rules           []SecurityGroupRuleResponse    `json:"rules"`
+	Relationships   SecurityGroupRelationships     `json:"relationships"`
+	Links           map[string]Link                `json:"links"`
+}
+
+type SecurityGroupWorkloadsResponse struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+// SecurityGroupRuleResponse omits the fields that do not apply to the protocol of the rule, as Cloud Foundry does
+type SecurityGroupRuleResponse struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int   `json:"type,omitempty"`
+	Code        *int   `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+func ForSecurityGroup(record repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupResponse {
+	rules := make([]SecurityGroupRuleResponse, 0, len(record.Rules))
+	for _, rule := range record.Rules {
+		rules = append(rules, SecurityGroupRuleResponse(rule))
+	}
+
+	return SecurityGroupResponse{
+		GUID:      record.GUID,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+		Name:      record.Name,
+		GloballyEnabled: SecurityGroupWorkloadsResponse{
+			Running: record.GloballyEnabledRunning,
+			Staging: record.GloballyEnabledStaging,
+		},
+		Rules: rules,
+		Relationships: SecurityGroupRelationships{
+			RunningSpaces: forToManyRelationship(record.RunningSpaceGUIDs),
+			StagingSpaces: forToManyRelationship(record.StagingSpaceGUIDs),
+		},
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForSecurityGroupList(records []repositories.SecurityGroupRecord, baseURL, requestURL url.URL) ListResponse {
+	responses := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		responses = append(responses, ForSecurityGroup(record, baseURL))
+	}
+
+	return ForList(responses, baseURL, requestURL)
+}
+
+// ForSecurityGroupSpaces presents the spaces a security group is bound to for the lifecycle, as returned when binding
+// the security group
+func ForSecurityGroupSpaces(record repositories.SecurityGroupRecord, lifecycle string, baseURL url.URL) ToManyRelationshipResponse {
+	relationship := "running_spaces"
+	guids := record.RunningSpaceGUIDs
+	if lifecycle == repositories.SecurityGroupStagingLifecycle {
+		relationship = "staging_spaces"
+		guids = record.StagingSpaceGUIDs
+	}
+
+	return ToManyRelationshipResponse{
+		Data: forToManyRelationship(guids).Data,
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID, "relationships", relationship).build(),
+			},
+		},
+	}
+}
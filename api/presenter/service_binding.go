@@ -70,8 +70,8 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 			},
 		},
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(record.Labels),
+			Annotations: orEmptyMap(record.Annotations),
 		},
 	}
 }
@@ -84,9 +84,17 @@ func ForServiceBindingList(serviceBindingRecord []repositories.ServiceBindingRec
 
 	ret := ForList(serviceBindingResponses, baseURL, requestURL)
 	if len(appRecords) > 0 {
-		appData := IncludedData{}
+		// only include the apps bound by the service bindings on the requested page
+		pageAppGUIDs := map[string]bool{}
+		for _, resource := range ret.Resources {
+			pageAppGUIDs[resource.(ServiceBindingResponse).Relationships["app"].Data.GUID] = true
+		}
+
+		appData := IncludedData{Apps: []interface{}{}}
 		for _, appRecord := range appRecords {
-			appData.Apps = append(appData.Apps, ForApp(appRecord, baseURL))
+			if pageAppGUIDs[appRecord.GUID] {
+				appData.Apps = append(appData.Apps, ForApp(appRecord, baseURL))
+			}
 		}
 		ret.Included = &appData
 	}
@@ -0,0 +1,59 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	serviceBrokersBase = "/v3/service_brokers"
+)
+
+type ServiceBrokerResponse struct {
+	GUID          string             `json:"guid"`
+	Name          string             `json:"name"`
+	URL           string             `json:"url"`
+	CreatedAt     string             `json:"created_at"`
+	UpdatedAt     string             `json:"updated_at"`
+	Relationships Relationships      `json:"relationships"`
+	Metadata      Metadata           `json:"metadata"`
+	Links         ServiceBrokerLinks `json:"links"`
+}
+
+type ServiceBrokerLinks struct {
+	Self             Link `json:"self"`
+	ServiceOfferings Link `json:"service_offerings"`
+}
+
+func ForServiceBroker(serviceBrokerRecord repositories.ServiceBrokerRecord, baseURL url.URL) ServiceBrokerResponse {
+	return ServiceBrokerResponse{
+		GUID:          serviceBrokerRecord.GUID,
+		Name:          serviceBrokerRecord.Name,
+		URL:           serviceBrokerRecord.URL,
+		CreatedAt:     serviceBrokerRecord.CreatedAt,
+		UpdatedAt:     serviceBrokerRecord.UpdatedAt,
+		Relationships: Relationships{},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(serviceBrokerRecord.Labels),
+			Annotations: orEmptyMap(serviceBrokerRecord.Annotations),
+		},
+		Links: ServiceBrokerLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(serviceBrokersBase, serviceBrokerRecord.GUID).build(),
+			},
+			ServiceOfferings: Link{
+				HREF: buildURL(baseURL).appendPath(serviceOfferingsBase).setQuery("service_broker_guids=" + serviceBrokerRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceBrokerList(serviceBrokerRecords []repositories.ServiceBrokerRecord, baseURL, requestURL url.URL) ListResponse {
+	serviceBrokerResponses := make([]interface{}, 0, len(serviceBrokerRecords))
+	for _, serviceBroker := range serviceBrokerRecords {
+		serviceBrokerResponses = append(serviceBrokerResponses, ForServiceBroker(serviceBroker, baseURL))
+	}
+
+	return ForList(serviceBrokerResponses, baseURL, requestURL)
+}
@@ -44,9 +44,35 @@ type ServiceInstanceLinks struct {
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceResponse {
-	lastOperationType := "update"
+	instanceLastOperation := lastOperation{
+		CreatedAt:   serviceInstanceRecord.CreatedAt,
+		UpdatedAt:   serviceInstanceRecord.UpdatedAt,
+		Description: "Operation succeeded",
+		State:       "succeeded",
+		Type:        "update",
+	}
 	if serviceInstanceRecord.CreatedAt == serviceInstanceRecord.UpdatedAt {
-		lastOperationType = "create"
+		instanceLastOperation.Type = "create"
+	}
+	if serviceInstanceRecord.LastOperation != nil {
+		instanceLastOperation.Description = serviceInstanceRecord.LastOperation.Description
+		instanceLastOperation.State = serviceInstanceRecord.LastOperation.State
+		instanceLastOperation.Type = serviceInstanceRecord.LastOperation.Type
+	}
+
+	relationships := Relationships{
+		"space": Relationship{
+			Data: &RelationshipData{
+				GUID: serviceInstanceRecord.SpaceGUID,
+			},
+		},
+	}
+	if serviceInstanceRecord.ServicePlanGUID != "" {
+		relationships["service_plan"] = Relationship{
+			Data: &RelationshipData{
+				GUID: serviceInstanceRecord.ServicePlanGUID,
+			},
+		}
 	}
 
 	tags := serviceInstanceRecord.Tags
@@ -55,29 +81,17 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 	}
 
 	return ServiceInstanceResponse{
-		Name: serviceInstanceRecord.Name,
-		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
-		Tags: tags,
-		LastOperation: lastOperation{
-			CreatedAt:   serviceInstanceRecord.CreatedAt,
-			UpdatedAt:   serviceInstanceRecord.UpdatedAt,
-			Description: "Operation succeeded",
-			State:       "succeeded",
-			Type:        lastOperationType,
-		},
-		CreatedAt: serviceInstanceRecord.CreatedAt,
-		UpdatedAt: serviceInstanceRecord.UpdatedAt,
-		Relationships: Relationships{
-			"space": Relationship{
-				Data: &RelationshipData{
-					GUID: serviceInstanceRecord.SpaceGUID,
-				},
-			},
-		},
+		Name:          serviceInstanceRecord.Name,
+		GUID:          serviceInstanceRecord.GUID,
+		Type:          serviceInstanceRecord.Type,
+		Tags:          tags,
+		LastOperation: instanceLastOperation,
+		CreatedAt:     serviceInstanceRecord.CreatedAt,
+		UpdatedAt:     serviceInstanceRecord.UpdatedAt,
+		Relationships: relationships,
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      orEmptyMap(serviceInstanceRecord.Labels),
+			Annotations: orEmptyMap(serviceInstanceRecord.Annotations),
 		},
 		Links: ServiceInstanceLinks{
 			Self: Link{
@@ -0,0 +1,104 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	serviceOfferingsBase = "/v3/service_offerings"
+)
+
+type ServiceOfferingResponse struct {
+	GUID             string                       `json:"guid"`
+	Name             string                       `json:"name"`
+	Description      string                       `json:"description"`
+	Available        bool                         `json:"available"`
+	Tags             []string                     `json:"tags"`
+	Requires         []string                     `json:"requires"`
+	Shareable        bool                         `json:"shareable"`
+	DocumentationURL *string                      `json:"documentation_url"`
+	BrokerCatalog    ServiceOfferingBrokerCatalog `json:"broker_catalog"`
+	CreatedAt        string                       `json:"created_at"`
+	UpdatedAt        string                       `json:"updated_at"`
+	Relationships    Relationships                `json:"relationships"`
+	Metadata         Metadata                     `json:"metadata"`
+	Links            ServiceOfferingLinks         `json:"links"`
+}
+
+type ServiceOfferingBrokerCatalog struct {
+	ID       string                  `json:"id"`
+	Metadata map[string]string       `json:"metadata"`
+	Features ServiceOfferingFeatures `json:"features"`
+}
+
+type ServiceOfferingFeatures struct {
+	PlanUpdateable       bool `json:"plan_updateable"`
+	Bindable             bool `json:"bindable"`
+	InstancesRetrievable bool `json:"instances_retrievable"`
+	BindingsRetrievable  bool `json:"bindings_retrievable"`
+	AllowContextUpdates  bool `json:"allow_context_updates"`
+}
+
+type ServiceOfferingLinks struct {
+	Self          Link `json:"self"`
+	ServicePlans  Link `json:"service_plans"`
+	ServiceBroker Link `json:"service_broker"`
+}
+
+func ForServiceOffering(serviceOfferingRecord repositories.ServiceOfferingRecord, baseURL url.URL) ServiceOfferingResponse {
+	tags := serviceOfferingRecord.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return ServiceOfferingResponse{
+		GUID:        serviceOfferingRecord.GUID,
+		Name:        serviceOfferingRecord.Name,
+		Description: serviceOfferingRecord.Description,
+		Available:   true,
+		Tags:        tags,
+		Requires:    []string{},
+		BrokerCatalog: ServiceOfferingBrokerCatalog{
+			ID:       serviceOfferingRecord.CatalogID,
+			Metadata: map[string]string{},
+			Features: ServiceOfferingFeatures{
+				Bindable: serviceOfferingRecord.Bindable,
+			},
+		},
+		CreatedAt: serviceOfferingRecord.CreatedAt,
+		UpdatedAt: serviceOfferingRecord.UpdatedAt,
+		Relationships: Relationships{
+			"service_broker": Relationship{
+				Data: &RelationshipData{
+					GUID: serviceOfferingRecord.ServiceBrokerGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(serviceOfferingRecord.Labels),
+			Annotations: orEmptyMap(serviceOfferingRecord.Annotations),
+		},
+		Links: ServiceOfferingLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(serviceOfferingsBase, serviceOfferingRecord.GUID).build(),
+			},
+			ServicePlans: Link{
+				HREF: buildURL(baseURL).appendPath(servicePlansBase).setQuery("service_offering_guids=" + serviceOfferingRecord.GUID).build(),
+			},
+			ServiceBroker: Link{
+				HREF: buildURL(baseURL).appendPath(serviceBrokersBase, serviceOfferingRecord.ServiceBrokerGUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceOfferingList(serviceOfferingRecords []repositories.ServiceOfferingRecord, baseURL, requestURL url.URL) ListResponse {
+	serviceOfferingResponses := make([]interface{}, 0, len(serviceOfferingRecords))
+	for _, serviceOffering := range serviceOfferingRecords {
+		serviceOfferingResponses = append(serviceOfferingResponses, ForServiceOffering(serviceOffering, baseURL))
+	}
+
+	return ForList(serviceOfferingResponses, baseURL, requestURL)
+}
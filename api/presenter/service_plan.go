@@ -0,0 +1,83 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	servicePlansBase = "/v3/service_plans"
+)
+
+type ServicePlanResponse struct {
+	GUID           string                   `json:"guid"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description"`
+	VisibilityType string                   `json:"visibility_type"`
+	Available      bool                     `json:"available"`
+	Free           bool                     `json:"free"`
+	Costs          []interface{}            `json:"costs"`
+	BrokerCatalog  ServicePlanBrokerCatalog `json:"broker_catalog"`
+	CreatedAt      string                   `json:"created_at"`
+	UpdatedAt      string                   `json:"updated_at"`
+	Relationships  Relationships            `json:"relationships"`
+	Metadata       Metadata                 `json:"metadata"`
+	Links          ServicePlanLinks         `json:"links"`
+}
+
+type ServicePlanBrokerCatalog struct {
+	ID       string            `json:"id"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+type ServicePlanLinks struct {
+	Self            Link `json:"self"`
+	ServiceOffering Link `json:"service_offering"`
+}
+
+func ForServicePlan(servicePlanRecord repositories.ServicePlanRecord, baseURL url.URL) ServicePlanResponse {
+	return ServicePlanResponse{
+		GUID:           servicePlanRecord.GUID,
+		Name:           servicePlanRecord.Name,
+		Description:    servicePlanRecord.Description,
+		VisibilityType: "public",
+		Available:      true,
+		Free:           servicePlanRecord.Free,
+		Costs:          []interface{}{},
+		BrokerCatalog: ServicePlanBrokerCatalog{
+			ID:       servicePlanRecord.CatalogID,
+			Metadata: map[string]string{},
+		},
+		CreatedAt: servicePlanRecord.CreatedAt,
+		UpdatedAt: servicePlanRecord.UpdatedAt,
+		Relationships: Relationships{
+			"service_offering": Relationship{
+				Data: &RelationshipData{
+					GUID: servicePlanRecord.ServiceOfferingGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(servicePlanRecord.Labels),
+			Annotations: orEmptyMap(servicePlanRecord.Annotations),
+		},
+		Links: ServicePlanLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(servicePlansBase, servicePlanRecord.GUID).build(),
+			},
+			ServiceOffering: Link{
+				HREF: buildURL(baseURL).appendPath(serviceOfferingsBase, servicePlanRecord.ServiceOfferingGUID).build(),
+			},
+		},
+	}
+}
+
+func ForServicePlanList(servicePlanRecords []repositories.ServicePlanRecord, baseURL, requestURL url.URL) ListResponse {
+	servicePlanResponses := make([]interface{}, 0, len(servicePlanRecords))
+	for _, servicePlan := range servicePlanRecords {
+		servicePlanResponses = append(servicePlanResponses, ForServicePlan(servicePlan, baseURL))
+	}
+
+	return ForList(servicePlanResponses, baseURL, requestURL)
+}
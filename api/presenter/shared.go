@@ -3,6 +3,10 @@ package presenter
 import (
 	"net/url"
 	"path"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
 )
 
 type Lifecycle struct {
@@ -42,35 +46,89 @@ type ListResponse struct {
 }
 
 type PaginationData struct {
-	TotalResults int     `json:"total_results"`
-	TotalPages   int     `json:"total_pages"`
-	First        PageRef `json:"first"`
-	Last         PageRef `json:"last"`
-	Next         *int    `json:"next"`
-	Previous     *int    `json:"previous"`
+	TotalResults int      `json:"total_results"`
+	TotalPages   int      `json:"total_pages"`
+	First        PageRef  `json:"first"`
+	Last         PageRef  `json:"last"`
+	Next         *PageRef `json:"next"`
+	Previous     *PageRef `json:"previous"`
 }
 
 type IncludedData struct {
-	Apps []interface{} `json:"apps"`
+	Apps          []interface{} `json:"apps,omitempty"`
+	Users         []interface{} `json:"users,omitempty"`
+	Spaces        []interface{} `json:"spaces,omitempty"`
+	Organizations []interface{} `json:"organizations,omitempty"`
 }
 
 type PageRef struct {
 	HREF string `json:"href"`
 }
 
+// ForList presents the page of resources requested by the page and per_page query parameters of the request
 func ForList(resources []interface{}, baseURL, requestURL url.URL) ListResponse {
+	pagination := payloads.PaginationFromQuery(requestURL.Query())
+	page := pagination.PageNumber()
+	perPage := pagination.PerPageNumber()
+
+	totalResults := len(resources)
+	start := (page - 1) * perPage
+	if start > totalResults {
+		start = totalResults
+	}
+	end := start + perPage
+	if end > totalResults {
+		end = totalResults
+	}
+
+	return ForListPage(resources[start:end], totalResults, baseURL, requestURL)
+}
+
+// ForListPage presents a page of resources that a repository has already paginated, out of totalResults resources
+func ForListPage(resources []interface{}, totalResults int, baseURL, requestURL url.URL) ListResponse {
+	pagination := payloads.PaginationFromQuery(requestURL.Query())
+	page := pagination.PageNumber()
+	perPage := pagination.PerPageNumber()
+
+	totalPages := (totalResults + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	paginationData := PaginationData{
+		TotalResults: totalResults,
+		TotalPages:   totalPages,
+		First:        pageRef(baseURL, requestURL, 1, perPage),
+		Last:         pageRef(baseURL, requestURL, totalPages, perPage),
+	}
+	if page < totalPages {
+		next := pageRef(baseURL, requestURL, page+1, perPage)
+		paginationData.Next = &next
+	}
+	if page > 1 {
+		previous := pageRef(baseURL, requestURL, page-1, perPage)
+		paginationData.Previous = &previous
+	}
+
 	return ListResponse{
-		PaginationData: PaginationData{
-			TotalResults: len(resources),
-			TotalPages:   1,
-			First: PageRef{
-				HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(requestURL.RawQuery).build(),
-			},
-			Last: PageRef{
-				HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(requestURL.RawQuery).build(),
-			},
-		},
-		Resources: resources,
+		PaginationData: paginationData,
+		Resources:      resources,
+	}
+}
+
+// pageRef keeps the other query parameters of the request as they were sent, so that filters are preserved verbatim
+func pageRef(baseURL, requestURL url.URL, page, perPage int) PageRef {
+	var params []string
+	for _, param := range strings.Split(requestURL.RawQuery, "&") {
+		if param == "" || strings.HasPrefix(param, "page=") || strings.HasPrefix(param, "per_page=") {
+			continue
+		}
+		params = append(params, param)
+	}
+	params = append(params, "page="+strconv.Itoa(page), "per_page="+strconv.Itoa(perPage))
+
+	return PageRef{
+		HREF: buildURL(baseURL).appendPath(requestURL.Path).setQuery(strings.Join(params, "&")).build(),
 	}
 }
 
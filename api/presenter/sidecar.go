@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SidecarResponse struct {
+	GUID          string        `json:"guid"`
+	Name          string        `json:"name"`
+	Command       string        `json:"command"`
+	ProcessTypes  []string      `json:"process_types"`
+	MemoryInMB    *int64        `json:"memory_in_mb"`
+	Origin        string        `json:"origin"`
+	Relationships Relationships `json:"relationships"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+}
+
+func ForSidecar(sidecarRecord repositories.SidecarRecord) SidecarResponse {
+	var memoryInMB *int64
+	if sidecarRecord.MemoryMB != 0 {
+		memoryInMB = &sidecarRecord.MemoryMB
+	}
+
+	processTypes := sidecarRecord.ProcessTypes
+	if processTypes == nil {
+		processTypes = []string{}
+	}
+
+	return SidecarResponse{
+		GUID:         sidecarRecord.GUID,
+		Name:         sidecarRecord.Name,
+		Command:      sidecarRecord.Command,
+		ProcessTypes: processTypes,
+		MemoryInMB:   memoryInMB,
+		Origin:       sidecarRecord.Origin,
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: sidecarRecord.AppGUID,
+				},
+			},
+		},
+		CreatedAt: sidecarRecord.CreatedAt,
+		UpdatedAt: sidecarRecord.UpdatedAt,
+	}
+}
+
+func ForSidecarList(sidecarRecords []repositories.SidecarRecord, baseURL, requestURL url.URL) ListResponse {
+	sidecarResponses := make([]interface{}, 0, len(sidecarRecords))
+	for _, sidecar := range sidecarRecords {
+		sidecarResponses = append(sidecarResponses, ForSidecar(sidecar))
+	}
+
+	return ForList(sidecarResponses, baseURL, requestURL)
+}
@@ -0,0 +1,98 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	tasksBase = "/v3/tasks"
+)
+
+type TaskResponse struct {
+	GUID          string        `json:"guid"`
+	SequenceID    int64         `json:"sequence_id"`
+	Name          string        `json:"name"`
+	Command       string        `json:"command,omitempty"`
+	State         string        `json:"state"`
+	MemoryInMB    int64         `json:"memory_in_mb"`
+	DiskInMB      int64         `json:"disk_in_mb"`
+	Result        TaskResult    `json:"result"`
+	DropletGUID   string        `json:"droplet_guid"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+	Relationships Relationships `json:"relationships"`
+	Metadata      Metadata      `json:"metadata"`
+	Links         TaskLinks     `json:"links"`
+}
+
+type TaskResult struct {
+	FailureReason *string `json:"failure_reason"`
+}
+
+type TaskLinks struct {
+	Self    Link `json:"self"`
+	App     Link `json:"app"`
+	Cancel  Link `json:"cancel"`
+	Droplet Link `json:"droplet"`
+}
+
+func ForTask(taskRecord repositories.TaskRecord, baseURL url.URL) TaskResponse {
+	var failureReason *string
+	if taskRecord.FailureReason != "" {
+		failureReason = &taskRecord.FailureReason
+	}
+
+	return TaskResponse{
+		GUID:        taskRecord.GUID,
+		SequenceID:  taskRecord.SequenceID,
+		Name:        taskRecord.Name,
+		Command:     taskRecord.Command,
+		State:       taskRecord.State,
+		MemoryInMB:  taskRecord.MemoryMB,
+		DiskInMB:    taskRecord.DiskMB,
+		Result:      TaskResult{FailureReason: failureReason},
+		DropletGUID: taskRecord.DropletGUID,
+		CreatedAt:   taskRecord.CreatedAt,
+		UpdatedAt:   taskRecord.UpdatedAt,
+		Relationships: Relationships{
+			"app": Relationship{
+				Data: &RelationshipData{
+					GUID: taskRecord.AppGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      orEmptyMap(taskRecord.Labels),
+			Annotations: orEmptyMap(taskRecord.Annotations),
+		},
+		Links: TaskLinks{
+			Self: Link{
+				HREF: buildURL(baseURL).appendPath(tasksBase, taskRecord.GUID).build(),
+			},
+			App: Link{
+				HREF: buildURL(baseURL).appendPath(appsBase, taskRecord.AppGUID).build(),
+			},
+			Cancel: Link{
+				HREF:   buildURL(baseURL).appendPath(tasksBase, taskRecord.GUID, "actions/cancel").build(),
+				Method: "POST",
+			},
+			Droplet: Link{
+				HREF: buildURL(baseURL).appendPath(dropletsBase, taskRecord.DropletGUID).build(),
+			},
+		},
+	}
+}
+
+// ForTaskList presents the tasks without their commands, which CF does not show in lists
+func ForTaskList(taskRecords []repositories.TaskRecord, baseURL, requestURL url.URL) ListResponse {
+	taskResponses := make([]interface{}, 0, len(taskRecords))
+	for _, task := range taskRecords {
+		taskResponse := ForTask(task, baseURL)
+		taskResponse.Command = ""
+		taskResponses = append(taskResponses, taskResponse)
+	}
+
+	return ForList(taskResponses, baseURL, requestURL)
+}
@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	usersBase = "/v3/users"
+)
+
+// UserResponse leaves the origin null for the users that are only known by the subjects of their roles
+type UserResponse struct {
+	GUID             string          `json:"guid"`
+	CreatedAt        string          `json:"created_at"`
+	UpdatedAt        string          `json:"updated_at"`
+	Username         string          `json:"username"`
+	PresentationName string          `json:"presentation_name"`
+	Origin           *string         `json:"origin"`
+	Metadata         Metadata        `json:"metadata"`
+	Links            map[string]Link `json:"links"`
+}
+
+func ForUser(user repositories.UserRecord, apiBaseURL url.URL) UserResponse {
+	return toUserResponse(user, apiBaseURL)
+}
+
+func ForUserList(users []repositories.UserRecord, apiBaseURL, requestURL url.URL) ListResponse {
+	userResponses := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, toUserResponse(user, apiBaseURL))
+	}
+
+	return ForList(userResponses, apiBaseURL, requestURL)
+}
+
+func toUserResponse(user repositories.UserRecord, apiBaseURL url.URL) UserResponse {
+	var origin *string
+	if user.Origin != "" {
+		origin = &user.Origin
+	}
+
+	return UserResponse{
+		GUID:             user.GUID,
+		CreatedAt:        user.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:        user.UpdatedAt.UTC().Format(time.RFC3339),
+		Username:         user.Username,
+		PresentationName: user.PresentationName,
+		Origin:           origin,
+		Metadata: Metadata{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Links: map[string]Link{
+			"self": {
+				HREF: buildURL(apiBaseURL).appendPath(usersBase, user.GUID).build(),
+			},
+		},
+	}
+}
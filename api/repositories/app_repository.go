@@ -133,10 +133,20 @@ type SetAppDesiredStateMessage struct {
 	DesiredState string
 }
 
+type PatchAppMessage struct {
+	AppGUID       string
+	SpaceGUID     string
+	Name          *string
+	Buildpacks    *[]string
+	Stack         *string
+	MetadataPatch MetadataPatch
+}
+
 type ListAppsMessage struct {
-	Names      []string
-	Guids      []string
-	SpaceGuids []string
+	Names         []string
+	Guids         []string
+	SpaceGuids    []string
+	LabelSelector string
 }
 
 type byName []AppRecord
@@ -246,17 +256,27 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 		return []AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []AppRecord{}, err
+	}
+
 	var filteredApps []workloadsv1alpha1.CFApp
 	for ns := range nsList {
+		if !matchesFilter(ns, message.SpaceGuids) {
+			continue
+		}
+
 		appList := &workloadsv1alpha1.CFAppList{}
-		err := userClient.List(ctx, appList, client.InNamespace(ns))
+		err := listInChunks(ctx, userClient, appList, func() {
+			filteredApps = append(filteredApps, applyAppListFilter(appList.Items, message)...)
+		}, client.InNamespace(ns), labelSelector)
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
 			return []AppRecord{}, fmt.Errorf("failed to list apps in namespace %s: %w", ns, apierrors.FromK8sError(err, AppResourceType))
 		}
-		filteredApps = append(filteredApps, applyAppListFilter(appList.Items, message)...)
 	}
 
 	appRecords := returnAppList(filteredApps)
@@ -267,6 +287,34 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 	return appRecords, nil
 }
 
+// ListSpaceApps lists one page of the apps of a space, along with the number of apps the space has. Unlike ListApps,
+// which sorts apps by name, the apps are in GUID order, as that is the order the Kubernetes API pages them in.
+func (f *AppRepo) ListSpaceApps(ctx context.Context, authInfo authorization.Info, spaceGUID, labelSelector string, page Page) ([]AppRecord, int, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	labelSelectorOption, err := labelSelectorListOption(labelSelector)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	appList := &workloadsv1alpha1.CFAppList{}
+	appRecords := []AppRecord{}
+	total, err := listPage(ctx, userClient, appList, page, func() {
+		appRecords = returnAppList(appList.Items)
+	}, client.InNamespace(spaceGUID), labelSelectorOption)
+	if k8serrors.IsForbidden(err) {
+		return []AppRecord{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list apps in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return appRecords, total, nil
+}
+
 func applyAppListFilter(appList []workloadsv1alpha1.CFApp, message ListAppsMessage) []workloadsv1alpha1.CFApp {
 	nameFilterSpecified := len(message.Names) > 0
 	guidsFilterSpecified := len(message.Guids) > 0
@@ -444,6 +492,45 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 	return cfAppToAppRecord(*cfApp), nil
 }
 
+func (f *AppRepo) PatchApp(ctx context.Context, authInfo authorization.Info, message PatchAppMessage) (AppRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	baseCFApp := new(workloadsv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, baseCFApp)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	cfApp := baseCFApp.DeepCopy()
+	if message.Name != nil {
+		cfApp.Spec.Name = *message.Name
+	}
+	if message.Buildpacks != nil {
+		cfApp.Spec.Lifecycle.Data.Buildpacks = *message.Buildpacks
+	}
+	if message.Stack != nil {
+		cfApp.Spec.Lifecycle.Data.Stack = *message.Stack
+	}
+	message.MetadataPatch.apply(cfApp)
+
+	err = userClient.Patch(ctx, cfApp, client.MergeFrom(baseCFApp))
+	if err != nil {
+		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == workloads.DuplicateAppErrorType {
+				return AppRecord{}, apierrors.NewUniquenessError(err, validationError.Error())
+			}
+			return AppRecord{}, apierrors.NewUnprocessableEntityError(err, validationError.Error())
+		}
+
+		return AppRecord{}, fmt.Errorf("failed to patch app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppRecord(*cfApp), nil
+}
+
 func (f *AppRepo) DeleteApp(ctx context.Context, authInfo authorization.Info, message DeleteAppMessage) error {
 	cfApp := &workloadsv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -497,8 +584,8 @@ func (m *CreateAppMessage) toCFApp() workloadsv1alpha1.CFApp {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
-			Labels:      m.Labels,
-			Annotations: m.Annotations,
+			Labels:      withCFMetadata(nil, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
 		},
 		Spec: workloadsv1alpha1.CFAppSpec{
 			Name:          m.Name,
@@ -525,8 +612,8 @@ func cfAppToAppRecord(cfApp workloadsv1alpha1.CFApp) AppRecord {
 		Name:        cfApp.Spec.Name,
 		SpaceGUID:   cfApp.Namespace,
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
-		Labels:      cfApp.Labels,
-		Annotations: cfApp.Annotations,
+		Labels:      cfMetadata(cfApp.Labels),
+		Annotations: cfMetadata(cfApp.Annotations),
 		State:       DesiredState(cfApp.Spec.DesiredState),
 		Lifecycle: Lifecycle{
 			Type: string(cfApp.Spec.Lifecycle.Type),
@@ -347,6 +347,72 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("ListSpaceApps", func() {
+		var (
+			cfApp2  *workloadsv1alpha1.CFApp
+			page    Page
+			appList []AppRecord
+			total   int
+			listErr error
+		)
+
+		BeforeEach(func() {
+			cfApp2 = createApp(space.Name)
+			createApp(createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space2")).Name)
+
+			page = Page{Number: 1, PerPage: 50}
+		})
+
+		JustBeforeEach(func() {
+			appList, total, listErr = appRepo.ListSpaceApps(testCtx, authInfo, space.Name, "", page)
+		})
+
+		It("returns no apps to unauthorized users", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(total).To(BeZero())
+			Expect(appList).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the apps of the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(total).To(Equal(2))
+				Expect(appList).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfApp.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfApp2.Name)}),
+				))
+			})
+
+			When("the page holds a single app", func() {
+				BeforeEach(func() {
+					page = Page{Number: 2, PerPage: 1}
+				})
+
+				It("returns that page and the number of apps of the space", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(total).To(Equal(2))
+					Expect(appList).To(HaveLen(1))
+				})
+			})
+
+			When("the page is past the last app", func() {
+				BeforeEach(func() {
+					page = Page{Number: 3, PerPage: 1}
+				})
+
+				It("returns no apps", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(total).To(Equal(2))
+					Expect(appList).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("CreateApp", func() {
 		const (
 			testAppName = "test-app-name"
@@ -825,6 +891,96 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("PatchApp", func() {
+		var (
+			message   PatchAppMessage
+			appRecord AppRecord
+			patchErr  error
+		)
+
+		BeforeEach(func() {
+			cfApp.Labels = map[string]string{
+				"metadata.cloudfoundry.org/env":        "dev",
+				"metadata.cloudfoundry.org/deprecated": "true",
+				"korifi.example.com/internal":          "keep",
+			}
+			Expect(k8sClient.Update(testCtx, cfApp)).To(Succeed())
+
+			newName := "new-name"
+			stack := "cflinuxfs4"
+			prod := "prod"
+			message = PatchAppMessage{
+				AppGUID:    cfApp.Name,
+				SpaceGUID:  space.Name,
+				Name:       &newName,
+				Buildpacks: &[]string{"go_buildpack"},
+				Stack:      &stack,
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"env": &prod, "deprecated": nil},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			appRecord, patchErr = appRepo.PatchApp(testCtx, authInfo, message)
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the patched app", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(appRecord.GUID).To(Equal(cfApp.Name))
+				Expect(appRecord.Name).To(Equal("new-name"))
+				Expect(appRecord.Lifecycle.Data.Buildpacks).To(Equal([]string{"go_buildpack"}))
+				Expect(appRecord.Lifecycle.Data.Stack).To(Equal("cflinuxfs4"))
+				Expect(appRecord.Labels).To(Equal(map[string]string{"env": "prod"}))
+			})
+
+			It("updates the app and only touches its CF labels", func() {
+				updatedCFApp := new(workloadsv1alpha1.CFApp)
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(cfApp), updatedCFApp)).To(Succeed())
+				Expect(updatedCFApp.Spec.Name).To(Equal("new-name"))
+				Expect(updatedCFApp.Labels).To(Equal(map[string]string{
+					"metadata.cloudfoundry.org/env": "prod",
+					"korifi.example.com/internal":   "keep",
+				}))
+			})
+
+			When("only the metadata is patched", func() {
+				BeforeEach(func() {
+					message.Name = nil
+					message.Buildpacks = nil
+					message.Stack = nil
+				})
+
+				It("leaves the app unchanged", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(appRecord.Name).To(Equal(cfApp.Spec.Name))
+					Expect(appRecord.Lifecycle.Data.Buildpacks).To(Equal([]string{"java"}))
+				})
+			})
+
+			When("the app doesn't exist", func() {
+				BeforeEach(func() {
+					message.AppGUID = "no-such-app"
+				})
+
+				It("returns a not found error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("DeleteApp", func() {
 		var (
 			appGUID      string
@@ -0,0 +1,413 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=list;create;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=list
+
+const (
+	AuditEventResourceType = "Audit Event"
+
+	AuditEventLabel           = "korifi.cloudfoundry.org/audit-event"
+	AuditEventTypeLabel       = "korifi.cloudfoundry.org/audit-event-type"
+	AuditEventTargetGUIDLabel = "korifi.cloudfoundry.org/audit-event-target-guid"
+
+	auditEventTypeKey             = "type"
+	auditEventActorGUIDKey        = "actor_guid"
+	auditEventActorTypeKey        = "actor_type"
+	auditEventActorNameKey        = "actor_name"
+	auditEventTargetGUIDKey       = "target_guid"
+	auditEventTargetTypeKey       = "target_type"
+	auditEventTargetNameKey       = "target_name"
+	auditEventSpaceGUIDKey        = "space_guid"
+	auditEventOrganizationGUIDKey = "organization_guid"
+	auditEventDataKey             = "data"
+)
+
+type AuditEventActor struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventTarget struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventRecord struct {
+	GUID             string
+	Type             string
+	Actor            AuditEventActor
+	Target           AuditEventTarget
+	SpaceGUID        string
+	OrganizationGUID string
+	Data             map[string]interface{}
+	CreatedAt        string
+	UpdatedAt        string
+}
+
+type CreateAuditEventMessage struct {
+	Type             string
+	Actor            AuditEventActor
+	Target           AuditEventTarget
+	SpaceGUID        string
+	OrganizationGUID string
+	Data             map[string]interface{}
+}
+
+// TimeFilter matches timestamps that equal any of Equals and lie within the bounds that are set
+type TimeFilter struct {
+	Equals             []time.Time
+	GreaterThan        *time.Time
+	GreaterThanOrEqual *time.Time
+	LessThan           *time.Time
+	LessThanOrEqual    *time.Time
+}
+
+type ListAuditEventsMessage struct {
+	Types       []string
+	TargetGUIDs []string
+	SpaceGUIDs  []string
+	CreatedAts  TimeFilter
+}
+
+// AuditEventRepo stores audit events as ConfigMaps in the root namespace, so that the trail outlives the
+// spaces and orgs it refers to. The type and target of the events are also stored as labels, so that lists are
+// filtered by the Kubernetes API. Crashes of app processes are recorded by the controllers as Kubernetes
+// events on the CFApp and are listed alongside.
+type AuditEventRepo struct {
+	rootNamespace        string
+	privilegedClient     client.Client
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewAuditEventRepo(rootNamespace string, privilegedClient client.Client, namespacePermissions *authorization.NamespacePermissions) *AuditEventRepo {
+	return &AuditEventRepo{
+		rootNamespace:        rootNamespace,
+		privilegedClient:     privilegedClient,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+func (r *AuditEventRepo) CreateAuditEvent(ctx context.Context, message CreateAuditEventMessage) (AuditEventRecord, error) {
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to marshal audit event data: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+			Labels: map[string]string{
+				AuditEventLabel:           "true",
+				AuditEventTypeLabel:       message.Type,
+				AuditEventTargetGUIDLabel: message.Target.GUID,
+			},
+		},
+		Data: map[string]string{
+			auditEventTypeKey:             message.Type,
+			auditEventActorGUIDKey:        message.Actor.GUID,
+			auditEventActorTypeKey:        message.Actor.Type,
+			auditEventActorNameKey:        message.Actor.Name,
+			auditEventTargetGUIDKey:       message.Target.GUID,
+			auditEventTargetTypeKey:       message.Target.Type,
+			auditEventTargetNameKey:       message.Target.Name,
+			auditEventSpaceGUIDKey:        message.SpaceGUID,
+			auditEventOrganizationGUIDKey: message.OrganizationGUID,
+			auditEventDataKey:             string(data),
+		},
+	}
+
+	if err := r.privilegedClient.Create(ctx, configMap); err != nil {
+		return AuditEventRecord{}, apierrors.FromK8sError(err, AuditEventResourceType)
+	}
+
+	return configMapToAuditEventRecord(*configMap)
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	visibility := auditEventVisibility{
+		privilegedClient: r.privilegedClient,
+		authorizedSpaces: authorizedSpaces,
+		authorizedOrgs:   authorizedOrgs,
+		existingSpaces:   map[string]bool{},
+	}
+
+	selector, err := auditEventSelector(message)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []AuditEventRecord{}
+
+	var recordErrs []error
+	configMapList := &corev1.ConfigMapList{}
+	err = listInChunks(ctx, r.privilegedClient, configMapList, func() {
+		for _, configMap := range configMapList.Items {
+			record, recordErr := configMapToAuditEventRecord(configMap)
+			if recordErr != nil {
+				recordErrs = append(recordErrs, recordErr)
+				continue
+			}
+			records = append(records, record)
+		}
+	}, client.InNamespace(r.rootNamespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+	if len(recordErrs) > 0 {
+		return nil, recordErrs[0]
+	}
+
+	for spaceGUID := range authorizedSpaces {
+		eventList := &corev1.EventList{}
+		err = r.privilegedClient.List(ctx, eventList, client.InNamespace(spaceGUID), client.MatchingFields{"reason": workloadsv1alpha1.AppProcessCrashEventReason})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list crash events in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, AuditEventResourceType))
+		}
+
+		for _, event := range eventList.Items {
+			records = append(records, crashEventToAuditEventRecord(event))
+		}
+	}
+
+	filteredRecords := []AuditEventRecord{}
+	for _, record := range records {
+		if !matchesFilter(record.Type, message.Types) ||
+			!matchesFilter(record.Target.GUID, message.TargetGUIDs) ||
+			!matchesFilter(record.SpaceGUID, message.SpaceGUIDs) ||
+			!message.CreatedAts.matches(record.CreatedAt) {
+			continue
+		}
+
+		visible, err := visibility.isVisible(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+
+		if visible {
+			filteredRecords = append(filteredRecords, record)
+		}
+	}
+
+	sort.SliceStable(filteredRecords, func(i, j int) bool {
+		return filteredRecords[i].CreatedAt < filteredRecords[j].CreatedAt
+	})
+
+	return filteredRecords, nil
+}
+
+// DeleteAuditEventsBefore deletes the audit events created before cutoff. Crash events are Kubernetes events, which
+// expire on their own.
+func (r *AuditEventRepo) DeleteAuditEventsBefore(ctx context.Context, cutoff time.Time) error {
+	var expired []corev1.ConfigMap
+	configMapList := &corev1.ConfigMapList{}
+	err := listInChunks(ctx, r.privilegedClient, configMapList, func() {
+		for _, configMap := range configMapList.Items {
+			if configMap.CreationTimestamp.Time.Before(cutoff) {
+				expired = append(expired, configMap)
+			}
+		}
+	}, client.InNamespace(r.rootNamespace), client.MatchingLabels{AuditEventLabel: "true"})
+	if err != nil {
+		return fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	for i := range expired {
+		err = r.privilegedClient.Delete(ctx, &expired[i])
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete audit event %q: %w", expired[i].Name, apierrors.FromK8sError(err, AuditEventResourceType))
+		}
+	}
+
+	return nil
+}
+
+// auditEventSelector selects the audit event ConfigMaps with any of the types and target GUIDs of the message
+func auditEventSelector(message ListAuditEventsMessage) (labels.Selector, error) {
+	requirement, err := labels.NewRequirement(AuditEventLabel, selection.Equals, []string{"true"})
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.NewSelector().Add(*requirement)
+
+	for key, values := range map[string][]string{AuditEventTypeLabel: message.Types, AuditEventTargetGUIDLabel: message.TargetGUIDs} {
+		if len(values) == 0 {
+			continue
+		}
+
+		requirement, err = labels.NewRequirement(key, selection.In, values)
+		if err != nil {
+			return nil, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Invalid audit event filter: %s", err))
+		}
+		selector = selector.Add(*requirement)
+	}
+
+	return selector, nil
+}
+
+// auditEventVisibility decides which audit events a user may see. Events that belong to a space are visible to
+// users with a role in that space. Once the space is gone they become visible to users with a role in its org,
+// as are events that only belong to an org.
+type auditEventVisibility struct {
+	privilegedClient client.Client
+	authorizedSpaces map[string]bool
+	authorizedOrgs   map[string]bool
+	existingSpaces   map[string]bool
+}
+
+func (v *auditEventVisibility) isVisible(ctx context.Context, record AuditEventRecord) (bool, error) {
+	if record.SpaceGUID != "" {
+		if v.authorizedSpaces[record.SpaceGUID] {
+			return true, nil
+		}
+
+		spaceExists, err := v.spaceExists(ctx, record.SpaceGUID)
+		if err != nil || spaceExists {
+			return false, err
+		}
+	}
+
+	return record.OrganizationGUID != "" && v.authorizedOrgs[record.OrganizationGUID], nil
+}
+
+func (v *auditEventVisibility) spaceExists(ctx context.Context, spaceGUID string) (bool, error) {
+	if exists, ok := v.existingSpaces[spaceGUID]; ok {
+		return exists, nil
+	}
+
+	err := v.privilegedClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, &corev1.Namespace{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	v.existingSpaces[spaceGUID] = err == nil
+	return err == nil, nil
+}
+
+func (f TimeFilter) matches(timestamp string) bool {
+	t, err := time.Parse(TimestampFormat, timestamp)
+	if err != nil {
+		return false
+	}
+
+	if len(f.Equals) > 0 {
+		matchesAny := false
+		for _, equals := range f.Equals {
+			if t.Equal(equals) {
+				matchesAny = true
+				break
+			}
+		}
+		if !matchesAny {
+			return false
+		}
+	}
+
+	return (f.GreaterThan == nil || t.After(*f.GreaterThan)) &&
+		(f.GreaterThanOrEqual == nil || !t.Before(*f.GreaterThanOrEqual)) &&
+		(f.LessThan == nil || t.Before(*f.LessThan)) &&
+		(f.LessThanOrEqual == nil || !t.After(*f.LessThanOrEqual))
+}
+
+func configMapToAuditEventRecord(configMap corev1.ConfigMap) (AuditEventRecord, error) {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&configMap.ObjectMeta)
+
+	record := AuditEventRecord{
+		GUID: configMap.Name,
+		Type: configMap.Data[auditEventTypeKey],
+		Actor: AuditEventActor{
+			GUID: configMap.Data[auditEventActorGUIDKey],
+			Type: configMap.Data[auditEventActorTypeKey],
+			Name: configMap.Data[auditEventActorNameKey],
+		},
+		Target: AuditEventTarget{
+			GUID: configMap.Data[auditEventTargetGUIDKey],
+			Type: configMap.Data[auditEventTargetTypeKey],
+			Name: configMap.Data[auditEventTargetNameKey],
+		},
+		SpaceGUID:        configMap.Data[auditEventSpaceGUIDKey],
+		OrganizationGUID: configMap.Data[auditEventOrganizationGUIDKey],
+		Data:             map[string]interface{}{},
+		CreatedAt:        formatTimestamp(configMap.CreationTimestamp),
+		UpdatedAt:        updatedAtTime,
+	}
+
+	if data, ok := configMap.Data[auditEventDataKey]; ok && data != "" {
+		if err := json.Unmarshal([]byte(data), &record.Data); err != nil {
+			return AuditEventRecord{}, fmt.Errorf("failed to unmarshal data of audit event %q: %w", configMap.Name, err)
+		}
+	}
+
+	return record, nil
+}
+
+func crashEventToAuditEventRecord(event corev1.Event) AuditEventRecord {
+	updatedAt := ""
+	if !event.LastTimestamp.IsZero() {
+		updatedAt = formatTimestamp(event.LastTimestamp)
+	}
+
+	data := map[string]interface{}{
+		"index":            event.Annotations[workloadsv1alpha1.CFInstanceIndexAnnotationKey],
+		"reason":           event.Annotations[workloadsv1alpha1.CFExitReasonAnnotationKey],
+		"exit_description": event.Annotations[workloadsv1alpha1.CFExitDescriptionAnnotationKey],
+		"crash_count":      event.Count,
+	}
+	if index, err := strconv.Atoi(event.Annotations[workloadsv1alpha1.CFInstanceIndexAnnotationKey]); err == nil {
+		data["index"] = index
+	}
+	if exitStatus, err := strconv.Atoi(event.Annotations[workloadsv1alpha1.CFExitStatusAnnotationKey]); err == nil {
+		data["exit_status"] = exitStatus
+	}
+
+	return AuditEventRecord{
+		GUID: string(event.UID),
+		Type: event.Reason,
+		Actor: AuditEventActor{
+			GUID: event.Annotations[workloadsv1alpha1.CFProcessGUIDLabelKey],
+			Type: "process",
+			Name: event.Annotations[workloadsv1alpha1.CFProcessTypeLabelKey],
+		},
+		Target: AuditEventTarget{
+			GUID: event.InvolvedObject.Name,
+			Type: "app",
+		},
+		SpaceGUID: event.Namespace,
+		Data:      data,
+		CreatedAt: formatTimestamp(event.CreationTimestamp),
+		UpdatedAt: updatedAt,
+	}
+}
@@ -0,0 +1,204 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		testCtx        context.Context
+		auditEventRepo *repositories.AuditEventRepo
+		org            *hnsv1alpha2.SubnamespaceAnchor
+		space          *hnsv1alpha2.SubnamespaceAnchor
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		auditEventRepo = repositories.NewAuditEventRepo(rootNamespace, k8sClient, nsPerms)
+
+		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space"))
+	})
+
+	Describe("CreateAuditEvent", func() {
+		var (
+			record    repositories.AuditEventRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			record, createErr = auditEventRepo.CreateAuditEvent(testCtx, repositories.CreateAuditEventMessage{
+				Type:             "audit.app.update",
+				Actor:            repositories.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+				Target:           repositories.AuditEventTarget{GUID: "some-app-guid", Type: "app", Name: "some-app"},
+				SpaceGUID:        space.Name,
+				OrganizationGUID: org.Name,
+				Data:             map[string]interface{}{"request": map[string]interface{}{"name": "some-app"}},
+			})
+		})
+
+		It("returns the created event", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(record.GUID).NotTo(BeEmpty())
+			Expect(record.Type).To(Equal("audit.app.update"))
+			Expect(record.Actor).To(Equal(repositories.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"}))
+			Expect(record.Target).To(Equal(repositories.AuditEventTarget{GUID: "some-app-guid", Type: "app", Name: "some-app"}))
+			Expect(record.SpaceGUID).To(Equal(space.Name))
+			Expect(record.OrganizationGUID).To(Equal(org.Name))
+			Expect(record.Data).To(Equal(map[string]interface{}{"request": map[string]interface{}{"name": "some-app"}}))
+
+			createdAt, err := time.Parse(time.RFC3339, record.CreatedAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdAt).To(BeTemporally("~", time.Now(), timeCheckThreshold*time.Second))
+		})
+
+		It("labels the event with its type and target", func() {
+			configMap := new(corev1.ConfigMap)
+			Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, configMap)).To(Succeed())
+			Expect(configMap.Labels).To(HaveKeyWithValue(repositories.AuditEventTypeLabel, "audit.app.update"))
+			Expect(configMap.Labels).To(HaveKeyWithValue(repositories.AuditEventTargetGUIDLabel, "some-app-guid"))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("lists the event", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(ContainElement(record))
+			})
+
+			It("filters the events by type", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{
+					Types: []string{"audit.app.start"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).NotTo(ContainElement(record))
+			})
+
+			It("filters the events by target guid", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{
+					TargetGUIDs: []string{"some-app-guid"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(ContainElement(record))
+			})
+
+			It("excludes the events of other targets", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{
+					TargetGUIDs: []string{"another-app-guid"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).NotTo(ContainElement(record))
+			})
+
+			It("filters the events by creation time", func() {
+				future := time.Now().Add(time.Hour)
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{
+					CreatedAts: repositories.TimeFilter{GreaterThan: &future},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).NotTo(ContainElement(record))
+			})
+		})
+
+		When("the user is only an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("does not list the space event", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).NotTo(ContainElement(record))
+			})
+		})
+
+		When("the user has no roles", func() {
+			It("does not list the event", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).NotTo(ContainElement(record))
+			})
+		})
+	})
+
+	Describe("DeleteAuditEventsBefore", func() {
+		var (
+			record    repositories.AuditEventRecord
+			cutoff    time.Time
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			var err error
+			record, err = auditEventRepo.CreateAuditEvent(testCtx, repositories.CreateAuditEventMessage{
+				Type:             "audit.organization.update",
+				Target:           repositories.AuditEventTarget{GUID: org.Name, Type: "organization"},
+				OrganizationGUID: org.Name,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			cutoff = time.Now().Add(-time.Hour)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = auditEventRepo.DeleteAuditEventsBefore(testCtx, cutoff)
+		})
+
+		It("keeps the events created after the cutoff", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, new(corev1.ConfigMap))).To(Succeed())
+		})
+
+		When("the events were created before the cutoff", func() {
+			BeforeEach(func() {
+				cutoff = time.Now().Add(time.Hour)
+			})
+
+			It("deletes them", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				err := k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, new(corev1.ConfigMap))
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("org events", func() {
+		var record repositories.AuditEventRecord
+
+		BeforeEach(func() {
+			var err error
+			record, err = auditEventRepo.CreateAuditEvent(testCtx, repositories.CreateAuditEventMessage{
+				Type:             "audit.organization.update",
+				Actor:            repositories.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+				Target:           repositories.AuditEventTarget{GUID: org.Name, Type: "organization", Name: "my-org"},
+				OrganizationGUID: org.Name,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("lists the event", func() {
+				events, err := auditEventRepo.ListAuditEvents(testCtx, authInfo, repositories.ListAuditEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events).To(ContainElement(record))
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -10,8 +11,10 @@ import (
 
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -46,14 +49,16 @@ type BuildRecord struct {
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfbuilds/status,verbs=get
 
 type BuildRepo struct {
-	namespaceRetriever NamespaceRetriever
-	userClientFactory  UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
 }
 
-func NewBuildRepo(namespaceRetriever NamespaceRetriever, userClientFactory UserK8sClientFactory) *BuildRepo {
+func NewBuildRepo(namespaceRetriever NamespaceRetriever, userClientFactory UserK8sClientFactory, namespacePermissions *authorization.NamespacePermissions) *BuildRepo {
 	return &BuildRepo{
-		namespaceRetriever: namespaceRetriever,
-		userClientFactory:  userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
 	}
 }
 
@@ -76,6 +81,123 @@ func (b *BuildRepo) GetBuild(ctx context.Context, authInfo authorization.Info, b
 	return cfBuildToBuildRecord(build), nil
 }
 
+func (b *BuildRepo) ListBuilds(ctx context.Context, authInfo authorization.Info, message ListBuildsMessage) ([]BuildRecord, error) {
+	nsList, err := b.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := b.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []BuildRecord{}, fmt.Errorf("list-builds failed to build user client: %w", err)
+	}
+
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []BuildRecord{}, err
+	}
+
+	records := []BuildRecord{}
+	for ns := range nsList {
+		buildList := &workloadsv1alpha1.CFBuildList{}
+		err = listInChunks(ctx, userClient, buildList, func() {
+			for _, build := range buildList.Items {
+				if matchesFilter(build.Spec.AppRef.Name, message.AppGUIDs) && matchesFilter(build.Spec.PackageRef.Name, message.PackageGUIDs) {
+					records = append(records, cfBuildToBuildRecord(build))
+				}
+			}
+		}, client.InNamespace(ns), labelSelector)
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []BuildRecord{}, fmt.Errorf("failed to list builds in namespace %s: %w", ns, apierrors.FromK8sError(err, BuildResourceType))
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt < records[j].CreatedAt
+	})
+
+	return records, nil
+}
+
+func (b *BuildRepo) GetLatestBuildByAppGUID(ctx context.Context, authInfo authorization.Info, spaceGUID string, appGUID string) (BuildRecord, error) {
+	userClient, err := b.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return BuildRecord{}, fmt.Errorf("get-latest-build failed to build user client: %w", err)
+	}
+
+	buildList := &workloadsv1alpha1.CFBuildList{}
+	if err := userClient.List(ctx, buildList, client.InNamespace(spaceGUID)); err != nil {
+		return BuildRecord{}, fmt.Errorf("failed to list builds: %w", apierrors.FromK8sError(err, BuildResourceType))
+	}
+
+	var appBuilds []workloadsv1alpha1.CFBuild
+	for _, build := range buildList.Items {
+		if build.Spec.AppRef.Name == appGUID {
+			appBuilds = append(appBuilds, build)
+		}
+	}
+
+	if len(appBuilds) == 0 {
+		return BuildRecord{}, apierrors.NewNotFoundError(fmt.Errorf("no builds found for app %q in space %q", appGUID, spaceGUID), BuildResourceType)
+	}
+
+	sort.Slice(appBuilds, func(i, j int) bool {
+		return appBuilds[j].CreationTimestamp.Before(&appBuilds[i].CreationTimestamp)
+	})
+
+	return cfBuildToBuildRecord(appBuilds[0]), nil
+}
+
+// GetBuildLogs returns the logs of the kpack build pods that staged the given build.
+// kpack propagates the CFBuild guid label from the Image to its Builds and their pods.
+func (b *BuildRepo) GetBuildLogs(ctx context.Context, authInfo authorization.Info, message BuildLogsMessage) ([]LogRecord, error) {
+	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+		workloadsv1alpha1.CFBuildGUIDLabelKey: message.BuildGUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := b.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get-build-logs failed to build user client: %w", err)
+	}
+
+	podList := corev1.PodList{}
+	err = userClient.List(ctx, &podList, &client.ListOptions{Namespace: message.SpaceGUID, LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list build pods: %w", apierrors.FromK8sError(err, LogResourceType))
+	}
+
+	k8sClient, err := b.userClientFactory.BuildK8sClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get-build-logs failed to build user k8s client: %w", err)
+	}
+
+	var records []LogRecord
+	for _, pod := range podList.Items {
+		// the buildpack lifecycle phases run as init containers, in order
+		containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
+		for _, container := range containers {
+			containerLogs, err := readContainerLogs(ctx, k8sClient, pod, container.Name, message.StartTime, message.TailLines, message.Limit)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range containerLogs {
+				containerLogs[i].SourceType = StagingLogSourceType
+				containerLogs[i].InstanceID = "0"
+			}
+			records = append(records, containerLogs...)
+		}
+	}
+
+	return records, nil
+}
+
 func cfBuildToBuildRecord(cfBuild workloadsv1alpha1.CFBuild) BuildRecord {
 	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfBuild.ObjectMeta)
 
@@ -97,8 +219,8 @@ func cfBuildToBuildRecord(cfBuild workloadsv1alpha1.CFBuild) BuildRecord {
 		PackageGUID: cfBuild.Spec.PackageRef.Name,
 		DropletGUID: "",
 		AppGUID:     cfBuild.Spec.AppRef.Name,
-		Labels:      cfBuild.Labels,
-		Annotations: cfBuild.Annotations,
+		Labels:      cfMetadata(cfBuild.Labels),
+		Annotations: cfMetadata(cfBuild.Annotations),
 	}
 
 	if cfBuild.Spec.Lifecycle.Data.Buildpacks != nil {
@@ -136,6 +258,23 @@ func (b *BuildRepo) CreateBuild(ctx context.Context, authInfo authorization.Info
 	return cfBuildToBuildRecord(cfBuild), nil
 }
 
+type BuildLogsMessage struct {
+	SpaceGUID string
+	BuildGUID string
+	// StartTime is the earliest log timestamp to return, in nanoseconds since the epoch
+	StartTime int64
+	// TailLines, if set, limits the number of lines read from the end of each container log
+	TailLines *int64
+	// Limit, if set, stops reading each container log once that many lines have been read
+	Limit int64
+}
+
+type ListBuildsMessage struct {
+	AppGUIDs      []string
+	PackageGUIDs  []string
+	LabelSelector string
+}
+
 type CreateBuildMessage struct {
 	AppGUID         string
 	OwnerRef        metav1.OwnerReference
@@ -154,8 +293,8 @@ func (m CreateBuildMessage) toCFBuild() workloadsv1alpha1.CFBuild {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
-			Labels:      m.Labels,
-			Annotations: m.Annotations,
+			Labels:      withCFMetadata(nil, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
 			OwnerReferences: []metav1.OwnerReference{
 				m.OwnerRef,
 			},
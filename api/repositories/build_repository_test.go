@@ -25,7 +25,7 @@ var _ = Describe("BuildRepository", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 
-		buildRepo = repositories.NewBuildRepo(namespaceRetriever, userClientFactory)
+		buildRepo = repositories.NewBuildRepo(namespaceRetriever, userClientFactory, nsPerms)
 	})
 
 	Describe("GetBuild", func() {
@@ -275,6 +275,141 @@ var _ = Describe("BuildRepository", func() {
 		})
 	})
 
+	Describe("GetLatestBuildByAppGUID", func() {
+		const appGUID = "the-app-guid"
+
+		var (
+			namespace     *corev1.Namespace
+			olderBuild    *workloadsv1alpha1.CFBuild
+			newerBuild    *workloadsv1alpha1.CFBuild
+			buildRecord   repositories.BuildRecord
+			getBuildErr   error
+			lookupAppGUID string
+		)
+
+		makeAppBuild := func(appGUID string) *workloadsv1alpha1.CFBuild {
+			return &workloadsv1alpha1.CFBuild{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: namespace.Name,
+				},
+				Spec: workloadsv1alpha1.CFBuildSpec{
+					PackageRef: corev1.LocalObjectReference{Name: "the-package-guid"},
+					AppRef:     corev1.LocalObjectReference{Name: appGUID},
+					Lifecycle: workloadsv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: generateGUID()}}
+			Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+			lookupAppGUID = appGUID
+
+			olderBuild = makeAppBuild(appGUID)
+			Expect(k8sClient.Create(ctx, olderBuild)).To(Succeed())
+			// creation timestamps have a one second resolution
+			time.Sleep(1100 * time.Millisecond)
+			newerBuild = makeAppBuild(appGUID)
+			Expect(k8sClient.Create(ctx, newerBuild)).To(Succeed())
+			Expect(k8sClient.Create(ctx, makeAppBuild("another-app-guid"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			buildRecord, getBuildErr = buildRepo.GetLatestBuildByAppGUID(ctx, authInfo, namespace.Name, lookupAppGUID)
+		})
+
+		When("the user is authorized in the namespace", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, namespace.Name)
+			})
+
+			It("returns the most recently created build of the app", func() {
+				Expect(getBuildErr).NotTo(HaveOccurred())
+				Expect(buildRecord.GUID).To(Equal(newerBuild.Name))
+				Expect(buildRecord.AppGUID).To(Equal(appGUID))
+			})
+
+			When("the app has no builds", func() {
+				BeforeEach(func() {
+					lookupAppGUID = "app-without-builds"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getBuildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized in the namespace", func() {
+			It("returns a forbidden error", func() {
+				Expect(getBuildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetBuildLogs", func() {
+		var (
+			namespace *corev1.Namespace
+			buildGUID string
+		)
+
+		BeforeEach(func() {
+			namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: generateGUID()}}
+			Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+			buildGUID = generateGUID()
+
+			Expect(k8sClient.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "build-pod",
+					Namespace: namespace.Name,
+					Labels: map[string]string{
+						workloadsv1alpha1.CFBuildGUIDLabelKey: buildGUID,
+					},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "detect", Image: "lifecycle"}},
+					Containers:     []corev1.Container{{Name: "completion", Image: "completion"}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+		})
+
+		When("the user is authorized in the namespace", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, namespace.Name)
+			})
+
+			It("returns no logs for build pods that have not been scheduled yet", func() {
+				logs, err := buildRepo.GetBuildLogs(ctx, authInfo, repositories.BuildLogsMessage{
+					SpaceGUID: namespace.Name,
+					BuildGUID: buildGUID,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logs).To(BeEmpty())
+			})
+		})
+
+		When("the user is not authorized in the namespace", func() {
+			It("returns a forbidden error", func() {
+				_, err := buildRepo.GetBuildLogs(ctx, authInfo, repositories.BuildLogsMessage{
+					SpaceGUID: namespace.Name,
+					BuildGUID: buildGUID,
+				})
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("CreateBuild", func() {
 		const (
 			appGUID     = "the-app-guid"
@@ -303,8 +438,8 @@ var _ = Describe("BuildRepository", func() {
 				k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: spaceGUID}}),
 			).To(Succeed())
 
-			buildCreateLabels = nil
-			buildCreateAnnotations = nil
+			buildCreateLabels = map[string]string{"env": "prod"}
+			buildCreateAnnotations = map[string]string{"example.com/owner": "team-a"}
 			buildCreateMsg = repositories.CreateBuildMessage{
 				AppGUID:         appGUID,
 				PackageGUID:     packageGUID,
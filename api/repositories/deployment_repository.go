@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfdeployments,verbs=get;list;create;patch
+
+const (
+	DeploymentResourceType = "Deployment"
+
+	DeploymentStatusValueActive    = "ACTIVE"
+	DeploymentStatusValueFinalized = "FINALIZED"
+
+	DeploymentStatusReasonDeploying  = "DEPLOYING"
+	DeploymentStatusReasonCanceling  = "CANCELING"
+	DeploymentStatusReasonDeployed   = "DEPLOYED"
+	DeploymentStatusReasonCanceled   = "CANCELED"
+	DeploymentStatusReasonSuperseded = "SUPERSEDED"
+	DeploymentStatusReasonDegenerate = "DEGENERATE"
+)
+
+type DeploymentRepo struct {
+	namespaceRetriever   NamespaceRetriever
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewDeploymentRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+) *DeploymentRepo {
+	return &DeploymentRepo{
+		namespaceRetriever:   namespaceRetriever,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+type DeploymentRecord struct {
+	GUID                string
+	AppGUID             string
+	SpaceGUID           string
+	DropletGUID         string
+	PreviousDropletGUID string
+	Strategy            string
+	State               string
+	StatusValue         string
+	StatusReason        string
+	LastStatusChange    string
+	NewProcesses        []DeploymentProcess
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           string
+	UpdatedAt           string
+}
+
+type DeploymentProcess struct {
+	GUID string
+	Type string
+}
+
+type CreateDeploymentMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	DropletGUID  string
+	RevisionGUID string
+	Strategy     string
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+type ListDeploymentsMessage struct {
+	AppGUIDs      []string
+	States        []string
+	StatusValues  []string
+	StatusReasons []string
+}
+
+func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authorization.Info, message CreateDeploymentMessage) (DeploymentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfDeployment := message.toCFDeployment()
+	err = userClient.Create(ctx, &cfDeployment)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to create deployment: %w", apierrors.FromK8sError(err, DeploymentResourceType))
+	}
+
+	return cfDeploymentToDeploymentRecord(cfDeployment), nil
+}
+
+func (r *DeploymentRepo) GetDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, DeploymentResourceType)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	var cfDeployment workloadsv1alpha1.CFDeployment
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, &cfDeployment)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to get deployment %q: %w", deploymentGUID, apierrors.FromK8sError(err, DeploymentResourceType))
+	}
+
+	return cfDeploymentToDeploymentRecord(cfDeployment), nil
+}
+
+func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) ([]DeploymentRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	deploymentList := &workloadsv1alpha1.CFDeploymentList{}
+	var matches []DeploymentRecord
+	for ns := range nsList {
+		err = listInChunks(ctx, userClient, deploymentList, func() {
+			for _, cfDeployment := range deploymentList.Items {
+				record := cfDeploymentToDeploymentRecord(cfDeployment)
+				if matchesFilter(record.AppGUID, message.AppGUIDs) &&
+					matchesFilter(record.State, message.States) &&
+					matchesFilter(record.StatusValue, message.StatusValues) &&
+					matchesFilter(record.StatusReason, message.StatusReasons) {
+					matches = append(matches, record)
+				}
+			}
+		}, client.InNamespace(ns))
+		if err != nil {
+			return nil, apierrors.FromK8sError(err, DeploymentResourceType)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt < matches[j].CreatedAt
+	})
+
+	return matches, nil
+}
+
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, deploymentGUID, DeploymentResourceType)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfDeployment := new(workloadsv1alpha1.CFDeployment)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: deploymentGUID}, cfDeployment)
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to get deployment %q: %w", deploymentGUID, apierrors.FromK8sError(err, DeploymentResourceType))
+	}
+
+	originalDeployment := cfDeployment.DeepCopy()
+	cfDeployment.Spec.Canceled = true
+	err = userClient.Patch(ctx, cfDeployment, client.MergeFrom(originalDeployment))
+	if err != nil {
+		return DeploymentRecord{}, fmt.Errorf("failed to cancel deployment %q: %w", deploymentGUID, apierrors.FromK8sError(err, DeploymentResourceType))
+	}
+
+	return cfDeploymentToDeploymentRecord(*cfDeployment), nil
+}
+
+func (m CreateDeploymentMessage) toCFDeployment() workloadsv1alpha1.CFDeployment {
+	return workloadsv1alpha1.CFDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: m.SpaceGUID,
+			Labels: withCFMetadata(map[string]string{
+				workloadsv1alpha1.CFAppGUIDLabelKey: m.AppGUID,
+			}, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
+		},
+		Spec: workloadsv1alpha1.CFDeploymentSpec{
+			AppRef:      corev1.LocalObjectReference{Name: m.AppGUID},
+			DropletRef:  corev1.LocalObjectReference{Name: m.DropletGUID},
+			RevisionRef: corev1.LocalObjectReference{Name: m.RevisionGUID},
+			Strategy:    workloadsv1alpha1.DeploymentStrategy(m.Strategy),
+		},
+	}
+}
+
+// deploymentStatus is the CF status value and reason of the deployment. A deployment is DEPLOYING, or CANCELING once
+// canceled, until the controller finalizes it.
+func deploymentStatus(cfDeployment workloadsv1alpha1.CFDeployment) (string, string) {
+	if cfDeployment.Status.Value == workloadsv1alpha1.DeploymentStatusValueFinalized {
+		return string(cfDeployment.Status.Value), string(cfDeployment.Status.Reason)
+	}
+
+	if cfDeployment.Spec.Canceled {
+		return DeploymentStatusValueActive, DeploymentStatusReasonCanceling
+	}
+
+	return DeploymentStatusValueActive, DeploymentStatusReasonDeploying
+}
+
+// deploymentState is the deprecated state of CF deployments, which superseded and degenerate deployments report as DEPLOYED
+func deploymentState(statusReason string) string {
+	if statusReason == DeploymentStatusReasonSuperseded || statusReason == DeploymentStatusReasonDegenerate {
+		return DeploymentStatusReasonDeployed
+	}
+	return statusReason
+}
+
+func cfDeploymentToDeploymentRecord(cfDeployment workloadsv1alpha1.CFDeployment) DeploymentRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfDeployment.ObjectMeta)
+	statusValue, statusReason := deploymentStatus(cfDeployment)
+
+	lastStatusChange := formatTimestamp(cfDeployment.CreationTimestamp)
+	if !cfDeployment.Status.LastStatusChange.IsZero() {
+		lastStatusChange = formatTimestamp(cfDeployment.Status.LastStatusChange)
+	}
+
+	dropletGUID := cfDeployment.Status.DropletRef.Name
+	if dropletGUID == "" {
+		dropletGUID = cfDeployment.Spec.DropletRef.Name
+	}
+
+	newProcesses := make([]DeploymentProcess, 0, len(cfDeployment.Status.Processes))
+	for _, process := range cfDeployment.Status.Processes {
+		newProcesses = append(newProcesses, DeploymentProcess{GUID: process.GUID, Type: process.Type})
+	}
+
+	return DeploymentRecord{
+		GUID:                cfDeployment.Name,
+		AppGUID:             cfDeployment.Spec.AppRef.Name,
+		SpaceGUID:           cfDeployment.Namespace,
+		DropletGUID:         dropletGUID,
+		PreviousDropletGUID: cfDeployment.Status.PreviousDropletRef.Name,
+		Strategy:            string(cfDeployment.Spec.Strategy),
+		State:               deploymentState(statusReason),
+		StatusValue:         statusValue,
+		StatusReason:        statusReason,
+		LastStatusChange:    lastStatusChange,
+		NewProcesses:        newProcesses,
+		Labels:              cfMetadata(cfDeployment.Labels),
+		Annotations:         cfMetadata(cfDeployment.Annotations),
+		CreatedAt:           formatTimestamp(cfDeployment.CreationTimestamp),
+		UpdatedAt:           updatedAtTime,
+	}
+}
@@ -0,0 +1,243 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("DeploymentRepository", func() {
+	var (
+		ctx            context.Context
+		deploymentRepo *repositories.DeploymentRepo
+		org            *hnsv1alpha2.SubnamespaceAnchor
+		space          *hnsv1alpha2.SubnamespaceAnchor
+		appGUID        string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		deploymentRepo = repositories.NewDeploymentRepo(namespaceRetriever, userClientFactory, nsPerms)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		appGUID = prefixedGUID("app")
+	})
+
+	createDeployment := func(namespace string, status workloadsv1alpha1.CFDeploymentStatus) *workloadsv1alpha1.CFDeployment {
+		cfDeployment := &workloadsv1alpha1.CFDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("deployment"),
+				Namespace: namespace,
+				Labels:    map[string]string{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID},
+			},
+			Spec: workloadsv1alpha1.CFDeploymentSpec{
+				AppRef:     corev1.LocalObjectReference{Name: appGUID},
+				DropletRef: corev1.LocalObjectReference{Name: "droplet-guid"},
+				Strategy:   workloadsv1alpha1.RollingDeploymentStrategy,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfDeployment)).To(Succeed())
+
+		cfDeployment.Status = status
+		Expect(k8sClient.Status().Update(ctx, cfDeployment)).To(Succeed())
+
+		return cfDeployment
+	}
+
+	Describe("CreateDeployment", func() {
+		var (
+			deploymentRecord repositories.DeploymentRecord
+			createErr        error
+		)
+
+		JustBeforeEach(func() {
+			deploymentRecord, createErr = deploymentRepo.CreateDeployment(ctx, authInfo, repositories.CreateDeploymentMessage{
+				AppGUID:     appGUID,
+				SpaceGUID:   space.Name,
+				DropletGUID: "droplet-guid",
+				Strategy:    "rolling",
+				Labels:      map[string]string{"env": "prod"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a deploying deployment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(deploymentRecord.AppGUID).To(Equal(appGUID))
+				Expect(deploymentRecord.DropletGUID).To(Equal("droplet-guid"))
+				Expect(deploymentRecord.Strategy).To(Equal("rolling"))
+				Expect(deploymentRecord.StatusValue).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deploymentRecord.StatusReason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+				Expect(deploymentRecord.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+				var cfDeployment workloadsv1alpha1.CFDeployment
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: deploymentRecord.GUID}, &cfDeployment)).To(Succeed())
+				Expect(cfDeployment.Labels).To(HaveKeyWithValue(workloadsv1alpha1.CFAppGUIDLabelKey, appGUID))
+			})
+		})
+	})
+
+	Describe("GetDeployment", func() {
+		var (
+			cfDeployment     *workloadsv1alpha1.CFDeployment
+			deploymentRecord repositories.DeploymentRecord
+			getErr           error
+		)
+
+		BeforeEach(func() {
+			cfDeployment = createDeployment(space.Name, workloadsv1alpha1.CFDeploymentStatus{
+				Value:              workloadsv1alpha1.DeploymentStatusValueFinalized,
+				Reason:             workloadsv1alpha1.DeploymentStatusReasonSuperseded,
+				PreviousDropletRef: corev1.LocalObjectReference{Name: "previous-droplet-guid"},
+				Processes:          []workloadsv1alpha1.DeploymentProcess{{GUID: "process-guid", Type: "web"}},
+			})
+		})
+
+		JustBeforeEach(func() {
+			deploymentRecord, getErr = deploymentRepo.GetDeployment(ctx, authInfo, cfDeployment.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the deployment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(deploymentRecord.StatusValue).To(Equal(repositories.DeploymentStatusValueFinalized))
+				Expect(deploymentRecord.StatusReason).To(Equal(repositories.DeploymentStatusReasonSuperseded))
+				Expect(deploymentRecord.State).To(Equal(repositories.DeploymentStatusReasonDeployed))
+				Expect(deploymentRecord.PreviousDropletGUID).To(Equal("previous-droplet-guid"))
+				Expect(deploymentRecord.NewProcesses).To(ConsistOf(repositories.DeploymentProcess{GUID: "process-guid", Type: "web"}))
+			})
+		})
+	})
+
+	Describe("ListDeployments", func() {
+		var (
+			activeDeployment    *workloadsv1alpha1.CFDeployment
+			finalizedDeployment *workloadsv1alpha1.CFDeployment
+			message             repositories.ListDeploymentsMessage
+			deploymentRecords   []repositories.DeploymentRecord
+			listErr             error
+		)
+
+		BeforeEach(func() {
+			activeDeployment = createDeployment(space.Name, workloadsv1alpha1.CFDeploymentStatus{
+				Value:  workloadsv1alpha1.DeploymentStatusValueActive,
+				Reason: workloadsv1alpha1.DeploymentStatusReasonDeploying,
+			})
+			finalizedDeployment = createDeployment(space.Name, workloadsv1alpha1.CFDeploymentStatus{
+				Value:  workloadsv1alpha1.DeploymentStatusValueFinalized,
+				Reason: workloadsv1alpha1.DeploymentStatusReasonDeployed,
+			})
+			otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+			createDeployment(otherSpace.Name, workloadsv1alpha1.CFDeploymentStatus{})
+			message = repositories.ListDeploymentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			deploymentRecords, listErr = deploymentRepo.ListDeployments(ctx, authInfo, message)
+		})
+
+		It("returns no deployments", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(deploymentRecords).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the deployments in the spaces the user can see", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(deploymentRecords).To(ConsistOf(
+					HaveField("GUID", activeDeployment.Name),
+					HaveField("GUID", finalizedDeployment.Name),
+				))
+			})
+
+			When("filtering by status value", func() {
+				BeforeEach(func() {
+					message.StatusValues = []string{repositories.DeploymentStatusValueActive}
+				})
+
+				It("returns the deployments with that status value", func() {
+					Expect(deploymentRecords).To(ConsistOf(HaveField("GUID", activeDeployment.Name)))
+				})
+			})
+
+			When("filtering by app", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{"some-other-app"}
+				})
+
+				It("returns only the deployments of that app", func() {
+					Expect(deploymentRecords).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		var (
+			cfDeployment     *workloadsv1alpha1.CFDeployment
+			deploymentRecord repositories.DeploymentRecord
+			cancelErr        error
+		)
+
+		BeforeEach(func() {
+			cfDeployment = createDeployment(space.Name, workloadsv1alpha1.CFDeploymentStatus{
+				Value:  workloadsv1alpha1.DeploymentStatusValueActive,
+				Reason: workloadsv1alpha1.DeploymentStatusReasonDeploying,
+			})
+		})
+
+		JustBeforeEach(func() {
+			deploymentRecord, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, cfDeployment.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("requests the cancellation of the deployment", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(deploymentRecord.StatusReason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+
+				var updatedDeployment workloadsv1alpha1.CFDeployment
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDeployment), &updatedDeployment)).To(Succeed())
+				Expect(updatedDeployment.Spec.Canceled).To(BeTrue())
+			})
+		})
+	})
+})
@@ -143,10 +143,12 @@ func returnDomainList(domainList []networkingv1alpha1.CFDomain) []DomainRecord {
 func cfDomainToDomainRecord(cfDomain *networkingv1alpha1.CFDomain) DomainRecord {
 	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfDomain.ObjectMeta)
 	return DomainRecord{
-		Name:      cfDomain.Spec.Name,
-		GUID:      cfDomain.Name,
-		Namespace: cfDomain.Namespace,
-		CreatedAt: cfDomain.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt: updatedAtTime,
+		Name:        cfDomain.Spec.Name,
+		GUID:        cfDomain.Name,
+		Namespace:   cfDomain.Namespace,
+		Labels:      cfMetadata(cfDomain.Labels),
+		Annotations: cfMetadata(cfDomain.Annotations),
+		CreatedAt:   cfDomain.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:   updatedAtTime,
 	}
 }
@@ -45,6 +45,7 @@ type DropletRecord struct {
 	Lifecycle       Lifecycle
 	Stack           string
 	ProcessTypes    map[string]string
+	Image           string
 	AppGUID         string
 	PackageGUID     string
 	Labels          map[string]string
@@ -94,6 +95,11 @@ func cfBuildToDropletRecord(cfBuild workloadsv1alpha1.CFBuild) DropletRecord {
 		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
 	}
 
+	var image string
+	if cfBuild.Spec.Lifecycle.Type == workloadsv1alpha1.DockerLifecycle {
+		image = cfBuild.Status.BuildDropletStatus.Registry.Image
+	}
+
 	return DropletRecord{
 		GUID:      cfBuild.Name,
 		State:     "STAGED",
@@ -108,10 +114,11 @@ func cfBuildToDropletRecord(cfBuild workloadsv1alpha1.CFBuild) DropletRecord {
 		},
 		Stack:        cfBuild.Status.BuildDropletStatus.Stack,
 		ProcessTypes: processTypesMap,
+		Image:        image,
 		AppGUID:      cfBuild.Spec.AppRef.Name,
 		PackageGUID:  cfBuild.Spec.PackageRef.Name,
-		Labels:       cfBuild.Labels,
-		Annotations:  cfBuild.Annotations,
+		Labels:       cfMetadata(cfBuild.Labels),
+		Annotations:  cfMetadata(cfBuild.Annotations),
 	}
 }
 
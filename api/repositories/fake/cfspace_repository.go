@@ -68,6 +68,21 @@ type CFSpaceRepository struct {
 		result1 []repositories.SpaceRecord
 		result2 error
 	}
+	PatchSpaceStub        func(context.Context, authorization.Info, repositories.PatchSpaceMessage) (repositories.SpaceRecord, error)
+	patchSpaceMutex       sync.RWMutex
+	patchSpaceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSpaceMessage
+	}
+	patchSpaceReturns struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
+	patchSpaceReturnsOnCall map[int]struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -333,6 +348,72 @@ func (fake *CFSpaceRepository) ListSpacesReturnsOnCall(i int, result1 []reposito
 	}{result1, result2}
 }
 
+func (fake *CFSpaceRepository) PatchSpace(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchSpaceMessage) (repositories.SpaceRecord, error) {
+	fake.patchSpaceMutex.Lock()
+	ret, specificReturn := fake.patchSpaceReturnsOnCall[len(fake.patchSpaceArgsForCall)]
+	fake.patchSpaceArgsForCall = append(fake.patchSpaceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSpaceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchSpaceStub
+	fakeReturns := fake.patchSpaceReturns
+	fake.recordInvocation("PatchSpace", []interface{}{arg1, arg2, arg3})
+	fake.patchSpaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceRepository) PatchSpaceCallCount() int {
+	fake.patchSpaceMutex.RLock()
+	defer fake.patchSpaceMutex.RUnlock()
+	return len(fake.patchSpaceArgsForCall)
+}
+
+func (fake *CFSpaceRepository) PatchSpaceCalls(stub func(context.Context, authorization.Info, repositories.PatchSpaceMessage) (repositories.SpaceRecord, error)) {
+	fake.patchSpaceMutex.Lock()
+	defer fake.patchSpaceMutex.Unlock()
+	fake.PatchSpaceStub = stub
+}
+
+func (fake *CFSpaceRepository) PatchSpaceArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchSpaceMessage) {
+	fake.patchSpaceMutex.RLock()
+	defer fake.patchSpaceMutex.RUnlock()
+	argsForCall := fake.patchSpaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceRepository) PatchSpaceReturns(result1 repositories.SpaceRecord, result2 error) {
+	fake.patchSpaceMutex.Lock()
+	defer fake.patchSpaceMutex.Unlock()
+	fake.PatchSpaceStub = nil
+	fake.patchSpaceReturns = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceRepository) PatchSpaceReturnsOnCall(i int, result1 repositories.SpaceRecord, result2 error) {
+	fake.patchSpaceMutex.Lock()
+	defer fake.patchSpaceMutex.Unlock()
+	fake.PatchSpaceStub = nil
+	if fake.patchSpaceReturnsOnCall == nil {
+		fake.patchSpaceReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceRecord
+			result2 error
+		})
+	}
+	fake.patchSpaceReturnsOnCall[i] = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -344,6 +425,8 @@ func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	defer fake.getSpaceMutex.RUnlock()
 	fake.listSpacesMutex.RLock()
 	defer fake.listSpacesMutex.RUnlock()
+	fake.patchSpaceMutex.RLock()
+	defer fake.patchSpaceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
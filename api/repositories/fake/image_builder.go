@@ -7,15 +7,18 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
 type ImageBuilder struct {
-	BuildStub        func(context.Context, io.Reader) (v1.Image, error)
+	BuildStub        func(context.Context, string, io.Reader, []registry.Resource) (v1.Image, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
 		arg1 context.Context
-		arg2 io.Reader
+		arg2 string
+		arg3 io.Reader
+		arg4 []registry.Resource
 	}
 	buildReturns struct {
 		result1 v1.Image
@@ -29,19 +32,26 @@ type ImageBuilder struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ImageBuilder) Build(arg1 context.Context, arg2 io.Reader) (v1.Image, error) {
+func (fake *ImageBuilder) Build(arg1 context.Context, arg2 string, arg3 io.Reader, arg4 []registry.Resource) (v1.Image, error) {
+	var arg4Copy []registry.Resource
+	if arg4 != nil {
+		arg4Copy = make([]registry.Resource, len(arg4))
+		copy(arg4Copy, arg4)
+	}
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
 	fake.buildArgsForCall = append(fake.buildArgsForCall, struct {
 		arg1 context.Context
-		arg2 io.Reader
-	}{arg1, arg2})
+		arg2 string
+		arg3 io.Reader
+		arg4 []registry.Resource
+	}{arg1, arg2, arg3, arg4Copy})
 	stub := fake.BuildStub
 	fakeReturns := fake.buildReturns
-	fake.recordInvocation("Build", []interface{}{arg1, arg2})
+	fake.recordInvocation("Build", []interface{}{arg1, arg2, arg3, arg4Copy})
 	fake.buildMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,17 +65,17 @@ func (fake *ImageBuilder) BuildCallCount() int {
 	return len(fake.buildArgsForCall)
 }
 
-func (fake *ImageBuilder) BuildCalls(stub func(context.Context, io.Reader) (v1.Image, error)) {
+func (fake *ImageBuilder) BuildCalls(stub func(context.Context, string, io.Reader, []registry.Resource) (v1.Image, error)) {
 	fake.buildMutex.Lock()
 	defer fake.buildMutex.Unlock()
 	fake.BuildStub = stub
 }
 
-func (fake *ImageBuilder) BuildArgsForCall(i int) (context.Context, io.Reader) {
+func (fake *ImageBuilder) BuildArgsForCall(i int) (context.Context, string, io.Reader, []registry.Resource) {
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	argsForCall := fake.buildArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *ImageBuilder) BuildReturns(result1 v1.Image, result2 error) {
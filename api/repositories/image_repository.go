@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sort"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories/registry"
 	registryv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/pivotal/kpack/pkg/dockercreds/k8sdockercreds"
@@ -21,44 +24,66 @@ const SourceImageResourceType = "SourceImage"
 
 //counterfeiter:generate -o fake -fake-name ImageBuilder . ImageBuilder
 //counterfeiter:generate -o fake -fake-name ImagePusher . ImagePusher
+//counterfeiter:generate -o fake -fake-name ResourceCache . ResourceCache
 
 type ImageBuilder interface {
-	Build(ctx context.Context, srcReader io.Reader) (registryv1.Image, error)
+	Build(ctx context.Context, spaceGUID string, srcReader io.Reader, resources []registry.Resource) (registryv1.Image, error)
 }
 
 type ImagePusher interface {
 	Push(ctx context.Context, imageRef string, image registryv1.Image, credentials remote.Option) (string, error)
 }
 
-type ImageRepository struct {
-	privilegedK8sClient k8sclient.Interface
-	userClientFactory   UserK8sClientFactory
-	rootNamespace       string
-	registrySecretName  string
+type ResourceCache interface {
+	Contains(spaceGUID, sha1 string, size int64) bool
+}
 
-	builder ImageBuilder
-	pusher  ImagePusher
+// PackageResource is a file of a package, as identified by the resource matches of the CF API
+type PackageResource struct {
+	SHA1        string
+	SizeInBytes int64
+	Path        string
+	Mode        os.FileMode
+}
+
+type ImageRepository struct {
+	privilegedK8sClient  k8sclient.Interface
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
+	registrySecretName   string
+
+	builder       ImageBuilder
+	pusher        ImagePusher
+	resourceCache ResourceCache
 }
 
 func NewImageRepository(
 	privilegedK8sClient k8sclient.Interface,
 	userClientFactory UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
 	rootNamespace,
 	registrySecretName string,
 	builder ImageBuilder,
 	pusher ImagePusher,
+	resourceCache ResourceCache,
 ) *ImageRepository {
 	return &ImageRepository{
-		privilegedK8sClient: privilegedK8sClient,
-		userClientFactory:   userClientFactory,
-		rootNamespace:       rootNamespace,
-		registrySecretName:  registrySecretName,
-		builder:             builder,
-		pusher:              pusher,
+		privilegedK8sClient:  privilegedK8sClient,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		rootNamespace:        rootNamespace,
+		registrySecretName:   registrySecretName,
+		builder:              builder,
+		pusher:               pusher,
+		resourceCache:        resourceCache,
 	}
 }
 
-func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string) (string, error) {
+// UploadSourceImage builds the source image of a package out of the uploaded zip and the resources that were matched
+// in the resource cache of the spaces of the user, and pushes it to imageRef. The srcReader can be nil when all the
+// files are matched resources.
+func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, resources []PackageResource, spaceGUID string) (string, error) {
 	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
@@ -68,8 +93,17 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
 	}
 
-	image, err := r.builder.Build(ctx, srcReader)
+	cachedResources, err := r.findCachedResources(ctx, authInfo, spaceGUID, resources)
 	if err != nil {
+		return "", err
+	}
+
+	image, err := r.builder.Build(ctx, spaceGUID, srcReader, cachedResources)
+	if err != nil {
+		var notCachedErr registry.ResourceNotCachedError
+		if errors.As(err, &notCachedErr) {
+			return "", resourceNotUploadedError(err, notCachedErr.Path, notCachedErr.SHA1)
+		}
 		return "", fmt.Errorf("image build for ref '%s' failed: %w", imageRef, err)
 	}
 
@@ -86,6 +120,88 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
+// MatchResources returns the resources that are already in the resource cache of a space the user has a role in, and
+// so do not need to be uploaded
+func (r *ImageRepository) MatchResources(ctx context.Context, authInfo authorization.Info, resources []PackageResource) ([]PackageResource, error) {
+	spaceGUIDs, err := r.getResourceSpaces(ctx, authInfo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []PackageResource{}
+	for _, resource := range resources {
+		if _, found := r.findResourceSpace(spaceGUIDs, resource); found {
+			matches = append(matches, resource)
+		}
+	}
+
+	return matches, nil
+}
+
+// findCachedResources looks the resources up in the resource cache of the space of the package first, then in the
+// caches of the other spaces the user has a role in, as those are the spaces MatchResources matched them in
+func (r *ImageRepository) findCachedResources(ctx context.Context, authInfo authorization.Info, spaceGUID string, resources []PackageResource) ([]registry.Resource, error) {
+	cachedResources := make([]registry.Resource, 0, len(resources))
+	if len(resources) == 0 {
+		return cachedResources, nil
+	}
+
+	spaceGUIDs, err := r.getResourceSpaces(ctx, authInfo, spaceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		resourceSpaceGUID, found := r.findResourceSpace(spaceGUIDs, resource)
+		if !found {
+			return nil, resourceNotUploadedError(nil, resource.Path, resource.SHA1)
+		}
+		cachedResources = append(cachedResources, registry.Resource{
+			SpaceGUID: resourceSpaceGUID,
+			SHA1:      resource.SHA1,
+			Path:      resource.Path,
+			Mode:      resource.Mode,
+		})
+	}
+
+	return cachedResources, nil
+}
+
+// getResourceSpaces returns the spaces the user has a role in, starting with firstSpaceGUID if it is not empty
+func (r *ImageRepository) getResourceSpaces(ctx context.Context, authInfo authorization.Info, firstSpaceGUID string) ([]string, error) {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the authorized spaces for resource matching: %w", err)
+	}
+
+	otherSpaceGUIDs := []string{}
+	for authorizedSpace := range authorizedSpaces {
+		if authorizedSpace != firstSpaceGUID {
+			otherSpaceGUIDs = append(otherSpaceGUIDs, authorizedSpace)
+		}
+	}
+	sort.Strings(otherSpaceGUIDs)
+
+	if firstSpaceGUID == "" {
+		return otherSpaceGUIDs, nil
+	}
+	return append([]string{firstSpaceGUID}, otherSpaceGUIDs...), nil
+}
+
+func (r *ImageRepository) findResourceSpace(spaceGUIDs []string, resource PackageResource) (string, bool) {
+	for _, spaceGUID := range spaceGUIDs {
+		if r.resourceCache.Contains(spaceGUID, resource.SHA1, resource.SizeInBytes) {
+			return spaceGUID, true
+		}
+	}
+
+	return "", false
+}
+
+func resourceNotUploadedError(err error, path, sha1 string) error {
+	return apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("The resource %q with checksum %s has not been uploaded before.", path, sha1))
+}
+
 func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
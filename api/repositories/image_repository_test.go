@@ -18,6 +18,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/api/repositories/registry"
 )
 
 var _ = Describe("ImageRepository", func() {
@@ -25,10 +26,12 @@ var _ = Describe("ImageRepository", func() {
 		registrySecretName  string
 		imageBuilder        *fake.ImageBuilder
 		imagePusher         *fake.ImagePusher
+		resourceCache       *fake.ResourceCache
 		image               v1.Image
 		privilegedK8sClient k8sclient.Interface
 
 		imageSource io.Reader
+		resources   []repositories.PackageResource
 
 		imageRepo *repositories.ImageRepository
 
@@ -48,7 +51,11 @@ var _ = Describe("ImageRepository", func() {
 		imagePusher = new(fake.ImagePusher)
 		imagePusher.PushReturns("my-pushed-image", nil)
 
+		resourceCache = new(fake.ResourceCache)
+		resourceCache.ContainsReturns(true)
+
 		imageSource = bytes.NewBufferString("")
+		resources = nil
 
 		privilegedK8sClient, err = k8sclient.NewForConfig(k8sConfig)
 		Expect(err).NotTo(HaveOccurred())
@@ -95,15 +102,17 @@ var _ = Describe("ImageRepository", func() {
 		imageRepo = repositories.NewImageRepository(
 			privilegedK8sClient,
 			userClientFactory,
+			nsPerms,
 			rootNamespace,
 			registrySecretName,
 			imageBuilder,
 			imagePusher,
+			resourceCache,
 		)
 	})
 
 	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, "my-image", imageSource, space.Name)
+		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, "my-image", imageSource, resources, space.Name)
 	})
 
 	It("fails with unauthorized error without a valid role in the space", func() {
@@ -128,6 +137,98 @@ var _ = Describe("ImageRepository", func() {
 			Expect(credentials).NotTo(BeNil())
 		})
 
+		It("builds the image from the source", func() {
+			Expect(imageBuilder.BuildCallCount()).To(Equal(1))
+			_, actualSpaceGUID, actualSource, actualResources := imageBuilder.BuildArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal(space.Name))
+			Expect(actualSource).To(Equal(imageSource))
+			Expect(actualResources).To(BeEmpty())
+		})
+
+		When("the package includes cached resources", func() {
+			BeforeEach(func() {
+				resources = []repositories.PackageResource{{
+					SHA1:        "b907173290db6a155949ab4dc9b2d019dea0c901",
+					SizeInBytes: 65536,
+					Path:        "lib/app.jar",
+					Mode:        0o644,
+				}}
+			})
+
+			It("builds the image with the cached resources", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(resourceCache.ContainsCallCount()).To(Equal(1))
+				actualSpaceGUID, actualSHA1, actualSize := resourceCache.ContainsArgsForCall(0)
+				Expect(actualSpaceGUID).To(Equal(space.Name))
+				Expect(actualSHA1).To(Equal("b907173290db6a155949ab4dc9b2d019dea0c901"))
+				Expect(actualSize).To(BeEquivalentTo(65536))
+
+				_, _, _, actualResources := imageBuilder.BuildArgsForCall(0)
+				Expect(actualResources).To(Equal([]registry.Resource{{
+					SpaceGUID: space.Name,
+					SHA1:      "b907173290db6a155949ab4dc9b2d019dea0c901",
+					Path:      "lib/app.jar",
+					Mode:      0o644,
+				}}))
+			})
+
+			When("a resource is only cached in another space of the user", func() {
+				var otherSpace *hnsv1alpha2.SubnamespaceAnchor
+
+				BeforeEach(func() {
+					otherSpace = createSpaceAnchorAndNamespace(ctx, org.Name, prefixedGUID("other-space"))
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+					resourceCache.ContainsStub = func(spaceGUID, _ string, _ int64) bool {
+						return spaceGUID == otherSpace.Name
+					}
+				})
+
+				It("builds the image with the resource of the other space", func() {
+					Expect(uploadErr).NotTo(HaveOccurred())
+					_, _, _, actualResources := imageBuilder.BuildArgsForCall(0)
+					Expect(actualResources).To(HaveLen(1))
+					Expect(actualResources[0].SpaceGUID).To(Equal(otherSpace.Name))
+				})
+			})
+
+			When("a resource is only cached in a space the user has no role in", func() {
+				BeforeEach(func() {
+					otherSpace := createSpaceAnchorAndNamespace(ctx, org.Name, prefixedGUID("other-space"))
+					resourceCache.ContainsStub = func(spaceGUID, _ string, _ int64) bool {
+						return spaceGUID == otherSpace.Name
+					}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(imageBuilder.BuildCallCount()).To(Equal(0))
+				})
+			})
+
+			When("a resource is evicted from the cache before the image is built", func() {
+				BeforeEach(func() {
+					imageBuilder.BuildReturns(nil, registry.ResourceNotCachedError{Path: "lib/app.jar", SHA1: "b907173290db6a155949ab4dc9b2d019dea0c901"})
+				})
+
+				It("returns an unprocessable entity error naming the resource", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(uploadErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring(`"lib/app.jar"`))
+				})
+			})
+
+			When("a resource is not in the cache", func() {
+				BeforeEach(func() {
+					resourceCache.ContainsReturns(false)
+				})
+
+				It("returns an unprocessable entity error naming the resource", func() {
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(uploadErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring(`"lib/app.jar"`))
+					Expect(imageBuilder.BuildCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		When("building the image fails", func() {
 			BeforeEach(func() {
 				imageBuilder.BuildReturns(nil, errors.New("build-error"))
@@ -160,4 +261,45 @@ var _ = Describe("ImageRepository", func() {
 			})
 		})
 	})
+
+	Describe("MatchResources", func() {
+		var (
+			matches  []repositories.PackageResource
+			matchErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			resourceCache.ContainsStub = func(spaceGUID, sha1 string, size int64) bool {
+				return spaceGUID == space.Name && sha1 == "cached-sha1"
+			}
+		})
+
+		JustBeforeEach(func() {
+			matches, matchErr = imageRepo.MatchResources(ctx, authInfo, []repositories.PackageResource{
+				{SHA1: "cached-sha1", SizeInBytes: 65536, Path: "cached", Mode: 0o644},
+				{SHA1: "new-sha1", SizeInBytes: 65536, Path: "new", Mode: 0o644},
+			})
+		})
+
+		It("returns the resources that are in the cache of a space of the user", func() {
+			Expect(matchErr).NotTo(HaveOccurred())
+			Expect(matches).To(Equal([]repositories.PackageResource{
+				{SHA1: "cached-sha1", SizeInBytes: 65536, Path: "cached", Mode: 0o644},
+			}))
+		})
+
+		When("the resources are cached in a space the user has no role in", func() {
+			BeforeEach(func() {
+				resourceCache.ContainsStub = func(spaceGUID, sha1 string, size int64) bool {
+					return spaceGUID != space.Name
+				}
+			})
+
+			It("does not match them", func() {
+				Expect(matchErr).NotTo(HaveOccurred())
+				Expect(matches).To(BeEmpty())
+			})
+		})
+	})
 })
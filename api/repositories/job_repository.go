@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;create;patch;delete
+
+const (
+	JobResourceType = "Job"
+
+	JobStateProcessing = "PROCESSING"
+	JobStateComplete   = "COMPLETE"
+	JobStateFailed     = "FAILED"
+
+	AppDeleteJobOperation             = "app.delete"
+	OrgDeleteJobOperation             = "org.delete"
+	OrgQuotaDeleteJobOperation        = "organization_quota.delete"
+	RoleDeleteJobOperation            = "role.delete"
+	RouteDeleteJobOperation           = "route.delete"
+	SecurityGroupDeleteJobOperation   = "security_group.delete"
+	SpaceDeleteJobOperation           = "space.delete"
+	SpaceQuotaDeleteJobOperation      = "space_quota.delete"
+	UserDeleteJobOperation            = "user.delete"
+	ApplyManifestJobOperation         = "space.apply_manifest"
+	ServiceBrokerCreateJobOperation   = "service_broker.catalog.synchronize"
+	ServiceBrokerDeleteJobOperation   = "service_broker.delete"
+	ServiceInstanceCreateJobOperation = "service_instance.create"
+	ServiceInstanceDeleteJobOperation = "service_instance.delete"
+
+	JobLabel = "korifi.cloudfoundry.org/job"
+
+	jobOperationKey    = "operation"
+	jobResourceGUIDKey = "resource_guid"
+	jobStateKey        = "state"
+	jobErrorsKey       = "errors"
+	jobWarningsKey     = "warnings"
+	jobCreatorKindKey  = "creator_kind"
+	jobCreatorNameKey  = "creator_name"
+	jobHeartbeatKey    = "heartbeat"
+)
+
+type JobRecord struct {
+	GUID         string
+	Operation    string
+	ResourceGUID string
+	State        string
+	Errors       []JobError
+	Warnings     []string
+	Creator      authorization.Identity
+	// HeartbeatAt is the last time the API instance running the job reported it as running
+	HeartbeatAt string
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+type JobError struct {
+	Code   int    `json:"code"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+type CreateJobMessage struct {
+	Operation    string
+	ResourceGUID string
+	Creator      authorization.Identity
+}
+
+type UpdateJobMessage struct {
+	GUID     string
+	State    string
+	Errors   []JobError
+	Warnings []string
+}
+
+// JobRepo stores the state of asynchronous jobs as ConfigMaps in the root namespace.
+// Jobs are written by the API itself rather than on behalf of a user, hence the privileged client. The identity that
+// created a job is stored with it, so that the job is only reported to its creator.
+type JobRepo struct {
+	rootNamespace    string
+	privilegedClient client.Client
+}
+
+func NewJobRepo(rootNamespace string, privilegedClient client.Client) *JobRepo {
+	return &JobRepo{
+		rootNamespace:    rootNamespace,
+		privilegedClient: privilegedClient,
+	}
+}
+
+func (r *JobRepo) CreateJob(ctx context.Context, message CreateJobMessage) (JobRecord, error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+			Labels: map[string]string{
+				JobLabel: "true",
+			},
+		},
+		Data: map[string]string{
+			jobOperationKey:    message.Operation,
+			jobResourceGUIDKey: message.ResourceGUID,
+			jobStateKey:        JobStateProcessing,
+			jobCreatorKindKey:  message.Creator.Kind,
+			jobCreatorNameKey:  message.Creator.Name,
+			jobHeartbeatKey:    formatTimestamp(metav1.Now()),
+		},
+	}
+
+	if err := r.privilegedClient.Create(ctx, configMap); err != nil {
+		return JobRecord{}, apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	return configMapToJobRecord(*configMap)
+}
+
+func (r *JobRepo) GetJob(ctx context.Context, jobGUID string) (JobRecord, error) {
+	configMap := corev1.ConfigMap{}
+	if err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: jobGUID}, &configMap); err != nil {
+		return JobRecord{}, apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	if configMap.Labels[JobLabel] != "true" {
+		return JobRecord{}, apierrors.NewNotFoundError(fmt.Errorf("config map %q is not a job", jobGUID), JobResourceType)
+	}
+
+	return configMapToJobRecord(configMap)
+}
+
+func (r *JobRepo) ListJobs(ctx context.Context) ([]JobRecord, error) {
+	configMapList := corev1.ConfigMapList{}
+	if err := r.privilegedClient.List(ctx, &configMapList, client.InNamespace(r.rootNamespace), client.MatchingLabels{JobLabel: "true"}); err != nil {
+		return nil, apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	records := make([]JobRecord, 0, len(configMapList.Items))
+	for _, configMap := range configMapList.Items {
+		record, err := configMapToJobRecord(configMap)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r *JobRepo) DeleteJob(ctx context.Context, jobGUID string) error {
+	err := r.privilegedClient.Delete(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobGUID,
+			Namespace: r.rootNamespace,
+		},
+	})
+	return apierrors.FromK8sError(client.IgnoreNotFound(err), JobResourceType)
+}
+
+// RecordJobHeartbeat records that the job is still being run, so that it is not taken for a job whose API instance
+// has gone away
+func (r *JobRepo) RecordJobHeartbeat(ctx context.Context, jobGUID string) error {
+	configMap := &corev1.ConfigMap{}
+	if err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: jobGUID}, configMap); err != nil {
+		return apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	originalConfigMap := configMap.DeepCopy()
+	configMap.Data[jobHeartbeatKey] = formatTimestamp(metav1.Now())
+
+	if err := r.privilegedClient.Patch(ctx, configMap, client.MergeFrom(originalConfigMap)); err != nil {
+		return apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	return nil
+}
+
+func (r *JobRepo) UpdateJob(ctx context.Context, message UpdateJobMessage) (JobRecord, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.GUID}, configMap); err != nil {
+		return JobRecord{}, apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	jobErrors, err := json.Marshal(message.Errors)
+	if err != nil {
+		return JobRecord{}, fmt.Errorf("failed to marshal job errors: %w", err)
+	}
+
+	jobWarnings, err := json.Marshal(message.Warnings)
+	if err != nil {
+		return JobRecord{}, fmt.Errorf("failed to marshal job warnings: %w", err)
+	}
+
+	originalConfigMap := configMap.DeepCopy()
+	configMap.Data[jobStateKey] = message.State
+	configMap.Data[jobErrorsKey] = string(jobErrors)
+	configMap.Data[jobWarningsKey] = string(jobWarnings)
+
+	if err := r.privilegedClient.Patch(ctx, configMap, client.MergeFrom(originalConfigMap)); err != nil {
+		return JobRecord{}, apierrors.FromK8sError(err, JobResourceType)
+	}
+
+	return configMapToJobRecord(*configMap)
+}
+
+func configMapToJobRecord(configMap corev1.ConfigMap) (JobRecord, error) {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&configMap.ObjectMeta)
+
+	record := JobRecord{
+		GUID:         configMap.Name,
+		Operation:    configMap.Data[jobOperationKey],
+		ResourceGUID: configMap.Data[jobResourceGUIDKey],
+		State:        configMap.Data[jobStateKey],
+		Errors:       []JobError{},
+		Warnings:     []string{},
+		Creator: authorization.Identity{
+			Kind: configMap.Data[jobCreatorKindKey],
+			Name: configMap.Data[jobCreatorNameKey],
+		},
+		HeartbeatAt: configMap.Data[jobHeartbeatKey],
+		CreatedAt:   configMap.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:   updatedAtTime,
+	}
+
+	if jobErrors, ok := configMap.Data[jobErrorsKey]; ok {
+		if err := json.Unmarshal([]byte(jobErrors), &record.Errors); err != nil {
+			return JobRecord{}, fmt.Errorf("failed to unmarshal errors of job %q: %w", configMap.Name, err)
+		}
+	}
+
+	if jobWarnings, ok := configMap.Data[jobWarningsKey]; ok {
+		if err := json.Unmarshal([]byte(jobWarnings), &record.Warnings); err != nil {
+			return JobRecord{}, fmt.Errorf("failed to unmarshal warnings of job %q: %w", configMap.Name, err)
+		}
+	}
+
+	return record, nil
+}
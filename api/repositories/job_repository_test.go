@@ -0,0 +1,172 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("JobRepository", func() {
+	var (
+		testCtx context.Context
+		jobRepo *JobRepo
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		jobRepo = NewJobRepo(rootNamespace, k8sClient)
+	})
+
+	Describe("CreateJob", func() {
+		var (
+			job       JobRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			job, createErr = jobRepo.CreateJob(testCtx, CreateJobMessage{
+				Operation:    AppDeleteJobOperation,
+				ResourceGUID: "some-app-guid",
+				Creator:      authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"},
+			})
+		})
+
+		It("returns a processing job", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(job.GUID).NotTo(BeEmpty())
+			Expect(job.Operation).To(Equal(AppDeleteJobOperation))
+			Expect(job.ResourceGUID).To(Equal("some-app-guid"))
+			Expect(job.State).To(Equal(JobStateProcessing))
+			Expect(job.Errors).To(BeEmpty())
+			Expect(job.Warnings).To(BeEmpty())
+			Expect(job.HeartbeatAt).NotTo(BeEmpty())
+		})
+
+		It("can be fetched", func() {
+			fetchedJob, err := jobRepo.GetJob(testCtx, job.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fetchedJob.GUID).To(Equal(job.GUID))
+			Expect(fetchedJob.State).To(Equal(JobStateProcessing))
+			Expect(fetchedJob.Creator).To(Equal(authorization.Identity{Kind: rbacv1.UserKind, Name: "alice"}))
+		})
+
+		It("is listed", func() {
+			jobs, err := jobRepo.ListJobs(testCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).To(ContainElement(HaveField("GUID", job.GUID)))
+		})
+
+		When("the job is deleted", func() {
+			BeforeEach(func() {
+				Expect(jobRepo.DeleteJob(testCtx, job.GUID)).To(Succeed())
+			})
+
+			It("can no longer be fetched", func() {
+				_, err := jobRepo.GetJob(testCtx, job.GUID)
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			It("can be deleted again", func() {
+				Expect(jobRepo.DeleteJob(testCtx, job.GUID)).To(Succeed())
+			})
+		})
+
+		When("the job is updated", func() {
+			BeforeEach(func() {
+				_, err := jobRepo.UpdateJob(testCtx, UpdateJobMessage{
+					GUID:  job.GUID,
+					State: JobStateFailed,
+					Errors: []JobError{{
+						Code:   10010,
+						Title:  "CF-ResourceNotFound",
+						Detail: "Domain not found",
+					}},
+					Warnings: []string{"careful"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the updated state", func() {
+				fetchedJob, err := jobRepo.GetJob(testCtx, job.GUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchedJob.State).To(Equal(JobStateFailed))
+				Expect(fetchedJob.Errors).To(ConsistOf(JobError{
+					Code:   10010,
+					Title:  "CF-ResourceNotFound",
+					Detail: "Domain not found",
+				}))
+				Expect(fetchedJob.Warnings).To(ConsistOf("careful"))
+			})
+		})
+
+		When("a heartbeat of the job is recorded", func() {
+			BeforeEach(func() {
+				configMap := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: job.GUID}, configMap)).To(Succeed())
+				originalConfigMap := configMap.DeepCopy()
+				configMap.Data["heartbeat"] = "2020-01-01T00:00:00Z"
+				Expect(k8sClient.Patch(testCtx, configMap, client.MergeFrom(originalConfigMap))).To(Succeed())
+
+				Expect(jobRepo.RecordJobHeartbeat(testCtx, job.GUID)).To(Succeed())
+			})
+
+			It("refreshes the heartbeat and leaves the state alone", func() {
+				fetchedJob, err := jobRepo.GetJob(testCtx, job.GUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fetchedJob.HeartbeatAt).NotTo(Equal("2020-01-01T00:00:00Z"))
+				Expect(fetchedJob.State).To(Equal(JobStateProcessing))
+			})
+		})
+	})
+
+	Describe("ListJobs", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(testCtx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "not-a-listed-job",
+					Namespace: rootNamespace,
+				},
+			})).To(Succeed())
+		})
+
+		It("only lists jobs", func() {
+			jobs, err := jobRepo.ListJobs(testCtx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobs).NotTo(ContainElement(HaveField("GUID", "not-a-listed-job")))
+		})
+	})
+
+	Describe("GetJob", func() {
+		When("the job does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := jobRepo.GetJob(testCtx, "no-such-job")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("the config map is not a job", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(testCtx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "not-a-job",
+						Namespace: rootNamespace,
+					},
+				})).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				_, err := jobRepo.GetJob(testCtx, "not-a-job")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"k8s.io/apimachinery/pkg/api/meta"
+	k8sclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,6 +18,7 @@ import (
 
 type UserK8sClientFactory interface {
 	BuildClient(authorization.Info) (client.WithWatch, error)
+	BuildK8sClient(authorization.Info) (k8sclient.Interface, error)
 }
 
 type UnprivilegedClientFactory struct {
@@ -31,6 +34,27 @@ func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper) U
 }
 
 func (f UnprivilegedClientFactory) BuildClient(authInfo authorization.Info) (client.WithWatch, error) {
+	config, err := f.buildUserConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserClient(config, f.mapper)
+}
+
+// BuildK8sClient returns a typed clientset acting as the user. It is needed
+// for subresources such as pod logs that the controller-runtime client cannot
+// stream.
+func (f UnprivilegedClientFactory) BuildK8sClient(authInfo authorization.Info) (k8sclient.Interface, error) {
+	config, err := f.buildUserConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserK8sClient(config)
+}
+
+func (f UnprivilegedClientFactory) buildUserConfig(authInfo authorization.Info) (*rest.Config, error) {
 	config := rest.CopyConfig(f.config)
 
 	switch strings.ToLower(authInfo.Scheme()) {
@@ -55,9 +79,13 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo authorization.Info) (cli
 		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
 	}
 
+	return config, nil
+}
+
+func newUserClient(config *rest.Config, mapper meta.RESTMapper) (client.WithWatch, error) {
 	userClient, err := client.NewWithWatch(config, client.Options{
 		Scheme: scheme.Scheme,
-		Mapper: f.mapper,
+		Mapper: mapper,
 	})
 	if err != nil {
 		return nil, apierrors.FromK8sError(err, "")
@@ -66,6 +94,87 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo authorization.Info) (cli
 	return userClient, nil
 }
 
+func newUserK8sClient(config *rest.Config) (k8sclient.Interface, error) {
+	userK8sClient, err := k8sclient.NewForConfig(config)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, "")
+	}
+
+	return userK8sClient, nil
+}
+
+//counterfeiter:generate -o fake -fake-name LocalIdentityProvider . LocalIdentityProvider
+
+// LocalIdentityProvider gets the identity of the tokens that the API validates itself
+type LocalIdentityProvider interface {
+	Recognizes(token string) bool
+	GetIdentity(context.Context, authorization.Info) (authorization.Identity, error)
+}
+
+// ImpersonatingClientFactory builds the clients of the users whose tokens are validated by the API rather than by the
+// cluster. The cluster does not accept those tokens, so the clients impersonate the identity of the token with the
+// privileged config. The clients of the other users are built by the unprivileged factory.
+type ImpersonatingClientFactory struct {
+	unprivilegedClientFactory UnprivilegedClientFactory
+	privilegedConfig          *rest.Config
+	mapper                    meta.RESTMapper
+	identityProvider          LocalIdentityProvider
+}
+
+func NewImpersonatingClientFactory(config *rest.Config, mapper meta.RESTMapper, identityProvider LocalIdentityProvider) ImpersonatingClientFactory {
+	return ImpersonatingClientFactory{
+		unprivilegedClientFactory: NewUnprivilegedClientFactory(config, mapper),
+		privilegedConfig:          config,
+		mapper:                    mapper,
+		identityProvider:          identityProvider,
+	}
+}
+
+func (f ImpersonatingClientFactory) BuildClient(authInfo authorization.Info) (client.WithWatch, error) {
+	if !f.impersonates(authInfo) {
+		return f.unprivilegedClientFactory.BuildClient(authInfo)
+	}
+
+	config, err := f.buildImpersonatingConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserClient(config, f.mapper)
+}
+
+func (f ImpersonatingClientFactory) BuildK8sClient(authInfo authorization.Info) (k8sclient.Interface, error) {
+	if !f.impersonates(authInfo) {
+		return f.unprivilegedClientFactory.BuildK8sClient(authInfo)
+	}
+
+	config, err := f.buildImpersonatingConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserK8sClient(config)
+}
+
+func (f ImpersonatingClientFactory) impersonates(authInfo authorization.Info) bool {
+	return authInfo.Token != "" && f.identityProvider.Recognizes(authInfo.Token)
+}
+
+func (f ImpersonatingClientFactory) buildImpersonatingConfig(authInfo authorization.Info) (*rest.Config, error) {
+	identity, err := f.identityProvider.GetIdentity(context.Background(), authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	config := rest.CopyConfig(f.privilegedConfig)
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: identity.Name,
+		Groups:   identity.Groups,
+	}
+
+	return config, nil
+}
+
 func NewPrivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper) PrivilegedClientFactory {
 	return PrivilegedClientFactory{
 		config: config,
@@ -84,3 +193,7 @@ func (f PrivilegedClientFactory) BuildClient(_ authorization.Info) (client.WithW
 		Mapper: f.mapper,
 	})
 }
+
+func (f PrivilegedClientFactory) BuildK8sClient(_ authorization.Info) (k8sclient.Interface, error) {
+	return k8sclient.NewForConfig(f.config)
+}
@@ -0,0 +1,122 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var _ = Describe("ImpersonatingClientFactory", func() {
+	var (
+		ctx              context.Context
+		identityProvider *fake.LocalIdentityProvider
+		clientFactory    repositories.ImpersonatingClientFactory
+		namespace        string
+		tokenAuthInfo    authorization.Info
+		listErr          error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = prefixedGUID("space")
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Delete(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+		})
+
+		Expect(k8sClient.Create(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: generateGUID(), Namespace: namespace},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sso:developers"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: spaceDeveloperRole.Name},
+		})).To(Succeed())
+
+		identityProvider = new(fake.LocalIdentityProvider)
+		identityProvider.RecognizesReturns(true)
+		identityProvider.GetIdentityReturns(authorization.Identity{
+			Name:   "sso:bob",
+			Kind:   rbacv1.UserKind,
+			Groups: []string{"sso:developers"},
+		}, nil)
+
+		mapper, err := apiutil.NewDynamicRESTMapper(k8sConfig)
+		Expect(err).NotTo(HaveOccurred())
+		clientFactory = repositories.NewImpersonatingClientFactory(k8sConfig, mapper, identityProvider)
+
+		tokenAuthInfo = authorization.Info{Token: "a-token"}
+	})
+
+	JustBeforeEach(func() {
+		var userClient client.WithWatch
+		userClient, listErr = clientFactory.BuildClient(tokenAuthInfo)
+		if listErr != nil {
+			return
+		}
+
+		listErr = userClient.List(ctx, &workloadsv1alpha1.CFAppList{}, client.InNamespace(namespace))
+	})
+
+	It("impersonates the identity of the token", func() {
+		Expect(listErr).NotTo(HaveOccurred())
+
+		Expect(identityProvider.RecognizesArgsForCall(0)).To(Equal("a-token"))
+		_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(tokenAuthInfo))
+	})
+
+	When("the identity has no role in the namespace", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{Name: "sso:bob", Kind: rbacv1.UserKind}, nil)
+		})
+
+		It("is forbidden", func() {
+			Expect(listErr).To(MatchError(ContainSubstring("forbidden")))
+		})
+	})
+
+	When("getting the identity of the token fails", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{}, apierrors.NewInvalidAuthError(errors.New("expired")))
+		})
+
+		It("returns the error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token is not recognized", func() {
+		BeforeEach(func() {
+			identityProvider.RecognizesReturns(false)
+		})
+
+		It("does not impersonate, leaving the token to the cluster", func() {
+			Expect(listErr).To(HaveOccurred())
+			Expect(identityProvider.GetIdentityCallCount()).To(BeZero())
+		})
+	})
+
+	When("the user authenticates with a client certificate", func() {
+		BeforeEach(func() {
+			tokenAuthInfo = authInfo
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, namespace)
+		})
+
+		It("uses the certificate", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(identityProvider.RecognizesCallCount()).To(BeZero())
+			Expect(identityProvider.GetIdentityCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CFMetadataPrefix is the domain the CF labels and annotations of a resource are stored under on its Kubernetes
+// object, so that they cannot clash with the labels and annotations that korifi and Kubernetes set themselves.
+// A CF key without a prefix, e.g. "team", is stored as "metadata.cloudfoundry.org/team". The prefix of a prefixed CF
+// key becomes a subdomain, e.g. "example.com/team" is stored as "example.com.metadata.cloudfoundry.org/team".
+const CFMetadataPrefix = "metadata.cloudfoundry.org"
+
+func toK8sMetadataKey(cfKey string) string {
+	slash := strings.LastIndex(cfKey, "/")
+	if slash < 0 {
+		return CFMetadataPrefix + "/" + cfKey
+	}
+
+	return cfKey[:slash] + "." + CFMetadataPrefix + cfKey[slash:]
+}
+
+func toCFMetadataKey(k8sKey string) (string, bool) {
+	slash := strings.LastIndex(k8sKey, "/")
+	if slash < 0 {
+		return "", false
+	}
+
+	prefix, name := k8sKey[:slash], k8sKey[slash+1:]
+	if prefix == CFMetadataPrefix {
+		return name, true
+	}
+
+	cfPrefix := strings.TrimSuffix(prefix, "."+CFMetadataPrefix)
+	if cfPrefix == prefix {
+		return "", false
+	}
+
+	return cfPrefix + "/" + name, true
+}
+
+// withCFMetadata returns the labels or annotations of a Kubernetes object with the CF metadata added to them
+func withCFMetadata(k8sMetadata, cfMetadata map[string]string) map[string]string {
+	if len(cfMetadata) == 0 {
+		return k8sMetadata
+	}
+
+	result := make(map[string]string, len(k8sMetadata)+len(cfMetadata))
+	for key, value := range k8sMetadata {
+		result[key] = value
+	}
+	for key, value := range cfMetadata {
+		result[toK8sMetadataKey(key)] = value
+	}
+
+	return result
+}
+
+// cfMetadata returns the CF metadata stored in the labels or annotations of a Kubernetes object
+func cfMetadata(k8sMetadata map[string]string) map[string]string {
+	result := map[string]string{}
+	for key, value := range k8sMetadata {
+		if cfKey, ok := toCFMetadataKey(key); ok {
+			result[cfKey] = value
+		}
+	}
+
+	return result
+}
+
+// MetadataPatch holds the changes to the CF labels and annotations of a resource. Keys with a nil value are removed,
+// and keys that are not mentioned are left as they are.
+type MetadataPatch struct {
+	Labels      map[string]*string
+	Annotations map[string]*string
+}
+
+func (p MetadataPatch) apply(obj metav1.Object) {
+	obj.SetLabels(patchCFMetadata(obj.GetLabels(), p.Labels))
+	obj.SetAnnotations(patchCFMetadata(obj.GetAnnotations(), p.Annotations))
+}
+
+func patchCFMetadata(k8sMetadata map[string]string, patch map[string]*string) map[string]string {
+	if len(patch) == 0 {
+		return k8sMetadata
+	}
+
+	result := make(map[string]string, len(k8sMetadata)+len(patch))
+	for key, value := range k8sMetadata {
+		result[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(result, toK8sMetadataKey(key))
+			continue
+		}
+		result[toK8sMetadataKey(key)] = *value
+	}
+
+	return result
+}
+
+// ParseLabelSelector parses a CF label_selector into a selector for the Kubernetes labels that the CF labels are
+// stored under. An empty label_selector selects everything.
+func ParseLabelSelector(labelSelector string) (labels.Selector, error) {
+	cfSelector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, _ := cfSelector.Requirements()
+	selector := labels.NewSelector()
+	for _, requirement := range requirements {
+		k8sRequirement, err := labels.NewRequirement(toK8sMetadataKey(requirement.Key()), requirement.Operator(), requirement.Values().List())
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*k8sRequirement)
+	}
+
+	return selector, nil
+}
+
+func labelSelectorListOption(labelSelector string) (client.ListOption, error) {
+	selector, err := ParseLabelSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+
+	return client.MatchingLabelsSelector{Selector: selector}, nil
+}
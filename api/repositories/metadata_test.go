@@ -0,0 +1,38 @@
+package repositories_test
+
+import (
+	. "code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var _ = Describe("ParseLabelSelector", func() {
+	DescribeTable("translates CF label selectors to the labels the CF metadata is stored under",
+		func(labelSelector, expected string) {
+			selector, err := ParseLabelSelector(labelSelector)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selector.String()).To(Equal(expected))
+		},
+		Entry("empty", "", ""),
+		Entry("existence", "env", "metadata.cloudfoundry.org/env"),
+		Entry("non existence", "!env", "!metadata.cloudfoundry.org/env"),
+		Entry("equality", "env=prod", "metadata.cloudfoundry.org/env=prod"),
+		Entry("inequality", "env!=prod", "metadata.cloudfoundry.org/env!=prod"),
+		Entry("set", "example.com/team in (a,b)", "example.com.metadata.cloudfoundry.org/team in (a,b)"),
+		Entry("several requirements", "env=prod,!deprecated", "!metadata.cloudfoundry.org/deprecated,metadata.cloudfoundry.org/env=prod"),
+	)
+
+	It("matches the labels of resources with the CF labels", func() {
+		selector, err := ParseLabelSelector("env=prod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(selector.Matches(labels.Set{"metadata.cloudfoundry.org/env": "prod"})).To(BeTrue())
+		Expect(selector.Matches(labels.Set{"env": "prod"})).To(BeFalse())
+	})
+
+	It("fails for an invalid label selector", func() {
+		_, err := ParseLabelSelector("env in prod")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -66,16 +66,51 @@ var (
 		Resource: "cfserviceinstances",
 	}
 
+	CFTasksGVR = schema.GroupVersionResource{
+		Group:    "workloads.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cftasks",
+	}
+
+	CFDeploymentsGVR = schema.GroupVersionResource{
+		Group:    "workloads.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfdeployments",
+	}
+
+	CFAppRevisionsGVR = schema.GroupVersionResource{
+		Group:    "workloads.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfapprevisions",
+	}
+
+	CFSidecarsGVR = schema.GroupVersionResource{
+		Group:    "workloads.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfsidecars",
+	}
+
+	CFSpaceQuotasGVR = schema.GroupVersionResource{
+		Group:    "workloads.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfspacequotas",
+	}
+
 	ResourceMap = map[string]schema.GroupVersionResource{
 		AppResourceType:             CFAppsGVR,
 		BuildResourceType:           CFBuildsGVR,
+		DeploymentResourceType:      CFDeploymentsGVR,
 		DropletResourceType:         CFDropletsGVR,
 		DomainResourceType:          CFDomainsGVR,
 		PackageResourceType:         CFPackagesGVR,
 		ProcessResourceType:         CFProcessesGVR,
+		RevisionResourceType:        CFAppRevisionsGVR,
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
+		SidecarResourceType:         CFSidecarsGVR,
+		SpaceQuotaResourceType:      CFSpaceQuotasGVR,
+		TaskResourceType:            CFTasksGVR,
 	}
 )
 
@@ -0,0 +1,459 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cforgquotas,verbs=get;list;create;patch;delete
+
+const (
+	OrgQuotaResourceType = "Organization Quota"
+)
+
+type OrgQuotaRepo struct {
+	rootNamespace     string
+	privilegedClient  client.Client
+	userClientFactory UserK8sClientFactory
+}
+
+func NewOrgQuotaRepo(rootNamespace string, privilegedClient client.Client, userClientFactory UserK8sClientFactory) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		rootNamespace:     rootNamespace,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+	}
+}
+
+// QuotaLimits are the limits of an org or space quota. Nil limits are unlimited.
+type QuotaLimits struct {
+	TotalMemoryMB    *int64
+	InstanceMemoryMB *int64
+	AppInstances     *int64
+	Routes           *int64
+	ServiceInstances *int64
+}
+
+// QuotaLimitPatch changes a quota limit to Value, or to unlimited when Value is nil. Limits that are not Set are left
+// as they are.
+type QuotaLimitPatch struct {
+	Set   bool
+	Value *int64
+}
+
+type QuotaLimitsPatch struct {
+	TotalMemoryMB    QuotaLimitPatch
+	InstanceMemoryMB QuotaLimitPatch
+	AppInstances     QuotaLimitPatch
+	Routes           QuotaLimitPatch
+	ServiceInstances QuotaLimitPatch
+}
+
+type OrgQuotaRecord struct {
+	GUID      string
+	Name      string
+	Limits    QuotaLimits
+	OrgGUIDs  []string
+	CreatedAt string
+	UpdatedAt string
+}
+
+type CreateOrgQuotaMessage struct {
+	Name     string
+	Limits   QuotaLimits
+	OrgGUIDs []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs    []string
+	Names    []string
+	OrgGUIDs []string
+}
+
+type PatchOrgQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.checkNameIsFree(ctx, userClient, message.Name); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.checkOrgsExist(ctx, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	cfOrgQuota := &workloadsv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: workloadsv1alpha1.CFOrgQuotaSpec{
+			Name:   message.Name,
+			Limits: message.Limits.toCRDLimits(),
+		},
+	}
+	err = userClient.Create(ctx, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to create org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	if len(message.OrgGUIDs) > 0 {
+		return r.applyOrgQuota(ctx, userClient, cfOrgQuota, message.OrgGUIDs)
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getOrgQuota(ctx, userClient, guid)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuotaList := new(workloadsv1alpha1.CFOrgQuotaList)
+	err = userClient.List(ctx, cfOrgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []OrgQuotaRecord{}, nil
+		}
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to list org quotas in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	cfOrgQuotas := cfOrgQuotaList.Items
+	sort.Slice(cfOrgQuotas, func(i, j int) bool {
+		return cfOrgQuotas[i].CreationTimestamp.Before(&cfOrgQuotas[j].CreationTimestamp)
+	})
+
+	records := []OrgQuotaRecord{}
+	for i := range cfOrgQuotas {
+		if matchesFilter(cfOrgQuotas[i].Name, message.GUIDs) &&
+			matchesFilter(cfOrgQuotas[i].Spec.Name, message.Names) &&
+			matchesAnyFilter(cfOrgQuotas[i].Spec.Orgs, message.OrgGUIDs) {
+			records = append(records, cfOrgQuotaToRecord(&cfOrgQuotas[i]))
+		}
+	}
+
+	return records, nil
+}
+
+func (r *OrgQuotaRepo) PatchOrgQuota(ctx context.Context, authInfo authorization.Info, message PatchOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getOrgQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if message.Name != nil && *message.Name != cfOrgQuota.Spec.Name {
+		if err = r.checkNameIsFree(ctx, userClient, *message.Name); err != nil {
+			return OrgQuotaRecord{}, err
+		}
+	}
+
+	updatedOrgQuota := cfOrgQuota.DeepCopy()
+	if message.Name != nil {
+		updatedOrgQuota.Spec.Name = *message.Name
+	}
+	message.Limits.apply(&updatedOrgQuota.Spec.Limits)
+
+	err = userClient.Patch(ctx, updatedOrgQuota, client.MergeFrom(cfOrgQuota))
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to patch org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(updatedOrgQuota), nil
+}
+
+// DeleteOrgQuota deletes a quota that is not applied to any org
+func (r *OrgQuotaRepo) DeleteOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getOrgQuota(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	if len(cfOrgQuota.Spec.Orgs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			errors.New("org quota is applied to orgs"),
+			"This quota is applied to one or more organizations. Remove this quota from all organizations before deleting.",
+		)
+	}
+
+	err = userClient.Delete(ctx, cfOrgQuota)
+	if err != nil {
+		return fmt.Errorf("failed to delete org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return nil
+}
+
+// ApplyOrgQuota applies the quota to the orgs, in place of any other quota they had
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, guid string, orgGUIDs []string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getOrgQuota(ctx, userClient, guid)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.checkOrgsExist(ctx, orgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return r.applyOrgQuota(ctx, userClient, cfOrgQuota, orgGUIDs)
+}
+
+func (r *OrgQuotaRepo) applyOrgQuota(ctx context.Context, userClient client.Client, cfOrgQuota *workloadsv1alpha1.CFOrgQuota, orgGUIDs []string) (OrgQuotaRecord, error) {
+	cfOrgQuotaList := new(workloadsv1alpha1.CFOrgQuotaList)
+	err := userClient.List(ctx, cfOrgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	for i := range cfOrgQuotaList.Items {
+		otherQuota := &cfOrgQuotaList.Items[i]
+		if otherQuota.Name == cfOrgQuota.Name {
+			continue
+		}
+
+		remainingOrgs := withoutValues(otherQuota.Spec.Orgs, orgGUIDs)
+		if len(remainingOrgs) == len(otherQuota.Spec.Orgs) {
+			continue
+		}
+
+		updatedQuota := otherQuota.DeepCopy()
+		updatedQuota.Spec.Orgs = remainingOrgs
+		err = userClient.Patch(ctx, updatedQuota, client.MergeFrom(otherQuota))
+		if err != nil {
+			return OrgQuotaRecord{}, fmt.Errorf("failed to remove orgs from org quota %s: %w", otherQuota.Name, apierrors.FromK8sError(err, OrgQuotaResourceType))
+		}
+	}
+
+	updatedOrgQuota := cfOrgQuota.DeepCopy()
+	updatedOrgQuota.Spec.Orgs = withValues(updatedOrgQuota.Spec.Orgs, orgGUIDs)
+	err = userClient.Patch(ctx, updatedOrgQuota, client.MergeFrom(cfOrgQuota))
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to apply org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(updatedOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) getOrgQuota(ctx context.Context, userClient client.Client, guid string) (*workloadsv1alpha1.CFOrgQuota, error) {
+	cfOrgQuota := new(workloadsv1alpha1.CFOrgQuota)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfOrgQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuota, nil
+}
+
+func (r *OrgQuotaRepo) checkNameIsFree(ctx context.Context, userClient client.Client, name string) error {
+	cfOrgQuotaList := new(workloadsv1alpha1.CFOrgQuotaList)
+	err := userClient.List(ctx, cfOrgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	for _, cfOrgQuota := range cfOrgQuotaList.Items {
+		if cfOrgQuota.Spec.Name == name {
+			return apierrors.NewUniquenessError(
+				fmt.Errorf("org quota %q already exists", name),
+				fmt.Sprintf("Organization Quota '%s' already exists.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkOrgsExist looks the orgs up with the privileged client, as users managing quotas do not need to see the orgs
+func (r *OrgQuotaRepo) checkOrgsExist(ctx context.Context, orgGUIDs []string) error {
+	missingOrgs, err := missingAnchors(ctx, r.privilegedClient, r.rootNamespace, orgGUIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(missingOrgs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("orgs %v do not exist", missingOrgs),
+			fmt.Sprintf("Organizations with guids [%s] do not exist, or you do not have access to them.", quoteGUIDs(missingOrgs)),
+		)
+	}
+
+	return nil
+}
+
+// missingAnchors returns the GUIDs of the orgs or spaces that have no SubnamespaceAnchor in the namespace
+func missingAnchors(ctx context.Context, k8sClient client.Client, namespace string, guids []string) ([]string, error) {
+	missing := []string{}
+	for _, guid := range guids {
+		err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, new(v1alpha2.SubnamespaceAnchor))
+		if k8serrors.IsNotFound(err) {
+			missing = append(missing, guid)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subnamespace anchor %s/%s: %w", namespace, guid, err)
+		}
+	}
+
+	return missing, nil
+}
+
+func quoteGUIDs(guids []string) string {
+	quoted := make([]string, 0, len(guids))
+	for _, guid := range guids {
+		quoted = append(quoted, fmt.Sprintf("%q", guid))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// withValues returns the values followed by the additional values that are not already in them
+func withValues(values, additional []string) []string {
+	result := append([]string{}, values...)
+	for _, value := range additional {
+		if !containsValue(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func withoutValues(values, removed []string) []string {
+	result := []string{}
+	for _, value := range values {
+		if !containsValue(removed, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cfOrgQuotaToRecord(cfOrgQuota *workloadsv1alpha1.CFOrgQuota) OrgQuotaRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfOrgQuota.ObjectMeta)
+
+	return OrgQuotaRecord{
+		GUID:      cfOrgQuota.Name,
+		Name:      cfOrgQuota.Spec.Name,
+		Limits:    quotaLimitsFromCRD(cfOrgQuota.Spec.Limits),
+		OrgGUIDs:  append([]string{}, cfOrgQuota.Spec.Orgs...),
+		CreatedAt: formatTimestamp(cfOrgQuota.CreationTimestamp),
+		UpdatedAt: updatedAtTime,
+	}
+}
+
+func (l QuotaLimits) toCRDLimits() workloadsv1alpha1.QuotaLimits {
+	return workloadsv1alpha1.QuotaLimits{
+		TotalMemoryMB:    l.TotalMemoryMB,
+		InstanceMemoryMB: l.InstanceMemoryMB,
+		AppInstances:     toIntLimit(l.AppInstances),
+		Routes:           toIntLimit(l.Routes),
+		ServiceInstances: toIntLimit(l.ServiceInstances),
+	}
+}
+
+func (p QuotaLimitsPatch) apply(limits *workloadsv1alpha1.QuotaLimits) {
+	if p.TotalMemoryMB.Set {
+		limits.TotalMemoryMB = p.TotalMemoryMB.Value
+	}
+	if p.InstanceMemoryMB.Set {
+		limits.InstanceMemoryMB = p.InstanceMemoryMB.Value
+	}
+	if p.AppInstances.Set {
+		limits.AppInstances = toIntLimit(p.AppInstances.Value)
+	}
+	if p.Routes.Set {
+		limits.Routes = toIntLimit(p.Routes.Value)
+	}
+	if p.ServiceInstances.Set {
+		limits.ServiceInstances = toIntLimit(p.ServiceInstances.Value)
+	}
+}
+
+func quotaLimitsFromCRD(limits workloadsv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		TotalMemoryMB:    limits.TotalMemoryMB,
+		InstanceMemoryMB: limits.InstanceMemoryMB,
+		AppInstances:     toInt64Limit(limits.AppInstances),
+		Routes:           toInt64Limit(limits.Routes),
+		ServiceInstances: toInt64Limit(limits.ServiceInstances),
+	}
+}
+
+func toIntLimit(limit *int64) *int {
+	if limit == nil {
+		return nil
+	}
+
+	value := int(*limit)
+	return &value
+}
+
+func toInt64Limit(limit *int) *int64 {
+	if limit == nil {
+		return nil
+	}
+
+	value := int64(*limit)
+	return &value
+}
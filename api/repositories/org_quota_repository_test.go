@@ -0,0 +1,220 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("OrgQuotaRepository", func() {
+	var (
+		ctx          context.Context
+		orgQuotaRepo *repositories.OrgQuotaRepo
+		org          *hnsv1alpha2.SubnamespaceAnchor
+	)
+
+	int64Ptr := func(value int64) *int64 {
+		return &value
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgQuotaRepo = repositories.NewOrgQuotaRepo(rootNamespace, k8sClient, userClientFactory)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+	})
+
+	createOrgQuota := func(name string, orgGUIDs ...string) *workloadsv1alpha1.CFOrgQuota {
+		routes := 10
+		cfOrgQuota := &workloadsv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("org-quota"),
+				Namespace: rootNamespace,
+			},
+			Spec: workloadsv1alpha1.CFOrgQuotaSpec{
+				Name:   name,
+				Limits: workloadsv1alpha1.QuotaLimits{Routes: &routes},
+				Orgs:   orgGUIDs,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+		return cfOrgQuota
+	}
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			message        repositories.CreateOrgQuotaMessage
+			orgQuotaRecord repositories.OrgQuotaRecord
+			createErr      error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					TotalMemoryMB: int64Ptr(2048),
+					AppInstances:  int64Ptr(5),
+				},
+				OrgGUIDs: []string{org.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, createErr = orgQuotaRepo.CreateOrgQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the quota applied to the orgs", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.Name).To(Equal("my-quota"))
+				Expect(orgQuotaRecord.Limits).To(Equal(repositories.QuotaLimits{
+					TotalMemoryMB: int64Ptr(2048),
+					AppInstances:  int64Ptr(5),
+				}))
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+
+				cfOrgQuota := new(workloadsv1alpha1.CFOrgQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgQuotaRecord.GUID}, cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Name).To(Equal("my-quota"))
+				Expect(*cfOrgQuota.Spec.Limits.AppInstances).To(Equal(5))
+				Expect(cfOrgQuota.Spec.Limits.Routes).To(BeNil())
+				Expect(cfOrgQuota.Spec.Orgs).To(ConsistOf(org.Name))
+			})
+
+			When("a quota with the same name exists", func() {
+				BeforeEach(func() {
+					createOrgQuota("my-quota")
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+
+			When("an org does not exist", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{"not-an-org"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			quota1, quota2 *workloadsv1alpha1.CFOrgQuota
+			message        repositories.ListOrgQuotasMessage
+			records        []repositories.OrgQuotaRecord
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			quota1 = createOrgQuota("quota-1", org.Name)
+			quota2 = createOrgQuota("quota-2")
+			message = repositories.ListOrgQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = orgQuotaRepo.ListOrgQuotas(ctx, authInfo, message)
+		})
+
+		It("lists all the quotas", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+			))
+		})
+
+		When("filtering by org", func() {
+			BeforeEach(func() {
+				message.OrgGUIDs = []string{org.Name}
+			})
+
+			It("lists the quotas applied to the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].GUID).To(Equal(quota1.Name))
+				Expect(records[0].Limits.Routes).To(Equal(int64Ptr(10)))
+			})
+		})
+	})
+
+	Describe("DeleteOrgQuota", func() {
+		var (
+			cfOrgQuota *workloadsv1alpha1.CFOrgQuota
+			deleteErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			cfOrgQuota = createOrgQuota("my-quota")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = orgQuotaRepo.DeleteOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("deletes the quota", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			_, err := orgQuotaRepo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the quota is applied to an org", func() {
+			BeforeEach(func() {
+				cfOrgQuota = createOrgQuota("applied-quota", org.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("ApplyOrgQuota", func() {
+		var (
+			cfOrgQuota, otherQuota *workloadsv1alpha1.CFOrgQuota
+			orgQuotaRecord         repositories.OrgQuotaRecord
+			applyErr               error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			cfOrgQuota = createOrgQuota("my-quota")
+			otherQuota = createOrgQuota("other-quota", org.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, applyErr = orgQuotaRepo.ApplyOrgQuota(ctx, authInfo, cfOrgQuota.Name, []string{org.Name})
+		})
+
+		It("moves the org to the quota", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+			Expect(otherQuota.Spec.Orgs).To(BeEmpty())
+		})
+	})
+})
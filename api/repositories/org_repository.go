@@ -19,7 +19,7 @@ import (
 	"sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 )
 
-//+kubebuilder:rbac:groups=hnc.x-k8s.io,resources=subnamespaceanchors,verbs=list;create;delete;watch
+//+kubebuilder:rbac:groups=hnc.x-k8s.io,resources=subnamespaceanchors,verbs=get;list;create;delete;watch
 //+kubebuilder:rbac:groups=hnc.x-k8s.io,resources=hierarchyconfigurations,verbs=get;list;watch;update
 //+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;create;delete;watch
 
@@ -30,6 +30,7 @@ type CFSpaceRepository interface {
 	ListSpaces(context.Context, authorization.Info, ListSpacesMessage) ([]SpaceRecord, error)
 	GetSpace(context.Context, authorization.Info, string) (SpaceRecord, error)
 	DeleteSpace(context.Context, authorization.Info, DeleteSpaceMessage) error
+	PatchSpace(context.Context, authorization.Info, PatchSpaceMessage) (SpaceRecord, error)
 }
 
 const (
@@ -54,17 +55,34 @@ type CreateSpaceMessage struct {
 	Name                     string
 	OrganizationGUID         string
 	ImageRegistryCredentials string
+	Labels                   map[string]string
+	Annotations              map[string]string
 }
 
 type ListOrgsMessage struct {
-	Names []string
-	GUIDs []string
+	Names         []string
+	GUIDs         []string
+	LabelSelector string
 }
 
 type ListSpacesMessage struct {
 	Names             []string
 	GUIDs             []string
 	OrganizationGUIDs []string
+	LabelSelector     string
+}
+
+type PatchOrgMessage struct {
+	GUID          string
+	Name          *string
+	MetadataPatch MetadataPatch
+}
+
+type PatchSpaceMessage struct {
+	GUID             string
+	OrganizationGUID string
+	Name             *string
+	MetadataPatch    MetadataPatch
 }
 
 type DeleteOrgMessage struct {
@@ -134,9 +152,10 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, org Cr
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      orgGUID,
 				Namespace: r.rootNamespace,
-				Labels: map[string]string{
+				Labels: withCFMetadata(map[string]string{
 					OrgNameLabel: org.Name,
-				},
+				}, org.Labels),
+				Annotations: withCFMetadata(nil, org.Annotations),
 			},
 		},
 		OrgResourceType,
@@ -156,8 +175,8 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, org Cr
 		Name:        org.Name,
 		GUID:        anchor.Name,
 		Suspended:   org.Suspended,
-		Labels:      org.Labels,
-		Annotations: org.Annotations,
+		Labels:      cfMetadata(anchor.Labels),
+		Annotations: cfMetadata(anchor.Annotations),
 		CreatedAt:   anchor.CreationTimestamp.Time,
 		UpdatedAt:   anchor.CreationTimestamp.Time,
 	}
@@ -202,9 +221,10 @@ func (r *OrgRepo) CreateSpace(ctx context.Context, info authorization.Info, mess
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      spaceGUID,
 				Namespace: message.OrganizationGUID,
-				Labels: map[string]string{
+				Labels: withCFMetadata(map[string]string{
 					SpaceNameLabel: message.Name,
-				},
+				}, message.Labels),
+				Annotations: withCFMetadata(nil, message.Annotations),
 			},
 		},
 		SpaceResourceType,
@@ -248,6 +268,8 @@ func (r *OrgRepo) CreateSpace(ctx context.Context, info authorization.Info, mess
 		Name:             message.Name,
 		GUID:             anchor.Name,
 		OrganizationGUID: message.OrganizationGUID,
+		Labels:           cfMetadata(anchor.Labels),
+		Annotations:      cfMetadata(anchor.Annotations),
 		CreatedAt:        anchor.CreationTimestamp.Time,
 		UpdatedAt:        anchor.CreationTimestamp.Time,
 	}
@@ -309,6 +331,9 @@ outer:
 			// HNC is broken
 			return nil, fmt.Errorf("failed establishing permissions in new namespace after %s: %w", time.Since(t1), err)
 		default:
+			// the permissions of the user in the new namespace are propagated by HNC after the anchor is created
+			r.nsPerms.Invalidate()
+
 			var authorizedNamespaces map[string]bool
 			if resourceType == OrgResourceType {
 				authorizedNamespaces, err = r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
@@ -332,7 +357,12 @@ outer:
 func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter ListOrgsMessage) ([]OrgRecord, error) {
 	subnamespaceAnchorList := &v1alpha2.SubnamespaceAnchorList{}
 
-	options := []client.ListOption{client.InNamespace(r.rootNamespace)}
+	labelSelector, err := labelSelectorListOption(filter.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []client.ListOption{client.InNamespace(r.rootNamespace), labelSelector}
 	if len(filter.Names) > 0 {
 		namesRequirement, err := labels.NewRequirement(OrgNameLabel, selection.In, filter.Names)
 		if err != nil {
@@ -344,7 +374,7 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter
 		options = append(options, namesSelector)
 	}
 
-	err := r.privilegedClient.List(ctx, subnamespaceAnchorList, options...)
+	err = r.privilegedClient.List(ctx, subnamespaceAnchorList, options...)
 	if err != nil {
 		return nil, apierrors.FromK8sError(err, OrgResourceType)
 	}
@@ -361,10 +391,12 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter
 		}
 
 		records = append(records, OrgRecord{
-			Name:      anchor.Labels[OrgNameLabel],
-			GUID:      anchor.Name,
-			CreatedAt: anchor.CreationTimestamp.Time,
-			UpdatedAt: anchor.CreationTimestamp.Time,
+			Name:        anchor.Labels[OrgNameLabel],
+			GUID:        anchor.Name,
+			Labels:      cfMetadata(anchor.Labels),
+			Annotations: cfMetadata(anchor.Annotations),
+			CreatedAt:   anchor.CreationTimestamp.Time,
+			UpdatedAt:   anchor.CreationTimestamp.Time,
 		})
 	}
 
@@ -386,9 +418,15 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter
 }
 
 func (r *OrgRepo) ListSpaces(ctx context.Context, info authorization.Info, message ListSpacesMessage) ([]SpaceRecord, error) {
+	// the org anchors are listed together with the space anchors, so the label selector is matched in memory
+	labelSelector, err := ParseLabelSelector(message.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", message.LabelSelector, err)
+	}
+
 	subnamespaceAnchorList := &v1alpha2.SubnamespaceAnchorList{}
 
-	err := r.privilegedClient.List(ctx, subnamespaceAnchorList)
+	err = r.privilegedClient.List(ctx, subnamespaceAnchorList)
 	if err != nil {
 		return nil, apierrors.FromK8sError(err, SpaceResourceType)
 	}
@@ -428,10 +466,16 @@ func (r *OrgRepo) ListSpaces(ctx context.Context, info authorization.Info, messa
 			continue
 		}
 
+		if !labelSelector.Matches(labels.Set(anchor.Labels)) {
+			continue
+		}
+
 		records = append(records, SpaceRecord{
 			Name:             spaceName,
 			GUID:             anchor.Name,
 			OrganizationGUID: anchor.Namespace,
+			Labels:           cfMetadata(anchor.Labels),
+			Annotations:      cfMetadata(anchor.Annotations),
 			CreatedAt:        anchor.CreationTimestamp.Time,
 			UpdatedAt:        anchor.CreationTimestamp.Time,
 		})
@@ -498,6 +542,80 @@ func (r *OrgRepo) GetOrg(ctx context.Context, info authorization.Info, orgGUID s
 	return orgRecords[0], nil
 }
 
+func (r *OrgRepo) PatchOrg(ctx context.Context, info authorization.Info, message PatchOrgMessage) (OrgRecord, error) {
+	anchor, err := r.patchSubnamespaceAnchor(ctx, info, r.rootNamespace, message.GUID, OrgNameLabel, message.Name, message.MetadataPatch, OrgResourceType)
+	if err != nil {
+		return OrgRecord{}, err
+	}
+
+	return OrgRecord{
+		Name:        anchor.Labels[OrgNameLabel],
+		GUID:        anchor.Name,
+		Labels:      cfMetadata(anchor.Labels),
+		Annotations: cfMetadata(anchor.Annotations),
+		CreatedAt:   anchor.CreationTimestamp.Time,
+		UpdatedAt:   anchor.CreationTimestamp.Time,
+	}, nil
+}
+
+func (r *OrgRepo) PatchSpace(ctx context.Context, info authorization.Info, message PatchSpaceMessage) (SpaceRecord, error) {
+	anchor, err := r.patchSubnamespaceAnchor(ctx, info, message.OrganizationGUID, message.GUID, SpaceNameLabel, message.Name, message.MetadataPatch, SpaceResourceType)
+	if err != nil {
+		return SpaceRecord{}, err
+	}
+
+	return SpaceRecord{
+		Name:             anchor.Labels[SpaceNameLabel],
+		GUID:             anchor.Name,
+		OrganizationGUID: anchor.Namespace,
+		Labels:           cfMetadata(anchor.Labels),
+		Annotations:      cfMetadata(anchor.Annotations),
+		CreatedAt:        anchor.CreationTimestamp.Time,
+		UpdatedAt:        anchor.CreationTimestamp.Time,
+	}, nil
+}
+
+// patchSubnamespaceAnchor renames the org or space of the anchor and updates its metadata. The name is stored in the
+// nameLabel of the anchor, which the subnamespaceanchor webhook checks for duplicates.
+func (r *OrgRepo) patchSubnamespaceAnchor(
+	ctx context.Context,
+	info authorization.Info,
+	namespace, guid, nameLabel string,
+	name *string,
+	metadataPatch MetadataPatch,
+	resourceType string,
+) (*v1alpha2.SubnamespaceAnchor, error) {
+	baseAnchor := new(v1alpha2.SubnamespaceAnchor)
+	err := r.privilegedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: guid}, baseAnchor)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, resourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	anchor := baseAnchor.DeepCopy()
+	metadataPatch.apply(anchor)
+	if name != nil {
+		if anchor.Labels == nil {
+			anchor.Labels = map[string]string{}
+		}
+		anchor.Labels[nameLabel] = *name
+	}
+
+	err = userClient.Patch(ctx, anchor, client.MergeFrom(baseAnchor))
+	if err != nil {
+		if webhookError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			return nil, apierrors.NewUnprocessableEntityError(err, webhookError.Error())
+		}
+		return nil, fmt.Errorf("failed to patch subnamespaceanchor: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	return anchor, nil
+}
+
 func (r *OrgRepo) DeleteOrg(ctx context.Context, info authorization.Info, message DeleteOrgMessage) error {
 	var err error
 	hierarchyObj := v1alpha2.HierarchyConfiguration{}
@@ -702,6 +702,111 @@ var _ = Describe("OrgRepository", func() {
 		})
 	})
 
+	Describe("Patch", func() {
+		var (
+			orgAnchor *hncv1alpha2.SubnamespaceAnchor
+			newName   string
+			prod      string
+		)
+
+		BeforeEach(func() {
+			orgAnchor = createOrgAnchorAndNamespace(ctx, rootNamespace, "the-org")
+			newName = "new-name"
+			prod = "prod"
+		})
+
+		Describe("Org", func() {
+			var (
+				orgRecord repositories.OrgRecord
+				patchErr  error
+			)
+
+			JustBeforeEach(func() {
+				orgRecord, patchErr = orgRepo.PatchOrg(ctx, authInfo, repositories.PatchOrgMessage{
+					GUID: orgAnchor.Name,
+					Name: &newName,
+					MetadataPatch: repositories.MetadataPatch{
+						Labels: map[string]*string{"env": &prod},
+					},
+				})
+			})
+
+			When("the user has the admin role", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("renames the org and sets its labels", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(orgRecord.GUID).To(Equal(orgAnchor.Name))
+					Expect(orgRecord.Name).To(Equal(newName))
+					Expect(orgRecord.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+					anchor := &hncv1alpha2.SubnamespaceAnchor{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(orgAnchor), anchor)).To(Succeed())
+					Expect(anchor.Labels).To(HaveKeyWithValue(repositories.OrgNameLabel, newName))
+					Expect(anchor.Labels).To(HaveKeyWithValue("metadata.cloudfoundry.org/env", "prod"))
+				})
+			})
+
+			When("the user does not have the admin role", func() {
+				It("errors with forbidden", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
+		})
+
+		Describe("Space", func() {
+			var (
+				spaceAnchor *hncv1alpha2.SubnamespaceAnchor
+				spaceRecord repositories.SpaceRecord
+				patchErr    error
+			)
+
+			BeforeEach(func() {
+				spaceAnchor = createSpaceAnchorAndNamespace(ctx, orgAnchor.Name, "the-space")
+			})
+
+			JustBeforeEach(func() {
+				spaceRecord, patchErr = orgRepo.PatchSpace(ctx, authInfo, repositories.PatchSpaceMessage{
+					GUID:             spaceAnchor.Name,
+					OrganizationGUID: orgAnchor.Name,
+					Name:             &newName,
+				})
+			})
+
+			When("the user has the admin role", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, orgAnchor.Name)
+				})
+
+				It("renames the space", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(spaceRecord.GUID).To(Equal(spaceAnchor.Name))
+					Expect(spaceRecord.OrganizationGUID).To(Equal(orgAnchor.Name))
+					Expect(spaceRecord.Name).To(Equal(newName))
+					Expect(spaceRecord.Labels).To(BeEmpty())
+				})
+			})
+
+			When("the space doesn't exist", func() {
+				BeforeEach(func() {
+					spaceAnchor.Name = "non-existent-space"
+				})
+
+				It("errors with not found", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("the user does not have the admin role", func() {
+				It("errors with forbidden", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		var (
 			ctx context.Context
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 
@@ -9,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,7 +31,7 @@ const (
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfpackages,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfpackages/status,verbs=get
 
-//+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts;secrets,verbs=get;list;watch;create
 //+kubebuilder:rbac:groups="",resources=serviceaccounts/status;secrets/status,verbs=get
 
 type PackageRepo struct {
@@ -51,14 +53,17 @@ func NewPackageRepo(
 }
 
 type PackageRecord struct {
-	GUID      string
-	UID       types.UID
-	Type      string
-	AppGUID   string
-	SpaceGUID string
-	State     string
-	CreatedAt string // Can we also just use date objects directly here?
-	UpdatedAt string
+	GUID        string
+	UID         types.UID
+	Type        string
+	AppGUID     string
+	SpaceGUID   string
+	State       string
+	ImageRef    string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   string // Can we also just use date objects directly here?
+	UpdatedAt   string
 }
 
 type ListPackagesMessage struct {
@@ -66,18 +71,29 @@ type ListPackagesMessage struct {
 	SortBy          string
 	DescendingOrder bool
 	States          []string
+	LabelSelector   string
 }
 
 type CreatePackageMessage struct {
-	Type      string
-	AppGUID   string
-	SpaceGUID string
-	OwnerRef  metav1.OwnerReference
+	Type        string
+	AppGUID     string
+	SpaceGUID   string
+	OwnerRef    metav1.OwnerReference
+	Data        *PackageData
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// PackageData references the image of a docker package, and the credentials to pull it if it is private
+type PackageData struct {
+	Image    string
+	Username *string
+	Password *string
 }
 
 func (message CreatePackageMessage) toCFPackage() workloadsv1alpha1.CFPackage {
 	guid := uuid.NewString()
-	return workloadsv1alpha1.CFPackage{
+	cfPackage := workloadsv1alpha1.CFPackage{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       kind,
 			APIVersion: workloadsv1alpha1.GroupVersion.Identifier(),
@@ -86,6 +102,8 @@ func (message CreatePackageMessage) toCFPackage() workloadsv1alpha1.CFPackage {
 			Name:            guid,
 			Namespace:       message.SpaceGUID,
 			OwnerReferences: []metav1.OwnerReference{message.OwnerRef},
+			Labels:          withCFMetadata(nil, message.Labels),
+			Annotations:     withCFMetadata(nil, message.Annotations),
 		},
 		Spec: workloadsv1alpha1.CFPackageSpec{
 			Type: workloadsv1alpha1.PackageType(message.Type),
@@ -94,6 +112,15 @@ func (message CreatePackageMessage) toCFPackage() workloadsv1alpha1.CFPackage {
 			},
 		},
 	}
+
+	if message.Data != nil {
+		cfPackage.Spec.Source.Registry.Image = message.Data.Image
+		if message.Data.Username != nil {
+			cfPackage.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: guid}}
+		}
+	}
+
+	return cfPackage
 }
 
 type UpdatePackageSourceMessage struct {
@@ -109,15 +136,67 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	var registryServer string
+	if message.Data != nil {
+		ref, err := name.ParseReference(message.Data.Image)
+		if err != nil {
+			return PackageRecord{}, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Invalid image reference %q", message.Data.Image))
+		}
+		registryServer = ref.Context().RegistryStr()
+	}
+
 	cfPackage := message.toCFPackage()
 	err = userClient.Create(ctx, &cfPackage)
 	if err != nil {
 		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
 	}
 
+	if message.Data != nil && message.Data.Username != nil {
+		imagePullSecret, err := toImagePullSecret(cfPackage, registryServer, *message.Data.Username, *message.Data.Password)
+		if err != nil {
+			return PackageRecord{}, err
+		}
+
+		err = userClient.Create(ctx, &imagePullSecret)
+		if err != nil {
+			return PackageRecord{}, fmt.Errorf("failed to create image pull secret for package: %w", apierrors.FromK8sError(err, PackageResourceType))
+		}
+	}
+
 	return cfPackageToPackageRecord(cfPackage), nil
 }
 
+func toImagePullSecret(cfPackage workloadsv1alpha1.CFPackage, registryServer, username, password string) (corev1.Secret, error) {
+	dockerConfigJSON, err := json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{
+			registryServer: map[string]string{
+				"username": username,
+				"password": password,
+			},
+		},
+	})
+	if err != nil {
+		return corev1.Secret{}, err
+	}
+
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfPackage.Spec.Source.Registry.ImagePullSecrets[0].Name,
+			Namespace: cfPackage.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: APIVersion,
+				Kind:       kind,
+				Name:       cfPackage.Name,
+				UID:        cfPackage.UID,
+			}},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: dockerConfigJSON,
+		},
+	}, nil
+}
+
 func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Info, guid string) (PackageRecord, error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, PackageResourceType)
 	if err != nil {
@@ -147,17 +226,23 @@ func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.I
 		return []PackageRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []PackageRecord{}, err
+	}
+
 	var filteredPackages []workloadsv1alpha1.CFPackage
 	for ns := range nsList {
 		packageList := &workloadsv1alpha1.CFPackageList{}
-		err = userClient.List(ctx, packageList, client.InNamespace(ns))
+		err = listInChunks(ctx, userClient, packageList, func() {
+			filteredPackages = append(filteredPackages, applyPackageFilter(packageList.Items, message)...)
+		}, client.InNamespace(ns), labelSelector)
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
 			return []PackageRecord{}, fmt.Errorf("failed to list packages in namespace %s: %w", ns, apierrors.FromK8sError(err, PackageResourceType))
 		}
-		filteredPackages = append(filteredPackages, applyPackageFilter(packageList.Items, message)...)
 	}
 	orderedPackages := orderPackages(filteredPackages, message)
 
@@ -246,14 +331,17 @@ func cfPackageToPackageRecord(cfPackage workloadsv1alpha1.CFPackage) PackageReco
 		state = PackageStateReady
 	}
 	return PackageRecord{
-		GUID:      cfPackage.Name,
-		UID:       cfPackage.UID,
-		SpaceGUID: cfPackage.Namespace,
-		Type:      string(cfPackage.Spec.Type),
-		AppGUID:   cfPackage.Spec.AppRef.Name,
-		State:     state,
-		CreatedAt: formatTimestamp(cfPackage.CreationTimestamp),
-		UpdatedAt: updatedAtTime,
+		GUID:        cfPackage.Name,
+		UID:         cfPackage.UID,
+		SpaceGUID:   cfPackage.Namespace,
+		Type:        string(cfPackage.Spec.Type),
+		AppGUID:     cfPackage.Spec.AppRef.Name,
+		State:       state,
+		ImageRef:    cfPackage.Spec.Source.Registry.Image,
+		Labels:      cfMetadata(cfPackage.Labels),
+		Annotations: cfMetadata(cfPackage.Annotations),
+		CreatedAt:   formatTimestamp(cfPackage.CreationTimestamp),
+		UpdatedAt:   updatedAtTime,
 	}
 }
 
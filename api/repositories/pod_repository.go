@@ -1,27 +1,33 @@
 package repositories
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/apierrors"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	k8sclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	"k8s.io/metrics/pkg/client/clientset/versioned"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
 //+kubebuilder:rbac:groups="",resources=pods/status,verbs=get
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
 //+kubebuilder:rbac:groups="metrics.k8s.io",resources=pods,verbs=get;list;watch
 
 const (
@@ -31,11 +37,19 @@ const (
 	cfProcessGuidKey       = "workloads.cloudfoundry.org/guid"
 	RunningState           = "RUNNING"
 	pendingState           = "STARTING"
+	CrashedState           = "CRASHED"
 	// All below statuses changed to "DOWN" until we decide what statuses we want to support in the future
-	crashedState             = "DOWN"
 	unknownState             = "DOWN"
 	ProcessStatsResourceType = "Process Stats"
 	PodMetricsResourceType   = "Pod Metrics"
+	LogResourceType          = "Log"
+	InstanceResourceType     = "Instance"
+
+	RuntimeLogSourceTypePrefix = "APP/PROC/"
+	StagingLogSourceType       = "STG"
+
+	// maxLogLineSize bounds the size of a single log line read from a container
+	maxLogLineSize = 1024 * 1024
 )
 
 type PodRepo struct {
@@ -70,6 +84,32 @@ type Usage struct {
 	Disk *int64
 }
 
+type LogRecord struct {
+	Message    string
+	Timestamp  int64
+	SourceType string
+	InstanceID string
+}
+
+type RuntimeLogsMessage struct {
+	SpaceGUID string
+	AppGUID   string
+	// StartTime is the earliest log timestamp to return, in nanoseconds since the epoch
+	StartTime int64
+	// TailLines, if set, limits the number of lines read from the end of each container log
+	TailLines *int64
+	// Limit, if set, stops reading each container log once that many lines have been read
+	Limit int64
+}
+
+type DeletePodMessage struct {
+	SpaceGUID     string
+	AppGUID       string
+	AppRevision   string
+	ProcessGUID   string
+	InstanceIndex int
+}
+
 type ListPodStatsMessage struct {
 	Namespace   string
 	AppGUID     string
@@ -153,6 +193,90 @@ func (r *PodRepo) ListPodStats(ctx context.Context, authInfo authorization.Info,
 	return records, nil
 }
 
+// DeletePod deletes the pod running the given instance of a process. The pod is
+// recreated by its workload, which effectively restarts that single instance.
+func (r *PodRepo) DeletePod(ctx context.Context, authInfo authorization.Info, message DeletePodMessage) error {
+	labelSelector, err := labels.ValidatedSelectorFromSet(map[string]string{
+		workloadsv1alpha1.CFAppGUIDLabelKey: message.AppGUID,
+		eiriniLabelVersionKey:               message.AppRevision,
+		cfProcessGuidKey:                    message.ProcessGUID,
+	})
+	if err != nil {
+		return err
+	}
+
+	pods, err := r.listPods(ctx, authInfo, client.ListOptions{Namespace: message.SpaceGUID, LabelSelector: labelSelector})
+	if err != nil {
+		return err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	for i := range pods {
+		index, err := extractIndex(pods[i])
+		if err != nil || index != message.InstanceIndex {
+			continue
+		}
+
+		err = userClient.Delete(ctx, &pods[i])
+		if err != nil {
+			return fmt.Errorf("failed to delete pod: %w", apierrors.FromK8sError(err, InstanceResourceType))
+		}
+
+		return nil
+	}
+
+	return apierrors.NewNotFoundError(nil, InstanceResourceType)
+}
+
+func (r *PodRepo) GetRuntimeLogsForApp(ctx context.Context, authInfo authorization.Info, message RuntimeLogsMessage) ([]LogRecord, error) {
+	appGUIDRequirement, err := labels.NewRequirement(workloadsv1alpha1.CFAppGUIDLabelKey, selection.Equals, []string{message.AppGUID})
+	if err != nil {
+		return nil, err
+	}
+	// Only LRP pods carry the process guid label, this excludes the kpack build pods of the app
+	processGUIDRequirement, err := labels.NewRequirement(cfProcessGuidKey, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	labelSelector := labels.NewSelector().Add(*appGUIDRequirement, *processGUIDRequirement)
+
+	pods, err := r.listPods(ctx, authInfo, client.ListOptions{Namespace: message.SpaceGUID, LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	k8sClient, err := r.userClientFactory.BuildK8sClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user k8s client: %w", err)
+	}
+
+	var records []LogRecord
+	for _, pod := range pods {
+		index, err := extractIndex(pod)
+		if err != nil {
+			return nil, err
+		}
+
+		containerLogs, err := readContainerLogs(ctx, k8sClient, pod, workloadsContainerName, message.StartTime, message.TailLines, message.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		sourceType := RuntimeLogSourceTypePrefix + strings.ToUpper(pod.Labels[workloadsv1alpha1.CFProcessTypeLabelKey])
+		for i := range containerLogs {
+			containerLogs[i].SourceType = sourceType
+			containerLogs[i].InstanceID = strconv.Itoa(index)
+		}
+		records = append(records, containerLogs...)
+	}
+
+	return records, nil
+}
+
 func (r *PodRepo) listPods(ctx context.Context, authInfo authorization.Info, listOpts client.ListOptions) ([]corev1.Pod, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -168,6 +292,63 @@ func (r *PodRepo) listPods(ctx context.Context, authInfo authorization.Info, lis
 	return podList.Items, nil
 }
 
+// readContainerLogs reads the logs of a single container, dropping lines older than startTime (in nanoseconds). When
+// limit is set, the log stream is closed as soon as limit lines have been read, rather than read to its end.
+// Containers that have not started yet have no logs and are reported as such rather than as an error.
+func readContainerLogs(ctx context.Context, k8sClient k8sclient.Interface, pod corev1.Pod, containerName string, startTime int64, tailLines *int64, limit int64) ([]LogRecord, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container:  containerName,
+		Timestamps: true,
+		TailLines:  tailLines,
+	}
+	if startTime > 0 {
+		sinceTime := v1.NewTime(time.Unix(0, startTime))
+		logOptions.SinceTime = &sinceTime
+	}
+
+	logStream, err := k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		if k8serrors.IsBadRequest(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read logs of pod %q: %w", pod.Name, apierrors.FromK8sError(err, LogResourceType))
+	}
+	defer logStream.Close()
+
+	var records []LogRecord
+	scanner := bufio.NewScanner(logStream)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogLineSize)
+	for scanner.Scan() {
+		record, ok := parseLogLine(scanner.Text())
+		if !ok || record.Timestamp < startTime {
+			continue
+		}
+		records = append(records, record)
+		if limit > 0 && int64(len(records)) >= limit {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read logs of pod %q: %w", pod.Name, err)
+	}
+
+	return records, nil
+}
+
+// parseLogLine splits a line returned with PodLogOptions.Timestamps into its RFC3339 timestamp and message
+func parseLogLine(line string) (LogRecord, bool) {
+	timestamp, message, _ := strings.Cut(line, " ")
+	parsedTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return LogRecord{}, false
+	}
+
+	return LogRecord{
+		Message:   message,
+		Timestamp: parsedTime.UnixNano(),
+	}, true
+}
+
 func extractProcessContainer(containers []corev1.Container) (*corev1.Container, error) {
 	for i, c := range containers {
 		if c.Name == workloadsContainerName {
@@ -217,14 +398,14 @@ func getPodState(pod corev1.Pod) string {
 
 	if podPending(&pod) {
 		if containersHaveBrokenImage(pod.Status.ContainerStatuses) {
-			return crashedState
+			return CrashedState
 		}
 
 		return pendingState
 	}
 
 	if podFailed(&pod) {
-		return crashedState
+		return CrashedState
 	}
 
 	if podRunning(&pod) {
@@ -238,7 +419,7 @@ func getPodState(pod corev1.Pod) string {
 	}
 
 	if containersFailed(pod.Status.ContainerStatuses) {
-		return crashedState
+		return CrashedState
 	}
 
 	return unknownState
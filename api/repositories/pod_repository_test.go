@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -308,6 +309,33 @@ var _ = Describe("PodRepository", func() {
 				})
 			})
 
+			When("A pod is crash looping", func() {
+				BeforeEach(func() {
+					pod2.Status = corev1.PodStatus{
+						Phase: corev1.PodRunning,
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+								},
+								RestartCount: 3,
+							},
+						},
+					}
+					Expect(k8sClient.Status().Update(ctx, pod2)).To(Succeed())
+				})
+
+				It("reports the instance as crashed", func() {
+					Expect(listStatsErr).NotTo(HaveOccurred())
+					Expect(records).To(MatchElementsWithIndex(matchElementsWithIndexIDFn, IgnoreExtras, Elements{
+						"1": MatchFields(IgnoreExtras, Fields{
+							"Index": Equal(1),
+							"State": Equal("CRASHED"),
+						}),
+					}))
+				})
+			})
+
 			When("MetricFetcherFunction return an metrics resource not found error", func() {
 				BeforeEach(func() {
 					metricFetcherFn.Returns(nil, errors.New("the server could not find the requested resource"))
@@ -357,6 +385,113 @@ var _ = Describe("PodRepository", func() {
 			})
 		})
 	})
+
+	Describe("DeletePod", func() {
+		var (
+			message   DeletePodMessage
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, createPodDef(pod1Name, spaceGUID, appGUID, processGUID, "0", "1"))).To(Succeed())
+			Expect(k8sClient.Create(ctx, createPodDef(pod2Name, spaceGUID, appGUID, processGUID, "1", "1"))).To(Succeed())
+			Expect(k8sClient.Create(ctx, createPodDef(podOtherVersionName, spaceGUID, appGUID, processGUID, "1", "2"))).To(Succeed())
+
+			message = DeletePodMessage{
+				SpaceGUID:     spaceGUID,
+				AppGUID:       appGUID,
+				AppRevision:   "1",
+				ProcessGUID:   processGUID,
+				InstanceIndex: 1,
+			}
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = podRepo.DeletePod(ctx, authInfo, message)
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, spaceGUID)
+			})
+
+			It("deletes only the pod of the instance with the given index for the app revision", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				podList := &corev1.PodList{}
+				Expect(k8sClient.List(ctx, podList, client.InNamespace(spaceGUID))).To(Succeed())
+
+				var remainingPods []string
+				for _, pod := range podList.Items {
+					if pod.DeletionTimestamp == nil {
+						remainingPods = append(remainingPods, pod.Name)
+					}
+				}
+				Expect(remainingPods).To(ConsistOf(pod1Name, podOtherVersionName))
+			})
+
+			When("there is no pod for the instance index", func() {
+				BeforeEach(func() {
+					message.InstanceIndex = 5
+				})
+
+				It("returns a not found error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user can only read the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, spaceGUID)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetRuntimeLogsForApp", func() {
+		var (
+			logs       []LogRecord
+			getLogsErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, createPodDef(pod1Name, spaceGUID, appGUID, processGUID, "0", "1"))).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			logs, getLogsErr = podRepo.GetRuntimeLogsForApp(ctx, authInfo, RuntimeLogsMessage{
+				SpaceGUID: spaceGUID,
+				AppGUID:   appGUID,
+			})
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, spaceGUID)
+			})
+
+			It("returns no logs for pods that have not been scheduled yet", func() {
+				Expect(getLogsErr).NotTo(HaveOccurred())
+				Expect(logs).To(BeEmpty())
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(getLogsErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
 })
 
 func createPodDef(name, namespace, appGUID, processGUID, index, version string) *corev1.Pod {
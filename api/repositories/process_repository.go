@@ -102,8 +102,9 @@ type PatchProcessMessage struct {
 }
 
 type ListProcessesMessage struct {
-	AppGUIDs  []string
-	SpaceGUID string
+	AppGUIDs      []string
+	SpaceGUID     string
+	LabelSelector string
 }
 
 func (r *ProcessRepo) GetProcess(ctx context.Context, authInfo authorization.Info, processGUID string) (ProcessRecord, error) {
@@ -137,23 +138,50 @@ func (r *ProcessRepo) ListProcesses(ctx context.Context, authInfo authorization.
 		return []ProcessRecord{}, fmt.Errorf("get-process: failed to build user k8s client: %w", err)
 	}
 
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []ProcessRecord{}, err
+	}
+
 	processList := &workloadsv1alpha1.CFProcessList{}
 	var matches []workloadsv1alpha1.CFProcess
 	for ns := range nsList {
 		if message.SpaceGUID != "" && message.SpaceGUID != ns {
 			continue
 		}
-		err = userClient.List(ctx, processList, client.InNamespace(ns))
+		err = listInChunks(ctx, userClient, processList, func() {
+			matches = append(matches, filterProcessesByAppGUID(processList.Items, message.AppGUIDs)...)
+		}, client.InNamespace(ns), labelSelector)
 		if err != nil {
 			return []ProcessRecord{}, apierrors.FromK8sError(err, ProcessResourceType)
 		}
-		allProcesses := processList.Items
-		matches = append(matches, filterProcessesByAppGUID(allProcesses, message.AppGUIDs)...)
 	}
 
 	return returnProcesses(matches)
 }
 
+// ListAppProcesses lists one page of the processes of an app, along with the number of processes the app has
+func (r *ProcessRepo) ListAppProcesses(ctx context.Context, authInfo authorization.Info, spaceGUID, appGUID string, page Page) ([]ProcessRecord, int, error) {
+	userClient, err := r.clientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	// the page is converted as soon as it is read, as listing the rest of the processes reuses the list
+	processList := &workloadsv1alpha1.CFProcessList{}
+	records := []ProcessRecord{}
+	total, err := listPage(ctx, userClient, processList, page, func() {
+		for _, cfProcess := range processList.Items {
+			records = append(records, cfProcessToProcessRecord(cfProcess))
+		}
+	}, client.InNamespace(spaceGUID), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list processes of app %q: %w", appGUID, apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	return records, total, nil
+}
+
 func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.Info, scaleProcessMessage ScaleProcessMessage) (ProcessRecord, error) {
 	baseCFProcess := &workloadsv1alpha1.CFProcess{
 		ObjectMeta: metav1.ObjectMeta{
@@ -179,6 +207,9 @@ func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.I
 
 	err = userClient.Patch(ctx, cfProcess, client.MergeFrom(baseCFProcess))
 	if err != nil {
+		if quotaErr, ok := asQuotaExceededError(err); ok {
+			return ProcessRecord{}, quotaErr
+		}
 		return ProcessRecord{}, fmt.Errorf("failed to scale process %q: %w", scaleProcessMessage.GUID, apierrors.FromK8sError(err, ProcessResourceType))
 	}
 
@@ -211,6 +242,9 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 			Ports:            []int32{},
 		},
 	})
+	if quotaErr, ok := asQuotaExceededError(err); ok {
+		return quotaErr
+	}
 	return apierrors.FromK8sError(err, ProcessResourceType)
 }
 
@@ -278,6 +312,9 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 
 	err = userClient.Patch(ctx, updatedProcess, client.MergeFrom(baseProcess))
 	if err != nil {
+		if quotaErr, ok := asQuotaExceededError(err); ok {
+			return ProcessRecord{}, quotaErr
+		}
 		return ProcessRecord{}, apierrors.FromK8sError(err, ProcessResourceType)
 	}
 
@@ -343,8 +380,8 @@ func cfProcessToProcessRecord(cfProcess workloadsv1alpha1.CFProcess) ProcessReco
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
 			},
 		},
-		Labels:      map[string]string{},
-		Annotations: map[string]string{},
+		Labels:      cfMetadata(cfProcess.Labels),
+		Annotations: cfMetadata(cfProcess.Annotations),
 		CreatedAt:   cfProcess.CreationTimestamp.UTC().Format(TimestampFormat),
 		UpdatedAt:   updatedAtTime,
 	}
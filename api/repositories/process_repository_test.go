@@ -205,6 +205,75 @@ var _ = Describe("ProcessRepo", func() {
 		})
 	})
 
+	Describe("ListAppProcesses", func() {
+		var (
+			process2GUID string
+			process3GUID string
+			page         repositories.Page
+			processes    []repositories.ProcessRecord
+			total        int
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			process2GUID = prefixedGUID("process2")
+			process3GUID = prefixedGUID("process3")
+
+			_ = createProcessCR(ctx, k8sClient, process1GUID, space.Name, app1GUID)
+			_ = createProcessCR(ctx, k8sClient, process2GUID, space.Name, app1GUID)
+			_ = createProcessCR(ctx, k8sClient, process3GUID, space.Name, prefixedGUID("app2"))
+
+			page = repositories.Page{Number: 1, PerPage: 50}
+		})
+
+		JustBeforeEach(func() {
+			processes, total, listErr = processRepo.ListAppProcesses(ctx, authInfo, space.Name, app1GUID, page)
+		})
+
+		It("returns a forbidden error to unauthorized users", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the processes of the app", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(total).To(Equal(2))
+				Expect(processes).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(process1GUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(process2GUID)}),
+				))
+			})
+
+			When("the page holds a single process", func() {
+				BeforeEach(func() {
+					page = repositories.Page{Number: 2, PerPage: 1}
+				})
+
+				It("returns that page and the number of processes of the app", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(total).To(Equal(2))
+					Expect(processes).To(HaveLen(1))
+				})
+			})
+
+			When("the page is past the last process", func() {
+				BeforeEach(func() {
+					page = repositories.Page{Number: 3, PerPage: 1}
+				})
+
+				It("returns no processes", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(total).To(Equal(2))
+					Expect(processes).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("ScaleProcess", func() {
 		var (
 			space1              *hnsv1alpha2.SubnamespaceAnchor
@@ -1,11 +1,15 @@
 package registry
 
 import (
+	"archive/zip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
+	"strings"
 
 	"github.com/buildpacks/pack/pkg/archive"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -14,20 +18,63 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 )
 
-type ImageBuilder struct{}
+// Resource is a file of the package that is taken from the ResourceCache of a space instead of the uploaded source
+type Resource struct {
+	SpaceGUID string
+	SHA1      string
+	Path      string
+	Mode      os.FileMode
+}
 
-func NewImageBuilder() *ImageBuilder {
-	return &ImageBuilder{}
+// ResourceNotCachedError is returned when a resource of the package is not in the ResourceCache, e.g. because it was
+// evicted after it was matched
+type ResourceNotCachedError struct {
+	Path string
+	SHA1 string
 }
 
-func (r *ImageBuilder) Build(ctx context.Context, srcReader io.Reader) (v1.Image, error) {
-	tempFile, err := copyIntoTempFile(srcReader)
-	if err != nil {
-		return nil, err
+func (e ResourceNotCachedError) Error() string {
+	return fmt.Sprintf("resource %q with checksum %s is not cached", e.Path, e.SHA1)
+}
+
+type ImageBuilder struct {
+	resourceCache *ResourceCache
+}
+
+func NewImageBuilder(resourceCache *ResourceCache) *ImageBuilder {
+	return &ImageBuilder{
+		resourceCache: resourceCache,
 	}
-	defer os.Remove(tempFile)
+}
+
+// Build creates an image with a single layer holding the files of the uploaded source zip and the cached resources.
+// The files of the source are cached for the space. The srcReader can be nil when all the files of the package are
+// cached resources.
+func (r *ImageBuilder) Build(ctx context.Context, spaceGUID string, srcReader io.Reader, resources []Resource) (v1.Image, error) {
+	srcFile := ""
+	if srcReader != nil {
+		tempFile, err := copyIntoTempFile(srcReader)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tempFile)
 
-	return createImage(tempFile)
+		if err = r.cacheResources(spaceGUID, tempFile); err != nil {
+			return nil, err
+		}
+		srcFile = tempFile
+	}
+
+	if len(resources) > 0 || srcFile == "" {
+		fullSrcFile, err := r.addCachedResources(srcFile, resources)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(fullSrcFile)
+		srcFile = fullSrcFile
+	}
+
+	return createImage(srcFile)
 }
 
 func copyIntoTempFile(srcReader io.Reader) (string, error) {
@@ -43,6 +90,121 @@ func copyIntoTempFile(srcReader io.Reader) (string, error) {
 	return tmpFile.Name(), nil
 }
 
+// cacheResources adds the files of the source zip to the resource cache of the space, so that later uploads can skip them
+func (r *ImageBuilder) cacheResources(spaceGUID, srcFile string) error {
+	zipReader, err := zip.OpenReader(srcFile)
+	if err != nil {
+		return fmt.Errorf("failed to open source file '%s': not a valid zip file: %w", srcFile, err)
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() || file.UncompressedSize64 < MinCachedResourceSize {
+			continue
+		}
+
+		if err = r.cacheResource(spaceGUID, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ImageBuilder) cacheResource(spaceGUID string, file *zip.File) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open '%s' in source file: %w", file.Name, err)
+	}
+	defer content.Close()
+
+	if _, err = r.resourceCache.Add(spaceGUID, content); err != nil {
+		return fmt.Errorf("failed to cache '%s': %w", file.Name, err)
+	}
+
+	return nil
+}
+
+// addCachedResources writes a new zip with the files of the source zip, if any, and the cached resources
+func (r *ImageBuilder) addCachedResources(srcFile string, resources []Resource) (string, error) {
+	fullSrcFile, err := ioutil.TempFile(os.TempDir(), "sourceimg-%s")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for image: %w", err)
+	}
+	defer fullSrcFile.Close()
+
+	zipWriter := zip.NewWriter(fullSrcFile)
+
+	if err = r.writeCachedResources(zipWriter, srcFile, resources); err != nil {
+		os.Remove(fullSrcFile.Name())
+		return "", err
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		os.Remove(fullSrcFile.Name())
+		return "", fmt.Errorf("failed to write zip file '%s': %w", fullSrcFile.Name(), err)
+	}
+
+	return fullSrcFile.Name(), nil
+}
+
+func (r *ImageBuilder) writeCachedResources(zipWriter *zip.Writer, srcFile string, resources []Resource) error {
+	if srcFile != "" {
+		zipReader, err := zip.OpenReader(srcFile)
+		if err != nil {
+			return fmt.Errorf("failed to open source file '%s': not a valid zip file: %w", srcFile, err)
+		}
+		defer zipReader.Close()
+
+		for _, file := range zipReader.File {
+			if err = zipWriter.Copy(file); err != nil {
+				return fmt.Errorf("failed to copy '%s' from source file: %w", file.Name, err)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		if err := r.writeCachedResource(zipWriter, resource); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ImageBuilder) writeCachedResource(zipWriter *zip.Writer, resource Resource) error {
+	resourcePath := path.Clean(resource.Path)
+	if path.IsAbs(resourcePath) || resourcePath == ".." || strings.HasPrefix(resourcePath, "../") {
+		return fmt.Errorf("invalid resource path %q", resource.Path)
+	}
+
+	content, err := r.resourceCache.Open(resource.SpaceGUID, resource.SHA1)
+	if errors.Is(err, os.ErrNotExist) {
+		return ResourceNotCachedError{Path: resource.Path, SHA1: resource.SHA1}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open cached resource %s: %w", resource.SHA1, err)
+	}
+	defer content.Close()
+
+	header := &zip.FileHeader{
+		Name:   resourcePath,
+		Method: zip.Deflate,
+	}
+	header.SetMode(resource.Mode)
+
+	entry, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add '%s' to zip file: %w", resourcePath, err)
+	}
+
+	if _, err = io.Copy(entry, content); err != nil {
+		return fmt.Errorf("failed to copy cached resource %s to '%s': %w", resource.SHA1, resourcePath, err)
+	}
+
+	return nil
+}
+
 func createImage(srcFile string) (v1.Image, error) {
 	image, err := random.Image(0, 0)
 	if err != nil {
@@ -2,10 +2,15 @@ package registry_test
 
 import (
 	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"errors"
 	"io"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/repositories/registry"
 	"code.cloudfoundry.org/korifi/api/repositories/registry/fake"
@@ -20,6 +25,9 @@ var _ = Describe("ImageBuilder", func() {
 	var (
 		imageBuilder     *registry.ImageBuilder
 		imageLayerReader *fake.Reader
+		srcReader        io.Reader
+		resourceCache    *registry.ResourceCache
+		resources        []registry.Resource
 
 		builtImage v1.Image
 		buildErr   error
@@ -35,11 +43,16 @@ var _ = Describe("ImageBuilder", func() {
 			n := copy(b, bs)
 			return n, io.EOF
 		}
-		imageBuilder = registry.NewImageBuilder()
+		var err error
+		resourceCache, err = registry.NewResourceCache(GinkgoT().TempDir(), 10*registry.MinCachedResourceSize)
+		Expect(err).NotTo(HaveOccurred())
+		srcReader = imageLayerReader
+		resources = nil
+		imageBuilder = registry.NewImageBuilder(resourceCache)
 	})
 
 	JustBeforeEach(func() {
-		builtImage, buildErr = imageBuilder.Build(context.Background(), imageLayerReader)
+		builtImage, buildErr = imageBuilder.Build(context.Background(), "space-guid", srcReader, resources)
 	})
 
 	It("builds an an image with a layer containing the source file", func() {
@@ -55,6 +68,70 @@ var _ = Describe("ImageBuilder", func() {
 		Expect(header.FileInfo().Name()).To(Equal("foo"))
 	})
 
+	When("the source has files that are big enough to cache", func() {
+		var bigContent []byte
+
+		BeforeEach(func() {
+			bigContent = bytes.Repeat([]byte("a"), registry.MinCachedResourceSize)
+			imageLayerReader.ReadStub = bytes.NewReader(makeZip(map[string][]byte{
+				"small": []byte("small"),
+				"big":   bigContent,
+			})).Read
+		})
+
+		It("adds them to the resource cache", func() {
+			Expect(buildErr).NotTo(HaveOccurred())
+			Expect(resourceCache.Contains("space-guid", sha1Of(bigContent), int64(len(bigContent)))).To(BeTrue())
+			Expect(resourceCache.Contains("space-guid", sha1Of([]byte("small")), 5)).To(BeFalse())
+		})
+	})
+
+	When("the package includes cached resources", func() {
+		BeforeEach(func() {
+			cachedSHA1, err := resourceCache.Add("other-space-guid", strings.NewReader("cached-content"))
+			Expect(err).NotTo(HaveOccurred())
+			resources = []registry.Resource{{SpaceGUID: "other-space-guid", SHA1: cachedSHA1, Path: "lib/cached", Mode: 0o755}}
+		})
+
+		It("builds a layer with both the source and the cached files", func() {
+			Expect(buildErr).NotTo(HaveOccurred())
+			files := getLayerFiles(builtImage)
+			Expect(files).To(HaveKeyWithValue("foo", Not(BeEmpty())))
+			Expect(files).To(HaveKeyWithValue("lib/cached", "cached-content"))
+		})
+
+		When("there is no source", func() {
+			BeforeEach(func() {
+				srcReader = nil
+			})
+
+			It("builds a layer with the cached files only", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(getLayerFiles(builtImage)).To(Equal(map[string]string{"lib/cached": "cached-content"}))
+			})
+		})
+
+		When("a resource path escapes the package", func() {
+			BeforeEach(func() {
+				resources[0].Path = "../../etc/passwd"
+			})
+
+			It("returns an error", func() {
+				Expect(buildErr).To(MatchError(ContainSubstring("invalid resource path")))
+			})
+		})
+
+		When("a resource is not in the cache", func() {
+			BeforeEach(func() {
+				resources[0].SHA1 = sha1Of([]byte("not-cached"))
+			})
+
+			It("returns a not cached error naming the resource", func() {
+				Expect(buildErr).To(MatchError(registry.ResourceNotCachedError{Path: "lib/cached", SHA1: sha1Of([]byte("not-cached"))}))
+			})
+		})
+	})
+
 	When("reading from the source reader fails", func() {
 		BeforeEach(func() {
 			imageLayerReader.ReadReturns(0, errors.New("error-from-reader"))
@@ -84,3 +161,46 @@ func getImageLayers(image v1.Image) []v1.Layer {
 	Expect(err).NotTo(HaveOccurred())
 	return imgLayers
 }
+
+func getLayerFiles(image v1.Image) map[string]string {
+	imgLayers := getImageLayers(image)
+	Expect(imgLayers).To(HaveLen(1))
+
+	layerContent, err := imgLayers[0].Uncompressed()
+	Expect(err).NotTo(HaveOccurred())
+
+	files := map[string]string{}
+	layerReader := tar.NewReader(layerContent)
+	for {
+		header, err := layerReader.Next()
+		if err == io.EOF {
+			return files
+		}
+		Expect(err).NotTo(HaveOccurred())
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(layerReader)
+		Expect(err).NotTo(HaveOccurred())
+		files[strings.TrimPrefix(header.Name, "/")] = string(content)
+	}
+}
+
+func makeZip(files map[string][]byte) []byte {
+	var b bytes.Buffer
+	zipWriter := zip.NewWriter(&b)
+	for name, content := range files {
+		entry, err := zipWriter.Create(name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = entry.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(zipWriter.Close()).To(Succeed())
+	return b.Bytes()
+}
+
+func sha1Of(content []byte) string {
+	sum := sha1.Sum(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,231 @@
+package registry
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MinCachedResourceSize is the size of the smallest file kept in the ResourceCache. Like in the Cloud Controller,
+// smaller files are cheaper to upload again than to match.
+const MinCachedResourceSize = 64 * 1024
+
+const tempResourcePrefix = "resource-"
+
+// ResourceCache is a content-addressed store of the files of uploaded packages, keyed by the space they were uploaded
+// to and their SHA1. It allows clients to skip uploading the files that were part of an earlier package, see
+// https://v3-apidocs.cloudfoundry.org/version/3.110.0/index.html#resource-matches
+//
+// The cache holds at most maxSize bytes. Once it grows over that, the least recently used resources are evicted.
+type ResourceCache struct {
+	dir     string
+	maxSize int64
+
+	mutex   sync.Mutex
+	size    int64
+	entries map[string]*list.Element
+	// recency holds the cachedResources from the most to the least recently used
+	recency *list.List
+}
+
+type cachedResource struct {
+	key  string
+	size int64
+}
+
+func NewResourceCache(dir string, maxSize int64) (*ResourceCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create resource cache dir %q: %w", dir, err)
+	}
+
+	c := &ResourceCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		recency: list.New(),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *ResourceCache) Contains(spaceGUID, sha1 string, size int64) bool {
+	key, ok := resourceKey(spaceGUID, sha1)
+	if !ok {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists || element.Value.(cachedResource).size != size {
+		return false
+	}
+	c.recency.MoveToFront(element)
+
+	return true
+}
+
+// Open returns the content of a cached resource. The returned error wraps os.ErrNotExist if the resource is not cached.
+func (c *ResourceCache) Open(spaceGUID, sha1 string) (*os.File, error) {
+	key, ok := resourceKey(spaceGUID, sha1)
+	if !ok {
+		return nil, fmt.Errorf("invalid resource SHA1 %q in space %q", sha1, spaceGUID)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.recency.MoveToFront(element)
+	}
+
+	// the file is opened under the lock, so that it cannot be evicted in between. Once open, it stays readable even
+	// if it gets evicted.
+	return os.Open(filepath.Join(c.dir, key))
+}
+
+// Add stores the content read from src in the cache of the space and returns its SHA1
+func (c *ResourceCache) Add(spaceGUID string, src io.Reader) (string, error) {
+	if !isSpaceGUID(spaceGUID) {
+		return "", fmt.Errorf("invalid space %q", spaceGUID)
+	}
+
+	tmpFile, err := os.CreateTemp(c.dir, tempResourcePrefix+"*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for resource: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	hash := sha1.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), src)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy resource into temp file '%s': %w", tmpFile.Name(), err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file '%s': %w", tmpFile.Name(), err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	key := spaceGUID + "/" + sum
+	if err = os.MkdirAll(filepath.Join(c.dir, spaceGUID), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create resource cache dir for space %q: %w", spaceGUID, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// the rename is atomic, so concurrent uploads of the same file never expose a partially written resource
+	if err = os.Rename(tmpFile.Name(), filepath.Join(c.dir, key)); err != nil {
+		return "", fmt.Errorf("failed to store resource %s: %w", sum, err)
+	}
+	c.track(key, size)
+	c.evict()
+
+	return sum, nil
+}
+
+// load tracks the resources left in the cache dir by an earlier run, from the least to the most recently modified
+func (c *ResourceCache) load() error {
+	type storedResource struct {
+		key  string
+		info os.FileInfo
+	}
+	var resources []storedResource
+
+	spaceDirs, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read resource cache dir %q: %w", c.dir, err)
+	}
+
+	for _, spaceDir := range spaceDirs {
+		if !spaceDir.IsDir() {
+			// leftover temp files of interrupted uploads
+			if strings.HasPrefix(spaceDir.Name(), tempResourcePrefix) {
+				os.Remove(filepath.Join(c.dir, spaceDir.Name()))
+			}
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(c.dir, spaceDir.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read resource cache dir of space %q: %w", spaceDir.Name(), err)
+		}
+
+		for _, file := range files {
+			key, ok := resourceKey(spaceDir.Name(), file.Name())
+			if !ok || !file.Type().IsRegular() {
+				continue
+			}
+			info, err := file.Info()
+			if err != nil {
+				return fmt.Errorf("failed to stat cached resource %q: %w", key, err)
+			}
+			resources = append(resources, storedResource{key: key, info: info})
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].info.ModTime().Before(resources[j].info.ModTime())
+	})
+	for _, resource := range resources {
+		c.track(resource.key, resource.info.Size())
+	}
+	c.evict()
+
+	return nil
+}
+
+// track marks the resource as the most recently used one. It expects the lock to be held.
+func (c *ResourceCache) track(key string, size int64) {
+	if element, exists := c.entries[key]; exists {
+		c.size -= element.Value.(cachedResource).size
+		c.recency.Remove(element)
+	}
+
+	c.entries[key] = c.recency.PushFront(cachedResource{key: key, size: size})
+	c.size += size
+}
+
+// evict removes the least recently used resources until the cache fits in its max size. It expects the lock to be held.
+func (c *ResourceCache) evict() {
+	for c.size > c.maxSize && c.recency.Len() > 0 {
+		resource := c.recency.Remove(c.recency.Back()).(cachedResource)
+		delete(c.entries, resource.key)
+		c.size -= resource.size
+		// a file that cannot be removed is no longer tracked either, so it is never matched again
+		_ = os.Remove(filepath.Join(c.dir, resource.key))
+	}
+}
+
+func resourceKey(spaceGUID, sha1 string) (string, bool) {
+	if !isSpaceGUID(spaceGUID) || !isSHA1(sha1) {
+		return "", false
+	}
+
+	return spaceGUID + "/" + sha1, true
+}
+
+// isSpaceGUID checks that the space GUID is a namespace name, so that it is safe to use as a dir name
+func isSpaceGUID(s string) bool {
+	return len(validation.IsDNS1123Label(s)) == 0
+}
+
+func isSHA1(s string) bool {
+	decoded, err := hex.DecodeString(s)
+	// only lower case hex digits are accepted, as that is how the resources are named in the cache dir
+	return err == nil && len(decoded) == sha1.Size && hex.EncodeToString(decoded) == s
+}
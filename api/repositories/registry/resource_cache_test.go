@@ -0,0 +1,126 @@
+package registry_test
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCache", func() {
+	var (
+		cacheDir      string
+		maxSize       int64
+		resourceCache *registry.ResourceCache
+	)
+
+	BeforeEach(func() {
+		cacheDir = GinkgoT().TempDir()
+		maxSize = 1024
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		resourceCache, err = registry.NewResourceCache(cacheDir, maxSize)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("stores resources by their SHA1", func() {
+		sha1, err := resourceCache.Add("space-guid", strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sha1).To(Equal("aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"))
+
+		Expect(resourceCache.Contains("space-guid", sha1, 5)).To(BeTrue())
+
+		content, err := resourceCache.Open("space-guid", sha1)
+		Expect(err).NotTo(HaveOccurred())
+		defer content.Close()
+		Expect(io.ReadAll(content)).To(BeEquivalentTo("hello"))
+	})
+
+	It("does not contain resources with a different size", func() {
+		sha1, err := resourceCache.Add("space-guid", strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceCache.Contains("space-guid", sha1, 6)).To(BeFalse())
+	})
+
+	It("does not contain resources that were never added", func() {
+		Expect(resourceCache.Contains("space-guid", "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d", 5)).To(BeFalse())
+		_, err := resourceCache.Open("space-guid", "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d")
+		Expect(err).To(MatchError(os.ErrNotExist))
+	})
+
+	It("does not share resources between spaces", func() {
+		sha1, err := resourceCache.Add("space-guid", strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resourceCache.Contains("other-space-guid", sha1, 5)).To(BeFalse())
+	})
+
+	It("rejects checksums that are not SHA1s and spaces that are not namespace names", func() {
+		Expect(resourceCache.Contains("space-guid", "../../etc/passwd", 5)).To(BeFalse())
+		_, err := resourceCache.Open("space-guid", "../../etc/passwd")
+		Expect(err).To(MatchError(ContainSubstring("invalid resource SHA1")))
+
+		_, err = resourceCache.Add("../space-guid", strings.NewReader("hello"))
+		Expect(err).To(MatchError(ContainSubstring("invalid space")))
+	})
+
+	When("the cache grows over its max size", func() {
+		var firstSHA1, secondSHA1, thirdSHA1 string
+
+		BeforeEach(func() {
+			maxSize = 10
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			firstSHA1, err = resourceCache.Add("space-guid", strings.NewReader("first"))
+			Expect(err).NotTo(HaveOccurred())
+			secondSHA1, err = resourceCache.Add("space-guid", strings.NewReader("secnd"))
+			Expect(err).NotTo(HaveOccurred())
+
+			// using the first resource makes the second one the least recently used
+			Expect(resourceCache.Contains("space-guid", firstSHA1, 5)).To(BeTrue())
+
+			thirdSHA1, err = resourceCache.Add("space-guid", strings.NewReader("third"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("evicts the least recently used resources", func() {
+			Expect(resourceCache.Contains("space-guid", firstSHA1, 5)).To(BeTrue())
+			Expect(resourceCache.Contains("space-guid", secondSHA1, 5)).To(BeFalse())
+			Expect(resourceCache.Contains("space-guid", thirdSHA1, 5)).To(BeTrue())
+
+			_, err := resourceCache.Open("space-guid", secondSHA1)
+			Expect(err).To(MatchError(os.ErrNotExist))
+		})
+	})
+
+	When("the cache dir holds resources of an earlier run", func() {
+		var sha1 string
+
+		BeforeEach(func() {
+			earlierCache, err := registry.NewResourceCache(cacheDir, maxSize)
+			Expect(err).NotTo(HaveOccurred())
+			sha1, err = earlierCache.Add("space-guid", strings.NewReader("hello"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps them", func() {
+			Expect(resourceCache.Contains("space-guid", sha1, 5)).To(BeTrue())
+		})
+
+		When("they do not fit in the max size", func() {
+			BeforeEach(func() {
+				maxSize = 4
+			})
+
+			It("evicts them", func() {
+				Expect(resourceCache.Contains("space-guid", sha1, 5)).To(BeFalse())
+			})
+		})
+	})
+})
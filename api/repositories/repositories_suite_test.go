@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 
@@ -130,7 +131,7 @@ var _ = BeforeEach(func() {
 	tokenInspector := authorization.NewTokenReviewer(k8sClient)
 	certInspector := authorization.NewCertInspector(k8sConfig)
 	baseIDProvider := authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
-	idProvider = authorization.NewCachingIdentityProvider(baseIDProvider, cache.NewExpiring())
+	idProvider = authorization.NewCachingIdentityProvider(baseIDProvider, cache.NewLRUExpireCache(100), time.Minute, time.Second)
 	nsPerms = authorization.NewNamespacePermissions(k8sClient, idProvider, rootNamespace)
 
 	mapper, err := apiutil.NewDynamicRESTMapper(k8sConfig)
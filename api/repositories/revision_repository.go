@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapprevisions,verbs=get;list
+
+const RevisionResourceType = "Revision"
+
+type RevisionRepo struct {
+	namespaceRetriever NamespaceRetriever
+	userClientFactory  UserK8sClientFactory
+}
+
+func NewRevisionRepo(namespaceRetriever NamespaceRetriever, userClientFactory UserK8sClientFactory) *RevisionRepo {
+	return &RevisionRepo{
+		namespaceRetriever: namespaceRetriever,
+		userClientFactory:  userClientFactory,
+	}
+}
+
+type RevisionRecord struct {
+	GUID            string
+	Version         int64
+	Description     string
+	AppGUID         string
+	SpaceGUID       string
+	DropletGUID     string
+	ProcessCommands map[string]string
+	Deployable      bool
+	Labels          map[string]string
+	Annotations     map[string]string
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+type ListRevisionsMessage struct {
+	AppGUID   string
+	SpaceGUID string
+	Versions  []string
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, revisionGUID, RevisionResourceType)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfAppRevision := new(workloadsv1alpha1.CFAppRevision)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: revisionGUID}, cfAppRevision)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to get revision %q: %w", revisionGUID, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	stagedDroplets, err := r.stagedDroplets(ctx, userClient, ns)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return cfAppRevisionToRevisionRecord(*cfAppRevision, stagedDroplets), nil
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	revisionList := new(workloadsv1alpha1.CFAppRevisionList)
+	err = userClient.List(ctx, revisionList, client.InNamespace(message.SpaceGUID), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: message.AppGUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions of app %q: %w", message.AppGUID, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	stagedDroplets, err := r.stagedDroplets(ctx, userClient, message.SpaceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []RevisionRecord{}
+	for _, cfAppRevision := range revisionList.Items {
+		if matchesFilter(strconv.FormatInt(cfAppRevision.Spec.Version, 10), message.Versions) {
+			records = append(records, cfAppRevisionToRevisionRecord(cfAppRevision, stagedDroplets))
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
+
+	return records, nil
+}
+
+// ListDeployedRevisions returns the revision the app is currently running. Stopped apps have no deployed revisions.
+func (r *RevisionRepo) ListDeployedRevisions(ctx context.Context, authInfo authorization.Info, spaceGUID, appGUID string) ([]RevisionRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := new(workloadsv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: appGUID}, cfApp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app %q: %w", appGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	if cfApp.Spec.DesiredState != workloadsv1alpha1.StartedState || cfApp.Status.CurrentRevisionRef.Name == "" {
+		return []RevisionRecord{}, nil
+	}
+
+	cfAppRevision := new(workloadsv1alpha1.CFAppRevision)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: cfApp.Status.CurrentRevisionRef.Name}, cfAppRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %q: %w", cfApp.Status.CurrentRevisionRef.Name, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	stagedDroplets, err := r.stagedDroplets(ctx, userClient, spaceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []RevisionRecord{cfAppRevisionToRevisionRecord(*cfAppRevision, stagedDroplets)}, nil
+}
+
+// stagedDroplets returns the names of the droplets in the namespace that can be deployed. Users who cannot list
+// droplets, such as space auditors, see every revision as not deployable.
+func (r *RevisionRepo) stagedDroplets(ctx context.Context, userClient client.Client, namespace string) (map[string]bool, error) {
+	buildList := new(workloadsv1alpha1.CFBuildList)
+	err := userClient.List(ctx, buildList, client.InNamespace(namespace))
+	if k8serrors.IsForbidden(err) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets in namespace %q: %w", namespace, apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	stagedDroplets := map[string]bool{}
+	for _, cfBuild := range buildList.Items {
+		if cfBuild.Status.BuildDropletStatus != nil {
+			stagedDroplets[cfBuild.Name] = true
+		}
+	}
+
+	return stagedDroplets, nil
+}
+
+func cfAppRevisionToRevisionRecord(cfAppRevision workloadsv1alpha1.CFAppRevision, stagedDroplets map[string]bool) RevisionRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfAppRevision.ObjectMeta)
+
+	processCommands := make(map[string]string, len(cfAppRevision.Spec.Processes))
+	for _, process := range cfAppRevision.Spec.Processes {
+		processCommands[process.Type] = process.Command
+	}
+
+	return RevisionRecord{
+		GUID:            cfAppRevision.Name,
+		Version:         cfAppRevision.Spec.Version,
+		Description:     cfAppRevision.Spec.Description,
+		AppGUID:         cfAppRevision.Spec.AppRef.Name,
+		SpaceGUID:       cfAppRevision.Namespace,
+		DropletGUID:     cfAppRevision.Spec.DropletRef.Name,
+		ProcessCommands: processCommands,
+		Deployable:      stagedDroplets[cfAppRevision.Spec.DropletRef.Name],
+		Labels:          cfMetadata(cfAppRevision.Labels),
+		Annotations:     cfMetadata(cfAppRevision.Annotations),
+		CreatedAt:       formatTimestamp(cfAppRevision.CreationTimestamp),
+		UpdatedAt:       updatedAtTime,
+	}
+}
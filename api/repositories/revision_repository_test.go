@@ -0,0 +1,155 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		ctx          context.Context
+		revisionRepo *repositories.RevisionRepo
+		org          *hnsv1alpha2.SubnamespaceAnchor
+		space        *hnsv1alpha2.SubnamespaceAnchor
+		appGUID      string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		revisionRepo = repositories.NewRevisionRepo(namespaceRetriever, userClientFactory)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		appGUID = prefixedGUID("app")
+	})
+
+	createRevision := func(version int64, dropletGUID string) *workloadsv1alpha1.CFAppRevision {
+		cfAppRevision := &workloadsv1alpha1.CFAppRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("revision"),
+				Namespace: space.Name,
+				Labels:    map[string]string{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID},
+			},
+			Spec: workloadsv1alpha1.CFAppRevisionSpec{
+				AppRef:        corev1.LocalObjectReference{Name: appGUID},
+				Version:       version,
+				Description:   "Initial revision.",
+				DropletRef:    corev1.LocalObjectReference{Name: dropletGUID},
+				EnvSecretHash: "env-hash",
+				Processes:     []workloadsv1alpha1.RevisionProcess{{Type: "web", Command: "bundle exec rackup"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfAppRevision)).To(Succeed())
+		return cfAppRevision
+	}
+
+	Describe("GetRevision", func() {
+		var (
+			cfAppRevision  *workloadsv1alpha1.CFAppRevision
+			revisionRecord repositories.RevisionRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			cfAppRevision = createRevision(1, "droplet-guid")
+		})
+
+		JustBeforeEach(func() {
+			revisionRecord, getErr = revisionRepo.GetRevision(ctx, authInfo, cfAppRevision.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(revisionRecord.GUID).To(Equal(cfAppRevision.Name))
+				Expect(revisionRecord.Version).To(BeEquivalentTo(1))
+				Expect(revisionRecord.AppGUID).To(Equal(appGUID))
+				Expect(revisionRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(revisionRecord.DropletGUID).To(Equal("droplet-guid"))
+				Expect(revisionRecord.Description).To(Equal("Initial revision."))
+				Expect(revisionRecord.ProcessCommands).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+			})
+
+			It("is not deployable without a staged droplet", func() {
+				Expect(revisionRecord.Deployable).To(BeFalse())
+			})
+		})
+
+		When("the revision does not exist", func() {
+			JustBeforeEach(func() {
+				_, getErr = revisionRepo.GetRevision(ctx, authInfo, "i-dont-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			revisionRecords []repositories.RevisionRecord
+			listErr         error
+			versions        []string
+		)
+
+		BeforeEach(func() {
+			createRevision(2, "droplet-2-guid")
+			createRevision(1, "droplet-1-guid")
+			versions = nil
+		})
+
+		JustBeforeEach(func() {
+			revisionRecords, listErr = revisionRepo.ListRevisions(ctx, authInfo, repositories.ListRevisionsMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+				Versions:  versions,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space auditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("returns the revisions of the app ordered by version", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(revisionRecords).To(HaveLen(2))
+				Expect(revisionRecords[0].Version).To(BeEquivalentTo(1))
+				Expect(revisionRecords[1].Version).To(BeEquivalentTo(2))
+			})
+
+			When("filtering by version", func() {
+				BeforeEach(func() {
+					versions = []string{"2"}
+				})
+
+				It("returns the matching revisions", func() {
+					Expect(revisionRecords).To(HaveLen(1))
+					Expect(revisionRecords[0].DropletGUID).To(Equal("droplet-2-guid"))
+				})
+			})
+		})
+	})
+})
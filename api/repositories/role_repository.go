@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -32,7 +34,7 @@ type AuthorizedInChecker interface {
 	AuthorizedIn(ctx context.Context, identity authorization.Identity, namespace string) (bool, error)
 }
 
-//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create;list
 
 type CreateRoleMessage struct {
 	GUID  string
@@ -43,6 +45,14 @@ type CreateRoleMessage struct {
 	Kind  string
 }
 
+type ListRolesMessage struct {
+	GUIDs      []string
+	Types      []string
+	SpaceGUIDs []string
+	OrgGUIDs   []string
+	UserGUIDs  []string
+}
+
 type RoleRecord struct {
 	GUID      string
 	CreatedAt time.Time
@@ -55,20 +65,29 @@ type RoleRecord struct {
 }
 
 type RoleRepo struct {
-	privilegedClient    client.Client
-	rootNamespace       string
-	roleMappings        map[string]config.Role
-	authorizedInChecker AuthorizedInChecker
-	userClientFactory   UserK8sClientFactory
+	privilegedClient     client.Client
+	rootNamespace        string
+	roleMappings         map[string]config.Role
+	authorizedInChecker  AuthorizedInChecker
+	namespacePermissions *authorization.NamespacePermissions
+	userClientFactory    UserK8sClientFactory
 }
 
-func NewRoleRepo(privilegedClient client.Client, userClientFactory UserK8sClientFactory, authorizedInChecker AuthorizedInChecker, rootNamespace string, roleMappings map[string]config.Role) *RoleRepo {
+func NewRoleRepo(
+	privilegedClient client.Client,
+	userClientFactory UserK8sClientFactory,
+	authorizedInChecker AuthorizedInChecker,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+	roleMappings map[string]config.Role,
+) *RoleRepo {
 	return &RoleRepo{
-		privilegedClient:    privilegedClient,
-		rootNamespace:       rootNamespace,
-		roleMappings:        roleMappings,
-		authorizedInChecker: authorizedInChecker,
-		userClientFactory:   userClientFactory,
+		privilegedClient:     privilegedClient,
+		rootNamespace:        rootNamespace,
+		roleMappings:         roleMappings,
+		authorizedInChecker:  authorizedInChecker,
+		namespacePermissions: namespacePermissions,
+		userClientFactory:    userClientFactory,
 	}
 }
 
@@ -117,6 +136,7 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 		}
 		return RoleRecord{}, fmt.Errorf("failed to assign user %q to role %q: %w", role.User, role.Type, apierrors.FromK8sError(err, RoleResourceType))
 	}
+	r.namespacePermissions.Invalidate()
 
 	cfUserk8sRoleConfig, ok := r.roleMappings[cfUserRoleType]
 	if !ok {
@@ -147,6 +167,181 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	return roleRecord, nil
 }
 
+// ListRoles lists the roles in the orgs and spaces the user can see. Roles are the RoleBindings labelled with a role
+// GUID, whose type is found from the ClusterRole they refer to. Copies of the bindings propagated to the spaces of an
+// org are not roles of their own.
+func (r *RoleRepo) ListRoles(ctx context.Context, authInfo authorization.Info, message ListRolesMessage) ([]RoleRecord, error) {
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return []RoleRecord{}, err
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return []RoleRecord{}, err
+	}
+
+	roles, err := r.listAllRoles(ctx)
+	if err != nil {
+		return []RoleRecord{}, err
+	}
+
+	records := []RoleRecord{}
+	for _, role := range roles {
+		if !authorizedOrgs[role.Org] && !authorizedSpaces[role.Space] {
+			continue
+		}
+
+		if matchesFilter(role.GUID, message.GUIDs) &&
+			matchesFilter(role.Type, message.Types) &&
+			matchesFilter(role.Space, message.SpaceGUIDs) &&
+			matchesFilter(role.Org, message.OrgGUIDs) &&
+			matchesFilter(role.User, message.UserGUIDs) {
+			records = append(records, role)
+		}
+	}
+
+	return records, nil
+}
+
+func (r *RoleRepo) GetRole(ctx context.Context, authInfo authorization.Info, guid string) (RoleRecord, error) {
+	roles, err := r.ListRoles(ctx, authInfo, ListRolesMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return RoleRecord{}, err
+	}
+
+	if len(roles) == 0 {
+		return RoleRecord{}, apierrors.NewNotFoundError(fmt.Errorf("role %q not found", guid), RoleResourceType)
+	}
+
+	return roles[0], nil
+}
+
+// DeleteRole deletes the RoleBinding of the role. An org role cannot be deleted while it is the last role of the user
+// in the org and the user still has roles in its spaces, as space roles require a role in the org.
+func (r *RoleRepo) DeleteRole(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	role, err := r.GetRole(ctx, authInfo, guid)
+	if err != nil {
+		return err
+	}
+
+	if role.Org != "" {
+		if err = r.validateNoSpaceRolesLeft(ctx, role); err != nil {
+			return err
+		}
+	}
+
+	namespace := role.Space
+	if namespace == "" {
+		namespace = role.Org
+	}
+
+	err = userClient.Delete(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      calculateRoleBindingName(role.Type, role.Kind, role.User),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete role %q: %w", guid, apierrors.FromK8sError(err, RoleResourceType))
+	}
+	r.namespacePermissions.Invalidate()
+
+	return nil
+}
+
+func (r *RoleRepo) validateNoSpaceRolesLeft(ctx context.Context, orgRole RoleRecord) error {
+	roles, err := r.listAllRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	spaceRoleNamespaces := []string{}
+	for _, role := range roles {
+		if role.User != orgRole.User || role.Kind != orgRole.Kind || role.GUID == orgRole.GUID {
+			continue
+		}
+
+		if role.Org == orgRole.Org {
+			return nil
+		}
+
+		if role.Space != "" {
+			spaceRoleNamespaces = append(spaceRoleNamespaces, role.Space)
+		}
+	}
+
+	for _, spaceGUID := range spaceRoleNamespaces {
+		orgName, err := r.getOrgName(ctx, spaceGUID)
+		if err != nil {
+			return err
+		}
+
+		if orgName == orgRole.Org {
+			return apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("user %q has roles in space %q of org %q", orgRole.User, spaceGUID, orgRole.Org),
+				fmt.Sprintf("Cannot delete the '%s' role of user '%s' while the user still has roles in spaces of the organization.", orgRole.Type, orgRole.User),
+			)
+		}
+	}
+
+	return nil
+}
+
+// listAllRoles lists the roles in every org and space, sorted by creation time
+func (r *RoleRepo) listAllRoles(ctx context.Context) ([]RoleRecord, error) {
+	roleBindingList := new(rbacv1.RoleBindingList)
+	err := r.privilegedClient.List(ctx, roleBindingList, client.HasLabels{RoleGuidLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role bindings: %w", apierrors.FromK8sError(err, RoleResourceType))
+	}
+
+	roleTypes := map[string]string{}
+	for roleType, roleConfig := range r.roleMappings {
+		roleTypes[roleConfig.Name] = roleType
+	}
+
+	roleBindings := roleBindingList.Items
+	sort.Slice(roleBindings, func(i, j int) bool {
+		return roleBindings[i].CreationTimestamp.Before(&roleBindings[j].CreationTimestamp)
+	})
+
+	roles := []RoleRecord{}
+	for _, roleBinding := range roleBindings {
+		roleType, ok := roleTypes[roleBinding.RoleRef.Name]
+		if !ok || roleType == cfUserRoleType || roleBinding.Namespace == r.rootNamespace || len(roleBinding.Subjects) != 1 {
+			continue
+		}
+
+		if _, inherited := roleBinding.Labels[hnsv1alpha2.LabelInheritedFrom]; inherited {
+			continue
+		}
+
+		role := RoleRecord{
+			GUID:      roleBinding.Labels[RoleGuidLabel],
+			CreatedAt: roleBinding.CreationTimestamp.Time,
+			UpdatedAt: roleBinding.CreationTimestamp.Time,
+			Type:      roleType,
+			User:      roleBinding.Subjects[0].Name,
+			Kind:      roleBinding.Subjects[0].Kind,
+		}
+		if strings.HasPrefix(roleType, "space_") {
+			role.Space = roleBinding.Namespace
+		} else {
+			role.Org = roleBinding.Namespace
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
 func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleMessage, userIdentity authorization.Identity) error {
 	orgName, err := r.getOrgName(ctx, role.Space)
 	if err != nil {
@@ -187,7 +382,13 @@ func (r *RoleRepo) getOrgName(ctx context.Context, spaceGUID string) (string, er
 	return orgName, nil
 }
 
-func calculateRoleBindingName(roleType, roleUser string) string {
+// calculateRoleBindingName names the binding of a role after its type and subject. The bindings of groups are named
+// apart from the bindings of users and service accounts of the same name.
+func calculateRoleBindingName(roleType, roleKind, roleUser string) string {
+	if roleKind == rbacv1.GroupKind {
+		roleUser = "group:" + roleUser
+	}
+
 	plain := []byte(roleType + "::" + roleUser)
 	sum := sha256.Sum256(plain)
 
@@ -198,7 +399,7 @@ func createRoleBinding(namespace, roleType, roleKind, roleUser, roleGUID, roleCo
 	return rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      calculateRoleBindingName(roleType, roleUser),
+			Name:      calculateRoleBindingName(roleType, roleKind, roleUser),
 			Labels: map[string]string{
 				RoleGuidLabel: roleGUID,
 			},
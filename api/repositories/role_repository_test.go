@@ -37,7 +37,7 @@ var _ = Describe("RoleRepository", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 		authorizedInChecker = new(fake.AuthorizedInChecker)
-		roleRepo = repositories.NewRoleRepo(k8sClient, userClientFactory, authorizedInChecker, rootNamespace, map[string]config.Role{
+		roleRepo = repositories.NewRoleRepo(k8sClient, userClientFactory, authorizedInChecker, nsPerms, rootNamespace, map[string]config.Role{
 			"space_developer":      {Name: spaceDeveloperRole.Name},
 			"organization_manager": {Name: orgManagerRole.Name, Propagate: true},
 			"organization_user":    {Name: orgUserRole.Name},
@@ -165,6 +165,24 @@ var _ = Describe("RoleRepository", func() {
 				})
 			})
 
+			When("using a group identity", func() {
+				BeforeEach(func() {
+					roleCreateMessage.Kind = rbacv1.GroupKind
+					roleCreateMessage.User = "developers"
+					// Sha256 sum of "organization_manager::group:developers"
+					expectedName = "cf-d38781b810ec4cde3c66cb6b71b52ef788015013d16eb4e2af2176baca57bb06"
+				})
+
+				It("succeeds and uses a group subject kind", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					roleBinding := getTheRoleBinding(expectedName, orgAnchor.Name)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Name).To(Equal("developers"))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+			})
+
 			When("the org does not exist", func() {
 				BeforeEach(func() {
 					roleCreateMessage.Org = "i-do-not-exist"
@@ -371,4 +389,100 @@ var _ = Describe("RoleRepository", func() {
 			})
 		})
 	})
+
+	Describe("ListRoles, GetRole and DeleteRole", func() {
+		var (
+			spaceAnchor *hnsv1alpha2.SubnamespaceAnchor
+			orgRole     repositories.RoleRecord
+			spaceRole   repositories.RoleRecord
+		)
+
+		createRole := func(message repositories.CreateRoleMessage) repositories.RoleRecord {
+			message.GUID = uuid.NewString()
+			message.User = "myuser@example.com"
+			message.Kind = rbacv1.UserKind
+			role, err := roleRepo.CreateRole(ctx, authInfo, message)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			return role
+		}
+
+		BeforeEach(func() {
+			authorizedInChecker.AuthorizedInReturns(true, nil)
+			spaceAnchor = createSpaceAnchorAndNamespace(ctx, orgAnchor.Name, uuid.NewString())
+			createRoleBinding(ctx, userName, adminRole.Name, orgAnchor.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, spaceAnchor.Name)
+
+			orgRole = createRole(repositories.CreateRoleMessage{Type: "organization_user", Org: orgAnchor.Name})
+			spaceRole = createRole(repositories.CreateRoleMessage{Type: "space_developer", Space: spaceAnchor.Name})
+
+			otherOrgAnchor := createOrgAnchorAndNamespace(ctx, rootNamespace, uuid.NewString())
+			invisibleRoleBinding := rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invisible",
+					Namespace: otherOrgAnchor.Name,
+					Labels:    map[string]string{repositories.RoleGuidLabel: "invisible-role-guid"},
+				},
+				Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "someone-else"}},
+				RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: orgUserRole.Name},
+			}
+			Expect(k8sClient.Create(ctx, &invisibleRoleBinding)).To(Succeed())
+		})
+
+		It("lists the roles in the orgs and spaces the user can see", func() {
+			roles, err := roleRepo.ListRoles(ctx, authInfo, repositories.ListRolesMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(HaveLen(2))
+
+			Expect(roles[0].GUID).To(Equal(orgRole.GUID))
+			Expect(roles[0].Type).To(Equal("organization_user"))
+			Expect(roles[0].Org).To(Equal(orgAnchor.Name))
+			Expect(roles[0].Space).To(BeEmpty())
+			Expect(roles[0].User).To(Equal("myuser@example.com"))
+			Expect(roles[0].Kind).To(Equal(rbacv1.UserKind))
+
+			Expect(roles[1].GUID).To(Equal(spaceRole.GUID))
+			Expect(roles[1].Type).To(Equal("space_developer"))
+			Expect(roles[1].Space).To(Equal(spaceAnchor.Name))
+			Expect(roles[1].Org).To(BeEmpty())
+		})
+
+		It("filters the roles", func() {
+			roles, err := roleRepo.ListRoles(ctx, authInfo, repositories.ListRolesMessage{
+				Types:     []string{"space_developer"},
+				UserGUIDs: []string{"myuser@example.com"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(HaveLen(1))
+			Expect(roles[0].GUID).To(Equal(spaceRole.GUID))
+		})
+
+		It("gets a role", func() {
+			role, err := roleRepo.GetRole(ctx, authInfo, orgRole.GUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(role.Type).To(Equal("organization_user"))
+		})
+
+		It("does not get roles the user cannot see", func() {
+			_, err := roleRepo.GetRole(ctx, authInfo, "invisible-role-guid")
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		It("deletes a space role", func() {
+			Expect(roleRepo.DeleteRole(ctx, authInfo, spaceRole.GUID)).To(Succeed())
+
+			roleBinding := rbacv1.RoleBinding{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: spaceAnchor.Name, Name: "cf-94662df3659074e12fbb2a05fbda554db8fd0bf2f59394874412ebb0dddf6ba4"}, &roleBinding)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("does not delete the org role of a user with space roles in the org", func() {
+			err := roleRepo.DeleteRole(ctx, authInfo, orgRole.GUID)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		It("deletes the org role once the space roles are gone", func() {
+			Expect(roleRepo.DeleteRole(ctx, authInfo, spaceRole.GUID)).To(Succeed())
+			Expect(roleRepo.DeleteRole(ctx, authInfo, orgRole.GUID)).To(Succeed())
+		})
+	})
 })
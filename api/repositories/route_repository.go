@@ -89,11 +89,12 @@ func (m DestinationMessage) toCFDestination() networkingv1alpha1.Destination {
 }
 
 type ListRoutesMessage struct {
-	AppGUIDs    []string
-	SpaceGUIDs  []string
-	DomainGUIDs []string
-	Hosts       []string
-	Paths       []string
+	AppGUIDs      []string
+	SpaceGUIDs    []string
+	DomainGUIDs   []string
+	Hosts         []string
+	Paths         []string
+	LabelSelector string
 }
 
 type CreateRouteMessage struct {
@@ -107,6 +108,12 @@ type CreateRouteMessage struct {
 	Annotations     map[string]string
 }
 
+type PatchRouteMessage struct {
+	GUID          string
+	SpaceGUID     string
+	MetadataPatch MetadataPatch
+}
+
 type DeleteRouteMessage struct {
 	GUID      string
 	SpaceGUID string
@@ -121,8 +128,8 @@ func (m CreateRouteMessage) toCFRoute() networkingv1alpha1.CFRoute {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        RoutePrefix + uuid.NewString(),
 			Namespace:   m.SpaceGUID,
-			Labels:      m.Labels,
-			Annotations: m.Annotations,
+			Labels:      withCFMetadata(nil, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
 		},
 		Spec: networkingv1alpha1.CFRouteSpec{
 			Host:     m.Host,
@@ -167,22 +174,59 @@ func (f *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info,
 		return []RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []RouteRecord{}, err
+	}
+
 	filteredRoutes := []networkingv1alpha1.CFRoute{}
 	for ns := range nsList {
+		if !matchesFilter(ns, message.SpaceGUIDs) {
+			continue
+		}
+
 		cfRouteList := &networkingv1alpha1.CFRouteList{}
-		err := userClient.List(ctx, cfRouteList, client.InNamespace(ns))
+		err := listInChunks(ctx, userClient, cfRouteList, func() {
+			filteredRoutes = append(filteredRoutes, applyRouteListFilter(cfRouteList.Items, message)...)
+		}, client.InNamespace(ns), labelSelector)
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
 			return []RouteRecord{}, fmt.Errorf("failed to list routes namespace %s: %w", ns, apierrors.FromK8sError(err, RouteResourceType))
 		}
-		filteredRoutes = append(filteredRoutes, applyRouteListFilter(cfRouteList.Items, message)...)
 	}
 
 	return returnRouteList(filteredRoutes), nil
 }
 
+// ListSpaceRoutes lists one page of the routes of a space, along with the number of routes the space has
+func (f *RouteRepo) ListSpaceRoutes(ctx context.Context, authInfo authorization.Info, spaceGUID, labelSelector string, page Page) ([]RouteRecord, int, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	labelSelectorOption, err := labelSelectorListOption(labelSelector)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cfRouteList := &networkingv1alpha1.CFRouteList{}
+	routeRecords := []RouteRecord{}
+	total, err := listPage(ctx, userClient, cfRouteList, page, func() {
+		routeRecords = returnRouteList(cfRouteList.Items)
+	}, client.InNamespace(spaceGUID), labelSelectorOption)
+	if k8serrors.IsForbidden(err) {
+		return []RouteRecord{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list routes in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return routeRecords, total, nil
+}
+
 func applyRouteListFilter(routes []networkingv1alpha1.CFRoute, message ListRoutesMessage) []networkingv1alpha1.CFRoute {
 	if len(message.AppGUIDs) == 0 &&
 		len(message.SpaceGUIDs) == 0 &&
@@ -287,6 +331,8 @@ func cfRouteToRouteRecord(cfRoute networkingv1alpha1.CFRoute) RouteRecord {
 		Path:         cfRoute.Spec.Path,
 		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
 		Destinations: destinations,
+		Labels:       cfMetadata(cfRoute.Labels),
+		Annotations:  cfMetadata(cfRoute.Annotations),
 		CreatedAt:    cfRoute.CreationTimestamp.UTC().Format(TimestampFormat),
 		UpdatedAt:    updatedAtTime,
 	}
@@ -311,6 +357,9 @@ func (f *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info
 
 	err = userClient.Create(ctx, &cfRoute)
 	if err != nil {
+		if quotaErr, ok := asQuotaExceededError(err); ok {
+			return RouteRecord{}, quotaErr
+		}
 		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
 			return RouteRecord{}, apierrors.NewUnprocessableEntityError(err, validationError.Error())
 		}
@@ -335,6 +384,32 @@ func (f *RouteRepo) DeleteRoute(ctx context.Context, authInfo authorization.Info
 	return apierrors.FromK8sError(err, RouteResourceType)
 }
 
+func (f *RouteRepo) PatchRoute(ctx context.Context, authInfo authorization.Info, message PatchRouteMessage) (RouteRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	baseCFRoute := new(networkingv1alpha1.CFRoute)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, baseCFRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	cfRoute := baseCFRoute.DeepCopy()
+	message.MetadataPatch.apply(cfRoute)
+
+	err = userClient.Patch(ctx, cfRoute, client.MergeFrom(baseCFRoute))
+	if err != nil {
+		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			return RouteRecord{}, apierrors.NewUnprocessableEntityError(err, validationError.Error())
+		}
+		return RouteRecord{}, fmt.Errorf("failed to patch route %q: %w", message.GUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
 func (f *RouteRepo) GetOrCreateRoute(ctx context.Context, authInfo authorization.Info, message CreateRouteMessage) (RouteRecord, error) {
 	existingRecord, exists, err := f.fetchRouteByFields(ctx, authInfo, message)
 	if err != nil {
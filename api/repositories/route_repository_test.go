@@ -427,6 +427,63 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("ListSpaceRoutes", func() {
+		var (
+			cfRoute1, cfRoute2 *networkingv1alpha1.CFRoute
+			page               Page
+			routeRecords       []RouteRecord
+			total              int
+			listErr            error
+		)
+
+		BeforeEach(func() {
+			cfRoute1 = createRoute(route1GUID, space.Name, "my-subdomain-1", "", domainGUID, "some-app-guid-1")
+			cfRoute2 = createRoute(route2GUID, space.Name, "my-subdomain-2", "", domainGUID, "some-app-guid-2")
+
+			space2 := createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space2"))
+			createRoute(generateGUID(), space2.Name, "my-subdomain-3", "", domainGUID, "some-app-guid-3")
+
+			page = Page{Number: 1, PerPage: 50}
+		})
+
+		JustBeforeEach(func() {
+			routeRecords, total, listErr = routeRepo.ListSpaceRoutes(testCtx, authInfo, space.Name, "", page)
+		})
+
+		It("returns no routes to unauthorized users", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(total).To(BeZero())
+			Expect(routeRecords).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the routes of the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(total).To(Equal(2))
+				Expect(routeRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfRoute1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfRoute2.Name)}),
+				))
+			})
+
+			When("the page holds a single route", func() {
+				BeforeEach(func() {
+					page = Page{Number: 2, PerPage: 1}
+				})
+
+				It("returns that page and the number of routes of the space", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(total).To(Equal(2))
+					Expect(routeRecords).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
 	Describe("ListRoutesForApp", func() {
 		var (
 			appGUID      string
@@ -624,6 +681,66 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("PatchRoute", func() {
+		var (
+			cfRoute     *networkingv1alpha1.CFRoute
+			routeRecord RouteRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			cfRoute = &networkingv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      route1GUID,
+					Namespace: space.Name,
+					Labels: map[string]string{
+						"metadata.cloudfoundry.org/env": "dev",
+						"metadata.cloudfoundry.org/old": "value",
+					},
+				},
+				Spec: networkingv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			prod := "prod"
+			routeRecord, patchErr = routeRepo.PatchRoute(testCtx, authInfo, PatchRouteMessage{
+				GUID:      route1GUID,
+				SpaceGUID: space.Name,
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"env": &prod, "old": nil},
+				},
+			})
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the labels of the route", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(routeRecord.GUID).To(Equal(route1GUID))
+				Expect(routeRecord.Host).To(Equal("my-subdomain-1"))
+				Expect(routeRecord.Labels).To(Equal(map[string]string{"env": "prod"}))
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("DeleteRoute", func() {
 		var cfRoute1 *networkingv1alpha1.CFRoute
 
@@ -0,0 +1,430 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+//+kubebuilder:rbac:groups=networking.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;create;patch;delete
+
+const (
+	SecurityGroupResourceType = "Security Group"
+
+	SecurityGroupRunningLifecycle = "running"
+	SecurityGroupStagingLifecycle = "staging"
+)
+
+type SecurityGroupRepo struct {
+	rootNamespace     string
+	privilegedClient  client.Client
+	userClientFactory UserK8sClientFactory
+}
+
+func NewSecurityGroupRepo(rootNamespace string, privilegedClient client.Client, userClientFactory UserK8sClientFactory) *SecurityGroupRepo {
+	return &SecurityGroupRepo{
+		rootNamespace:     rootNamespace,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+	}
+}
+
+type SecurityGroupRule struct {
+	Protocol    string
+	Destination string
+	Ports       string
+	Type        *int
+	Code        *int
+	Description string
+	Log         bool
+}
+
+type SecurityGroupRecord struct {
+	GUID                   string
+	Name                   string
+	GloballyEnabledRunning bool
+	GloballyEnabledStaging bool
+	Rules                  []SecurityGroupRule
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+	CreatedAt              string
+	UpdatedAt              string
+}
+
+type CreateSecurityGroupMessage struct {
+	Name                   string
+	GloballyEnabledRunning bool
+	GloballyEnabledStaging bool
+	Rules                  []SecurityGroupRule
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+type ListSecurityGroupsMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+// PatchSecurityGroupMessage leaves the fields that are nil as they are. Rules replace all the rules of the group.
+type PatchSecurityGroupMessage struct {
+	GUID                   string
+	Name                   *string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	Rules                  []SecurityGroupRule
+}
+
+// BindSecurityGroupMessage binds the security group to the running or staging workloads of the spaces
+type BindSecurityGroupMessage struct {
+	GUID       string
+	Lifecycle  string
+	SpaceGUIDs []string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID      string
+	Lifecycle string
+	SpaceGUID string
+}
+
+func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo authorization.Info, message CreateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.checkNameIsFree(ctx, userClient, message.Name); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if err = r.checkSpacesExist(ctx, append(append([]string{}, message.RunningSpaceGUIDs...), message.StagingSpaceGUIDs...)); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	cfSecurityGroup := &networkingv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: networkingv1alpha1.CFSecurityGroupSpec{
+			DisplayName: message.Name,
+			Rules:       rulesToCRDRules(message.Rules),
+			GloballyEnabled: networkingv1alpha1.SecurityGroupWorkloads{
+				Running: message.GloballyEnabledRunning,
+				Staging: message.GloballyEnabledStaging,
+			},
+			RunningSpaces: withValues(nil, message.RunningSpaceGUIDs),
+			StagingSpaces: withValues(nil, message.StagingSpaceGUIDs),
+		},
+	}
+	err = userClient.Create(ctx, cfSecurityGroup)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to create security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, guid)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupsMessage) ([]SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroupList := new(networkingv1alpha1.CFSecurityGroupList)
+	err = userClient.List(ctx, cfSecurityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []SecurityGroupRecord{}, nil
+		}
+		return []SecurityGroupRecord{}, fmt.Errorf("failed to list security groups in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	cfSecurityGroups := cfSecurityGroupList.Items
+	sort.Slice(cfSecurityGroups, func(i, j int) bool {
+		return cfSecurityGroups[i].CreationTimestamp.Before(&cfSecurityGroups[j].CreationTimestamp)
+	})
+
+	records := []SecurityGroupRecord{}
+	for i := range cfSecurityGroups {
+		spec := cfSecurityGroups[i].Spec
+		if matchesFilter(cfSecurityGroups[i].Name, message.GUIDs) &&
+			matchesFilter(spec.DisplayName, message.Names) &&
+			matchesBoolFilter(spec.GloballyEnabled.Running, message.GloballyEnabledRunning) &&
+			matchesBoolFilter(spec.GloballyEnabled.Staging, message.GloballyEnabledStaging) &&
+			matchesAnyFilter(spec.RunningSpaces, message.RunningSpaceGUIDs) &&
+			matchesAnyFilter(spec.StagingSpaces, message.StagingSpaceGUIDs) {
+			records = append(records, cfSecurityGroupToRecord(&cfSecurityGroups[i]))
+		}
+	}
+
+	return records, nil
+}
+
+func (r *SecurityGroupRepo) PatchSecurityGroup(ctx context.Context, authInfo authorization.Info, message PatchSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if message.Name != nil && *message.Name != cfSecurityGroup.Spec.DisplayName {
+		if err = r.checkNameIsFree(ctx, userClient, *message.Name); err != nil {
+			return SecurityGroupRecord{}, err
+		}
+	}
+
+	updatedSecurityGroup := cfSecurityGroup.DeepCopy()
+	if message.Name != nil {
+		updatedSecurityGroup.Spec.DisplayName = *message.Name
+	}
+	if message.GloballyEnabledRunning != nil {
+		updatedSecurityGroup.Spec.GloballyEnabled.Running = *message.GloballyEnabledRunning
+	}
+	if message.GloballyEnabledStaging != nil {
+		updatedSecurityGroup.Spec.GloballyEnabled.Staging = *message.GloballyEnabledStaging
+	}
+	if message.Rules != nil {
+		updatedSecurityGroup.Spec.Rules = rulesToCRDRules(message.Rules)
+	}
+
+	err = userClient.Patch(ctx, updatedSecurityGroup, client.MergeFrom(cfSecurityGroup))
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to patch security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(updatedSecurityGroup), nil
+}
+
+// DeleteSecurityGroup deletes the security group. The controllers remove its network policies from the spaces.
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	err = userClient.Delete(ctx, cfSecurityGroup)
+	if err != nil {
+		return fmt.Errorf("failed to delete security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+// BindSecurityGroup applies the security group to the running or staging workloads of the spaces, in addition to the
+// spaces it is already bound to
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if err = r.checkSpacesExist(ctx, message.SpaceGUIDs); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	updatedSecurityGroup := cfSecurityGroup.DeepCopy()
+	spaces := lifecycleSpaces(&updatedSecurityGroup.Spec, message.Lifecycle)
+	*spaces = withValues(*spaces, message.SpaceGUIDs)
+
+	err = userClient.Patch(ctx, updatedSecurityGroup, client.MergeFrom(cfSecurityGroup))
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to bind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(updatedSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	if !containsValue(*lifecycleSpaces(&cfSecurityGroup.Spec, message.Lifecycle), message.SpaceGUID) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("security group %s is not bound to space %s", message.GUID, message.SpaceGUID),
+			fmt.Sprintf("Unable to unbind security group from space with guid '%s'. Ensure the space is bound to this security group.", message.SpaceGUID),
+		)
+	}
+
+	updatedSecurityGroup := cfSecurityGroup.DeepCopy()
+	spaces := lifecycleSpaces(&updatedSecurityGroup.Spec, message.Lifecycle)
+	*spaces = withoutValues(*spaces, []string{message.SpaceGUID})
+
+	err = userClient.Patch(ctx, updatedSecurityGroup, client.MergeFrom(cfSecurityGroup))
+	if err != nil {
+		return fmt.Errorf("failed to unbind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) getSecurityGroup(ctx context.Context, userClient client.Client, guid string) (*networkingv1alpha1.CFSecurityGroup, error) {
+	cfSecurityGroup := new(networkingv1alpha1.CFSecurityGroup)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfSecurityGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroup, nil
+}
+
+func (r *SecurityGroupRepo) checkNameIsFree(ctx context.Context, userClient client.Client, name string) error {
+	cfSecurityGroupList := new(networkingv1alpha1.CFSecurityGroupList)
+	err := userClient.List(ctx, cfSecurityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list security groups: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	for _, cfSecurityGroup := range cfSecurityGroupList.Items {
+		if cfSecurityGroup.Spec.DisplayName == name {
+			return apierrors.NewUniquenessError(
+				fmt.Errorf("security group %q already exists", name),
+				fmt.Sprintf("Security group with name '%s' already exists.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkSpacesExist looks the spaces up with the privileged client, as admins managing security groups may not be
+// members of the spaces, and spaces live in the namespaces of their orgs
+func (r *SecurityGroupRepo) checkSpacesExist(ctx context.Context, spaceGUIDs []string) error {
+	if len(spaceGUIDs) == 0 {
+		return nil
+	}
+
+	anchorList := new(v1alpha2.SubnamespaceAnchorList)
+	err := r.privilegedClient.List(ctx, anchorList, client.HasLabels{SpaceNameLabel})
+	if err != nil {
+		return fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	existingSpaces := []string{}
+	for _, anchor := range anchorList.Items {
+		existingSpaces = append(existingSpaces, anchor.Name)
+	}
+
+	missingSpaces := []string{}
+	for _, spaceGUID := range spaceGUIDs {
+		if !containsValue(existingSpaces, spaceGUID) && !containsValue(missingSpaces, spaceGUID) {
+			missingSpaces = append(missingSpaces, spaceGUID)
+		}
+	}
+
+	if len(missingSpaces) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("spaces %v do not exist", missingSpaces),
+			fmt.Sprintf("Spaces with guids [%s] do not exist, or you do not have access to them.", quoteGUIDs(missingSpaces)),
+		)
+	}
+
+	return nil
+}
+
+func lifecycleSpaces(spec *networkingv1alpha1.CFSecurityGroupSpec, lifecycle string) *[]string {
+	if lifecycle == SecurityGroupStagingLifecycle {
+		return &spec.StagingSpaces
+	}
+
+	return &spec.RunningSpaces
+}
+
+func matchesBoolFilter(field bool, filter *bool) bool {
+	return filter == nil || field == *filter
+}
+
+func rulesToCRDRules(rules []SecurityGroupRule) []networkingv1alpha1.SecurityGroupRule {
+	crdRules := []networkingv1alpha1.SecurityGroupRule{}
+	for _, rule := range rules {
+		crdRules = append(crdRules, networkingv1alpha1.SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	return crdRules
+}
+
+func cfSecurityGroupToRecord(cfSecurityGroup *networkingv1alpha1.CFSecurityGroup) SecurityGroupRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfSecurityGroup.ObjectMeta)
+
+	rules := []SecurityGroupRule{}
+	for _, rule := range cfSecurityGroup.Spec.Rules {
+		rules = append(rules, SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	return SecurityGroupRecord{
+		GUID:                   cfSecurityGroup.Name,
+		Name:                   cfSecurityGroup.Spec.DisplayName,
+		GloballyEnabledRunning: cfSecurityGroup.Spec.GloballyEnabled.Running,
+		GloballyEnabledStaging: cfSecurityGroup.Spec.GloballyEnabled.Staging,
+		Rules:                  rules,
+		RunningSpaceGUIDs:      append([]string{}, cfSecurityGroup.Spec.RunningSpaces...),
+		StagingSpaceGUIDs:      append([]string{}, cfSecurityGroup.Spec.StagingSpaces...),
+		CreatedAt:              formatTimestamp(cfSecurityGroup.CreationTimestamp),
+		UpdatedAt:              updatedAtTime,
+	}
+}
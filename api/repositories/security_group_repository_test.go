@@ -0,0 +1,197 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("SecurityGroupRepository", func() {
+	var (
+		ctx               context.Context
+		securityGroupRepo *repositories.SecurityGroupRepo
+		space             *hnsv1alpha2.SubnamespaceAnchor
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		securityGroupRepo = repositories.NewSecurityGroupRepo(rootNamespace, k8sClient, userClientFactory)
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+	})
+
+	createSecurityGroup := func(name string, runningSpaces ...string) *networkingv1alpha1.CFSecurityGroup {
+		cfSecurityGroup := &networkingv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("security-group"),
+				Namespace: rootNamespace,
+			},
+			Spec: networkingv1alpha1.CFSecurityGroupSpec{
+				DisplayName: name,
+				Rules: []networkingv1alpha1.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.0/16", Ports: "443"},
+				},
+				RunningSpaces: runningSpaces,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+		return cfSecurityGroup
+	}
+
+	Describe("CreateSecurityGroup", func() {
+		var (
+			message             repositories.CreateSecurityGroupMessage
+			securityGroupRecord repositories.SecurityGroupRecord
+			createErr           error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSecurityGroupMessage{
+				Name:                   prefixedGUID("my-group"),
+				GloballyEnabledStaging: true,
+				Rules: []repositories.SecurityGroupRule{
+					{Protocol: "udp", Destination: "10.0.0.1-10.0.0.9", Ports: "53"},
+				},
+				RunningSpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, createErr = securityGroupRepo.CreateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the security group bound to the spaces", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.Name).To(Equal(message.Name))
+				Expect(securityGroupRecord.GloballyEnabledStaging).To(BeTrue())
+				Expect(securityGroupRecord.RunningSpaceGUIDs).To(ConsistOf(space.Name))
+
+				cfSecurityGroup := new(networkingv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: securityGroupRecord.GUID}, cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.DisplayName).To(Equal(message.Name))
+				Expect(cfSecurityGroup.Spec.Rules).To(Equal([]networkingv1alpha1.SecurityGroupRule{
+					{Protocol: "udp", Destination: "10.0.0.1-10.0.0.9", Ports: "53"},
+				}))
+				Expect(cfSecurityGroup.Spec.RunningSpaces).To(ConsistOf(space.Name))
+			})
+
+			When("a security group with the same name exists", func() {
+				BeforeEach(func() {
+					createSecurityGroup(message.Name)
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+
+			When("a space does not exist", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{"not-a-space"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			boundGroup   *networkingv1alpha1.CFSecurityGroup
+			unboundGroup *networkingv1alpha1.CFSecurityGroup
+		)
+
+		BeforeEach(func() {
+			boundGroup = createSecurityGroup(prefixedGUID("bound"), space.Name)
+			unboundGroup = createSecurityGroup(prefixedGUID("unbound"))
+		})
+
+		It("lists the security groups to root namespace users", func() {
+			records, err := securityGroupRepo.ListSecurityGroups(ctx, authInfo, repositories.ListSecurityGroupsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+
+			guids := []string{}
+			for _, record := range records {
+				guids = append(guids, record.GUID)
+			}
+			Expect(guids).To(ContainElements(boundGroup.Name, unboundGroup.Name))
+		})
+
+		It("filters the security groups by running space", func() {
+			records, err := securityGroupRepo.ListSecurityGroups(ctx, authInfo, repositories.ListSecurityGroupsMessage{
+				RunningSpaceGUIDs: []string{space.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(boundGroup.Name))
+		})
+	})
+
+	Describe("BindSecurityGroup and UnbindSecurityGroup", func() {
+		var cfSecurityGroup *networkingv1alpha1.CFSecurityGroup
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(prefixedGUID("my-group"))
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		It("binds and unbinds the staging workloads of the space", func() {
+			record, err := securityGroupRepo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:       cfSecurityGroup.Name,
+				Lifecycle:  repositories.SecurityGroupStagingLifecycle,
+				SpaceGUIDs: []string{space.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.StagingSpaceGUIDs).To(ConsistOf(space.Name))
+			Expect(record.RunningSpaceGUIDs).To(BeEmpty())
+
+			Expect(securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:      cfSecurityGroup.Name,
+				Lifecycle: repositories.SecurityGroupStagingLifecycle,
+				SpaceGUID: space.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			Expect(cfSecurityGroup.Spec.StagingSpaces).To(BeEmpty())
+		})
+
+		It("fails to unbind a space that is not bound", func() {
+			err := securityGroupRepo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+				GUID:      cfSecurityGroup.Name,
+				Lifecycle: repositories.SecurityGroupRunningLifecycle,
+				SpaceGUID: space.Name,
+			})
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+	})
+
+	Describe("DeleteSecurityGroup", func() {
+		It("deletes the security group", func() {
+			cfSecurityGroup := createSecurityGroup(prefixedGUID("my-group"), space.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+			Expect(securityGroupRepo.DeleteSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)).To(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).NotTo(Succeed())
+		})
+	})
+})
@@ -48,6 +48,8 @@ type ServiceBindingRecord struct {
 	AppGUID             string
 	ServiceInstanceGUID string
 	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
 	CreatedAt           string
 	UpdatedAt           string
 	LastOperation       ServiceBindingLastOperation
@@ -66,6 +68,8 @@ type CreateServiceBindingMessage struct {
 	ServiceInstanceGUID string
 	AppGUID             string
 	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
 }
 
 type DeleteServiceBindingMessage struct {
@@ -81,9 +85,10 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() servicesv1alpha1.CFSer
 	guid := uuid.NewString()
 	return servicesv1alpha1.CFServiceBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      guid,
-			Namespace: m.SpaceGUID,
-			Labels:    map[string]string{LabelServiceBindingProvisionedService: "true"},
+			Name:        guid,
+			Namespace:   m.SpaceGUID,
+			Labels:      withCFMetadata(map[string]string{LabelServiceBindingProvisionedService: "true"}, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
 		},
 		Spec: servicesv1alpha1.CFServiceBindingSpec{
 			Name: m.Name,
@@ -112,6 +117,25 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 	return cfServiceBindingToRecord(cfServiceBinding), err
 }
 
+func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
+	if err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to get namespace for service binding: %w", err)
+	}
+
+	binding := servicesv1alpha1.CFServiceBinding{}
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &binding); err != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to get service binding: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return cfServiceBindingToRecord(binding), nil
+}
+
 func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -169,6 +193,8 @@ func cfServiceBindingToRecord(binding servicesv1alpha1.CFServiceBinding) Service
 		AppGUID:             binding.Spec.AppRef.Name,
 		ServiceInstanceGUID: binding.Spec.Service.Name,
 		SpaceGUID:           binding.Namespace,
+		Labels:              cfMetadata(binding.Labels),
+		Annotations:         cfMetadata(binding.Annotations),
 		CreatedAt:           createdAt,
 		UpdatedAt:           updatedAt,
 		LastOperation: ServiceBindingLastOperation{
@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;create;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete
+
+const (
+	ServiceBrokerResourceType = "Service Broker"
+
+	CatalogSyncedConditionType = "CatalogSynced"
+
+	serviceBrokerUsernameKey = "username"
+	serviceBrokerPasswordKey = "password"
+)
+
+type ServiceBrokerRepo struct {
+	rootNamespace     string
+	userClientFactory UserK8sClientFactory
+}
+
+func NewServiceBrokerRepo(rootNamespace string, userClientFactory UserK8sClientFactory) *ServiceBrokerRepo {
+	return &ServiceBrokerRepo{
+		rootNamespace:     rootNamespace,
+		userClientFactory: userClientFactory,
+	}
+}
+
+type CreateServiceBrokerMessage struct {
+	Name        string
+	URL         string
+	Username    string
+	Password    string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type ListServiceBrokersMessage struct {
+	Names []string
+}
+
+type ServiceBrokerRecord struct {
+	GUID        string
+	Name        string
+	URL         string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   string
+	UpdatedAt   string
+
+	// CatalogSynced is True once the catalog of the broker has been synced, and False when syncing it failed
+	CatalogSynced      metav1.ConditionStatus
+	CatalogSyncMessage string
+}
+
+func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo authorization.Info, message CreateServiceBrokerMessage) (ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	guid := uuid.NewString()
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+		StringData: map[string]string{
+			serviceBrokerUsernameKey: message.Username,
+			serviceBrokerPasswordKey: message.Password,
+		},
+	}
+	err = userClient.Create(ctx, credentialsSecret)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to create service broker credentials: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	cfServiceBroker := &servicesv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        guid,
+			Namespace:   r.rootNamespace,
+			Labels:      withCFMetadata(nil, message.Labels),
+			Annotations: withCFMetadata(nil, message.Annotations),
+		},
+		Spec: servicesv1alpha1.CFServiceBrokerSpec{
+			Name:        message.Name,
+			URL:         message.URL,
+			Credentials: corev1.LocalObjectReference{Name: credentialsSecret.Name},
+		},
+	}
+	err = userClient.Create(ctx, cfServiceBroker)
+	if err != nil {
+		// the broker failed to be created, so it will never own the credentials
+		_ = userClient.Delete(ctx, credentialsSecret)
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to create service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return cfServiceBrokerToRecord(cfServiceBroker), nil
+}
+
+func (r *ServiceBrokerRepo) GetServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBroker := new(servicesv1alpha1.CFServiceBroker)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceBroker)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to get service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return cfServiceBrokerToRecord(cfServiceBroker), nil
+}
+
+func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo authorization.Info, message ListServiceBrokersMessage) ([]ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBrokerList := new(servicesv1alpha1.CFServiceBrokerList)
+	err = userClient.List(ctx, cfServiceBrokerList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []ServiceBrokerRecord{}, nil
+		}
+		return []ServiceBrokerRecord{}, fmt.Errorf("failed to list service brokers in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	cfServiceBrokers := cfServiceBrokerList.Items
+	sort.Slice(cfServiceBrokers, func(i, j int) bool {
+		return cfServiceBrokers[i].CreationTimestamp.Before(&cfServiceBrokers[j].CreationTimestamp)
+	})
+
+	records := []ServiceBrokerRecord{}
+	for i := range cfServiceBrokers {
+		if matchesFilter(cfServiceBrokers[i].Spec.Name, message.Names) {
+			records = append(records, cfServiceBrokerToRecord(&cfServiceBrokers[i]))
+		}
+	}
+
+	return records, nil
+}
+
+// DeleteServiceBroker deletes the broker and its credentials. The offerings and plans of the broker are owned by
+// it and are garbage collected.
+func (r *ServiceBrokerRepo) DeleteServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBroker := new(servicesv1alpha1.CFServiceBroker)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceBroker)
+	if err != nil {
+		return fmt.Errorf("failed to get service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	err = userClient.Delete(ctx, cfServiceBroker)
+	if err != nil {
+		return fmt.Errorf("failed to delete service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	err = userClient.Delete(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBroker.Spec.Credentials.Name,
+			Namespace: r.rootNamespace,
+		},
+	})
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete service broker credentials: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return nil
+}
+
+func cfServiceBrokerToRecord(cfServiceBroker *servicesv1alpha1.CFServiceBroker) ServiceBrokerRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfServiceBroker.ObjectMeta)
+
+	record := ServiceBrokerRecord{
+		GUID:          cfServiceBroker.Name,
+		Name:          cfServiceBroker.Spec.Name,
+		URL:           cfServiceBroker.Spec.URL,
+		Labels:        cfMetadata(cfServiceBroker.Labels),
+		Annotations:   cfMetadata(cfServiceBroker.Annotations),
+		CreatedAt:     formatTimestamp(cfServiceBroker.CreationTimestamp),
+		UpdatedAt:     updatedAtTime,
+		CatalogSynced: getConditionValue(&cfServiceBroker.Status.Conditions, CatalogSyncedConditionType),
+	}
+
+	if condition := meta.FindStatusCondition(cfServiceBroker.Status.Conditions, CatalogSyncedConditionType); condition != nil {
+		record.CatalogSyncMessage = condition.Message
+	}
+
+	return record
+}
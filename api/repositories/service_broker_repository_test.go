@@ -0,0 +1,186 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceBrokerRepository", func() {
+	var (
+		testCtx           context.Context
+		serviceBrokerRepo *ServiceBrokerRepo
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		serviceBrokerRepo = NewServiceBrokerRepo(rootNamespace, userClientFactory)
+	})
+
+	Describe("CreateServiceBroker", func() {
+		var (
+			record    ServiceBrokerRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = serviceBrokerRepo.CreateServiceBroker(testCtx, authInfo, CreateServiceBrokerMessage{
+				Name:     "my-broker",
+				URL:      "https://broker.example.com",
+				Username: "user",
+				Password: "pass",
+				Labels:   map[string]string{"env": "test"},
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the broker and its credentials in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-broker"))
+				Expect(record.URL).To(Equal("https://broker.example.com"))
+				Expect(record.Labels).To(Equal(map[string]string{"env": "test"}))
+				Expect(record.CatalogSynced).To(Equal(metav1.ConditionUnknown))
+
+				cfServiceBroker := new(servicesv1alpha1.CFServiceBroker)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, cfServiceBroker)).To(Succeed())
+				Expect(cfServiceBroker.Spec.URL).To(Equal("https://broker.example.com"))
+
+				secret := new(corev1.Secret)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: cfServiceBroker.Spec.Credentials.Name}, secret)).To(Succeed())
+				Expect(secret.Data).To(Equal(map[string][]byte{
+					"username": []byte("user"),
+					"password": []byte("pass"),
+				}))
+			})
+		})
+
+		When("the user is not an admin", func() {
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("GetServiceBroker", func() {
+		var cfServiceBroker *servicesv1alpha1.CFServiceBroker
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, adminRole.Name, rootNamespace)
+
+			cfServiceBroker = &servicesv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: rootNamespace,
+				},
+				Spec: servicesv1alpha1.CFServiceBrokerSpec{
+					Name:        "my-broker",
+					URL:         "https://broker.example.com",
+					Credentials: corev1.LocalObjectReference{Name: "creds"},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, cfServiceBroker)).To(Succeed())
+
+			meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+				Type:    CatalogSyncedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "CatalogSyncFailed",
+				Message: "connection refused",
+			})
+			Expect(k8sClient.Status().Update(testCtx, cfServiceBroker)).To(Succeed())
+		})
+
+		It("returns the broker with the state of its catalog", func() {
+			record, err := serviceBrokerRepo.GetServiceBroker(testCtx, authInfo, cfServiceBroker.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(cfServiceBroker.Name))
+			Expect(record.CatalogSynced).To(Equal(metav1.ConditionFalse))
+			Expect(record.CatalogSyncMessage).To(Equal("connection refused"))
+		})
+
+		When("the broker does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := serviceBrokerRepo.GetServiceBroker(testCtx, authInfo, "does-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListServiceBrokers", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"broker-1", "broker-2"} {
+				Expect(k8sClient.Create(testCtx, &servicesv1alpha1.CFServiceBroker{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      generateGUID(),
+						Namespace: rootNamespace,
+					},
+					Spec: servicesv1alpha1.CFServiceBrokerSpec{
+						Name: name,
+						URL:  "https://broker.example.com",
+					},
+				})).To(Succeed())
+			}
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists the brokers matching the filter", func() {
+				records, err := serviceBrokerRepo.ListServiceBrokers(testCtx, authInfo, ListServiceBrokersMessage{Names: []string{"broker-2"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].Name).To(Equal("broker-2"))
+			})
+		})
+
+		When("the user is not allowed to list brokers", func() {
+			It("returns an empty list", func() {
+				records, err := serviceBrokerRepo.ListServiceBrokers(testCtx, authInfo, ListServiceBrokersMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteServiceBroker", func() {
+		var record ServiceBrokerRecord
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, adminRole.Name, rootNamespace)
+
+			var err error
+			record, err = serviceBrokerRepo.CreateServiceBroker(testCtx, authInfo, CreateServiceBrokerMessage{
+				Name:     "my-broker",
+				URL:      "https://broker.example.com",
+				Username: "user",
+				Password: "pass",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the broker and its credentials", func() {
+			Expect(serviceBrokerRepo.DeleteServiceBroker(testCtx, authInfo, record.GUID)).To(Succeed())
+
+			_, err := serviceBrokerRepo.GetServiceBroker(testCtx, authInfo, record.GUID)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+
+			err = k8sClient.Get(testCtx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, new(corev1.Secret))
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+	})
+})
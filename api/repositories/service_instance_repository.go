@@ -18,7 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceinstances,verbs=list;create;get;delete
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceinstances,verbs=list;create;get;patch;delete
 
 const (
 	CFServiceInstanceGUIDLabel     = "services.cloudfoundry.org/service-instance-guid"
@@ -31,17 +31,20 @@ type NamespaceGetter interface {
 }
 
 type ServiceInstanceRepo struct {
+	rootNamespace        string
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
 }
 
 func NewServiceInstanceRepo(
+	rootNamespace string,
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory UserK8sClientFactory,
 	namespacePermissions *authorization.NamespacePermissions,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
+		rootNamespace:        rootNamespace,
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: namespacePermissions,
@@ -49,36 +52,58 @@ func NewServiceInstanceRepo(
 }
 
 type CreateServiceInstanceMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]string
-	Type        string
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name            string
+	SpaceGUID       string
+	Credentials     map[string]string
+	Type            string
+	ServicePlanGUID string
+	Tags            []string
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type ListServiceInstanceMessage struct {
 	Names           []string
 	SpaceGuids      []string
+	LabelSelector   string
 	OrderBy         string
 	DescendingOrder bool
 }
 
+type PatchServiceInstanceMessage struct {
+	GUID          string
+	SpaceGUID     string
+	Name          *string
+	Tags          *[]string
+	Credentials   *map[string]string
+	MetadataPatch MetadataPatch
+}
+
 type DeleteServiceInstanceMessage struct {
 	GUID      string
 	SpaceGUID string
 }
 
 type ServiceInstanceRecord struct {
-	Name       string
-	GUID       string
-	SpaceGUID  string
-	SecretName string
-	Tags       []string
-	Type       string
-	CreatedAt  string
-	UpdatedAt  string
+	Name            string
+	GUID            string
+	SpaceGUID       string
+	SecretName      string
+	Tags            []string
+	Type            string
+	ServicePlanGUID string
+	LastOperation   *ServiceInstanceOperation
+	Labels          map[string]string
+	Annotations     map[string]string
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+// ServiceInstanceOperation is the last operation the broker performed on a managed service instance
+type ServiceInstanceOperation struct {
+	Type        string
+	State       string
+	Description string
 }
 
 func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInfo authorization.Info, message CreateServiceInstanceMessage) (ServiceInstanceRecord, error) {
@@ -88,15 +113,23 @@ func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInf
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	cfServiceInstance := message.toCFServiceInstance()
+	cfServiceInstance := message.toCFServiceInstance(r.rootNamespace)
 	err = userClient.Create(ctx, &cfServiceInstance)
 	if err != nil {
+		if quotaErr, ok := asQuotaExceededError(err); ok {
+			return ServiceInstanceRecord{}, quotaErr
+		}
 		if webhookError, ok := webhooks.WebhookErrorToValidationError(err); ok {
 			return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(err, webhookError.Error())
 		}
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	// the credentials of managed service instances are provided by the broker when they are bound
+	if cfServiceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+		return cfServiceInstanceToServiceInstanceRecord(cfServiceInstance), nil
+	}
+
 	secretObj := cfServiceInstanceToSecret(cfServiceInstance)
 	_, err = controllerutil.CreateOrPatch(ctx, userClient, &secretObj, func() error {
 		secretObj.StringData = message.Credentials
@@ -126,10 +159,21 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 		return []ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	labelSelector, err := labelSelectorListOption(message.LabelSelector)
+	if err != nil {
+		return []ServiceInstanceRecord{}, err
+	}
+
 	var filteredServiceInstances []servicesv1alpha1.CFServiceInstance
 	for ns := range nsList {
+		if !matchesFilter(ns, message.SpaceGuids) {
+			continue
+		}
+
 		serviceInstanceList := new(servicesv1alpha1.CFServiceInstanceList)
-		err = userClient.List(ctx, serviceInstanceList, client.InNamespace(ns))
+		err = listInChunks(ctx, userClient, serviceInstanceList, func() {
+			filteredServiceInstances = append(filteredServiceInstances, applyServiceInstanceListFilter(serviceInstanceList.Items, message)...)
+		}, client.InNamespace(ns), labelSelector)
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
@@ -139,7 +183,6 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 				apierrors.FromK8sError(err, ServiceInstanceResourceType),
 			)
 		}
-		filteredServiceInstances = append(filteredServiceInstances, applyServiceInstanceListFilter(serviceInstanceList.Items, message)...)
 	}
 
 	orderedServiceInstances := orderServiceInstances(filteredServiceInstances, message.OrderBy, message.DescendingOrder)
@@ -166,6 +209,56 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 	return cfServiceInstanceToServiceInstanceRecord(serviceInstance), nil
 }
 
+func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo authorization.Info, message PatchServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	baseServiceInstance := new(servicesv1alpha1.CFServiceInstance)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, baseServiceInstance)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	cfServiceInstance := baseServiceInstance.DeepCopy()
+	if message.Name != nil {
+		cfServiceInstance.Spec.Name = *message.Name
+	}
+	if message.Tags != nil {
+		cfServiceInstance.Spec.Tags = *message.Tags
+	}
+	message.MetadataPatch.apply(cfServiceInstance)
+
+	err = userClient.Patch(ctx, cfServiceInstance, client.MergeFrom(baseServiceInstance))
+	if err != nil {
+		if webhookError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(err, webhookError.Error())
+		}
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to patch service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	if message.Credentials != nil {
+		// the credentials replace the existing ones, rather than being merged with them
+		secretObj := cfServiceInstanceToSecret(*cfServiceInstance)
+		_, err = controllerutil.CreateOrPatch(ctx, userClient, &secretObj, func() error {
+			secretObj.Data = nil
+			secretObj.StringData = map[string]string{}
+			for key, value := range *message.Credentials {
+				secretObj.StringData[key] = value
+			}
+			updateSecretTypeFields(&secretObj)
+
+			return nil
+		})
+		if err != nil {
+			return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
+		}
+	}
+
+	return cfServiceInstanceToServiceInstanceRecord(*cfServiceInstance), nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -186,14 +279,34 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return nil
 }
 
-func (m CreateServiceInstanceMessage) toCFServiceInstance() servicesv1alpha1.CFServiceInstance {
+func (m CreateServiceInstanceMessage) toCFServiceInstance(rootNamespace string) servicesv1alpha1.CFServiceInstance {
 	guid := uuid.NewString()
+	if m.Type == servicesv1alpha1.ManagedType {
+		return servicesv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        guid,
+				Namespace:   m.SpaceGUID,
+				Labels:      withCFMetadata(nil, m.Labels),
+				Annotations: withCFMetadata(nil, m.Annotations),
+			},
+			Spec: servicesv1alpha1.CFServiceInstanceSpec{
+				Name: m.Name,
+				Type: servicesv1alpha1.ManagedType,
+				Tags: m.Tags,
+				ServicePlanRef: &corev1.ObjectReference{
+					Name:      m.ServicePlanGUID,
+					Namespace: rootNamespace,
+				},
+			},
+		}
+	}
+
 	return servicesv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
-			Labels:      m.Labels,
-			Annotations: m.Annotations,
+			Labels:      withCFMetadata(nil, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
 		},
 		Spec: servicesv1alpha1.CFServiceInstanceSpec{
 			Name:       m.Name,
@@ -207,16 +320,39 @@ func (m CreateServiceInstanceMessage) toCFServiceInstance() servicesv1alpha1.CFS
 func cfServiceInstanceToServiceInstanceRecord(cfServiceInstance servicesv1alpha1.CFServiceInstance) ServiceInstanceRecord {
 	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfServiceInstance.ObjectMeta)
 
-	return ServiceInstanceRecord{
-		Name:       cfServiceInstance.Spec.Name,
-		GUID:       cfServiceInstance.Name,
-		SpaceGUID:  cfServiceInstance.Namespace,
-		SecretName: cfServiceInstance.Spec.SecretName,
-		Tags:       cfServiceInstance.Spec.Tags,
-		Type:       string(cfServiceInstance.Spec.Type),
-		CreatedAt:  cfServiceInstance.CreationTimestamp.UTC().Format(TimestampFormat),
-		UpdatedAt:  updatedAtTime,
+	record := ServiceInstanceRecord{
+		Name:        cfServiceInstance.Spec.Name,
+		GUID:        cfServiceInstance.Name,
+		SpaceGUID:   cfServiceInstance.Namespace,
+		SecretName:  cfServiceInstance.Spec.SecretName,
+		Tags:        cfServiceInstance.Spec.Tags,
+		Type:        string(cfServiceInstance.Spec.Type),
+		Labels:      cfMetadata(cfServiceInstance.Labels),
+		Annotations: cfMetadata(cfServiceInstance.Annotations),
+		CreatedAt:   cfServiceInstance.CreationTimestamp.UTC().Format(TimestampFormat),
+		UpdatedAt:   updatedAtTime,
 	}
+
+	if cfServiceInstance.Spec.ServicePlanRef != nil {
+		record.ServicePlanGUID = cfServiceInstance.Spec.ServicePlanRef.Name
+	}
+
+	if cfServiceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+		// the broker has not been asked to provision the instance yet
+		record.LastOperation = &ServiceInstanceOperation{
+			Type:  servicesv1alpha1.CreateOperationType,
+			State: servicesv1alpha1.OperationStateInProgress,
+		}
+		if lastOperation := cfServiceInstance.Status.LastOperation; lastOperation != nil {
+			record.LastOperation = &ServiceInstanceOperation{
+				Type:        lastOperation.Type,
+				State:       lastOperation.State,
+				Description: lastOperation.Description,
+			}
+		}
+	}
+
+	return record
 }
 
 func cfServiceInstanceToSecret(cfServiceInstance servicesv1alpha1.CFServiceInstance) corev1.Secret {
@@ -31,7 +31,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 	BeforeEach(func() {
 		testCtx = context.Background()
-		serviceInstanceRepo = repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPerms)
+		serviceInstanceRepo = repositories.NewServiceInstanceRepo(rootNamespace, namespaceRetriever, userClientFactory, nsPerms)
 
 		org = createOrgAnchorAndNamespace(testCtx, rootNamespace, prefixedGUID("org"))
 		space = createSpaceAnchorAndNamespace(testCtx, org.Name, prefixedGUID("space1"))
@@ -156,6 +156,37 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 			})
 		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				serviceInstanceCreateMessage.Type = "managed"
+				serviceInstanceCreateMessage.ServicePlanGUID = "plan-guid"
+				serviceInstanceCreateMessage.Credentials = nil
+			})
+
+			It("creates a CFServiceInstance referencing the plan in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(createdServiceInstanceRecord.ServicePlanGUID).To(Equal("plan-guid"))
+				Expect(createdServiceInstanceRecord.SecretName).To(BeEmpty())
+				Expect(createdServiceInstanceRecord.LastOperation).To(Equal(&repositories.ServiceInstanceOperation{
+					Type:  "create",
+					State: "in progress",
+				}))
+
+				cfServiceInstance := new(servicesv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: createdServiceInstanceRecord.GUID, Namespace: space.Name}, cfServiceInstance)).To(Succeed())
+				Expect(cfServiceInstance.Spec.ServicePlanRef).To(Equal(&corev1.ObjectReference{
+					Name:      "plan-guid",
+					Namespace: rootNamespace,
+				}))
+			})
+
+			It("does not create a credentials secret", func() {
+				err := k8sClient.Get(testCtx, types.NamespacedName{Name: createdServiceInstanceRecord.GUID, Namespace: space.Name}, new(corev1.Secret))
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
 	})
 
 	Describe("ListServiceInstances", func() {
@@ -481,6 +512,78 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("PatchServiceInstance", func() {
+		var (
+			serviceInstance *servicesv1alpha1.CFServiceInstance
+			patchMessage    repositories.PatchServiceInstanceMessage
+			record          repositories.ServiceInstanceRecord
+			patchErr        error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+
+			newName := "new-name"
+			newTags := []string{"new-tag"}
+			newCredentials := map[string]string{"new-cred": "new-val"}
+			patchMessage = repositories.PatchServiceInstanceMessage{
+				GUID:        serviceInstance.Name,
+				SpaceGUID:   space.Name,
+				Name:        &newName,
+				Tags:        &newTags,
+				Credentials: &newCredentials,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, patchErr = serviceInstanceRepo.PatchServiceInstance(testCtx, authInfo, patchMessage)
+		})
+
+		When("the user has permissions to patch service instances", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the service instance", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.Tags).To(Equal([]string{"new-tag"}))
+
+				updated := new(servicesv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: serviceInstance.Name, Namespace: space.Name}, updated)).To(Succeed())
+				Expect(updated.Spec.Name).To(Equal("new-name"))
+				Expect(updated.Spec.Tags).To(Equal([]string{"new-tag"}))
+			})
+
+			It("replaces the credentials", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+
+				secret := new(corev1.Secret)
+				Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: record.SecretName, Namespace: space.Name}, secret)).To(Succeed())
+				Expect(secret.Data).To(Equal(map[string][]byte{
+					"new-cred": []byte("new-val"),
+					"type":     []byte("user-provided"),
+				}))
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					patchMessage.GUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(errors.As(patchErr, &apierrors.NotFoundError{})).To(BeTrue())
+				})
+			})
+		})
+
+		When("there are no permissions on service instances", func() {
+			It("returns a forbidden error", func() {
+				Expect(errors.As(patchErr, &apierrors.ForbiddenError{})).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *servicesv1alpha1.CFServiceInstance
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list
+
+const ServiceOfferingResourceType = "Service Offering"
+
+type ServiceOfferingRepo struct {
+	rootNamespace     string
+	userClientFactory UserK8sClientFactory
+}
+
+func NewServiceOfferingRepo(rootNamespace string, userClientFactory UserK8sClientFactory) *ServiceOfferingRepo {
+	return &ServiceOfferingRepo{
+		rootNamespace:     rootNamespace,
+		userClientFactory: userClientFactory,
+	}
+}
+
+type ListServiceOfferingsMessage struct {
+	Names              []string
+	ServiceBrokerGUIDs []string
+}
+
+type ServiceOfferingRecord struct {
+	GUID              string
+	Name              string
+	Description       string
+	Tags              []string
+	Bindable          bool
+	CatalogID         string
+	ServiceBrokerGUID string
+	Labels            map[string]string
+	Annotations       map[string]string
+	CreatedAt         string
+	UpdatedAt         string
+}
+
+func (r *ServiceOfferingRepo) GetServiceOffering(ctx context.Context, authInfo authorization.Info, guid string) (ServiceOfferingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceOfferingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceOffering := new(servicesv1alpha1.CFServiceOffering)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceOffering)
+	if err != nil {
+		return ServiceOfferingRecord{}, fmt.Errorf("failed to get service offering: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+
+	return cfServiceOfferingToRecord(cfServiceOffering), nil
+}
+
+func (r *ServiceOfferingRepo) ListServiceOfferings(ctx context.Context, authInfo authorization.Info, message ListServiceOfferingsMessage) ([]ServiceOfferingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceOfferingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceOfferingList := new(servicesv1alpha1.CFServiceOfferingList)
+	err = userClient.List(ctx, cfServiceOfferingList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []ServiceOfferingRecord{}, nil
+		}
+		return []ServiceOfferingRecord{}, fmt.Errorf("failed to list service offerings in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+
+	cfServiceOfferings := cfServiceOfferingList.Items
+	sort.Slice(cfServiceOfferings, func(i, j int) bool {
+		return cfServiceOfferings[i].CreationTimestamp.Before(&cfServiceOfferings[j].CreationTimestamp)
+	})
+
+	records := []ServiceOfferingRecord{}
+	for i := range cfServiceOfferings {
+		if matchesFilter(cfServiceOfferings[i].Spec.Name, message.Names) &&
+			matchesFilter(cfServiceOfferings[i].Spec.ServiceBrokerRef.Name, message.ServiceBrokerGUIDs) {
+			records = append(records, cfServiceOfferingToRecord(&cfServiceOfferings[i]))
+		}
+	}
+
+	return records, nil
+}
+
+func cfServiceOfferingToRecord(cfServiceOffering *servicesv1alpha1.CFServiceOffering) ServiceOfferingRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfServiceOffering.ObjectMeta)
+
+	return ServiceOfferingRecord{
+		GUID:              cfServiceOffering.Name,
+		Name:              cfServiceOffering.Spec.Name,
+		Description:       cfServiceOffering.Spec.Description,
+		Tags:              cfServiceOffering.Spec.Tags,
+		Bindable:          cfServiceOffering.Spec.Bindable,
+		CatalogID:         cfServiceOffering.Spec.BrokerCatalog.ID,
+		ServiceBrokerGUID: cfServiceOffering.Spec.ServiceBrokerRef.Name,
+		Labels:            cfMetadata(cfServiceOffering.Labels),
+		Annotations:       cfMetadata(cfServiceOffering.Annotations),
+		CreatedAt:         formatTimestamp(cfServiceOffering.CreationTimestamp),
+		UpdatedAt:         updatedAtTime,
+	}
+}
@@ -0,0 +1,92 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ServiceOfferingRepository", func() {
+	var (
+		testCtx             context.Context
+		serviceOfferingRepo *ServiceOfferingRepo
+		offeringGUID        string
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		serviceOfferingRepo = NewServiceOfferingRepo(rootNamespace, userClientFactory)
+
+		offeringGUID = generateGUID()
+		Expect(k8sClient.Create(testCtx, &servicesv1alpha1.CFServiceOffering{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      offeringGUID,
+				Namespace: rootNamespace,
+			},
+			Spec: servicesv1alpha1.CFServiceOfferingSpec{
+				Name:             "postgres",
+				Description:      "a database",
+				Tags:             []string{"sql"},
+				Bindable:         true,
+				BrokerCatalog:    servicesv1alpha1.ServiceBrokerCatalog{ID: "service-id"},
+				ServiceBrokerRef: corev1.LocalObjectReference{Name: "broker-guid"},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(testCtx, &servicesv1alpha1.CFServiceOffering{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: servicesv1alpha1.CFServiceOfferingSpec{
+				Name:             "redis",
+				BrokerCatalog:    servicesv1alpha1.ServiceBrokerCatalog{ID: "other-service-id"},
+				ServiceBrokerRef: corev1.LocalObjectReference{Name: "other-broker-guid"},
+			},
+		})).To(Succeed())
+	})
+
+	Describe("GetServiceOffering", func() {
+		It("returns the offering", func() {
+			record, err := serviceOfferingRepo.GetServiceOffering(testCtx, authInfo, offeringGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("postgres"))
+			Expect(record.Description).To(Equal("a database"))
+			Expect(record.Tags).To(ConsistOf("sql"))
+			Expect(record.Bindable).To(BeTrue())
+			Expect(record.CatalogID).To(Equal("service-id"))
+			Expect(record.ServiceBrokerGUID).To(Equal("broker-guid"))
+		})
+
+		When("the offering does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := serviceOfferingRepo.GetServiceOffering(testCtx, authInfo, "does-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListServiceOfferings", func() {
+		It("lists all the offerings", func() {
+			records, err := serviceOfferingRepo.ListServiceOfferings(testCtx, authInfo, ListServiceOfferingsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+		})
+
+		It("filters the offerings by broker", func() {
+			records, err := serviceOfferingRepo.ListServiceOfferings(testCtx, authInfo, ListServiceOfferingsMessage{
+				ServiceBrokerGUIDs: []string{"broker-guid"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(offeringGUID))
+		})
+	})
+})
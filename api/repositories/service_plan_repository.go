@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceplans,verbs=get;list
+
+const ServicePlanResourceType = "Service Plan"
+
+type ServicePlanRepo struct {
+	rootNamespace     string
+	userClientFactory UserK8sClientFactory
+}
+
+func NewServicePlanRepo(rootNamespace string, userClientFactory UserK8sClientFactory) *ServicePlanRepo {
+	return &ServicePlanRepo{
+		rootNamespace:     rootNamespace,
+		userClientFactory: userClientFactory,
+	}
+}
+
+type ListServicePlansMessage struct {
+	Names                []string
+	ServiceOfferingGUIDs []string
+}
+
+type ServicePlanRecord struct {
+	GUID                string
+	Name                string
+	Description         string
+	Free                bool
+	CatalogID           string
+	ServiceOfferingGUID string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           string
+	UpdatedAt           string
+}
+
+func (r *ServicePlanRepo) GetServicePlan(ctx context.Context, authInfo authorization.Info, guid string) (ServicePlanRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan := new(servicesv1alpha1.CFServicePlan)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServicePlan)
+	if err != nil {
+		return ServicePlanRecord{}, fmt.Errorf("failed to get service plan: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	return cfServicePlanToRecord(cfServicePlan), nil
+}
+
+func (r *ServicePlanRepo) ListServicePlans(ctx context.Context, authInfo authorization.Info, message ListServicePlansMessage) ([]ServicePlanRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlanList := new(servicesv1alpha1.CFServicePlanList)
+	err = userClient.List(ctx, cfServicePlanList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []ServicePlanRecord{}, nil
+		}
+		return []ServicePlanRecord{}, fmt.Errorf("failed to list service plans in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	cfServicePlans := cfServicePlanList.Items
+	sort.Slice(cfServicePlans, func(i, j int) bool {
+		return cfServicePlans[i].CreationTimestamp.Before(&cfServicePlans[j].CreationTimestamp)
+	})
+
+	records := []ServicePlanRecord{}
+	for i := range cfServicePlans {
+		if matchesFilter(cfServicePlans[i].Spec.Name, message.Names) &&
+			matchesFilter(cfServicePlans[i].Spec.ServiceOfferingRef.Name, message.ServiceOfferingGUIDs) {
+			records = append(records, cfServicePlanToRecord(&cfServicePlans[i]))
+		}
+	}
+
+	return records, nil
+}
+
+func cfServicePlanToRecord(cfServicePlan *servicesv1alpha1.CFServicePlan) ServicePlanRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfServicePlan.ObjectMeta)
+
+	return ServicePlanRecord{
+		GUID:                cfServicePlan.Name,
+		Name:                cfServicePlan.Spec.Name,
+		Description:         cfServicePlan.Spec.Description,
+		Free:                cfServicePlan.Spec.Free,
+		CatalogID:           cfServicePlan.Spec.BrokerCatalog.ID,
+		ServiceOfferingGUID: cfServicePlan.Spec.ServiceOfferingRef.Name,
+		Labels:              cfMetadata(cfServicePlan.Labels),
+		Annotations:         cfMetadata(cfServicePlan.Annotations),
+		CreatedAt:           formatTimestamp(cfServicePlan.CreationTimestamp),
+		UpdatedAt:           updatedAtTime,
+	}
+}
@@ -0,0 +1,84 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ServicePlanRepository", func() {
+	var (
+		testCtx         context.Context
+		servicePlanRepo *ServicePlanRepo
+		planGUID        string
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		servicePlanRepo = NewServicePlanRepo(rootNamespace, userClientFactory)
+
+		planGUID = generateGUID()
+		Expect(k8sClient.Create(testCtx, &servicesv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      planGUID,
+				Namespace: rootNamespace,
+			},
+			Spec: servicesv1alpha1.CFServicePlanSpec{
+				Name:               "small",
+				Description:        "a small database",
+				Free:               true,
+				BrokerCatalog:      servicesv1alpha1.ServiceBrokerCatalog{ID: "small-id"},
+				ServiceOfferingRef: corev1.LocalObjectReference{Name: "offering-guid"},
+			},
+		})).To(Succeed())
+		Expect(k8sClient.Create(testCtx, &servicesv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: servicesv1alpha1.CFServicePlanSpec{
+				Name:               "large",
+				BrokerCatalog:      servicesv1alpha1.ServiceBrokerCatalog{ID: "large-id"},
+				ServiceOfferingRef: corev1.LocalObjectReference{Name: "other-offering-guid"},
+			},
+		})).To(Succeed())
+	})
+
+	Describe("GetServicePlan", func() {
+		It("returns the plan", func() {
+			record, err := servicePlanRepo.GetServicePlan(testCtx, authInfo, planGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("small"))
+			Expect(record.Free).To(BeTrue())
+			Expect(record.CatalogID).To(Equal("small-id"))
+			Expect(record.ServiceOfferingGUID).To(Equal("offering-guid"))
+		})
+
+		When("the plan does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := servicePlanRepo.GetServicePlan(testCtx, authInfo, "does-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListServicePlans", func() {
+		It("filters the plans by offering and name", func() {
+			records, err := servicePlanRepo.ListServicePlans(testCtx, authInfo, ListServicePlansMessage{
+				Names:                []string{"small", "large"},
+				ServiceOfferingGUIDs: []string{"offering-guid"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(1))
+			Expect(records[0].GUID).To(Equal(planGUID))
+		})
+	})
+})
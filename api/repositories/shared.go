@@ -1,16 +1,78 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
+// listChunkSize bounds the number of objects a single list call asks the Kubernetes API for
+const listChunkSize = 500
+
+// listInChunks lists objects using the Limit and Continue list options, so that large lists are fetched from the
+// Kubernetes API in bounded chunks. processChunk is called each time a chunk has been read into list.
+func listInChunks(ctx context.Context, k8sClient client.Client, list client.ObjectList, processChunk func(), opts ...client.ListOption) error {
+	continueToken := ""
+	for {
+		chunkOpts := append([]client.ListOption{client.Limit(listChunkSize), client.Continue(continueToken)}, opts...)
+		if err := k8sClient.List(ctx, list, chunkOpts...); err != nil {
+			return err
+		}
+		processChunk()
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// Page selects one page of a list, numbered from 1, for repositories that paginate lists themselves
+type Page struct {
+	Number  int
+	PerPage int
+}
+
+// listPage lists the objects of one page using the Limit and Continue list options, so that the objects of a single
+// namespace are fetched from the Kubernetes API a page at a time rather than all at once. processPage is called once
+// the requested page has been read into list, and is not called when the page is past the end of the list. It returns
+// the total number of objects, which the Kubernetes API reports without listing the rest of the objects unless the
+// list is filtered by a selector.
+func listPage(ctx context.Context, k8sClient client.Client, list client.ObjectList, page Page, processPage func(), opts ...client.ListOption) (int, error) {
+	total := 0
+	continueToken := ""
+	for number := 1; ; number++ {
+		pageOpts := append([]client.ListOption{client.Limit(int64(page.PerPage)), client.Continue(continueToken)}, opts...)
+		if err := k8sClient.List(ctx, list, pageOpts...); err != nil {
+			return 0, err
+		}
+		if number == page.Number {
+			processPage()
+		}
+		total += meta.LenList(list)
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return total, nil
+		}
+
+		if remaining := list.GetRemainingItemCount(); number >= page.Number && remaining != nil {
+			return total + int(*remaining), nil
+		}
+	}
+}
+
 // getTimeLastUpdatedTimestamp takes the ObjectMeta from a CR and extracts the last updated time from its list of ManagedFields
 // Returns an error if the list is empty or the time could not be extracted
 func getTimeLastUpdatedTimestamp(metadata *metav1.ObjectMeta) (string, error) {
@@ -72,3 +134,29 @@ func matchesFilter(field string, filter []string) bool {
 
 	return false
 }
+
+// matchesAnyFilter is like matchesFilter for fields holding several values
+func matchesAnyFilter(fields []string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, field := range fields {
+		if matchesFilter(field, filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// asQuotaExceededError returns an unprocessable entity error carrying the CF message of the quota that the admission
+// webhooks found to be exceeded, if err is such an error
+func asQuotaExceededError(err error) (apierrors.UnprocessableEntityError, bool) {
+	validationError, ok := webhooks.WebhookErrorToValidationError(err)
+	if !ok || validationError.Type != workloads.QuotaExceededErrorType {
+		return apierrors.UnprocessableEntityError{}, false
+	}
+
+	return apierrors.NewUnprocessableEntityError(err, validationError.Message), true
+}
@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfsidecars,verbs=get;list;create;patch;delete
+
+const (
+	SidecarResourceType = "Sidecar"
+
+	SidecarOriginUser = "user"
+)
+
+type SidecarRepo struct {
+	namespaceRetriever NamespaceRetriever
+	userClientFactory  UserK8sClientFactory
+}
+
+func NewSidecarRepo(namespaceRetriever NamespaceRetriever, userClientFactory UserK8sClientFactory) *SidecarRepo {
+	return &SidecarRepo{
+		namespaceRetriever: namespaceRetriever,
+		userClientFactory:  userClientFactory,
+	}
+}
+
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+	Origin       string
+	AppGUID      string
+	SpaceGUID    string
+	CreatedAt    string
+	UpdatedAt    string
+}
+
+type CreateSidecarMessage struct {
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+	AppGUID      string
+	AppEtcdUID   types.UID
+	SpaceGUID    string
+}
+
+type PatchSidecarMessage struct {
+	GUID         string
+	SpaceGUID    string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type ListSidecarsMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Names        []string
+	ProcessTypes []string
+}
+
+type DeleteSidecarMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, sidecarGUID string) (SidecarRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, sidecarGUID, SidecarResourceType)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecar := new(workloadsv1alpha1.CFSidecar)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: sidecarGUID}, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get sidecar %q: %w", sidecarGUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToSidecarRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) ([]SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecars, err := r.listCFSidecars(ctx, userClient, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []SidecarRecord{}
+	for _, cfSidecar := range cfSidecars {
+		if matchesFilter(cfSidecar.Spec.Name, message.Names) && matchesAnyFilter(cfSidecar.Spec.ProcessTypes, message.ProcessTypes) {
+			records = append(records, cfSidecarToSidecarRecord(cfSidecar))
+		}
+	}
+
+	return records, nil
+}
+
+// CreateSidecar adds a sidecar to an app. Sidecar names are unique within an app.
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	existingSidecars, err := r.listCFSidecars(ctx, userClient, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return SidecarRecord{}, err
+	}
+	if err = checkSidecarNameIsUnique(existingSidecars, "", message.Name); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	cfSidecar := message.toCFSidecar()
+	err = userClient.Create(ctx, &cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to create sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToSidecarRecord(cfSidecar), nil
+}
+
+func (r *SidecarRepo) PatchSidecar(ctx context.Context, authInfo authorization.Info, message PatchSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecar := new(workloadsv1alpha1.CFSidecar)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get sidecar %q: %w", message.GUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	if message.Name != nil && *message.Name != cfSidecar.Spec.Name {
+		existingSidecars, listErr := r.listCFSidecars(ctx, userClient, message.SpaceGUID, cfSidecar.Spec.AppRef.Name)
+		if listErr != nil {
+			return SidecarRecord{}, listErr
+		}
+		if err = checkSidecarNameIsUnique(existingSidecars, cfSidecar.Name, *message.Name); err != nil {
+			return SidecarRecord{}, err
+		}
+	}
+
+	originalSidecar := cfSidecar.DeepCopy()
+	if message.Name != nil {
+		cfSidecar.Spec.Name = *message.Name
+	}
+	if message.Command != nil {
+		cfSidecar.Spec.Command = *message.Command
+	}
+	if message.ProcessTypes != nil {
+		cfSidecar.Spec.ProcessTypes = message.ProcessTypes
+	}
+	if message.MemoryMB != nil {
+		cfSidecar.Spec.MemoryMB = *message.MemoryMB
+	}
+
+	err = userClient.Patch(ctx, cfSidecar, client.MergeFrom(originalSidecar))
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to patch sidecar %q: %w", message.GUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToSidecarRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, message DeleteSidecarMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecar := &workloadsv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.GUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+
+	if err := userClient.Delete(ctx, cfSidecar); err != nil {
+		return fmt.Errorf("failed to delete sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return nil
+}
+
+// listCFSidecars returns the sidecars of an app, oldest first
+func (r *SidecarRepo) listCFSidecars(ctx context.Context, userClient client.Client, spaceGUID, appGUID string) ([]workloadsv1alpha1.CFSidecar, error) {
+	sidecarList := new(workloadsv1alpha1.CFSidecarList)
+	err := userClient.List(ctx, sidecarList, client.InNamespace(spaceGUID), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []workloadsv1alpha1.CFSidecar{}, nil
+		}
+		return nil, fmt.Errorf("failed to list sidecars of app %q: %w", appGUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	cfSidecars := sidecarList.Items
+	sort.Slice(cfSidecars, func(i, j int) bool {
+		return cfSidecars[i].CreationTimestamp.Before(&cfSidecars[j].CreationTimestamp)
+	})
+
+	return cfSidecars, nil
+}
+
+func checkSidecarNameIsUnique(cfSidecars []workloadsv1alpha1.CFSidecar, sidecarGUID, name string) error {
+	for _, cfSidecar := range cfSidecars {
+		if cfSidecar.Name != sidecarGUID && cfSidecar.Spec.Name == name {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Sidecar with name '%s' already exists for given app", name))
+		}
+	}
+
+	return nil
+}
+
+func (m CreateSidecarMessage) toCFSidecar() workloadsv1alpha1.CFSidecar {
+	return workloadsv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: m.SpaceGUID,
+			Labels: map[string]string{
+				workloadsv1alpha1.CFAppGUIDLabelKey: m.AppGUID,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: APIVersion,
+					Kind:       Kind,
+					Name:       m.AppGUID,
+					UID:        m.AppEtcdUID,
+				},
+			},
+		},
+		Spec: workloadsv1alpha1.CFSidecarSpec{
+			AppRef:       corev1.LocalObjectReference{Name: m.AppGUID},
+			Name:         m.Name,
+			Command:      m.Command,
+			ProcessTypes: m.ProcessTypes,
+			MemoryMB:     m.MemoryMB,
+		},
+	}
+}
+
+func cfSidecarToSidecarRecord(cfSidecar workloadsv1alpha1.CFSidecar) SidecarRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfSidecar.ObjectMeta)
+
+	return SidecarRecord{
+		GUID:         cfSidecar.Name,
+		Name:         cfSidecar.Spec.Name,
+		Command:      cfSidecar.Spec.Command,
+		ProcessTypes: cfSidecar.Spec.ProcessTypes,
+		MemoryMB:     cfSidecar.Spec.MemoryMB,
+		Origin:       SidecarOriginUser,
+		AppGUID:      cfSidecar.Spec.AppRef.Name,
+		SpaceGUID:    cfSidecar.Namespace,
+		CreatedAt:    formatTimestamp(cfSidecar.CreationTimestamp),
+		UpdatedAt:    updatedAtTime,
+	}
+}
@@ -0,0 +1,286 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("SidecarRepository", func() {
+	var (
+		ctx         context.Context
+		sidecarRepo *repositories.SidecarRepo
+		org         *hnsv1alpha2.SubnamespaceAnchor
+		space       *hnsv1alpha2.SubnamespaceAnchor
+		appGUID     string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		sidecarRepo = repositories.NewSidecarRepo(namespaceRetriever, userClientFactory)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		appGUID = prefixedGUID("app")
+	})
+
+	createSidecar := func(name string, processTypes ...string) *workloadsv1alpha1.CFSidecar {
+		cfSidecar := &workloadsv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("sidecar"),
+				Namespace: space.Name,
+				Labels:    map[string]string{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID},
+			},
+			Spec: workloadsv1alpha1.CFSidecarSpec{
+				AppRef:       corev1.LocalObjectReference{Name: appGUID},
+				Name:         name,
+				Command:      "./" + name,
+				ProcessTypes: processTypes,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSidecar)).To(Succeed())
+		return cfSidecar
+	}
+
+	Describe("GetSidecar", func() {
+		var (
+			cfSidecar     *workloadsv1alpha1.CFSidecar
+			sidecarRecord repositories.SidecarRecord
+			getErr        error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("apm-agent", "web")
+		})
+
+		JustBeforeEach(func() {
+			sidecarRecord, getErr = sidecarRepo.GetSidecar(ctx, authInfo, cfSidecar.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the sidecar", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(sidecarRecord.GUID).To(Equal(cfSidecar.Name))
+				Expect(sidecarRecord.Name).To(Equal("apm-agent"))
+				Expect(sidecarRecord.Command).To(Equal("./apm-agent"))
+				Expect(sidecarRecord.ProcessTypes).To(Equal([]string{"web"}))
+				Expect(sidecarRecord.Origin).To(Equal("user"))
+				Expect(sidecarRecord.AppGUID).To(Equal(appGUID))
+				Expect(sidecarRecord.SpaceGUID).To(Equal(space.Name))
+			})
+		})
+
+		When("the sidecar does not exist", func() {
+			JustBeforeEach(func() {
+				_, getErr = sidecarRepo.GetSidecar(ctx, authInfo, "i-dont-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			sidecarRecords []repositories.SidecarRecord
+			listErr        error
+			processTypes   []string
+		)
+
+		BeforeEach(func() {
+			createSidecar("apm-agent", "web", "worker")
+			createSidecar("proxy", "web")
+			processTypes = nil
+		})
+
+		JustBeforeEach(func() {
+			sidecarRecords, listErr = sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+				AppGUID:      appGUID,
+				SpaceGUID:    space.Name,
+				ProcessTypes: processTypes,
+			})
+		})
+
+		It("returns an empty list to users without access", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(sidecarRecords).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the sidecars of the app", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(sidecarRecords).To(HaveLen(2))
+			})
+
+			When("filtering by process type", func() {
+				BeforeEach(func() {
+					processTypes = []string{"worker"}
+				})
+
+				It("returns the sidecars of the process type", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(sidecarRecords).To(HaveLen(1))
+					Expect(sidecarRecords[0].Name).To(Equal("apm-agent"))
+				})
+			})
+		})
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			cfApp         *workloadsv1alpha1.CFApp
+			sidecarRecord repositories.SidecarRecord
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			cfApp = createAppWithGUID(space.Name, appGUID)
+		})
+
+		JustBeforeEach(func() {
+			sidecarRecord, createErr = sidecarRepo.CreateSidecar(ctx, authInfo, repositories.CreateSidecarMessage{
+				Name:         "apm-agent",
+				Command:      "./apm-agent",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+				AppGUID:      appGUID,
+				AppEtcdUID:   cfApp.UID,
+				SpaceGUID:    space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a CFSidecar for the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(sidecarRecord.Name).To(Equal("apm-agent"))
+				Expect(sidecarRecord.MemoryMB).To(BeEquivalentTo(64))
+
+				cfSidecar := new(workloadsv1alpha1.CFSidecar)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: sidecarRecord.GUID}, cfSidecar)).To(Succeed())
+				Expect(cfSidecar.Labels).To(HaveKeyWithValue(workloadsv1alpha1.CFAppGUIDLabelKey, appGUID))
+				Expect(cfSidecar.Spec.AppRef.Name).To(Equal(appGUID))
+				Expect(cfSidecar.Spec.Command).To(Equal("./apm-agent"))
+				Expect(cfSidecar.Spec.ProcessTypes).To(Equal([]string{"web"}))
+				Expect(cfSidecar.OwnerReferences).To(ConsistOf(HaveField("UID", cfApp.UID)))
+			})
+
+			When("the app already has a sidecar with the same name", func() {
+				BeforeEach(func() {
+					createSidecar("apm-agent", "worker")
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("PatchSidecar", func() {
+		var (
+			cfSidecar     *workloadsv1alpha1.CFSidecar
+			sidecarRecord repositories.SidecarRecord
+			patchErr      error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("apm-agent", "web")
+		})
+
+		JustBeforeEach(func() {
+			command := "./apm-agent --verbose"
+			sidecarRecord, patchErr = sidecarRepo.PatchSidecar(ctx, authInfo, repositories.PatchSidecarMessage{
+				GUID:         cfSidecar.Name,
+				SpaceGUID:    space.Name,
+				Command:      &command,
+				ProcessTypes: []string{"web", "worker"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("patches the sidecar", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(sidecarRecord.Name).To(Equal("apm-agent"))
+				Expect(sidecarRecord.Command).To(Equal("./apm-agent --verbose"))
+				Expect(sidecarRecord.ProcessTypes).To(Equal([]string{"web", "worker"}))
+
+				updatedSidecar := new(workloadsv1alpha1.CFSidecar)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSidecar), updatedSidecar)).To(Succeed())
+				Expect(updatedSidecar.Spec.Command).To(Equal("./apm-agent --verbose"))
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var (
+			cfSidecar *workloadsv1alpha1.CFSidecar
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("apm-agent", "web")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = sidecarRepo.DeleteSidecar(ctx, authInfo, repositories.DeleteSidecarMessage{
+				GUID:      cfSidecar.Name,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the sidecar", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSidecar), new(workloadsv1alpha1.CFSidecar))
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,374 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfspacequotas,verbs=get;list;create;patch;delete
+
+const (
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type SpaceQuotaRepo struct {
+	namespaceRetriever   NamespaceRetriever
+	privilegedClient     client.Client
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewSpaceQuotaRepo(
+	namespaceRetriever NamespaceRetriever,
+	privilegedClient client.Client,
+	userClientFactory UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		namespaceRetriever:   namespaceRetriever,
+		privilegedClient:     privilegedClient,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+// SpaceQuotaRecord is a quota of an org that can be applied to the spaces of the org
+type SpaceQuotaRecord struct {
+	GUID       string
+	Name       string
+	Limits     QuotaLimits
+	OrgGUID    string
+	SpaceGUIDs []string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name       string
+	Limits     QuotaLimits
+	OrgGUID    string
+	SpaceGUIDs []string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+}
+
+type PatchSpaceQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.checkNameIsFree(ctx, userClient, message.OrgGUID, message.Name); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.checkSpacesExist(ctx, message.OrgGUID, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	cfSpaceQuota := &workloadsv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.OrgGUID,
+		},
+		Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+			Name:   message.Name,
+			Limits: message.Limits.toCRDLimits(),
+		},
+	}
+	err = userClient.Create(ctx, cfSpaceQuota)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to create space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	if len(message.SpaceGUIDs) > 0 {
+		return r.applySpaceQuota(ctx, userClient, cfSpaceQuota, message.SpaceGUIDs)
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuotas := []workloadsv1alpha1.CFSpaceQuota{}
+	for ns := range nsList {
+		if !matchesFilter(ns, message.OrgGUIDs) {
+			continue
+		}
+
+		cfSpaceQuotaList := new(workloadsv1alpha1.CFSpaceQuotaList)
+		err = userClient.List(ctx, cfSpaceQuotaList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []SpaceQuotaRecord{}, fmt.Errorf("failed to list space quotas in namespace %s: %w", ns, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+
+		cfSpaceQuotas = append(cfSpaceQuotas, cfSpaceQuotaList.Items...)
+	}
+
+	sort.Slice(cfSpaceQuotas, func(i, j int) bool {
+		return cfSpaceQuotas[i].CreationTimestamp.Before(&cfSpaceQuotas[j].CreationTimestamp)
+	})
+
+	records := []SpaceQuotaRecord{}
+	for i := range cfSpaceQuotas {
+		if matchesFilter(cfSpaceQuotas[i].Name, message.GUIDs) &&
+			matchesFilter(cfSpaceQuotas[i].Spec.Name, message.Names) &&
+			matchesAnyFilter(cfSpaceQuotas[i].Spec.Spaces, message.SpaceGUIDs) {
+			records = append(records, cfSpaceQuotaToRecord(&cfSpaceQuotas[i]))
+		}
+	}
+
+	return records, nil
+}
+
+func (r *SpaceQuotaRepo) PatchSpaceQuota(ctx context.Context, authInfo authorization.Info, message PatchSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if message.Name != nil && *message.Name != cfSpaceQuota.Spec.Name {
+		if err = r.checkNameIsFree(ctx, userClient, cfSpaceQuota.Namespace, *message.Name); err != nil {
+			return SpaceQuotaRecord{}, err
+		}
+	}
+
+	updatedSpaceQuota := cfSpaceQuota.DeepCopy()
+	if message.Name != nil {
+		updatedSpaceQuota.Spec.Name = *message.Name
+	}
+	message.Limits.apply(&updatedSpaceQuota.Spec.Limits)
+
+	err = userClient.Patch(ctx, updatedSpaceQuota, client.MergeFrom(cfSpaceQuota))
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to patch space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(updatedSpaceQuota), nil
+}
+
+// DeleteSpaceQuota deletes a quota that is not applied to any space
+func (r *SpaceQuotaRepo) DeleteSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	if len(cfSpaceQuota.Spec.Spaces) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			errors.New("space quota is applied to spaces"),
+			"This quota is applied to one or more spaces. Remove this quota from all spaces before deleting.",
+		)
+	}
+
+	err = userClient.Delete(ctx, cfSpaceQuota)
+	if err != nil {
+		return fmt.Errorf("failed to delete space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+// ApplySpaceQuota applies the quota to spaces of its org, in place of any other quota they had
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, guid string, spaceGUIDs []string) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.checkSpacesExist(ctx, cfSpaceQuota.Namespace, spaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return r.applySpaceQuota(ctx, userClient, cfSpaceQuota, spaceGUIDs)
+}
+
+// RemoveSpaceQuota removes the quota from the space. Removing it from a space it is not applied to does nothing.
+func (r *SpaceQuotaRepo) RemoveSpaceQuota(ctx context.Context, authInfo authorization.Info, guid, spaceGUID string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	if !containsValue(cfSpaceQuota.Spec.Spaces, spaceGUID) {
+		return nil
+	}
+
+	updatedSpaceQuota := cfSpaceQuota.DeepCopy()
+	updatedSpaceQuota.Spec.Spaces = withoutValues(updatedSpaceQuota.Spec.Spaces, []string{spaceGUID})
+	err = userClient.Patch(ctx, updatedSpaceQuota, client.MergeFrom(cfSpaceQuota))
+	if err != nil {
+		return fmt.Errorf("failed to remove space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) applySpaceQuota(ctx context.Context, userClient client.Client, cfSpaceQuota *workloadsv1alpha1.CFSpaceQuota, spaceGUIDs []string) (SpaceQuotaRecord, error) {
+	cfSpaceQuotaList := new(workloadsv1alpha1.CFSpaceQuotaList)
+	err := userClient.List(ctx, cfSpaceQuotaList, client.InNamespace(cfSpaceQuota.Namespace))
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	for i := range cfSpaceQuotaList.Items {
+		otherQuota := &cfSpaceQuotaList.Items[i]
+		if otherQuota.Name == cfSpaceQuota.Name {
+			continue
+		}
+
+		remainingSpaces := withoutValues(otherQuota.Spec.Spaces, spaceGUIDs)
+		if len(remainingSpaces) == len(otherQuota.Spec.Spaces) {
+			continue
+		}
+
+		updatedQuota := otherQuota.DeepCopy()
+		updatedQuota.Spec.Spaces = remainingSpaces
+		err = userClient.Patch(ctx, updatedQuota, client.MergeFrom(otherQuota))
+		if err != nil {
+			return SpaceQuotaRecord{}, fmt.Errorf("failed to remove spaces from space quota %s: %w", otherQuota.Name, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+	}
+
+	updatedSpaceQuota := cfSpaceQuota.DeepCopy()
+	updatedSpaceQuota.Spec.Spaces = withValues(updatedSpaceQuota.Spec.Spaces, spaceGUIDs)
+	err = userClient.Patch(ctx, updatedSpaceQuota, client.MergeFrom(cfSpaceQuota))
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to apply space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(updatedSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) getSpaceQuota(ctx context.Context, userClient client.Client, guid string) (*workloadsv1alpha1.CFSpaceQuota, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SpaceQuotaResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for space quota: %w", err)
+	}
+
+	cfSpaceQuota := new(workloadsv1alpha1.CFSpaceQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfSpaceQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuota, nil
+}
+
+func (r *SpaceQuotaRepo) checkNameIsFree(ctx context.Context, userClient client.Client, orgGUID, name string) error {
+	cfSpaceQuotaList := new(workloadsv1alpha1.CFSpaceQuotaList)
+	err := userClient.List(ctx, cfSpaceQuotaList, client.InNamespace(orgGUID))
+	if err != nil {
+		return fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	for _, cfSpaceQuota := range cfSpaceQuotaList.Items {
+		if cfSpaceQuota.Spec.Name == name {
+			return apierrors.NewUniquenessError(
+				fmt.Errorf("space quota %q already exists in org %s", name, orgGUID),
+				fmt.Sprintf("Space Quota '%s' already exists.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkSpacesExist looks the spaces up with the privileged client, as org managers cannot get the space anchors
+func (r *SpaceQuotaRepo) checkSpacesExist(ctx context.Context, orgGUID string, spaceGUIDs []string) error {
+	missingSpaces, err := missingAnchors(ctx, r.privilegedClient, orgGUID, spaceGUIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(missingSpaces) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("spaces %v do not exist in org %s", missingSpaces, orgGUID),
+			fmt.Sprintf("Spaces with guids [%s] do not exist within the organization, or you do not have access to them.", quoteGUIDs(missingSpaces)),
+		)
+	}
+
+	return nil
+}
+
+func cfSpaceQuotaToRecord(cfSpaceQuota *workloadsv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfSpaceQuota.ObjectMeta)
+
+	return SpaceQuotaRecord{
+		GUID:       cfSpaceQuota.Name,
+		Name:       cfSpaceQuota.Spec.Name,
+		Limits:     quotaLimitsFromCRD(cfSpaceQuota.Spec.Limits),
+		OrgGUID:    cfSpaceQuota.Namespace,
+		SpaceGUIDs: append([]string{}, cfSpaceQuota.Spec.Spaces...),
+		CreatedAt:  formatTimestamp(cfSpaceQuota.CreationTimestamp),
+		UpdatedAt:  updatedAtTime,
+	}
+}
@@ -0,0 +1,209 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("SpaceQuotaRepository", func() {
+	var (
+		ctx            context.Context
+		spaceQuotaRepo *repositories.SpaceQuotaRepo
+		org            *hnsv1alpha2.SubnamespaceAnchor
+		space          *hnsv1alpha2.SubnamespaceAnchor
+	)
+
+	int64Ptr := func(value int64) *int64 {
+		return &value
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		spaceQuotaRepo = repositories.NewSpaceQuotaRepo(namespaceRetriever, k8sClient, userClientFactory, nsPerms)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+	})
+
+	createSpaceQuota := func(name string, spaceGUIDs ...string) *workloadsv1alpha1.CFSpaceQuota {
+		cfSpaceQuota := &workloadsv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("space-quota"),
+				Namespace: org.Name,
+			},
+			Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+				Name:   name,
+				Limits: workloadsv1alpha1.QuotaLimits{InstanceMemoryMB: int64Ptr(512)},
+				Spaces: spaceGUIDs,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+		return cfSpaceQuota
+	}
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			message          repositories.CreateSpaceQuotaMessage
+			spaceQuotaRecord repositories.SpaceQuotaRecord
+			createErr        error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSpaceQuotaMessage{
+				Name:       "my-quota",
+				Limits:     repositories.QuotaLimits{Routes: int64Ptr(3)},
+				OrgGUID:    org.Name,
+				SpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecord, createErr = spaceQuotaRepo.CreateSpaceQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			})
+
+			It("creates the quota in the org, applied to the spaces", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(spaceQuotaRecord.Name).To(Equal("my-quota"))
+				Expect(spaceQuotaRecord.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+				Expect(spaceQuotaRecord.Limits).To(Equal(repositories.QuotaLimits{Routes: int64Ptr(3)}))
+
+				cfSpaceQuota := new(workloadsv1alpha1.CFSpaceQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: org.Name, Name: spaceQuotaRecord.GUID}, cfSpaceQuota)).To(Succeed())
+				Expect(*cfSpaceQuota.Spec.Limits.Routes).To(Equal(3))
+				Expect(cfSpaceQuota.Spec.Spaces).To(ConsistOf(space.Name))
+			})
+
+			When("a quota with the same name exists in the org", func() {
+				BeforeEach(func() {
+					createSpaceQuota("my-quota")
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UniquenessError{}))
+				})
+			})
+
+			When("a space is not in the org", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+					otherSpace := createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("other-space"))
+					message.SpaceGUIDs = []string{otherSpace.Name}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			cfSpaceQuota *workloadsv1alpha1.CFSpaceQuota
+			records      []repositories.SpaceQuotaRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota("my-quota", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = spaceQuotaRepo.ListSpaceQuotas(ctx, authInfo, repositories.ListSpaceQuotasMessage{
+				SpaceGUIDs: []string{space.Name},
+			})
+		})
+
+		It("returns an empty list", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("lists the quotas of the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(1))
+				Expect(records[0].GUID).To(Equal(cfSpaceQuota.Name))
+				Expect(records[0].OrgGUID).To(Equal(org.Name))
+				Expect(records[0].Limits.InstanceMemoryMB).To(Equal(int64Ptr(512)))
+			})
+		})
+	})
+
+	Describe("DeleteSpaceQuota", func() {
+		var (
+			cfSpaceQuota *workloadsv1alpha1.CFSpaceQuota
+			deleteErr    error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			cfSpaceQuota = createSpaceQuota("my-quota")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = spaceQuotaRepo.DeleteSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("deletes the quota", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)
+			Expect(err).To(HaveOccurred())
+		})
+
+		When("the quota is applied to a space", func() {
+			BeforeEach(func() {
+				cfSpaceQuota = createSpaceQuota("applied-quota", space.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("RemoveSpaceQuota", func() {
+		var (
+			cfSpaceQuota *workloadsv1alpha1.CFSpaceQuota
+			removeErr    error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			cfSpaceQuota = createSpaceQuota("my-quota", space.Name)
+		})
+
+		JustBeforeEach(func() {
+			removeErr = spaceQuotaRepo.RemoveSpaceQuota(ctx, authInfo, cfSpaceQuota.Name, space.Name)
+		})
+
+		It("removes the space from the quota", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+			Expect(cfSpaceQuota.Spec.Spaces).To(BeEmpty())
+		})
+	})
+})
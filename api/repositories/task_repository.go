@@ -0,0 +1,288 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cftasks,verbs=get;list;watch;create;patch
+
+const (
+	TaskResourceType = "Task"
+
+	TaskStatePending   = "PENDING"
+	TaskStateRunning   = "RUNNING"
+	TaskStateSucceeded = "SUCCEEDED"
+	TaskStateFailed    = "FAILED"
+	TaskStateCanceling = "CANCELING"
+)
+
+type TaskRepo struct {
+	namespaceRetriever   NamespaceRetriever
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	timeout              time.Duration
+}
+
+func NewTaskRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	timeout time.Duration,
+) *TaskRepo {
+	return &TaskRepo{
+		namespaceRetriever:   namespaceRetriever,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		timeout:              timeout,
+	}
+}
+
+type TaskRecord struct {
+	GUID          string
+	Name          string
+	Command       string
+	AppGUID       string
+	SpaceGUID     string
+	DropletGUID   string
+	SequenceID    int64
+	State         string
+	FailureReason string
+	MemoryMB      int64
+	DiskMB        int64
+	Labels        map[string]string
+	Annotations   map[string]string
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+type CreateTaskMessage struct {
+	Name        string
+	Command     string
+	AppGUID     string
+	SpaceGUID   string
+	MemoryMB    int64
+	DiskMB      int64
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type ListTasksMessage struct {
+	AppGUIDs []string
+	Names    []string
+	States   []string
+}
+
+func (r *TaskRepo) CreateTask(ctx context.Context, authInfo authorization.Info, message CreateTaskMessage) (TaskRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfTask := message.toCFTask()
+	err = userClient.Create(ctx, &cfTask)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to create task: %w", apierrors.FromK8sError(err, TaskResourceType))
+	}
+
+	initializedTask, err := r.awaitInitialization(ctx, userClient, cfTask)
+	if err != nil {
+		return TaskRecord{}, err
+	}
+
+	return cfTaskToTaskRecord(*initializedTask), nil
+}
+
+// awaitInitialization waits for the controller to give the task its sequence ID, which is part of the create response
+func (r *TaskRepo) awaitInitialization(ctx context.Context, userClient client.WithWatch, cfTask workloadsv1alpha1.CFTask) (*workloadsv1alpha1.CFTask, error) {
+	timeoutCtx, cancelFn := context.WithTimeout(ctx, r.timeout)
+	defer cancelFn()
+
+	watch, err := userClient.Watch(timeoutCtx, &workloadsv1alpha1.CFTaskList{},
+		client.InNamespace(cfTask.Namespace),
+		client.MatchingFields{"metadata.name": cfTask.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up watch on tasks: %w", apierrors.FromK8sError(err, TaskResourceType))
+	}
+	defer watch.Stop()
+
+	for res := range watch.ResultChan() {
+		updatedTask, ok := res.Object.(*workloadsv1alpha1.CFTask)
+		if !ok {
+			// should never happen, but avoids panic above
+			continue
+		}
+		if updatedTask.Status.SequenceID != 0 {
+			return updatedTask, nil
+		}
+	}
+
+	return nil, fmt.Errorf("task %s/%s was not initialized within timeout period %d ms", cfTask.Namespace, cfTask.Name, r.timeout.Milliseconds())
+}
+
+func (r *TaskRepo) GetTask(ctx context.Context, authInfo authorization.Info, taskGUID string) (TaskRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, taskGUID, TaskResourceType)
+	if err != nil {
+		return TaskRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	var cfTask workloadsv1alpha1.CFTask
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: taskGUID}, &cfTask)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to get task %q: %w", taskGUID, apierrors.FromK8sError(err, TaskResourceType))
+	}
+
+	return cfTaskToTaskRecord(cfTask), nil
+}
+
+func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, message ListTasksMessage) ([]TaskRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	taskList := &workloadsv1alpha1.CFTaskList{}
+	var matches []workloadsv1alpha1.CFTask
+	for ns := range nsList {
+		err = listInChunks(ctx, userClient, taskList, func() {
+			for _, cfTask := range taskList.Items {
+				if matchesFilter(cfTask.Spec.AppRef.Name, message.AppGUIDs) &&
+					matchesFilter(cfTask.Spec.Name, message.Names) &&
+					matchesFilter(taskState(cfTask), message.States) {
+					matches = append(matches, cfTask)
+				}
+			}
+		}, client.InNamespace(ns))
+		if err != nil {
+			return nil, apierrors.FromK8sError(err, TaskResourceType)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreationTimestamp.Before(&matches[j].CreationTimestamp)
+	})
+
+	records := make([]TaskRecord, 0, len(matches))
+	for _, cfTask := range matches {
+		records = append(records, cfTaskToTaskRecord(cfTask))
+	}
+
+	return records, nil
+}
+
+func (r *TaskRepo) CancelTask(ctx context.Context, authInfo authorization.Info, taskGUID string) (TaskRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, taskGUID, TaskResourceType)
+	if err != nil {
+		return TaskRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfTask := new(workloadsv1alpha1.CFTask)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: taskGUID}, cfTask)
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to get task %q: %w", taskGUID, apierrors.FromK8sError(err, TaskResourceType))
+	}
+
+	originalTask := cfTask.DeepCopy()
+	cfTask.Spec.Canceled = true
+	err = userClient.Patch(ctx, cfTask, client.MergeFrom(originalTask))
+	if err != nil {
+		return TaskRecord{}, fmt.Errorf("failed to cancel task %q: %w", taskGUID, apierrors.FromK8sError(err, TaskResourceType))
+	}
+
+	return cfTaskToTaskRecord(*cfTask), nil
+}
+
+func (m CreateTaskMessage) toCFTask() workloadsv1alpha1.CFTask {
+	guid := uuid.NewString()
+
+	// like CF, a task without a name gets a random one
+	name := m.Name
+	if name == "" {
+		name = guid[:8]
+	}
+
+	return workloadsv1alpha1.CFTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: m.SpaceGUID,
+			Labels: withCFMetadata(map[string]string{
+				workloadsv1alpha1.CFAppGUIDLabelKey: m.AppGUID,
+			}, m.Labels),
+			Annotations: withCFMetadata(nil, m.Annotations),
+		},
+		Spec: workloadsv1alpha1.CFTaskSpec{
+			Name:        name,
+			Command:     m.Command,
+			AppRef:      corev1.LocalObjectReference{Name: m.AppGUID},
+			MemoryMB:    m.MemoryMB,
+			DiskQuotaMB: m.DiskMB,
+		},
+	}
+}
+
+// taskState is the CF state of the task. A canceled task is CANCELING until the controller has stopped it.
+func taskState(cfTask workloadsv1alpha1.CFTask) string {
+	switch cfTask.Status.State {
+	case workloadsv1alpha1.TaskSucceededState, workloadsv1alpha1.TaskFailedState:
+		return string(cfTask.Status.State)
+	}
+
+	if cfTask.Spec.Canceled {
+		return TaskStateCanceling
+	}
+
+	if cfTask.Status.State == "" {
+		return TaskStatePending
+	}
+
+	return string(cfTask.Status.State)
+}
+
+func cfTaskToTaskRecord(cfTask workloadsv1alpha1.CFTask) TaskRecord {
+	updatedAtTime, _ := getTimeLastUpdatedTimestamp(&cfTask.ObjectMeta)
+
+	return TaskRecord{
+		GUID:          cfTask.Name,
+		Name:          cfTask.Spec.Name,
+		Command:       cfTask.Spec.Command,
+		AppGUID:       cfTask.Spec.AppRef.Name,
+		SpaceGUID:     cfTask.Namespace,
+		DropletGUID:   cfTask.Status.DropletRef.Name,
+		SequenceID:    cfTask.Status.SequenceID,
+		State:         taskState(cfTask),
+		FailureReason: cfTask.Status.FailureReason,
+		MemoryMB:      cfTask.Spec.MemoryMB,
+		DiskMB:        cfTask.Spec.DiskQuotaMB,
+		Labels:        cfMetadata(cfTask.Labels),
+		Annotations:   cfMetadata(cfTask.Annotations),
+		CreatedAt:     formatTimestamp(cfTask.CreationTimestamp),
+		UpdatedAt:     updatedAtTime,
+	}
+}
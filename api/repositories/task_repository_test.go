@@ -0,0 +1,278 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("TaskRepository", func() {
+	var (
+		ctx      context.Context
+		taskRepo *repositories.TaskRepo
+		org      *hnsv1alpha2.SubnamespaceAnchor
+		space    *hnsv1alpha2.SubnamespaceAnchor
+		appGUID  string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		taskRepo = repositories.NewTaskRepo(namespaceRetriever, userClientFactory, nsPerms, 2*time.Second)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		appGUID = prefixedGUID("app")
+	})
+
+	createTask := func(namespace, name string, status workloadsv1alpha1.CFTaskStatus) *workloadsv1alpha1.CFTask {
+		cfTask := &workloadsv1alpha1.CFTask{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("task"),
+				Namespace: namespace,
+			},
+			Spec: workloadsv1alpha1.CFTaskSpec{
+				Name:        name,
+				Command:     "echo hello",
+				AppRef:      corev1.LocalObjectReference{Name: appGUID},
+				MemoryMB:    256,
+				DiskQuotaMB: 512,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfTask)).To(Succeed())
+
+		cfTask.Status = status
+		Expect(k8sClient.Status().Update(ctx, cfTask)).To(Succeed())
+
+		return cfTask
+	}
+
+	Describe("CreateTask", func() {
+		var (
+			createMessage repositories.CreateTaskMessage
+			taskRecord    repositories.TaskRecord
+			createErr     error
+		)
+
+		BeforeEach(func() {
+			createMessage = repositories.CreateTaskMessage{
+				Command:   "echo hello",
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+				MemoryMB:  256,
+				DiskMB:    512,
+				Labels:    map[string]string{"env": "prod"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			taskRecord, createErr = taskRepo.CreateTask(ctx, authInfo, createMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			When("the controller initializes the task", func() {
+				BeforeEach(func() {
+					// stands in for the task controller, which is not running in this suite
+					go func() {
+						defer GinkgoRecover()
+
+						var cfTask workloadsv1alpha1.CFTask
+						Eventually(func() error {
+							var taskList workloadsv1alpha1.CFTaskList
+							if err := k8sClient.List(ctx, &taskList, client.InNamespace(space.Name)); err != nil {
+								return err
+							}
+							if len(taskList.Items) == 0 {
+								return apierrors.NewNotFoundError(nil, repositories.TaskResourceType)
+							}
+							cfTask = taskList.Items[0]
+							return nil
+						}).Should(Succeed())
+
+						cfTask.Status.SequenceID = 1
+						cfTask.Status.State = workloadsv1alpha1.TaskPendingState
+						cfTask.Status.DropletRef = corev1.LocalObjectReference{Name: "droplet-guid"}
+						Expect(k8sClient.Status().Update(ctx, &cfTask)).To(Succeed())
+					}()
+				})
+
+				It("returns the initialized task", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(taskRecord.GUID).NotTo(BeEmpty())
+					Expect(taskRecord.Name).To(Equal(taskRecord.GUID[:8]))
+					Expect(taskRecord.Command).To(Equal("echo hello"))
+					Expect(taskRecord.AppGUID).To(Equal(appGUID))
+					Expect(taskRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(taskRecord.SequenceID).To(BeEquivalentTo(1))
+					Expect(taskRecord.State).To(Equal(repositories.TaskStatePending))
+					Expect(taskRecord.DropletGUID).To(Equal("droplet-guid"))
+					Expect(taskRecord.MemoryMB).To(BeEquivalentTo(256))
+					Expect(taskRecord.DiskMB).To(BeEquivalentTo(512))
+					Expect(taskRecord.Labels).To(Equal(map[string]string{"env": "prod"}))
+				})
+
+				It("labels the task with its app", func() {
+					var cfTask workloadsv1alpha1.CFTask
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: taskRecord.GUID}, &cfTask)).To(Succeed())
+					Expect(cfTask.Labels).To(HaveKeyWithValue(workloadsv1alpha1.CFAppGUIDLabelKey, appGUID))
+				})
+			})
+
+			When("the task is never initialized", func() {
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("was not initialized within timeout period")))
+				})
+			})
+		})
+	})
+
+	Describe("GetTask", func() {
+		var (
+			cfTask     *workloadsv1alpha1.CFTask
+			taskRecord repositories.TaskRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			cfTask = createTask(space.Name, "migrate", workloadsv1alpha1.CFTaskStatus{
+				SequenceID: 7,
+				State:      workloadsv1alpha1.TaskRunningState,
+			})
+		})
+
+		JustBeforeEach(func() {
+			taskRecord, getErr = taskRepo.GetTask(ctx, authInfo, cfTask.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the task", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(taskRecord.Name).To(Equal("migrate"))
+				Expect(taskRecord.SequenceID).To(BeEquivalentTo(7))
+				Expect(taskRecord.State).To(Equal(repositories.TaskStateRunning))
+			})
+		})
+	})
+
+	Describe("ListTasks", func() {
+		var (
+			runningTask   *workloadsv1alpha1.CFTask
+			succeededTask *workloadsv1alpha1.CFTask
+			message       repositories.ListTasksMessage
+			taskRecords   []repositories.TaskRecord
+			listErr       error
+		)
+
+		BeforeEach(func() {
+			runningTask = createTask(space.Name, "running", workloadsv1alpha1.CFTaskStatus{SequenceID: 1, State: workloadsv1alpha1.TaskRunningState})
+			succeededTask = createTask(space.Name, "succeeded", workloadsv1alpha1.CFTaskStatus{SequenceID: 2, State: workloadsv1alpha1.TaskSucceededState})
+			otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+			createTask(otherSpace.Name, "invisible", workloadsv1alpha1.CFTaskStatus{SequenceID: 1})
+			message = repositories.ListTasksMessage{}
+		})
+
+		JustBeforeEach(func() {
+			taskRecords, listErr = taskRepo.ListTasks(ctx, authInfo, message)
+		})
+
+		It("returns no tasks", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(taskRecords).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the tasks in the spaces the user can see", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(taskRecords).To(ConsistOf(
+					HaveField("GUID", runningTask.Name),
+					HaveField("GUID", succeededTask.Name),
+				))
+			})
+
+			When("filtering by state", func() {
+				BeforeEach(func() {
+					message.States = []string{repositories.TaskStateSucceeded}
+				})
+
+				It("returns the tasks in that state", func() {
+					Expect(taskRecords).To(ConsistOf(HaveField("GUID", succeededTask.Name)))
+				})
+			})
+
+			When("filtering by app", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{"some-other-app"}
+				})
+
+				It("returns only the tasks of that app", func() {
+					Expect(taskRecords).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("CancelTask", func() {
+		var (
+			cfTask     *workloadsv1alpha1.CFTask
+			taskRecord repositories.TaskRecord
+			cancelErr  error
+		)
+
+		BeforeEach(func() {
+			cfTask = createTask(space.Name, "sleep", workloadsv1alpha1.CFTaskStatus{SequenceID: 1, State: workloadsv1alpha1.TaskRunningState})
+		})
+
+		JustBeforeEach(func() {
+			taskRecord, cancelErr = taskRepo.CancelTask(ctx, authInfo, cfTask.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("requests the cancellation of the task", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(taskRecord.State).To(Equal(repositories.TaskStateCanceling))
+
+				var updatedTask workloadsv1alpha1.CFTask
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfTask), &updatedTask)).To(Succeed())
+				Expect(updatedTask.Spec.Canceled).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfusers,verbs=get;list;create;delete
+
+const (
+	UserResourceType = "User"
+	cfUserNamePrefix = "cf-user-"
+)
+
+// UserRecord is a user known by the subject of its roles or by its CFUser. The GUID of a user is its username, as
+// in the user relationship of its roles.
+type UserRecord struct {
+	GUID             string
+	Username         string
+	PresentationName string
+	Origin           string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type CreateUserMessage struct {
+	Username string
+	Origin   string
+}
+
+type ListUsersMessage struct {
+	GUIDs     []string
+	Usernames []string
+	Origins   []string
+}
+
+type UserRepo struct {
+	rootNamespace        string
+	privilegedClient     client.Client
+	userClientFactory    UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+}
+
+func NewUserRepo(
+	rootNamespace string,
+	privilegedClient client.Client,
+	userClientFactory UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+) *UserRepo {
+	return &UserRepo{
+		rootNamespace:        rootNamespace,
+		privilegedClient:     privilegedClient,
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+	}
+}
+
+// ListUsers lists the users with roles in the orgs and spaces the user can see. Users that can list the CFUsers in
+// the root namespace, such as admins, see every user.
+func (r *UserRepo) ListUsers(ctx context.Context, authInfo authorization.Info, message ListUsersMessage) ([]UserRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []UserRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	seesAllUsers := true
+	err = userClient.List(ctx, &workloadsv1alpha1.CFUserList{}, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if !k8serrors.IsForbidden(err) {
+			return []UserRecord{}, fmt.Errorf("failed to list users: %w", apierrors.FromK8sError(err, UserResourceType))
+		}
+		seesAllUsers = false
+	}
+
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return []UserRecord{}, err
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return []UserRecord{}, err
+	}
+
+	roleBindingList := new(rbacv1.RoleBindingList)
+	err = r.privilegedClient.List(ctx, roleBindingList, client.HasLabels{RoleGuidLabel})
+	if err != nil {
+		return []UserRecord{}, fmt.Errorf("failed to list role bindings: %w", apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	cfUserList := new(workloadsv1alpha1.CFUserList)
+	err = r.privilegedClient.List(ctx, cfUserList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []UserRecord{}, fmt.Errorf("failed to list users: %w", apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	users := map[string]UserRecord{}
+	for _, roleBinding := range roleBindingList.Items {
+		if !seesAllUsers && !authorizedOrgs[roleBinding.Namespace] && !authorizedSpaces[roleBinding.Namespace] {
+			continue
+		}
+
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind != rbacv1.UserKind {
+				continue
+			}
+
+			user, ok := users[subject.Name]
+			if !ok || roleBinding.CreationTimestamp.Time.Before(user.CreatedAt) {
+				users[subject.Name] = UserRecord{
+					GUID:             subject.Name,
+					Username:         subject.Name,
+					PresentationName: subject.Name,
+					CreatedAt:        roleBinding.CreationTimestamp.Time,
+					UpdatedAt:        roleBinding.CreationTimestamp.Time,
+				}
+			}
+		}
+	}
+
+	for _, cfUser := range cfUserList.Items {
+		if _, ok := users[cfUser.Spec.Username]; ok || seesAllUsers {
+			users[cfUser.Spec.Username] = cfUserToRecord(cfUser)
+		}
+	}
+
+	records := []UserRecord{}
+	for _, user := range users {
+		if matchesFilter(user.GUID, message.GUIDs) &&
+			matchesFilter(user.Username, message.Usernames) &&
+			matchesFilter(user.Origin, message.Origins) {
+			records = append(records, user)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].Username < records[j].Username
+		}
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func (r *UserRepo) GetUser(ctx context.Context, authInfo authorization.Info, guid string) (UserRecord, error) {
+	users, err := r.ListUsers(ctx, authInfo, ListUsersMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return UserRecord{}, err
+	}
+
+	if len(users) == 0 {
+		return UserRecord{}, apierrors.NewNotFoundError(fmt.Errorf("user %q not found", guid), UserResourceType)
+	}
+
+	return users[0], nil
+}
+
+// CreateUser records the user as a CFUser in the root namespace, so that it can be found before it has any roles
+func (r *UserRepo) CreateUser(ctx context.Context, authInfo authorization.Info, message CreateUserMessage) (UserRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return UserRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfUser := &workloadsv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfUserName(message.Username),
+			Namespace: r.rootNamespace,
+		},
+		Spec: workloadsv1alpha1.CFUserSpec{
+			Username: message.Username,
+			Origin:   message.Origin,
+		},
+	}
+	err = userClient.Create(ctx, cfUser)
+	if err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return UserRecord{}, apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("user %q already exists", message.Username),
+				fmt.Sprintf("User with username '%s' already exists.", message.Username),
+			)
+		}
+		return UserRecord{}, fmt.Errorf("failed to create user: %w", apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	return cfUserToRecord(*cfUser), nil
+}
+
+// DeleteUser deletes the CFUser of the user and all of its roles, including its cf_user role in the root namespace.
+// The copies of org roles propagated to the spaces of the org are removed with the roles they are copied from.
+func (r *UserRepo) DeleteUser(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	user, err := r.GetUser(ctx, authInfo, guid)
+	if err != nil {
+		return err
+	}
+
+	roleBindingList := new(rbacv1.RoleBindingList)
+	err = r.privilegedClient.List(ctx, roleBindingList, client.HasLabels{RoleGuidLabel})
+	if err != nil {
+		return fmt.Errorf("failed to list role bindings: %w", apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	for i := range roleBindingList.Items {
+		roleBinding := &roleBindingList.Items[i]
+		if _, inherited := roleBinding.Labels[hnsv1alpha2.LabelInheritedFrom]; inherited || !hasUserSubject(*roleBinding, user.Username) {
+			continue
+		}
+
+		err = userClient.Delete(ctx, roleBinding)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete role %q of user %q: %w", roleBinding.Labels[RoleGuidLabel], guid, apierrors.FromK8sError(err, UserResourceType))
+		}
+	}
+	r.namespacePermissions.Invalidate()
+
+	err = userClient.Delete(ctx, &workloadsv1alpha1.CFUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfUserName(user.Username),
+			Namespace: r.rootNamespace,
+		},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete user %q: %w", guid, apierrors.FromK8sError(err, UserResourceType))
+	}
+
+	return nil
+}
+
+func hasUserSubject(roleBinding rbacv1.RoleBinding, username string) bool {
+	for _, subject := range roleBinding.Subjects {
+		if subject.Kind == rbacv1.UserKind && subject.Name == username {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cfUserName is the name of the CFUser of the username, which need not be a valid object name
+func cfUserName(username string) string {
+	return fmt.Sprintf("%s%x", cfUserNamePrefix, sha256.Sum256([]byte(username)))
+}
+
+func cfUserToRecord(cfUser workloadsv1alpha1.CFUser) UserRecord {
+	presentationName := cfUser.Spec.PresentationName
+	if presentationName == "" {
+		presentationName = cfUser.Spec.Username
+	}
+
+	return UserRecord{
+		GUID:             cfUser.Spec.Username,
+		Username:         cfUser.Spec.Username,
+		PresentationName: presentationName,
+		Origin:           cfUser.Spec.Origin,
+		CreatedAt:        cfUser.CreationTimestamp.Time,
+		UpdatedAt:        cfUser.CreationTimestamp.Time,
+	}
+}
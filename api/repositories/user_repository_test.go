@@ -0,0 +1,270 @@
+package repositories_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/apierrors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("UserRepository", func() {
+	var (
+		ctx          context.Context
+		userRepo     *repositories.UserRepo
+		space        *hnsv1alpha2.SubnamespaceAnchor
+		otherSpace   *hnsv1alpha2.SubnamespaceAnchor
+		spaceUser    string
+		invisibleOne string
+	)
+
+	createUserRole := func(namespace, username, roleName string) *rbacv1.RoleBinding {
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: namespace,
+				Labels:    map[string]string{repositories.RoleGuidLabel: generateGUID()},
+			},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: username}},
+			RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: roleName},
+		}
+		Expect(k8sClient.Create(ctx, roleBinding)).To(Succeed())
+		return roleBinding
+	}
+
+	matchUser := func(username, origin string) types.GomegaMatcher {
+		return SatisfyAll(HaveField("GUID", username), HaveField("Username", username), HaveField("Origin", origin))
+	}
+
+	createCFUser := func(username, origin string) *workloadsv1alpha1.CFUser {
+		cfUser := &workloadsv1alpha1.CFUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("cf-user"),
+				Namespace: rootNamespace,
+			},
+			Spec: workloadsv1alpha1.CFUserSpec{
+				Username:         username,
+				Origin:           origin,
+				PresentationName: "Presented " + username,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfUser)).To(Succeed())
+		return cfUser
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		userRepo = repositories.NewUserRepo(rootNamespace, k8sClient, userClientFactory, nsPerms)
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+
+		spaceUser = prefixedGUID("space-user")
+		invisibleOne = prefixedGUID("invisible-user")
+		createUserRole(space.Name, spaceUser, spaceDeveloperRole.Name)
+		createUserRole(otherSpace.Name, invisibleOne, spaceDeveloperRole.Name)
+	})
+
+	Describe("ListUsers", func() {
+		var (
+			message repositories.ListUsersMessage
+			users   []repositories.UserRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListUsersMessage{Usernames: []string{spaceUser, invisibleOne}}
+		})
+
+		JustBeforeEach(func() {
+			users, listErr = userRepo.ListUsers(ctx, authInfo, message)
+		})
+
+		It("returns no users", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(users).To(BeEmpty())
+		})
+
+		When("the user has a role in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the users with roles in the space, known by their username", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(ConsistOf(matchUser(spaceUser, "")))
+			})
+
+			When("the user has a CFUser", func() {
+				BeforeEach(func() {
+					createCFUser(spaceUser, "uaa")
+				})
+
+				It("returns the details of the CFUser", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(users).To(HaveLen(1))
+					Expect(users[0].Origin).To(Equal("uaa"))
+					Expect(users[0].PresentationName).To(Equal("Presented " + spaceUser))
+				})
+
+				When("filtering by another origin", func() {
+					BeforeEach(func() {
+						message.Origins = []string{"ldap"}
+					})
+
+					It("returns no users", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(users).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		When("the user is an admin", func() {
+			var userWithoutRoles string
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				userWithoutRoles = prefixedGUID("new-user")
+				createCFUser(userWithoutRoles, "uaa")
+				message.Usernames = append(message.Usernames, userWithoutRoles)
+			})
+
+			It("returns every user, including the users without roles", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(users).To(ConsistOf(
+					matchUser(spaceUser, ""),
+					matchUser(invisibleOne, ""),
+					matchUser(userWithoutRoles, "uaa"),
+				))
+			})
+		})
+	})
+
+	Describe("GetUser", func() {
+		var (
+			user   repositories.UserRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			user, getErr = userRepo.GetUser(ctx, authInfo, spaceUser)
+		})
+
+		It("returns a not found error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user can see the roles of the user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the user", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(user.GUID).To(Equal(spaceUser))
+				Expect(user.Username).To(Equal(spaceUser))
+				Expect(user.PresentationName).To(Equal(spaceUser))
+				Expect(user.Origin).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("CreateUser", func() {
+		var (
+			username  string
+			user      repositories.UserRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			username = prefixedGUID("alice") + "@example.org"
+		})
+
+		JustBeforeEach(func() {
+			user, createErr = userRepo.CreateUser(ctx, authInfo, repositories.CreateUserMessage{Username: username, Origin: "uaa"})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFUser in the root namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(user.GUID).To(Equal(username))
+				Expect(user.Origin).To(Equal("uaa"))
+
+				cfUsers := new(workloadsv1alpha1.CFUserList)
+				Expect(k8sClient.List(ctx, cfUsers, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfUsers.Items).To(ContainElement(WithTransform(func(cfUser workloadsv1alpha1.CFUser) workloadsv1alpha1.CFUserSpec {
+					return cfUser.Spec
+				}, Equal(workloadsv1alpha1.CFUserSpec{Username: username, Origin: "uaa"}))))
+			})
+
+			When("the user already exists", func() {
+				BeforeEach(func() {
+					_, err := userRepo.CreateUser(ctx, authInfo, repositories.CreateUserMessage{Username: username, Origin: "uaa"})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteUser", func() {
+		var (
+			spaceRole *rbacv1.RoleBinding
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			spaceRole = createUserRole(otherSpace.Name, spaceUser, spaceDeveloperRole.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = userRepo.DeleteUser(ctx, authInfo, spaceUser)
+		})
+
+		It("returns a not found error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, space.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, otherSpace.Name)
+			})
+
+			It("deletes the roles of the user", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(spaceRole), &rbacv1.RoleBinding{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+				users, err := userRepo.ListUsers(ctx, authInfo, repositories.ListUsersMessage{Usernames: []string{spaceUser}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(users).To(BeEmpty())
+			})
+		})
+	})
+})
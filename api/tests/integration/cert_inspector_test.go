@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
 
 var _ = Describe("CertInspector", func() {
@@ -41,6 +42,20 @@ var _ = Describe("CertInspector", func() {
 		Expect(id.Name).To(Equal("alice"))
 	})
 
+	When("the certificate subject has organizations", func() {
+		BeforeEach(func() {
+			authUser, err := testEnv.ControlPlane.AddUser(envtest.User{Name: "bob", Groups: []string{"developers"}}, testEnv.Config)
+			Expect(err).NotTo(HaveOccurred())
+			certPEM = helpers.JoinCertAndKey(authUser.Config().CertData, authUser.Config().KeyData)
+		})
+
+		It("extracts them as the groups of the user", func() {
+			Expect(inspectorErr).NotTo(HaveOccurred())
+			Expect(id.Name).To(Equal("bob"))
+			Expect(id.Groups).To(ContainElement("developers"))
+		})
+	})
+
 	When("the certificate is not recognized by the cluster", func() {
 		BeforeEach(func() {
 			certPEM = generateUnsignedCert("alice")
@@ -40,6 +40,16 @@ var _ = Describe("TokenReviewer", func() {
 		Expect(id.Name).To(Equal(oidcPrefix + "alice"))
 	})
 
+	When("the user belongs to groups", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("alice", "developers", "auditors")
+		})
+
+		It("extracts the groups of the user", func() {
+			Expect(id.Groups).To(ContainElements("developers", "auditors"))
+		})
+	})
+
 	When("the token is issued for a serviceaccount", func() {
 		BeforeEach(func() {
 			restartEnvTest(authProvider.APIServerExtraArgs("system:serviceaccount:"))
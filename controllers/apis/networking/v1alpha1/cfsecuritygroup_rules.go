@@ -0,0 +1,183 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+const (
+	minICMPValue = -1
+	maxICMPValue = 255
+)
+
+// PortRange is a range of ports allowed by a rule. Start and End are the same for a single port
+type PortRange struct {
+	Start int32
+	End   int32
+}
+
+// Validate checks the rule is valid for its protocol, with messages matching the ones of Cloud Foundry
+func (r SecurityGroupRule) Validate() error {
+	if _, err := r.DestinationCIDRs(); err != nil {
+		return err
+	}
+
+	switch r.Protocol {
+	case ProtocolTCP, ProtocolUDP:
+		if r.Ports == "" {
+			return errors.New("ports are required for protocols of type TCP and UDP")
+		}
+		if _, err := r.PortRanges(); err != nil {
+			return err
+		}
+		if r.Type != nil || r.Code != nil {
+			return errors.New("types and codes are only allowed for protocols of type ICMP")
+		}
+	case ProtocolICMP:
+		if r.Ports != "" {
+			return errors.New("ports are not allowed for protocols of type ICMP")
+		}
+		if r.Type == nil {
+			return errors.New("type is required for protocols of type ICMP")
+		}
+		if *r.Type < minICMPValue || *r.Type > maxICMPValue {
+			return errors.New("type must be an integer between -1 and 255 (inclusive)")
+		}
+		if r.Code == nil {
+			return errors.New("code is required for protocols of type ICMP")
+		}
+		if *r.Code < minICMPValue || *r.Code > maxICMPValue {
+			return errors.New("code must be an integer between -1 and 255 (inclusive)")
+		}
+	case ProtocolAll:
+		if r.Ports != "" {
+			return errors.New("ports are not allowed for protocols of type all")
+		}
+		if r.Type != nil || r.Code != nil {
+			return errors.New("types and codes are only allowed for protocols of type ICMP")
+		}
+	default:
+		return errors.New("protocol must be 'tcp', 'udp', 'icmp', or 'all'")
+	}
+
+	return nil
+}
+
+// DestinationCIDRs returns the destinations of the rule as CIDRs. IP address ranges are split into the fewest CIDRs
+// covering them
+func (r SecurityGroupRule) DestinationCIDRs() ([]string, error) {
+	invalidErr := errors.New("destination must be a valid CIDR, IP address, or IP address range and may not contain whitespace")
+
+	if r.Destination == "" || strings.ContainsAny(r.Destination, " \t\n") {
+		return nil, invalidErr
+	}
+
+	cidrs := []string{}
+	for _, destination := range strings.Split(r.Destination, ",") {
+		switch {
+		case strings.Contains(destination, "/"):
+			prefix, err := netip.ParsePrefix(destination)
+			if err != nil {
+				return nil, invalidErr
+			}
+			cidrs = append(cidrs, prefix.Masked().String())
+
+		case strings.Contains(destination, "-"):
+			bounds := strings.Split(destination, "-")
+			if len(bounds) != 2 {
+				return nil, invalidErr
+			}
+			start, startErr := netip.ParseAddr(bounds[0])
+			end, endErr := netip.ParseAddr(bounds[1])
+			if startErr != nil || endErr != nil || !start.Is4() || !end.Is4() || end.Less(start) {
+				return nil, invalidErr
+			}
+			cidrs = append(cidrs, ipv4RangeToCIDRs(start, end)...)
+
+		default:
+			addr, err := netip.ParseAddr(destination)
+			if err != nil {
+				return nil, invalidErr
+			}
+			cidrs = append(cidrs, netip.PrefixFrom(addr, addr.BitLen()).String())
+		}
+	}
+
+	return cidrs, nil
+}
+
+// PortRanges returns the ports of the rule, which are either a single range or a list of ports
+func (r SecurityGroupRule) PortRanges() ([]PortRange, error) {
+	invalidErr := errors.New("ports must be a valid single port, comma separated list of ports, or range of ports, formatted as a string")
+
+	if strings.Contains(r.Ports, "-") {
+		bounds := strings.Split(r.Ports, "-")
+		if len(bounds) != 2 {
+			return nil, invalidErr
+		}
+		start, startErr := parsePort(bounds[0])
+		end, endErr := parsePort(bounds[1])
+		if startErr != nil || endErr != nil || end < start {
+			return nil, invalidErr
+		}
+		return []PortRange{{Start: start, End: end}}, nil
+	}
+
+	ranges := []PortRange{}
+	for _, portString := range strings.Split(r.Ports, ",") {
+		port, err := parsePort(portString)
+		if err != nil {
+			return nil, invalidErr
+		}
+		ranges = append(ranges, PortRange{Start: port, End: port})
+	}
+
+	return ranges, nil
+}
+
+func parsePort(portString string) (int32, error) {
+	port, err := strconv.ParseInt(portString, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d is out of range", port)
+	}
+
+	return int32(port), nil
+}
+
+// ipv4RangeToCIDRs covers the range with the largest aligned blocks that fit in it
+func ipv4RangeToCIDRs(start, end netip.Addr) []string {
+	first := ipv4ToUint64(start)
+	last := ipv4ToUint64(end)
+
+	cidrs := []string{}
+	for first <= last {
+		blockBits := 32
+		if first != 0 {
+			blockBits = bits.TrailingZeros64(first)
+		}
+		for first+(uint64(1)<<blockBits)-1 > last {
+			blockBits--
+		}
+
+		cidrs = append(cidrs, netip.PrefixFrom(uint64ToIPv4(first), 32-blockBits).String())
+		first += uint64(1) << blockBits
+	}
+
+	return cidrs
+}
+
+func ipv4ToUint64(addr netip.Addr) uint64 {
+	octets := addr.As4()
+	return uint64(octets[0])<<24 | uint64(octets[1])<<16 | uint64(octets[2])<<8 | uint64(octets[3])
+}
+
+func uint64ToIPv4(value uint64) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)})
+}
@@ -0,0 +1,93 @@
+package v1alpha1_test
+
+import (
+	"code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityGroupRule", func() {
+	var rule v1alpha1.SecurityGroupRule
+
+	intPtr := func(value int) *int {
+		return &value
+	}
+
+	BeforeEach(func() {
+		rule = v1alpha1.SecurityGroupRule{
+			Protocol:    "tcp",
+			Destination: "10.0.0.0/16",
+			Ports:       "443",
+		}
+	})
+
+	Describe("Validate", func() {
+		It("accepts a valid rule", func() {
+			Expect(rule.Validate()).To(Succeed())
+		})
+
+		DescribeTable("invalid rules",
+			func(modify func(*v1alpha1.SecurityGroupRule), message string) {
+				modify(&rule)
+				Expect(rule.Validate()).To(MatchError(message))
+			},
+			Entry("unknown protocol", func(r *v1alpha1.SecurityGroupRule) { r.Protocol = "sctp" },
+				"protocol must be 'tcp', 'udp', 'icmp', or 'all'"),
+			Entry("destination with whitespace", func(r *v1alpha1.SecurityGroupRule) { r.Destination = "10.0.0.1, 10.0.0.2" },
+				"destination must be a valid CIDR, IP address, or IP address range and may not contain whitespace"),
+			Entry("reversed range", func(r *v1alpha1.SecurityGroupRule) { r.Destination = "10.0.0.9-10.0.0.1" },
+				"destination must be a valid CIDR, IP address, or IP address range and may not contain whitespace"),
+			Entry("tcp without ports", func(r *v1alpha1.SecurityGroupRule) { r.Ports = "" },
+				"ports are required for protocols of type TCP and UDP"),
+			Entry("out of range port", func(r *v1alpha1.SecurityGroupRule) { r.Ports = "70000" },
+				"ports must be a valid single port, comma separated list of ports, or range of ports, formatted as a string"),
+			Entry("range mixed with a list", func(r *v1alpha1.SecurityGroupRule) { r.Ports = "80,8000-8080" },
+				"ports must be a valid single port, comma separated list of ports, or range of ports, formatted as a string"),
+			Entry("icmp without a type", func(r *v1alpha1.SecurityGroupRule) {
+				r.Protocol = "icmp"
+				r.Ports = ""
+				r.Code = intPtr(0)
+			}, "type is required for protocols of type ICMP"),
+			Entry("icmp with an invalid code", func(r *v1alpha1.SecurityGroupRule) {
+				r.Protocol = "icmp"
+				r.Ports = ""
+				r.Type = intPtr(8)
+				r.Code = intPtr(256)
+			}, "code must be an integer between -1 and 255 (inclusive)"),
+			Entry("all with ports", func(r *v1alpha1.SecurityGroupRule) { r.Protocol = "all" },
+				"ports are not allowed for protocols of type all"),
+		)
+	})
+
+	Describe("DestinationCIDRs", func() {
+		It("converts IP addresses, CIDRs and ranges", func() {
+			rule.Destination = "192.168.1.7,10.1.2.3/16,10.0.0.1-10.0.0.6"
+			Expect(rule.DestinationCIDRs()).To(Equal([]string{
+				"192.168.1.7/32",
+				"10.1.0.0/16",
+				"10.0.0.1/32",
+				"10.0.0.2/31",
+				"10.0.0.4/31",
+				"10.0.0.6/32",
+			}))
+		})
+
+		It("covers the whole address space with a single CIDR", func() {
+			rule.Destination = "0.0.0.0-255.255.255.255"
+			Expect(rule.DestinationCIDRs()).To(Equal([]string{"0.0.0.0/0"}))
+		})
+	})
+
+	Describe("PortRanges", func() {
+		It("parses a list of ports", func() {
+			rule.Ports = "80,443"
+			Expect(rule.PortRanges()).To(Equal([]v1alpha1.PortRange{{Start: 80, End: 80}, {Start: 443, End: 443}}))
+		})
+
+		It("parses a range of ports", func() {
+			rule.Ports = "8000-8080"
+			Expect(rule.PortRanges()).To(Equal([]v1alpha1.PortRange{{Start: 8000, End: 8080}}))
+		})
+	})
+})
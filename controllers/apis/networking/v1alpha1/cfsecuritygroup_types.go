@@ -0,0 +1,114 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ProtocolTCP  = "tcp"
+	ProtocolUDP  = "udp"
+	ProtocolICMP = "icmp"
+	ProtocolAll  = "all"
+)
+
+// CFSecurityGroupSpec defines the desired state of CFSecurityGroup
+type CFSecurityGroupSpec struct {
+	// Name of the security group displayed to the user
+	DisplayName string `json:"displayName"`
+
+	// Rules are the egress traffic allowed to the workloads the security group applies to
+	// +optional
+	Rules []SecurityGroupRule `json:"rules,omitempty"`
+
+	// GloballyEnabled applies the security group to the workloads of every space
+	// +optional
+	GloballyEnabled SecurityGroupWorkloads `json:"globallyEnabled,omitempty"`
+
+	// RunningSpaces are the GUIDs of the spaces whose app processes and tasks the security group applies to
+	// +optional
+	RunningSpaces []string `json:"runningSpaces,omitempty"`
+
+	// StagingSpaces are the GUIDs of the spaces whose builds the security group applies to
+	// +optional
+	StagingSpaces []string `json:"stagingSpaces,omitempty"`
+}
+
+// SecurityGroupWorkloads selects the lifecycle of the workloads a security group applies to
+type SecurityGroupWorkloads struct {
+	// +optional
+	Running bool `json:"running,omitempty"`
+	// +optional
+	Staging bool `json:"staging,omitempty"`
+}
+
+// SecurityGroupRule allows egress traffic in the format of Cloud Foundry security group rules
+type SecurityGroupRule struct {
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	Protocol string `json:"protocol"`
+
+	// Destination is an IP address, a CIDR, or a range of IP addresses such as 10.0.0.1-10.0.0.10. Several
+	// destinations can be separated by commas
+	Destination string `json:"destination"`
+
+	// Ports is a port, a range of ports such as 8000-8080, or a comma separated list of ports. It is required for the
+	// tcp and udp protocols only
+	// +optional
+	Ports string `json:"ports,omitempty"`
+
+	// Type is the ICMP type, -1 meaning any type
+	// +optional
+	Type *int `json:"type,omitempty"`
+
+	// Code is the ICMP code, -1 meaning any code
+	// +optional
+	Code *int `json:"code,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Log is accepted for compatibility only, as NetworkPolicies cannot log traffic
+	// +optional
+	Log bool `json:"log,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSecurityGroup is the Schema for the cfsecuritygroups API
+type CFSecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSecurityGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSecurityGroupList contains a list of CFSecurityGroup
+type CFSecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSecurityGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSecurityGroup{}, &CFSecurityGroupList{})
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 const (
-	CFDomainGUIDLabelKey = "networking.cloudfoundry.org/domain-guid"
-	CFRouteGUIDLabelKey  = "networking.cloudfoundry.org/route-guid"
+	CFDomainGUIDLabelKey        = "networking.cloudfoundry.org/domain-guid"
+	CFRouteGUIDLabelKey         = "networking.cloudfoundry.org/route-guid"
+	CFSecurityGroupGUIDLabelKey = "networking.cloudfoundry.org/security-group-guid"
 )
@@ -0,0 +1,66 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFServiceBrokerSpec defines the desired state of CFServiceBroker
+type CFServiceBrokerSpec struct {
+	// Name defines the name of the Service Broker
+	Name string `json:"name"`
+
+	// URL of the Open Service Broker API endpoint of the broker
+	URL string `json:"url"`
+
+	// Name of a secret containing the `username` and `password` of the broker
+	Credentials v1.LocalObjectReference `json:"credentials"`
+}
+
+// CFServiceBrokerStatus defines the observed state of CFServiceBroker
+type CFServiceBrokerStatus struct {
+	// Conditions capture the current status of the CFServiceBroker
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// CFServiceBroker is the Schema for the cfservicebrokers API
+type CFServiceBroker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceBrokerSpec `json:"spec,omitempty"`
+
+	Status CFServiceBrokerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceBrokerList contains a list of CFServiceBroker
+type CFServiceBrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceBroker `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceBroker{}, &CFServiceBrokerList{})
+}
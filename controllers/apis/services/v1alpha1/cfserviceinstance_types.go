@@ -26,6 +26,14 @@ import (
 
 const (
 	UserProvidedType = "user-provided"
+	ManagedType      = "managed"
+
+	CreateOperationType = "create"
+	DeleteOperationType = "delete"
+
+	OperationStateInProgress = "in progress"
+	OperationStateSucceeded  = "succeeded"
+	OperationStateFailed     = "failed"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -33,18 +41,21 @@ type CFServiceInstanceSpec struct {
 	// Name defines the name of the Service Instance
 	Name string `json:"name"`
 
-	// Name of a secret containing the service credentials
-	SecretName string `json:"secretName"`
+	// Name of a secret containing the service credentials. Only used by `user-provided` Service Instances
+	SecretName string `json:"secretName,omitempty"`
 
-	// Type of the Service Instance. Must be `user-provided`
+	// Type of the Service Instance. Must be `user-provided` or `managed`
 	Type InstanceType `json:"type"`
 
+	// Specifies the Service Plan that a `managed` Service Instance is provisioned from
+	ServicePlanRef *v1.ObjectReference `json:"servicePlanRef,omitempty"`
+
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
-// +kubebuilder:validation:Enum=user-provided
+// +kubebuilder:validation:Enum=user-provided;managed
 type InstanceType string
 
 // CFServiceInstanceStatus defines the observed state of CFServiceInstance
@@ -55,6 +66,24 @@ type CFServiceInstanceStatus struct {
 
 	// Conditions capture the current status of the CFServiceInstance
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// The last operation the broker performed on a `managed` Service Instance
+	LastOperation *ServiceInstanceOperation `json:"lastOperation,omitempty"`
+}
+
+// ServiceInstanceOperation describes an operation of the broker on a Service Instance
+type ServiceInstanceOperation struct {
+	// Type of the operation. Either `create` or `delete`
+	Type string `json:"type"`
+
+	// State of the operation. One of `in progress`, `succeeded` or `failed`
+	State string `json:"state"`
+
+	// Description of the state of the operation, as given by the broker
+	Description string `json:"description,omitempty"`
+
+	// The operation the broker returned for an asynchronous request. It is used to poll the broker for its state
+	BrokerOperation string `json:"brokerOperation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
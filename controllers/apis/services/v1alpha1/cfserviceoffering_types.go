@@ -0,0 +1,72 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFServiceOfferingSpec defines the desired state of CFServiceOffering
+type CFServiceOfferingSpec struct {
+	// Name defines the name of the Service Offering
+	Name string `json:"name"`
+
+	// Description of the Service Offering
+	Description string `json:"description"`
+
+	// Tags are used by apps to identify service instances of the offering
+	Tags []string `json:"tags,omitempty"`
+
+	// Bindable is true when apps can be bound to service instances of the offering
+	Bindable bool `json:"bindable"`
+
+	// The identity of the Service Offering in the catalog of the broker
+	BrokerCatalog ServiceBrokerCatalog `json:"brokerCatalog"`
+
+	// Specifies the Service Broker that offers the Service Offering
+	ServiceBrokerRef v1.LocalObjectReference `json:"serviceBrokerRef"`
+}
+
+// ServiceBrokerCatalog identifies a Service Offering or Service Plan in the catalog of its broker
+type ServiceBrokerCatalog struct {
+	// ID of the Service Offering or Service Plan in the catalog of the broker
+	ID string `json:"id"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceOffering is the Schema for the cfserviceofferings API
+type CFServiceOffering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceOfferingSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceOfferingList contains a list of CFServiceOffering
+type CFServiceOfferingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceOffering `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceOffering{}, &CFServiceOfferingList{})
+}
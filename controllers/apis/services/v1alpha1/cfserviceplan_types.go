@@ -0,0 +1,63 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFServicePlanSpec defines the desired state of CFServicePlan
+type CFServicePlanSpec struct {
+	// Name defines the name of the Service Plan
+	Name string `json:"name"`
+
+	// Description of the Service Plan
+	Description string `json:"description"`
+
+	// Free is true when service instances of the plan are free of charge
+	Free bool `json:"free"`
+
+	// The identity of the Service Plan in the catalog of the broker
+	BrokerCatalog ServiceBrokerCatalog `json:"brokerCatalog"`
+
+	// Specifies the Service Offering the Service Plan belongs to
+	ServiceOfferingRef v1.LocalObjectReference `json:"serviceOfferingRef"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServicePlan is the Schema for the cfserviceplans API
+type CFServicePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServicePlanSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServicePlanList contains a list of CFServicePlan
+type CFServicePlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServicePlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServicePlan{}, &CFServicePlanList{})
+}
@@ -2,4 +2,6 @@ package v1alpha1
 
 const (
 	CFServiceInstanceGUIDLabelKey = "services.cloudfoundry.org/service-instance-guid"
+	CFServiceBrokerGUIDLabelKey   = "services.cloudfoundry.org/service-broker-guid"
+	CFServiceOfferingGUIDLabelKey = "services.cloudfoundry.org/service-offering-guid"
 )
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -130,6 +131,103 @@ func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBroker.
+func (in *CFServiceBroker) DeepCopy() *CFServiceBroker {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBroker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBroker) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerList) DeepCopyInto(out *CFServiceBrokerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceBroker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerList.
+func (in *CFServiceBrokerList) DeepCopy() *CFServiceBrokerList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBrokerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerSpec) DeepCopyInto(out *CFServiceBrokerSpec) {
+	*out = *in
+	out.Credentials = in.Credentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerSpec.
+func (in *CFServiceBrokerSpec) DeepCopy() *CFServiceBrokerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerStatus) DeepCopyInto(out *CFServiceBrokerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerStatus.
+func (in *CFServiceBrokerStatus) DeepCopy() *CFServiceBrokerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
 	*out = *in
@@ -197,6 +295,11 @@ func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServicePlanRef != nil {
+		in, out := &in.ServicePlanRef, &out.ServicePlanRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
@@ -220,6 +323,11 @@ func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastOperation != nil {
+		in, out := &in.LastOperation, &out.LastOperation
+		*out = new(ServiceInstanceOperation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
@@ -231,3 +339,188 @@ func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceOffering) DeepCopyInto(out *CFServiceOffering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOffering.
+func (in *CFServiceOffering) DeepCopy() *CFServiceOffering {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceOffering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceOffering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceOfferingList) DeepCopyInto(out *CFServiceOfferingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceOffering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingList.
+func (in *CFServiceOfferingList) DeepCopy() *CFServiceOfferingList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceOfferingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceOfferingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceOfferingSpec) DeepCopyInto(out *CFServiceOfferingSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.BrokerCatalog = in.BrokerCatalog
+	out.ServiceBrokerRef = in.ServiceBrokerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
+func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceOfferingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
+func (in *CFServicePlan) DeepCopy() *CFServicePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServicePlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
+func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
+	*out = *in
+	out.BrokerCatalog = in.BrokerCatalog
+	out.ServiceOfferingRef = in.ServiceOfferingRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
+func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerCatalog) DeepCopyInto(out *ServiceBrokerCatalog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerCatalog.
+func (in *ServiceBrokerCatalog) DeepCopy() *ServiceBrokerCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceOperation) DeepCopyInto(out *ServiceInstanceOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceOperation.
+func (in *ServiceInstanceOperation) DeepCopy() *ServiceInstanceOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceOperation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -54,6 +54,21 @@ type CFAppStatus struct {
 	Conditions []metav1.Condition `json:"conditions"`
 
 	ObservedDesiredState DesiredState `json:"observedDesiredState"`
+
+	// RunningInstances is the number of running replicas across all the Processes of the App
+	RunningInstances int `json:"runningInstances,omitempty"`
+
+	// RestartCount is the number of container restarts across all the Processes of the App
+	RestartCount int32 `json:"restartCount,omitempty"`
+
+	// LastTaskSequenceID is the sequence ID given to the most recent task of the App
+	LastTaskSequenceID int64 `json:"lastTaskSequenceID,omitempty"`
+
+	// CurrentRevisionRef provides reference to the CFAppRevision recording the current droplet, environment and commands of the App
+	CurrentRevisionRef v1.LocalObjectReference `json:"currentRevisionRef,omitempty"`
+
+	// LastRevisionVersion is the version given to the most recent revision of the App
+	LastRevisionVersion int64 `json:"lastRevisionVersion,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFAppRevisionSpec defines the desired state of CFAppRevision
+type CFAppRevisionSpec struct {
+	// Specifies the App the revision belongs to
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// Version is the sequence number of the revision within the App, starting at 1
+	Version int64 `json:"version"`
+
+	// Description lists what changed compared to the previous revision
+	Description string `json:"description,omitempty"`
+
+	// DropletRef is the droplet the App was running
+	DropletRef v1.LocalObjectReference `json:"dropletRef"`
+
+	// EnvSecretHash is a hash of the contents of the environment variable secret of the App
+	EnvSecretHash string `json:"envSecretHash"`
+
+	// EnvSecretRef is the Secret holding a snapshot of the environment variables of the App, restored on rollback
+	EnvSecretRef v1.LocalObjectReference `json:"envSecretRef,omitempty"`
+
+	// Processes are the commands of the processes of the App
+	Processes []RevisionProcess `json:"processes,omitempty"`
+}
+
+// RevisionProcess is a process recorded by a revision
+type RevisionProcess struct {
+	// Type of the CFProcess
+	Type string `json:"type"`
+
+	// Command of the CFProcess
+	Command string `json:"command,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFAppRevision is the Schema for the cfapprevisions API
+type CFAppRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFAppRevisionList contains a list of CFAppRevision
+type CFAppRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppRevision{}, &CFAppRevisionList{})
+}
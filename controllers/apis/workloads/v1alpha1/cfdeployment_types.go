@@ -0,0 +1,115 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFDeploymentSpec defines the desired state of CFDeployment
+type CFDeploymentSpec struct {
+	// Specifies the App that is being deployed
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// Specifies the droplet to deploy. The current droplet of the App is redeployed when empty
+	DropletRef v1.LocalObjectReference `json:"dropletRef,omitempty"`
+
+	// Specifies a revision of the App to roll back to. The droplet and process commands of the revision are deployed
+	RevisionRef v1.LocalObjectReference `json:"revisionRef,omitempty"`
+
+	// Specifies how the App is deployed
+	// Allowed values are:
+	// "rolling": new instances are started before the old ones are stopped
+	Strategy DeploymentStrategy `json:"strategy"`
+
+	// Canceled requests the deployment to be rolled back to the previous droplet
+	Canceled bool `json:"canceled,omitempty"`
+}
+
+// DeploymentStrategy defines how the App is deployed
+// +kubebuilder:validation:Enum=rolling
+type DeploymentStrategy string
+
+// DeploymentStatusValue tells whether the deployment is still in progress
+// +kubebuilder:validation:Enum=ACTIVE;FINALIZED
+type DeploymentStatusValue string
+
+// DeploymentStatusReason details the DeploymentStatusValue
+// +kubebuilder:validation:Enum=DEPLOYING;CANCELING;DEPLOYED;CANCELED;SUPERSEDED;DEGENERATE
+type DeploymentStatusReason string
+
+// DeploymentProcess is a process run by the deployment
+type DeploymentProcess struct {
+	// GUID of the CFProcess
+	GUID string `json:"guid"`
+
+	// Type of the CFProcess
+	Type string `json:"type"`
+}
+
+// CFDeploymentStatus defines the observed state of CFDeployment
+type CFDeploymentStatus struct {
+	// Value is ACTIVE while the deployment is in progress, FINALIZED afterwards
+	Value DeploymentStatusValue `json:"value,omitempty"`
+
+	// Reason details the Value
+	Reason DeploymentStatusReason `json:"reason,omitempty"`
+
+	// LastStatusChange is the time when Value or Reason last changed
+	LastStatusChange metav1.Time `json:"lastStatusChange,omitempty"`
+
+	// DropletRef is the droplet being deployed
+	DropletRef v1.LocalObjectReference `json:"dropletRef,omitempty"`
+
+	// PreviousDropletRef is the droplet the App was running before the deployment
+	PreviousDropletRef v1.LocalObjectReference `json:"previousDropletRef,omitempty"`
+
+	// Revision is the revision of the App being deployed
+	Revision string `json:"revision,omitempty"`
+
+	// PreviousRevision is the revision of the App before the deployment
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// Processes are the processes of the App running the new revision
+	Processes []DeploymentProcess `json:"processes,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// CFDeployment is the Schema for the cfdeployments API
+type CFDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFDeploymentSpec   `json:"spec,omitempty"`
+	Status CFDeploymentStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFDeploymentList contains a list of CFDeployment
+type CFDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFDeployment{}, &CFDeploymentList{})
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFOrgQuotaSpec defines the desired state of CFOrgQuota
+type CFOrgQuotaSpec struct {
+	// Name of the quota displayed to the user
+	Name string `json:"name"`
+
+	// Limits applied to each of the orgs of the quota
+	Limits QuotaLimits `json:"limits"`
+
+	// Orgs are the GUIDs of the orgs the quota is applied to. An org has at most one quota
+	// +optional
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFOrgQuota is the Schema for the cforgquotas API
+type CFOrgQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFOrgQuotaSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFOrgQuotaList contains a list of CFOrgQuota
+type CFOrgQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFOrgQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFOrgQuota{}, &CFOrgQuotaList{})
+}
@@ -26,6 +26,7 @@ type CFPackageSpec struct {
 	// Type specifies the package type
 	// Valid values are:
 	// "bits": package to upload source code
+	// "docker": package referencing a container image to run as is
 	Type PackageType `json:"type"`
 
 	// AppRef reference to the CFApp that owns this package
@@ -36,7 +37,7 @@ type CFPackageSpec struct {
 }
 
 // PackageType used to enum the inputs to package.type
-// +kubebuilder:validation:Enum=bits
+// +kubebuilder:validation:Enum=bits;docker
 type PackageType string
 
 type PackageSource struct {
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // CFProcessSpec defines the desired state of CFProcess
@@ -77,10 +78,24 @@ type HealthCheckData struct {
 type CFProcessStatus struct {
 	// RunningInstances captures the actual number of Process replicas
 	RunningInstances int `json:"runningInstances"`
+	// RestartCount is the number of times the containers of the current Process replicas have been restarted
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// ReportedCrashes records, for each container of the current Process replicas, how many of its terminations have
+	// been reported as crash events
+	ReportedCrashes []ReportedCrash `json:"reportedCrashes,omitempty"`
 	// Conditions capture the current status of the Process
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// ReportedCrash identifies the container of a Process replica whose terminations have been reported
+type ReportedCrash struct {
+	PodUID        types.UID `json:"podUID"`
+	ContainerName string    `json:"containerName"`
+	// Terminations is the number of terminations of the container that have been reported, counting the one after its
+	// last restart
+	Terminations int32 `json:"terminations"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -0,0 +1,64 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSidecarSpec defines the desired state of CFSidecar
+type CFSidecarSpec struct {
+	// Specifies the App the sidecar belongs to
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// Name of the sidecar, used as the name of its container
+	Name string `json:"name"`
+
+	// Command the sidecar runs
+	Command string `json:"command"`
+
+	// ProcessTypes are the types of the CFProcesses the sidecar runs next to
+	ProcessTypes []string `json:"processTypes"`
+
+	// MemoryMB is the memory limit of the sidecar. When unset, the memory limit of the process applies
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSidecar is the Schema for the cfsidecars API
+type CFSidecar struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSidecarSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSidecarList contains a list of CFSidecar
+type CFSidecarList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSidecar `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSidecar{}, &CFSidecarList{})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSpaceQuotaSpec defines the desired state of CFSpaceQuota
+type CFSpaceQuotaSpec struct {
+	// Name of the quota displayed to the user
+	Name string `json:"name"`
+
+	// Limits applied to each of the spaces of the quota
+	Limits QuotaLimits `json:"limits"`
+
+	// Spaces are the GUIDs of the spaces the quota is applied to. They must belong to the org the quota is created in,
+	// and a space has at most one quota
+	// +optional
+	Spaces []string `json:"spaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSpaceQuota is the Schema for the cfspacequotas API
+type CFSpaceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSpaceQuotaSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSpaceQuotaList contains a list of CFSpaceQuota
+type CFSpaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSpaceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSpaceQuota{}, &CFSpaceQuotaList{})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFTaskSpec defines the desired state of CFTask
+type CFTaskSpec struct {
+	// Specifies the user-facing name of the task
+	Name string `json:"name"`
+
+	// Specifies the command to run in the droplet of the App
+	Command string `json:"command"`
+
+	// Specifies the App that owns this task
+	AppRef v1.LocalObjectReference `json:"appRef"`
+
+	// Specifies the Task memory limit
+	MemoryMB int64 `json:"memoryMB"`
+
+	// Specifies the Task disk limit
+	DiskQuotaMB int64 `json:"diskQuotaMB"`
+
+	// Canceled requests the task to be stopped
+	Canceled bool `json:"canceled,omitempty"`
+}
+
+// TaskState describes the lifecycle of a task
+// +kubebuilder:validation:Enum=PENDING;RUNNING;SUCCEEDED;FAILED
+type TaskState string
+
+// CFTaskStatus defines the observed state of CFTask
+type CFTaskStatus struct {
+	// SequenceID is the number of the task among all the tasks of the App, starting from 1
+	SequenceID int64 `json:"sequenceID,omitempty"`
+
+	// DropletRef is the droplet of the App at the time the task was initialized
+	DropletRef v1.LocalObjectReference `json:"dropletRef,omitempty"`
+
+	// State is the current state of the task
+	State TaskState `json:"state,omitempty"`
+
+	// FailureReason explains why a FAILED task failed
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// CFTask is the Schema for the cftasks API
+type CFTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFTaskSpec   `json:"spec,omitempty"`
+	Status CFTaskStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFTaskList contains a list of CFTask
+type CFTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFTask `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFTask{}, &CFTaskList{})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFUserSpec defines the desired state of CFUser
+type CFUserSpec struct {
+	// Username of the user, as the subject of its role bindings
+	Username string `json:"username"`
+
+	// Origin is the identity provider the user logs in with
+	// +optional
+	Origin string `json:"origin,omitempty"`
+
+	// PresentationName is the name of the user displayed to other users. Defaults to the username
+	// +optional
+	PresentationName string `json:"presentationName,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFUser is the Schema for the cfusers API. Users are known by the subjects of their role bindings, CFUsers record
+// the users that have no roles yet and the details of users that cannot be found in their tokens.
+type CFUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUserSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFUserList contains a list of CFUser
+type CFUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUser{}, &CFUserList{})
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 const (
 	BuildpackLifecycle LifecycleType = "buildpack"
+	DockerLifecycle    LifecycleType = "docker"
 	DockerPackage      PackageType   = "docker"
 
 	StartedState DesiredState = "STARTED"
@@ -10,4 +11,21 @@ const (
 	HTTPHealthCheckType    HealthCheckType = "http"
 	PortHealthCheckType    HealthCheckType = "port"
 	ProcessHealthCheckType HealthCheckType = "process"
+
+	TaskPendingState   TaskState = "PENDING"
+	TaskRunningState   TaskState = "RUNNING"
+	TaskSucceededState TaskState = "SUCCEEDED"
+	TaskFailedState    TaskState = "FAILED"
+
+	RollingDeploymentStrategy DeploymentStrategy = "rolling"
+
+	DeploymentStatusValueActive    DeploymentStatusValue = "ACTIVE"
+	DeploymentStatusValueFinalized DeploymentStatusValue = "FINALIZED"
+
+	DeploymentStatusReasonDeploying  DeploymentStatusReason = "DEPLOYING"
+	DeploymentStatusReasonCanceling  DeploymentStatusReason = "CANCELING"
+	DeploymentStatusReasonDeployed   DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonCanceled   DeploymentStatusReason = "CANCELED"
+	DeploymentStatusReasonSuperseded DeploymentStatusReason = "SUPERSEDED"
+	DeploymentStatusReasonDegenerate DeploymentStatusReason = "DEGENERATE"
 )
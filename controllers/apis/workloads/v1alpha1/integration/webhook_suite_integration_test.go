@@ -101,6 +101,7 @@ var _ = BeforeSuite(func() {
 
 	Expect(workloads.NewCFAppValidation(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType)),
+		workloads.NewQuotaValidator(mgr.GetClient(), "cf"),
 	).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	Expect(workloads.NewSubnamespaceAnchorValidation(
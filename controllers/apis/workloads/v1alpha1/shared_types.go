@@ -12,23 +12,32 @@ const (
 	CFBuildGUIDLabelKey     = "workloads.cloudfoundry.org/build-guid"
 	CFProcessGUIDLabelKey   = "workloads.cloudfoundry.org/process-guid"
 	CFProcessTypeLabelKey   = "workloads.cloudfoundry.org/process-type"
+	CFTaskGUIDLabelKey      = "workloads.cloudfoundry.org/task-guid"
 	StagingConditionType    = "Staging"
 	ReadyConditionType      = "Ready"
 	SucceededConditionType  = "Succeeded"
+
+	// AppProcessCrashEventReason is the reason of the events recorded on a CFApp when an instance of one of its processes crashes
+	AppProcessCrashEventReason     = "audit.app.process.crash"
+	CFInstanceIndexAnnotationKey   = "workloads.cloudfoundry.org/instance-index"
+	CFExitStatusAnnotationKey      = "workloads.cloudfoundry.org/exit-status"
+	CFExitReasonAnnotationKey      = "workloads.cloudfoundry.org/exit-reason"
+	CFExitDescriptionAnnotationKey = "workloads.cloudfoundry.org/exit-description"
 )
 
 type Lifecycle struct {
 	// Specifies the CF Lifecycle type:
 	// Valid values are:
 	// "buildpack": stage the app using kpack
+	// "docker": run the app from a container image
 	Type LifecycleType `json:"type"`
 	// Lifecycle data used to specify details for the Lifecycle
 	Data LifecycleData `json:"data"`
 }
 
 // LifecycleType inform the platform of how to build droplets and run apps
-// allow only values "buildpack"
-// +kubebuilder:validation:Enum=buildpack
+// allow only values "buildpack" and "docker"
+// +kubebuilder:validation:Enum=buildpack;docker
 type LifecycleType string
 
 // Shared by CFApp and CFBuild
@@ -45,3 +54,22 @@ type Registry struct {
 	// ImagePullSecrets specifies a list of secrets required to access the image
 	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
+
+// QuotaLimits is shared by CFOrgQuota and CFSpaceQuota. Unset limits are unlimited
+type QuotaLimits struct {
+	// TotalMemoryMB limits the memory of all the process instances
+	// +optional
+	TotalMemoryMB *int64 `json:"totalMemoryMB,omitempty"`
+	// InstanceMemoryMB limits the memory of a single process instance
+	// +optional
+	InstanceMemoryMB *int64 `json:"instanceMemoryMB,omitempty"`
+	// AppInstances limits the number of process instances
+	// +optional
+	AppInstances *int `json:"appInstances,omitempty"`
+	// Routes limits the number of routes
+	// +optional
+	Routes *int `json:"routes,omitempty"`
+	// ServiceInstances limits the number of managed service instances
+	// +optional
+	ServiceInstances *int `json:"serviceInstances,omitempty"`
+}
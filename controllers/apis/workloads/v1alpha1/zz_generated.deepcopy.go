@@ -112,6 +112,87 @@ func (in *CFAppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevision) DeepCopyInto(out *CFAppRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevision.
+func (in *CFAppRevision) DeepCopy() *CFAppRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevisionList) DeepCopyInto(out *CFAppRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFAppRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevisionList.
+func (in *CFAppRevisionList) DeepCopy() *CFAppRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppRevisionSpec) DeepCopyInto(out *CFAppRevisionSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.DropletRef = in.DropletRef
+	out.EnvSecretRef = in.EnvSecretRef
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]RevisionProcess, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppRevisionSpec.
+func (in *CFAppRevisionSpec) DeepCopy() *CFAppRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
@@ -139,6 +220,7 @@ func (in *CFAppStatus) DeepCopyInto(out *CFAppStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	out.CurrentRevisionRef = in.CurrentRevisionRef
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppStatus.
@@ -255,6 +337,106 @@ func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDeployment) DeepCopyInto(out *CFDeployment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDeployment.
+func (in *CFDeployment) DeepCopy() *CFDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDeploymentList) DeepCopyInto(out *CFDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDeploymentList.
+func (in *CFDeploymentList) DeepCopy() *CFDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDeploymentSpec) DeepCopyInto(out *CFDeploymentSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.DropletRef = in.DropletRef
+	out.RevisionRef = in.RevisionRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDeploymentSpec.
+func (in *CFDeploymentSpec) DeepCopy() *CFDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFDeploymentStatus) DeepCopyInto(out *CFDeploymentStatus) {
+	*out = *in
+	in.LastStatusChange.DeepCopyInto(&out.LastStatusChange)
+	out.DropletRef = in.DropletRef
+	out.PreviousDropletRef = in.PreviousDropletRef
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]DeploymentProcess, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDeploymentStatus.
+func (in *CFDeploymentStatus) DeepCopy() *CFDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFOrg) DeepCopyInto(out *CFOrg) {
 	*out = *in
@@ -314,6 +496,85 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuota) DeepCopyInto(out *CFOrgQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuota.
+func (in *CFOrgQuota) DeepCopy() *CFOrgQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaList) DeepCopyInto(out *CFOrgQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrgQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaList.
+func (in *CFOrgQuotaList) DeepCopy() *CFOrgQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaSpec) DeepCopyInto(out *CFOrgQuotaSpec) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaSpec.
+func (in *CFOrgQuotaSpec) DeepCopy() *CFOrgQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
 	*out = *in
@@ -533,6 +794,11 @@ func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
 	*out = *in
+	if in.ReportedCrashes != nil {
+		in, out := &in.ReportedCrashes, &out.ReportedCrashes
+		*out = make([]ReportedCrash, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -552,6 +818,85 @@ func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSidecar) DeepCopyInto(out *CFSidecar) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecar.
+func (in *CFSidecar) DeepCopy() *CFSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSidecar) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSidecarList) DeepCopyInto(out *CFSidecarList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarList.
+func (in *CFSidecarList) DeepCopy() *CFSidecarList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSidecarList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSidecarList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSidecarSpec) DeepCopyInto(out *CFSidecarSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	if in.ProcessTypes != nil {
+		in, out := &in.ProcessTypes, &out.ProcessTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarSpec.
+func (in *CFSidecarSpec) DeepCopy() *CFSidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
@@ -611,6 +956,85 @@ func (in *CFSpaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuota) DeepCopyInto(out *CFSpaceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuota.
+func (in *CFSpaceQuota) DeepCopy() *CFSpaceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaList) DeepCopyInto(out *CFSpaceQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSpaceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaList.
+func (in *CFSpaceQuotaList) DeepCopy() *CFSpaceQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaSpec) DeepCopyInto(out *CFSpaceQuotaSpec) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaSpec.
+func (in *CFSpaceQuotaSpec) DeepCopy() *CFSpaceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
 	*out = *in
@@ -648,6 +1072,185 @@ func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTask) DeepCopyInto(out *CFTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
+func (in *CFTask) DeepCopy() *CFTask {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
+func (in *CFTaskList) DeepCopy() *CFTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
+func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
+	*out = *in
+	out.DropletRef = in.DropletRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
+func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUser) DeepCopyInto(out *CFUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUser.
+func (in *CFUser) DeepCopy() *CFUser {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUserList) DeepCopyInto(out *CFUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserList.
+func (in *CFUserList) DeepCopy() *CFUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUserSpec) DeepCopyInto(out *CFUserSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUserSpec.
+func (in *CFUserSpec) DeepCopy() *CFUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentProcess) DeepCopyInto(out *DeploymentProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentProcess.
+func (in *DeploymentProcess) DeepCopy() *DeploymentProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -746,6 +1349,46 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaLimits) DeepCopyInto(out *QuotaLimits) {
+	*out = *in
+	if in.TotalMemoryMB != nil {
+		in, out := &in.TotalMemoryMB, &out.TotalMemoryMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InstanceMemoryMB != nil {
+		in, out := &in.InstanceMemoryMB, &out.InstanceMemoryMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AppInstances != nil {
+		in, out := &in.AppInstances, &out.AppInstances
+		*out = new(int)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = new(int)
+		**out = **in
+	}
+	if in.ServiceInstances != nil {
+		in, out := &in.ServiceInstances, &out.ServiceInstances
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaLimits.
+func (in *QuotaLimits) DeepCopy() *QuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -765,3 +1408,33 @@ func (in *Registry) DeepCopy() *Registry {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportedCrash) DeepCopyInto(out *ReportedCrash) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportedCrash.
+func (in *ReportedCrash) DeepCopy() *ReportedCrash {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportedCrash)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionProcess) DeepCopyInto(out *RevisionProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionProcess.
+func (in *RevisionProcess) DeepCopy() *RevisionProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionProcess)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,341 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+
+	"github.com/go-logr/logr"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+const (
+	SecurityGroupFinalizerName = "cfSecurityGroup.networking.cloudfoundry.org"
+
+	runningLifecycle = "running"
+	stagingLifecycle = "staging"
+)
+
+// CFSecurityGroupReconciler translates the rules of a CFSecurityGroup into a NetworkPolicy allowing their egress
+// traffic in the namespace of each space the security group applies to. Running policies select the pods of app
+// processes and tasks, staging policies select kpack build pods.
+//
+// Pods selected by at least one policy can only reach the destinations allowed by their policies, as Kubernetes
+// denies any other egress traffic of such pods.
+type CFSecurityGroupReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Log    logr.Logger
+}
+
+//+kubebuilder:rbac:groups=networking.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=networking.cloudfoundry.org,resources=cfsecuritygroups/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=hnc.x-k8s.io,resources=subnamespaceanchors,verbs=list;watch
+
+func (r *CFSecurityGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfSecurityGroup := new(networkingv1alpha1.CFSecurityGroup)
+	err := r.Client.Get(ctx, req.NamespacedName, cfSecurityGroup)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "failed to get CFSecurityGroup")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cfSecurityGroup.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFSecurityGroup(ctx, cfSecurityGroup)
+	}
+
+	err = r.addFinalizer(ctx, cfSecurityGroup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	runningNamespaces, stagingNamespaces, err := r.boundNamespaces(ctx, cfSecurityGroup)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error listing the spaces of CFSecurityGroup %s/%s", req.Namespace, req.Name))
+		return ctrl.Result{}, err
+	}
+
+	egressRules := r.egressRules(cfSecurityGroup)
+	desiredPolicies := map[types.NamespacedName]bool{}
+
+	for _, namespace := range runningNamespaces {
+		policy, err := r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, namespace, runningLifecycle, runningPodSelector(), egressRules)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		desiredPolicies[client.ObjectKeyFromObject(policy)] = true
+	}
+
+	for _, namespace := range stagingNamespaces {
+		policy, err := r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, namespace, stagingLifecycle, stagingPodSelector(), egressRules)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		desiredPolicies[client.ObjectKeyFromObject(policy)] = true
+	}
+
+	err = r.deleteStaleNetworkPolicies(ctx, cfSecurityGroup, desiredPolicies)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFSecurityGroupReconciler) addFinalizer(ctx context.Context, cfSecurityGroup *networkingv1alpha1.CFSecurityGroup) error {
+	if controllerutil.ContainsFinalizer(cfSecurityGroup, SecurityGroupFinalizerName) {
+		return nil
+	}
+
+	originalCFSecurityGroup := cfSecurityGroup.DeepCopy()
+	controllerutil.AddFinalizer(cfSecurityGroup, SecurityGroupFinalizerName)
+
+	err := r.Client.Patch(ctx, cfSecurityGroup, client.MergeFrom(originalCFSecurityGroup))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error adding finalizer to CFSecurityGroup/%s", cfSecurityGroup.Name))
+		return err
+	}
+
+	return nil
+}
+
+// finalizeCFSecurityGroup deletes the network policies of the security group, which live in other namespaces and
+// therefore cannot be garbage collected through owner references
+func (r *CFSecurityGroupReconciler) finalizeCFSecurityGroup(ctx context.Context, cfSecurityGroup *networkingv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cfSecurityGroup, SecurityGroupFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	err := r.deleteStaleNetworkPolicies(ctx, cfSecurityGroup, map[types.NamespacedName]bool{})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	originalCFSecurityGroup := cfSecurityGroup.DeepCopy()
+	controllerutil.RemoveFinalizer(cfSecurityGroup, SecurityGroupFinalizerName)
+	if err := r.Client.Patch(ctx, cfSecurityGroup, client.MergeFrom(originalCFSecurityGroup)); err != nil {
+		r.Log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// boundNamespaces returns the namespaces of the spaces whose running and staging workloads the security group applies
+// to. Spaces whose namespace is not ready yet are skipped: the security group is reconciled again once it is.
+func (r *CFSecurityGroupReconciler) boundNamespaces(ctx context.Context, cfSecurityGroup *networkingv1alpha1.CFSecurityGroup) ([]string, []string, error) {
+	anchorList := new(hnsv1alpha2.SubnamespaceAnchorList)
+	err := r.Client.List(ctx, anchorList, client.HasLabels{workloads.SpaceNameLabel})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spec := cfSecurityGroup.Spec
+	runningNamespaces := []string{}
+	stagingNamespaces := []string{}
+	for _, anchor := range anchorList.Items {
+		if anchor.Status.State != hnsv1alpha2.Ok {
+			continue
+		}
+
+		if spec.GloballyEnabled.Running || contains(spec.RunningSpaces, anchor.Name) {
+			runningNamespaces = append(runningNamespaces, anchor.Name)
+		}
+		if spec.GloballyEnabled.Staging || contains(spec.StagingSpaces, anchor.Name) {
+			stagingNamespaces = append(stagingNamespaces, anchor.Name)
+		}
+	}
+
+	return runningNamespaces, stagingNamespaces, nil
+}
+
+// egressRules translates the rules of the security group. NetworkPolicies cannot select ICMP traffic, so ICMP rules
+// are skipped rather than widened to all the protocols.
+func (r *CFSecurityGroupReconciler) egressRules(cfSecurityGroup *networkingv1alpha1.CFSecurityGroup) []networkingv1.NetworkPolicyEgressRule {
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+	for i, rule := range cfSecurityGroup.Spec.Rules {
+		if err := rule.Validate(); err != nil {
+			r.Log.Info("skipping invalid rule", "securityGroup", cfSecurityGroup.Name, "rule", i, "reason", err.Error())
+			continue
+		}
+
+		if rule.Protocol == networkingv1alpha1.ProtocolICMP {
+			r.Log.Info("skipping ICMP rule, as network policies do not support ICMP", "securityGroup", cfSecurityGroup.Name, "rule", i)
+			continue
+		}
+
+		egressRule := networkingv1.NetworkPolicyEgressRule{}
+
+		cidrs, _ := rule.DestinationCIDRs()
+		for _, cidr := range cidrs {
+			egressRule.To = append(egressRule.To, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+
+		if rule.Protocol != networkingv1alpha1.ProtocolAll {
+			protocol := corev1.ProtocolTCP
+			if rule.Protocol == networkingv1alpha1.ProtocolUDP {
+				protocol = corev1.ProtocolUDP
+			}
+
+			portRanges, _ := rule.PortRanges()
+			for _, portRange := range portRanges {
+				policyPort := networkingv1.NetworkPolicyPort{
+					Protocol: &protocol,
+					Port:     &intstr.IntOrString{Type: intstr.Int, IntVal: portRange.Start},
+				}
+				if portRange.End != portRange.Start {
+					endPort := portRange.End
+					policyPort.EndPort = &endPort
+				}
+				egressRule.Ports = append(egressRule.Ports, policyPort)
+			}
+		}
+
+		egressRules = append(egressRules, egressRule)
+	}
+
+	return egressRules
+}
+
+func (r *CFSecurityGroupReconciler) createOrPatchNetworkPolicy(
+	ctx context.Context,
+	cfSecurityGroup *networkingv1alpha1.CFSecurityGroup,
+	namespace string,
+	lifecycle string,
+	podSelector metav1.LabelSelector,
+	egressRules []networkingv1.NetworkPolicyEgressRule,
+) (*networkingv1.NetworkPolicy, error) {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", cfSecurityGroup.Name, lifecycle),
+			Namespace: namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, policy, func() error {
+		if policy.Labels == nil {
+			policy.Labels = map[string]string{}
+		}
+		policy.Labels[networkingv1alpha1.CFSecurityGroupGUIDLabelKey] = cfSecurityGroup.Name
+		policy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			Egress:      egressRules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		}
+		return nil
+	})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error creating or patching NetworkPolicy %s/%s", namespace, policy.Name))
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// deleteStaleNetworkPolicies deletes the network policies of the security group that are no longer desired
+func (r *CFSecurityGroupReconciler) deleteStaleNetworkPolicies(ctx context.Context, cfSecurityGroup *networkingv1alpha1.CFSecurityGroup, desiredPolicies map[types.NamespacedName]bool) error {
+	policyList := new(networkingv1.NetworkPolicyList)
+	err := r.Client.List(ctx, policyList, client.MatchingLabels{networkingv1alpha1.CFSecurityGroupGUIDLabelKey: cfSecurityGroup.Name})
+	if err != nil {
+		r.Log.Error(err, "Error listing NetworkPolicies")
+		return err
+	}
+
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if desiredPolicies[client.ObjectKeyFromObject(policy)] {
+			continue
+		}
+
+		if err := r.Client.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, fmt.Sprintf("Error deleting NetworkPolicy %s/%s", policy.Namespace, policy.Name))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runningPodSelector selects the pods of app processes and tasks. kpack build pods inherit the app label of their
+// image, so they are excluded explicitly.
+func runningPodSelector() metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: workloadsv1alpha1.CFAppGUIDLabelKey, Operator: metav1.LabelSelectorOpExists},
+			{Key: buildv1alpha2.BuildLabel, Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	}
+}
+
+// stagingPodSelector selects the kpack build pods of CFBuilds
+func stagingPodSelector() metav1.LabelSelector {
+	return metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: workloadsv1alpha1.CFBuildGUIDLabelKey, Operator: metav1.LabelSelectorOpExists},
+			{Key: buildv1alpha2.BuildLabel, Operator: metav1.LabelSelectorOpExists},
+		},
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CFSecurityGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkingv1alpha1.CFSecurityGroup{}).
+		Watches(&source.Kind{Type: &hnsv1alpha2.SubnamespaceAnchor{}}, handler.EnqueueRequestsFromMapFunc(func(anchor client.Object) []reconcile.Request {
+			if _, ok := anchor.GetLabels()[workloads.SpaceNameLabel]; !ok {
+				return []reconcile.Request{}
+			}
+
+			securityGroupList := new(networkingv1alpha1.CFSecurityGroupList)
+			err := mgr.GetClient().List(context.Background(), securityGroupList)
+			if err != nil {
+				r.Log.Error(err, "failed to list CFSecurityGroups")
+				return []reconcile.Request{}
+			}
+
+			requests := []reconcile.Request{}
+			for i := range securityGroupList.Items {
+				spec := securityGroupList.Items[i].Spec
+				if spec.GloballyEnabled.Running || spec.GloballyEnabled.Staging ||
+					contains(spec.RunningSpaces, anchor.GetName()) || contains(spec.StagingSpaces, anchor.GetName()) {
+					requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&securityGroupList.Items[i])})
+				}
+			}
+
+			return requests
+		})).
+		Complete(r)
+}
@@ -0,0 +1,270 @@
+package networking_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/networking"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("CFSecurityGroupReconciler.Reconcile", func() {
+	var (
+		fakeClient *fake.Client
+
+		cfSecurityGroup  *networkingv1alpha1.CFSecurityGroup
+		anchors          []hnsv1alpha2.SubnamespaceAnchor
+		existingPolicies []networkingv1.NetworkPolicy
+
+		reconciler *CFSecurityGroupReconciler
+		ctx        context.Context
+		req        ctrl.Request
+
+		reconcileErr error
+	)
+
+	spaceAnchor := func(name string, state hnsv1alpha2.SubnamespaceAnchorState) hnsv1alpha2.SubnamespaceAnchor {
+		return hnsv1alpha2.SubnamespaceAnchor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "org-guid",
+				Labels:    map[string]string{"cloudfoundry.org/space-name": name},
+			},
+			Status: hnsv1alpha2.SubnamespaceAnchorStatus{State: state},
+		}
+	}
+
+	createdPolicies := func() []*networkingv1.NetworkPolicy {
+		policies := []*networkingv1.NetworkPolicy{}
+		for i := 0; i < fakeClient.CreateCallCount(); i++ {
+			_, obj, _ := fakeClient.CreateArgsForCall(i)
+			if policy, ok := obj.(*networkingv1.NetworkPolicy); ok {
+				policies = append(policies, policy)
+			}
+		}
+		return policies
+	}
+
+	BeforeEach(func() {
+		fakeClient = new(fake.Client)
+
+		icmpType := 8
+		cfSecurityGroup = &networkingv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "sg-guid",
+				Namespace:  "root-ns",
+				Finalizers: []string{SecurityGroupFinalizerName},
+			},
+			Spec: networkingv1alpha1.CFSecurityGroupSpec{
+				DisplayName: "my-group",
+				Rules: []networkingv1alpha1.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.1-10.0.0.2", Ports: "8000-8080"},
+					{Protocol: "udp", Destination: "10.1.0.0/16", Ports: "53,5353"},
+					{Protocol: "icmp", Destination: "10.0.0.0/8", Type: &icmpType, Code: &icmpType},
+					{Protocol: "all", Destination: "192.168.0.1"},
+				},
+				RunningSpaces: []string{"space-1"},
+				StagingSpaces: []string{"space-2"},
+			},
+		}
+		anchors = []hnsv1alpha2.SubnamespaceAnchor{
+			spaceAnchor("space-1", hnsv1alpha2.Ok),
+			spaceAnchor("space-2", hnsv1alpha2.Ok),
+			spaceAnchor("space-3", hnsv1alpha2.Ok),
+			spaceAnchor("space-4", hnsv1alpha2.Missing),
+		}
+		existingPolicies = nil
+
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *networkingv1alpha1.CFSecurityGroup:
+				cfSecurityGroup.DeepCopyInto(obj)
+				return nil
+			case *networkingv1.NetworkPolicy:
+				return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *hnsv1alpha2.SubnamespaceAnchorList:
+				list.Items = anchors
+			case *networkingv1.NetworkPolicyList:
+				list.Items = existingPolicies
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+			return nil
+		}
+
+		Expect(networkingv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+		reconciler = &CFSecurityGroupReconciler{
+			Client: fakeClient,
+			Scheme: scheme.Scheme,
+			Log:    zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+		}
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      "sg-guid",
+				Namespace: "root-ns",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, reconcileErr = reconciler.Reconcile(ctx, req)
+	})
+
+	It("creates a running policy in the running spaces and a staging policy in the staging spaces", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+
+		policies := createdPolicies()
+		Expect(policies).To(HaveLen(2))
+
+		Expect(policies[0].Namespace).To(Equal("space-1"))
+		Expect(policies[0].Name).To(Equal("sg-guid-running"))
+		Expect(policies[0].Labels).To(HaveKeyWithValue(networkingv1alpha1.CFSecurityGroupGUIDLabelKey, "sg-guid"))
+		Expect(policies[0].Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+		Expect(policies[0].Spec.PodSelector.MatchExpressions).To(ConsistOf(
+			metav1.LabelSelectorRequirement{Key: "workloads.cloudfoundry.org/app-guid", Operator: metav1.LabelSelectorOpExists},
+			metav1.LabelSelectorRequirement{Key: "kpack.io/build", Operator: metav1.LabelSelectorOpDoesNotExist},
+		))
+
+		Expect(policies[1].Namespace).To(Equal("space-2"))
+		Expect(policies[1].Name).To(Equal("sg-guid-staging"))
+		Expect(policies[1].Spec.PodSelector.MatchExpressions).To(ConsistOf(
+			metav1.LabelSelectorRequirement{Key: "workloads.cloudfoundry.org/build-guid", Operator: metav1.LabelSelectorOpExists},
+			metav1.LabelSelectorRequirement{Key: "kpack.io/build", Operator: metav1.LabelSelectorOpExists},
+		))
+	})
+
+	It("translates the tcp, udp and all rules, and skips the icmp rule", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+
+		tcp := corev1.ProtocolTCP
+		udp := corev1.ProtocolUDP
+		endPort := int32(8080)
+		Expect(createdPolicies()[0].Spec.Egress).To(Equal([]networkingv1.NetworkPolicyEgressRule{
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.2/32"}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &tcp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 8000}, EndPort: &endPort},
+				},
+			},
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.1.0.0/16"}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &udp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 53}},
+					{Protocol: &udp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: 5353}},
+				},
+			},
+			{
+				To: []networkingv1.NetworkPolicyPeer{
+					{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+				},
+			},
+		}))
+	})
+
+	When("the security group is globally enabled for running workloads", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.GloballyEnabled.Running = true
+		})
+
+		It("creates running policies in every ready space", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			namespaces := []string{}
+			for _, policy := range createdPolicies() {
+				if policy.Name == "sg-guid-running" {
+					namespaces = append(namespaces, policy.Namespace)
+				}
+			}
+			Expect(namespaces).To(ConsistOf("space-1", "space-2", "space-3"))
+		})
+	})
+
+	When("the security group no longer applies to a space", func() {
+		BeforeEach(func() {
+			existingPolicies = []networkingv1.NetworkPolicy{
+				{ObjectMeta: metav1.ObjectMeta{Name: "sg-guid-running", Namespace: "space-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "sg-guid-running", Namespace: "space-3"}},
+			}
+		})
+
+		It("deletes its stale policies", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(obj.GetNamespace()).To(Equal("space-3"))
+		})
+	})
+
+	When("the security group has no finalizer", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Finalizers = nil
+		})
+
+		It("adds the finalizer", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+			Expect(obj.GetFinalizers()).To(ConsistOf(SecurityGroupFinalizerName))
+		})
+	})
+
+	When("the security group is being deleted", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			existingPolicies = []networkingv1.NetworkPolicy{
+				{ObjectMeta: metav1.ObjectMeta{Name: "sg-guid-running", Namespace: "space-1"}},
+			}
+		})
+
+		It("deletes its policies and removes the finalizer", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(createdPolicies()).To(BeEmpty())
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+			Expect(obj.GetFinalizers()).To(BeEmpty())
+		})
+	})
+
+	When("creating a policy fails", func() {
+		BeforeEach(func() {
+			fakeClient.CreateReturns(errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
@@ -18,11 +18,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 
 	"github.com/go-logr/logr"
 	servicebindingv1beta1 "github.com/servicebinding/service-binding-controller/apis/v1beta1"
@@ -41,11 +44,14 @@ import (
 // CFServiceBindingReconciler reconciles a CFServiceBinding object
 type CFServiceBindingReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme       *runtime.Scheme
+	Log          logr.Logger
+	BrokerClient BrokerClient
 }
 
 const (
+	BindingFinalizerName = "cfServiceBinding.services.cloudfoundry.org"
+
 	BindingSecretAvailableCondition   = "BindingSecretAvailable"
 	ServiceBindingGUIDLabel           = "services.cloudfoundry.org/service-binding-guid"
 	ServiceCredentialBindingTypeLabel = "services.cloudfoundry.org/service-credential-binding-type"
@@ -55,6 +61,7 @@ const (
 //+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebindings/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebindings/finalizers,verbs=update
 //+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 
 func (r *CFServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -67,6 +74,10 @@ func (r *CFServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFServiceBinding(ctx, cfServiceBinding)
+	}
+
 	cfApp := new(workloadsv1alpha1.CFApp)
 	err = r.Client.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
 	if err != nil {
@@ -118,9 +129,23 @@ func (r *CFServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return result, err
 	}
 
+	secretName := instance.Spec.SecretName
+	if instance.Spec.Type == servicesv1alpha1.ManagedType {
+		var bound bool
+		bound, err = r.bindManagedInstance(ctx, cfServiceBinding, instance, cfApp)
+		if !bound {
+			cfServiceBinding.Status.Binding.Name = ""
+			statusErr := r.setStatus(ctx, cfServiceBinding)
+			if statusErr != nil {
+				return ctrl.Result{}, statusErr
+			}
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, err
+		}
+		secretName = cfServiceBinding.Name
+	}
+
 	secret := new(corev1.Secret)
-	// Note: is there a reason to fetch the secret name from the service instance spec?
-	err = r.Client.Get(ctx, types.NamespacedName{Name: instance.Spec.SecretName, Namespace: req.Namespace}, secret)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: secretName, Namespace: req.Namespace}, secret)
 	if err != nil {
 		var result ctrl.Result
 		if apierrors.IsNotFound(err) {
@@ -148,7 +173,7 @@ func (r *CFServiceBindingReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 		return result, err
 	} else {
-		cfServiceBinding.Status.Binding.Name = instance.Spec.SecretName
+		cfServiceBinding.Status.Binding.Name = secretName
 		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 			Type:    BindingSecretAvailableCondition,
 			Status:  metav1.ConditionTrue,
@@ -234,6 +259,174 @@ func generateDesiredServiceBinding(actualServiceBinding *servicebindingv1beta1.S
 	return &desiredServiceBinding
 }
 
+// bindManagedInstance asks the broker for credentials and stores them in a secret named after the binding.
+// It returns true once the secret exists.
+func (r *CFServiceBindingReconciler) bindManagedInstance(ctx context.Context, cfServiceBinding *servicesv1alpha1.CFServiceBinding, instance *servicesv1alpha1.CFServiceInstance, cfApp *workloadsv1alpha1.CFApp) (bool, error) {
+	if err := r.addFinalizer(ctx, cfServiceBinding); err != nil {
+		return false, err
+	}
+
+	lastOperation := instance.Status.LastOperation
+	if lastOperation == nil || lastOperation.Type != servicesv1alpha1.CreateOperationType || lastOperation.State != servicesv1alpha1.OperationStateSucceeded {
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:    BindingSecretAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ServiceInstanceNotReady",
+			Message: "Service instance is not provisioned",
+		})
+		return false, nil
+	}
+
+	secret := new(corev1.Secret)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Name, Namespace: cfServiceBinding.Namespace}, secret)
+	if err == nil {
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, r.bindingFailed(cfServiceBinding, fmt.Errorf("error fetching the binding secret: %w", err))
+	}
+
+	planContext, err := getServicePlanContext(ctx, r.Client, instance.Spec.ServicePlanRef)
+	if err != nil {
+		return false, r.bindingFailed(cfServiceBinding, err)
+	}
+
+	response, err := r.BrokerClient.Bind(ctx, planContext.broker, osbapi.BindRequest{
+		InstanceID: instance.Name,
+		BindingID:  cfServiceBinding.Name,
+		ServiceID:  planContext.offering.Spec.BrokerCatalog.ID,
+		PlanID:     planContext.plan.Spec.BrokerCatalog.ID,
+		AppGUID:    cfApp.Name,
+		Context: map[string]interface{}{
+			"platform":   "cloudfoundry",
+			"space_guid": cfServiceBinding.Namespace,
+			"app_guid":   cfApp.Name,
+		},
+	})
+	if err != nil {
+		return false, r.bindingFailed(cfServiceBinding, err)
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name,
+			Namespace: cfServiceBinding.Namespace,
+			Labels: map[string]string{
+				ServiceBindingGUIDLabel: cfServiceBinding.Name,
+			},
+		},
+		StringData: map[string]string{
+			"type": planContext.offering.Spec.Name,
+		},
+	}
+	for key, value := range response.Credentials {
+		secret.StringData[key], err = credentialValue(value)
+		if err != nil {
+			return false, r.bindingFailed(cfServiceBinding, err)
+		}
+	}
+
+	if err = controllerutil.SetControllerReference(cfServiceBinding, secret, r.Scheme); err != nil {
+		return false, r.bindingFailed(cfServiceBinding, err)
+	}
+
+	if err = r.Client.Create(ctx, secret); err != nil {
+		return false, r.bindingFailed(cfServiceBinding, fmt.Errorf("error creating the binding secret: %w", err))
+	}
+
+	return true, nil
+}
+
+// credentialValue flattens a credential of the broker into a secret value. Non-string values are stored as JSON.
+func credentialValue(value interface{}) (string, error) {
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+
+	jsonValue, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error encoding credential: %w", err)
+	}
+	return string(jsonValue), nil
+}
+
+func (r *CFServiceBindingReconciler) bindingFailed(cfServiceBinding *servicesv1alpha1.CFServiceBinding, err error) error {
+	r.Log.Error(err, fmt.Sprintf("Error binding CFServiceBinding %s/%s", cfServiceBinding.Namespace, cfServiceBinding.Name))
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:    BindingSecretAvailableCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "BindingFailed",
+		Message: err.Error(),
+	})
+	return err
+}
+
+func (r *CFServiceBindingReconciler) addFinalizer(ctx context.Context, cfServiceBinding *servicesv1alpha1.CFServiceBinding) error {
+	if controllerutil.ContainsFinalizer(cfServiceBinding, BindingFinalizerName) {
+		return nil
+	}
+
+	originalCFServiceBinding := cfServiceBinding.DeepCopy()
+	controllerutil.AddFinalizer(cfServiceBinding, BindingFinalizerName)
+
+	err := r.Client.Patch(ctx, cfServiceBinding, client.MergeFrom(originalCFServiceBinding))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error adding finalizer to CFServiceBinding/%s", cfServiceBinding.Name))
+		return err
+	}
+
+	return nil
+}
+
+// finalizeCFServiceBinding unbinds bindings of managed service instances from the broker
+func (r *CFServiceBindingReconciler) finalizeCFServiceBinding(ctx context.Context, cfServiceBinding *servicesv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cfServiceBinding, BindingFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	err := r.unbind(ctx, cfServiceBinding)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error unbinding CFServiceBinding %s/%s", cfServiceBinding.Namespace, cfServiceBinding.Name))
+		return ctrl.Result{}, err
+	}
+
+	originalCFServiceBinding := cfServiceBinding.DeepCopy()
+	controllerutil.RemoveFinalizer(cfServiceBinding, BindingFinalizerName)
+	if err = r.Client.Patch(ctx, cfServiceBinding, client.MergeFrom(originalCFServiceBinding)); err != nil {
+		r.Log.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFServiceBindingReconciler) unbind(ctx context.Context, cfServiceBinding *servicesv1alpha1.CFServiceBinding) error {
+	instance := new(servicesv1alpha1.CFServiceInstance)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, instance)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	planContext, err := getServicePlanContext(ctx, r.Client, instance.Spec.ServicePlanRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	err = r.BrokerClient.Unbind(ctx, planContext.broker, osbapi.UnbindRequest{
+		InstanceID: instance.Name,
+		BindingID:  cfServiceBinding.Name,
+		ServiceID:  planContext.offering.Spec.BrokerCatalog.ID,
+		PlanID:     planContext.plan.Spec.BrokerCatalog.ID,
+	})
+	if errors.Is(err, osbapi.ErrGone) {
+		return nil
+	}
+	return err
+}
+
 func (r *CFServiceBindingReconciler) setStatus(ctx context.Context, cfServiceBinding *servicesv1alpha1.CFServiceBinding) error {
 	if statusErr := r.Client.Status().Update(ctx, cfServiceBinding); statusErr != nil {
 		r.Log.Error(statusErr, "unable to update CFServiceBinding status")
@@ -18,6 +18,7 @@ import (
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/fake"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -34,6 +35,7 @@ var _ = Describe("CFServiceBinding.Reconcile", func() {
 	var (
 		fakeClient       *fake.Client
 		fakeStatusWriter *fake.StatusWriter
+		fakeBrokerClient *fake.BrokerClient
 
 		cfServiceBinding        *servicesv1alpha1.CFServiceBinding
 		cfServiceInstance       *servicesv1alpha1.CFServiceInstance
@@ -83,6 +85,7 @@ var _ = Describe("CFServiceBinding.Reconcile", func() {
 		fakeClient = new(fake.Client)
 		fakeStatusWriter = new(fake.StatusWriter)
 		fakeClient.StatusReturns(fakeStatusWriter)
+		fakeBrokerClient = new(fake.BrokerClient)
 
 		cfServiceBinding = new(servicesv1alpha1.CFServiceBinding)
 		cfServiceInstance = new(servicesv1alpha1.CFServiceInstance)
@@ -138,9 +141,10 @@ var _ = Describe("CFServiceBinding.Reconcile", func() {
 		Expect(servicebindingv1beta1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 		cfServiceBindingReconciler = &CFServiceBindingReconciler{
-			Client: fakeClient,
-			Scheme: scheme.Scheme,
-			Log:    zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			Client:       fakeClient,
+			Scheme:       scheme.Scheme,
+			Log:          zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			BrokerClient: fakeBrokerClient,
 		}
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -332,4 +336,210 @@ var _ = Describe("CFServiceBinding.Reconcile", func() {
 			})
 		})
 	})
+
+	When("the CFServiceBinding is bound to a managed service instance", func() {
+		var getBindingSecretError error
+
+		BeforeEach(func() {
+			getBindingSecretError = apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, req.Name)
+			getSBServiceBindingError = apierrors.NewNotFound(schema.GroupResource{Group: "servicebinding.io", Resource: "ServiceBinding"}, "foo")
+			cfServiceBinding.Name = req.Name
+			cfServiceBinding.Namespace = req.Namespace
+			cfServiceInstance.Spec = servicesv1alpha1.CFServiceInstanceSpec{
+				Name:           "my-db",
+				Type:           servicesv1alpha1.ManagedType,
+				ServicePlanRef: &corev1.ObjectReference{Name: "plan-guid", Namespace: "root-ns"},
+			}
+			cfServiceInstance.Status.LastOperation = &servicesv1alpha1.ServiceInstanceOperation{
+				Type:  servicesv1alpha1.CreateOperationType,
+				State: servicesv1alpha1.OperationStateSucceeded,
+			}
+
+			baseGetStub := fakeClient.GetStub
+			fakeClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+				switch obj := obj.(type) {
+				case *servicesv1alpha1.CFServicePlan:
+					obj.Name = key.Name
+					obj.Namespace = key.Namespace
+					obj.Spec.BrokerCatalog.ID = "plan-id"
+					obj.Spec.ServiceOfferingRef.Name = "offering-guid"
+					return nil
+				case *servicesv1alpha1.CFServiceOffering:
+					obj.Name = key.Name
+					obj.Namespace = key.Namespace
+					obj.Spec.Name = "postgres"
+					obj.Spec.BrokerCatalog.ID = "service-id"
+					obj.Spec.ServiceBrokerRef.Name = "broker-guid"
+					return nil
+				case *servicesv1alpha1.CFServiceBroker:
+					obj.Name = key.Name
+					obj.Namespace = key.Namespace
+					obj.Spec.URL = "https://broker.example.com"
+					obj.Spec.Credentials.Name = "broker-credentials"
+					return nil
+				case *corev1.Secret:
+					switch key.Name {
+					case "broker-credentials":
+						obj.Data = map[string][]byte{"username": []byte("user"), "password": []byte("pass")}
+						return nil
+					case req.Name:
+						if getBindingSecretError == nil {
+							obj.Name = key.Name
+						}
+						return getBindingSecretError
+					}
+				}
+				return baseGetStub(ctx, key, obj)
+			}
+
+			fakeClient.PatchStub = func(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+				return nil
+			}
+
+			fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				if _, ok := obj.(*corev1.Secret); ok {
+					getBindingSecretError = nil
+				}
+				return nil
+			}
+
+			fakeBrokerClient.BindReturns(osbapi.BindResponse{
+				Credentials: map[string]interface{}{"username": "db-user", "port": float64(5432)},
+			}, nil)
+		})
+
+		It("binds the service instance with the broker", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+
+			Expect(fakeBrokerClient.BindCallCount()).To(Equal(1))
+			_, broker, bindRequest := fakeBrokerClient.BindArgsForCall(0)
+			Expect(broker).To(Equal(osbapi.Broker{URL: "https://broker.example.com", Username: "user", Password: "pass"}))
+			Expect(bindRequest.InstanceID).To(Equal(cfServiceInstanceName))
+			Expect(bindRequest.BindingID).To(Equal(req.Name))
+			Expect(bindRequest.ServiceID).To(Equal("service-id"))
+			Expect(bindRequest.PlanID).To(Equal("plan-id"))
+			Expect(bindRequest.AppGUID).To(Equal(cfAppName))
+		})
+
+		It("adds a finalizer to the binding", func() {
+			Expect(fakeClient.PatchCallCount()).To(BeNumerically(">=", 2))
+			_, obj, _, _ := fakeClient.PatchArgsForCall(1)
+			Expect(obj.GetFinalizers()).To(ContainElement("cfServiceBinding.services.cloudfoundry.org"))
+		})
+
+		It("stores the credentials in a secret owned by the binding", func() {
+			Expect(fakeClient.CreateCallCount()).To(BeNumerically(">=", 1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			secret, ok := obj.(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+			Expect(secret.Name).To(Equal(req.Name))
+			Expect(secret.Namespace).To(Equal(req.Namespace))
+			Expect(secret.StringData).To(Equal(map[string]string{
+				"type":     "postgres",
+				"username": "db-user",
+				"port":     "5432",
+			}))
+			Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFServiceBinding"),
+			})))
+		})
+
+		It("refers to the binding secret in the status", func() {
+			_, serviceBindingObj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+			updatedCFServiceBinding := serviceBindingObj.(*servicesv1alpha1.CFServiceBinding)
+			Expect(updatedCFServiceBinding.Status.Binding.Name).To(Equal(req.Name))
+		})
+
+		When("the binding secret already exists", func() {
+			BeforeEach(func() {
+				getBindingSecretError = nil
+			})
+
+			It("does not bind again", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeBrokerClient.BindCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service instance is not provisioned yet", func() {
+			BeforeEach(func() {
+				cfServiceInstance.Status.LastOperation.State = servicesv1alpha1.OperationStateInProgress
+			})
+
+			It("requeues without binding", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 2 * time.Second}))
+				Expect(fakeBrokerClient.BindCallCount()).To(BeZero())
+
+				_, serviceBindingObj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				updatedCFServiceBinding := serviceBindingObj.(*servicesv1alpha1.CFServiceBinding)
+				Expect(updatedCFServiceBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("BindingSecretAvailable"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("ServiceInstanceNotReady"),
+				})))
+			})
+		})
+
+		When("the broker fails to bind", func() {
+			BeforeEach(func() {
+				fakeBrokerClient.BindReturns(osbapi.BindResponse{}, errors.New("bind-failed"))
+			})
+
+			It("returns the error and records it in the status", func() {
+				Expect(reconcileErr).To(MatchError("bind-failed"))
+
+				_, serviceBindingObj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				updatedCFServiceBinding := serviceBindingObj.(*servicesv1alpha1.CFServiceBinding)
+				Expect(updatedCFServiceBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal("BindingSecretAvailable"),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("BindingFailed"),
+					"Message": Equal("bind-failed"),
+				})))
+			})
+		})
+
+		When("the binding is being deleted", func() {
+			BeforeEach(func() {
+				cfServiceBinding.Finalizers = []string{"cfServiceBinding.services.cloudfoundry.org"}
+				cfServiceBinding.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("unbinds from the broker and removes the finalizer", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+
+				Expect(fakeBrokerClient.UnbindCallCount()).To(Equal(1))
+				_, _, unbindRequest := fakeBrokerClient.UnbindArgsForCall(0)
+				Expect(unbindRequest.BindingID).To(Equal(cfServiceBinding.Name))
+				Expect(unbindRequest.PlanID).To(Equal("plan-id"))
+
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				Expect(obj.GetFinalizers()).To(BeEmpty())
+			})
+
+			When("the broker has already forgotten the binding", func() {
+				BeforeEach(func() {
+					fakeBrokerClient.UnbindReturns(osbapi.ErrGone)
+				})
+
+				It("removes the finalizer", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				})
+			})
+
+			When("unbinding fails", func() {
+				BeforeEach(func() {
+					fakeBrokerClient.UnbindReturns(errors.New("unbind-failed"))
+				})
+
+				It("returns the error and keeps the finalizer", func() {
+					Expect(reconcileErr).To(MatchError("unbind-failed"))
+					Expect(fakeClient.PatchCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
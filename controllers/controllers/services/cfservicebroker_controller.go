@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	CatalogSyncedCondition = "CatalogSynced"
+
+	// catalogResyncPeriod is how often the catalog of a broker is fetched again
+	catalogResyncPeriod = 5 * time.Minute
+)
+
+// CFServiceBrokerReconciler syncs the catalog of a CFServiceBroker into CFServiceOfferings and CFServicePlans
+type CFServiceBrokerReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	Log          logr.Logger
+	BrokerClient BrokerClient
+}
+
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebrokers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebrokers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CFServiceBrokerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfServiceBroker := new(servicesv1alpha1.CFServiceBroker)
+	err := r.Client.Get(ctx, req.NamespacedName, cfServiceBroker)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.Log.Error(err, "unable to fetch CFServiceBroker", req.Name, req.Namespace)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !cfServiceBroker.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	err = r.syncCatalog(ctx, cfServiceBroker)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error syncing the catalog of CFServiceBroker %s/%s", req.Namespace, req.Name))
+		meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+			Type:    CatalogSyncedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "CatalogSyncFailed",
+			Message: err.Error(),
+		})
+	} else {
+		meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+			Type:    CatalogSyncedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "CatalogSynced",
+			Message: "",
+		})
+	}
+
+	if statusErr := r.Client.Status().Update(ctx, cfServiceBroker); statusErr != nil {
+		r.Log.Error(statusErr, "unable to update CFServiceBroker status")
+		return ctrl.Result{}, statusErr
+	}
+
+	return ctrl.Result{RequeueAfter: catalogResyncPeriod}, err
+}
+
+func (r *CFServiceBrokerReconciler) syncCatalog(ctx context.Context, cfServiceBroker *servicesv1alpha1.CFServiceBroker) error {
+	broker, err := getBroker(ctx, r.Client, cfServiceBroker)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := r.BrokerClient.GetCatalog(ctx, broker)
+	if err != nil {
+		return fmt.Errorf("error fetching the catalog: %w", err)
+	}
+
+	offeringNames := map[string]bool{}
+	planNames := map[string]bool{}
+	for _, service := range catalog.Services {
+		offering, err := r.createOrPatchOffering(ctx, cfServiceBroker, service)
+		if err != nil {
+			return err
+		}
+		offeringNames[offering.Name] = true
+
+		for _, plan := range service.Plans {
+			cfServicePlan, err := r.createOrPatchPlan(ctx, cfServiceBroker, offering, plan)
+			if err != nil {
+				return err
+			}
+			planNames[cfServicePlan.Name] = true
+		}
+	}
+
+	return r.deleteStaleEntries(ctx, cfServiceBroker, offeringNames, planNames)
+}
+
+func (r *CFServiceBrokerReconciler) createOrPatchOffering(ctx context.Context, cfServiceBroker *servicesv1alpha1.CFServiceBroker, service osbapi.Service) (*servicesv1alpha1.CFServiceOffering, error) {
+	offering := &servicesv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogEntryGUID(cfServiceBroker.Name, service.ID),
+			Namespace: cfServiceBroker.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, offering, func() error {
+		if offering.Labels == nil {
+			offering.Labels = map[string]string{}
+		}
+		offering.Labels[servicesv1alpha1.CFServiceBrokerGUIDLabelKey] = cfServiceBroker.Name
+		offering.Spec = servicesv1alpha1.CFServiceOfferingSpec{
+			Name:             service.Name,
+			Description:      service.Description,
+			Tags:             service.Tags,
+			Bindable:         service.Bindable,
+			BrokerCatalog:    servicesv1alpha1.ServiceBrokerCatalog{ID: service.ID},
+			ServiceBrokerRef: corev1.LocalObjectReference{Name: cfServiceBroker.Name},
+		}
+		return controllerutil.SetControllerReference(cfServiceBroker, offering, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating or patching CFServiceOffering %q: %w", service.Name, err)
+	}
+
+	return offering, nil
+}
+
+func (r *CFServiceBrokerReconciler) createOrPatchPlan(ctx context.Context, cfServiceBroker *servicesv1alpha1.CFServiceBroker, offering *servicesv1alpha1.CFServiceOffering, plan osbapi.Plan) (*servicesv1alpha1.CFServicePlan, error) {
+	cfServicePlan := &servicesv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogEntryGUID(offering.Name, plan.ID),
+			Namespace: cfServiceBroker.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, cfServicePlan, func() error {
+		if cfServicePlan.Labels == nil {
+			cfServicePlan.Labels = map[string]string{}
+		}
+		cfServicePlan.Labels[servicesv1alpha1.CFServiceBrokerGUIDLabelKey] = cfServiceBroker.Name
+		cfServicePlan.Labels[servicesv1alpha1.CFServiceOfferingGUIDLabelKey] = offering.Name
+		cfServicePlan.Spec = servicesv1alpha1.CFServicePlanSpec{
+			Name:               plan.Name,
+			Description:        plan.Description,
+			Free:               plan.IsFree(),
+			BrokerCatalog:      servicesv1alpha1.ServiceBrokerCatalog{ID: plan.ID},
+			ServiceOfferingRef: corev1.LocalObjectReference{Name: offering.Name},
+		}
+		return controllerutil.SetControllerReference(cfServiceBroker, cfServicePlan, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating or patching CFServicePlan %q: %w", plan.Name, err)
+	}
+
+	return cfServicePlan, nil
+}
+
+// deleteStaleEntries deletes the offerings and plans of the broker that are no longer in its catalog
+func (r *CFServiceBrokerReconciler) deleteStaleEntries(ctx context.Context, cfServiceBroker *servicesv1alpha1.CFServiceBroker, offeringNames, planNames map[string]bool) error {
+	brokerSelector := client.MatchingLabels{servicesv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name}
+
+	planList := new(servicesv1alpha1.CFServicePlanList)
+	if err := r.Client.List(ctx, planList, client.InNamespace(cfServiceBroker.Namespace), brokerSelector); err != nil {
+		return fmt.Errorf("error listing CFServicePlans: %w", err)
+	}
+	for i := range planList.Items {
+		if planNames[planList.Items[i].Name] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &planList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting CFServicePlan %q: %w", planList.Items[i].Name, err)
+		}
+	}
+
+	offeringList := new(servicesv1alpha1.CFServiceOfferingList)
+	if err := r.Client.List(ctx, offeringList, client.InNamespace(cfServiceBroker.Namespace), brokerSelector); err != nil {
+		return fmt.Errorf("error listing CFServiceOfferings: %w", err)
+	}
+	for i := range offeringList.Items {
+		if offeringNames[offeringList.Items[i].Name] {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &offeringList.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("error deleting CFServiceOffering %q: %w", offeringList.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// catalogEntryGUID derives a stable GUID for an entry of the catalog of a broker from the GUID of its parent
+func catalogEntryGUID(parentGUID, catalogID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(parentGUID+"/"+catalogID)).String()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CFServiceBrokerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&servicesv1alpha1.CFServiceBroker{}).
+		Complete(r)
+}
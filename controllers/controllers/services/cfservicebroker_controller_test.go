@@ -0,0 +1,248 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/fake"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("CFServiceBroker.Reconcile", func() {
+	var (
+		fakeClient       *fake.Client
+		fakeStatusWriter *fake.StatusWriter
+		fakeBrokerClient *fake.BrokerClient
+
+		cfServiceBroker   *servicesv1alpha1.CFServiceBroker
+		credentialsSecret *corev1.Secret
+		existingPlans     []servicesv1alpha1.CFServicePlan
+		existingOfferings []servicesv1alpha1.CFServiceOffering
+
+		reconciler *CFServiceBrokerReconciler
+		ctx        context.Context
+		req        ctrl.Request
+
+		reconcileResult ctrl.Result
+		reconcileErr    error
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(fake.Client)
+		fakeStatusWriter = new(fake.StatusWriter)
+		fakeClient.StatusReturns(fakeStatusWriter)
+		fakeBrokerClient = new(fake.BrokerClient)
+
+		cfServiceBroker = &servicesv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "broker-guid",
+				Namespace: "root-ns",
+			},
+			Spec: servicesv1alpha1.CFServiceBrokerSpec{
+				Name:        "my-broker",
+				URL:         "https://broker.example.com",
+				Credentials: corev1.LocalObjectReference{Name: "broker-credentials"},
+			},
+		}
+		credentialsSecret = &corev1.Secret{
+			Data: map[string][]byte{
+				"username": []byte("user"),
+				"password": []byte("pass"),
+			},
+		}
+		existingPlans = nil
+		existingOfferings = nil
+
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *servicesv1alpha1.CFServiceBroker:
+				cfServiceBroker.DeepCopyInto(obj)
+				return nil
+			case *corev1.Secret:
+				credentialsSecret.DeepCopyInto(obj)
+				return nil
+			case *servicesv1alpha1.CFServiceOffering, *servicesv1alpha1.CFServicePlan:
+				return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *servicesv1alpha1.CFServicePlanList:
+				list.Items = existingPlans
+			case *servicesv1alpha1.CFServiceOfferingList:
+				list.Items = existingOfferings
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+			return nil
+		}
+
+		fakeBrokerClient.GetCatalogReturns(osbapi.Catalog{
+			Services: []osbapi.Service{{
+				ID:          "service-id",
+				Name:        "postgres",
+				Description: "a database",
+				Tags:        []string{"sql"},
+				Bindable:    true,
+				Plans: []osbapi.Plan{
+					{ID: "small-id", Name: "small", Description: "a small database"},
+				},
+			}},
+		}, nil)
+
+		Expect(servicesv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+		reconciler = &CFServiceBrokerReconciler{
+			Client:       fakeClient,
+			Scheme:       scheme.Scheme,
+			Log:          zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			BrokerClient: fakeBrokerClient,
+		}
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      "broker-guid",
+				Namespace: "root-ns",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		reconcileResult, reconcileErr = reconciler.Reconcile(ctx, req)
+	})
+
+	It("fetches the catalog using the broker credentials", func() {
+		Expect(fakeBrokerClient.GetCatalogCallCount()).To(Equal(1))
+		_, broker := fakeBrokerClient.GetCatalogArgsForCall(0)
+		Expect(broker).To(Equal(osbapi.Broker{
+			URL:      "https://broker.example.com",
+			Username: "user",
+			Password: "pass",
+		}))
+	})
+
+	It("creates an offering and a plan for the catalog entries", func() {
+		Expect(reconcileErr).NotTo(HaveOccurred())
+		Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Minute}))
+
+		Expect(fakeClient.CreateCallCount()).To(Equal(2))
+
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		offering, ok := obj.(*servicesv1alpha1.CFServiceOffering)
+		Expect(ok).To(BeTrue())
+		Expect(offering.Namespace).To(Equal("root-ns"))
+		Expect(offering.Labels).To(HaveKeyWithValue(servicesv1alpha1.CFServiceBrokerGUIDLabelKey, "broker-guid"))
+		Expect(offering.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Kind": Equal("CFServiceBroker"),
+			"Name": Equal("broker-guid"),
+		})))
+		Expect(offering.Spec).To(Equal(servicesv1alpha1.CFServiceOfferingSpec{
+			Name:             "postgres",
+			Description:      "a database",
+			Tags:             []string{"sql"},
+			Bindable:         true,
+			BrokerCatalog:    servicesv1alpha1.ServiceBrokerCatalog{ID: "service-id"},
+			ServiceBrokerRef: corev1.LocalObjectReference{Name: "broker-guid"},
+		}))
+
+		_, obj, _ = fakeClient.CreateArgsForCall(1)
+		plan, ok := obj.(*servicesv1alpha1.CFServicePlan)
+		Expect(ok).To(BeTrue())
+		Expect(plan.Labels).To(HaveKeyWithValue(servicesv1alpha1.CFServiceOfferingGUIDLabelKey, offering.Name))
+		Expect(plan.Spec).To(Equal(servicesv1alpha1.CFServicePlanSpec{
+			Name:               "small",
+			Description:        "a small database",
+			Free:               true,
+			BrokerCatalog:      servicesv1alpha1.ServiceBrokerCatalog{ID: "small-id"},
+			ServiceOfferingRef: corev1.LocalObjectReference{Name: offering.Name},
+		}))
+	})
+
+	It("names the offerings and plans deterministically", func() {
+		_, offering, _ := fakeClient.CreateArgsForCall(0)
+		_, plan, _ := fakeClient.CreateArgsForCall(1)
+
+		_, _ = reconciler.Reconcile(ctx, req)
+		Expect(fakeClient.CreateCallCount()).To(Equal(4))
+		_, secondOffering, _ := fakeClient.CreateArgsForCall(2)
+		_, secondPlan, _ := fakeClient.CreateArgsForCall(3)
+
+		Expect(secondOffering.GetName()).To(Equal(offering.GetName()))
+		Expect(secondPlan.GetName()).To(Equal(plan.GetName()))
+		Expect(offering.GetName()).NotTo(Equal(plan.GetName()))
+	})
+
+	It("sets the CatalogSynced condition", func() {
+		Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+		_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+		Expect(obj.(*servicesv1alpha1.CFServiceBroker).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+			"Type":   Equal("CatalogSynced"),
+			"Status": Equal(metav1.ConditionTrue),
+		})))
+	})
+
+	When("entries have been removed from the catalog", func() {
+		BeforeEach(func() {
+			existingOfferings = []servicesv1alpha1.CFServiceOffering{{ObjectMeta: metav1.ObjectMeta{Name: "stale-offering"}}}
+			existingPlans = []servicesv1alpha1.CFServicePlan{{ObjectMeta: metav1.ObjectMeta{Name: "stale-plan"}}}
+		})
+
+		It("deletes the stale offerings and plans", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(Equal(2))
+			_, deletedPlan, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(deletedPlan.GetName()).To(Equal("stale-plan"))
+			_, deletedOffering, _ := fakeClient.DeleteArgsForCall(1)
+			Expect(deletedOffering.GetName()).To(Equal("stale-offering"))
+		})
+	})
+
+	When("fetching the catalog fails", func() {
+		BeforeEach(func() {
+			fakeBrokerClient.GetCatalogReturns(osbapi.Catalog{}, errors.New("broker-down"))
+		})
+
+		It("returns the error and records it in the status", func() {
+			Expect(reconcileErr).To(MatchError(ContainSubstring("broker-down")))
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+
+			Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+			_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+			Expect(obj.(*servicesv1alpha1.CFServiceBroker).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":    Equal("CatalogSynced"),
+				"Status":  Equal(metav1.ConditionFalse),
+				"Reason":  Equal("CatalogSyncFailed"),
+				"Message": ContainSubstring("broker-down"),
+			})))
+		})
+	})
+
+	When("updating the status fails", func() {
+		BeforeEach(func() {
+			fakeStatusWriter.UpdateReturns(errors.New("status-failed"))
+		})
+
+		It("returns the error", func() {
+			Expect(reconcileErr).To(MatchError("status-failed"))
+		})
+	})
+})
@@ -18,10 +18,13 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -42,18 +45,26 @@ import (
 
 const (
 	FinalizerName = "cfServiceInstance.services.cloudfoundry.org"
+
+	ProvisionedCondition = "Provisioned"
+
+	// lastOperationPollInterval is how often the broker is polled for the state of an asynchronous operation
+	lastOperationPollInterval = 5 * time.Second
 )
 
 // CFServiceInstanceReconciler reconciles a CFServiceInstance object
 type CFServiceInstanceReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme       *runtime.Scheme
+	Log          logr.Logger
+	BrokerClient BrokerClient
 }
 
 //+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups=services.cloudfoundry.org,resources=cfservicebrokers;cfserviceofferings;cfserviceplans,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *CFServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -77,6 +88,10 @@ func (r *CFServiceInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return r.finalizeCFServiceInstance(ctx, cfServiceInstance)
 	}
 
+	if cfServiceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+		return r.reconcileManagedInstance(ctx, cfServiceInstance)
+	}
+
 	secret := new(corev1.Secret)
 	var errorReturn error
 	err = r.Client.Get(ctx, types.NamespacedName{Name: cfServiceInstance.Spec.SecretName, Namespace: req.Namespace}, secret)
@@ -164,6 +179,18 @@ func (r *CFServiceInstanceReconciler) finalizeCFServiceInstance(ctx context.Cont
 		}
 	}
 
+	if cfServiceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+		// the bindings have to be unbound by the broker before the service instance can be deprovisioned
+		if len(cfServiceBindingList.Items) > 0 {
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+
+		done, err := r.deprovision(ctx, cfServiceInstance)
+		if err != nil || !done {
+			return ctrl.Result{RequeueAfter: lastOperationPollInterval}, err
+		}
+	}
+
 	controllerutil.RemoveFinalizer(cfServiceInstance, FinalizerName)
 	if err := r.Client.Update(ctx, cfServiceInstance); err != nil {
 		r.Log.Error(err, "Failed to remove finalizer")
@@ -173,6 +200,205 @@ func (r *CFServiceInstanceReconciler) finalizeCFServiceInstance(ctx context.Cont
 	return ctrl.Result{}, nil
 }
 
+func (r *CFServiceInstanceReconciler) reconcileManagedInstance(ctx context.Context, cfServiceInstance *servicesv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation != nil && lastOperation.State != servicesv1alpha1.OperationStateInProgress {
+		return ctrl.Result{}, nil
+	}
+
+	planContext, err := getServicePlanContext(ctx, r.Client, cfServiceInstance.Spec.ServicePlanRef)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error resolving the service plan of CFServiceInstance %s/%s", cfServiceInstance.Namespace, cfServiceInstance.Name))
+		return ctrl.Result{}, err
+	}
+
+	if lastOperation == nil {
+		return r.provision(ctx, cfServiceInstance, planContext)
+	}
+
+	response, err := r.BrokerClient.LastOperation(ctx, planContext.broker, osbapi.LastOperationRequest{
+		InstanceID: cfServiceInstance.Name,
+		ServiceID:  planContext.offering.Spec.BrokerCatalog.ID,
+		PlanID:     planContext.plan.Spec.BrokerCatalog.ID,
+		Operation:  lastOperation.BrokerOperation,
+	})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error polling the last operation of CFServiceInstance %s/%s", cfServiceInstance.Namespace, cfServiceInstance.Name))
+		return ctrl.Result{}, err
+	}
+
+	if response.State == osbapi.StateInProgress {
+		return ctrl.Result{RequeueAfter: lastOperationPollInterval}, nil
+	}
+
+	return ctrl.Result{}, r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+		Type:        servicesv1alpha1.CreateOperationType,
+		State:       response.State,
+		Description: response.Description,
+	})
+}
+
+func (r *CFServiceInstanceReconciler) provision(ctx context.Context, cfServiceInstance *servicesv1alpha1.CFServiceInstance, planContext servicePlanContext) (ctrl.Result, error) {
+	orgGUID, err := r.getOrgGUID(ctx, cfServiceInstance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	response, err := r.BrokerClient.Provision(ctx, planContext.broker, osbapi.ProvisionRequest{
+		InstanceID:       cfServiceInstance.Name,
+		ServiceID:        planContext.offering.Spec.BrokerCatalog.ID,
+		PlanID:           planContext.plan.Spec.BrokerCatalog.ID,
+		OrganizationGUID: orgGUID,
+		SpaceGUID:        cfServiceInstance.Namespace,
+		Context: map[string]interface{}{
+			"platform":          "cloudfoundry",
+			"organization_guid": orgGUID,
+			"space_guid":        cfServiceInstance.Namespace,
+			"instance_name":     cfServiceInstance.Spec.Name,
+		},
+	})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error provisioning CFServiceInstance %s/%s", cfServiceInstance.Namespace, cfServiceInstance.Name))
+		if !osbapi.IsRejected(err) {
+			// the broker may not have received the request, so leave the last operation unset and retry
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+			Type:        servicesv1alpha1.CreateOperationType,
+			State:       servicesv1alpha1.OperationStateFailed,
+			Description: err.Error(),
+		})
+	}
+
+	if response.Async {
+		err = r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+			Type:            servicesv1alpha1.CreateOperationType,
+			State:           servicesv1alpha1.OperationStateInProgress,
+			BrokerOperation: response.Operation,
+		})
+		return ctrl.Result{RequeueAfter: lastOperationPollInterval}, err
+	}
+
+	return ctrl.Result{}, r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+		Type:  servicesv1alpha1.CreateOperationType,
+		State: servicesv1alpha1.OperationStateSucceeded,
+	})
+}
+
+// deprovision asks the broker to deprovision the service instance and returns true once it is gone
+func (r *CFServiceInstanceReconciler) deprovision(ctx context.Context, cfServiceInstance *servicesv1alpha1.CFServiceInstance) (bool, error) {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation == nil {
+		return true, nil
+	}
+
+	planContext, err := getServicePlanContext(ctx, r.Client, cfServiceInstance.Spec.ServicePlanRef)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info(fmt.Sprintf("Skipping deprovisioning of CFServiceInstance %s/%s: %s", cfServiceInstance.Namespace, cfServiceInstance.Name, err.Error()))
+			return true, nil
+		}
+		return false, err
+	}
+
+	if lastOperation.Type == servicesv1alpha1.DeleteOperationType && lastOperation.State == servicesv1alpha1.OperationStateInProgress {
+		response, err := r.BrokerClient.LastOperation(ctx, planContext.broker, osbapi.LastOperationRequest{
+			InstanceID: cfServiceInstance.Name,
+			ServiceID:  planContext.offering.Spec.BrokerCatalog.ID,
+			PlanID:     planContext.plan.Spec.BrokerCatalog.ID,
+			Operation:  lastOperation.BrokerOperation,
+		})
+		if errors.Is(err, osbapi.ErrGone) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		switch response.State {
+		case osbapi.StateInProgress:
+			return false, nil
+		case osbapi.StateSucceeded:
+			return true, nil
+		default:
+			return false, r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+				Type:        servicesv1alpha1.DeleteOperationType,
+				State:       servicesv1alpha1.OperationStateFailed,
+				Description: response.Description,
+			})
+		}
+	}
+
+	response, err := r.BrokerClient.Deprovision(ctx, planContext.broker, osbapi.DeprovisionRequest{
+		InstanceID: cfServiceInstance.Name,
+		ServiceID:  planContext.offering.Spec.BrokerCatalog.ID,
+		PlanID:     planContext.plan.Spec.BrokerCatalog.ID,
+	})
+	if errors.Is(err, osbapi.ErrGone) {
+		return true, nil
+	}
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error deprovisioning CFServiceInstance %s/%s", cfServiceInstance.Namespace, cfServiceInstance.Name))
+		if statusErr := r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+			Type:        servicesv1alpha1.DeleteOperationType,
+			State:       servicesv1alpha1.OperationStateFailed,
+			Description: err.Error(),
+		}); statusErr != nil {
+			return false, statusErr
+		}
+		return false, err
+	}
+
+	if response.Async {
+		return false, r.setLastOperation(ctx, cfServiceInstance, servicesv1alpha1.ServiceInstanceOperation{
+			Type:            servicesv1alpha1.DeleteOperationType,
+			State:           servicesv1alpha1.OperationStateInProgress,
+			BrokerOperation: response.Operation,
+		})
+	}
+
+	return true, nil
+}
+
+// getOrgGUID returns the GUID of the org of the space namespace, which is the namespace it was created under
+func (r *CFServiceInstanceReconciler) getOrgGUID(ctx context.Context, spaceGUID string) (string, error) {
+	namespace := new(corev1.Namespace)
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: spaceGUID}, namespace); err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error fetching namespace %s", spaceGUID))
+		return "", err
+	}
+
+	return namespace.Annotations[hnsv1alpha2.SubnamespaceOf], nil
+}
+
+func (r *CFServiceInstanceReconciler) setLastOperation(ctx context.Context, cfServiceInstance *servicesv1alpha1.CFServiceInstance, operation servicesv1alpha1.ServiceInstanceOperation) error {
+	cfServiceInstance.Status.LastOperation = &operation
+
+	condition := metav1.Condition{
+		Type:   ProvisionedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: "ProvisioningInProgress",
+	}
+	if operation.Type == servicesv1alpha1.DeleteOperationType {
+		condition.Reason = "DeprovisioningInProgress"
+	}
+	if operation.Type == servicesv1alpha1.CreateOperationType && operation.State == servicesv1alpha1.OperationStateSucceeded {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Provisioned"
+	}
+	if operation.State == servicesv1alpha1.OperationStateFailed {
+		condition.Reason = "OperationFailed"
+		condition.Message = operation.Description
+	}
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, condition)
+
+	if err := r.Client.Status().Update(ctx, cfServiceInstance); err != nil {
+		r.Log.Error(err, "unable to update CFServiceInstance status")
+		return err
+	}
+	return nil
+}
+
 func isFinalizing(cfServiceInstance *servicesv1alpha1.CFServiceInstance) bool {
 	return cfServiceInstance.ObjectMeta.DeletionTimestamp != nil && !cfServiceInstance.ObjectMeta.DeletionTimestamp.IsZero()
 }
@@ -3,6 +3,7 @@ package services_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -14,6 +15,7 @@ import (
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/fake"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,6 +31,7 @@ var _ = Describe("CFServiceInstance.Reconcile", func() {
 	var (
 		fakeClient       *fake.Client
 		fakeStatusWriter *fake.StatusWriter
+		fakeBrokerClient *fake.BrokerClient
 
 		cfServiceInstance       *servicesv1alpha1.CFServiceInstance
 		cfServiceInstanceSecret *corev1.Secret
@@ -53,6 +56,7 @@ var _ = Describe("CFServiceInstance.Reconcile", func() {
 		fakeClient = new(fake.Client)
 		fakeStatusWriter = new(fake.StatusWriter)
 		fakeClient.StatusReturns(fakeStatusWriter)
+		fakeBrokerClient = new(fake.BrokerClient)
 
 		cfServiceInstance = new(servicesv1alpha1.CFServiceInstance)
 		cfServiceInstanceSecret = new(corev1.Secret)
@@ -77,9 +81,10 @@ var _ = Describe("CFServiceInstance.Reconcile", func() {
 		Expect(servicesv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 		cfServiceInstanceReconciler = &CFServiceInstanceReconciler{
-			Client: fakeClient,
-			Scheme: scheme.Scheme,
-			Log:    zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			Client:       fakeClient,
+			Scheme:       scheme.Scheme,
+			Log:          zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			BrokerClient: fakeBrokerClient,
 		}
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -242,4 +247,260 @@ var _ = Describe("CFServiceInstance.Reconcile", func() {
 			})
 		})
 	})
+
+	When("the CFServiceInstance is managed", func() {
+		var updatedCFServiceInstance func() *servicesv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			cfServiceInstance.Name = "instance-guid"
+			cfServiceInstance.Namespace = "space-guid"
+			cfServiceInstance.Finalizers = []string{"cfServiceInstance.services.cloudfoundry.org"}
+			cfServiceInstance.Spec = servicesv1alpha1.CFServiceInstanceSpec{
+				Name:           "my-db",
+				Type:           servicesv1alpha1.ManagedType,
+				ServicePlanRef: &corev1.ObjectReference{Name: "plan-guid", Namespace: "root-ns"},
+			}
+
+			baseGetStub := fakeClient.GetStub
+			fakeClient.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object) error {
+				switch obj := obj.(type) {
+				case *servicesv1alpha1.CFServicePlan:
+					obj.Namespace = key.Namespace
+					obj.Spec.BrokerCatalog.ID = "plan-id"
+					obj.Spec.ServiceOfferingRef.Name = "offering-guid"
+					return nil
+				case *servicesv1alpha1.CFServiceOffering:
+					obj.Namespace = key.Namespace
+					obj.Spec.BrokerCatalog.ID = "service-id"
+					obj.Spec.ServiceBrokerRef.Name = "broker-guid"
+					return nil
+				case *servicesv1alpha1.CFServiceBroker:
+					obj.Namespace = key.Namespace
+					obj.Spec.URL = "https://broker.example.com"
+					return nil
+				case *corev1.Namespace:
+					obj.Annotations = map[string]string{"hnc.x-k8s.io/subnamespace-of": "org-guid"}
+					return nil
+				}
+				return baseGetStub(ctx, key, obj)
+			}
+
+			updatedCFServiceInstance = func() *servicesv1alpha1.CFServiceInstance {
+				Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+				_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				return obj.(*servicesv1alpha1.CFServiceInstance)
+			}
+		})
+
+		It("provisions the service instance with the broker", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(reconcileResult).To(Equal(ctrl.Result{}))
+
+			Expect(fakeBrokerClient.ProvisionCallCount()).To(Equal(1))
+			_, broker, provisionRequest := fakeBrokerClient.ProvisionArgsForCall(0)
+			Expect(broker.URL).To(Equal("https://broker.example.com"))
+			Expect(provisionRequest.InstanceID).To(Equal("instance-guid"))
+			Expect(provisionRequest.ServiceID).To(Equal("service-id"))
+			Expect(provisionRequest.PlanID).To(Equal("plan-id"))
+			Expect(provisionRequest.OrganizationGUID).To(Equal("org-guid"))
+			Expect(provisionRequest.SpaceGUID).To(Equal("space-guid"))
+
+			Expect(updatedCFServiceInstance().Status.LastOperation).To(Equal(&servicesv1alpha1.ServiceInstanceOperation{
+				Type:  "create",
+				State: "succeeded",
+			}))
+			Expect(updatedCFServiceInstance().Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal("Provisioned"),
+				"Status": Equal(metav1.ConditionTrue),
+			})))
+		})
+
+		When("the broker provisions asynchronously", func() {
+			BeforeEach(func() {
+				fakeBrokerClient.ProvisionReturns(osbapi.OperationResponse{Async: true, Operation: "op-1"}, nil)
+			})
+
+			It("records the operation and requeues to poll it", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Second}))
+				Expect(updatedCFServiceInstance().Status.LastOperation).To(Equal(&servicesv1alpha1.ServiceInstanceOperation{
+					Type:            "create",
+					State:           "in progress",
+					BrokerOperation: "op-1",
+				}))
+			})
+		})
+
+		When("the broker rejects the provisioning", func() {
+			BeforeEach(func() {
+				fakeBrokerClient.ProvisionReturns(osbapi.OperationResponse{}, osbapi.UnexpectedStatusError{Action: "provisioning the service instance", StatusCode: http.StatusBadRequest})
+			})
+
+			It("records the failed operation", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(updatedCFServiceInstance().Status.LastOperation).To(Equal(&servicesv1alpha1.ServiceInstanceOperation{
+					Type:        "create",
+					State:       "failed",
+					Description: "broker responded with status 400 when provisioning the service instance",
+				}))
+			})
+		})
+
+		When("provisioning fails transiently", func() {
+			BeforeEach(func() {
+				fakeBrokerClient.ProvisionReturns(osbapi.OperationResponse{}, errors.New("provision-failed"))
+			})
+
+			It("returns the error without recording an operation so that provisioning is retried", func() {
+				Expect(reconcileErr).To(MatchError("provision-failed"))
+				Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+			})
+		})
+
+		When("the broker fails with a server error", func() {
+			BeforeEach(func() {
+				fakeBrokerClient.ProvisionReturns(osbapi.OperationResponse{}, osbapi.UnexpectedStatusError{Action: "provisioning the service instance", StatusCode: http.StatusServiceUnavailable})
+			})
+
+			It("returns the error so that provisioning is retried", func() {
+				Expect(reconcileErr).To(MatchError(ContainSubstring("status 503")))
+				Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+			})
+		})
+
+		When("provisioning is in progress", func() {
+			BeforeEach(func() {
+				cfServiceInstance.Status.LastOperation = &servicesv1alpha1.ServiceInstanceOperation{
+					Type:            "create",
+					State:           "in progress",
+					BrokerOperation: "op-1",
+				}
+				fakeBrokerClient.LastOperationReturns(osbapi.LastOperationResponse{State: "succeeded", Description: "done"}, nil)
+			})
+
+			It("polls the broker and records the outcome", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeBrokerClient.ProvisionCallCount()).To(BeZero())
+
+				Expect(fakeBrokerClient.LastOperationCallCount()).To(Equal(1))
+				_, _, lastOperationRequest := fakeBrokerClient.LastOperationArgsForCall(0)
+				Expect(lastOperationRequest.Operation).To(Equal("op-1"))
+
+				Expect(updatedCFServiceInstance().Status.LastOperation).To(Equal(&servicesv1alpha1.ServiceInstanceOperation{
+					Type:        "create",
+					State:       "succeeded",
+					Description: "done",
+				}))
+			})
+
+			When("the operation is still in progress", func() {
+				BeforeEach(func() {
+					fakeBrokerClient.LastOperationReturns(osbapi.LastOperationResponse{State: "in progress"}, nil)
+				})
+
+				It("requeues", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Second}))
+					Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the service instance is provisioned", func() {
+			BeforeEach(func() {
+				cfServiceInstance.Status.LastOperation = &servicesv1alpha1.ServiceInstanceOperation{
+					Type:  "create",
+					State: "succeeded",
+				}
+			})
+
+			It("does not talk to the broker", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeBrokerClient.ProvisionCallCount()).To(BeZero())
+				Expect(fakeBrokerClient.LastOperationCallCount()).To(BeZero())
+			})
+
+			When("it is being deleted", func() {
+				var cfServiceBindingList servicesv1alpha1.CFServiceBindingList
+
+				BeforeEach(func() {
+					cfServiceInstance.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+					cfServiceBindingList = servicesv1alpha1.CFServiceBindingList{}
+					fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+						cfServiceBindingList.DeepCopyInto(list.(*servicesv1alpha1.CFServiceBindingList))
+						return nil
+					}
+				})
+
+				It("deprovisions the service instance and removes the finalizer", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+
+					Expect(fakeBrokerClient.DeprovisionCallCount()).To(Equal(1))
+					_, _, deprovisionRequest := fakeBrokerClient.DeprovisionArgsForCall(0)
+					Expect(deprovisionRequest).To(Equal(osbapi.DeprovisionRequest{
+						InstanceID: "instance-guid",
+						ServiceID:  "service-id",
+						PlanID:     "plan-id",
+					}))
+
+					Expect(fakeClient.UpdateCallCount()).To(Equal(1))
+					_, obj, _ := fakeClient.UpdateArgsForCall(0)
+					Expect(obj.GetFinalizers()).To(BeEmpty())
+				})
+
+				When("the service instance still has bindings", func() {
+					BeforeEach(func() {
+						cfServiceBindingList.Items = []servicesv1alpha1.CFServiceBinding{{}}
+					})
+
+					It("waits for them to be unbound", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 2 * time.Second}))
+						Expect(fakeBrokerClient.DeprovisionCallCount()).To(BeZero())
+						Expect(fakeClient.UpdateCallCount()).To(BeZero())
+					})
+				})
+
+				When("the broker deprovisions asynchronously", func() {
+					BeforeEach(func() {
+						fakeBrokerClient.DeprovisionReturns(osbapi.OperationResponse{Async: true, Operation: "op-2"}, nil)
+					})
+
+					It("keeps the finalizer until the operation completes", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(reconcileResult).To(Equal(ctrl.Result{RequeueAfter: 5 * time.Second}))
+						Expect(fakeClient.UpdateCallCount()).To(BeZero())
+						Expect(updatedCFServiceInstance().Status.LastOperation).To(Equal(&servicesv1alpha1.ServiceInstanceOperation{
+							Type:            "delete",
+							State:           "in progress",
+							BrokerOperation: "op-2",
+						}))
+					})
+				})
+
+				When("the broker has already forgotten the service instance", func() {
+					BeforeEach(func() {
+						fakeBrokerClient.DeprovisionReturns(osbapi.OperationResponse{}, osbapi.ErrGone)
+					})
+
+					It("removes the finalizer", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(fakeClient.UpdateCallCount()).To(Equal(1))
+					})
+				})
+
+				When("deprovisioning fails", func() {
+					BeforeEach(func() {
+						fakeBrokerClient.DeprovisionReturns(osbapi.OperationResponse{}, errors.New("deprovision-failed"))
+					})
+
+					It("returns the error and keeps the finalizer", func() {
+						Expect(reconcileErr).To(MatchError("deprovision-failed"))
+						Expect(fakeClient.UpdateCallCount()).To(BeZero())
+						Expect(updatedCFServiceInstance().Status.LastOperation.State).To(Equal("failed"))
+					})
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,145 @@
+package integration_test
+
+import (
+	"context"
+
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fakebroker"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceBroker", func() {
+	var (
+		ctx             context.Context
+		namespace       *corev1.Namespace
+		cfServiceBroker *servicesv1alpha1.CFServiceBroker
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = BuildNamespaceObject(GenerateGUID())
+		Expect(k8sClient.Create(ctx, namespace)).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "broker-credentials",
+				Namespace: namespace.Name,
+			},
+			StringData: map[string]string{
+				"username": fakebroker.Username,
+				"password": fakebroker.Password,
+			},
+		})).To(Succeed())
+
+		cfServiceBroker = &servicesv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: servicesv1alpha1.CFServiceBrokerSpec{
+				Name:        "my-broker",
+				URL:         fakeBroker.URL,
+				Credentials: corev1.LocalObjectReference{Name: "broker-credentials"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfServiceBroker)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, namespace)).To(Succeed())
+	})
+
+	It("syncs the catalog of the broker", func() {
+		Eventually(func(g Gomega) {
+			updatedBroker := new(servicesv1alpha1.CFServiceBroker)
+			g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updatedBroker)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(updatedBroker.Status.Conditions, "CatalogSynced")).To(BeTrue())
+		}).Should(Succeed())
+
+		offerings := new(servicesv1alpha1.CFServiceOfferingList)
+		Expect(k8sClient.List(ctx, offerings, client.InNamespace(namespace.Name))).To(Succeed())
+		Expect(offerings.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Spec": MatchFields(IgnoreExtras, Fields{
+				"Name":          Equal("postgres"),
+				"BrokerCatalog": Equal(servicesv1alpha1.ServiceBrokerCatalog{ID: "service-id"}),
+			}),
+		})))
+
+		plans := new(servicesv1alpha1.CFServicePlanList)
+		Expect(k8sClient.List(ctx, plans, client.InNamespace(namespace.Name))).To(Succeed())
+		Expect(plans.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Spec": MatchFields(IgnoreExtras, Fields{
+				"Name":               Equal("small"),
+				"ServiceOfferingRef": Equal(corev1.LocalObjectReference{Name: offerings.Items[0].Name}),
+			}),
+		})))
+	})
+
+	When("a managed service instance is created from a plan of the broker", func() {
+		var cfServiceInstance *servicesv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			plans := new(servicesv1alpha1.CFServicePlanList)
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.List(ctx, plans, client.InNamespace(namespace.Name))).To(Succeed())
+				g.Expect(plans.Items).To(HaveLen(1))
+			}).Should(Succeed())
+
+			cfServiceInstance = &servicesv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      GenerateGUID(),
+					Namespace: namespace.Name,
+				},
+				Spec: servicesv1alpha1.CFServiceInstanceSpec{
+					Name: "my-db",
+					Type: servicesv1alpha1.ManagedType,
+					ServicePlanRef: &corev1.ObjectReference{
+						Name:      plans.Items[0].Name,
+						Namespace: namespace.Name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+		})
+
+		It("provisions the service instance with the broker", func() {
+			Eventually(func(g Gomega) {
+				updatedInstance := new(servicesv1alpha1.CFServiceInstance)
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceInstance.Name, Namespace: namespace.Name}, updatedInstance)).To(Succeed())
+				g.Expect(updatedInstance.Status.LastOperation).NotTo(BeNil())
+				g.Expect(updatedInstance.Status.LastOperation.State).To(Equal(servicesv1alpha1.OperationStateSucceeded))
+			}).Should(Succeed())
+
+			Expect(fakeBroker.Instances()).To(HaveKeyWithValue(cfServiceInstance.Name, fakebroker.Instance{
+				ServiceID: "service-id",
+				PlanID:    "small-id",
+				SpaceGUID: namespace.Name,
+			}))
+		})
+
+		When("the service instance is deleted", func() {
+			BeforeEach(func() {
+				Eventually(func() map[string]fakebroker.Instance {
+					return fakeBroker.Instances()
+				}).Should(HaveKey(cfServiceInstance.Name))
+
+				Expect(k8sClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("deprovisions it from the broker", func() {
+				Eventually(func() map[string]fakebroker.Instance {
+					return fakeBroker.Instances()
+				}).ShouldNot(HaveKey(cfServiceInstance.Name))
+			})
+		})
+	})
+})
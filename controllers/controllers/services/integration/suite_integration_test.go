@@ -18,6 +18,7 @@ package integration_test
 
 import (
 	"context"
+	"net/http"
 	"path/filepath"
 	"testing"
 	"time"
@@ -26,6 +27,8 @@ import (
 	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fakebroker"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	servicebindingv1beta1 "github.com/servicebinding/service-binding-controller/apis/v1beta1"
@@ -47,9 +50,10 @@ import (
 // http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
 
 var (
-	cancel    context.CancelFunc
-	testEnv   *envtest.Environment
-	k8sClient client.Client
+	cancel     context.CancelFunc
+	testEnv    *envtest.Environment
+	k8sClient  client.Client
+	fakeBroker *fakebroker.Broker
 )
 
 func TestAPIs(t *testing.T) {
@@ -105,17 +109,40 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	fakeBroker = fakebroker.New(osbapi.Catalog{
+		Services: []osbapi.Service{{
+			ID:          "service-id",
+			Name:        "postgres",
+			Description: "a database",
+			Bindable:    true,
+			Plans: []osbapi.Plan{
+				{ID: "small-id", Name: "small", Description: "a small database"},
+			},
+		}},
+	})
+	brokerClient := osbapi.NewClient(http.DefaultClient)
+
 	err = (&CFServiceBindingReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+		Client:       k8sManager.GetClient(),
+		Scheme:       k8sManager.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+		BrokerClient: brokerClient,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&CFServiceInstanceReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
+		Client:       k8sManager.GetClient(),
+		Scheme:       k8sManager.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
+		BrokerClient: brokerClient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&CFServiceBrokerReconciler{
+		Client:       k8sManager.GetClient(),
+		Scheme:       k8sManager.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
+		BrokerClient: brokerClient,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -133,6 +160,7 @@ var _ = BeforeSuite(func() {
 })
 
 var _ = AfterSuite(func() {
+	fakeBroker.Close()
 	cancel()
 	Expect(testEnv.Stop()).To(Succeed())
 })
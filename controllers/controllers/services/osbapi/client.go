@@ -0,0 +1,315 @@
+package osbapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// APIVersion is the version of the Open Service Broker API spoken to brokers
+	APIVersion       = "2.17"
+	APIVersionHeader = "X-Broker-API-Version"
+
+	StateInProgress = "in progress"
+	StateSucceeded  = "succeeded"
+	StateFailed     = "failed"
+)
+
+// ErrGone is returned when the broker does not know about the service instance, binding or operation any more
+var ErrGone = errors.New("resource gone")
+
+// Broker holds the endpoint and basic auth credentials of a service broker
+type Broker struct {
+	URL      string
+	Username string
+	Password string
+}
+
+type Catalog struct {
+	Services []Service `json:"services"`
+}
+
+type Service struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Bindable    bool     `json:"bindable"`
+	Plans       []Plan   `json:"plans"`
+}
+
+type Plan struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Free        *bool  `json:"free,omitempty"`
+}
+
+// IsFree returns whether the plan is free. Plans are free unless the broker says otherwise
+func (p Plan) IsFree() bool {
+	return p.Free == nil || *p.Free
+}
+
+type ProvisionRequest struct {
+	InstanceID       string                 `json:"-"`
+	ServiceID        string                 `json:"service_id"`
+	PlanID           string                 `json:"plan_id"`
+	OrganizationGUID string                 `json:"organization_guid"`
+	SpaceGUID        string                 `json:"space_guid"`
+	Context          map[string]interface{} `json:"context,omitempty"`
+}
+
+type DeprovisionRequest struct {
+	InstanceID string
+	ServiceID  string
+	PlanID     string
+}
+
+// OperationResponse is returned for requests that the broker can complete asynchronously.
+// Async is true when the broker accepted the request and the Operation has to be polled for its state.
+type OperationResponse struct {
+	Async     bool
+	Operation string
+}
+
+type LastOperationRequest struct {
+	InstanceID string
+	ServiceID  string
+	PlanID     string
+	Operation  string
+}
+
+type LastOperationResponse struct {
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+}
+
+type BindRequest struct {
+	InstanceID string                 `json:"-"`
+	BindingID  string                 `json:"-"`
+	ServiceID  string                 `json:"service_id"`
+	PlanID     string                 `json:"plan_id"`
+	AppGUID    string                 `json:"app_guid,omitempty"`
+	Context    map[string]interface{} `json:"context,omitempty"`
+}
+
+type BindResponse struct {
+	Credentials map[string]interface{} `json:"credentials"`
+}
+
+type UnbindRequest struct {
+	InstanceID string
+	BindingID  string
+	ServiceID  string
+	PlanID     string
+}
+
+// Client speaks the Open Service Broker API to service brokers
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) GetCatalog(ctx context.Context, broker Broker) (Catalog, error) {
+	var catalog Catalog
+	statusCode, err := c.do(ctx, broker, http.MethodGet, "/v2/catalog", nil, nil, &catalog)
+	if err != nil {
+		return Catalog{}, err
+	}
+	if statusCode != http.StatusOK {
+		return Catalog{}, unexpectedStatusError("fetching the catalog", statusCode)
+	}
+
+	return catalog, nil
+}
+
+func (c *Client) Provision(ctx context.Context, broker Broker, request ProvisionRequest) (OperationResponse, error) {
+	var response struct {
+		Operation string `json:"operation"`
+	}
+	statusCode, err := c.do(ctx, broker, http.MethodPut, instancePath(request.InstanceID), acceptsIncomplete(), request, &response)
+	if err != nil {
+		return OperationResponse{}, err
+	}
+
+	switch statusCode {
+	case http.StatusOK, http.StatusCreated:
+		return OperationResponse{}, nil
+	case http.StatusAccepted:
+		return OperationResponse{Async: true, Operation: response.Operation}, nil
+	default:
+		return OperationResponse{}, unexpectedStatusError("provisioning the service instance", statusCode)
+	}
+}
+
+func (c *Client) Deprovision(ctx context.Context, broker Broker, request DeprovisionRequest) (OperationResponse, error) {
+	query := acceptsIncomplete()
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+
+	var response struct {
+		Operation string `json:"operation"`
+	}
+	statusCode, err := c.do(ctx, broker, http.MethodDelete, instancePath(request.InstanceID), query, nil, &response)
+	if err != nil {
+		return OperationResponse{}, err
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		return OperationResponse{}, nil
+	case http.StatusAccepted:
+		return OperationResponse{Async: true, Operation: response.Operation}, nil
+	case http.StatusGone:
+		return OperationResponse{}, ErrGone
+	default:
+		return OperationResponse{}, unexpectedStatusError("deprovisioning the service instance", statusCode)
+	}
+}
+
+func (c *Client) LastOperation(ctx context.Context, broker Broker, request LastOperationRequest) (LastOperationResponse, error) {
+	query := url.Values{}
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+	if request.Operation != "" {
+		query.Set("operation", request.Operation)
+	}
+
+	var response LastOperationResponse
+	statusCode, err := c.do(ctx, broker, http.MethodGet, instancePath(request.InstanceID)+"/last_operation", query, nil, &response)
+	if err != nil {
+		return LastOperationResponse{}, err
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		return response, nil
+	case http.StatusGone:
+		return LastOperationResponse{}, ErrGone
+	default:
+		return LastOperationResponse{}, unexpectedStatusError("fetching the last operation", statusCode)
+	}
+}
+
+func (c *Client) Bind(ctx context.Context, broker Broker, request BindRequest) (BindResponse, error) {
+	var response BindResponse
+	statusCode, err := c.do(ctx, broker, http.MethodPut, bindingPath(request.InstanceID, request.BindingID), nil, request, &response)
+	if err != nil {
+		return BindResponse{}, err
+	}
+
+	switch statusCode {
+	case http.StatusOK, http.StatusCreated:
+		return response, nil
+	default:
+		return BindResponse{}, unexpectedStatusError("binding the service instance", statusCode)
+	}
+}
+
+func (c *Client) Unbind(ctx context.Context, broker Broker, request UnbindRequest) error {
+	query := url.Values{}
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+
+	statusCode, err := c.do(ctx, broker, http.MethodDelete, bindingPath(request.InstanceID, request.BindingID), query, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusGone:
+		return ErrGone
+	default:
+		return unexpectedStatusError("unbinding the service instance", statusCode)
+	}
+}
+
+func (c *Client) do(ctx context.Context, broker Broker, method, path string, query url.Values, requestBody, responseBody interface{}) (int, error) {
+	var body io.Reader
+	if requestBody != nil {
+		payload, err := json.Marshal(requestBody)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal the request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	requestURL := strings.TrimSuffix(broker.URL, "/") + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create the request: %w", err)
+	}
+	req.SetBasicAuth(broker.Username, broker.Password)
+	req.Header.Set(APIVersionHeader, APIVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send the request to the broker: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if responseBody != nil && resp.StatusCode < http.StatusMultipleChoices {
+		if err = json.NewDecoder(resp.Body).Decode(responseBody); err != nil && !errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("failed to decode the broker response: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func acceptsIncomplete() url.Values {
+	return url.Values{"accepts_incomplete": []string{"true"}}
+}
+
+func instancePath(instanceID string) string {
+	return "/v2/service_instances/" + url.PathEscape(instanceID)
+}
+
+func bindingPath(instanceID, bindingID string) string {
+	return instancePath(instanceID) + "/service_bindings/" + url.PathEscape(bindingID)
+}
+
+// UnexpectedStatusError is returned when the broker responds with a status code that the OSBAPI does not define for the request
+type UnexpectedStatusError struct {
+	Action     string
+	StatusCode int
+}
+
+func (e UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("broker responded with status %d when %s", e.StatusCode, e.Action)
+}
+
+func unexpectedStatusError(action string, statusCode int) error {
+	return UnexpectedStatusError{Action: action, StatusCode: statusCode}
+}
+
+// IsRejected returns whether the broker definitively rejected the request, i.e. responded with a 4xx status code.
+// Any other error (network failures, 5xx responses) is transient and the request may be retried.
+func IsRejected(err error) bool {
+	var statusErr UnexpectedStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode >= http.StatusBadRequest && statusErr.StatusCode < http.StatusInternalServerError
+}
@@ -0,0 +1,217 @@
+package osbapi_test
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fakebroker"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ctx     context.Context
+		broker  *fakebroker.Broker
+		target  osbapi.Broker
+		client  *osbapi.Client
+		catalog osbapi.Catalog
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		notFree := false
+		catalog = osbapi.Catalog{
+			Services: []osbapi.Service{{
+				ID:          "service-id",
+				Name:        "my-service",
+				Description: "a service",
+				Tags:        []string{"sql"},
+				Bindable:    true,
+				Plans: []osbapi.Plan{
+					{ID: "small-id", Name: "small", Description: "a small plan"},
+					{ID: "large-id", Name: "large", Description: "a large plan", Free: &notFree},
+				},
+			}},
+		}
+		broker = fakebroker.New(catalog)
+		broker.Credentials = map[string]interface{}{"username": "db-user", "port": 5432}
+		target = broker.Target()
+		client = osbapi.NewClient(http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		broker.Close()
+	})
+
+	Describe("GetCatalog", func() {
+		It("returns the catalog of the broker", func() {
+			actualCatalog, err := client.GetCatalog(ctx, target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualCatalog).To(Equal(catalog))
+			Expect(actualCatalog.Services[0].Plans[0].IsFree()).To(BeTrue())
+			Expect(actualCatalog.Services[0].Plans[1].IsFree()).To(BeFalse())
+		})
+
+		When("the credentials are wrong", func() {
+			BeforeEach(func() {
+				target.Password = "wrong"
+			})
+
+			It("returns a rejection error", func() {
+				_, err := client.GetCatalog(ctx, target)
+				Expect(err).To(MatchError(ContainSubstring("status 401")))
+				Expect(osbapi.IsRejected(err)).To(BeTrue())
+			})
+		})
+
+		When("the broker cannot be reached", func() {
+			BeforeEach(func() {
+				target.URL = "http://127.0.0.1:1"
+			})
+
+			It("returns an error that is not a rejection", func() {
+				_, err := client.GetCatalog(ctx, target)
+				Expect(err).To(MatchError(ContainSubstring("failed to send the request")))
+				Expect(osbapi.IsRejected(err)).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("provisioning", func() {
+		var provisionRequest osbapi.ProvisionRequest
+
+		BeforeEach(func() {
+			provisionRequest = osbapi.ProvisionRequest{
+				InstanceID:       "instance-guid",
+				ServiceID:        "service-id",
+				PlanID:           "small-id",
+				OrganizationGUID: "org-guid",
+				SpaceGUID:        "space-guid",
+			}
+		})
+
+		It("provisions the service instance synchronously", func() {
+			response, err := client.Provision(ctx, target, provisionRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Async).To(BeFalse())
+			Expect(broker.Instances()).To(HaveKeyWithValue("instance-guid", fakebroker.Instance{
+				ServiceID: "service-id",
+				PlanID:    "small-id",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		When("the broker provisions asynchronously", func() {
+			BeforeEach(func() {
+				broker.SetAsync(true)
+			})
+
+			It("returns the operation to poll", func() {
+				response, err := client.Provision(ctx, target, provisionRequest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response).To(Equal(osbapi.OperationResponse{Async: true, Operation: "provision"}))
+
+				lastOperation, err := client.LastOperation(ctx, target, osbapi.LastOperationRequest{
+					InstanceID: "instance-guid",
+					ServiceID:  "service-id",
+					PlanID:     "small-id",
+					Operation:  response.Operation,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastOperation.State).To(Equal(osbapi.StateInProgress))
+
+				broker.CompleteOperations()
+
+				lastOperation, err = client.LastOperation(ctx, target, osbapi.LastOperationRequest{
+					InstanceID: "instance-guid",
+					Operation:  response.Operation,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lastOperation.State).To(Equal(osbapi.StateSucceeded))
+			})
+		})
+
+		Describe("Deprovision", func() {
+			var deprovisionRequest osbapi.DeprovisionRequest
+
+			BeforeEach(func() {
+				_, err := client.Provision(ctx, target, provisionRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				deprovisionRequest = osbapi.DeprovisionRequest{
+					InstanceID: "instance-guid",
+					ServiceID:  "service-id",
+					PlanID:     "small-id",
+				}
+			})
+
+			It("deprovisions the service instance", func() {
+				response, err := client.Deprovision(ctx, target, deprovisionRequest)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Async).To(BeFalse())
+				Expect(broker.Instances()).To(BeEmpty())
+			})
+
+			When("the service instance is already gone", func() {
+				BeforeEach(func() {
+					_, err := client.Deprovision(ctx, target, deprovisionRequest)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns ErrGone", func() {
+					_, err := client.Deprovision(ctx, target, deprovisionRequest)
+					Expect(err).To(MatchError(osbapi.ErrGone))
+				})
+			})
+		})
+	})
+
+	Describe("binding", func() {
+		BeforeEach(func() {
+			_, err := client.Provision(ctx, target, osbapi.ProvisionRequest{
+				InstanceID: "instance-guid",
+				ServiceID:  "service-id",
+				PlanID:     "small-id",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds and unbinds the service instance", func() {
+			response, err := client.Bind(ctx, target, osbapi.BindRequest{
+				InstanceID: "instance-guid",
+				BindingID:  "binding-guid",
+				ServiceID:  "service-id",
+				PlanID:     "small-id",
+				AppGUID:    "app-guid",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Credentials).To(Equal(map[string]interface{}{"username": "db-user", "port": float64(5432)}))
+			Expect(broker.Bindings()).To(HaveKeyWithValue("binding-guid", fakebroker.Binding{InstanceID: "instance-guid", AppGUID: "app-guid"}))
+
+			Expect(client.Unbind(ctx, target, osbapi.UnbindRequest{
+				InstanceID: "instance-guid",
+				BindingID:  "binding-guid",
+				ServiceID:  "service-id",
+				PlanID:     "small-id",
+			})).To(Succeed())
+			Expect(broker.Bindings()).To(BeEmpty())
+		})
+
+		When("the binding does not exist", func() {
+			It("returns ErrGone on unbind", func() {
+				err := client.Unbind(ctx, target, osbapi.UnbindRequest{InstanceID: "instance-guid", BindingID: "unknown"})
+				Expect(err).To(MatchError(osbapi.ErrGone))
+			})
+		})
+
+		When("the service instance does not exist", func() {
+			It("returns an error", func() {
+				_, err := client.Bind(ctx, target, osbapi.BindRequest{InstanceID: "unknown", BindingID: "binding-guid"})
+				Expect(err).To(MatchError(ContainSubstring("status 400")))
+			})
+		})
+	})
+})
@@ -0,0 +1,240 @@
+// Package fakebroker provides an in-process service broker that speaks the Open Service Broker API.
+// It keeps its service instances and bindings in memory and is meant to be used in tests.
+package fakebroker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+)
+
+const (
+	Username = "broker-user"
+	Password = "broker-password"
+)
+
+type Instance struct {
+	ServiceID string
+	PlanID    string
+	SpaceGUID string
+}
+
+type Binding struct {
+	InstanceID string
+	AppGUID    string
+}
+
+// Broker is a fake service broker. Requests must use the Username and Password basic auth credentials.
+type Broker struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	catalog   osbapi.Catalog
+	async     bool
+	instances map[string]Instance
+	bindings  map[string]Binding
+	pending   map[string]string
+
+	// Credentials are returned by the broker for every binding
+	Credentials map[string]interface{}
+}
+
+func New(catalog osbapi.Catalog) *Broker {
+	b := &Broker{
+		catalog:     catalog,
+		instances:   map[string]Instance{},
+		bindings:    map[string]Binding{},
+		pending:     map[string]string{},
+		Credentials: map[string]interface{}{},
+	}
+	b.Server = httptest.NewServer(http.HandlerFunc(b.serveHTTP))
+
+	return b
+}
+
+// Target returns the broker the client should talk to
+func (b *Broker) Target() osbapi.Broker {
+	return osbapi.Broker{
+		URL:      b.URL,
+		Username: Username,
+		Password: Password,
+	}
+}
+
+// SetAsync makes the broker provision and deprovision service instances asynchronously.
+// Asynchronous operations stay in progress until they are completed with CompleteOperations.
+func (b *Broker) SetAsync(async bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.async = async
+}
+
+// CompleteOperations completes all the asynchronous operations that are in progress
+func (b *Broker) CompleteOperations() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for instanceID, operation := range b.pending {
+		if operation == "deprovision" {
+			delete(b.instances, instanceID)
+		}
+		delete(b.pending, instanceID)
+	}
+}
+
+func (b *Broker) Instances() map[string]Instance {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	instances := map[string]Instance{}
+	for id, instance := range b.instances {
+		instances[id] = instance
+	}
+	return instances
+}
+
+func (b *Broker) Bindings() map[string]Binding {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bindings := map[string]Binding{}
+	for id, binding := range b.bindings {
+		bindings[id] = binding
+	}
+	return bindings
+}
+
+func (b *Broker) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || username != Username || password != Password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.Header.Get(osbapi.APIVersionHeader) == "" {
+		writeJSON(w, http.StatusPreconditionFailed, map[string]string{"description": "missing API version header"})
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/catalog":
+		writeJSON(w, http.StatusOK, b.catalog)
+	case len(segments) == 3 && segments[1] == "service_instances" && r.Method == http.MethodPut:
+		b.provision(w, r, segments[2])
+	case len(segments) == 3 && segments[1] == "service_instances" && r.Method == http.MethodDelete:
+		b.deprovision(w, segments[2])
+	case len(segments) == 4 && segments[3] == "last_operation" && r.Method == http.MethodGet:
+		b.lastOperation(w, segments[2])
+	case len(segments) == 5 && segments[3] == "service_bindings" && r.Method == http.MethodPut:
+		b.bind(w, r, segments[2], segments[4])
+	case len(segments) == 5 && segments[3] == "service_bindings" && r.Method == http.MethodDelete:
+		b.unbind(w, segments[4])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (b *Broker) provision(w http.ResponseWriter, r *http.Request, instanceID string) {
+	var request osbapi.ProvisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": err.Error()})
+		return
+	}
+
+	if _, exists := b.instances[instanceID]; exists {
+		writeJSON(w, http.StatusOK, map[string]string{})
+		return
+	}
+
+	b.instances[instanceID] = Instance{
+		ServiceID: request.ServiceID,
+		PlanID:    request.PlanID,
+		SpaceGUID: request.SpaceGUID,
+	}
+
+	if b.async && r.URL.Query().Get("accepts_incomplete") == "true" {
+		b.pending[instanceID] = "provision"
+		writeJSON(w, http.StatusAccepted, map[string]string{"operation": "provision"})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{})
+}
+
+func (b *Broker) deprovision(w http.ResponseWriter, instanceID string) {
+	if _, exists := b.instances[instanceID]; !exists {
+		writeJSON(w, http.StatusGone, map[string]string{})
+		return
+	}
+
+	if b.async {
+		b.pending[instanceID] = "deprovision"
+		writeJSON(w, http.StatusAccepted, map[string]string{"operation": "deprovision"})
+		return
+	}
+
+	delete(b.instances, instanceID)
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (b *Broker) lastOperation(w http.ResponseWriter, instanceID string) {
+	if _, pending := b.pending[instanceID]; pending {
+		writeJSON(w, http.StatusOK, osbapi.LastOperationResponse{State: osbapi.StateInProgress})
+		return
+	}
+
+	if _, exists := b.instances[instanceID]; !exists {
+		writeJSON(w, http.StatusGone, map[string]string{})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, osbapi.LastOperationResponse{State: osbapi.StateSucceeded})
+}
+
+func (b *Broker) bind(w http.ResponseWriter, r *http.Request, instanceID, bindingID string) {
+	var request osbapi.BindRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": err.Error()})
+		return
+	}
+
+	if _, exists := b.instances[instanceID]; !exists {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": "service instance does not exist"})
+		return
+	}
+
+	status := http.StatusCreated
+	if _, exists := b.bindings[bindingID]; exists {
+		status = http.StatusOK
+	}
+
+	b.bindings[bindingID] = Binding{
+		InstanceID: instanceID,
+		AppGUID:    request.AppGUID,
+	}
+	writeJSON(w, status, osbapi.BindResponse{Credentials: b.Credentials})
+}
+
+func (b *Broker) unbind(w http.ResponseWriter, bindingID string) {
+	if _, exists := b.bindings[bindingID]; !exists {
+		writeJSON(w, http.StatusGone, map[string]string{})
+		return
+	}
+
+	delete(b.bindings, bindingID)
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,13 @@
+package osbapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOSBAPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "OSBAPI Client Suite")
+}
@@ -1,7 +1,92 @@
 package services
 
+import (
+	"context"
+	"fmt"
+
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate -o fake -fake-name Client sigs.k8s.io/controller-runtime/pkg/client.Client
 
 //counterfeiter:generate -o fake -fake-name StatusWriter sigs.k8s.io/controller-runtime/pkg/client.StatusWriter
+
+//counterfeiter:generate -o fake -fake-name BrokerClient . BrokerClient
+
+const (
+	BrokerCredentialsUsernameKey = "username"
+	BrokerCredentialsPasswordKey = "password"
+)
+
+// BrokerClient speaks the Open Service Broker API to service brokers
+type BrokerClient interface {
+	GetCatalog(ctx context.Context, broker osbapi.Broker) (osbapi.Catalog, error)
+	Provision(ctx context.Context, broker osbapi.Broker, request osbapi.ProvisionRequest) (osbapi.OperationResponse, error)
+	Deprovision(ctx context.Context, broker osbapi.Broker, request osbapi.DeprovisionRequest) (osbapi.OperationResponse, error)
+	LastOperation(ctx context.Context, broker osbapi.Broker, request osbapi.LastOperationRequest) (osbapi.LastOperationResponse, error)
+	Bind(ctx context.Context, broker osbapi.Broker, request osbapi.BindRequest) (osbapi.BindResponse, error)
+	Unbind(ctx context.Context, broker osbapi.Broker, request osbapi.UnbindRequest) error
+}
+
+// servicePlanContext is everything needed to talk to the broker about service instances of a plan
+type servicePlanContext struct {
+	plan     *servicesv1alpha1.CFServicePlan
+	offering *servicesv1alpha1.CFServiceOffering
+	broker   osbapi.Broker
+}
+
+func getServicePlanContext(ctx context.Context, k8sClient client.Client, planRef *corev1.ObjectReference) (servicePlanContext, error) {
+	if planRef == nil {
+		return servicePlanContext{}, fmt.Errorf("managed service instance has no service plan")
+	}
+
+	plan := new(servicesv1alpha1.CFServicePlan)
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: planRef.Name, Namespace: planRef.Namespace}, plan)
+	if err != nil {
+		return servicePlanContext{}, fmt.Errorf("error fetching CFServicePlan %s/%s: %w", planRef.Namespace, planRef.Name, err)
+	}
+
+	offering := new(servicesv1alpha1.CFServiceOffering)
+	err = k8sClient.Get(ctx, types.NamespacedName{Name: plan.Spec.ServiceOfferingRef.Name, Namespace: plan.Namespace}, offering)
+	if err != nil {
+		return servicePlanContext{}, fmt.Errorf("error fetching CFServiceOffering %s/%s: %w", plan.Namespace, plan.Spec.ServiceOfferingRef.Name, err)
+	}
+
+	cfServiceBroker := new(servicesv1alpha1.CFServiceBroker)
+	err = k8sClient.Get(ctx, types.NamespacedName{Name: offering.Spec.ServiceBrokerRef.Name, Namespace: offering.Namespace}, cfServiceBroker)
+	if err != nil {
+		return servicePlanContext{}, fmt.Errorf("error fetching CFServiceBroker %s/%s: %w", offering.Namespace, offering.Spec.ServiceBrokerRef.Name, err)
+	}
+
+	broker, err := getBroker(ctx, k8sClient, cfServiceBroker)
+	if err != nil {
+		return servicePlanContext{}, err
+	}
+
+	return servicePlanContext{
+		plan:     plan,
+		offering: offering,
+		broker:   broker,
+	}, nil
+}
+
+func getBroker(ctx context.Context, k8sClient client.Client, cfServiceBroker *servicesv1alpha1.CFServiceBroker) (osbapi.Broker, error) {
+	secret := new(corev1.Secret)
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBroker.Spec.Credentials.Name, Namespace: cfServiceBroker.Namespace}, secret)
+	if err != nil {
+		return osbapi.Broker{}, fmt.Errorf("error fetching the credentials of CFServiceBroker %s/%s: %w", cfServiceBroker.Namespace, cfServiceBroker.Name, err)
+	}
+
+	return osbapi.Broker{
+		URL:      cfServiceBroker.Spec.URL,
+		Username: string(secret.Data[BrokerCredentialsUsernameKey]),
+		Password: string(secret.Data[BrokerCredentialsPasswordKey]),
+	}, nil
+}
@@ -2,8 +2,14 @@ package workloads
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
 	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
@@ -13,6 +19,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -21,6 +28,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const (
@@ -42,6 +52,8 @@ type CFAppReconciler struct {
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapprevisions,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -87,8 +99,9 @@ func (r *CFAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 
 		droplet := cfBuild.Status.BuildDropletStatus
+		processTypes := addWebIfMissing(droplet.ProcessTypes)
 
-		for _, process := range addWebIfMissing(droplet.ProcessTypes) {
+		for _, process := range processTypes {
 			var processExistsForType bool
 			processExistsForType, err = r.checkCFProcessExistsForType(ctx, cfApp.Name, cfApp.Namespace, process.Type)
 			if err != nil {
@@ -104,14 +117,17 @@ func (r *CFAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 				}
 			}
 		}
+
+		err = r.recordRevision(ctx, cfApp, processTypes)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
-	meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
-		Type:    StatusConditionRunning,
-		Status:  metav1.ConditionFalse,
-		Reason:  "unimplemented",
-		Message: "",
-	})
+	err = r.aggregateProcessStatus(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	cfApp.Status.ObservedDesiredState = cfApp.Spec.DesiredState
 
 	if statusErr := r.Client.Status().Update(ctx, cfApp); statusErr != nil {
@@ -122,6 +138,252 @@ func (r *CFAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	return ctrl.Result{}, nil
 }
 
+// aggregateProcessStatus sums up the running instances and restarts of the app processes, and sets the app as running
+// once all of its processes are running. The app is reported as crashing as soon as one of its processes is.
+func (r *CFAppReconciler) aggregateProcessStatus(ctx context.Context, cfApp *workloadsv1alpha1.CFApp) error {
+	cfProcessList := workloadsv1alpha1.CFProcessList{}
+	err := r.Client.List(ctx, &cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the CFProcesses of CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return err
+	}
+
+	var (
+		runningInstances int
+		desiredInstances int
+		restartCount     int32
+		crashing         bool
+	)
+	for _, cfProcess := range cfProcessList.Items {
+		runningInstances += cfProcess.Status.RunningInstances
+		desiredInstances += cfProcess.Spec.DesiredInstances
+		restartCount += cfProcess.Status.RestartCount
+
+		runningCondition := meta.FindStatusCondition(cfProcess.Status.Conditions, StatusConditionRunning)
+		if runningCondition != nil && runningCondition.Reason == StatusConditionReasonCrashing {
+			crashing = true
+		}
+	}
+
+	cfApp.Status.RunningInstances = runningInstances
+	cfApp.Status.RestartCount = restartCount
+
+	condition := metav1.Condition{
+		Type:    StatusConditionRunning,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("%d/%d instances running", runningInstances, desiredInstances),
+	}
+	switch {
+	case cfApp.Spec.DesiredState != workloadsv1alpha1.StartedState:
+		condition.Reason = StatusConditionReasonStopped
+	case crashing:
+		condition.Reason = StatusConditionReasonCrashing
+	case len(cfProcessList.Items) > 0 && runningInstances >= desiredInstances:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = StatusConditionReasonRunning
+	default:
+		condition.Reason = StatusConditionReasonStarting
+	}
+	meta.SetStatusCondition(&cfApp.Status.Conditions, condition)
+
+	return nil
+}
+
+// recordRevision creates a new CFAppRevision whenever the droplet, the environment variables or the process commands of
+// the app differ from its current revision. Recording waits for a CFProcess to exist for each of the droplet process
+// types, as processes created by this reconcile only show up once it is triggered again. The status of the app is
+// updated by the caller.
+func (r *CFAppReconciler) recordRevision(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, processTypes []workloadsv1alpha1.ProcessType) error {
+	cfProcessList := workloadsv1alpha1.CFProcessList{}
+	err := r.Client.List(ctx, &cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the CFProcesses of CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return err
+	}
+
+	processes := make([]workloadsv1alpha1.RevisionProcess, 0, len(cfProcessList.Items))
+	for _, cfProcess := range cfProcessList.Items {
+		processes = append(processes, workloadsv1alpha1.RevisionProcess{
+			Type:    cfProcess.Spec.ProcessType,
+			Command: cfProcess.Spec.Command,
+		})
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Type < processes[j].Type
+	})
+
+	for _, processType := range processTypes {
+		if !hasRevisionProcess(processes, processType.Type) {
+			return nil
+		}
+	}
+
+	envVars, err := r.getEnvVars(ctx, cfApp)
+	if err != nil {
+		return err
+	}
+
+	envSecretHash, err := hashEnvVars(envVars)
+	if err != nil {
+		return err
+	}
+
+	var currentRevision *workloadsv1alpha1.CFAppRevision
+	if cfApp.Status.CurrentRevisionRef.Name != "" {
+		currentRevision = new(workloadsv1alpha1.CFAppRevision)
+		err = r.Client.Get(ctx, types.NamespacedName{Name: cfApp.Status.CurrentRevisionRef.Name, Namespace: cfApp.Namespace}, currentRevision)
+		if client.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFAppRevision %s/%s", cfApp.Namespace, cfApp.Status.CurrentRevisionRef.Name))
+			return err
+		}
+		if err != nil {
+			currentRevision = nil
+		}
+	}
+
+	if currentRevision != nil &&
+		currentRevision.Spec.DropletRef.Name == cfApp.Spec.CurrentDropletRef.Name &&
+		currentRevision.Spec.EnvSecretHash == envSecretHash &&
+		reflect.DeepEqual(currentRevision.Spec.Processes, processes) {
+		return nil
+	}
+
+	version := cfApp.Status.LastRevisionVersion + 1
+	cfAppRevision := workloadsv1alpha1.CFAppRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", cfApp.Name, version),
+			Namespace: cfApp.Namespace,
+			Labels: map[string]string{
+				workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: workloadsv1alpha1.CFAppRevisionSpec{
+			AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:       version,
+			DropletRef:    cfApp.Spec.CurrentDropletRef,
+			EnvSecretHash: envSecretHash,
+			EnvSecretRef:  corev1.LocalObjectReference{Name: fmt.Sprintf("%s-%d-env", cfApp.Name, version)},
+			Processes:     processes,
+		},
+	}
+	cfAppRevision.Spec.Description = describeRevision(currentRevision, &cfAppRevision)
+
+	err = controllerutil.SetOwnerReference(cfApp, &cfAppRevision, r.Scheme)
+	if err != nil {
+		r.Log.Error(err, "failed to set OwnerRef on CFAppRevision")
+		return err
+	}
+
+	// the revision name is derived from its version, so a revision left behind by a failed status update is reused
+	err = r.Client.Create(ctx, &cfAppRevision)
+	if apierrors.IsAlreadyExists(err) {
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(&cfAppRevision), &cfAppRevision)
+	}
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to create CFAppRevision %s/%s", cfAppRevision.Namespace, cfAppRevision.Name))
+		return err
+	}
+
+	err = r.snapshotEnvVars(ctx, &cfAppRevision, envVars)
+	if err != nil {
+		return err
+	}
+
+	cfApp.Status.LastRevisionVersion = version
+	cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: cfAppRevision.Name}
+	return nil
+}
+
+func hasRevisionProcess(processes []workloadsv1alpha1.RevisionProcess, processType string) bool {
+	for _, process := range processes {
+		if process.Type == processType {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotEnvVars stores the environment variables of the app in a Secret owned by the revision, so that they can be
+// restored when a deployment rolls back to it
+func (r *CFAppReconciler) snapshotEnvVars(ctx context.Context, cfAppRevision *workloadsv1alpha1.CFAppRevision, envVars map[string][]byte) error {
+	envSecret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfAppRevision.Spec.EnvSecretRef.Name,
+			Namespace: cfAppRevision.Namespace,
+		},
+		Data: envVars,
+	}
+
+	err := controllerutil.SetOwnerReference(cfAppRevision, &envSecret, r.Scheme)
+	if err != nil {
+		r.Log.Error(err, "failed to set OwnerRef on the environment variable snapshot Secret")
+		return err
+	}
+
+	err = r.Client.Create(ctx, &envSecret)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to create the environment variable snapshot Secret %s/%s", envSecret.Namespace, envSecret.Name))
+		return err
+	}
+
+	return nil
+}
+
+func (r *CFAppReconciler) getEnvVars(ctx context.Context, cfApp *workloadsv1alpha1.CFApp) (map[string][]byte, error) {
+	envVars := map[string][]byte{}
+	if cfApp.Spec.EnvSecretName != "" {
+		secret := new(corev1.Secret)
+		err := r.Client.Get(ctx, types.NamespacedName{Name: cfApp.Spec.EnvSecretName, Namespace: cfApp.Namespace}, secret)
+		if client.IgnoreNotFound(err) != nil {
+			r.Log.Error(err, fmt.Sprintf("Error when trying to fetch the environment variable Secret %s/%s", cfApp.Namespace, cfApp.Spec.EnvSecretName))
+			return nil, err
+		}
+		if secret.Data != nil {
+			envVars = secret.Data
+		}
+	}
+
+	return envVars, nil
+}
+
+func hashEnvVars(envVars map[string][]byte) (string, error) {
+	// map keys are marshalled in sorted order, so equal contents always give the same hash
+	envVarsJSON, err := json.Marshal(envVars)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(envVarsJSON)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func describeRevision(previous, current *workloadsv1alpha1.CFAppRevision) string {
+	if previous == nil {
+		return "Initial revision."
+	}
+
+	var changes []string
+	if previous.Spec.DropletRef.Name != current.Spec.DropletRef.Name {
+		changes = append(changes, "New droplet deployed.")
+	}
+	if previous.Spec.EnvSecretHash != current.Spec.EnvSecretHash {
+		changes = append(changes, "New environment variables deployed.")
+	}
+
+	previousCommands := map[string]string{}
+	for _, process := range previous.Spec.Processes {
+		previousCommands[process.Type] = process.Command
+	}
+	for _, process := range current.Spec.Processes {
+		previousCommand, ok := previousCommands[process.Type]
+		if ok && previousCommand != process.Command {
+			changes = append(changes, fmt.Sprintf("Start command updated for '%s' process.", process.Type))
+		}
+	}
+
+	return strings.Join(changes, " ")
+}
+
 func addWebIfMissing(processTypes []workloadsv1alpha1.ProcessType) []workloadsv1alpha1.ProcessType {
 	for _, p := range processTypes {
 		if p.Type == processTypeWeb {
@@ -286,5 +548,19 @@ func (r *CFAppReconciler) getCFRoutes(ctx context.Context, cfAppGUID string, cfA
 func (r *CFAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&workloadsv1alpha1.CFApp{}).
+		Watches(&source.Kind{Type: &workloadsv1alpha1.CFProcess{}}, handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+			cfProcess, ok := obj.(*workloadsv1alpha1.CFProcess)
+			if !ok {
+				return []reconcile.Request{}
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cfProcess.Spec.AppRef.Name, Namespace: cfProcess.Namespace}}}
+		})).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(func(secret client.Object) []reconcile.Request {
+			appGUID, ok := secret.GetLabels()[workloadsv1alpha1.CFAppGUIDLabelKey]
+			if !ok {
+				return []reconcile.Request{}
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: appGUID, Namespace: secret.GetNamespace()}}}
+		})).
 		Complete(r)
 }
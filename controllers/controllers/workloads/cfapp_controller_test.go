@@ -51,6 +51,11 @@ var _ = Describe("CFAppReconciler", func() {
 		cfRoutePatchErr error
 		cfRouteListErr  error
 
+		envSecret          *v1.Secret
+		cfAppRevision      *workloadsv1alpha1.CFAppRevision
+		cfAppRevisionError error
+		cfProcessList      workloadsv1alpha1.CFProcessList
+
 		cfAppReconciler *CFAppReconciler
 		ctx             context.Context
 		req             ctrl.Request
@@ -76,6 +81,16 @@ var _ = Describe("CFAppReconciler", func() {
 		cfRoutePatchErr = nil
 		cfRouteListErr = nil
 
+		envSecret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfApp.Spec.EnvSecretName,
+				Namespace: defaultNamespace,
+			},
+			Data: map[string][]byte{"FOO": []byte("bar")},
+		}
+		cfAppRevision = nil
+		cfAppRevisionError = nil
+
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object) error {
 			// cast obj to find its kind
 			switch obj := obj.(type) {
@@ -85,6 +100,14 @@ var _ = Describe("CFAppReconciler", func() {
 			case *workloadsv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return cfAppError
+			case *v1.Secret:
+				envSecret.DeepCopyInto(obj)
+				return nil
+			case *workloadsv1alpha1.CFAppRevision:
+				if cfAppRevision != nil {
+					cfAppRevision.DeepCopyInto(obj)
+				}
+				return cfAppRevisionError
 			default:
 				panic("TestClient Get provided a weird obj")
 			}
@@ -94,7 +117,7 @@ var _ = Describe("CFAppReconciler", func() {
 		fakeStatusWriter = &fake.StatusWriter{}
 		fakeClient.StatusReturns(fakeStatusWriter)
 
-		cfProcessList := workloadsv1alpha1.CFProcessList{}
+		cfProcessList = workloadsv1alpha1.CFProcessList{}
 		cfRouteList := networkingv1alpha1.CFRouteList{
 			Items: []networkingv1alpha1.CFRoute{
 				{
@@ -244,6 +267,86 @@ var _ = Describe("CFAppReconciler", func() {
 		})
 	})
 
+	When("the processes of a started CFApp report their instances", func() {
+		var webProcess, workerProcess *workloadsv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+
+			webProcess = BuildCFProcessCRObject("web-guid", defaultNamespace, cfAppGUID, "web", "rackup")
+			webProcess.Spec.DesiredInstances = 2
+			webProcess.Status.RunningInstances = 2
+			webProcess.Status.RestartCount = 1
+			meta.SetStatusCondition(&webProcess.Status.Conditions, metav1.Condition{Type: StatusConditionRunning, Status: metav1.ConditionTrue, Reason: StatusConditionReasonRunning})
+
+			workerProcess = BuildCFProcessCRObject("worker-guid", defaultNamespace, cfAppGUID, "worker", "work")
+			workerProcess.Spec.DesiredInstances = 1
+			workerProcess.Status.RunningInstances = 1
+			workerProcess.Status.RestartCount = 2
+			meta.SetStatusCondition(&workerProcess.Status.Conditions, metav1.Condition{Type: StatusConditionRunning, Status: metav1.ConditionTrue, Reason: StatusConditionReasonRunning})
+		})
+
+		JustBeforeEach(func() {
+			cfProcessList.Items = []workloadsv1alpha1.CFProcess{*webProcess, *workerProcess}
+			reconcileResult, reconcileErr = cfAppReconciler.Reconcile(ctx, req)
+		})
+
+		updatedApp := func() *workloadsv1alpha1.CFApp {
+			Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+			_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+			return obj.(*workloadsv1alpha1.CFApp)
+		}
+
+		It("sums up the instances and restarts of the processes and sets the app as running", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(updatedApp().Status.RunningInstances).To(Equal(3))
+			Expect(updatedApp().Status.RestartCount).To(BeEquivalentTo(3))
+
+			runningCondition := meta.FindStatusCondition(updatedApp().Status.Conditions, StatusConditionRunning)
+			Expect(runningCondition).NotTo(BeNil())
+			Expect(runningCondition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(runningCondition.Message).To(Equal("3/3 instances running"))
+		})
+
+		When("a process is crashing", func() {
+			BeforeEach(func() {
+				workerProcess.Status.RunningInstances = 0
+				meta.SetStatusCondition(&workerProcess.Status.Conditions, metav1.Condition{Type: StatusConditionRunning, Status: metav1.ConditionFalse, Reason: StatusConditionReasonCrashing})
+			})
+
+			It("sets the app as crashing", func() {
+				runningCondition := meta.FindStatusCondition(updatedApp().Status.Conditions, StatusConditionRunning)
+				Expect(runningCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(runningCondition.Reason).To(Equal(StatusConditionReasonCrashing))
+				Expect(runningCondition.Message).To(Equal("2/3 instances running"))
+			})
+		})
+
+		When("not all the instances are running yet", func() {
+			BeforeEach(func() {
+				webProcess.Status.RunningInstances = 1
+			})
+
+			It("sets the app as starting", func() {
+				runningCondition := meta.FindStatusCondition(updatedApp().Status.Conditions, StatusConditionRunning)
+				Expect(runningCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(runningCondition.Reason).To(Equal(StatusConditionReasonStarting))
+			})
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				cfApp.Spec.DesiredState = workloadsv1alpha1.StoppedState
+			})
+
+			It("sets the app as stopped", func() {
+				runningCondition := meta.FindStatusCondition(updatedApp().Status.Conditions, StatusConditionRunning)
+				Expect(runningCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(runningCondition.Reason).To(Equal(StatusConditionReasonStopped))
+			})
+		})
+	})
+
 	When("a CFApp is updated to set currentDropletRef and Reconcile function is called", func() {
 		BeforeEach(func() {
 			cfApp.Spec.CurrentDropletRef = v1.LocalObjectReference{Name: cfBuildGUID}
@@ -271,8 +374,8 @@ var _ = Describe("CFAppReconciler", func() {
 				Expect(testRequestNamespacedName.Namespace).To(Equal(defaultNamespace))
 				Expect(testRequestNamespacedName.Name).To(Equal(cfBuildGUID))
 
-				// Validate call count to fetch CFProcess
-				Expect(fakeClient.ListCallCount()).To(Equal(1))
+				// Validate call count to fetch CFProcess, for the revision and for the running status
+				Expect(fakeClient.ListCallCount()).To(Equal(3))
 
 				// Validate call count to create CFProcess
 				Expect(fakeClient.CreateCallCount()).To(Equal(1))
@@ -288,6 +391,205 @@ var _ = Describe("CFAppReconciler", func() {
 			})
 		})
 
+		When("recording revisions", func() {
+			var (
+				createdRevision    *workloadsv1alpha1.CFAppRevision
+				createdEnvSnapshot *v1.Secret
+			)
+
+			BeforeEach(func() {
+				cfBuild.Status.BuildDropletStatus.ProcessTypes = []workloadsv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}}
+				cfProcessList.Items = []workloadsv1alpha1.CFProcess{
+					*BuildCFProcessCRObject("cf-proc-guid", defaultNamespace, cfAppGUID, "web", "bundle exec rackup"),
+				}
+			})
+
+			JustBeforeEach(func() {
+				createdRevision = nil
+				createdEnvSnapshot = nil
+				createCallCount := fakeClient.CreateCallCount()
+				reconcileResult, reconcileErr = cfAppReconciler.Reconcile(ctx, req)
+				for i := createCallCount; i < fakeClient.CreateCallCount(); i++ {
+					_, obj, _ := fakeClient.CreateArgsForCall(i)
+					switch obj := obj.(type) {
+					case *workloadsv1alpha1.CFAppRevision:
+						createdRevision = obj
+					case *v1.Secret:
+						createdEnvSnapshot = obj
+					}
+				}
+			})
+
+			It("records the initial revision of the app", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(createdRevision).NotTo(BeNil())
+				Expect(createdRevision.Name).To(Equal(cfAppGUID + "-1"))
+				Expect(createdRevision.Labels).To(HaveKeyWithValue(workloadsv1alpha1.CFAppGUIDLabelKey, cfAppGUID))
+				Expect(createdRevision.OwnerReferences).To(HaveLen(1))
+				Expect(createdRevision.Spec.AppRef.Name).To(Equal(cfAppGUID))
+				Expect(createdRevision.Spec.Version).To(BeEquivalentTo(1))
+				Expect(createdRevision.Spec.Description).To(Equal("Initial revision."))
+				Expect(createdRevision.Spec.DropletRef.Name).To(Equal(cfBuildGUID))
+				Expect(createdRevision.Spec.EnvSecretHash).NotTo(BeEmpty())
+				Expect(createdRevision.Spec.Processes).To(ConsistOf(workloadsv1alpha1.RevisionProcess{Type: "web", Command: "bundle exec rackup"}))
+			})
+
+			It("snapshots the environment variables of the app in a secret owned by the revision", func() {
+				Expect(createdEnvSnapshot).NotTo(BeNil())
+				Expect(createdEnvSnapshot.Name).To(Equal(cfAppGUID + "-1-env"))
+				Expect(createdEnvSnapshot.Namespace).To(Equal(defaultNamespace))
+				Expect(createdEnvSnapshot.Data).To(Equal(envSecret.Data))
+				Expect(createdEnvSnapshot.OwnerReferences).To(ConsistOf(HaveField("Name", cfAppGUID+"-1")))
+				Expect(createdRevision.Spec.EnvSecretRef.Name).To(Equal(createdEnvSnapshot.Name))
+			})
+
+			It("fetches the environment variable secret of the app", func() {
+				_, secretName, _ := fakeClient.GetArgsForCall(fakeClient.GetCallCount() - 1)
+				Expect(secretName).To(Equal(types.NamespacedName{Name: cfApp.Spec.EnvSecretName, Namespace: defaultNamespace}))
+			})
+
+			It("points the app status at the new revision", func() {
+				Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+				_, updatedCFApp, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				cast, ok := updatedCFApp.(*workloadsv1alpha1.CFApp)
+				Expect(ok).To(BeTrue())
+				Expect(cast.Status.CurrentRevisionRef.Name).To(Equal(cfAppGUID + "-1"))
+				Expect(cast.Status.LastRevisionVersion).To(BeEquivalentTo(1))
+			})
+
+			When("the app is already at a revision", func() {
+				BeforeEach(func() {
+					_, err := cfAppReconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+					for i := 0; i < fakeClient.CreateCallCount(); i++ {
+						_, obj, _ := fakeClient.CreateArgsForCall(i)
+						if revision, ok := obj.(*workloadsv1alpha1.CFAppRevision); ok {
+							cfAppRevision = revision.DeepCopy()
+						}
+					}
+					cfApp.Status.CurrentRevisionRef.Name = cfAppRevision.Name
+					cfApp.Status.LastRevisionVersion = cfAppRevision.Spec.Version
+				})
+
+				When("nothing has changed", func() {
+					It("does not record a revision", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(createdRevision).To(BeNil())
+						Expect(createdEnvSnapshot).To(BeNil())
+					})
+				})
+
+				When("the droplet has changed", func() {
+					BeforeEach(func() {
+						cfApp.Spec.CurrentDropletRef.Name = "another-build-guid"
+					})
+
+					It("records a new revision", func() {
+						Expect(reconcileErr).NotTo(HaveOccurred())
+						Expect(createdRevision).NotTo(BeNil())
+						Expect(createdRevision.Name).To(Equal(cfAppGUID + "-2"))
+						Expect(createdRevision.Spec.Version).To(BeEquivalentTo(2))
+						Expect(createdRevision.Spec.DropletRef.Name).To(Equal("another-build-guid"))
+						Expect(createdRevision.Spec.Description).To(Equal("New droplet deployed."))
+					})
+				})
+
+				When("the environment variables have changed", func() {
+					BeforeEach(func() {
+						envSecret.Data["FOO"] = []byte("baz")
+					})
+
+					It("records a new revision", func() {
+						Expect(createdRevision).NotTo(BeNil())
+						Expect(createdRevision.Spec.EnvSecretHash).NotTo(Equal(cfAppRevision.Spec.EnvSecretHash))
+						Expect(createdRevision.Spec.Description).To(Equal("New environment variables deployed."))
+					})
+
+					It("snapshots the new environment variables", func() {
+						Expect(createdEnvSnapshot).NotTo(BeNil())
+						Expect(createdEnvSnapshot.Name).To(Equal(cfAppGUID + "-2-env"))
+						Expect(createdEnvSnapshot.Data).To(HaveKeyWithValue("FOO", []byte("baz")))
+					})
+				})
+
+				When("a process command has changed", func() {
+					BeforeEach(func() {
+						cfProcessList.Items[0].Spec.Command = "bundle exec puma"
+					})
+
+					It("records a new revision", func() {
+						Expect(createdRevision).NotTo(BeNil())
+						Expect(createdRevision.Spec.Processes).To(ConsistOf(workloadsv1alpha1.RevisionProcess{Type: "web", Command: "bundle exec puma"}))
+						Expect(createdRevision.Spec.Description).To(Equal("Start command updated for 'web' process."))
+					})
+				})
+			})
+
+			When("a process of the droplet does not exist yet", func() {
+				BeforeEach(func() {
+					cfProcessList.Items = nil
+				})
+
+				It("waits for it before recording a revision", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					Expect(createdRevision).To(BeNil())
+				})
+			})
+
+			When("the revision already exists", func() {
+				BeforeEach(func() {
+					fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						if _, ok := obj.(*workloadsv1alpha1.CFAppRevision); ok {
+							return apierrors.NewAlreadyExists(schema.GroupResource{}, obj.GetName())
+						}
+						return nil
+					}
+				})
+
+				It("still points the app status at it", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					_, updatedCFApp, _ := fakeStatusWriter.UpdateArgsForCall(0)
+					Expect(updatedCFApp.(*workloadsv1alpha1.CFApp).Status.CurrentRevisionRef.Name).To(Equal(cfAppGUID + "-1"))
+				})
+
+				It("still snapshots the environment variables", func() {
+					Expect(createdEnvSnapshot).NotTo(BeNil())
+					Expect(createdEnvSnapshot.Name).To(Equal(cfAppGUID + "-1-env"))
+				})
+			})
+
+			When("creating the environment variable snapshot fails", func() {
+				BeforeEach(func() {
+					fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						if _, ok := obj.(*v1.Secret); ok {
+							return errors.New(failsOnPurposeErrorMessage)
+						}
+						return nil
+					}
+				})
+
+				It("returns an error without moving the app to the revision", func() {
+					Expect(reconcileErr).To(MatchError(failsOnPurposeErrorMessage))
+					Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+				})
+			})
+
+			When("creating the revision fails", func() {
+				BeforeEach(func() {
+					fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						if _, ok := obj.(*workloadsv1alpha1.CFAppRevision); ok {
+							return errors.New(failsOnPurposeErrorMessage)
+						}
+						return nil
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(reconcileErr).To(MatchError(failsOnPurposeErrorMessage))
+				})
+			})
+		})
+
 		When("on the unhappy path", func() {
 			When("fetch CFApp returns an error", func() {
 				BeforeEach(func() {
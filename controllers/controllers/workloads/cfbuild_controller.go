@@ -25,6 +25,7 @@ import (
 
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/imageprocessfetcher"
 
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -58,17 +59,18 @@ const (
 )
 
 //counterfeiter:generate -o fake -fake-name RegistryAuthFetcher . RegistryAuthFetcher
-type RegistryAuthFetcher func(ctx context.Context, namespace string) (remote.Option, error)
+type RegistryAuthFetcher func(ctx context.Context, namespace string, imagePullSecrets []corev1.LocalObjectReference) (remote.Option, error)
 
 func NewRegistryAuthFetcher(privilegedK8sClient k8sclient.Interface) RegistryAuthFetcher {
-	return func(ctx context.Context, namespace string) (remote.Option, error) {
+	return func(ctx context.Context, namespace string, imagePullSecrets []corev1.LocalObjectReference) (remote.Option, error) {
 		keychainFactory, err := k8sdockercreds.NewSecretKeychainFactory(privilegedK8sClient)
 		if err != nil {
 			return nil, fmt.Errorf("error in k8sdockercreds.NewSecretKeychainFactory: %w", err)
 		}
 		keychain, err := keychainFactory.KeychainForSecretRef(ctx, registry.SecretRef{
-			Namespace:      namespace,
-			ServiceAccount: kpackServiceAccount,
+			Namespace:        namespace,
+			ServiceAccount:   kpackServiceAccount,
+			ImagePullSecrets: imagePullSecrets,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("error in keychainFactory.KeychainForSecretRef: %w", err)
@@ -151,6 +153,15 @@ func (r *CFBuildReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	succeededStatus := getConditionOrSetAsUnknown(&cfBuild.Status.Conditions, workloadsv1alpha1.SucceededConditionType)
 
 	if stagingStatus == metav1.ConditionUnknown &&
+		succeededStatus == metav1.ConditionUnknown &&
+		cfPackage.Spec.Type == workloadsv1alpha1.DockerPackage {
+		// Scenario: CFBuild newly created for a docker package. There is nothing to stage, so
+		// the droplet is generated from the config of the image right away.
+		err = r.generateDockerDropletAndUpdateStatus(ctx, cfBuild, cfPackage)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if stagingStatus == metav1.ConditionUnknown &&
 		succeededStatus == metav1.ConditionUnknown {
 		// Scenario: CFBuild newly created and all status conditions are unknown, it
 		// Creates a KpackImage resource to trigger staging.
@@ -290,6 +301,48 @@ func (r *CFBuildReconciler) createKpackImageAndUpdateStatus(ctx context.Context,
 	return nil
 }
 
+func (r *CFBuildReconciler) generateDockerDropletAndUpdateStatus(ctx context.Context, cfBuild *workloadsv1alpha1.CFBuild, cfPackage *workloadsv1alpha1.CFPackage) error {
+	err := r.ensureKpackImageRequirements(ctx, cfPackage)
+	if err != nil {
+		r.Log.Info("Image pull secrets for CFPackage are not available", "guid", cfPackage.Name, "reason", err)
+		return err
+	}
+
+	registry := cfPackage.Spec.Source.Registry
+	credentials, err := r.RegistryAuthFetcher(ctx, cfPackage.Namespace, registry.ImagePullSecrets)
+	if err != nil {
+		r.Log.Error(err, "Error when fetching registry credentials for docker image")
+		return err
+	}
+
+	processTypes, ports, err := r.ImageProcessFetcher(registry.Image, credentials)
+	if err != nil && !imageprocessfetcher.IsPermanentError(err) {
+		r.Log.Info("Error when fetching the config of the docker image, retrying", "image", registry.Image, "reason", err)
+		return err
+	}
+
+	if err != nil {
+		r.Log.Info("Error when fetching the config of the docker image", "image", registry.Image, "reason", err)
+		setStatusConditionOnLocalCopy(&cfBuild.Status.Conditions, workloadsv1alpha1.StagingConditionType, metav1.ConditionFalse, "docker", "docker")
+		setStatusConditionOnLocalCopy(&cfBuild.Status.Conditions, workloadsv1alpha1.SucceededConditionType, metav1.ConditionFalse, "docker", err.Error())
+	} else {
+		setStatusConditionOnLocalCopy(&cfBuild.Status.Conditions, workloadsv1alpha1.StagingConditionType, metav1.ConditionFalse, "docker", "docker")
+		setStatusConditionOnLocalCopy(&cfBuild.Status.Conditions, workloadsv1alpha1.SucceededConditionType, metav1.ConditionTrue, "docker", "docker")
+		cfBuild.Status.BuildDropletStatus = &workloadsv1alpha1.BuildDropletStatus{
+			Registry:     *registry.DeepCopy(),
+			ProcessTypes: processTypes,
+			Ports:        ports,
+		}
+	}
+
+	if err := r.Client.Status().Update(ctx, cfBuild); err != nil {
+		r.Log.Error(err, "Error when updating CFBuild status")
+		return err
+	}
+
+	return nil
+}
+
 func (r *CFBuildReconciler) prepareBuildServices(ctx context.Context, namespace, appGUID string) (buildv1alpha2.Services, error) {
 	serviceBindingsList := &servicesv1alpha1.CFServiceBindingList{}
 	err := r.Client.List(ctx, serviceBindingsList,
@@ -366,11 +419,9 @@ func (r *CFBuildReconciler) ensureKpackImageRequirements(ctx context.Context, cf
 
 func (r *CFBuildReconciler) generateBuildDropletStatus(ctx context.Context, kpackImage *buildv1alpha2.Image, imagePullSecrets []corev1.LocalObjectReference) (*workloadsv1alpha1.BuildDropletStatus, error) {
 	imageRef := kpackImage.Status.LatestImage
-	// imagePullSecrets := kpackImage.Spec.Source.Registry.ImagePullSecrets
 
-	// Use ImagePullSecrets to extract credentials with RegistryAuthFetcher
-	// RegistryAuthFetcher func(ctx context.Context, imagePullSecrets []corev1.LocalObjectReference, namespace string) (remote.Option, error)
-	credentials, err := r.RegistryAuthFetcher(ctx, kpackImage.Namespace)
+	// The droplet image is pushed with the credentials of the kpack service account, so no extra ImagePullSecrets are needed
+	credentials, err := r.RegistryAuthFetcher(ctx, kpackImage.Namespace, nil)
 	if err != nil {
 		r.Log.Error(err, "Error when fetching registry credentials for Droplet image")
 		return nil, err
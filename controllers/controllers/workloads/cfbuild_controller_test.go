@@ -3,6 +3,7 @@ package workloads_test
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -10,12 +11,14 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/fake"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -299,6 +302,128 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("CFBuild status conditions are unknown and the package is a docker image", func() {
+		BeforeEach(func() {
+			cfPackage.Spec.Type = workloadsv1alpha1.DockerPackage
+			cfPackage.Spec.Source.Registry.Image = "registry.example.com/my/image:latest"
+			fakeImageProcessFetcher.Returns(
+				[]workloadsv1alpha1.ProcessType{{Type: "web", Command: "/entrypoint serve"}},
+				[]int32{8080},
+				nil,
+			)
+		})
+
+		It("does not create a kpack image", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+
+		It("fetches the image config with the image pull secrets of the package", func() {
+			Expect(fakeRegistryAuthFetcher.CallCount()).To(Equal(1))
+			_, namespace, imagePullSecrets := fakeRegistryAuthFetcher.ArgsForCall(0)
+			Expect(namespace).To(Equal(defaultNamespace))
+			Expect(imagePullSecrets).To(Equal(cfPackage.Spec.Source.Registry.ImagePullSecrets))
+
+			Expect(fakeImageProcessFetcher.CallCount()).To(Equal(1))
+			imageRef, _ := fakeImageProcessFetcher.ArgsForCall(0)
+			Expect(imageRef).To(Equal("registry.example.com/my/image:latest"))
+		})
+
+		It("sets the droplet from the image config and marks the build as succeeded", func() {
+			Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+			_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+			updatedCFBuild, ok := obj.(*workloadsv1alpha1.CFBuild)
+			Expect(ok).To(BeTrue())
+
+			Expect(updatedCFBuild.Status.BuildDropletStatus).To(Equal(&workloadsv1alpha1.BuildDropletStatus{
+				Registry:     cfPackage.Spec.Source.Registry,
+				ProcessTypes: []workloadsv1alpha1.ProcessType{{Type: "web", Command: "/entrypoint serve"}},
+				Ports:        []int32{8080},
+			}))
+			Expect(meta.IsStatusConditionFalse(updatedCFBuild.Status.Conditions, stagingConditionType)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(updatedCFBuild.Status.Conditions, succeededConditionType)).To(BeTrue())
+		})
+
+		When("the registry does not know the image", func() {
+			BeforeEach(func() {
+				fakeImageProcessFetcher.Returns(nil, nil, &transport.Error{
+					StatusCode: http.StatusNotFound,
+					Errors:     []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode, Message: "image not found"}},
+				})
+			})
+
+			It("marks the build as failed", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+				_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				updatedCFBuild := obj.(*workloadsv1alpha1.CFBuild)
+
+				Expect(updatedCFBuild.Status.BuildDropletStatus).To(BeNil())
+				succeededCondition := meta.FindStatusCondition(updatedCFBuild.Status.Conditions, succeededConditionType)
+				Expect(succeededCondition).NotTo(BeNil())
+				Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(succeededCondition.Message).To(ContainSubstring("image not found"))
+			})
+		})
+
+		When("the registry denies access to the image", func() {
+			BeforeEach(func() {
+				fakeImageProcessFetcher.Returns(nil, nil, &transport.Error{StatusCode: http.StatusUnauthorized})
+			})
+
+			It("marks the build as failed", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeStatusWriter.UpdateCallCount()).To(Equal(1))
+				_, obj, _ := fakeStatusWriter.UpdateArgsForCall(0)
+				updatedCFBuild := obj.(*workloadsv1alpha1.CFBuild)
+				Expect(meta.IsStatusConditionFalse(updatedCFBuild.Status.Conditions, succeededConditionType)).To(BeTrue())
+			})
+		})
+
+		When("the registry fails with a server error", func() {
+			BeforeEach(func() {
+				fakeImageProcessFetcher.Returns(nil, nil, &transport.Error{StatusCode: http.StatusServiceUnavailable})
+			})
+
+			It("returns the error so that the build is retried", func() {
+				Expect(reconcileErr).To(HaveOccurred())
+				Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+			})
+		})
+
+		When("the registry cannot be reached", func() {
+			BeforeEach(func() {
+				fakeImageProcessFetcher.Returns(nil, nil, errors.New("dial tcp: connection refused"))
+			})
+
+			It("returns the error so that the build is retried", func() {
+				Expect(reconcileErr).To(MatchError("dial tcp: connection refused"))
+				Expect(fakeStatusWriter.UpdateCallCount()).To(BeZero())
+			})
+		})
+
+		When("the image pull secret does not exist", func() {
+			BeforeEach(func() {
+				kpackRegistrySecretError = apierrors.NewNotFound(schema.GroupResource{}, kpackRegistrySecretName)
+			})
+
+			It("returns an error without fetching the image", func() {
+				Expect(reconcileErr).To(HaveOccurred())
+				Expect(fakeImageProcessFetcher.CallCount()).To(BeZero())
+			})
+		})
+
+		When("RegistryAuthFetcher fails", func() {
+			BeforeEach(func() {
+				fakeRegistryAuthFetcher.Returns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("boom"))
+			})
+		})
+	})
+
 	When("CFBuild status conditions for Staging is True and others are unknown", func() {
 		BeforeEach(func() {
 			SetStatusCondition(&cfBuild.Status.Conditions, stagingConditionType, metav1.ConditionTrue)
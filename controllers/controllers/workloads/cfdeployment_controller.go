@@ -0,0 +1,507 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// CFDeploymentReconciler reconciles a CFDeployment object
+type CFDeploymentReconciler struct {
+	client.Client
+	// APIReader reads from the API server rather than from the cache of the Client
+	APIReader client.Reader
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	// ProgressDeadline is how long a deployment may be deploying or canceling before it is finalized as DEGENERATE
+	ProgressDeadline time.Duration
+}
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfdeployments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfdeployments/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapprevisions,verbs=get
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="eirini.cloudfoundry.org",resources=lrps,verbs=get;list;watch;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+
+// Reconcile rolls the processes of the app over to the revision of the deployment.
+// The new revision is started by the CFProcessReconciler, which leaves the LRPs of the old revision
+// alone while the deployment is active. The old LRPs are scaled down as the new instances become ready.
+// The deployment is finalized without touching the LRPs any further when the app is moved to another revision
+// outside the deployment, e.g. by a restart, or when the new instances do not become ready in time.
+func (r *CFDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfDeployment := new(workloadsv1alpha1.CFDeployment)
+	err := r.Client.Get(ctx, req.NamespacedName, cfDeployment)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFDeployment %s/%s", req.Namespace, req.Name))
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if cfDeployment.Status.Value == workloadsv1alpha1.DeploymentStatusValueFinalized {
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := new(workloadsv1alpha1.CFApp)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: cfDeployment.Spec.AppRef.Name, Namespace: cfDeployment.Namespace}, cfApp)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFApp %s/%s", req.Namespace, cfDeployment.Spec.AppRef.Name))
+		return ctrl.Result{}, err
+	}
+
+	if cfDeployment.Status.Value == "" {
+		err = r.initializeDeployment(ctx, cfDeployment, cfApp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if !cancelRequested(cfDeployment) {
+		var onRevision bool
+		onRevision, err = r.syncAppRevision(ctx, cfDeployment, cfApp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !onRevision {
+			return ctrl.Result{}, r.setDeploymentStatus(ctx, cfDeployment, workloadsv1alpha1.DeploymentStatusValueFinalized, workloadsv1alpha1.DeploymentStatusReasonSuperseded)
+		}
+	}
+
+	if cancelRequested(cfDeployment) {
+		err = r.cancelDeployment(ctx, cfDeployment, cfApp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	targetRevision := cfDeployment.Status.Revision
+	finalReason := workloadsv1alpha1.DeploymentStatusReasonDeployed
+	if cfDeployment.Status.Reason == workloadsv1alpha1.DeploymentStatusReasonCanceling {
+		targetRevision = cfDeployment.Status.PreviousRevision
+		finalReason = workloadsv1alpha1.DeploymentStatusReasonCanceled
+	}
+
+	done, err := r.rollProcesses(ctx, cfApp, targetRevision)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if done {
+		return ctrl.Result{}, r.setDeploymentStatus(ctx, cfDeployment, workloadsv1alpha1.DeploymentStatusValueFinalized, finalReason)
+	}
+
+	remaining := r.ProgressDeadline - time.Since(cfDeployment.Status.LastStatusChange.Time)
+	if remaining <= 0 {
+		r.Log.Info(fmt.Sprintf("CFDeployment %s/%s made no progress within %s", cfDeployment.Namespace, cfDeployment.Name, r.ProgressDeadline))
+		return ctrl.Result{}, r.setDeploymentStatus(ctx, cfDeployment, workloadsv1alpha1.DeploymentStatusValueFinalized, workloadsv1alpha1.DeploymentStatusReasonDegenerate)
+	}
+
+	// the deployment is reconciled again when its LRPs change, or once its progress deadline has passed
+	return ctrl.Result{RequeueAfter: remaining}, nil
+}
+
+// syncAppRevision tells whether the app is still on the revision the deployment moves it to. It is not when the app
+// has been moved to another revision outside the deployment, e.g. by a restart, in which case the LRPs of that
+// revision are no longer the deployment's to scale down.
+func (r *CFDeploymentReconciler) syncAppRevision(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment, cfApp *workloadsv1alpha1.CFApp) (bool, error) {
+	// the cached app may not show the revision the deployment moved it to yet, which would be taken for another one
+	err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return false, err
+	}
+
+	revision := appRevision(cfApp)
+	if cfDeployment.Status.Reason == workloadsv1alpha1.DeploymentStatusReasonCanceling {
+		return revision == cfDeployment.Status.PreviousRevision, nil
+	}
+
+	if revision == cfDeployment.Status.PreviousRevision {
+		// revisions only go up outside of deployments, so the app has not been moved to the new revision yet
+		return true, r.patchApp(ctx, cfApp, cfDeployment.Status.DropletRef, cfDeployment.Status.Revision)
+	}
+
+	return revision == cfDeployment.Status.Revision, nil
+}
+
+func cancelRequested(cfDeployment *workloadsv1alpha1.CFDeployment) bool {
+	return cfDeployment.Spec.Canceled && cfDeployment.Status.Reason == workloadsv1alpha1.DeploymentStatusReasonDeploying
+}
+
+// initializeDeployment supersedes the other active deployments of the app, then moves the app to a new revision
+// running the droplet of the deployment. The deployment is marked as active before the app is changed, so that the
+// CFProcessReconciler keeps the LRPs of the previous revision running.
+func (r *CFDeploymentReconciler) initializeDeployment(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment, cfApp *workloadsv1alpha1.CFApp) error {
+	err := r.supersedeActiveDeployments(ctx, cfDeployment)
+	if err != nil {
+		return err
+	}
+
+	originalCFDeployment := cfDeployment.DeepCopy()
+	err = controllerutil.SetOwnerReference(cfApp, cfDeployment, r.Scheme)
+	if err != nil {
+		r.Log.Error(err, "unable to set owner reference on CFDeployment")
+		return err
+	}
+
+	err = r.Client.Patch(ctx, cfDeployment, client.MergeFrom(originalCFDeployment))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error setting owner reference on the CFDeployment %s/%s", cfDeployment.Namespace, cfDeployment.Name))
+		return err
+	}
+
+	processes, err := r.fetchProcessesForApp(ctx, cfApp)
+	if err != nil {
+		return err
+	}
+
+	previousRevision := appRevision(cfApp)
+	dropletRef := cfDeployment.Spec.DropletRef
+	if dropletRef.Name == "" {
+		dropletRef = cfApp.Spec.CurrentDropletRef
+	}
+
+	if cfDeployment.Spec.RevisionRef.Name != "" {
+		dropletRef, err = r.rollBackToRevision(ctx, cfDeployment, cfApp, processes)
+		if err != nil {
+			return err
+		}
+	}
+
+	originalCFDeployment = cfDeployment.DeepCopy()
+	cfDeployment.Status.Value = workloadsv1alpha1.DeploymentStatusValueActive
+	cfDeployment.Status.Reason = workloadsv1alpha1.DeploymentStatusReasonDeploying
+	cfDeployment.Status.LastStatusChange = metav1.Now()
+	cfDeployment.Status.DropletRef = dropletRef
+	cfDeployment.Status.PreviousDropletRef = cfApp.Spec.CurrentDropletRef
+	cfDeployment.Status.Revision = nextRevision(previousRevision)
+	cfDeployment.Status.PreviousRevision = previousRevision
+	cfDeployment.Status.Processes = make([]workloadsv1alpha1.DeploymentProcess, 0, len(processes))
+	for _, process := range processes {
+		cfDeployment.Status.Processes = append(cfDeployment.Status.Processes, workloadsv1alpha1.DeploymentProcess{
+			GUID: process.Name,
+			Type: process.Spec.ProcessType,
+		})
+	}
+	err = r.Client.Status().Patch(ctx, cfDeployment, client.MergeFrom(originalCFDeployment))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to initialize the status of CFDeployment %s/%s", cfDeployment.Namespace, cfDeployment.Name))
+		return err
+	}
+
+	return r.patchApp(ctx, cfApp, dropletRef, cfDeployment.Status.Revision)
+}
+
+// rollBackToRevision restores the environment variables and the process commands recorded by the revision of the
+// deployment and returns its droplet.
+func (r *CFDeploymentReconciler) rollBackToRevision(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment, cfApp *workloadsv1alpha1.CFApp, processes []workloadsv1alpha1.CFProcess) (corev1.LocalObjectReference, error) {
+	cfAppRevision := new(workloadsv1alpha1.CFAppRevision)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: cfDeployment.Spec.RevisionRef.Name, Namespace: cfDeployment.Namespace}, cfAppRevision)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFAppRevision %s/%s", cfDeployment.Namespace, cfDeployment.Spec.RevisionRef.Name))
+		return corev1.LocalObjectReference{}, err
+	}
+
+	err = r.restoreEnvVars(ctx, cfAppRevision, cfApp)
+	if err != nil {
+		return corev1.LocalObjectReference{}, err
+	}
+
+	commands := map[string]string{}
+	for _, process := range cfAppRevision.Spec.Processes {
+		commands[process.Type] = process.Command
+	}
+
+	for i := range processes {
+		cfProcess := &processes[i]
+		command, ok := commands[cfProcess.Spec.ProcessType]
+		if !ok || command == cfProcess.Spec.Command {
+			continue
+		}
+
+		originalCFProcess := cfProcess.DeepCopy()
+		cfProcess.Spec.Command = command
+		err = r.Client.Patch(ctx, cfProcess, client.MergeFrom(originalCFProcess))
+		if err != nil {
+			r.Log.Error(err, fmt.Sprintf("Error when trying to restore the command of CFProcess %s/%s", cfProcess.Namespace, cfProcess.Name))
+			return corev1.LocalObjectReference{}, err
+		}
+	}
+
+	return cfAppRevision.Spec.DropletRef, nil
+}
+
+// restoreEnvVars replaces the contents of the environment variable Secret of the app with the snapshot of the revision
+func (r *CFDeploymentReconciler) restoreEnvVars(ctx context.Context, cfAppRevision *workloadsv1alpha1.CFAppRevision, cfApp *workloadsv1alpha1.CFApp) error {
+	if cfApp.Spec.EnvSecretName == "" {
+		return nil
+	}
+
+	snapshotSecret := new(corev1.Secret)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: cfAppRevision.Spec.EnvSecretRef.Name, Namespace: cfAppRevision.Namespace}, snapshotSecret)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch the environment variable snapshot Secret of CFAppRevision %s/%s", cfAppRevision.Namespace, cfAppRevision.Name))
+		return err
+	}
+
+	envSecret := new(corev1.Secret)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: cfApp.Spec.EnvSecretName, Namespace: cfApp.Namespace}, envSecret)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch the environment variable Secret %s/%s", cfApp.Namespace, cfApp.Spec.EnvSecretName))
+		return err
+	}
+
+	if reflect.DeepEqual(envSecret.Data, snapshotSecret.Data) {
+		return nil
+	}
+
+	originalEnvSecret := envSecret.DeepCopy()
+	envSecret.Data = snapshotSecret.Data
+	err = r.Client.Patch(ctx, envSecret, client.MergeFrom(originalEnvSecret))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to restore the environment variable Secret %s/%s", envSecret.Namespace, envSecret.Name))
+		return err
+	}
+
+	return nil
+}
+
+func (r *CFDeploymentReconciler) supersedeActiveDeployments(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment) error {
+	deploymentList := new(workloadsv1alpha1.CFDeploymentList)
+	err := r.Client.List(ctx, deploymentList, client.InNamespace(cfDeployment.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfDeployment.Spec.AppRef.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the CFDeployments of CFApp %s/%s", cfDeployment.Namespace, cfDeployment.Spec.AppRef.Name))
+		return err
+	}
+
+	for i := range deploymentList.Items {
+		otherDeployment := &deploymentList.Items[i]
+		if otherDeployment.Name == cfDeployment.Name || otherDeployment.Status.Value != workloadsv1alpha1.DeploymentStatusValueActive {
+			continue
+		}
+
+		err = r.setDeploymentStatus(ctx, otherDeployment, workloadsv1alpha1.DeploymentStatusValueFinalized, workloadsv1alpha1.DeploymentStatusReasonSuperseded)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancelDeployment moves the app back to the droplet and revision it was running before the deployment.
+// The LRPs of the previous revision are then scaled back up the same way the new ones were. The app is moved before
+// the deployment is marked as canceling, so that a deployment that is canceling always has the app on the previous
+// revision, unless something else moved it since.
+func (r *CFDeploymentReconciler) cancelDeployment(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment, cfApp *workloadsv1alpha1.CFApp) error {
+	dropletRef := cfDeployment.Status.PreviousDropletRef
+	if dropletRef.Name == "" {
+		dropletRef = cfApp.Spec.CurrentDropletRef
+	}
+
+	err := r.patchApp(ctx, cfApp, dropletRef, cfDeployment.Status.PreviousRevision)
+	if err != nil {
+		return err
+	}
+
+	return r.setDeploymentStatus(ctx, cfDeployment, workloadsv1alpha1.DeploymentStatusValueActive, workloadsv1alpha1.DeploymentStatusReasonCanceling)
+}
+
+func (r *CFDeploymentReconciler) patchApp(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, dropletRef corev1.LocalObjectReference, revision string) error {
+	originalCFApp := cfApp.DeepCopy()
+	cfApp.Spec.CurrentDropletRef = dropletRef
+	cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+	if cfApp.Annotations == nil {
+		cfApp.Annotations = map[string]string{}
+	}
+	cfApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = revision
+
+	err := r.Client.Patch(ctx, cfApp, client.MergeFrom(originalCFApp))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to move CFApp %s/%s to revision %s", cfApp.Namespace, cfApp.Name, revision))
+		return err
+	}
+
+	return nil
+}
+
+// rollProcesses scales down the LRPs of every other revision by the number of ready instances of the target revision.
+// It returns true once only the target revision is left and all its instances are ready.
+func (r *CFDeploymentReconciler) rollProcesses(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, targetRevision string) (bool, error) {
+	if cfApp.Spec.DesiredState == workloadsv1alpha1.StoppedState {
+		// the CFProcessReconciler deletes all the LRPs of stopped apps
+		return true, nil
+	}
+
+	processes, err := r.fetchProcessesForApp(ctx, cfApp)
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	for i := range processes {
+		processDone, err := r.rollProcess(ctx, &processes[i], targetRevision)
+		if err != nil {
+			return false, err
+		}
+		done = done && processDone
+	}
+
+	return done, nil
+}
+
+func (r *CFDeploymentReconciler) rollProcess(ctx context.Context, cfProcess *workloadsv1alpha1.CFProcess, targetRevision string) (bool, error) {
+	lrpList := new(eiriniv1.LRPList)
+	err := r.Client.List(ctx, lrpList, client.InNamespace(cfProcess.Namespace), client.MatchingLabels{workloadsv1alpha1.CFProcessGUIDLabelKey: cfProcess.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch LRPs for Process %s/%s", cfProcess.Namespace, cfProcess.Name))
+		return false, err
+	}
+
+	readyInstances := 0
+	for _, lrp := range lrpList.Items {
+		if lrp.Labels[workloadsv1alpha1.CFAppRevisionKey] == targetRevision {
+			readyInstances = int(lrp.Status.Replicas)
+		}
+	}
+
+	oldInstances := cfProcess.Spec.DesiredInstances - readyInstances
+	done := oldInstances <= 0
+	for i := range lrpList.Items {
+		lrp := &lrpList.Items[i]
+		if lrp.Labels[workloadsv1alpha1.CFAppRevisionKey] == targetRevision {
+			continue
+		}
+
+		if oldInstances <= 0 {
+			err = r.Client.Delete(ctx, lrp)
+			if client.IgnoreNotFound(err) != nil {
+				r.Log.Error(err, fmt.Sprintf("Error when trying to delete LRP %s/%s", lrp.Namespace, lrp.Name))
+				return false, err
+			}
+			continue
+		}
+
+		done = false
+		if lrp.Spec.Instances > oldInstances {
+			originalLRP := lrp.DeepCopy()
+			lrp.Spec.Instances = oldInstances
+			err = r.Client.Patch(ctx, lrp, client.MergeFrom(originalLRP))
+			if err != nil {
+				r.Log.Error(err, fmt.Sprintf("Error when trying to scale down LRP %s/%s", lrp.Namespace, lrp.Name))
+				return false, err
+			}
+		}
+	}
+
+	return done, nil
+}
+
+func (r *CFDeploymentReconciler) fetchProcessesForApp(ctx context.Context, cfApp *workloadsv1alpha1.CFApp) ([]workloadsv1alpha1.CFProcess, error) {
+	processList := new(workloadsv1alpha1.CFProcessList)
+	err := r.Client.List(ctx, processList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the CFProcesses of CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return nil, err
+	}
+
+	return processList.Items, nil
+}
+
+func (r *CFDeploymentReconciler) setDeploymentStatus(ctx context.Context, cfDeployment *workloadsv1alpha1.CFDeployment, value workloadsv1alpha1.DeploymentStatusValue, reason workloadsv1alpha1.DeploymentStatusReason) error {
+	if cfDeployment.Status.Value == value && cfDeployment.Status.Reason == reason {
+		return nil
+	}
+
+	originalCFDeployment := cfDeployment.DeepCopy()
+	cfDeployment.Status.Value = value
+	cfDeployment.Status.Reason = reason
+	cfDeployment.Status.LastStatusChange = metav1.Now()
+	err := r.Client.Status().Patch(ctx, cfDeployment, client.MergeFrom(originalCFDeployment))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to update the status of CFDeployment %s/%s", cfDeployment.Namespace, cfDeployment.Name))
+		return err
+	}
+
+	return nil
+}
+
+func appRevision(cfApp *workloadsv1alpha1.CFApp) string {
+	if revision, ok := cfApp.GetAnnotations()[workloadsv1alpha1.CFAppRevisionKey]; ok {
+		return revision
+	}
+	return workloadsv1alpha1.CFAppRevisionKeyDefault
+}
+
+func nextRevision(revision string) string {
+	revValue, err := strconv.Atoi(revision)
+	if err != nil {
+		return workloadsv1alpha1.CFAppRevisionKeyDefault
+	}
+	return strconv.Itoa(revValue + 1)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CFDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workloadsv1alpha1.CFDeployment{}).
+		Watches(&source.Kind{Type: &eiriniv1.LRP{}}, handler.EnqueueRequestsFromMapFunc(func(lrp client.Object) []reconcile.Request {
+			appGUID, ok := lrp.GetLabels()[workloadsv1alpha1.CFAppGUIDLabelKey]
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			deploymentList := &workloadsv1alpha1.CFDeploymentList{}
+			err := mgr.GetClient().List(context.Background(), deploymentList, client.InNamespace(lrp.GetNamespace()), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID})
+			if err != nil {
+				r.Log.Error(err, fmt.Sprintf("Error when trying to list CFDeployments in namespace %q", lrp.GetNamespace()))
+				return []reconcile.Request{}
+			}
+
+			var requests []reconcile.Request
+			for _, deployment := range deploymentList.Items {
+				if deployment.Status.Value == workloadsv1alpha1.DeploymentStatusValueFinalized {
+					continue
+				}
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      deployment.Name,
+						Namespace: deployment.Namespace,
+					},
+				})
+			}
+			return requests
+		})).
+		Complete(r)
+}
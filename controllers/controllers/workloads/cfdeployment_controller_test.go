@@ -0,0 +1,524 @@
+package workloads_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/fake"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("CFDeploymentReconciler", func() {
+	const (
+		testDeploymentGUID = "test-deployment-guid"
+		testNewDropletGUID = "test-new-droplet-guid"
+	)
+
+	var (
+		fakeClient       *fake.Client
+		fakeAPIReader    *fake.Client
+		fakeStatusWriter *fake.StatusWriter
+
+		cfDeployment *workloadsv1alpha1.CFDeployment
+		cfApp        *workloadsv1alpha1.CFApp
+		// apiCFApp is the app on the API server, which the cached cfApp may lag behind
+		apiCFApp    *workloadsv1alpha1.CFApp
+		cfProcess   *workloadsv1alpha1.CFProcess
+		deployments []workloadsv1alpha1.CFDeployment
+		revision    *workloadsv1alpha1.CFAppRevision
+		envSecrets  map[string]*corev1.Secret
+		lrps        []eiriniv1.LRP
+
+		cfDeploymentReconciler *CFDeploymentReconciler
+		ctx                    context.Context
+		req                    ctrl.Request
+
+		reconcileErr error
+	)
+
+	buildLRP := func(revision string, instances int, readyInstances int32) eiriniv1.LRP {
+		return eiriniv1.LRP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testProcessGUID + "-" + revision,
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					workloadsv1alpha1.CFProcessGUIDLabelKey: testProcessGUID,
+					workloadsv1alpha1.CFAppRevisionKey:      revision,
+				},
+			},
+			Spec:   eiriniv1.LRPSpec{Instances: instances},
+			Status: eiriniv1.LRPStatus{Replicas: readyInstances},
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClient = new(fake.Client)
+		fakeStatusWriter = new(fake.StatusWriter)
+		fakeClient.StatusReturns(fakeStatusWriter)
+
+		cfApp = BuildCFAppCRObject(testAppGUID, testNamespace)
+		UpdateCFAppWithCurrentDropletRef(cfApp, testBuildGUID)
+		cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+		apiCFApp = nil
+		cfProcess = BuildCFProcessCRObject(testProcessGUID, testNamespace, testAppGUID, testProcessType, testProcessCommand)
+		cfProcess.Spec.DesiredInstances = 2
+		cfDeployment = BuildCFDeploymentObject(testDeploymentGUID, testNamespace, testAppGUID, testNewDropletGUID)
+		deployments = nil
+		revision = &workloadsv1alpha1.CFAppRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: testAppGUID + "-1", Namespace: testNamespace},
+			Spec: workloadsv1alpha1.CFAppRevisionSpec{
+				AppRef:       cfDeployment.Spec.AppRef,
+				Version:      1,
+				DropletRef:   corev1.LocalObjectReference{Name: "test-old-droplet-guid"},
+				EnvSecretRef: corev1.LocalObjectReference{Name: testAppGUID + "-1-env"},
+				Processes:    []workloadsv1alpha1.RevisionProcess{{Type: testProcessType, Command: "old-command"}},
+			},
+		}
+		envSecrets = map[string]*corev1.Secret{
+			cfApp.Spec.EnvSecretName: {
+				ObjectMeta: metav1.ObjectMeta{Name: cfApp.Spec.EnvSecretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"FOO": []byte("bar"), "NEW": []byte("var")},
+			},
+			revision.Spec.EnvSecretRef.Name: {
+				ObjectMeta: metav1.ObjectMeta{Name: revision.Spec.EnvSecretRef.Name, Namespace: testNamespace},
+				Data:       map[string][]byte{"FOO": []byte("old-bar")},
+			},
+		}
+		lrps = []eiriniv1.LRP{buildLRP("0", 2, 2)}
+
+		fakeClient.GetStub = func(_ context.Context, name types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *workloadsv1alpha1.CFDeployment:
+				cfDeployment.DeepCopyInto(obj)
+				return nil
+			case *workloadsv1alpha1.CFApp:
+				cfApp.DeepCopyInto(obj)
+				return nil
+			case *workloadsv1alpha1.CFAppRevision:
+				revision.DeepCopyInto(obj)
+				return nil
+			case *corev1.Secret:
+				secret, ok := envSecrets[name.Name]
+				if !ok {
+					return apierrors.NewNotFound(schema.GroupResource{}, name.Name)
+				}
+				secret.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided a weird obj")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *workloadsv1alpha1.CFDeploymentList:
+				deploymentList := workloadsv1alpha1.CFDeploymentList{Items: deployments}
+				deploymentList.DeepCopyInto(list)
+				return nil
+			case *workloadsv1alpha1.CFProcessList:
+				processList := workloadsv1alpha1.CFProcessList{Items: []workloadsv1alpha1.CFProcess{*cfProcess}}
+				processList.DeepCopyInto(list)
+				return nil
+			case *eiriniv1.LRPList:
+				lrpList := eiriniv1.LRPList{Items: lrps}
+				lrpList.DeepCopyInto(list)
+				return nil
+			default:
+				panic("TestClient List provided a weird obj")
+			}
+		}
+
+		fakeAPIReader = new(fake.Client)
+		fakeAPIReader.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object) error {
+			if apiCFApp == nil {
+				cfApp.DeepCopyInto(obj.(*workloadsv1alpha1.CFApp))
+				return nil
+			}
+			apiCFApp.DeepCopyInto(obj.(*workloadsv1alpha1.CFApp))
+			return nil
+		}
+
+		Expect(workloadsv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+		cfDeploymentReconciler = &CFDeploymentReconciler{
+			Client:           fakeClient,
+			APIReader:        fakeAPIReader,
+			Scheme:           scheme.Scheme,
+			Log:              zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			ProgressDeadline: time.Hour,
+		}
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      testDeploymentGUID,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, reconcileErr = cfDeploymentReconciler.Reconcile(ctx, req)
+	})
+
+	patchedApp := func() *workloadsv1alpha1.CFApp {
+		for i := 0; i < fakeClient.PatchCallCount(); i++ {
+			_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+			if app, ok := obj.(*workloadsv1alpha1.CFApp); ok {
+				return app
+			}
+		}
+		return nil
+	}
+
+	lastDeploymentStatus := func() workloadsv1alpha1.CFDeploymentStatus {
+		Expect(fakeStatusWriter.PatchCallCount()).To(BeNumerically(">", 0))
+		_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(fakeStatusWriter.PatchCallCount() - 1)
+		patchedDeployment, ok := obj.(*workloadsv1alpha1.CFDeployment)
+		Expect(ok).To(BeTrue())
+		return patchedDeployment.Status
+	}
+
+	When("the deployment is new", func() {
+		It("initializes the status of the deployment", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			status := lastDeploymentStatus()
+			Expect(status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueActive))
+			Expect(status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonDeploying))
+			Expect(status.DropletRef.Name).To(Equal(testNewDropletGUID))
+			Expect(status.PreviousDropletRef.Name).To(Equal(testBuildGUID))
+			Expect(status.Revision).To(Equal("1"))
+			Expect(status.PreviousRevision).To(Equal("0"))
+			Expect(status.Processes).To(ConsistOf(workloadsv1alpha1.DeploymentProcess{GUID: testProcessGUID, Type: testProcessType}))
+		})
+
+		It("moves the app to a new revision running the droplet of the deployment", func() {
+			app := patchedApp()
+			Expect(app).NotTo(BeNil())
+			Expect(app.Spec.CurrentDropletRef.Name).To(Equal(testNewDropletGUID))
+			Expect(app.Spec.DesiredState).To(Equal(workloadsv1alpha1.StartedState))
+			Expect(app.Annotations).To(HaveKeyWithValue(workloadsv1alpha1.CFAppRevisionKey, "1"))
+		})
+
+		It("makes the app the owner of the deployment", func() {
+			_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+			Expect(obj.GetName()).To(Equal(testDeploymentGUID))
+			Expect(obj.GetOwnerReferences()).To(ConsistOf(HaveField("Name", testAppGUID)))
+		})
+
+		It("does not scale down the previous revision before the new one is ready", func() {
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+			for i := 0; i < fakeClient.PatchCallCount(); i++ {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+				Expect(obj).NotTo(BeAssignableToTypeOf(&eiriniv1.LRP{}))
+			}
+		})
+
+		When("the deployment does not specify a droplet", func() {
+			BeforeEach(func() {
+				cfDeployment.Spec.DropletRef.Name = ""
+			})
+
+			It("redeploys the current droplet of the app", func() {
+				Expect(lastDeploymentStatus().DropletRef.Name).To(Equal(testBuildGUID))
+				Expect(patchedApp().Spec.CurrentDropletRef.Name).To(Equal(testBuildGUID))
+			})
+		})
+
+		When("the deployment rolls back to a revision", func() {
+			BeforeEach(func() {
+				cfDeployment.Spec.DropletRef.Name = ""
+				cfDeployment.Spec.RevisionRef.Name = revision.Name
+			})
+
+			It("deploys the droplet of the revision", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(lastDeploymentStatus().DropletRef.Name).To(Equal("test-old-droplet-guid"))
+				Expect(patchedApp().Spec.CurrentDropletRef.Name).To(Equal("test-old-droplet-guid"))
+			})
+
+			It("restores the process commands of the revision before moving the app to a new revision", func() {
+				var processPatchIndex, appPatchIndex int
+				for i := 0; i < fakeClient.PatchCallCount(); i++ {
+					_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+					switch obj := obj.(type) {
+					case *workloadsv1alpha1.CFProcess:
+						processPatchIndex = i
+						Expect(obj.Spec.Command).To(Equal("old-command"))
+					case *workloadsv1alpha1.CFApp:
+						appPatchIndex = i
+					}
+				}
+				Expect(processPatchIndex).To(BeNumerically(">", 0))
+				Expect(processPatchIndex).To(BeNumerically("<", appPatchIndex))
+			})
+
+			It("restores the environment variables of the revision before moving the app to a new revision", func() {
+				var secretPatchIndex, appPatchIndex int
+				for i := 0; i < fakeClient.PatchCallCount(); i++ {
+					_, obj, patch, _ := fakeClient.PatchArgsForCall(i)
+					switch obj := obj.(type) {
+					case *corev1.Secret:
+						secretPatchIndex = i
+						Expect(obj.Name).To(Equal(cfApp.Spec.EnvSecretName))
+						Expect(obj.Data).To(Equal(map[string][]byte{"FOO": []byte("old-bar")}))
+						patchData, err := patch.Data(obj)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(patchData)).To(ContainSubstring(`"NEW":null`))
+					case *workloadsv1alpha1.CFApp:
+						appPatchIndex = i
+					}
+				}
+				Expect(secretPatchIndex).To(BeNumerically(">", 0))
+				Expect(secretPatchIndex).To(BeNumerically("<", appPatchIndex))
+			})
+
+			When("the environment variables already match the revision", func() {
+				BeforeEach(func() {
+					envSecrets[cfApp.Spec.EnvSecretName].Data = map[string][]byte{"FOO": []byte("old-bar")}
+				})
+
+				It("does not patch the environment variable secret", func() {
+					Expect(reconcileErr).NotTo(HaveOccurred())
+					for i := 0; i < fakeClient.PatchCallCount(); i++ {
+						_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+						Expect(obj).NotTo(BeAssignableToTypeOf(&corev1.Secret{}))
+					}
+				})
+			})
+
+			When("the environment variable snapshot of the revision is missing", func() {
+				BeforeEach(func() {
+					delete(envSecrets, revision.Spec.EnvSecretRef.Name)
+				})
+
+				It("returns an error without moving the app", func() {
+					Expect(reconcileErr).To(HaveOccurred())
+					Expect(patchedApp()).To(BeNil())
+				})
+			})
+		})
+
+		When("another deployment of the app is active", func() {
+			BeforeEach(func() {
+				otherDeployment := BuildCFDeploymentObject("other-deployment-guid", testNamespace, testAppGUID, testBuildGUID)
+				otherDeployment.Status.Value = workloadsv1alpha1.DeploymentStatusValueActive
+				otherDeployment.Status.Reason = workloadsv1alpha1.DeploymentStatusReasonDeploying
+				deployments = []workloadsv1alpha1.CFDeployment{*otherDeployment, *cfDeployment}
+			})
+
+			It("supersedes it", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				supersededDeployment, ok := obj.(*workloadsv1alpha1.CFDeployment)
+				Expect(ok).To(BeTrue())
+				Expect(supersededDeployment.Name).To(Equal("other-deployment-guid"))
+				Expect(supersededDeployment.Status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+				Expect(supersededDeployment.Status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonSuperseded))
+			})
+		})
+	})
+
+	When("the deployment is in progress", func() {
+		BeforeEach(func() {
+			cfApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = "1"
+			cfDeployment.Status = workloadsv1alpha1.CFDeploymentStatus{
+				Value:            workloadsv1alpha1.DeploymentStatusValueActive,
+				Reason:           workloadsv1alpha1.DeploymentStatusReasonDeploying,
+				DropletRef:       cfDeployment.Spec.DropletRef,
+				Revision:         "1",
+				PreviousRevision: "0",
+				LastStatusChange: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}
+		})
+
+		When("some instances of the new revision are ready", func() {
+			BeforeEach(func() {
+				lrps = []eiriniv1.LRP{buildLRP("0", 2, 2), buildLRP("1", 2, 1)}
+			})
+
+			It("scales down the previous revision by as many instances", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				patchedLRP, ok := obj.(*eiriniv1.LRP)
+				Expect(ok).To(BeTrue())
+				Expect(patchedLRP.Name).To(Equal(testProcessGUID + "-0"))
+				Expect(patchedLRP.Spec.Instances).To(Equal(1))
+			})
+
+			It("keeps the deployment active", func() {
+				Expect(fakeStatusWriter.PatchCallCount()).To(BeZero())
+			})
+
+			It("checks on the deployment again before its progress deadline", func() {
+				result, err := cfDeploymentReconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 59*time.Minute, time.Minute))
+			})
+		})
+
+		When("the new revision does not become ready within the progress deadline", func() {
+			BeforeEach(func() {
+				cfDeployment.Status.LastStatusChange = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				lrps = []eiriniv1.LRP{buildLRP("0", 2, 2), buildLRP("1", 2, 0)}
+			})
+
+			It("finalizes the deployment as degenerate", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				status := lastDeploymentStatus()
+				Expect(status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+				Expect(status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonDegenerate))
+			})
+
+			It("leaves the app on the new revision", func() {
+				Expect(patchedApp()).To(BeNil())
+			})
+		})
+
+		When("the app has been restarted during the deployment", func() {
+			BeforeEach(func() {
+				apiCFApp = cfApp.DeepCopy()
+				apiCFApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = "2"
+				lrps = []eiriniv1.LRP{buildLRP("0", 2, 2), buildLRP("1", 2, 2), buildLRP("2", 2, 0)}
+			})
+
+			It("finalizes the deployment as superseded", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				status := lastDeploymentStatus()
+				Expect(status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+				Expect(status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonSuperseded))
+			})
+
+			It("leaves the LRPs alone", func() {
+				Expect(fakeClient.PatchCallCount()).To(BeZero())
+				Expect(fakeClient.DeleteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app has not been moved to the new revision yet", func() {
+			BeforeEach(func() {
+				cfApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = "0"
+			})
+
+			It("moves it", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				app := patchedApp()
+				Expect(app).NotTo(BeNil())
+				Expect(app.Spec.CurrentDropletRef.Name).To(Equal(testNewDropletGUID))
+				Expect(app.Annotations).To(HaveKeyWithValue(workloadsv1alpha1.CFAppRevisionKey, "1"))
+			})
+		})
+
+		When("all the instances of the new revision are ready", func() {
+			BeforeEach(func() {
+				lrps = []eiriniv1.LRP{buildLRP("0", 1, 1), buildLRP("1", 2, 2)}
+			})
+
+			It("deletes the previous revision", func() {
+				Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+				_, obj, _ := fakeClient.DeleteArgsForCall(0)
+				Expect(obj.GetName()).To(Equal(testProcessGUID + "-0"))
+			})
+
+			It("finalizes the deployment", func() {
+				status := lastDeploymentStatus()
+				Expect(status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+				Expect(status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonDeployed))
+			})
+		})
+
+		When("the deployment is canceled", func() {
+			BeforeEach(func() {
+				cfDeployment.Spec.Canceled = true
+				cfDeployment.Status.PreviousDropletRef.Name = testBuildGUID
+				lrps = []eiriniv1.LRP{buildLRP("0", 1, 1), buildLRP("1", 2, 1)}
+			})
+
+			It("marks the deployment as canceling", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				status := lastDeploymentStatus()
+				Expect(status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueActive))
+				Expect(status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonCanceling))
+			})
+
+			It("moves the app back to its previous revision and droplet", func() {
+				app := patchedApp()
+				Expect(app).NotTo(BeNil())
+				Expect(app.Spec.CurrentDropletRef.Name).To(Equal(testBuildGUID))
+				Expect(app.Annotations).To(HaveKeyWithValue(workloadsv1alpha1.CFAppRevisionKey, "0"))
+			})
+
+			It("scales down the new revision as the previous one comes back", func() {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(fakeClient.PatchCallCount() - 1)
+				patchedLRP, ok := obj.(*eiriniv1.LRP)
+				Expect(ok).To(BeTrue())
+				Expect(patchedLRP.Name).To(Equal(testProcessGUID + "-1"))
+				Expect(patchedLRP.Spec.Instances).To(Equal(1))
+			})
+		})
+
+		When("the app has been restarted while the deployment is canceling", func() {
+			BeforeEach(func() {
+				cfDeployment.Status.Reason = workloadsv1alpha1.DeploymentStatusReasonCanceling
+				cfApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = "0"
+				apiCFApp = cfApp.DeepCopy()
+				apiCFApp.Annotations[workloadsv1alpha1.CFAppRevisionKey] = "1"
+				lrps = []eiriniv1.LRP{buildLRP("0", 2, 2), buildLRP("1", 2, 0)}
+			})
+
+			It("finalizes the deployment as superseded without deleting the LRPs of the restarted revision", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(lastDeploymentStatus().Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonSuperseded))
+				Expect(fakeClient.PatchCallCount()).To(BeZero())
+				Expect(fakeClient.DeleteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app has been stopped", func() {
+			BeforeEach(func() {
+				cfApp.Spec.DesiredState = workloadsv1alpha1.StoppedState
+			})
+
+			It("finalizes the deployment", func() {
+				Expect(lastDeploymentStatus().Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+			})
+		})
+
+		When("listing the processes of the app fails", func() {
+			BeforeEach(func() {
+				fakeClient.ListReturns(errors.New("boom"))
+				fakeClient.ListStub = nil
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("boom"))
+			})
+		})
+	})
+
+	When("the deployment is finalized", func() {
+		BeforeEach(func() {
+			cfDeployment.Status.Value = workloadsv1alpha1.DeploymentStatusValueFinalized
+		})
+
+		It("does nothing", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.PatchCallCount()).To(BeZero())
+			Expect(fakeStatusWriter.PatchCallCount()).To(BeZero())
+		})
+	})
+})
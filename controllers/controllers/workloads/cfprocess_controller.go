@@ -19,6 +19,7 @@ package workloads
 import (
 	"context"
 	"crypto/sha1"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
@@ -30,9 +31,12 @@ import (
 
 	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -46,12 +50,26 @@ type EnvBuilder interface {
 	BuildEnv(ctx context.Context, cfApp *workloadsv1alpha1.CFApp) (map[string]string, error)
 }
 
+const (
+	// lrpGUIDLabelKey is the label eirini puts on the pods of an LRP, holding the GUID of the LRP
+	lrpGUIDLabelKey = "workloads.cloudfoundry.org/guid"
+	// lrpContainerName is the name eirini gives the container running the LRP command
+	lrpContainerName   = "opi"
+	cfInstanceIndexKey = "CF_INSTANCE_INDEX"
+
+	StatusConditionReasonRunning  = "Running"
+	StatusConditionReasonStarting = "Starting"
+	StatusConditionReasonCrashing = "Crashing"
+	StatusConditionReasonStopped  = "Stopped"
+)
+
 // CFProcessReconciler reconciles a CFProcess object
 type CFProcessReconciler struct {
 	client.Client
 	Scheme     *runtime.Scheme
 	Log        logr.Logger
 	EnvBuilder EnvBuilder
+	Recorder   record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
@@ -59,6 +77,10 @@ type CFProcessReconciler struct {
 //+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
 //+kubebuilder:rbac:groups="eirini.cloudfoundry.org",resources=lrps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfdeployments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfsidecars,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *CFProcessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	cfProcess := new(workloadsv1alpha1.CFProcess)
@@ -93,7 +115,17 @@ func (r *CFProcessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
-	err = r.cleanUpLRPs(ctx, cfProcess, cfApp.Spec.DesiredState, cfAppRev)
+	deploying, err := r.hasActiveDeployment(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.cleanUpLRPs(ctx, cfProcess, cfApp.Spec.DesiredState, cfAppRev, deploying)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = r.updateInstanceStatus(ctx, cfApp, cfProcess)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -101,6 +133,188 @@ func (r *CFProcessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	return ctrl.Result{}, nil
 }
 
+// updateInstanceStatus counts the running and restarted instances of the process from the pods of its LRPs, and reports
+// each container termination as a crash event on the app. The terminations reported for each container are recorded on
+// the process status before the events are emitted, so that no termination is reported twice, even when the status
+// cannot be updated.
+func (r *CFProcessReconciler) updateInstanceStatus(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess) error {
+	podList := new(corev1.PodList)
+	err := r.Client.List(ctx, podList, client.InNamespace(cfProcess.Namespace), client.MatchingLabels{lrpGUIDLabelKey: cfProcess.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the pods of CFProcess %s/%s", cfProcess.Namespace, cfProcess.Name))
+		return err
+	}
+
+	originalCFProcess := cfProcess.DeepCopy()
+
+	type crash struct {
+		pod         *corev1.Pod
+		termination *corev1.ContainerStateTerminated
+	}
+
+	var (
+		runningInstances  int
+		crashingInstances int
+		restartCount      int32
+		reportedCrashes   []workloadsv1alpha1.ReportedCrash
+		crashes           []crash
+	)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if podReady(pod) {
+			runningInstances++
+		} else if podCrashing(pod) {
+			crashingInstances++
+		}
+
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			restartCount += containerStatus.RestartCount
+
+			// the last termination is the one before the last restart, and the container may have terminated since
+			reported := reportedTerminations(cfProcess.Status.ReportedCrashes, pod.UID, containerStatus.Name)
+			terminations := reported
+			for _, termination := range []struct {
+				number int32
+				state  *corev1.ContainerStateTerminated
+			}{
+				{number: containerStatus.RestartCount, state: containerStatus.LastTerminationState.Terminated},
+				{number: containerStatus.RestartCount + 1, state: containerStatus.State.Terminated},
+			} {
+				if termination.state == nil || termination.number <= reported {
+					continue
+				}
+
+				crashes = append(crashes, crash{pod: pod, termination: termination.state})
+				terminations = termination.number
+			}
+
+			if terminations > 0 {
+				reportedCrashes = append(reportedCrashes, workloadsv1alpha1.ReportedCrash{
+					PodUID:        pod.UID,
+					ContainerName: containerStatus.Name,
+					Terminations:  terminations,
+				})
+			}
+		}
+	}
+
+	cfProcess.Status.RunningInstances = runningInstances
+	cfProcess.Status.RestartCount = restartCount
+	cfProcess.Status.ReportedCrashes = reportedCrashes
+	meta.SetStatusCondition(&cfProcess.Status.Conditions, processRunningCondition(cfApp, cfProcess, crashingInstances))
+
+	err = r.Client.Status().Patch(ctx, cfProcess, client.MergeFrom(originalCFProcess))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to update the status of CFProcess %s/%s", cfProcess.Namespace, cfProcess.Name))
+		return err
+	}
+
+	for _, crash := range crashes {
+		r.recordCrash(cfApp, cfProcess, crash.pod, crash.termination)
+	}
+
+	return nil
+}
+
+func processRunningCondition(cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess, crashingInstances int) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    StatusConditionRunning,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("%d/%d instances running", cfProcess.Status.RunningInstances, cfProcess.Spec.DesiredInstances),
+	}
+
+	switch {
+	case cfApp.Spec.DesiredState != workloadsv1alpha1.StartedState:
+		condition.Reason = StatusConditionReasonStopped
+	case cfProcess.Status.RunningInstances >= cfProcess.Spec.DesiredInstances:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = StatusConditionReasonRunning
+	case crashingInstances > 0:
+		condition.Reason = StatusConditionReasonCrashing
+	default:
+		condition.Reason = StatusConditionReasonStarting
+	}
+
+	return condition
+}
+
+func (r *CFProcessReconciler) recordCrash(cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess, pod *corev1.Pod, termination *corev1.ContainerStateTerminated) {
+	index := instanceIndex(pod)
+	exitStatus := strconv.Itoa(int(termination.ExitCode))
+
+	annotations := map[string]string{
+		workloadsv1alpha1.CFProcessGUIDLabelKey:          cfProcess.Name,
+		workloadsv1alpha1.CFProcessTypeLabelKey:          cfProcess.Spec.ProcessType,
+		workloadsv1alpha1.CFInstanceIndexAnnotationKey:   index,
+		workloadsv1alpha1.CFExitStatusAnnotationKey:      exitStatus,
+		workloadsv1alpha1.CFExitReasonAnnotationKey:      termination.Reason,
+		workloadsv1alpha1.CFExitDescriptionAnnotationKey: termination.Message,
+	}
+
+	r.Recorder.AnnotatedEventf(cfApp, annotations, corev1.EventTypeWarning, workloadsv1alpha1.AppProcessCrashEventReason,
+		"Instance %s of process %s exited with status %s (%s)", index, cfProcess.Spec.ProcessType, exitStatus, termination.Reason)
+}
+
+// reportedTerminations returns how many terminations of the container of the pod have already been reported
+func reportedTerminations(reportedCrashes []workloadsv1alpha1.ReportedCrash, podUID types.UID, containerName string) int32 {
+	for _, reportedCrash := range reportedCrashes {
+		if reportedCrash.PodUID == podUID && reportedCrash.ContainerName == containerName {
+			return reportedCrash.Terminations
+		}
+	}
+
+	return 0
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func podCrashing(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodFailed {
+		return true
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Terminated != nil {
+			return true
+		}
+
+		if containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instanceIndex reads the index of the instance run by the pod from the environment of the LRP container
+func instanceIndex(pod *corev1.Pod) string {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != lrpContainerName {
+			continue
+		}
+
+		for _, envVar := range container.Env {
+			if envVar.Name == cfInstanceIndexKey {
+				return envVar.Value
+			}
+		}
+	}
+
+	return ""
+}
+
 func (r *CFProcessReconciler) createOrPatchLRP(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess, cfAppRev string) error {
 	cfBuild := new(workloadsv1alpha1.CFBuild)
 	err := r.Client.Get(ctx, types.NamespacedName{Name: cfApp.Spec.CurrentDropletRef.Name, Namespace: cfProcess.Namespace}, cfBuild)
@@ -127,6 +341,12 @@ func (r *CFProcessReconciler) createOrPatchLRP(ctx context.Context, cfApp *workl
 		return err
 	}
 
+	cfSidecars, err := r.fetchSidecarsForProcess(ctx, cfApp, cfProcess)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch sidecars for Process %s/%s", cfProcess.Namespace, cfProcess.Name))
+		return err
+	}
+
 	actualLRP := &eiriniv1.LRP{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfProcess.Namespace,
@@ -135,13 +355,21 @@ func (r *CFProcessReconciler) createOrPatchLRP(ctx context.Context, cfApp *workl
 	}
 
 	var desiredLRP *eiriniv1.LRP
-	desiredLRP, err = r.generateLRP(actualLRP, cfApp, cfProcess, cfBuild, appPort, envVars)
+	desiredLRP, err = r.generateLRP(actualLRP, cfApp, cfProcess, cfBuild, cfSidecars, appPort, envVars)
 	if err != nil {
 		// untested
 		r.Log.Error(err, "Error when initializing LRP")
 		return err
 	}
 
+	if cfApp.Spec.Lifecycle.Type == workloadsv1alpha1.DockerLifecycle {
+		desiredLRP.Spec.PrivateRegistry, err = r.getPrivateRegistry(ctx, cfProcess.Namespace, cfBuild.Status.BuildDropletStatus.Registry)
+		if err != nil {
+			r.Log.Error(err, fmt.Sprintf("Error when trying to fetch the image pull secrets of CFBuild %s/%s", cfProcess.Namespace, cfBuild.Name))
+			return err
+		}
+	}
+
 	_, err = controllerutil.CreateOrPatch(ctx, r.Client, actualLRP, lrpMutateFunction(actualLRP, desiredLRP))
 	if err != nil {
 		r.Log.Error(err, "Error calling CreateOrPatch on LRP")
@@ -166,7 +394,26 @@ func (r *CFProcessReconciler) setOwnerRef(ctx context.Context, cfProcess *worklo
 	return nil
 }
 
-func (r *CFProcessReconciler) cleanUpLRPs(ctx context.Context, cfProcess *workloadsv1alpha1.CFProcess, desiredState workloadsv1alpha1.DesiredState, cfAppRev string) error {
+// hasActiveDeployment tells whether a CFDeployment is rolling the app over to a new revision
+func (r *CFProcessReconciler) hasActiveDeployment(ctx context.Context, cfApp *workloadsv1alpha1.CFApp) (bool, error) {
+	deploymentList := new(workloadsv1alpha1.CFDeploymentList)
+	err := r.Client.List(ctx, deploymentList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to list the CFDeployments of CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return false, err
+	}
+
+	for _, deployment := range deploymentList.Items {
+		if deployment.Status.Value != workloadsv1alpha1.DeploymentStatusValueFinalized {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// cleanUpLRPs deletes the LRPs of the other revisions of the app, unless a deployment is scaling them down
+func (r *CFProcessReconciler) cleanUpLRPs(ctx context.Context, cfProcess *workloadsv1alpha1.CFProcess, desiredState workloadsv1alpha1.DesiredState, cfAppRev string, deploying bool) error {
 	lrpsForProcess, err := r.fetchLRPsForProcess(ctx, cfProcess)
 	if err != nil {
 		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch LRPs for Process %s/%s", cfProcess.Namespace, cfProcess.Name))
@@ -174,7 +421,7 @@ func (r *CFProcessReconciler) cleanUpLRPs(ctx context.Context, cfProcess *worklo
 	}
 
 	for _, currentLRP := range lrpsForProcess {
-		if desiredState == workloadsv1alpha1.StoppedState || currentLRP.Labels[workloadsv1alpha1.CFAppRevisionKey] != cfAppRev {
+		if desiredState == workloadsv1alpha1.StoppedState || (!deploying && currentLRP.Labels[workloadsv1alpha1.CFAppRevisionKey] != cfAppRev) {
 			err := r.Client.Delete(ctx, &currentLRP)
 			if err != nil {
 				r.Log.Info(fmt.Sprintf("Error occurred deleting LRP: %s, %s", currentLRP.Name, err))
@@ -195,7 +442,7 @@ func lrpMutateFunction(actuallrp, desiredlrp *eiriniv1.LRP) controllerutil.Mutat
 	}
 }
 
-func (r *CFProcessReconciler) generateLRP(actualLRP *eiriniv1.LRP, cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess, cfBuild *workloadsv1alpha1.CFBuild, appPort int, envVars map[string]string) (*eiriniv1.LRP, error) {
+func (r *CFProcessReconciler) generateLRP(actualLRP *eiriniv1.LRP, cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess, cfBuild *workloadsv1alpha1.CFBuild, cfSidecars []workloadsv1alpha1.CFSidecar, appPort int, envVars map[string]string) (*eiriniv1.LRP, error) {
 	var desiredLRP eiriniv1.LRP
 	actualLRP.DeepCopyInto(&desiredLRP)
 
@@ -216,7 +463,7 @@ func (r *CFProcessReconciler) generateLRP(actualLRP *eiriniv1.LRP, cfApp *worklo
 	desiredLRP.Spec.DiskMB = cfProcess.Spec.DiskQuotaMB
 	desiredLRP.Spec.MemoryMB = cfProcess.Spec.MemoryMB
 	desiredLRP.Spec.ProcessType = cfProcess.Spec.ProcessType
-	desiredLRP.Spec.Command = commandForProcess(cfProcess, cfApp)
+	desiredLRP.Spec.Command = commandForProcess(cfProcess, cfApp, cfBuild)
 	desiredLRP.Spec.AppName = cfApp.Spec.Name
 	desiredLRP.Spec.AppGUID = cfApp.Name
 	desiredLRP.Spec.Image = cfBuild.Status.BuildDropletStatus.Registry.Image
@@ -231,7 +478,7 @@ func (r *CFProcessReconciler) generateLRP(actualLRP *eiriniv1.LRP, cfApp *worklo
 		TimeoutMs: uint(cfProcess.Spec.HealthCheck.Data.TimeoutSeconds * 1000),
 	}
 	desiredLRP.Spec.CPUWeight = 0
-	desiredLRP.Spec.Sidecars = nil
+	desiredLRP.Spec.Sidecars = generateSidecars(cfSidecars, cfProcess, cfApp, desiredLRP.Spec.Env)
 
 	err := controllerutil.SetOwnerReference(cfProcess, &desiredLRP, r.Scheme)
 	if err != nil {
@@ -241,6 +488,31 @@ func (r *CFProcessReconciler) generateLRP(actualLRP *eiriniv1.LRP, cfApp *worklo
 	return &desiredLRP, err
 }
 
+// generateSidecars runs the sidecars in the environment of the process. Sidecars without a memory limit of their own
+// get the memory limit of the process
+func generateSidecars(cfSidecars []workloadsv1alpha1.CFSidecar, cfProcess *workloadsv1alpha1.CFProcess, cfApp *workloadsv1alpha1.CFApp, env map[string]string) []eiriniv1.Sidecar {
+	if len(cfSidecars) == 0 {
+		return nil
+	}
+
+	sidecars := make([]eiriniv1.Sidecar, 0, len(cfSidecars))
+	for _, cfSidecar := range cfSidecars {
+		memoryMB := cfSidecar.Spec.MemoryMB
+		if memoryMB == 0 {
+			memoryMB = cfProcess.Spec.MemoryMB
+		}
+
+		sidecars = append(sidecars, eiriniv1.Sidecar{
+			Name:     cfSidecar.Spec.Name,
+			Command:  launchCommand(cfApp, cfSidecar.Spec.Command),
+			MemoryMB: memoryMB,
+			Env:      env,
+		})
+	}
+
+	return sidecars
+}
+
 func generateLRPName(cfAppRev string, processGUID string) string {
 	h := sha1.New()
 	h.Write([]byte(cfAppRev))
@@ -264,6 +536,31 @@ func (r *CFProcessReconciler) fetchLRPsForProcess(ctx context.Context, cfProcess
 	return lrpsForProcess, err
 }
 
+// fetchSidecarsForProcess returns the sidecars of the app that run next to the process, ordered by name
+func (r *CFProcessReconciler) fetchSidecarsForProcess(ctx context.Context, cfApp *workloadsv1alpha1.CFApp, cfProcess *workloadsv1alpha1.CFProcess) ([]workloadsv1alpha1.CFSidecar, error) {
+	sidecarList := new(workloadsv1alpha1.CFSidecarList)
+	err := r.Client.List(ctx, sidecarList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	var sidecarsForProcess []workloadsv1alpha1.CFSidecar
+	for _, sidecar := range sidecarList.Items {
+		for _, processType := range sidecar.Spec.ProcessTypes {
+			if processType == cfProcess.Spec.ProcessType {
+				sidecarsForProcess = append(sidecarsForProcess, sidecar)
+				break
+			}
+		}
+	}
+
+	sort.Slice(sidecarsForProcess, func(i, j int) bool {
+		return sidecarsForProcess[i].Spec.Name < sidecarsForProcess[j].Spec.Name
+	})
+
+	return sidecarsForProcess, nil
+}
+
 func (r *CFProcessReconciler) getPort(ctx context.Context, cfProcess *workloadsv1alpha1.CFProcess, cfApp *workloadsv1alpha1.CFApp) (int, error) {
 	// Get Routes for the process
 	var cfRoutesForProcess networkingv1alpha1.CFRouteList
@@ -304,14 +601,66 @@ func generateEnvMap(port int, commonEnv map[string]string) map[string]string {
 	return result
 }
 
-func commandForProcess(process *workloadsv1alpha1.CFProcess, app *workloadsv1alpha1.CFApp) []string {
+func commandForProcess(process *workloadsv1alpha1.CFProcess, app *workloadsv1alpha1.CFApp, build *workloadsv1alpha1.CFBuild) []string {
 	if process.Spec.Command == "" {
 		return []string{}
-	} else if app.Spec.Lifecycle.Type == workloadsv1alpha1.BuildpackLifecycle {
-		return []string{"/cnb/lifecycle/launcher", process.Spec.Command}
+	} else if app.Spec.Lifecycle.Type == workloadsv1alpha1.DockerLifecycle && process.Spec.Command == dropletCommand(build, process.Spec.ProcessType) {
+		// docker images run their own entrypoint, unless the command of the process has been changed
+		return []string{}
 	} else {
-		return []string{"/bin/sh", "-c", process.Spec.Command}
+		return launchCommand(app, process.Spec.Command)
+	}
+}
+
+func launchCommand(app *workloadsv1alpha1.CFApp, command string) []string {
+	if app.Spec.Lifecycle.Type == workloadsv1alpha1.BuildpackLifecycle {
+		return []string{"/cnb/lifecycle/launcher", command}
 	}
+	return []string{"/bin/sh", "-c", command}
+}
+
+func dropletCommand(build *workloadsv1alpha1.CFBuild, processType string) string {
+	for _, dropletProcessType := range build.Status.BuildDropletStatus.ProcessTypes {
+		if dropletProcessType.Type == processType {
+			return dropletProcessType.Command
+		}
+	}
+	return ""
+}
+
+type dockerConfigJSON struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// getPrivateRegistry returns the credentials in the image pull secret of a docker image, if it has any
+func (r *CFProcessReconciler) getPrivateRegistry(ctx context.Context, namespace string, registry workloadsv1alpha1.Registry) (*eiriniv1.PrivateRegistry, error) {
+	if len(registry.ImagePullSecrets) == 0 {
+		return nil, nil
+	}
+
+	secret := new(corev1.Secret)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: registry.ImagePullSecrets[0].Name, Namespace: namespace}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var dockerConfig dockerConfigJSON
+	err = json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image pull secret %s/%s: %w", namespace, secret.Name, err)
+	}
+
+	for _, auth := range dockerConfig.Auths {
+		return &eiriniv1.PrivateRegistry{
+			Username: auth.Username,
+			Password: auth.Password,
+		}, nil
+	}
+
+	return nil, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -337,5 +686,37 @@ func (r *CFProcessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			}
 			return requests
 		})).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(pod client.Object) []reconcile.Request {
+			processGUID, ok := pod.GetLabels()[lrpGUIDLabelKey]
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: processGUID, Namespace: pod.GetNamespace()}}}
+		})).
+		Watches(&source.Kind{Type: &workloadsv1alpha1.CFSidecar{}}, handler.EnqueueRequestsFromMapFunc(func(sidecar client.Object) []reconcile.Request {
+			cfSidecar, ok := sidecar.(*workloadsv1alpha1.CFSidecar)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			processList := &workloadsv1alpha1.CFProcessList{}
+			err := mgr.GetClient().List(context.Background(), processList, client.InNamespace(cfSidecar.Namespace), client.MatchingLabels{workloadsv1alpha1.CFAppGUIDLabelKey: cfSidecar.Spec.AppRef.Name})
+			if err != nil {
+				r.Log.Error(err, fmt.Sprintf("Error when trying to list CFProcesses in namespace %q", cfSidecar.Namespace))
+				return []reconcile.Request{}
+			}
+
+			var requests []reconcile.Request
+			for _, process := range processList.Items {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      process.Name,
+						Namespace: process.Namespace,
+					},
+				})
+			}
+			return requests
+		})).
 		Complete(r)
 }
@@ -21,8 +21,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -40,14 +42,21 @@ const (
 
 var _ = Describe("CFProcessReconciler Unit Tests", func() {
 	var (
-		fakeClient *fake.Client
-		envBuilder *fake.EnvBuilder
+		fakeClient       *fake.Client
+		fakeStatusWriter *fake.StatusWriter
+		envBuilder       *fake.EnvBuilder
+		recorder         *record.FakeRecorder
 
 		cfBuild   *workloadsv1alpha1.CFBuild
 		cfProcess *workloadsv1alpha1.CFProcess
 		cfApp     *workloadsv1alpha1.CFApp
 		lrp       *eiriniv1.LRP
 		routes    []networkingv1alpha1.CFRoute
+		secret    *corev1.Secret
+
+		deployments []workloadsv1alpha1.CFDeployment
+		sidecars    []workloadsv1alpha1.CFSidecar
+		pods        []corev1.Pod
 
 		cfBuildError   error
 		cfAppError     error
@@ -65,8 +74,11 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 
 	BeforeEach(func() {
 		fakeClient = new(fake.Client)
+		fakeStatusWriter = new(fake.StatusWriter)
+		fakeClient.StatusReturns(fakeStatusWriter)
 
 		envBuilder = new(fake.EnvBuilder)
+		recorder = record.NewFakeRecorder(10)
 
 		cfApp = BuildCFAppCRObject(testAppGUID, testNamespace)
 		cfAppError = nil
@@ -76,6 +88,10 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 		cfProcess = BuildCFProcessCRObject(testProcessGUID, testNamespace, testAppGUID, testProcessType, testProcessCommand)
 		cfProcessError = nil
 
+		secret = nil
+		deployments = nil
+		sidecars = nil
+		pods = nil
 		lrp = nil
 		lrpError = nil
 		lrpListError = nil
@@ -97,6 +113,12 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 					lrp.DeepCopyInto(obj)
 				}
 				return lrpError
+			case *corev1.Secret:
+				if secret == nil {
+					return apierrors.NewNotFound(schema.GroupResource{}, name.Name)
+				}
+				secret.DeepCopyInto(obj)
+				return nil
 			default:
 				panic("TestClient Get provided a weird obj")
 			}
@@ -116,6 +138,18 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 
 				routeList.DeepCopyInto(listObj)
 				return routeListError
+			case *workloadsv1alpha1.CFDeploymentList:
+				deploymentList := workloadsv1alpha1.CFDeploymentList{Items: deployments}
+				deploymentList.DeepCopyInto(listObj)
+				return nil
+			case *workloadsv1alpha1.CFSidecarList:
+				sidecarList := workloadsv1alpha1.CFSidecarList{Items: sidecars}
+				sidecarList.DeepCopyInto(listObj)
+				return nil
+			case *corev1.PodList:
+				podList := corev1.PodList{Items: pods}
+				podList.DeepCopyInto(listObj)
+				return nil
 			default:
 				panic("TestClient Get provided a weird obj")
 			}
@@ -128,6 +162,7 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 			Scheme:     scheme.Scheme,
 			Log:        zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
 			EnvBuilder: envBuilder,
+			Recorder:   recorder,
 		}
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -189,6 +224,55 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 		})
 	})
 
+	When("the CFApp has an LRP for a previous revision", func() {
+		BeforeEach(func() {
+			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+			// the LRP of the current revision does not exist yet
+			lrpError = apierrors.NewNotFound(schema.GroupResource{}, testProcessGUID)
+			lrp = &eiriniv1.LRP{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testProcessGUID + "-old",
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						workloadsv1alpha1.CFProcessGUIDLabelKey: testProcessGUID,
+						workloadsv1alpha1.CFAppRevisionKey:      "previous-rev",
+					},
+				},
+			}
+		})
+
+		It("deletes the LRP of the previous revision", func() {
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1), "Client.Delete call count mismatch")
+			_, deletedLRP, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(deletedLRP.GetName()).To(Equal(testProcessGUID + "-old"))
+		})
+
+		When("a deployment of the CFApp is active", func() {
+			BeforeEach(func() {
+				deployments = []workloadsv1alpha1.CFDeployment{{
+					Status: workloadsv1alpha1.CFDeploymentStatus{Value: workloadsv1alpha1.DeploymentStatusValueActive},
+				}}
+			})
+
+			It("leaves the LRP of the previous revision to the deployment", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.DeleteCallCount()).To(Equal(0), "Client.Delete call count mismatch")
+			})
+		})
+
+		When("the deployments of the CFApp are finalized", func() {
+			BeforeEach(func() {
+				deployments = []workloadsv1alpha1.CFDeployment{{
+					Status: workloadsv1alpha1.CFDeploymentStatus{Value: workloadsv1alpha1.DeploymentStatusValueFinalized},
+				}}
+			})
+
+			It("deletes the LRP of the previous revision", func() {
+				Expect(fakeClient.DeleteCallCount()).To(Equal(1), "Client.Delete call count mismatch")
+			})
+		})
+	})
+
 	When("the CFApp is started and there are existing routes matching", func() {
 		const testPort = 1234
 
@@ -254,6 +338,283 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 		})
 	})
 
+	When("the CFApp is a started docker app", func() {
+		BeforeEach(func() {
+			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+			cfApp.Spec.Lifecycle = workloadsv1alpha1.Lifecycle{Type: workloadsv1alpha1.DockerLifecycle}
+			cfBuild.Status.BuildDropletStatus.Registry = workloadsv1alpha1.Registry{
+				Image:            "registry.example.com/my/image",
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+			}
+			cfBuild.Status.BuildDropletStatus.ProcessTypes = []workloadsv1alpha1.ProcessType{
+				{Type: testProcessType, Command: testProcessCommand},
+			}
+			lrpError = apierrors.NewNotFound(schema.GroupResource{}, "some-guid")
+
+			secret = BuildDockerRegistrySecret("image-pull-secret", testNamespace)
+			secret.Data = map[string][]byte{}
+			for key, value := range secret.StringData {
+				secret.Data[key] = []byte(value)
+			}
+		})
+
+		createdLRP := func() *eiriniv1.LRP {
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			return obj.(*eiriniv1.LRP)
+		}
+
+		It("runs the image of the droplet with the credentials of its image pull secret", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(createdLRP().Spec.Image).To(Equal("registry.example.com/my/image"))
+			Expect(createdLRP().Spec.PrivateRegistry).To(Equal(&eiriniv1.PrivateRegistry{
+				Username: "user",
+				Password: "password",
+			}))
+		})
+
+		It("lets the image run its own entrypoint", func() {
+			Expect(createdLRP().Spec.Command).To(BeEmpty())
+		})
+
+		When("the command of the process has been changed", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.Command = "my-command"
+			})
+
+			It("runs the command in a shell", func() {
+				Expect(createdLRP().Spec.Command).To(Equal([]string{"/bin/sh", "-c", "my-command"}))
+			})
+		})
+
+		When("the image is public", func() {
+			BeforeEach(func() {
+				cfBuild.Status.BuildDropletStatus.Registry.ImagePullSecrets = nil
+			})
+
+			It("does not set any registry credentials", func() {
+				Expect(createdLRP().Spec.PrivateRegistry).To(BeNil())
+			})
+		})
+
+		When("the image pull secret does not exist", func() {
+			BeforeEach(func() {
+				secret = nil
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the CFApp is started and has sidecars", func() {
+		BeforeEach(func() {
+			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+			cfProcess.Spec.MemoryMB = 512
+			lrpError = apierrors.NewNotFound(schema.GroupResource{}, "some-guid")
+			envBuilder.BuildEnvReturns(map[string]string{"FOO": "bar"}, nil)
+
+			sidecars = []workloadsv1alpha1.CFSidecar{
+				{
+					Spec: workloadsv1alpha1.CFSidecarSpec{
+						AppRef:       corev1.LocalObjectReference{Name: testAppGUID},
+						Name:         "proxy",
+						Command:      "./proxy",
+						ProcessTypes: []string{testProcessType},
+					},
+				},
+				{
+					Spec: workloadsv1alpha1.CFSidecarSpec{
+						AppRef:       corev1.LocalObjectReference{Name: testAppGUID},
+						Name:         "apm-agent",
+						Command:      "./apm-agent",
+						ProcessTypes: []string{"worker", testProcessType},
+						MemoryMB:     64,
+					},
+				},
+				{
+					Spec: workloadsv1alpha1.CFSidecarSpec{
+						AppRef:       corev1.LocalObjectReference{Name: testAppGUID},
+						Name:         "worker-only",
+						Command:      "./worker-only",
+						ProcessTypes: []string{"worker"},
+					},
+				},
+			}
+		})
+
+		It("runs the sidecars of the process type next to the process", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			createdLRP := obj.(*eiriniv1.LRP)
+
+			Expect(createdLRP.Spec.Sidecars).To(HaveLen(2))
+			Expect(createdLRP.Spec.Sidecars[0].Name).To(Equal("apm-agent"))
+			Expect(createdLRP.Spec.Sidecars[0].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "./apm-agent"}))
+			Expect(createdLRP.Spec.Sidecars[0].MemoryMB).To(BeEquivalentTo(64))
+			Expect(createdLRP.Spec.Sidecars[0].Env).To(HaveKeyWithValue("FOO", "bar"))
+			Expect(createdLRP.Spec.Sidecars[1].Name).To(Equal("proxy"))
+			Expect(createdLRP.Spec.Sidecars[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "./proxy"}))
+			Expect(createdLRP.Spec.Sidecars[1].MemoryMB).To(BeEquivalentTo(512))
+		})
+
+		When("the app is a docker app", func() {
+			BeforeEach(func() {
+				cfApp.Spec.Lifecycle = workloadsv1alpha1.Lifecycle{Type: workloadsv1alpha1.DockerLifecycle}
+			})
+
+			It("runs the sidecar commands in a shell", func() {
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				createdLRP := obj.(*eiriniv1.LRP)
+				Expect(createdLRP.Spec.Sidecars[0].Command).To(Equal([]string{"/bin/sh", "-c", "./apm-agent"}))
+			})
+		})
+	})
+
+	When("the CFApp is started and its LRP has pods", func() {
+		var crashTime metav1.Time
+
+		BeforeEach(func() {
+			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+			cfProcess.Spec.DesiredInstances = 2
+			lrpError = apierrors.NewNotFound(schema.GroupResource{}, "some-guid")
+
+			crashTime = metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			cfProcess.Status.ReportedCrashes = []workloadsv1alpha1.ReportedCrash{
+				{PodUID: "pod-uid-0", ContainerName: "opi", Terminations: 1},
+				{PodUID: "pod-uid-1", ContainerName: "opi", Terminations: 2},
+			}
+
+			pods = []corev1.Pod{
+				buildLRPPod("0", corev1.ContainerStatus{
+					Name:         "opi",
+					RestartCount: 1,
+					State:        corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						Reason:     "Error",
+						FinishedAt: metav1.NewTime(crashTime.Add(-time.Minute)),
+					}},
+				}, true),
+				buildLRPPod("1", corev1.ContainerStatus{
+					Name:         "opi",
+					RestartCount: 3,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason: "CrashLoopBackOff",
+					}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   137,
+						Reason:     "OOMKilled",
+						FinishedAt: crashTime,
+					}},
+				}, false),
+			}
+		})
+
+		It("updates the running instances and restarts of the process", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+			_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedProcess := obj.(*workloadsv1alpha1.CFProcess)
+
+			Expect(patchedProcess.Status.RunningInstances).To(Equal(1))
+			Expect(patchedProcess.Status.RestartCount).To(BeEquivalentTo(4))
+			Expect(patchedProcess.Status.ReportedCrashes).To(ConsistOf(
+				workloadsv1alpha1.ReportedCrash{PodUID: "pod-uid-0", ContainerName: "opi", Terminations: 1},
+				workloadsv1alpha1.ReportedCrash{PodUID: "pod-uid-1", ContainerName: "opi", Terminations: 3},
+			))
+
+			runningCondition := meta.FindStatusCondition(patchedProcess.Status.Conditions, StatusConditionRunning)
+			Expect(runningCondition).NotTo(BeNil())
+			Expect(runningCondition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(runningCondition.Reason).To(Equal(StatusConditionReasonCrashing))
+			Expect(runningCondition.Message).To(Equal("1/2 instances running"))
+		})
+
+		It("records an event for the terminations that have not been reported yet", func() {
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(Equal("Warning audit.app.process.crash Instance 1 of process web exited with status 137 (OOMKilled)"))
+		})
+
+		When("no termination has been reported yet and the instances crashed in the same second", func() {
+			BeforeEach(func() {
+				cfProcess.Status.ReportedCrashes = nil
+				pods[0].Status.ContainerStatuses[0].LastTerminationState.Terminated.FinishedAt = crashTime
+				pods[1].Status.ContainerStatuses[0].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   1,
+					Reason:     "Error",
+					FinishedAt: crashTime,
+				}}
+			})
+
+			It("records an event for every termination", func() {
+				Expect(recorder.Events).To(HaveLen(3))
+				Expect([]string{<-recorder.Events, <-recorder.Events, <-recorder.Events}).To(ConsistOf(
+					"Warning audit.app.process.crash Instance 0 of process web exited with status 1 (Error)",
+					"Warning audit.app.process.crash Instance 1 of process web exited with status 137 (OOMKilled)",
+					"Warning audit.app.process.crash Instance 1 of process web exited with status 1 (Error)",
+				))
+			})
+
+			It("records the terminations of each container as reported", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedProcess := obj.(*workloadsv1alpha1.CFProcess)
+
+				Expect(patchedProcess.Status.ReportedCrashes).To(ConsistOf(
+					workloadsv1alpha1.ReportedCrash{PodUID: "pod-uid-0", ContainerName: "opi", Terminations: 1},
+					workloadsv1alpha1.ReportedCrash{PodUID: "pod-uid-1", ContainerName: "opi", Terminations: 4},
+				))
+			})
+		})
+
+		When("all the instances are running", func() {
+			BeforeEach(func() {
+				pods[1].Status.Conditions[0].Status = corev1.ConditionTrue
+				pods[1].Status.ContainerStatuses[0].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+			})
+
+			It("sets the process as running", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedProcess := obj.(*workloadsv1alpha1.CFProcess)
+
+				Expect(patchedProcess.Status.RunningInstances).To(Equal(2))
+				Expect(meta.IsStatusConditionTrue(patchedProcess.Status.Conditions, StatusConditionRunning)).To(BeTrue())
+			})
+		})
+
+		When("a pod is being deleted", func() {
+			BeforeEach(func() {
+				deletionTimestamp := metav1.Now()
+				pods[1].DeletionTimestamp = &deletionTimestamp
+			})
+
+			It("neither counts it nor reports its termination as a crash", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedProcess := obj.(*workloadsv1alpha1.CFProcess)
+
+				Expect(patchedProcess.Status.RestartCount).To(BeEquivalentTo(1))
+				Expect(recorder.Events).To(BeEmpty())
+			})
+		})
+
+		When("updating the process status fails", func() {
+			BeforeEach(func() {
+				fakeStatusWriter.PatchReturns(errors.New(failsOnPurposeErrorMessage))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError(failsOnPurposeErrorMessage))
+			})
+
+			It("does not report the terminations, so that they are reported once the status is updated", func() {
+				Expect(recorder.Events).To(BeEmpty())
+			})
+		})
+	})
+
 	When("the app is started", func() {
 		BeforeEach(func() {
 			cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
@@ -330,3 +691,30 @@ var _ = Describe("CFProcessReconciler Unit Tests", func() {
 		})
 	})
 })
+
+func buildLRPPod(index string, containerStatus corev1.ContainerStatus, ready bool) corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testProcessGUID + "-" + index,
+			Namespace: testNamespace,
+			UID:       types.UID("pod-uid-" + index),
+			Labels:    map[string]string{"workloads.cloudfoundry.org/guid": testProcessGUID},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "opi",
+				Env:  []corev1.EnvVar{{Name: "CF_INSTANCE_INDEX", Value: index}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{containerStatus},
+		},
+	}
+}
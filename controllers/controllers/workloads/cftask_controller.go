@@ -0,0 +1,340 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	taskContainerName   = "task"
+	taskCanceledReason  = "task was cancelled"
+	taskFailedReason    = "task exited with a non-zero status"
+	taskNoDropletReason = "app has no droplet"
+	mebibyte            = 1024 * 1024
+
+	// taskJobTTLSeconds is how long the Job of a task, and so its pod, is kept after it finished. The task records
+	// its final state before then, so the Job is not needed any more.
+	taskJobTTLSeconds = 24 * 60 * 60
+)
+
+// CFTaskReconciler reconciles a CFTask object
+type CFTaskReconciler struct {
+	client.Client
+	// APIReader reads from the API server rather than from the cache of the Client
+	APIReader  client.Reader
+	Scheme     *runtime.Scheme
+	Log        logr.Logger
+	EnvBuilder EnvBuilder
+}
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cftasks,verbs=get;list;watch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cftasks/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+func (r *CFTaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfTask := new(workloadsv1alpha1.CFTask)
+	err := r.Client.Get(ctx, req.NamespacedName, cfTask)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFTask %s/%s", req.Namespace, req.Name))
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if isTaskTerminated(cfTask) {
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := new(workloadsv1alpha1.CFApp)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: cfTask.Spec.AppRef.Name, Namespace: cfTask.Namespace}, cfApp)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFApp %s/%s", req.Namespace, cfTask.Spec.AppRef.Name))
+		return ctrl.Result{}, err
+	}
+
+	if cfTask.Status.SequenceID == 0 {
+		err = r.initializeTask(ctx, cfTask, cfApp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if cfTask.Spec.Canceled {
+		return ctrl.Result{}, r.cancelTask(ctx, cfTask)
+	}
+
+	if cfTask.Status.DropletRef.Name == "" {
+		return ctrl.Result{}, r.setTaskState(ctx, cfTask, workloadsv1alpha1.TaskFailedState, taskNoDropletReason)
+	}
+
+	job, err := r.getOrCreateJob(ctx, cfTask, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	state, failureReason := taskStateFromJob(job)
+	return ctrl.Result{}, r.setTaskState(ctx, cfTask, state, failureReason)
+}
+
+// initializeTask gives the task the next sequence ID of its app and pins the droplet it is going to run.
+// The sequence ID is reserved on the app with an optimistic lock, so that concurrent tasks never share an ID.
+// A failure after the reservation only results in a gap in the sequence.
+func (r *CFTaskReconciler) initializeTask(ctx context.Context, cfTask *workloadsv1alpha1.CFTask, cfApp *workloadsv1alpha1.CFApp) error {
+	// the cached task may not show the sequence ID given to it by an earlier reconcile yet, so check the task on the
+	// API server before reserving another ID for it
+	err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(cfTask), cfTask)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return err
+	}
+
+	if cfTask.Status.SequenceID != 0 {
+		return nil
+	}
+
+	originalCFApp := cfApp.DeepCopy()
+	cfApp.Status.LastTaskSequenceID++
+	err = r.Client.Status().Patch(ctx, cfApp, client.MergeFromWithOptions(originalCFApp, client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to reserve a task sequence ID on CFApp %s/%s", cfApp.Namespace, cfApp.Name))
+		return err
+	}
+
+	originalCFTask := cfTask.DeepCopy()
+	err = controllerutil.SetOwnerReference(cfApp, cfTask, r.Scheme)
+	if err != nil {
+		r.Log.Error(err, "unable to set owner reference on CFTask")
+		return err
+	}
+
+	err = r.Client.Patch(ctx, cfTask, client.MergeFrom(originalCFTask))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error setting owner reference on the CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return err
+	}
+
+	originalCFTask = cfTask.DeepCopy()
+	cfTask.Status.SequenceID = cfApp.Status.LastTaskSequenceID
+	cfTask.Status.DropletRef = cfApp.Spec.CurrentDropletRef
+	cfTask.Status.State = workloadsv1alpha1.TaskPendingState
+	// the optimistic lock makes sure the sequence ID is only ever set on the task version that was checked above
+	err = r.Client.Status().Patch(ctx, cfTask, client.MergeFromWithOptions(originalCFTask, client.MergeFromWithOptimisticLock{}))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to initialize the status of CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return err
+	}
+
+	return nil
+}
+
+func (r *CFTaskReconciler) cancelTask(ctx context.Context, cfTask *workloadsv1alpha1.CFTask) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfTask.Name,
+			Namespace: cfTask.Namespace,
+		},
+	}
+	err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if client.IgnoreNotFound(err) != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to delete the Job of CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return err
+	}
+
+	return r.setTaskState(ctx, cfTask, workloadsv1alpha1.TaskFailedState, taskCanceledReason)
+}
+
+func (r *CFTaskReconciler) getOrCreateJob(ctx context.Context, cfTask *workloadsv1alpha1.CFTask, cfApp *workloadsv1alpha1.CFApp) (*batchv1.Job, error) {
+	job := new(batchv1.Job)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: cfTask.Name, Namespace: cfTask.Namespace}, job)
+	if err == nil {
+		return job, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch the Job of CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return nil, err
+	}
+
+	cfBuild := new(workloadsv1alpha1.CFBuild)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: cfTask.Status.DropletRef.Name, Namespace: cfTask.Namespace}, cfBuild)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to fetch CFBuild %s/%s", cfTask.Namespace, cfTask.Status.DropletRef.Name))
+		return nil, err
+	}
+
+	if cfBuild.Status.BuildDropletStatus == nil {
+		err = errors.New("no build droplet status on CFBuild")
+		r.Log.Error(err, fmt.Sprintf("No build droplet status on CFBuild %s/%s", cfTask.Namespace, cfBuild.Name))
+		return nil, err
+	}
+
+	envVars, err := r.EnvBuilder.BuildEnv(ctx, cfApp)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to build the task environment for app %s/%s", cfTask.Namespace, cfApp.Spec.Name))
+		return nil, err
+	}
+
+	job, err = r.generateJob(cfTask, cfApp, cfBuild, envVars)
+	if err != nil {
+		// untested
+		r.Log.Error(err, "Error when initializing Job")
+		return nil, err
+	}
+
+	err = r.Client.Create(ctx, job)
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to create the Job of CFTask %s/%s", cfTask.Namespace, cfTask.Name))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *CFTaskReconciler) generateJob(cfTask *workloadsv1alpha1.CFTask, cfApp *workloadsv1alpha1.CFApp, cfBuild *workloadsv1alpha1.CFBuild, envVars map[string]string) (*batchv1.Job, error) {
+	labels := map[string]string{
+		workloadsv1alpha1.CFAppGUIDLabelKey:  cfApp.Name,
+		workloadsv1alpha1.CFTaskGUIDLabelKey: cfTask.Name,
+	}
+
+	// tasks are never retried
+	backoffLimit := int32(0)
+	ttlSecondsAfterFinished := int32(taskJobTTLSeconds)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfTask.Name,
+			Namespace: cfTask.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttlSecondsAfterFinished,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: cfBuild.Status.BuildDropletStatus.Registry.ImagePullSecrets,
+					Containers: []corev1.Container{{
+						Name:    taskContainerName,
+						Image:   cfBuild.Status.BuildDropletStatus.Registry.Image,
+						Command: commandForTask(cfTask, cfApp),
+						Env:     generateEnvVars(envVars),
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory:           *resource.NewQuantity(cfTask.Spec.MemoryMB*mebibyte, resource.BinarySI),
+								corev1.ResourceEphemeralStorage: *resource.NewQuantity(cfTask.Spec.DiskQuotaMB*mebibyte, resource.BinarySI),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	err := controllerutil.SetControllerReference(cfTask, job, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *CFTaskReconciler) setTaskState(ctx context.Context, cfTask *workloadsv1alpha1.CFTask, state workloadsv1alpha1.TaskState, failureReason string) error {
+	if cfTask.Status.State == state && cfTask.Status.FailureReason == failureReason {
+		return nil
+	}
+
+	originalCFTask := cfTask.DeepCopy()
+	cfTask.Status.State = state
+	cfTask.Status.FailureReason = failureReason
+	err := r.Client.Status().Patch(ctx, cfTask, client.MergeFrom(originalCFTask))
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("Error when trying to set the state of CFTask %s/%s to %s", cfTask.Namespace, cfTask.Name, state))
+		return err
+	}
+
+	return nil
+}
+
+// taskStateFromJob maps the status of the Job to the state of the task.
+// A Job with an active pod is considered running, even if the pod is still pulling its image.
+func taskStateFromJob(job *batchv1.Job) (workloadsv1alpha1.TaskState, string) {
+	switch {
+	case job.Status.Succeeded > 0:
+		return workloadsv1alpha1.TaskSucceededState, ""
+	case job.Status.Failed > 0:
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue && condition.Message != "" {
+				return workloadsv1alpha1.TaskFailedState, condition.Message
+			}
+		}
+		return workloadsv1alpha1.TaskFailedState, taskFailedReason
+	case job.Status.Active > 0:
+		return workloadsv1alpha1.TaskRunningState, ""
+	default:
+		return workloadsv1alpha1.TaskPendingState, ""
+	}
+}
+
+func isTaskTerminated(cfTask *workloadsv1alpha1.CFTask) bool {
+	return cfTask.Status.State == workloadsv1alpha1.TaskSucceededState || cfTask.Status.State == workloadsv1alpha1.TaskFailedState
+}
+
+func commandForTask(cfTask *workloadsv1alpha1.CFTask, cfApp *workloadsv1alpha1.CFApp) []string {
+	if cfApp.Spec.Lifecycle.Type == workloadsv1alpha1.BuildpackLifecycle {
+		return []string{"/cnb/lifecycle/launcher", cfTask.Spec.Command}
+	}
+	return []string{"/bin/sh", "-c", cfTask.Spec.Command}
+}
+
+func generateEnvVars(env map[string]string) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
+	for name, value := range env {
+		envVars = append(envVars, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	sort.Slice(envVars, func(i, j int) bool {
+		return envVars[i].Name < envVars[j].Name
+	})
+
+	return envVars
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CFTaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workloadsv1alpha1.CFTask{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
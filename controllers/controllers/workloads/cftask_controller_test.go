@@ -0,0 +1,339 @@
+package workloads_test
+
+import (
+	"context"
+	"errors"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/fake"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("CFTaskReconciler", func() {
+	const (
+		testTaskGUID    = "test-task-guid"
+		testTaskCommand = "bundle exec rake db:migrate"
+	)
+
+	var (
+		fakeClient       *fake.Client
+		fakeAPIReader    *fake.Client
+		fakeStatusWriter *fake.StatusWriter
+		envBuilder       *fake.EnvBuilder
+
+		cfTask *workloadsv1alpha1.CFTask
+		// apiCFTask is the task on the API server, which the cached cfTask may lag behind
+		apiCFTask *workloadsv1alpha1.CFTask
+		cfApp     *workloadsv1alpha1.CFApp
+		cfBuild   *workloadsv1alpha1.CFBuild
+		job       *batchv1.Job
+
+		cfTaskReconciler *CFTaskReconciler
+		ctx              context.Context
+		req              ctrl.Request
+
+		reconcileErr error
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(fake.Client)
+		fakeStatusWriter = new(fake.StatusWriter)
+		fakeClient.StatusReturns(fakeStatusWriter)
+
+		envBuilder = new(fake.EnvBuilder)
+		envBuilder.BuildEnvReturns(map[string]string{"VCAP_SERVICES": "{}", "FOO": "bar"}, nil)
+
+		cfApp = BuildCFAppCRObject(testAppGUID, testNamespace)
+		UpdateCFAppWithCurrentDropletRef(cfApp, testBuildGUID)
+		cfApp.Status.LastTaskSequenceID = 41
+		cfBuild = BuildCFBuildObject(testBuildGUID, testNamespace, testPackageGUID, testAppGUID)
+		UpdateCFBuildWithDropletStatus(cfBuild)
+		cfBuild.Status.BuildDropletStatus.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "image-pull-secret"}}
+		cfTask = BuildCFTaskObject(testTaskGUID, testNamespace, testAppGUID, testTaskCommand)
+		cfTask.ResourceVersion = "1"
+		apiCFTask = nil
+		job = nil
+
+		fakeClient.GetStub = func(_ context.Context, name types.NamespacedName, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *workloadsv1alpha1.CFTask:
+				cfTask.DeepCopyInto(obj)
+				return nil
+			case *workloadsv1alpha1.CFApp:
+				cfApp.DeepCopyInto(obj)
+				return nil
+			case *workloadsv1alpha1.CFBuild:
+				cfBuild.DeepCopyInto(obj)
+				return nil
+			case *batchv1.Job:
+				if job == nil {
+					return apierrors.NewNotFound(schema.GroupResource{}, name.Name)
+				}
+				job.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided a weird obj")
+			}
+		}
+
+		fakeAPIReader = new(fake.Client)
+		fakeAPIReader.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object) error {
+			if apiCFTask == nil {
+				cfTask.DeepCopyInto(obj.(*workloadsv1alpha1.CFTask))
+				return nil
+			}
+			apiCFTask.DeepCopyInto(obj.(*workloadsv1alpha1.CFTask))
+			return nil
+		}
+
+		Expect(workloadsv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+		cfTaskReconciler = &CFTaskReconciler{
+			Client:     fakeClient,
+			APIReader:  fakeAPIReader,
+			Scheme:     scheme.Scheme,
+			Log:        zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)),
+			EnvBuilder: envBuilder,
+		}
+		ctx = context.Background()
+		req = ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: testNamespace,
+				Name:      testTaskGUID,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		_, reconcileErr = cfTaskReconciler.Reconcile(ctx, req)
+	})
+
+	When("the task is new", func() {
+		It("reserves the next sequence ID on the app", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeStatusWriter.PatchCallCount()).To(BeNumerically(">=", 2))
+			_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+			patchedApp, ok := obj.(*workloadsv1alpha1.CFApp)
+			Expect(ok).To(BeTrue())
+			Expect(patchedApp.Status.LastTaskSequenceID).To(BeEquivalentTo(42))
+		})
+
+		It("initializes the status of the task", func() {
+			_, obj, patch, _ := fakeStatusWriter.PatchArgsForCall(1)
+			patchedTask, ok := obj.(*workloadsv1alpha1.CFTask)
+			Expect(ok).To(BeTrue())
+			patchData, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(patchData)).To(ContainSubstring(`"resourceVersion":"1"`))
+			Expect(patchedTask.Status.SequenceID).To(BeEquivalentTo(42))
+			Expect(patchedTask.Status.DropletRef.Name).To(Equal(testBuildGUID))
+			Expect(patchedTask.Status.State).To(Equal(workloadsv1alpha1.TaskPendingState))
+		})
+
+		It("makes the app the owner of the task", func() {
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+			_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+			Expect(obj.GetOwnerReferences()).To(ConsistOf(HaveField("Name", testAppGUID)))
+		})
+
+		It("creates a job running the droplet of the app", func() {
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			createdJob, ok := obj.(*batchv1.Job)
+			Expect(ok).To(BeTrue())
+
+			Expect(createdJob.Name).To(Equal(testTaskGUID))
+			Expect(createdJob.Namespace).To(Equal(testNamespace))
+			Expect(createdJob.Labels).To(HaveKeyWithValue(workloadsv1alpha1.CFTaskGUIDLabelKey, testTaskGUID))
+			Expect(createdJob.OwnerReferences).To(ConsistOf(HaveField("Name", testTaskGUID)))
+			Expect(*createdJob.Spec.BackoffLimit).To(BeZero())
+			Expect(*createdJob.Spec.TTLSecondsAfterFinished).To(BeEquivalentTo(24 * 60 * 60))
+
+			podSpec := createdJob.Spec.Template.Spec
+			Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(podSpec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "image-pull-secret"}))
+			Expect(podSpec.Containers).To(HaveLen(1))
+
+			container := podSpec.Containers[0]
+			Expect(container.Image).To(Equal("my-image"))
+			Expect(container.Command).To(Equal([]string{"/cnb/lifecycle/launcher", testTaskCommand}))
+			Expect(container.Env).To(Equal([]corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+				{Name: "VCAP_SERVICES", Value: "{}"},
+			}))
+			Expect(container.Resources.Limits).To(Equal(corev1.ResourceList{
+				corev1.ResourceMemory:           resource.MustParse("256Mi"),
+				corev1.ResourceEphemeralStorage: resource.MustParse("512Mi"),
+			}))
+		})
+
+		When("the app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				cfApp.Spec.Lifecycle.Type = workloadsv1alpha1.DockerLifecycle
+			})
+
+			It("runs the command in a shell", func() {
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				Expect(obj.(*batchv1.Job).Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"/bin/sh", "-c", testTaskCommand}))
+			})
+		})
+
+		When("the app has no droplet", func() {
+			BeforeEach(func() {
+				cfApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{}
+			})
+
+			It("fails the task without creating a job", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(fakeStatusWriter.PatchCallCount() - 1)
+				patchedTask := obj.(*workloadsv1alpha1.CFTask)
+				Expect(patchedTask.Status.State).To(Equal(workloadsv1alpha1.TaskFailedState))
+				Expect(patchedTask.Status.FailureReason).To(Equal("app has no droplet"))
+			})
+		})
+
+		When("the cached task does not show the sequence ID it was given yet", func() {
+			BeforeEach(func() {
+				apiCFTask = cfTask.DeepCopy()
+				apiCFTask.Status.SequenceID = 42
+				apiCFTask.Status.DropletRef = cfApp.Spec.CurrentDropletRef
+				apiCFTask.Status.State = workloadsv1alpha1.TaskPendingState
+			})
+
+			It("does not reserve another sequence ID", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				for i := 0; i < fakeStatusWriter.PatchCallCount(); i++ {
+					_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(i)
+					Expect(obj).NotTo(BeAssignableToTypeOf(&workloadsv1alpha1.CFApp{}))
+				}
+				Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			})
+		})
+
+		When("reserving the sequence ID fails", func() {
+			BeforeEach(func() {
+				fakeStatusWriter.PatchReturnsOnCall(0, errors.New("conflict"))
+			})
+
+			It("returns the error and does not create a job", func() {
+				Expect(reconcileErr).To(MatchError("conflict"))
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	When("the task has been initialized", func() {
+		BeforeEach(func() {
+			cfTask.Status.SequenceID = 3
+			cfTask.Status.DropletRef = corev1.LocalObjectReference{Name: testBuildGUID}
+			cfTask.Status.State = workloadsv1alpha1.TaskPendingState
+		})
+
+		It("does not reserve another sequence ID", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			for i := 0; i < fakeStatusWriter.PatchCallCount(); i++ {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(i)
+				Expect(obj).NotTo(BeAssignableToTypeOf(&workloadsv1alpha1.CFApp{}))
+			}
+		})
+
+		When("the job has an active pod", func() {
+			BeforeEach(func() {
+				job = &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}
+			})
+
+			It("sets the task to RUNNING", func() {
+				Expect(fakeClient.CreateCallCount()).To(Equal(0))
+				Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				Expect(obj.(*workloadsv1alpha1.CFTask).Status.State).To(Equal(workloadsv1alpha1.TaskRunningState))
+			})
+		})
+
+		When("the job succeeded", func() {
+			BeforeEach(func() {
+				job = &batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1}}
+			})
+
+			It("sets the task to SUCCEEDED", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				Expect(obj.(*workloadsv1alpha1.CFTask).Status.State).To(Equal(workloadsv1alpha1.TaskSucceededState))
+			})
+		})
+
+		When("the job failed", func() {
+			BeforeEach(func() {
+				job = &batchv1.Job{Status: batchv1.JobStatus{
+					Failed: 1,
+					Conditions: []batchv1.JobCondition{{
+						Type:    batchv1.JobFailed,
+						Status:  corev1.ConditionTrue,
+						Message: "Job has reached the specified backoff limit",
+					}},
+				}}
+			})
+
+			It("sets the task to FAILED with the reason of the job", func() {
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedTask := obj.(*workloadsv1alpha1.CFTask)
+				Expect(patchedTask.Status.State).To(Equal(workloadsv1alpha1.TaskFailedState))
+				Expect(patchedTask.Status.FailureReason).To(Equal("Job has reached the specified backoff limit"))
+			})
+		})
+
+		When("the state of the job has not changed", func() {
+			BeforeEach(func() {
+				job = &batchv1.Job{}
+			})
+
+			It("does not patch the task", func() {
+				Expect(fakeStatusWriter.PatchCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the task is canceled", func() {
+			BeforeEach(func() {
+				cfTask.Spec.Canceled = true
+			})
+
+			It("deletes the job and fails the task", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+				_, deletedObj, _ := fakeClient.DeleteArgsForCall(0)
+				Expect(deletedObj.GetName()).To(Equal(testTaskGUID))
+
+				_, obj, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedTask := obj.(*workloadsv1alpha1.CFTask)
+				Expect(patchedTask.Status.State).To(Equal(workloadsv1alpha1.TaskFailedState))
+				Expect(patchedTask.Status.FailureReason).To(Equal("task was cancelled"))
+			})
+		})
+	})
+
+	When("the task has terminated", func() {
+		BeforeEach(func() {
+			cfTask.Status.SequenceID = 3
+			cfTask.Status.State = workloadsv1alpha1.TaskSucceededState
+		})
+
+		It("does nothing", func() {
+			Expect(reconcileErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(Equal(0))
+			Expect(fakeStatusWriter.PatchCallCount()).To(Equal(0))
+		})
+	})
+})
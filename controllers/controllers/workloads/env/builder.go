@@ -15,9 +15,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
-type vcapServicesPresenter struct {
-	UserProvided []serviceDetails `json:"user-provided,omitempty"`
-}
+// vcapServicesPresenter groups the services by label, which is the name of the offering for managed services
+type vcapServicesPresenter map[string][]serviceDetails
 
 type serviceDetails struct {
 	Label          string            `json:"label"`
@@ -98,8 +97,14 @@ func fromServiceBinding(
 		tags = []string{}
 	}
 
+	label := servicesv1alpha1.UserProvidedType
+	if serviceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+		// the binding controller records the offering name as the type of the binding secret
+		label = string(serviceBindingSecret.Data["type"])
+	}
+
 	return serviceDetails{
-		Label:          "user-provided",
+		Label:          label,
 		Name:           serviceName,
 		Tags:           tags,
 		InstanceGUID:   serviceInstance.Name,
@@ -126,7 +131,7 @@ func buildVcapServicesEnvValue(ctx context.Context, k8sClient workloads.CFClient
 		return "{}", nil
 	}
 
-	serviceEnvs := []serviceDetails{}
+	serviceEnvs := vcapServicesPresenter{}
 	for _, currentServiceBinding := range serviceBindings.Items {
 		var serviceEnv serviceDetails
 		serviceEnv, err = buildSingleServiceEnv(ctx, k8sClient, currentServiceBinding)
@@ -134,12 +139,10 @@ func buildVcapServicesEnvValue(ctx context.Context, k8sClient workloads.CFClient
 			return "", err
 		}
 
-		serviceEnvs = append(serviceEnvs, serviceEnv)
+		serviceEnvs[serviceEnv.Label] = append(serviceEnvs[serviceEnv.Label], serviceEnv)
 	}
 
-	toReturn, err := json.Marshal(vcapServicesPresenter{
-		UserProvided: serviceEnvs,
-	})
+	toReturn, err := json.Marshal(serviceEnvs)
 	if err != nil {
 		return "", err
 	}
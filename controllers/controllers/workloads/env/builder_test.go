@@ -222,6 +222,20 @@ var _ = Describe("Builder", func() {
 		})
 	})
 
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			serviceInstance.Spec.Type = servicesv1alpha1.ManagedType
+			serviceBindingSecret.Data["type"] = []byte("postgres")
+		})
+
+		It("lists the service under the name of its offering", func() {
+			var vcapServices map[string][]interface{}
+			Expect(json.Unmarshal([]byte(envMap["VCAP_SERVICES"]), &vcapServices)).To(Succeed())
+			Expect(vcapServices).To(SatisfyAll(HaveLen(1), HaveKey("postgres")))
+			Expect(vcapServices["postgres"][0]).To(HaveKeyWithValue("label", "postgres"))
+		})
+	})
+
 	When("service instance tags are nil", func() {
 		BeforeEach(func() {
 			serviceInstance.Spec.Tags = nil
@@ -7,14 +7,16 @@ import (
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	v1 "k8s.io/api/core/v1"
 )
 
 type RegistryAuthFetcher struct {
-	Stub        func(context.Context, string) (remote.Option, error)
+	Stub        func(context.Context, string, []v1.LocalObjectReference) (remote.Option, error)
 	mutex       sync.RWMutex
 	argsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 []v1.LocalObjectReference
 	}
 	returns struct {
 		result1 remote.Option
@@ -28,19 +30,25 @@ type RegistryAuthFetcher struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *RegistryAuthFetcher) Spy(arg1 context.Context, arg2 string) (remote.Option, error) {
+func (fake *RegistryAuthFetcher) Spy(arg1 context.Context, arg2 string, arg3 []v1.LocalObjectReference) (remote.Option, error) {
+	var arg3Copy []v1.LocalObjectReference
+	if arg3 != nil {
+		arg3Copy = make([]v1.LocalObjectReference, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.mutex.Lock()
 	ret, specificReturn := fake.returnsOnCall[len(fake.argsForCall)]
 	fake.argsForCall = append(fake.argsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 []v1.LocalObjectReference
+	}{arg1, arg2, arg3Copy})
 	stub := fake.Stub
 	returns := fake.returns
-	fake.recordInvocation("RegistryAuthFetcher", []interface{}{arg1, arg2})
+	fake.recordInvocation("RegistryAuthFetcher", []interface{}{arg1, arg2, arg3Copy})
 	fake.mutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -54,16 +62,16 @@ func (fake *RegistryAuthFetcher) CallCount() int {
 	return len(fake.argsForCall)
 }
 
-func (fake *RegistryAuthFetcher) Calls(stub func(context.Context, string) (remote.Option, error)) {
+func (fake *RegistryAuthFetcher) Calls(stub func(context.Context, string, []v1.LocalObjectReference) (remote.Option, error)) {
 	fake.mutex.Lock()
 	defer fake.mutex.Unlock()
 	fake.Stub = stub
 }
 
-func (fake *RegistryAuthFetcher) ArgsForCall(i int) (context.Context, string) {
+func (fake *RegistryAuthFetcher) ArgsForCall(i int) (context.Context, string, []v1.LocalObjectReference) {
 	fake.mutex.RLock()
 	defer fake.mutex.RUnlock()
-	return fake.argsForCall[i].arg1, fake.argsForCall[i].arg2
+	return fake.argsForCall[i].arg1, fake.argsForCall[i].arg2, fake.argsForCall[i].arg3
 }
 
 func (fake *RegistryAuthFetcher) Returns(result1 remote.Option, result2 error) {
@@ -95,8 +103,6 @@ func (fake *RegistryAuthFetcher) ReturnsOnCall(i int, result1 remote.Option, res
 func (fake *RegistryAuthFetcher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.mutex.RLock()
-	defer fake.mutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,7 +2,9 @@ package imageprocessfetcher
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
@@ -13,6 +15,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"k8s.io/utils/net"
 )
 
@@ -39,20 +42,30 @@ func (f *ImageProcessFetcher) Fetch(imageRef string, credsOption remote.Option)
 		return nil, nil, err
 	}
 
-	// Unmarshall Build Metadata information from Image Config
-	var buildMetadata platform.BuildMetadata
-	err = json.Unmarshal([]byte(cfgFile.Config.Labels[platform.BuildMetadataLabel]), &buildMetadata)
-	if err != nil {
-		f.Log.Info(fmt.Sprintf("Error unmarshalling image build metadata: %s\n", err))
-		return nil, nil, err
-	}
-
-	// Loop over all the Processes and extract the complete command string
 	processTypes := []workloadsv1alpha1.ProcessType{}
-	for _, process := range buildMetadata.Processes {
+	buildMetadataLabel, builtByBuildpacks := cfgFile.Config.Labels[platform.BuildMetadataLabel]
+	if builtByBuildpacks {
+		// Unmarshall Build Metadata information from Image Config
+		var buildMetadata platform.BuildMetadata
+		err = json.Unmarshal([]byte(buildMetadataLabel), &buildMetadata)
+		if err != nil {
+			f.Log.Info(fmt.Sprintf("Error unmarshalling image build metadata: %s\n", err))
+			return nil, nil, err
+		}
+
+		// Loop over all the Processes and extract the complete command string
+		for _, process := range buildMetadata.Processes {
+			processTypes = append(processTypes, workloadsv1alpha1.ProcessType{
+				Type:    process.Type,
+				Command: extractFullCommand(process),
+			})
+		}
+	} else {
+		// Images that were not built with buildpacks, e.g. the ones of docker apps, only have a web process
+		// running their entrypoint
 		processTypes = append(processTypes, workloadsv1alpha1.ProcessType{
-			Type:    process.Type,
-			Command: extractFullCommand(process),
+			Type:    "web",
+			Command: extractImageCommand(&cfgFile.Config),
 		})
 	}
 
@@ -64,12 +77,47 @@ func (f *ImageProcessFetcher) Fetch(imageRef string, credsOption remote.Option)
 	return processTypes, exposedPorts, nil
 }
 
+// IsPermanentError returns whether fetching the image failed because the registry denied access to it or does not
+// know it, as opposed to a network or registry failure that may go away when retried
+func IsPermanentError(err error) bool {
+	// an invalid reference can never be fetched, whatever the registry
+	var badNameErr *name.ErrBadName
+	if errors.As(err, &badNameErr) {
+		return true
+	}
+
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+
+	switch transportErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return true
+	}
+
+	for _, diagnostic := range transportErr.Errors {
+		switch diagnostic.Code {
+		case transport.UnauthorizedErrorCode, transport.DeniedErrorCode, transport.ManifestUnknownErrorCode, transport.NameUnknownErrorCode:
+			return true
+		}
+	}
+
+	return false
+}
+
 // Reconstruct command with arguments into a single command string
 func extractFullCommand(process launch.Process) string {
 	commandWithArgs := append([]string{process.Command}, process.Args...)
 	return strings.Join(commandWithArgs, " ")
 }
 
+// Reconstruct the command an image runs by default from its entrypoint and cmd
+func extractImageCommand(imageConfig *v1.Config) string {
+	commandWithArgs := append(append([]string{}, imageConfig.Entrypoint...), imageConfig.Cmd...)
+	return strings.Join(commandWithArgs, " ")
+}
+
 func extractExposedPorts(imageConfig *v1.Config) ([]int32, error) {
 	// Drop the protocol since we only use TCP (the default) and only store the port number
 	ports := []int32{}
@@ -169,6 +169,24 @@ var _ = Describe("CFAppReconciler", func() {
 			})
 		})
 
+		It("eventually records a revision of the app", func() {
+			testCtx := context.Background()
+			var updatedCFApp workloadsv1alpha1.CFApp
+			Eventually(func() string {
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: cfAppGUID, Namespace: namespaceGUID}, &updatedCFApp)).To(Succeed())
+				return updatedCFApp.Status.CurrentRevisionRef.Name
+			}).ShouldNot(BeEmpty())
+
+			var cfAppRevision workloadsv1alpha1.CFAppRevision
+			Expect(k8sClient.Get(testCtx, types.NamespacedName{Name: updatedCFApp.Status.CurrentRevisionRef.Name, Namespace: namespaceGUID}, &cfAppRevision)).To(Succeed())
+			Expect(cfAppRevision.Spec.Version).To(BeEquivalentTo(1))
+			Expect(cfAppRevision.Spec.DropletRef.Name).To(Equal(cfBuildGUID))
+			Expect(cfAppRevision.Spec.Processes).To(ConsistOf(
+				workloadsv1alpha1.RevisionProcess{Type: processTypeWeb, Command: processTypeWebCommand},
+				workloadsv1alpha1.RevisionProcess{Type: processTypeWorker, Command: processTypeWorkerCommand},
+			))
+		})
+
 		When("CFProcesses exist for the app", func() {
 			var (
 				cfProcessForTypeWebGUID string
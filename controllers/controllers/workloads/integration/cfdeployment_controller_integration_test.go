@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"context"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	eiriniv1 "code.cloudfoundry.org/eirini-controller/pkg/apis/eirini/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFDeploymentReconciler Integration Tests", func() {
+	var (
+		ctx           context.Context
+		testNamespace string
+		ns            *corev1.Namespace
+
+		testAppGUID     string
+		testProcessGUID string
+		testBuildGUID   string
+		testPackageGUID string
+		cfDeployment    *workloadsv1alpha1.CFDeployment
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		testNamespace = GenerateGUID()
+		ns = createNamespace(ctx, k8sClient, testNamespace)
+
+		testAppGUID = GenerateGUID()
+		testProcessGUID = GenerateGUID()
+		testBuildGUID = GenerateGUID()
+		testPackageGUID = GenerateGUID()
+
+		Expect(k8sClient.Create(ctx, BuildCFAppEnvVarsSecret(testAppGUID, testNamespace, map[string]string{"FOO": "bar"}))).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, BuildCFPackageCRObject(testPackageGUID, testNamespace, testAppGUID))).To(Succeed())
+		cfBuild := BuildCFBuildObject(testBuildGUID, testNamespace, testPackageGUID, testAppGUID)
+		createBuildWithDroplet(ctx, k8sClient, cfBuild, BuildCFBuildDropletStatusObject(map[string]string{"web": "web-command"}, []int32{8080}))
+
+		cfProcess := BuildCFProcessCRObject(testProcessGUID, testNamespace, testAppGUID, "web", "web-command")
+		cfProcess.Spec.DesiredInstances = 1
+		Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+
+		cfApp := BuildCFAppCRObject(testAppGUID, testNamespace)
+		UpdateCFAppWithCurrentDropletRef(cfApp, testBuildGUID)
+		cfApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+		Expect(k8sClient.Create(ctx, cfApp)).To(Succeed())
+
+		Eventually(func() []eiriniv1.LRP { return lrpsForRevision(ctx, testNamespace, testProcessGUID, "0") }).Should(HaveLen(1))
+
+		cfDeployment = BuildCFDeploymentObject(GenerateGUID(), testNamespace, testAppGUID, testBuildGUID)
+		Expect(k8sClient.Create(ctx, cfDeployment)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+
+	getDeployment := func() workloadsv1alpha1.CFDeployment {
+		var deployment workloadsv1alpha1.CFDeployment
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cfDeployment.Name, Namespace: testNamespace}, &deployment)).To(Succeed())
+		return deployment
+	}
+
+	It("starts the new revision next to the previous one", func() {
+		Eventually(func() []eiriniv1.LRP { return lrpsForRevision(ctx, testNamespace, testProcessGUID, "1") }).Should(HaveLen(1))
+		Consistently(func() []eiriniv1.LRP { return lrpsForRevision(ctx, testNamespace, testProcessGUID, "0") }).Should(HaveLen(1))
+		Expect(getDeployment().Status.Reason).To(Equal(workloadsv1alpha1.DeploymentStatusReasonDeploying))
+	})
+
+	When("the instances of the new revision become ready", func() {
+		BeforeEach(func() {
+			var newLRP eiriniv1.LRP
+			Eventually(func() []eiriniv1.LRP { return lrpsForRevision(ctx, testNamespace, testProcessGUID, "1") }).Should(HaveLen(1))
+			newLRP = lrpsForRevision(ctx, testNamespace, testProcessGUID, "1")[0]
+
+			originalLRP := newLRP.DeepCopy()
+			newLRP.Status.Replicas = 1
+			Expect(k8sClient.Status().Patch(ctx, &newLRP, client.MergeFrom(originalLRP))).To(Succeed())
+		})
+
+		It("stops the previous revision and finalizes the deployment", func() {
+			Eventually(func() []eiriniv1.LRP { return lrpsForRevision(ctx, testNamespace, testProcessGUID, "0") }).Should(BeEmpty())
+			Eventually(func() workloadsv1alpha1.DeploymentStatusReason { return getDeployment().Status.Reason }).Should(Equal(workloadsv1alpha1.DeploymentStatusReasonDeployed))
+			Expect(getDeployment().Status.Value).To(Equal(workloadsv1alpha1.DeploymentStatusValueFinalized))
+		})
+	})
+})
+
+func lrpsForRevision(ctx context.Context, namespace, processGUID, revision string) []eiriniv1.LRP {
+	var lrpList eiriniv1.LRPList
+	Expect(k8sClient.List(ctx, &lrpList, client.InNamespace(namespace), client.MatchingLabels{
+		workloadsv1alpha1.CFProcessGUIDLabelKey: processGUID,
+		workloadsv1alpha1.CFAppRevisionKey:      revision,
+	})).To(Succeed())
+	return lrpList.Items
+}
@@ -0,0 +1,100 @@
+package integration_test
+
+import (
+	"context"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFTaskReconciler Integration Tests", func() {
+	var (
+		ctx           context.Context
+		testNamespace string
+		ns            *corev1.Namespace
+
+		testAppGUID     string
+		testBuildGUID   string
+		testPackageGUID string
+		cfApp           *workloadsv1alpha1.CFApp
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		testNamespace = GenerateGUID()
+		ns = createNamespace(ctx, k8sClient, testNamespace)
+
+		testAppGUID = GenerateGUID()
+		testBuildGUID = GenerateGUID()
+		testPackageGUID = GenerateGUID()
+
+		Expect(k8sClient.Create(ctx, BuildCFAppEnvVarsSecret(testAppGUID, testNamespace, map[string]string{"FOO": "bar"}))).To(Succeed())
+
+		cfApp = BuildCFAppCRObject(testAppGUID, testNamespace)
+		UpdateCFAppWithCurrentDropletRef(cfApp, testBuildGUID)
+		Expect(k8sClient.Create(ctx, cfApp)).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, BuildCFPackageCRObject(testPackageGUID, testNamespace, testAppGUID))).To(Succeed())
+		cfBuild := BuildCFBuildObject(testBuildGUID, testNamespace, testPackageGUID, testAppGUID)
+		createBuildWithDroplet(ctx, k8sClient, cfBuild, BuildCFBuildDropletStatusObject(map[string]string{"web": "web-command"}, []int32{8080}))
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), ns)).To(Succeed())
+	})
+
+	getTask := func(guid string) workloadsv1alpha1.CFTask {
+		var task workloadsv1alpha1.CFTask
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: guid, Namespace: testNamespace}, &task)).To(Succeed())
+		return task
+	}
+
+	It("gives consecutive sequence IDs to the tasks of an app", func() {
+		firstTask := BuildCFTaskObject(GenerateGUID(), testNamespace, testAppGUID, "first")
+		Expect(k8sClient.Create(ctx, firstTask)).To(Succeed())
+		Eventually(func() int64 { return getTask(firstTask.Name).Status.SequenceID }).Should(BeEquivalentTo(1))
+
+		secondTask := BuildCFTaskObject(GenerateGUID(), testNamespace, testAppGUID, "second")
+		Expect(k8sClient.Create(ctx, secondTask)).To(Succeed())
+		Eventually(func() int64 { return getTask(secondTask.Name).Status.SequenceID }).Should(BeEquivalentTo(2))
+	})
+
+	It("runs the task as a job owned by the task", func() {
+		cfTask := BuildCFTaskObject(GenerateGUID(), testNamespace, testAppGUID, "rake db:migrate")
+		Expect(k8sClient.Create(ctx, cfTask)).To(Succeed())
+
+		var job batchv1.Job
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: cfTask.Name, Namespace: testNamespace}, &job)
+		}).Should(Succeed())
+
+		Expect(job.OwnerReferences).To(ConsistOf(HaveField("Name", cfTask.Name)))
+		Expect(job.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "rake db:migrate"}))
+		Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "FOO", Value: "bar"}))
+		Expect(getTask(cfTask.Name).Status.DropletRef.Name).To(Equal(testBuildGUID))
+	})
+
+	When("the task is canceled", func() {
+		It("fails the task", func() {
+			cfTask := BuildCFTaskObject(GenerateGUID(), testNamespace, testAppGUID, "sleep 3600")
+			Expect(k8sClient.Create(ctx, cfTask)).To(Succeed())
+			Eventually(func() workloadsv1alpha1.TaskState { return getTask(cfTask.Name).Status.State }).ShouldNot(BeEmpty())
+
+			task := getTask(cfTask.Name)
+			originalTask := task.DeepCopy()
+			task.Spec.Canceled = true
+			Expect(k8sClient.Patch(ctx, &task, client.MergeFrom(originalTask))).To(Succeed())
+
+			Eventually(func() workloadsv1alpha1.TaskState { return getTask(cfTask.Name).Status.State }).Should(Equal(workloadsv1alpha1.TaskFailedState))
+			Expect(getTask(cfTask.Name).Status.FailureReason).To(Equal("task was cancelled"))
+		})
+	})
+})
@@ -141,6 +141,23 @@ var _ = BeforeSuite(func() {
 		Scheme:     k8sManager.GetScheme(),
 		Log:        ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		EnvBuilder: env.NewBuilder(k8sManager.GetClient()),
+		Recorder:   k8sManager.GetEventRecorderFor("cfprocess-controller"),
+	}).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&CFTaskReconciler{
+		Client:     k8sManager.GetClient(),
+		APIReader:  k8sManager.GetAPIReader(),
+		Scheme:     k8sManager.GetScheme(),
+		Log:        ctrl.Log.WithName("controllers").WithName("CFTask"),
+		EnvBuilder: env.NewBuilder(k8sManager.GetClient()),
+	}).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&CFDeploymentReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		Log:    ctrl.Log.WithName("controllers").WithName("CFDeployment"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
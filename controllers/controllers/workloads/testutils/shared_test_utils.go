@@ -238,6 +238,42 @@ func BuildCFProcessCRObject(cfProcessGUID string, namespace string, cfAppGUID st
 	}
 }
 
+func BuildCFTaskObject(cfTaskGUID string, namespace string, cfAppGUID string, command string) *workloadsv1alpha1.CFTask {
+	return &workloadsv1alpha1.CFTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfTaskGUID,
+			Namespace: namespace,
+			Labels: map[string]string{
+				CFAppLabelKey: cfAppGUID,
+			},
+		},
+		Spec: workloadsv1alpha1.CFTaskSpec{
+			Name:        "test-task-name",
+			Command:     command,
+			AppRef:      corev1.LocalObjectReference{Name: cfAppGUID},
+			MemoryMB:    256,
+			DiskQuotaMB: 512,
+		},
+	}
+}
+
+func BuildCFDeploymentObject(cfDeploymentGUID string, namespace string, cfAppGUID string, dropletGUID string) *workloadsv1alpha1.CFDeployment {
+	return &workloadsv1alpha1.CFDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfDeploymentGUID,
+			Namespace: namespace,
+			Labels: map[string]string{
+				CFAppLabelKey: cfAppGUID,
+			},
+		},
+		Spec: workloadsv1alpha1.CFDeploymentSpec{
+			AppRef:     corev1.LocalObjectReference{Name: cfAppGUID},
+			DropletRef: corev1.LocalObjectReference{Name: dropletGUID},
+			Strategy:   workloadsv1alpha1.RollingDeploymentStrategy,
+		},
+	}
+}
+
 func SetStatusCondition(conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus) {
 	meta.SetStatusCondition(conditions, metav1.Condition{
 		Type:    conditionType,
@@ -19,7 +19,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
 	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
@@ -27,6 +29,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	networkingcontrollers "code.cloudfoundry.org/korifi/controllers/controllers/networking"
 	servicescontrollers "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	workloadscontrollers "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
@@ -61,6 +64,12 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// brokerRequestTimeout bounds the requests to service brokers, so that an unresponsive broker does not block reconciliation
+const (
+	brokerRequestTimeout       = 60 * time.Second
+	deploymentProgressDeadline = 10 * time.Minute
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(workloadsv1alpha1.AddToScheme(scheme))
@@ -173,11 +182,34 @@ func main() {
 		Scheme:     mgr.GetScheme(),
 		Log:        ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		EnvBuilder: env.NewBuilder(mgr.GetClient()),
+		Recorder:   mgr.GetEventRecorderFor("cfprocess-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 		os.Exit(1)
 	}
 
+	if err = (&workloadscontrollers.CFTaskReconciler{
+		Client:     mgr.GetClient(),
+		APIReader:  mgr.GetAPIReader(),
+		Scheme:     mgr.GetScheme(),
+		Log:        ctrl.Log.WithName("controllers").WithName("CFTask"),
+		EnvBuilder: env.NewBuilder(mgr.GetClient()),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CFTask")
+		os.Exit(1)
+	}
+
+	if err = (&workloadscontrollers.CFDeploymentReconciler{
+		Client:           mgr.GetClient(),
+		APIReader:        mgr.GetAPIReader(),
+		Scheme:           mgr.GetScheme(),
+		Log:              ctrl.Log.WithName("controllers").WithName("CFDeployment"),
+		ProgressDeadline: deploymentProgressDeadline,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CFDeployment")
+		os.Exit(1)
+	}
+
 	if err = (&networkingcontrollers.CFRouteReconciler{
 		Client:           mgr.GetClient(),
 		Scheme:           mgr.GetScheme(),
@@ -188,22 +220,43 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&servicescontrollers.CFServiceInstanceReconciler{
+	if err = (&networkingcontrollers.CFSecurityGroupReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
+		Log:    ctrl.Log.WithName("controllers").WithName("CFSecurityGroup"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CFSecurityGroup")
+		os.Exit(1)
+	}
+
+	brokerClient := osbapi.NewClient(&http.Client{Timeout: brokerRequestTimeout})
+	if err = (&servicescontrollers.CFServiceInstanceReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
+		BrokerClient: brokerClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CFServiceInstance")
 		os.Exit(1)
 	}
 	if err = (&servicescontrollers.CFServiceBindingReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+		BrokerClient: brokerClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 		os.Exit(1)
 	}
+	if err = (&servicescontrollers.CFServiceBrokerReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Log:          ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
+		BrokerClient: brokerClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CFServiceBroker")
+		os.Exit(1)
+	}
 
 	if err = workloadscontrollers.NewCFOrgReconciler(
 		mgr.GetClient(),
@@ -252,15 +305,26 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := workloads.NewQuotaValidator(mgr.GetClient(), controllerConfig.CFRootNamespace)
+
 		if err = workloads.NewCFAppValidation(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
 		}
 
+		if err = workloads.NewCFProcessValidation(
+			quotaValidator,
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
 		if err = networking.NewCFRouteValidation(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), networking.RouteEntityType)),
+			quotaValidator,
 			controllerConfig.CFRootNamespace,
 			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -270,6 +334,7 @@ func main() {
 
 		if err = services.NewCFServiceInstanceValidation(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
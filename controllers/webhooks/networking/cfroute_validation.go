@@ -49,6 +49,7 @@ const (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fake -fake-name NameValidator . NameValidator
+//counterfeiter:generate -o fake -fake-name RouteQuotaValidator . RouteQuotaValidator
 
 type NameValidator interface {
 	ValidateCreate(ctx context.Context, logger logr.Logger, namespace, newName string) error
@@ -56,6 +57,10 @@ type NameValidator interface {
 	ValidateDelete(ctx context.Context, logger logr.Logger, namespace, oldName string) error
 }
 
+type RouteQuotaValidator interface {
+	ValidateRouteCreate(ctx context.Context, logger logr.Logger, spaceGUID string) error
+}
+
 var logger = logf.Log.WithName("route-validation")
 
 //+kubebuilder:webhook:path=/validate-networking-cloudfoundry-org-v1alpha1-cfroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=networking.cloudfoundry.org,resources=cfroutes,verbs=create;update;delete,versions=v1alpha1,name=vcfroute.networking.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
@@ -64,12 +69,14 @@ type CFRouteValidation struct {
 	decoder            *admission.Decoder
 	rootNamespace      string
 	duplicateValidator NameValidator
+	quotaValidator     RouteQuotaValidator
 	Client             client.Client
 }
 
-func NewCFRouteValidation(nameValidator NameValidator, rootNamespace string, client client.Client) *CFRouteValidation {
+func NewCFRouteValidation(nameValidator NameValidator, quotaValidator RouteQuotaValidator, rootNamespace string, client client.Client) *CFRouteValidation {
 	return &CFRouteValidation{
 		duplicateValidator: nameValidator,
+		quotaValidator:     quotaValidator,
 		rootNamespace:      rootNamespace,
 		Client:             client,
 	}
@@ -138,6 +145,14 @@ func (v *CFRouteValidation) Handle(ctx context.Context, req admission.Request) a
 			return admission.Denied(validationError.Marshal())
 		}
 
+		if err := v.quotaValidator.ValidateRouteCreate(ctx, logger, route.Namespace); err != nil {
+			var validationError webhooks.ValidationError
+			if errors.As(err, &validationError) {
+				return admission.Denied(validationError.Marshal())
+			}
+			return admission.Denied(webhooks.AdmissionUnknownErrorReason())
+		}
+
 		validatorErr = v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, uniqueName(route))
 
 	case admissionv1.Update:
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -30,6 +31,7 @@ var _ = Describe("CF Route Validation", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.RouteQuotaValidator
 		fakeClient         *fake.Client
 		realDecoder        *admission.Decoder
 		cfRoute            *networkingv1alpha1.CFRoute
@@ -93,6 +95,7 @@ var _ = Describe("CF Route Validation", func() {
 		cfApp = &workloadsv1alpha1.CFApp{}
 
 		duplicateValidator = new(fake.NameValidator)
+		quotaValidator = new(fake.RouteQuotaValidator)
 		fakeClient = new(fake.Client)
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object) error {
@@ -108,7 +111,7 @@ var _ = Describe("CF Route Validation", func() {
 			}
 		}
 
-		validatingWebhook = networking.NewCFRouteValidation(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = networking.NewCFRouteValidation(duplicateValidator, quotaValidator, rootNamespace, fakeClient)
 
 		Expect(validatingWebhook.InjectDecoder(realDecoder)).To(Succeed())
 	})
@@ -329,6 +332,44 @@ var _ = Describe("CF Route Validation", func() {
 				Expect(name).To(Equal(testRouteHost + "::" + testDomainNamespace + "::" + testDomainGUID + "::" + testRoutePath))
 			})
 
+			It("validates the route against the quotas of its space", func() {
+				Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+				_, _, spaceGUID := quotaValidator.ValidateRouteCreateArgsForCall(0)
+				Expect(spaceGUID).To(Equal(testRouteNamespace))
+			})
+
+			When("the routes quota is exceeded", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateRouteCreateReturns(webhooks.ValidationError{
+						Type:    workloads.QuotaExceededErrorType,
+						Message: "Routes quota exceeded for space 'my-space'.",
+					})
+				})
+
+				It("denies the request", func() {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(string(response.Result.Reason)).To(Equal(webhooks.ValidationError{
+						Type:    workloads.QuotaExceededErrorType,
+						Message: "Routes quota exceeded for space 'my-space'.",
+					}.Marshal()))
+				})
+
+				It("does not register the route name", func() {
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+				})
+			})
+
+			When("validating the quotas fails", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateRouteCreateReturns(errors.New("boom"))
+				})
+
+				It("denies the request", func() {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(string(response.Result.Reason)).To(Equal(webhooks.AdmissionUnknownErrorReason()))
+				})
+			})
+
 			When("the host contains upper-case characters", func() {
 				BeforeEach(func() {
 					cfRoute.Spec.Host = "vAlidnAme"
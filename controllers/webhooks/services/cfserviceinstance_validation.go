@@ -17,6 +17,7 @@ import (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fake -fake-name NameValidator . NameValidator
+//counterfeiter:generate -o fake -fake-name ServiceInstanceQuotaValidator . ServiceInstanceQuotaValidator
 
 type NameValidator interface {
 	ValidateCreate(ctx context.Context, logger logr.Logger, namespace, newName string) error
@@ -24,6 +25,10 @@ type NameValidator interface {
 	ValidateDelete(ctx context.Context, logger logr.Logger, namespace, oldName string) error
 }
 
+type ServiceInstanceQuotaValidator interface {
+	ValidateServiceInstanceCreate(ctx context.Context, logger logr.Logger, spaceGUID string) error
+}
+
 const (
 	ServiceInstanceEntityType = "serviceinstance"
 
@@ -40,11 +45,13 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 type CFServiceInstanceValidation struct {
 	decoder            *admission.Decoder
 	duplicateValidator NameValidator
+	quotaValidator     ServiceInstanceQuotaValidator
 }
 
-func NewCFServiceInstanceValidation(duplicateValidator NameValidator) *CFServiceInstanceValidation {
+func NewCFServiceInstanceValidation(duplicateValidator NameValidator, quotaValidator ServiceInstanceQuotaValidator) *CFServiceInstanceValidation {
 	return &CFServiceInstanceValidation{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -78,6 +85,17 @@ func (v *CFServiceInstanceValidation) Handle(ctx context.Context, req admission.
 	var validatorErr error
 	switch req.Operation {
 	case admissionv1.Create:
+		// user provided service instances do not count towards the quotas
+		if cfServiceInstance.Spec.Type == v1alpha1.ManagedType {
+			if err := v.quotaValidator.ValidateServiceInstanceCreate(ctx, cfserviceinstancelog, cfServiceInstance.Namespace); err != nil {
+				var validationError webhooks.ValidationError
+				if errors.As(err, &validationError) {
+					return admission.Denied(validationError.Marshal())
+				}
+				return admission.Denied(webhooks.AdmissionUnknownErrorReason())
+			}
+		}
+
 		validatorErr = v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, cfServiceInstance.Namespace, cfServiceInstance.Spec.Name)
 
 	case admissionv1.Update:
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -28,6 +29,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		serviceInstanceName   string
 		ctx                   context.Context
 		duplicateValidator    *fake.NameValidator
+		quotaValidator        *fake.ServiceInstanceQuotaValidator
 		realDecoder           *admission.Decoder
 		serviceInstance       *servicesv1alpha1.CFServiceInstance
 		request               admission.Request
@@ -63,7 +65,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = services.NewCFServiceInstanceValidation(duplicateValidator)
+		quotaValidator = new(fake.ServiceInstanceQuotaValidator)
+		validatingWebhook = services.NewCFServiceInstanceValidation(duplicateValidator, quotaValidator)
 
 		Expect(validatingWebhook.InjectDecoder(realDecoder)).To(Succeed())
 	})
@@ -98,6 +101,57 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(name).To(Equal(serviceInstanceName))
 		})
 
+		It("does not validate user provided service instances against the quotas", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(0))
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstance.Spec.Type = servicesv1alpha1.ManagedType
+				managedServiceInstanceJSON, err := json.Marshal(serviceInstance)
+				Expect(err).NotTo(HaveOccurred())
+				request.Object = runtime.RawExtension{Raw: managedServiceInstanceJSON}
+			})
+
+			It("validates the service instance against the quotas of its space", func() {
+				Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+				_, _, spaceGUID := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+				Expect(spaceGUID).To(Equal(defaultNamespace))
+			})
+
+			When("the service instances quota is exceeded", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateServiceInstanceCreateReturns(webhooks.ValidationError{
+						Type:    workloads.QuotaExceededErrorType,
+						Message: "You have exceeded your space's services limit.",
+					})
+				})
+
+				It("denies the request", func() {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(string(response.Result.Reason)).To(Equal(webhooks.ValidationError{
+						Type:    workloads.QuotaExceededErrorType,
+						Message: "You have exceeded your space's services limit.",
+					}.Marshal()))
+				})
+
+				It("does not register the service instance name", func() {
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+				})
+			})
+
+			When("validating the quotas fails", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateServiceInstanceCreateReturns(errors.New("boom"))
+				})
+
+				It("denies the request", func() {
+					Expect(response.Allowed).To(BeFalse())
+					Expect(string(response.Result.Reason)).To(Equal(webhooks.AdmissionUnknownErrorReason()))
+				})
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(webhooks.ErrorDuplicateName)
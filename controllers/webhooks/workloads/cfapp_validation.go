@@ -19,6 +19,7 @@ import (
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fake -fake-name NameValidator . NameValidator
+//counterfeiter:generate -o fake -fake-name AppQuotaValidator . AppQuotaValidator
 
 type NameValidator interface {
 	ValidateCreate(ctx context.Context, logger logr.Logger, namespace, newName string) error
@@ -26,6 +27,10 @@ type NameValidator interface {
 	ValidateDelete(ctx context.Context, logger logr.Logger, namespace, oldName string) error
 }
 
+type AppQuotaValidator interface {
+	ValidateAppStart(ctx context.Context, logger logr.Logger, app, oldApp *v1alpha1.CFApp) error
+}
+
 const (
 	AppEntityType         = "app"
 	AppDecodingErrorType  = "AppDecodingError"
@@ -39,11 +44,13 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 type CFAppValidation struct {
 	decoder            *admission.Decoder
 	duplicateValidator NameValidator
+	quotaValidator     AppQuotaValidator
 }
 
-func NewCFAppValidation(duplicateValidator NameValidator) *CFAppValidation {
+func NewCFAppValidation(duplicateValidator NameValidator, quotaValidator AppQuotaValidator) *CFAppValidation {
 	return &CFAppValidation{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -101,6 +108,23 @@ func (v *CFAppValidation) Handle(ctx context.Context, req admission.Request) adm
 		return admission.Denied(webhooks.AdmissionUnknownErrorReason())
 	}
 
+	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
+		var oldApp *v1alpha1.CFApp
+		if req.Operation == admissionv1.Update {
+			oldApp = &oldCFApp
+		}
+
+		err := v.quotaValidator.ValidateAppStart(ctx, cfapplog, &cfApp, oldApp)
+		if err != nil {
+			var validationError webhooks.ValidationError
+			if errors.As(err, &validationError) {
+				return admission.Denied(validationError.Marshal())
+			}
+
+			return admission.Denied(webhooks.AdmissionUnknownErrorReason())
+		}
+	}
+
 	return admission.Allowed("")
 }
 
@@ -28,6 +28,7 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.AppQuotaValidator
 		realDecoder        *admission.Decoder
 		app                *workloadsv1alpha1.CFApp
 		request            admission.Request
@@ -61,7 +62,8 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = workloads.NewCFAppValidation(duplicateValidator)
+		quotaValidator = new(fake.AppQuotaValidator)
+		validatingWebhook = workloads.NewCFAppValidation(duplicateValidator, quotaValidator)
 
 		Expect(validatingWebhook.InjectDecoder(realDecoder)).To(Succeed())
 	})
@@ -144,6 +146,13 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 				Expect(string(response.Result.Reason)).To(Equal(webhooks.AdmissionUnknownErrorReason()))
 			})
 		})
+
+		It("validates the quotas of the app without an old app", func() {
+			Expect(quotaValidator.ValidateAppStartCallCount()).To(Equal(1))
+			_, _, actualApp, actualOldApp := quotaValidator.ValidateAppStartArgsForCall(0)
+			Expect(actualApp.Name).To(Equal(testAppGUID))
+			Expect(actualOldApp).To(BeNil())
+		})
 	})
 
 	Describe("Update", func() {
@@ -210,6 +219,35 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 			})
 		})
 
+		It("validates the quotas of the app against the old app", func() {
+			Expect(quotaValidator.ValidateAppStartCallCount()).To(Equal(1))
+			_, _, actualApp, actualOldApp := quotaValidator.ValidateAppStartArgsForCall(0)
+			Expect(actualApp.Spec.Name).To(Equal(updatedApp.Spec.Name))
+			Expect(actualOldApp.Spec.Name).To(Equal(app.Spec.Name))
+		})
+
+		When("starting the app exceeds a quota", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateAppStartReturns(webhooks.ValidationError{Type: workloads.QuotaExceededErrorType, Message: "memory space_quota_exceeded"})
+			})
+
+			It("denies the request", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(string(response.Result.Reason)).To(Equal(webhooks.ValidationError{Type: workloads.QuotaExceededErrorType, Message: "memory space_quota_exceeded"}.Marshal()))
+			})
+		})
+
+		When("validating the quotas fails", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateAppStartReturns(errors.New("boom"))
+			})
+
+			It("denies the request", func() {
+				Expect(response.Allowed).To(BeFalse())
+				Expect(string(response.Result.Reason)).To(Equal(webhooks.AdmissionUnknownErrorReason()))
+			})
+		})
+
 		When("the update validation fails for another reason", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateUpdateReturns(errors.New("boom!"))
@@ -245,6 +283,10 @@ var _ = Describe("CFAppValidatingWebhook", func() {
 			Expect(response.Allowed).To(BeTrue())
 		})
 
+		It("does not validate the quotas", func() {
+			Expect(quotaValidator.ValidateAppStartCallCount()).To(BeZero())
+		})
+
 		It("invokes the validator correctly", func() {
 			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
 			actualContext, _, namespace, name := duplicateValidator.ValidateDeleteArgsForCall(0)
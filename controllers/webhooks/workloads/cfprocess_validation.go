@@ -0,0 +1,89 @@
+package workloads
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//counterfeiter:generate -o fake -fake-name ProcessQuotaValidator . ProcessQuotaValidator
+
+type ProcessQuotaValidator interface {
+	ValidateProcess(ctx context.Context, logger logr.Logger, process, oldProcess *v1alpha1.CFProcess) error
+}
+
+const (
+	ProcessDecodingErrorType = "ProcessDecodingError"
+)
+
+var cfprocesslog = logf.Log.WithName("cfprocess-validate")
+
+//+kubebuilder:webhook:path=/validate-workloads-cloudfoundry-org-v1alpha1-cfprocess,mutating=false,failurePolicy=fail,sideEffects=None,groups=workloads.cloudfoundry.org,resources=cfprocesses,verbs=create;update,versions=v1alpha1,name=vcfprocess.workloads.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFProcessValidation struct {
+	decoder        *admission.Decoder
+	quotaValidator ProcessQuotaValidator
+}
+
+func NewCFProcessValidation(quotaValidator ProcessQuotaValidator) *CFProcessValidation {
+	return &CFProcessValidation{
+		quotaValidator: quotaValidator,
+	}
+}
+
+func (v *CFProcessValidation) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register("/validate-workloads-cloudfoundry-org-v1alpha1-cfprocess", &webhook.Admission{Handler: v})
+
+	return nil
+}
+
+func (v *CFProcessValidation) Handle(ctx context.Context, req admission.Request) admission.Response {
+	cfprocesslog.Info("Validate", "name", req.Name)
+
+	var cfProcess v1alpha1.CFProcess
+	err := v.decoder.Decode(req, &cfProcess)
+	if err != nil { // untested
+		errMessage := "Error while decoding CFProcess object"
+		cfprocesslog.Error(err, errMessage)
+
+		return admission.Denied(webhooks.ValidationError{Type: ProcessDecodingErrorType, Message: errMessage}.Marshal())
+	}
+
+	var oldCFProcess *v1alpha1.CFProcess
+	if req.Operation == admissionv1.Update {
+		oldCFProcess = new(v1alpha1.CFProcess)
+		err = v.decoder.DecodeRaw(req.OldObject, oldCFProcess)
+		if err != nil { // untested
+			errMessage := "Error while decoding old CFProcess object"
+			cfprocesslog.Error(err, errMessage)
+
+			return admission.Denied(webhooks.ValidationError{Type: ProcessDecodingErrorType, Message: errMessage}.Marshal())
+		}
+	}
+
+	err = v.quotaValidator.ValidateProcess(ctx, cfprocesslog, &cfProcess, oldCFProcess)
+	if err != nil {
+		var validationError webhooks.ValidationError
+		if errors.As(err, &validationError) {
+			return admission.Denied(validationError.Marshal())
+		}
+
+		return admission.Denied(webhooks.AdmissionUnknownErrorReason())
+	}
+
+	return admission.Allowed("")
+}
+
+func (v *CFProcessValidation) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
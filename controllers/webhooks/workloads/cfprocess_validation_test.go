@@ -0,0 +1,132 @@
+package workloads_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx               context.Context
+		quotaValidator    *fake.ProcessQuotaValidator
+		process           *workloadsv1alpha1.CFProcess
+		request           admission.Request
+		validatingWebhook *workloads.CFProcessValidation
+		response          admission.Response
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(workloadsv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		decoder, err := admission.NewDecoder(scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		process = &workloadsv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-process-guid",
+				Namespace: "test-space-guid",
+			},
+			Spec: workloadsv1alpha1.CFProcessSpec{
+				ProcessType:      "web",
+				DesiredInstances: 3,
+				MemoryMB:         512,
+			},
+		}
+
+		processJSON, err := json.Marshal(process)
+		Expect(err).NotTo(HaveOccurred())
+
+		request = admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Name:      process.Name,
+				Namespace: process.Namespace,
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: processJSON},
+			},
+		}
+
+		quotaValidator = new(fake.ProcessQuotaValidator)
+		validatingWebhook = workloads.NewCFProcessValidation(quotaValidator)
+		Expect(validatingWebhook.InjectDecoder(decoder)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		response = validatingWebhook.Handle(ctx, request)
+	})
+
+	It("allows the request", func() {
+		Expect(response.Allowed).To(BeTrue())
+	})
+
+	It("validates the new process against the quotas", func() {
+		Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+		_, _, actualProcess, oldProcess := quotaValidator.ValidateProcessArgsForCall(0)
+		Expect(actualProcess.Name).To(Equal("test-process-guid"))
+		Expect(actualProcess.Spec.DesiredInstances).To(Equal(3))
+		Expect(actualProcess.Spec.MemoryMB).To(BeNumerically("==", 512))
+		Expect(oldProcess).To(BeNil())
+	})
+
+	When("the process is updated", func() {
+		BeforeEach(func() {
+			oldProcess := process.DeepCopy()
+			oldProcess.Spec.DesiredInstances = 1
+			oldProcessJSON, err := json.Marshal(oldProcess)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Operation = admissionv1.Update
+			request.OldObject = runtime.RawExtension{Raw: oldProcessJSON}
+		})
+
+		It("passes the old process to the quota validator", func() {
+			Expect(quotaValidator.ValidateProcessCallCount()).To(Equal(1))
+			_, _, _, oldProcess := quotaValidator.ValidateProcessArgsForCall(0)
+			Expect(oldProcess).NotTo(BeNil())
+			Expect(oldProcess.Spec.DesiredInstances).To(Equal(1))
+		})
+	})
+
+	When("a quota is exceeded", func() {
+		BeforeEach(func() {
+			quotaValidator.ValidateProcessReturns(webhooks.ValidationError{
+				Type:    workloads.QuotaExceededErrorType,
+				Message: "memory quota_exceeded",
+			})
+		})
+
+		It("denies the request with the quota error", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(webhooks.ValidationError{
+				Type:    workloads.QuotaExceededErrorType,
+				Message: "memory quota_exceeded",
+			}.Marshal()))
+		})
+	})
+
+	When("validating the quotas fails", func() {
+		BeforeEach(func() {
+			quotaValidator.ValidateProcessReturns(errors.New("boom"))
+		})
+
+		It("denies the request with an unknown error", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(string(response.Result.Reason)).To(Equal(webhooks.AdmissionUnknownErrorReason()))
+		})
+	})
+})
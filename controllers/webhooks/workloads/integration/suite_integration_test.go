@@ -92,7 +92,7 @@ var _ = BeforeSuite(func() {
 	Expect((&v1alpha1.CFApp{}).SetupWebhookWithManager(mgr)).To(Succeed())
 
 	appNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType))
-	cfAppValidatingWebhook := workloads.NewCFAppValidation(appNameDuplicateValidator)
+	cfAppValidatingWebhook := workloads.NewCFAppValidation(appNameDuplicateValidator, workloads.NewQuotaValidator(mgr.GetClient(), "cf"))
 	Expect(cfAppValidatingWebhook.SetupWebhookWithManager(mgr)).To(Succeed())
 
 	orgNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.OrgEntityType))
@@ -0,0 +1,452 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+//counterfeiter:generate -o fake -fake-name Client sigs.k8s.io/controller-runtime/pkg/client.Client
+
+//+kubebuilder:rbac:groups=workloads.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+const (
+	QuotaExceededErrorType = "QuotaExceededError"
+
+	// Note: the messages match the ones of the CF cloud controller
+	spaceInstanceMemoryExceededMessage = "memory space_instance_memory_limit_exceeded"
+	orgInstanceMemoryExceededMessage   = "memory instance_memory_limit_exceeded"
+	spaceMemoryExceededMessage         = "memory space_quota_exceeded"
+	orgMemoryExceededMessage           = "memory quota_exceeded"
+	spaceAppInstancesExceededMessage   = "app_instance_limit space_app_instance_limit_exceeded"
+	orgAppInstancesExceededMessage     = "app_instance_limit app_instance_limit_exceeded"
+	spaceRoutesExceededMessage         = "Routes quota exceeded for space '%s'."
+	orgRoutesExceededMessage           = "Routes quota exceeded for organization '%s'."
+	spaceServicesExceededMessage       = "You have exceeded your space's services limit."
+	orgServicesExceededMessage         = "You have exceeded your organization's services limit."
+)
+
+// QuotaValidator checks that the process instances, routes and managed service instances of a space fit in the
+// CFSpaceQuota of the space and the CFOrgQuota of its org. Exceeded quotas are reported as a ValidationError of type
+// QuotaExceededErrorType.
+type QuotaValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewQuotaValidator(client client.Client, rootNamespace string) *QuotaValidator {
+	return &QuotaValidator{
+		client:        client,
+		rootNamespace: rootNamespace,
+	}
+}
+
+// spaceQuotas are the quotas applying to a space. Either quota is nil when none is applied.
+type spaceQuotas struct {
+	spaceGUID  string
+	orgGUID    string
+	spaceQuota *v1alpha1.CFSpaceQuota
+	orgQuota   *v1alpha1.CFOrgQuota
+}
+
+// ValidateProcess checks that the process fits in the quotas once it replaces oldProcess, which is nil when the
+// process is created. Updates that do not increase the memory or instances of the process are always allowed, so
+// that processes can be scaled down into their quotas. The processes of apps that are not started do not use the
+// quotas, so they are only checked when their app is started, see ValidateAppStart.
+func (v *QuotaValidator) ValidateProcess(ctx context.Context, logger logr.Logger, process, oldProcess *v1alpha1.CFProcess) error {
+	logger = logger.WithName("quotaValidator.ValidateProcess")
+
+	memoryIncreased := oldProcess == nil || process.Spec.MemoryMB > oldProcess.Spec.MemoryMB
+	instancesIncreased := oldProcess == nil || process.Spec.DesiredInstances > oldProcess.Spec.DesiredInstances
+	if !memoryIncreased && !instancesIncreased {
+		return nil
+	}
+
+	started, err := v.isAppStarted(ctx, process.Namespace, process.Spec.AppRef.Name)
+	if err != nil {
+		logger.Error(err, "failed to get the app of the process", "namespace", process.Namespace, "app", process.Spec.AppRef.Name)
+		return err
+	}
+	if !started {
+		return nil
+	}
+
+	quotas, err := v.quotasFor(ctx, process.Namespace)
+	if err != nil {
+		logger.Error(err, "failed to get quotas", "namespace", process.Namespace)
+		return err
+	}
+
+	if memoryIncreased {
+		if err = quotas.checkInstanceMemory(process.Spec.MemoryMB); err != nil {
+			return err
+		}
+	}
+
+	isValidatedProcess := func(p v1alpha1.CFProcess) bool {
+		return p.Name == process.Name
+	}
+	return v.checkProcessUsage(ctx, logger, quotas, []v1alpha1.CFProcess{*process}, isValidatedProcess)
+}
+
+// ValidateAppStart checks that the processes of the app fit in the quotas when the app is started, i.e. when its
+// desired state changes to started. Processes are not updated when their app starts, so ValidateProcess does not
+// catch that.
+func (v *QuotaValidator) ValidateAppStart(ctx context.Context, logger logr.Logger, app, oldApp *v1alpha1.CFApp) error {
+	logger = logger.WithName("quotaValidator.ValidateAppStart")
+
+	if app.Spec.DesiredState != v1alpha1.StartedState || (oldApp != nil && oldApp.Spec.DesiredState == v1alpha1.StartedState) {
+		return nil
+	}
+
+	var appProcesses v1alpha1.CFProcessList
+	if err := v.client.List(ctx, &appProcesses, client.InNamespace(app.Namespace), client.MatchingLabels{v1alpha1.CFAppGUIDLabelKey: app.Name}); err != nil {
+		logger.Error(err, "failed to list the processes of the app", "namespace", app.Namespace, "app", app.Name)
+		return err
+	}
+	if len(appProcesses.Items) == 0 {
+		return nil
+	}
+
+	quotas, err := v.quotasFor(ctx, app.Namespace)
+	if err != nil {
+		logger.Error(err, "failed to get quotas", "namespace", app.Namespace)
+		return err
+	}
+
+	for _, process := range appProcesses.Items {
+		if err = quotas.checkInstanceMemory(process.Spec.MemoryMB); err != nil {
+			return err
+		}
+	}
+
+	isAppProcess := func(p v1alpha1.CFProcess) bool {
+		return p.Spec.AppRef.Name == app.Name
+	}
+	return v.checkProcessUsage(ctx, logger, quotas, appProcesses.Items, isAppProcess)
+}
+
+// checkProcessUsage checks that the processes fit in the total memory and app instances of the quotas, together with
+// the processes of the started apps of the space and org. The excluded processes are not counted in the usage, as
+// they are the ones being validated.
+func (v *QuotaValidator) checkProcessUsage(ctx context.Context, logger logr.Logger, quotas spaceQuotas, processes []v1alpha1.CFProcess, excluded func(v1alpha1.CFProcess) bool) error {
+	var processesMemoryMB int64
+	var processesInstances int
+	for _, process := range processes {
+		processesMemoryMB += process.Spec.MemoryMB * int64(process.Spec.DesiredInstances)
+		processesInstances += process.Spec.DesiredInstances
+	}
+
+	if quotas.spaceQuota != nil {
+		memoryMB, instances, err := v.processUsage(ctx, []string{quotas.spaceGUID}, excluded)
+		if err != nil {
+			logger.Error(err, "failed to compute the process usage of the space", "namespace", quotas.spaceGUID)
+			return err
+		}
+
+		if exceeds(memoryMB+processesMemoryMB, quotas.spaceQuota.Spec.Limits.TotalMemoryMB) {
+			return quotaExceeded(spaceMemoryExceededMessage)
+		}
+		if exceedsCount(instances+processesInstances, quotas.spaceQuota.Spec.Limits.AppInstances) {
+			return quotaExceeded(spaceAppInstancesExceededMessage)
+		}
+	}
+
+	if quotas.orgQuota != nil {
+		spaceGUIDs, err := v.orgSpaces(ctx, quotas.orgGUID)
+		if err != nil {
+			logger.Error(err, "failed to list the spaces of the org", "org", quotas.orgGUID)
+			return err
+		}
+
+		memoryMB, instances, err := v.processUsage(ctx, spaceGUIDs, excluded)
+		if err != nil {
+			logger.Error(err, "failed to compute the process usage of the org", "org", quotas.orgGUID)
+			return err
+		}
+
+		if exceeds(memoryMB+processesMemoryMB, quotas.orgQuota.Spec.Limits.TotalMemoryMB) {
+			return quotaExceeded(orgMemoryExceededMessage)
+		}
+		if exceedsCount(instances+processesInstances, quotas.orgQuota.Spec.Limits.AppInstances) {
+			return quotaExceeded(orgAppInstancesExceededMessage)
+		}
+	}
+
+	return nil
+}
+
+// ValidateRouteCreate checks that one more route can be created in the space
+func (v *QuotaValidator) ValidateRouteCreate(ctx context.Context, logger logr.Logger, spaceGUID string) error {
+	logger = logger.WithName("quotaValidator.ValidateRouteCreate")
+
+	quotas, err := v.quotasFor(ctx, spaceGUID)
+	if err != nil {
+		logger.Error(err, "failed to get quotas", "namespace", spaceGUID)
+		return err
+	}
+
+	countRoutes := func(namespace string) (int, error) {
+		var routes networkingv1alpha1.CFRouteList
+		if err := v.client.List(ctx, &routes, client.InNamespace(namespace)); err != nil {
+			return 0, err
+		}
+		return len(routes.Items), nil
+	}
+
+	if quotas.spaceQuota != nil && quotas.spaceQuota.Spec.Limits.Routes != nil {
+		count, err := countRoutes(spaceGUID)
+		if err != nil {
+			logger.Error(err, "failed to list the routes of the space", "namespace", spaceGUID)
+			return err
+		}
+
+		if exceedsCount(count+1, quotas.spaceQuota.Spec.Limits.Routes) {
+			return quotaExceeded(fmt.Sprintf(spaceRoutesExceededMessage, v.anchorName(ctx, quotas.orgGUID, spaceGUID, SpaceNameLabel)))
+		}
+	}
+
+	if quotas.orgQuota != nil && quotas.orgQuota.Spec.Limits.Routes != nil {
+		count, err := v.countInOrg(ctx, quotas.orgGUID, countRoutes)
+		if err != nil {
+			logger.Error(err, "failed to list the routes of the org", "org", quotas.orgGUID)
+			return err
+		}
+
+		if exceedsCount(count+1, quotas.orgQuota.Spec.Limits.Routes) {
+			return quotaExceeded(fmt.Sprintf(orgRoutesExceededMessage, v.anchorName(ctx, v.rootNamespace, quotas.orgGUID, OrgNameLabel)))
+		}
+	}
+
+	return nil
+}
+
+// ValidateServiceInstanceCreate checks that one more managed service instance can be created in the space. User
+// provided service instances do not count towards the quotas.
+func (v *QuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, logger logr.Logger, spaceGUID string) error {
+	logger = logger.WithName("quotaValidator.ValidateServiceInstanceCreate")
+
+	quotas, err := v.quotasFor(ctx, spaceGUID)
+	if err != nil {
+		logger.Error(err, "failed to get quotas", "namespace", spaceGUID)
+		return err
+	}
+
+	countServiceInstances := func(namespace string) (int, error) {
+		var serviceInstances servicesv1alpha1.CFServiceInstanceList
+		if err := v.client.List(ctx, &serviceInstances, client.InNamespace(namespace)); err != nil {
+			return 0, err
+		}
+
+		count := 0
+		for _, serviceInstance := range serviceInstances.Items {
+			if serviceInstance.Spec.Type == servicesv1alpha1.ManagedType {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	if quotas.spaceQuota != nil && quotas.spaceQuota.Spec.Limits.ServiceInstances != nil {
+		count, err := countServiceInstances(spaceGUID)
+		if err != nil {
+			logger.Error(err, "failed to list the service instances of the space", "namespace", spaceGUID)
+			return err
+		}
+
+		if exceedsCount(count+1, quotas.spaceQuota.Spec.Limits.ServiceInstances) {
+			return quotaExceeded(spaceServicesExceededMessage)
+		}
+	}
+
+	if quotas.orgQuota != nil && quotas.orgQuota.Spec.Limits.ServiceInstances != nil {
+		count, err := v.countInOrg(ctx, quotas.orgGUID, countServiceInstances)
+		if err != nil {
+			logger.Error(err, "failed to list the service instances of the org", "org", quotas.orgGUID)
+			return err
+		}
+
+		if exceedsCount(count+1, quotas.orgQuota.Spec.Limits.ServiceInstances) {
+			return quotaExceeded(orgServicesExceededMessage)
+		}
+	}
+
+	return nil
+}
+
+// quotasFor returns the quotas applied to the space and to its org, which is the namespace the space namespace was
+// created under. Namespaces that are not CF spaces have no quotas.
+func (v *QuotaValidator) quotasFor(ctx context.Context, spaceGUID string) (spaceQuotas, error) {
+	quotas := spaceQuotas{spaceGUID: spaceGUID}
+
+	var namespace corev1.Namespace
+	if err := v.client.Get(ctx, types.NamespacedName{Name: spaceGUID}, &namespace); err != nil {
+		return spaceQuotas{}, err
+	}
+
+	quotas.orgGUID = namespace.Annotations[hnsv1alpha2.SubnamespaceOf]
+	if quotas.orgGUID == "" || quotas.orgGUID == v.rootNamespace {
+		return spaceQuotas{}, nil
+	}
+
+	var orgQuotas v1alpha1.CFOrgQuotaList
+	if err := v.client.List(ctx, &orgQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return spaceQuotas{}, err
+	}
+	for i := range orgQuotas.Items {
+		if contains(orgQuotas.Items[i].Spec.Orgs, quotas.orgGUID) {
+			quotas.orgQuota = &orgQuotas.Items[i]
+			break
+		}
+	}
+
+	var spaceQuotaList v1alpha1.CFSpaceQuotaList
+	if err := v.client.List(ctx, &spaceQuotaList, client.InNamespace(quotas.orgGUID)); err != nil {
+		return spaceQuotas{}, err
+	}
+	for i := range spaceQuotaList.Items {
+		if contains(spaceQuotaList.Items[i].Spec.Spaces, spaceGUID) {
+			quotas.spaceQuota = &spaceQuotaList.Items[i]
+			break
+		}
+	}
+
+	return quotas, nil
+}
+
+// orgSpaces returns the GUIDs of the spaces of the org, which are the namespaces right below it in the hierarchy
+func (v *QuotaValidator) orgSpaces(ctx context.Context, orgGUID string) ([]string, error) {
+	var namespaces corev1.NamespaceList
+	if err := v.client.List(ctx, &namespaces, client.MatchingLabels{orgGUID + hnsv1alpha2.LabelTreeDepthSuffix: "1"}); err != nil {
+		return nil, err
+	}
+
+	spaceGUIDs := make([]string, 0, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		spaceGUIDs = append(spaceGUIDs, namespace.Name)
+	}
+	return spaceGUIDs, nil
+}
+
+func (v *QuotaValidator) countInOrg(ctx context.Context, orgGUID string, countInSpace func(string) (int, error)) (int, error) {
+	spaceGUIDs, err := v.orgSpaces(ctx, orgGUID)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, spaceGUID := range spaceGUIDs {
+		count, err := countInSpace(spaceGUID)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// processUsage returns the memory and the instances of the processes of the started apps in the namespaces, except
+// the excluded ones
+func (v *QuotaValidator) processUsage(ctx context.Context, namespaces []string, excluded func(v1alpha1.CFProcess) bool) (int64, int, error) {
+	var memoryMB int64
+	var instances int
+
+	for _, namespace := range namespaces {
+		var apps v1alpha1.CFAppList
+		if err := v.client.List(ctx, &apps, client.InNamespace(namespace)); err != nil {
+			return 0, 0, err
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == v1alpha1.StartedState
+		}
+
+		var processes v1alpha1.CFProcessList
+		if err := v.client.List(ctx, &processes, client.InNamespace(namespace)); err != nil {
+			return 0, 0, err
+		}
+
+		for _, process := range processes.Items {
+			if !startedApps[process.Spec.AppRef.Name] || excluded(process) {
+				continue
+			}
+			memoryMB += process.Spec.MemoryMB * int64(process.Spec.DesiredInstances)
+			instances += process.Spec.DesiredInstances
+		}
+	}
+
+	return memoryMB, instances, nil
+}
+
+// isAppStarted returns whether the desired state of the app is started. Missing apps are not started.
+func (v *QuotaValidator) isAppStarted(ctx context.Context, namespace, appGUID string) (bool, error) {
+	var app v1alpha1.CFApp
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: appGUID}, &app); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return app.Spec.DesiredState == v1alpha1.StartedState, nil
+}
+
+// anchorName returns the display name of an org or space from the label of its SubnamespaceAnchor, falling back to
+// its GUID
+func (v *QuotaValidator) anchorName(ctx context.Context, namespace, guid, nameLabel string) string {
+	var anchor hnsv1alpha2.SubnamespaceAnchor
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: guid}, &anchor); err != nil {
+		return guid
+	}
+
+	if name, ok := anchor.Labels[nameLabel]; ok {
+		return name
+	}
+	return guid
+}
+
+// checkInstanceMemory checks that instances with the memory fit in the instance memory limits of the quotas
+func (q spaceQuotas) checkInstanceMemory(memoryMB int64) error {
+	if q.spaceQuota != nil && exceeds(memoryMB, q.spaceQuota.Spec.Limits.InstanceMemoryMB) {
+		return quotaExceeded(spaceInstanceMemoryExceededMessage)
+	}
+	if q.orgQuota != nil && exceeds(memoryMB, q.orgQuota.Spec.Limits.InstanceMemoryMB) {
+		return quotaExceeded(orgInstanceMemoryExceededMessage)
+	}
+
+	return nil
+}
+
+func quotaExceeded(message string) error {
+	return webhooks.ValidationError{Type: QuotaExceededErrorType, Message: message}
+}
+
+func exceeds(value int64, limit *int64) bool {
+	return limit != nil && value > *limit
+}
+
+func exceedsCount(value int, limit *int) bool {
+	return limit != nil && value > *limit
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
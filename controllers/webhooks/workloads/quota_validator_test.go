@@ -0,0 +1,543 @@
+package workloads_test
+
+import (
+	"context"
+	"errors"
+
+	networkingv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/networking/v1alpha1"
+	servicesv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/services/v1alpha1"
+	workloadsv1alpha1 "code.cloudfoundry.org/korifi/controllers/apis/workloads/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/fake"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	hnsv1alpha2 "sigs.k8s.io/hierarchical-namespaces/api/v1alpha2"
+)
+
+var _ = Describe("QuotaValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+		otherSpace    = "other-space-guid"
+	)
+
+	var (
+		ctx        context.Context
+		fakeClient *fake.Client
+		validator  *workloads.QuotaValidator
+
+		orgQuota         *workloadsv1alpha1.CFOrgQuota
+		spaceQuota       *workloadsv1alpha1.CFSpaceQuota
+		processes        map[string][]workloadsv1alpha1.CFProcess
+		stoppedApps      map[string]bool
+		routes           map[string][]networkingv1alpha1.CFRoute
+		serviceInstances map[string][]servicesv1alpha1.CFServiceInstance
+		listErr          error
+
+		validationErr error
+	)
+
+	limit := func(value int) *int { return &value }
+	memoryLimit := func(value int64) *int64 { return &value }
+
+	// processWith returns a process of its own app, whose GUID is the process name with an "-app" suffix
+	processWith := func(name, namespace string, instances int, memoryMB int64) workloadsv1alpha1.CFProcess {
+		appGUID := name + "-app"
+		return workloadsv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    map[string]string{workloadsv1alpha1.CFAppGUIDLabelKey: appGUID},
+			},
+			Spec: workloadsv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: appGUID},
+				DesiredInstances: instances,
+				MemoryMB:         memoryMB,
+			},
+		}
+	}
+
+	appState := func(appGUID string) workloadsv1alpha1.DesiredState {
+		if stoppedApps[appGUID] {
+			return workloadsv1alpha1.StoppedState
+		}
+		return workloadsv1alpha1.StartedState
+	}
+
+	expectQuotaExceeded := func(message string) {
+		ExpectWithOffset(1, validationErr).To(MatchError(webhooks.ValidationError{
+			Type:    workloads.QuotaExceededErrorType,
+			Message: message,
+		}))
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgQuota = nil
+		spaceQuota = nil
+		processes = map[string][]workloadsv1alpha1.CFProcess{}
+		stoppedApps = map[string]bool{}
+		routes = map[string][]networkingv1alpha1.CFRoute{}
+		serviceInstances = map[string][]servicesv1alpha1.CFServiceInstance{}
+		listErr = nil
+
+		fakeClient = new(fake.Client)
+		fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch obj := obj.(type) {
+			case *corev1.Namespace:
+				obj.Name = key.Name
+				obj.Annotations = map[string]string{hnsv1alpha2.SubnamespaceOf: orgGUID}
+				return nil
+			case *hnsv1alpha2.SubnamespaceAnchor:
+				obj.Name = key.Name
+				if key.Namespace == rootNamespace {
+					obj.Labels = map[string]string{workloads.OrgNameLabel: "my-org"}
+				} else {
+					obj.Labels = map[string]string{workloads.SpaceNameLabel: "my-space"}
+				}
+				return nil
+			case *workloadsv1alpha1.CFApp:
+				obj.Name = key.Name
+				obj.Namespace = key.Namespace
+				obj.Spec.DesiredState = appState(key.Name)
+				return nil
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			if listErr != nil {
+				return listErr
+			}
+
+			listOptions := new(client.ListOptions)
+			listOptions.ApplyOptions(opts)
+
+			switch list := list.(type) {
+			case *corev1.NamespaceList:
+				list.Items = []corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: spaceGUID}},
+					{ObjectMeta: metav1.ObjectMeta{Name: otherSpace}},
+				}
+			case *workloadsv1alpha1.CFOrgQuotaList:
+				if orgQuota != nil {
+					list.Items = []workloadsv1alpha1.CFOrgQuota{*orgQuota}
+				}
+			case *workloadsv1alpha1.CFSpaceQuotaList:
+				if spaceQuota != nil {
+					list.Items = []workloadsv1alpha1.CFSpaceQuota{*spaceQuota}
+				}
+			case *workloadsv1alpha1.CFAppList:
+				list.Items = nil
+				for _, process := range processes[listOptions.Namespace] {
+					list.Items = append(list.Items, workloadsv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{Name: process.Spec.AppRef.Name, Namespace: listOptions.Namespace},
+						Spec:       workloadsv1alpha1.CFAppSpec{DesiredState: appState(process.Spec.AppRef.Name)},
+					})
+				}
+			case *workloadsv1alpha1.CFProcessList:
+				list.Items = nil
+				for _, process := range processes[listOptions.Namespace] {
+					if listOptions.LabelSelector == nil || listOptions.LabelSelector.Matches(labels.Set(process.Labels)) {
+						list.Items = append(list.Items, process)
+					}
+				}
+			case *networkingv1alpha1.CFRouteList:
+				list.Items = routes[listOptions.Namespace]
+			case *servicesv1alpha1.CFServiceInstanceList:
+				list.Items = serviceInstances[listOptions.Namespace]
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+			return nil
+		}
+
+		validator = workloads.NewQuotaValidator(fakeClient, rootNamespace)
+	})
+
+	Describe("ValidateProcess", func() {
+		var process, oldProcess *workloadsv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			p := processWith("process-guid", spaceGUID, 2, 512)
+			process = &p
+			oldProcess = nil
+
+			processes[spaceGUID] = []workloadsv1alpha1.CFProcess{processWith("another-process", spaceGUID, 1, 1024)}
+			processes[otherSpace] = []workloadsv1alpha1.CFProcess{processWith("yet-another-process", otherSpace, 2, 1024)}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = validator.ValidateProcess(ctx, logr.Discard(), process, oldProcess)
+		})
+
+		It("allows processes without quotas", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("a space quota is applied to the space", func() {
+			BeforeEach(func() {
+				spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "space-quota-guid", Namespace: orgGUID},
+					Spec:       workloadsv1alpha1.CFSpaceQuotaSpec{Spaces: []string{spaceGUID}},
+				}
+			})
+
+			When("the process fits in the quota", func() {
+				BeforeEach(func() {
+					spaceQuota.Spec.Limits = workloadsv1alpha1.QuotaLimits{
+						TotalMemoryMB:    memoryLimit(2048),
+						InstanceMemoryMB: memoryLimit(512),
+						AppInstances:     limit(3),
+					}
+				})
+
+				It("allows the process", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the instance memory exceeds the quota", func() {
+				BeforeEach(func() {
+					spaceQuota.Spec.Limits.InstanceMemoryMB = memoryLimit(256)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("memory space_instance_memory_limit_exceeded")
+				})
+			})
+
+			When("the total memory of the space exceeds the quota", func() {
+				BeforeEach(func() {
+					spaceQuota.Spec.Limits.TotalMemoryMB = memoryLimit(2047)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("memory space_quota_exceeded")
+				})
+
+				When("the process is scaled down", func() {
+					BeforeEach(func() {
+						old := processWith("process-guid", spaceGUID, 4, 512)
+						oldProcess = &old
+					})
+
+					It("allows the process", func() {
+						Expect(validationErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("the instances of the space exceed the quota", func() {
+				BeforeEach(func() {
+					spaceQuota.Spec.Limits.AppInstances = limit(2)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("app_instance_limit space_app_instance_limit_exceeded")
+				})
+
+				When("the other app of the space is stopped", func() {
+					BeforeEach(func() {
+						stoppedApps["another-process-app"] = true
+					})
+
+					It("does not count its processes", func() {
+						Expect(validationErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the app of the process is stopped", func() {
+					BeforeEach(func() {
+						stoppedApps["process-guid-app"] = true
+					})
+
+					It("allows the process", func() {
+						Expect(validationErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+		})
+
+		When("an org quota is applied to the org", func() {
+			BeforeEach(func() {
+				orgQuota = &workloadsv1alpha1.CFOrgQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "org-quota-guid", Namespace: rootNamespace},
+					Spec:       workloadsv1alpha1.CFOrgQuotaSpec{Orgs: []string{orgGUID}},
+				}
+			})
+
+			When("the processes of all the spaces fit in the quota", func() {
+				BeforeEach(func() {
+					orgQuota.Spec.Limits.TotalMemoryMB = memoryLimit(4096)
+					orgQuota.Spec.Limits.AppInstances = limit(5)
+				})
+
+				It("allows the process", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the instance memory exceeds the quota", func() {
+				BeforeEach(func() {
+					orgQuota.Spec.Limits.InstanceMemoryMB = memoryLimit(128)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("memory instance_memory_limit_exceeded")
+				})
+			})
+
+			When("the total memory of the org exceeds the quota", func() {
+				BeforeEach(func() {
+					orgQuota.Spec.Limits.TotalMemoryMB = memoryLimit(4095)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("memory quota_exceeded")
+				})
+			})
+
+			When("the instances of the org exceed the quota", func() {
+				BeforeEach(func() {
+					orgQuota.Spec.Limits.AppInstances = limit(4)
+				})
+
+				It("denies the process", func() {
+					expectQuotaExceeded("app_instance_limit app_instance_limit_exceeded")
+				})
+			})
+		})
+
+		When("listing the processes fails", func() {
+			BeforeEach(func() {
+				spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+					Spec: workloadsv1alpha1.CFSpaceQuotaSpec{Spaces: []string{spaceGUID}},
+				}
+				listErr = errors.New("boom")
+			})
+
+			It("returns the error", func() {
+				Expect(validationErr).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("ValidateAppStart", func() {
+		var app, oldApp *workloadsv1alpha1.CFApp
+
+		BeforeEach(func() {
+			stoppedApps["process-guid-app"] = true
+			processes[spaceGUID] = []workloadsv1alpha1.CFProcess{
+				processWith("process-guid", spaceGUID, 2, 512),
+				processWith("another-process", spaceGUID, 1, 1024),
+			}
+			processes[otherSpace] = []workloadsv1alpha1.CFProcess{processWith("yet-another-process", otherSpace, 2, 1024)}
+
+			app = &workloadsv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "process-guid-app", Namespace: spaceGUID},
+				Spec:       workloadsv1alpha1.CFAppSpec{DesiredState: workloadsv1alpha1.StartedState},
+			}
+			oldApp = app.DeepCopy()
+			oldApp.Spec.DesiredState = workloadsv1alpha1.StoppedState
+
+			spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "space-quota-guid", Namespace: orgGUID},
+				Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+					Spaces: []string{spaceGUID},
+					Limits: workloadsv1alpha1.QuotaLimits{
+						TotalMemoryMB:    memoryLimit(2048),
+						InstanceMemoryMB: memoryLimit(512),
+						AppInstances:     limit(3),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = validator.ValidateAppStart(ctx, logr.Discard(), app, oldApp)
+		})
+
+		It("allows starting apps whose processes fit in the quotas", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the total memory of the space would exceed the quota", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Limits.TotalMemoryMB = memoryLimit(2047)
+			})
+
+			It("denies starting the app", func() {
+				expectQuotaExceeded("memory space_quota_exceeded")
+			})
+
+			When("the app is already started", func() {
+				BeforeEach(func() {
+					oldApp.Spec.DesiredState = workloadsv1alpha1.StartedState
+				})
+
+				It("allows the update", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the app is stopped", func() {
+				BeforeEach(func() {
+					app.Spec.DesiredState = workloadsv1alpha1.StoppedState
+				})
+
+				It("allows the update", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the instances of the org would exceed the quota", func() {
+			BeforeEach(func() {
+				orgQuota = &workloadsv1alpha1.CFOrgQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "org-quota-guid", Namespace: rootNamespace},
+					Spec: workloadsv1alpha1.CFOrgQuotaSpec{
+						Orgs:   []string{orgGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{AppInstances: limit(4)},
+					},
+				}
+			})
+
+			It("denies starting the app", func() {
+				expectQuotaExceeded("app_instance_limit app_instance_limit_exceeded")
+			})
+		})
+
+		When("the instance memory of a process of the app exceeds the quota", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.Limits.InstanceMemoryMB = memoryLimit(256)
+			})
+
+			It("denies starting the app", func() {
+				expectQuotaExceeded("memory space_instance_memory_limit_exceeded")
+			})
+		})
+
+		When("the app is created started without processes", func() {
+			BeforeEach(func() {
+				oldApp = nil
+				app.Name = "new-app"
+			})
+
+			It("allows the app", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ValidateRouteCreate", func() {
+		BeforeEach(func() {
+			routes[spaceGUID] = []networkingv1alpha1.CFRoute{{}, {}}
+			routes[otherSpace] = []networkingv1alpha1.CFRoute{{}}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = validator.ValidateRouteCreate(ctx, logr.Discard(), spaceGUID)
+		})
+
+		It("allows routes without quotas", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space has reached its route quota", func() {
+			BeforeEach(func() {
+				spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+					Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+						Spaces: []string{spaceGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{Routes: limit(2)},
+					},
+				}
+			})
+
+			It("denies the route", func() {
+				expectQuotaExceeded("Routes quota exceeded for space 'my-space'.")
+			})
+		})
+
+		When("the org has reached its route quota", func() {
+			BeforeEach(func() {
+				orgQuota = &workloadsv1alpha1.CFOrgQuota{
+					Spec: workloadsv1alpha1.CFOrgQuotaSpec{
+						Orgs:   []string{orgGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{Routes: limit(3)},
+					},
+				}
+			})
+
+			It("denies the route", func() {
+				expectQuotaExceeded("Routes quota exceeded for organization 'my-org'.")
+			})
+		})
+	})
+
+	Describe("ValidateServiceInstanceCreate", func() {
+		BeforeEach(func() {
+			serviceInstances[spaceGUID] = []servicesv1alpha1.CFServiceInstance{
+				{Spec: servicesv1alpha1.CFServiceInstanceSpec{Type: servicesv1alpha1.ManagedType}},
+				{Spec: servicesv1alpha1.CFServiceInstanceSpec{Type: servicesv1alpha1.UserProvidedType}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = validator.ValidateServiceInstanceCreate(ctx, logr.Discard(), spaceGUID)
+		})
+
+		When("only user provided service instances would exceed the quota", func() {
+			BeforeEach(func() {
+				spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+					Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+						Spaces: []string{spaceGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{ServiceInstances: limit(2)},
+					},
+				}
+			})
+
+			It("allows the service instance", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the space has reached its service instance quota", func() {
+			BeforeEach(func() {
+				spaceQuota = &workloadsv1alpha1.CFSpaceQuota{
+					Spec: workloadsv1alpha1.CFSpaceQuotaSpec{
+						Spaces: []string{spaceGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{ServiceInstances: limit(1)},
+					},
+				}
+			})
+
+			It("denies the service instance", func() {
+				expectQuotaExceeded("You have exceeded your space's services limit.")
+			})
+		})
+
+		When("the org has reached its service instance quota", func() {
+			BeforeEach(func() {
+				orgQuota = &workloadsv1alpha1.CFOrgQuota{
+					Spec: workloadsv1alpha1.CFOrgQuotaSpec{
+						Orgs:   []string{orgGUID},
+						Limits: workloadsv1alpha1.QuotaLimits{ServiceInstances: limit(1)},
+					},
+				}
+			})
+
+			It("denies the service instance", func() {
+				expectQuotaExceeded("You have exceeded your organization's services limit.")
+			})
+		})
+	})
+})